@@ -0,0 +1,140 @@
+// Package planchanges is the schema of the resource changes of the plans,
+// which the runners publish to a ConfigMap per Terraform object with
+// spec.publishPlanChanges. The schema is versioned: a new version is only
+// introduced for the changes breaking its readers, and the fields added to a
+// version are optional.
+package planchanges
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	// SchemaVersion is the version of the schema of the documents.
+	SchemaVersion = "planchanges/v1"
+
+	// DataKey is the key of the gzipped JSON document in the binary data
+	// of the ConfigMap.
+	DataKey = "changes.json.gz"
+
+	// SchemaVersionAnnotation is the annotation of the ConfigMap with the
+	// version of the schema of its document.
+	SchemaVersionAnnotation = "infra.contrib.fluxcd.io/plan-changes-schema"
+
+	// SensitiveValue replaces the sensitive values of the resources.
+	SensitiveValue = "(sensitive value)"
+)
+
+// ConfigMapName returns the name of the ConfigMap with the changes of the
+// last plan of the Terraform object of the given workspace and name.
+func ConfigMapName(workspace, name string) string {
+	return "tfplan-" + workspace + "-" + name + ".changes"
+}
+
+// Document is the resource changes of a plan.
+type Document struct {
+	// SchemaVersion is the version of the schema of the document.
+	SchemaVersion string `json:"schemaVersion"`
+
+	// PlanID is the ID of the plan, as in status.plan.pending.
+	PlanID string `json:"planId"`
+
+	// Revision is the source revision of the plan.
+	Revision string `json:"revision"`
+
+	// TerraformVersion is the version of Terraform which made the plan.
+	TerraformVersion string `json:"terraformVersion,omitempty"`
+
+	// ValuesOmitted is true when the values of the resources were left out
+	// for the document to fit in the ConfigMap.
+	ValuesOmitted bool `json:"valuesOmitted,omitempty"`
+
+	// ResourceChanges are the changes of the resources, in the order of
+	// their addresses. The resources without changes are left out.
+	ResourceChanges []ResourceChange `json:"resourceChanges"`
+}
+
+// ResourceChange is the change of a resource.
+type ResourceChange struct {
+	// Address is the absolute address of the resource instance.
+	Address string `json:"address"`
+
+	// ModuleAddress is the address of the module of the resource, empty
+	// for the root module.
+	ModuleAddress string `json:"moduleAddress,omitempty"`
+
+	// Mode is managed or data.
+	Mode string `json:"mode"`
+
+	// Type is the type of the resource, e.g. aws_instance.
+	Type string `json:"type"`
+
+	// Name is the name of the resource in its module.
+	Name string `json:"name"`
+
+	// Index is the count or for_each index of the instance, if any.
+	Index interface{} `json:"index,omitempty"`
+
+	// ProviderName is the address of the provider of the resource.
+	ProviderName string `json:"providerName"`
+
+	// Deposed is the key of the deposed object the change is for, if any.
+	Deposed string `json:"deposed,omitempty"`
+
+	// Actions are the actions of the change: ["create"], ["update"],
+	// ["delete"], ["read"], ["delete", "create"] or ["create", "delete"].
+	Actions []string `json:"actions"`
+
+	// Before is the value of the resource before the change, with its
+	// sensitive values replaced with SensitiveValue.
+	Before interface{} `json:"before,omitempty"`
+
+	// After is the value of the resource after the change, without its
+	// values known after the apply, and with its sensitive values replaced
+	// with SensitiveValue.
+	After interface{} `json:"after,omitempty"`
+
+	// AfterUnknown has the same structure as After, with true for the
+	// values known after the apply.
+	AfterUnknown interface{} `json:"afterUnknown,omitempty"`
+}
+
+// Encode returns the gzipped JSON of the document.
+func Encode(document *Document) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(writer).Encode(document); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decode reads a gzipped JSON document, of this version of the schema.
+func Decode(data []byte) (*Document, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	raw, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	document := &Document{}
+	if err := json.Unmarshal(raw, document); err != nil {
+		return nil, err
+	}
+	if document.SchemaVersion != SchemaVersion {
+		return nil, fmt.Errorf("unsupported schema version %q, expected %q", document.SchemaVersion, SchemaVersion)
+	}
+	return document, nil
+}
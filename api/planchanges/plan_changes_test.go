@@ -0,0 +1,43 @@
+package planchanges
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	g := NewWithT(t)
+
+	document := &Document{
+		SchemaVersion: SchemaVersion,
+		PlanID:        "plan-main-abc",
+		Revision:      "main@sha1:abc",
+		ResourceChanges: []ResourceChange{{
+			Address:      "aws_instance.web",
+			Mode:         "managed",
+			Type:         "aws_instance",
+			Name:         "web",
+			ProviderName: "registry.terraform.io/hashicorp/aws",
+			Actions:      []string{"update"},
+			Before:       map[string]interface{}{"password": SensitiveValue},
+		}},
+	}
+
+	data, err := Encode(document)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	decoded, err := Decode(data)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(decoded).To(Equal(document))
+
+	document.SchemaVersion = "planchanges/v2"
+	data, err = Encode(document)
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = Decode(data)
+	g.Expect(err).To(MatchError(ContainSubstring("unsupported schema version")))
+
+	_, err = Decode([]byte("{}"))
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(ConfigMapName("default", "helloworld")).To(Equal("tfplan-default-helloworld.changes"))
+}
@@ -0,0 +1,96 @@
+package v1alpha2
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// BranchPlanOfLabel marks the copies of a Terraform object, and of its
+	// GitRepository, planning a branch of the repository ad hoc, outside of
+	// the pull requests of the branch planner, with the name of the
+	// original. BranchPlanBranchAnnotation is the branch they plan, and
+	// BranchPlanExpiresAtAnnotation the time, in RFC 3339, at which the
+	// controller deletes them.
+	BranchPlanOfLabel             = "infra.contrib.fluxcd.io/branch-plan-of"
+	BranchPlanBranchAnnotation    = "infra.contrib.fluxcd.io/branch-plan-branch"
+	BranchPlanExpiresAtAnnotation = "infra.contrib.fluxcd.io/branch-plan-expires-at"
+)
+
+// BranchPlanName is the name of the copies of a Terraform object and of its
+// GitRepository planning a branch, suffixed with the hash of the branch, as
+// a branch name is not always a valid object name.
+func BranchPlanName(name, branch string) string {
+	sum := sha256.Sum256([]byte(branch))
+	return fmt.Sprintf("%s-branch-%s", name, hex.EncodeToString(sum[:])[:8])
+}
+
+// NewBranchPlan returns the copy of the Terraform object planning a branch
+// until it expires. Its source is the copy of the GitRepository of the
+// object following the branch, of the same name. The copy is only ever
+// planned, against the state of the object, without writing its outputs or
+// calling its webhooks, and it is deleted without touching the resources
+// or the state.
+func (in Terraform) NewBranchPlan(branch string, expiresAt time.Time) *Terraform {
+	name := BranchPlanName(in.GetName(), branch)
+
+	spec := *in.Spec.DeepCopy()
+	spec.SourceRef.Name = name
+	spec.PlanOnly = true
+	spec.StoreReadablePlan = "human"
+	spec.ApprovePlan = ""
+	spec.Force = false
+	spec.Suspend = false
+	spec.SourceRevisionOverride = ""
+	spec.DestroyResourcesOnDeletion = false
+	spec.DeletionPolicy = DeletionPolicyOrphan
+	spec.TTL = nil
+	spec.DestroyAt = nil
+	spec.DeleteOnExpiry = false
+	spec.WriteOutputsToSecret = nil
+	spec.WriteOutputsToSecrets = nil
+	spec.Webhooks = nil
+
+	if spec.BackendConfig == nil {
+		spec.BackendConfig = &BackendConfigSpec{
+			SecretSuffix:    in.GetName(),
+			InClusterConfig: true,
+		}
+	}
+	spec.TFState = &TFStateSpec{
+		ForceUnlock:     ForceUnlockEnumNo,
+		DisablePlanLock: spec.Cloud == nil,
+	}
+
+	return &Terraform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: in.GetNamespace(),
+			Labels:    map[string]string{BranchPlanOfLabel: in.GetName()},
+			Annotations: map[string]string{
+				BranchPlanBranchAnnotation:    branch,
+				BranchPlanExpiresAtAnnotation: expiresAt.UTC().Format(time.RFC3339),
+			},
+		},
+		Spec: spec,
+	}
+}
+
+// GetBranchPlanExpiry returns the time at which the copy of a Terraform
+// object planning a branch expires, or nil if the object is not one, or
+// its expiry is invalid.
+func (in Terraform) GetBranchPlanExpiry() *time.Time {
+	if in.GetLabels()[BranchPlanOfLabel] == "" {
+		return nil
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, in.GetAnnotations()[BranchPlanExpiresAtAnnotation])
+	if err != nil {
+		return nil
+	}
+	return &expiresAt
+}
@@ -0,0 +1,48 @@
+package v1alpha2
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewBranchPlan(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	expiresAt := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	original := Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+		Spec: TerraformSpec{
+			ApprovePlan: ApprovePlanAutoValue,
+			SourceRef:   CrossNamespaceSourceReference{Kind: "GitRepository", Name: "helloworld"},
+			TTL:         &metav1.Duration{Duration: time.Hour},
+			WriteOutputsToSecret: &WriteOutputsToSecretSpec{
+				Name: "helloworld-outputs",
+			},
+		},
+	}
+
+	plan := original.NewBranchPlan("feature/x", expiresAt)
+	g.Expect(plan.Name).To(Equal(BranchPlanName("helloworld", "feature/x")))
+	g.Expect(plan.Name).To(MatchRegexp(`^helloworld-branch-[0-9a-f]{8}$`))
+	g.Expect(plan.Namespace).To(Equal("flux-system"))
+	g.Expect(plan.Spec.SourceRef.Name).To(Equal(plan.Name))
+	g.Expect(plan.Spec.PlanOnly).To(BeTrue())
+	g.Expect(plan.Spec.ApprovePlan).To(BeEmpty())
+	g.Expect(plan.Spec.TTL).To(BeNil())
+	g.Expect(plan.Spec.WriteOutputsToSecret).To(BeNil())
+	g.Expect(plan.Spec.DeletionPolicy).To(Equal(DeletionPolicyOrphan))
+	g.Expect(plan.Spec.BackendConfig.SecretSuffix).To(Equal("helloworld"))
+	g.Expect(plan.Annotations[BranchPlanBranchAnnotation]).To(Equal("feature/x"))
+	g.Expect(*plan.GetBranchPlanExpiry()).To(Equal(expiresAt))
+
+	// another branch has a copy of its own
+	g.Expect(BranchPlanName("helloworld", "feature/y")).ToNot(Equal(plan.Name))
+
+	// the original is not a branch plan
+	g.Expect(original.GetBranchPlanExpiry()).To(BeNil())
+	plan.Annotations[BranchPlanExpiresAtAnnotation] = "tomorrow"
+	g.Expect(plan.GetBranchPlanExpiry()).To(BeNil())
+}
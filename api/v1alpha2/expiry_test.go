@@ -0,0 +1,65 @@
+package v1alpha2
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetExpiry(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	created := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	terraform := Terraform{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)}}
+	g.Expect(terraform.GetExpiry()).To(BeNil())
+	g.Expect(terraform.HasExpired(created.Add(1000 * time.Hour))).To(BeFalse())
+
+	terraform.Spec.TTL = &metav1.Duration{Duration: 72 * time.Hour}
+	g.Expect(*terraform.GetExpiry()).To(Equal(created.Add(72 * time.Hour)))
+	g.Expect(terraform.HasExpired(created.Add(71 * time.Hour))).To(BeFalse())
+	g.Expect(terraform.HasExpired(created.Add(72 * time.Hour))).To(BeTrue())
+
+	// the earliest expiry applies
+	destroyAt := metav1.NewTime(created.Add(24 * time.Hour))
+	terraform.Spec.DestroyAt = &destroyAt
+	g.Expect(*terraform.GetExpiry()).To(Equal(destroyAt.Time))
+
+	terraform.Spec.TTL = &metav1.Duration{Duration: time.Hour}
+	g.Expect(*terraform.GetExpiry()).To(Equal(created.Add(time.Hour)))
+}
+
+func TestShouldDestroyResourcesOnDeletion(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	terraform := Terraform{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(time.Now().Add(-2 * time.Hour))}}
+	g.Expect(terraform.ShouldDestroyResourcesOnDeletion()).To(BeFalse())
+
+	terraform.Spec.TTL = &metav1.Duration{Duration: time.Hour}
+	g.Expect(terraform.ShouldDestroyResourcesOnDeletion()).To(BeFalse())
+
+	terraform.Spec.DeleteOnExpiry = true
+	g.Expect(terraform.ShouldDestroyResourcesOnDeletion()).To(BeTrue())
+
+	terraform.Spec.TTL = &metav1.Duration{Duration: 3 * time.Hour}
+	g.Expect(terraform.ShouldDestroyResourcesOnDeletion()).To(BeFalse())
+
+	terraform.Spec.DestroyResourcesOnDeletion = true
+	g.Expect(terraform.ShouldDestroyResourcesOnDeletion()).To(BeTrue())
+}
+
+func TestGetDeletionPolicy(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	terraform := Terraform{}
+	g.Expect(terraform.GetDeletionPolicy()).To(Equal(DeletionPolicyOrphan))
+
+	terraform.Spec.DestroyResourcesOnDeletion = true
+	g.Expect(terraform.GetDeletionPolicy()).To(Equal(DeletionPolicyDestroy))
+
+	// the deletion policy takes precedence
+	terraform.Spec.DeletionPolicy = DeletionPolicyDeleteState
+	g.Expect(terraform.GetDeletionPolicy()).To(Equal(DeletionPolicyDeleteState))
+	g.Expect(terraform.ShouldDestroyResourcesOnDeletion()).To(BeFalse())
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/fluxcd/pkg/apis/meta"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -78,18 +79,25 @@ type BackendConfigsReference struct {
 // VarsReference contain a reference of a Secret or a ConfigMap to generate
 // variables for Terraform resources based on its data, selectively by varsKey.
 type VarsReference struct {
-	// Kind of the values referent, valid values are ('Secret', 'ConfigMap').
-	// +kubebuilder:validation:Enum=Secret;ConfigMap
+	// Kind of the values referent, valid values are ('Secret', 'ConfigMap', 'Exec').
+	// With Exec, the values are the JSON object the command of exec writes
+	// to its standard output.
+	// +kubebuilder:validation:Enum=Secret;ConfigMap;Exec
 	// +required
 	Kind string `json:"kind"`
 
 	// Name of the values referent. Should reside in the same namespace as the
-	// referring resource.
+	// referring resource. With Exec, it names the command in the errors.
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=253
 	// +required
 	Name string `json:"name"`
 
+	// Exec is the command the runner runs to emit the values, with the Exec
+	// kind.
+	// +optional
+	Exec *ExecVarsSource `json:"exec,omitempty"`
+
 	// VarsKeys is the data key at which a specific value can be found. Defaults to all keys.
 	// +optional
 	VarsKeys []string `json:"varsKeys,omitempty"`
@@ -101,6 +109,28 @@ type VarsReference struct {
 	Optional bool `json:"optional,omitempty"`
 }
 
+// ExecVarsSource is a command the runner runs at plan time to emit
+// variables, e.g. to query an IPAM or a CMDB.
+type ExecVarsSource struct {
+	// Command is the path of the binary in the runner pod and its
+	// arguments, run without a shell in the directory of the Terraform
+	// program. The binary must write a JSON object to its standard output.
+	// A PowerShell script (.ps1) is run with powershell.exe on a Windows
+	// runner.
+	// +kubebuilder:validation:MinItems=1
+	// +required
+	Command []string `json:"command"`
+
+	// Timeout of the command. Defaults to 30s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// MaxOutputSize is the maximum size of the standard output of the
+	// command. Defaults to 1Mi.
+	// +optional
+	MaxOutputSize *resource.Quantity `json:"maxOutputSize,omitempty"`
+}
+
 // HealthCheck contains configuration needed to perform a health check after
 // terraform is applied.
 type HealthCheck struct {
@@ -206,6 +236,77 @@ type RunnerPodSpec struct {
 	// Set host aliases for the Runner Pod
 	// +optional
 	HostAliases []corev1.HostAlias `json:"hostAliases,omitempty"`
+
+	// Set the proxy environment variables of the Runner Pod.
+	// Takes precedence over the proxy settings of the controller, but not over Env.
+	// +optional
+	Proxy *ProxySpec `json:"proxy,omitempty"`
+
+	// Set the compute resources of the Runner container. Increase the memory
+	// limit when the Runner Pod gets OOMKilled.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Set the DNS policy for the Runner Pod
+	// +optional
+	DNSPolicy corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// Set the DNS parameters for the Runner Pod
+	// +optional
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+
+	// TrustedCABundle is a reference to a ConfigMap or a Secret key holding
+	// PEM encoded CA certificates, to be trusted by the Runner Pod in addition
+	// to the system CA certificates.
+	// +optional
+	TrustedCABundle *CABundleReference `json:"trustedCABundle,omitempty"`
+
+	// RuntimeClassName is the RuntimeClass of the Runner Pod, e.g. of gVisor
+	// or Kata Containers, to run the modules in a sandbox. Defaults to the
+	// runtime class of the controller.
+	// +optional
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+
+	// OS is the operating system of the Runner Pod, linux or windows. A
+	// windows Runner Pod is scheduled on the Windows nodes and runs the
+	// Windows runner image, for the providers shelling out to Windows-only
+	// tooling. Defaults to linux.
+	// +kubebuilder:validation:Enum=linux;windows
+	// +optional
+	OS corev1.OSName `json:"os,omitempty"`
+}
+
+// ProxySpec defines the proxy environment variables of the runner.
+type ProxySpec struct {
+	// HTTPProxy is the value of the HTTP_PROXY environment variable.
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// HTTPSProxy is the value of the HTTPS_PROXY environment variable.
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// NoProxy is the value of the NO_PROXY environment variable.
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
+}
+
+// CABundleReference is a reference to a key of a ConfigMap or a Secret
+// holding PEM encoded CA certificates.
+type CABundleReference struct {
+	// Kind of the referent, valid values are ('Secret', 'ConfigMap').
+	// +kubebuilder:validation:Enum=Secret;ConfigMap
+	// +required
+	Kind string `json:"kind"`
+
+	// Name of the referent, in the same namespace as the Terraform object.
+	// +required
+	Name string `json:"name"`
+
+	// Key of the CA bundle in the referent. Defaults to 'ca.crt'.
+	// +kubebuilder:default:=ca.crt
+	// +optional
+	Key string `json:"key,omitempty"`
 }
 
 func (in HealthCheck) GetTimeout() time.Duration {
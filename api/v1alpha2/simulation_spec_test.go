@@ -0,0 +1,51 @@
+package v1alpha2
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestSimulationSpec(t *testing.T) {
+	g := NewGomegaWithT(t)
+	simulation := SimulationSpec{
+		Providers: []SimulatedProvider{
+			{
+				Name: "aws",
+				Endpoints: map[string]string{
+					"sts": "http://localstack:4566",
+					"s3":  "http://localstack:4566",
+				},
+				Config: "skip_credentials_validation = true\n  s3_use_path_style = true\n",
+			},
+			{
+				Name:   "aws",
+				Alias:  "us_east_1",
+				Config: "region = \"us-east-1\"",
+			},
+		},
+	}
+
+	fixture := strings.TrimLeft(`
+provider "aws" {
+  endpoints {
+    s3 = "http://localstack:4566"
+    sts = "http://localstack:4566"
+  }
+  skip_credentials_validation = true
+  s3_use_path_style = true
+}
+
+provider "aws" {
+  alias = "us_east_1"
+  region = "us-east-1"
+}
+`, "\n")
+	g.Expect(simulation.ToHCL()).To(Equal(fixture))
+
+	terraform := Terraform{Spec: TerraformSpec{Simulation: &simulation}}
+	g.Expect(terraform.IsSimulated()).To(BeFalse())
+	terraform.Spec.PlanOnly = true
+	g.Expect(terraform.IsSimulated()).To(BeTrue())
+}
@@ -0,0 +1,67 @@
+package v1alpha2
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	. "github.com/onsi/gomega"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTerraformStages(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	terraform := Terraform{}
+	g.Expect(terraform.GetStage(StageInit)).To(BeNil())
+
+	// a stage which never started is not finished
+	terraform = TerraformStageFinished(terraform, StageInit, nil)
+	g.Expect(terraform.Status.Stages).To(BeEmpty())
+
+	terraform = TerraformStageStarted(terraform, StageInit, "main@sha1:abc")
+	init := terraform.GetStage(StageInit)
+	g.Expect(init.Status).To(Equal(metav1.ConditionUnknown))
+	g.Expect(init.Reason).To(Equal(meta.ProgressingReason))
+	g.Expect(init.Revision).To(Equal("main@sha1:abc"))
+	g.Expect(init.FinishedAt).To(BeNil())
+
+	terraform = TerraformStageFinished(terraform, StageInit, nil)
+	init = terraform.GetStage(StageInit)
+	g.Expect(init.Status).To(Equal(metav1.ConditionTrue))
+	g.Expect(init.Reason).To(Equal(StageSucceededReason))
+	g.Expect(init.FinishedAt).ToNot(BeNil())
+	g.Expect(init.Duration).ToNot(BeNil())
+
+	// a finished stage is left as is
+	terraform = TerraformStageFinished(terraform, StageInit, errors.New("late"))
+	g.Expect(terraform.GetStage(StageInit).Status).To(Equal(metav1.ConditionTrue))
+
+	// a failed stage takes the reason of the Ready condition
+	terraform = TerraformStageStarted(terraform, StagePlan, "main@sha1:abc")
+	apimeta.SetStatusCondition(&terraform.Status.Conditions, metav1.Condition{
+		Type: meta.ReadyCondition, Status: metav1.ConditionFalse, Reason: TFExecPlanFailedReason,
+	})
+	terraform = TerraformStageFinished(terraform, StagePlan, errors.New("error running Plan"))
+	plan := terraform.GetStage(StagePlan)
+	g.Expect(plan.Status).To(Equal(metav1.ConditionFalse))
+	g.Expect(plan.Reason).To(Equal(TFExecPlanFailedReason))
+	g.Expect(plan.Message).To(Equal("error running Plan"))
+
+	// restarting a stage resets its condition, in place
+	terraform = TerraformStageStarted(terraform, StagePlan, "main@sha1:def")
+	g.Expect(terraform.Status.Stages).To(HaveLen(2))
+	plan = terraform.GetStage(StagePlan)
+	g.Expect(plan.Status).To(Equal(metav1.ConditionUnknown))
+	g.Expect(plan.Message).To(BeEmpty())
+	g.Expect(plan.Revision).To(Equal("main@sha1:def"))
+
+	// only the running stages are interrupted
+	terraform = TerraformStagesInterrupted(terraform, "the controller restarted")
+	g.Expect(terraform.GetStage(StageInit).Status).To(Equal(metav1.ConditionTrue))
+	plan = terraform.GetStage(StagePlan)
+	g.Expect(plan.Status).To(Equal(metav1.ConditionFalse))
+	g.Expect(plan.Reason).To(Equal(ReconciliationInterruptedReason))
+	g.Expect(plan.Message).To(Equal("the controller restarted"))
+}
@@ -0,0 +1,90 @@
+package v1alpha2
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetSummary(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	terraform := Terraform{}
+	g.Expect(terraform.GetSummary()).To(Equal(""))
+
+	terraform = TerraformProgressing(terraform, "Initializing")
+	g.Expect(terraform.GetSummary()).To(Equal("reconciling"))
+
+	terraform = TerraformPlannedWithChanges(terraform, "main@sha1:abc1234ef567", false, "Plan generated")
+	terraform.Status.Plan.Changes = &PlanChanges{Add: 3, Change: 1}
+	g.Expect(terraform.GetSummary()).To(Equal("plan pending approval: +3 ~1 -0 @ rev abc1234"))
+
+	terraform.Spec.ApprovePlan = ApprovePlanAutoValue
+	g.Expect(terraform.GetSummary()).To(Equal("plan pending apply: +3 ~1 -0 @ rev abc1234"))
+
+	terraform = TerraformApplied(terraform, "main@sha1:abc1234ef567", "Applied successfully", false, nil)
+	g.Expect(terraform.GetSummary()).To(Equal("applied @ rev abc1234"))
+
+	terraform = TerraformNotReady(terraform, "main/def5678", TFExecPlanFailedReason, "error running Plan")
+	g.Expect(terraform.GetSummary()).To(Equal("failed: TFExecPlanFailed @ rev def5678"))
+
+	terraform = TerraformPlannedNoChanges(terraform, "main/def5678", "Plan no changes")
+	g.Expect(terraform.GetSummary()).To(Equal("up to date @ rev def5678"))
+
+	terraform = TerraformWaiting(terraform, WaitingForRunnerCapacity, "The namespace quota allows 2 concurrent runners", 3)
+	terraform.Status.Waiting.Since = metav1.NewTime(time.Date(2023, 7, 8, 10, 0, 0, 0, time.UTC))
+	g.Expect(terraform.GetSummary()).To(Equal("waiting for a runner of the namespace quota since 2023-07-08T10:00:00Z"))
+
+	terraform = TerraformNotWaiting(terraform, WaitingForStateLock)
+	g.Expect(terraform.Status.Waiting).ToNot(BeNil())
+	terraform = TerraformNotWaiting(terraform)
+	g.Expect(terraform.GetSummary()).To(Equal("up to date @ rev def5678"))
+}
+
+func TestTerraformWaiting(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	since := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	terraform := Terraform{Status: TerraformStatus{Waiting: &WaitingStatus{Reason: WaitingForStateLock, Since: since}}}
+
+	// the wait goes on for the same reason
+	terraform = TerraformStateLocked(terraform, "lock-id", "Terraform Locked with Lock Identifier: lock-id")
+	g.Expect(terraform.Status.Waiting.Since).To(Equal(since))
+	g.Expect(terraform.Status.Waiting.Message).To(Equal("Terraform Locked with Lock Identifier: lock-id"))
+
+	terraform = TerraformWaiting(terraform, WaitingForApplyQuota, "", 0)
+	g.Expect(terraform.Status.Waiting.Reason).To(Equal(WaitingForApplyQuota))
+	g.Expect(terraform.Status.Waiting.Since.After(since.Time)).To(BeTrue())
+
+	terraform = TerraformWaiting(terraform, WaitingForStateLock, "", 0)
+	terraform = TerraformForceUnlock(terraform, "Unlocked")
+	g.Expect(terraform.Status.Waiting).To(BeNil())
+}
+
+func TestIsPlanPendingApproval(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	terraform := Terraform{}
+	g.Expect(terraform.IsPlanPendingApproval()).To(BeFalse())
+
+	terraform = TerraformPlannedWithChanges(terraform, "main@sha1:abc1234ef567", false, "Plan generated")
+	g.Expect(terraform.IsPlanPendingApproval()).To(BeTrue())
+	g.Expect(terraform.Status.Plan.PendingSince).ToNot(BeNil())
+
+	// the same plan keeps the time it was first planned
+	since := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	terraform.Status.Plan.PendingSince = &since
+	terraform = TerraformPlannedWithChanges(terraform, "main@sha1:abc1234ef567", false, "Plan generated")
+	g.Expect(terraform.Status.Plan.PendingSince).To(Equal(&since))
+	terraform = TerraformPlannedWithChanges(terraform, "main@sha1:def5678ab901", false, "Plan generated")
+	g.Expect(terraform.Status.Plan.PendingSince.Time).To(BeTemporally(">", since.Time))
+
+	terraform.Spec.ApprovePlan = "plan-main-def5678"
+	g.Expect(terraform.IsPlanPendingApproval()).To(BeFalse())
+	terraform.Spec.ApprovePlan = "plan-main-abc1234"
+	g.Expect(terraform.IsPlanPendingApproval()).To(BeTrue())
+	terraform.Spec.PlanOnly = true
+	g.Expect(terraform.IsPlanPendingApproval()).To(BeFalse())
+}
@@ -19,6 +19,7 @@ package v1alpha2
 import (
 	"bytes"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 	"unicode/utf8"
@@ -29,6 +30,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
@@ -43,7 +45,27 @@ const (
 	GitRepositoryIndexKey   = ".metadata.gitRepository"
 	BucketIndexKey          = ".metadata.bucket"
 	OCIRepositoryIndexKey   = ".metadata.ociRepository"
+	SecretIndexKey          = ".metadata.secret"
+	ConfigMapIndexKey       = ".metadata.configMap"
+	ManagedResourceIndexKey = ".status.managedResources"
 	BreakTheGlassAnnotation = "break-the-glass.tf-controller/requestedAt"
+
+	// OutputsHashAnnotation is the hash of the data of the outputs secret,
+	// which is rewritten only when the outputs change.
+	OutputsHashAnnotation = "infra.contrib.fluxcd.io/outputs-hash"
+
+	// OutputsChecksumsAnnotation is the checksum of each key of the outputs
+	// secret, as a JSON object, with spec.writeOutputsToSecret.checksums.
+	OutputsChecksumsAnnotation = "infra.contrib.fluxcd.io/outputs-checksums"
+
+	// DryRunAnnotation set to "true" on a change of the spec asks for a
+	// plan of the new spec before the change is accepted, with the dry run
+	// webhook. The plan runs in a copy of the object, labeled with
+	// DryRunOfLabel, and DryRunSpecAnnotation is the hash of the spec it
+	// plans.
+	DryRunAnnotation     = "infra.contrib.fluxcd.io/dry-run"
+	DryRunSpecAnnotation = "infra.contrib.fluxcd.io/dry-run-spec"
+	DryRunOfLabel        = "infra.contrib.fluxcd.io/dry-run-of"
 )
 
 type ReadInputsFromSecretSpec struct {
@@ -72,6 +94,117 @@ type WriteOutputsToSecretSpec struct {
 	// to the secret. Empty array means writing all outputs, which is default.
 	// +optional
 	Outputs []string `json:"outputs,omitempty"`
+
+	// Format of the outputs in the secret. With `flat`, the default, each
+	// output is written to a key of its own, as a string for string outputs
+	// and as JSON, along with its type in the `<key>__type` key, otherwise.
+	// With `json`, the outputs are written as a single JSON document, which
+	// preserves the types of their values, to the `key` key.
+	// +kubebuilder:validation:Enum=flat;json
+	// +optional
+	Format OutputsFormat `json:"format,omitempty"`
+
+	// Key of the secret the JSON document is written to, when the format
+	// is `json`. Defaults to `outputs.json`.
+	// +optional
+	Key string `json:"key,omitempty"`
+
+	// Extract writes values selected in the outputs with JSONPath
+	// expressions to keys of their own, whatever the format.
+	// +optional
+	Extract []OutputExtraction `json:"extract,omitempty"`
+
+	// Templates write the text of Go templates over the outputs to keys of
+	// their own, whatever the format, e.g. to assemble a connection string.
+	// +optional
+	Templates []OutputTemplate `json:"templates,omitempty"`
+
+	// Consumers are the workloads reading the Secret. The changes are not
+	// applied while one of them is rolling out, for the outputs not to
+	// change under the pods of a half-finished rollout.
+	// +optional
+	Consumers *OutputsConsumers `json:"consumers,omitempty"`
+
+	// Checksums stamps the secret with the checksum of each of its keys, in
+	// the infra.contrib.fluxcd.io/outputs-checksums annotation, and records
+	// an OutputsChanged event listing the keys which changed each time the
+	// secret is rewritten, for the automation to react to the outputs it
+	// uses only.
+	// +optional
+	Checksums bool `json:"checksums,omitempty"`
+}
+
+// OutputsConsumers select the workloads of the namespace of the Terraform
+// object which read its outputs Secret.
+type OutputsConsumers struct {
+	// Selector selects the Deployments, StatefulSets and DaemonSets reading
+	// the outputs Secret by their labels.
+	// +required
+	Selector metav1.LabelSelector `json:"selector"`
+}
+
+// OutputsFormat is the format of the outputs in the secret.
+type OutputsFormat string
+
+const (
+	OutputsFormatFlat OutputsFormat = "flat"
+	OutputsFormatJSON OutputsFormat = "json"
+
+	DefaultOutputsJSONKey = "outputs.json"
+)
+
+// OutputExtraction selects a value of an output to be written to a key of
+// the outputs secret.
+type OutputExtraction struct {
+	// Name is the key of the secret the value is written to.
+	// +required
+	Name string `json:"name"`
+
+	// Output is the name of the output to extract the value from.
+	// +required
+	Output string `json:"output"`
+
+	// JSONPath selects the value in the output, for example
+	// `{.endpoint}` or `{.subnets[0].id}`. A string value is written as
+	// is, any other value as JSON. Defaults to the whole output.
+	// +optional
+	JSONPath string `json:"jsonPath,omitempty"`
+
+	// Decode decodes the selected value, which must be a string, before it
+	// is written. Only `base64` is supported.
+	// +kubebuilder:validation:Enum=base64
+	// +optional
+	Decode OutputDecoding `json:"decode,omitempty"`
+}
+
+// OutputDecoding is the encoding of an output value to decode.
+type OutputDecoding string
+
+const (
+	OutputDecodingBase64 OutputDecoding = "base64"
+)
+
+// OutputTemplate is a Go template over the outputs, written to a key of the
+// outputs secret.
+type OutputTemplate struct {
+	// Name is the key of the secret the text is written to.
+	// +required
+	Name string `json:"name"`
+
+	// Template is a Go template, with the values of the outputs by name and
+	// the Sprig functions, for example
+	// `postgres://{{ .db_user }}:{{ .db_password | urlquery }}@{{ .db_host }}:{{ .db_port }}/app`.
+	// +required
+	Template string `json:"template"`
+}
+
+// GetKey returns the key of the secret the outputs are written to, when
+// the format is `json`.
+func (in WriteOutputsToSecretSpec) GetKey() string {
+	if in.Key != "" {
+		return in.Key
+	}
+	return DefaultOutputsJSONKey
 }
 
 type Variable struct {
@@ -94,6 +227,13 @@ type TerraformSpec struct {
 	// +optional
 	ApprovePlan string `json:"approvePlan,omitempty"`
 
+	// ApprovalEscalation reminds of the plans pending approval, escalates
+	// them, and discards them when they are not approved in time. Unset, the
+	// one of the first TerraformNamespacePolicy of the namespace, by name,
+	// setting one applies.
+	// +optional
+	ApprovalEscalation *ApprovalEscalation `json:"approvalEscalation,omitempty"`
+
 	// Destroy produces a destroy plan. Applying the plan will destroy all resources.
 	// +optional
 	Destroy bool `json:"destroy,omitempty"`
@@ -140,6 +280,19 @@ type TerraformSpec struct {
 	// +optional
 	RetryInterval *metav1.Duration `json:"retryInterval,omitempty"`
 
+	// OnError retries the failed reconciliations after a time of their own,
+	// by class of error, rather than after retryInterval, e.g. soon after a
+	// state lock, and late after a quota was exceeded. The classes which
+	// are not set are retried after retryInterval.
+	// +optional
+	OnError *OnErrorSpec `json:"onError,omitempty"`
+
+	// Priority orders the reconciliations when the controller starts: the
+	// objects with a higher priority are reconciled first, e.g. production
+	// before sandboxes. It can be negative. Defaults to 0.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
 	// Path to the directory containing Terraform (.tf) files.
 	// Defaults to 'None', which translates to the root path of the SourceRef.
 	// +optional
@@ -149,6 +302,20 @@ type TerraformSpec struct {
 	// +required
 	SourceRef CrossNamespaceSourceReference `json:"sourceRef"`
 
+	// SourceRevisionOverride pins the plans and the applies to a revision of
+	// the GitRepository source, regardless of the revision it currently
+	// advertises, e.g. to roll back or to reproduce a run. It is a full
+	// commit SHA, a Git reference starting with refs/, or a tag.
+	// +optional
+	SourceRevisionOverride string `json:"sourceRevisionOverride,omitempty"`
+
+	// SourceLayers are overlaid, in order, on the artifact of the source
+	// before the plan, e.g. the tfvars and the backend of an environment on
+	// a base module. The files of a layer replace the files at the same
+	// path of the source and of the previous layers.
+	// +optional
+	SourceLayers []SourceLayer `json:"sourceLayers,omitempty"`
+
 	// Suspend is to tell the controller to suspend subsequent TF executions,
 	// it does not apply to already started executions. Defaults to false.
 	// +optional
@@ -167,28 +334,132 @@ type TerraformSpec struct {
 	// +optional
 	WriteOutputsToSecret *WriteOutputsToSecretSpec `json:"writeOutputsToSecret,omitempty"`
 
+	// WriteOutputsToSecrets are more Secrets the outputs are written to,
+	// alongside writeOutputsToSecret, each with its own selection of the
+	// outputs, format and transformations.
+	// +optional
+	WriteOutputsToSecrets []WriteOutputsToSecretSpec `json:"writeOutputsToSecrets,omitempty"`
+
 	// Disable automatic drift detection. Drift detection may be resource intensive in
 	// the context of a large cluster or complex Terraform statefile. Defaults to false.
 	// +kubebuilder:default:=false
 	// +optional
 	DisableDriftDetection bool `json:"disableDriftDetection,omitempty"`
 
+	// DriftRemediation opens a pull request documenting the drift of the
+	// object in its Git repository, rather than applying the configuration
+	// again, when set.
+	// +optional
+	DriftRemediation *DriftRemediation `json:"driftRemediation,omitempty"`
+
+	// RevisionDiff records the commits and the files changed between the
+	// last applied revision and each new revision of the GitRepository
+	// source, in the status and in an event, for the approvers of a plan to
+	// see the commits it includes, when set.
+	// +optional
+	RevisionDiff *RevisionDiffSpec `json:"revisionDiff,omitempty"`
+
+	// EventVerbosity is the verbosity of the events of this object:
+	// errors-only records the errors alone, normal the errors and the
+	// results of the reconciliations, and debug the trace events too, such
+	// as the drift checks without drift, without aggregating the repeated
+	// events. Defaults to the verbosity of the controller.
+	// +optional
+	EventVerbosity EventVerbosity `json:"eventVerbosity,omitempty"`
+
+	// ProviderUpgrade upgrades the providers within their version
+	// constraints on a schedule, and pins them to the versions of the last
+	// upgrade in between, when set. Otherwise, the providers are upgraded on
+	// each reconciliation.
+	// +optional
+	ProviderUpgrade *ProviderUpgrade `json:"providerUpgrade,omitempty"`
+
+	// Quarantine stops retrying the object at its retry interval once its
+	// reconciliation failed a number of times in a row, and retries it with a
+	// long backoff instead, when set. A new source revision, a change of the
+	// spec or a reconcile request releases the object from quarantine.
+	// +optional
+	Quarantine *QuarantineSpec `json:"quarantine,omitempty"`
+
 	// +optional
 	// PushSpec *PushSpec `json:"pushSpec,omitempty"`
 
 	// +optional
 	CliConfigSecretRef *corev1.SecretReference `json:"cliConfigSecretRef,omitempty"`
 
+	// GitCredentials gives terraform init the credentials to fetch the
+	// modules sourced from private Git repositories, over SSH or HTTPS.
+	// +optional
+	GitCredentials *GitCredentials `json:"gitCredentials,omitempty"`
+
+	// RegistryCredentials gives terraform the API tokens of private module
+	// registries, such as the HCP Terraform private registry, to fetch the
+	// modules sourced from them.
+	// +optional
+	RegistryCredentials []RegistryCredentials `json:"registryCredentials,omitempty"`
+
+	// RegistryModule makes a module of a module registry the root module,
+	// in place of the configuration at spec.path. The files at spec.path,
+	// for example variable files, are kept along with the module.
+	// +optional
+	RegistryModule *RegistryModule `json:"registryModule,omitempty"`
+
+	// EgressAudit records the cloud API calls the providers make during the
+	// plan and the apply, to scope the IAM policies of the object. Set it to
+	// {} to write them to the <name>-egress-audit ConfigMap.
+	// +optional
+	EgressAudit *EgressAudit `json:"egressAudit,omitempty"`
+
 	// List of health checks to be performed.
 	// +optional
 	HealthChecks []HealthCheck `json:"healthChecks,omitempty"`
 
+	// CanaryApply applies the changes of the plans in two stages: the
+	// resources of the canary targets first, then, once the health checks
+	// of the canary pass, the remainder. A failed canary halts the apply.
+	// +optional
+	CanaryApply *CanaryApplySpec `json:"canaryApply,omitempty"`
+
+	// ReadyWhen is a CEL expression which must be true, once the object is
+	// applied, for the object to be ready, e.g.
+	// `outputs.instance_count >= 3 && conditions['Apply'].status == 'True'`.
+	// It is evaluated over `outputs`, the values of the outputs by name, and
+	// `conditions`, the type, status, reason and message of the conditions of
+	// the object by type. The objects depending on this one wait for it.
+	// +optional
+	ReadyWhen string `json:"readyWhen,omitempty"`
+
 	// Create destroy plan and apply it to destroy terraform resources
 	// upon deletion of this object. Defaults to false.
 	// +kubebuilder:default:=false
 	// +optional
 	DestroyResourcesOnDeletion bool `json:"destroyResourcesOnDeletion,omitempty"`
 
+	// DeletionPolicy determines what happens to the Terraform resources and
+	// to the state when this object is deleted: orphan keeps both, destroy
+	// destroys the resources, and delete-state keeps the resources but
+	// deletes the state, which is only supported with the in-cluster
+	// Kubernetes backend. It takes precedence over destroyResourcesOnDeletion.
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// TTL is the time to live of the Terraform resources, counted from the
+	// creation of this object. Once expired, the controller plans and applies
+	// a destroy, without waiting for an approval. Useful for sandbox and
+	// training environments.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
+	// DestroyAt is the time at which the Terraform resources expire, as with
+	// TTL. When both are set, the earliest expiry applies.
+	// +optional
+	DestroyAt *metav1.Time `json:"destroyAt,omitempty"`
+
+	// DeleteOnExpiry deletes this object when its resources expire, after
+	// destroying them, instead of keeping it with its resources destroyed.
+	// +optional
+	DeleteOnExpiry bool `json:"deleteOnExpiry,omitempty"`
+
 	// Name of a ServiceAccount for the runner Pod to provision Terraform resources.
 	// Default to tf-runner.
 	// +kubebuilder:default:=tf-runner
@@ -212,6 +483,27 @@ type TerraformSpec struct {
 	// +optional
 	RefreshBeforeApply bool `json:"refreshBeforeApply,omitempty"`
 
+	// Refresh refreshes the state on its own schedule, and lets the plans
+	// skip the refresh, to call the APIs of the providers less often.
+	// +optional
+	Refresh *RefreshSpec `json:"refresh,omitempty"`
+
+	// AutoReplanStalePlans discards a plan found stale before the apply step,
+	// because the state in the backend changed since the plan was created,
+	// and creates a new plan. When false, the stale plan is kept pending and
+	// is not applied.
+	// +kubebuilder:default:=false
+	// +optional
+	AutoReplanStalePlans bool `json:"autoReplanStalePlans,omitempty"`
+
+	// RequireMovedBlocks halts the apply of the plans which destroy a
+	// resource and create an identical one at another address, as when a
+	// resource is renamed, or moved into a module, without a moved block.
+	// The plan is kept pending until moved blocks are added. Such plans set
+	// the MovedBlocks condition to False either way.
+	// +optional
+	RequireMovedBlocks bool `json:"requireMovedBlocks,omitempty"`
+
 	// +optional
 	RunnerPodTemplate RunnerPodTemplate `json:"runnerPodTemplate,omitempty"`
 
@@ -237,6 +529,22 @@ type TerraformSpec struct {
 	// +optional
 	StoreReadablePlan string `json:"storeReadablePlan,omitempty"`
 
+	// ReadablePlanDiffs lists the renderers of field-level diffs appended to
+	// the human readable plan, for the resources whose HCL diff is hard to
+	// review. kubernetes_manifest renders the changes of the manifests of the
+	// kubernetes_manifest resources, and kubernetes_manifest_dry_run renders
+	// them against the live objects, with a server-side dry-run on the
+	// cluster of the runner.
+	// +optional
+	ReadablePlanDiffs []ReadablePlanDiff `json:"readablePlanDiffs,omitempty"`
+
+	// PublishPlanChanges publishes the resource changes of each plan to the
+	// ConfigMap tfplan-<workspace>-<name>.changes, as a gzipped JSON
+	// document with a versioned schema, for the tools to read the planned
+	// changes. The sensitive values of the resources are masked.
+	// +optional
+	PublishPlanChanges bool `json:"publishPlanChanges,omitempty"`
+
 	// +optional
 	Webhooks []Webhook `json:"webhooks,omitempty"`
 
@@ -252,10 +560,30 @@ type TerraformSpec struct {
 	// +optional
 	PlanOnly bool `json:"planOnly,omitempty"`
 
+	// Simulation is the profile of the simulated plans, run against emulated
+	// services such as LocalStack instead of the cloud. It only applies to
+	// the plan only objects, like the branch Terraform objects of the branch
+	// planner, which plan in simulation without the credentials of the
+	// object.
+	// +optional
+	Simulation *SimulationSpec `json:"simulation,omitempty"`
+
 	// BreakTheGlass specifies if the reconciliation should stop
 	// and allow interactive shell in case of emergency.
 	// +optional
 	BreakTheGlass bool `json:"breakTheGlass,omitempty"`
+
+	// CredentialsCheck enables a pre-flight check of the cloud credentials
+	// available to the runner. The check is performed before Terraform is
+	// initialized, so that invalid credentials are reported early.
+	// +optional
+	CredentialsCheck *CredentialsCheckSpec `json:"credentialsCheck,omitempty"`
+
+	// ResultsExport makes the controller write the results of each run as
+	// JUnit XML and SARIF reports, to be ingested by CI dashboards and code
+	// scanning tools.
+	// +optional
+	ResultsExport *ResultsExportSpec `json:"resultsExport,omitempty"`
 }
 
 type CloudSpec struct {
@@ -280,6 +608,546 @@ type CloudWorkspacesSpec struct {
 	Tags []string `json:"tags,omitempty"`
 }
 
+// SimulationSpec is the profile of the simulated plans of a Terraform
+// object.
+type SimulationSpec struct {
+	// Providers override the configuration of the providers, for them to
+	// call the emulated services.
+	// +required
+	Providers []SimulatedProvider `json:"providers"`
+
+	// Env are the environment variables of the runner in simulation, like
+	// the fake credentials of the emulator. They replace the environment
+	// variables of the runner pod template.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// ServiceAccountName is the service account of the runner in
+	// simulation, which should have no cloud identity. Defaults to
+	// tf-runner.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+}
+
+// SimulatedProvider overrides the configuration of a provider in
+// simulation.
+type SimulatedProvider struct {
+	// Name is the name of the provider, e.g. aws.
+	// +required
+	Name string `json:"name"`
+
+	// Alias is the alias of the provider configuration, when it has one.
+	// +optional
+	Alias string `json:"alias,omitempty"`
+
+	// Endpoints are the endpoints of the services of the provider, by
+	// service, e.g. s3: http://localstack:4566.
+	// +optional
+	Endpoints map[string]string `json:"endpoints,omitempty"`
+
+	// Config is the HCL of the other arguments of the provider
+	// configuration, e.g. skip_credentials_validation = true.
+	// +optional
+	Config string `json:"config,omitempty"`
+}
+
+// ToHCL returns the override file of the provider configurations of the
+// simulation, which Terraform merges into the provider blocks of the
+// module.
+func (in SimulationSpec) ToHCL() string {
+	var buf bytes.Buffer
+	for i, provider := range in.Providers {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		buf.WriteString(fmt.Sprintf("provider %q {\n", provider.Name))
+		if provider.Alias != "" {
+			buf.WriteString(fmt.Sprintf("  alias = %q\n", provider.Alias))
+		}
+		if len(provider.Endpoints) > 0 {
+			services := make([]string, 0, len(provider.Endpoints))
+			for service := range provider.Endpoints {
+				services = append(services, service)
+			}
+			sort.Strings(services)
+			buf.WriteString("  endpoints {\n")
+			for _, service := range services {
+				buf.WriteString(fmt.Sprintf("    %s = %q\n", service, provider.Endpoints[service]))
+			}
+			buf.WriteString("  }\n")
+		}
+		for _, line := range strings.Split(strings.TrimSpace(provider.Config), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				buf.WriteString("  " + line + "\n")
+			}
+		}
+		buf.WriteString("}\n")
+	}
+	return buf.String()
+}
+
+// GitCredentials are the credentials of the Git repositories of the modules.
+type GitCredentials struct {
+	// SecretRef references a Secret in the namespace of the Terraform object,
+	// in the format of the Secrets of Flux GitRepositories: the identity and
+	// known_hosts keys for SSH, and the username and password keys, or the
+	// bearerToken key, for HTTPS.
+	// +required
+	SecretRef meta.LocalObjectReference `json:"secretRef"`
+
+	// Hosts restricts the HTTPS credentials to the given hosts. When empty,
+	// the HTTPS credentials are sent to any host.
+	// +optional
+	Hosts []string `json:"hosts,omitempty"`
+}
+
+// DriftRemediation is the pull request opened on the Git repository of the
+// source of a Terraform object when a drift is detected. The drift is not
+// applied, whatever the approval of the plans of the object, so that it is
+// remediated by a person.
+type DriftRemediation struct {
+	// SecretRef references a Secret in the namespace of the Terraform object,
+	// with the API token of the Git provider in its token key.
+	// +required
+	SecretRef meta.LocalObjectReference `json:"secretRef"`
+
+	// BaseBranch is the branch the pull request is opened against. Defaults
+	// to the branch of the GitRepository source.
+	// +optional
+	BaseBranch string `json:"baseBranch,omitempty"`
+
+	// ReportPath is the path, in the repository, of the drift report that
+	// the pull request commits. Defaults to <path>/drift/<name>.md, with the
+	// path of the object.
+	// +optional
+	ReportPath string `json:"reportPath,omitempty"`
+}
+
+// DriftPullRequest is the pull request opened for a drift of a Terraform
+// object.
+type DriftPullRequest struct {
+	// Number is the number of the pull request.
+	Number int `json:"number"`
+
+	// URL is the web page of the pull request.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// Branch is the head branch of the pull request.
+	Branch string `json:"branch"`
+
+	// DriftDigest identifies the drift the pull request is opened for, so
+	// that the same drift does not open another one.
+	DriftDigest string `json:"driftDigest"`
+
+	// OpenedAt is the time the pull request was opened.
+	OpenedAt metav1.Time `json:"openedAt"`
+}
+
+// RevisionDiffSpec is the comparison of the revisions of the GitRepository
+// source of a Terraform object, with the API of its Git provider.
+type RevisionDiffSpec struct {
+	// SecretRef references a Secret in the namespace of the Terraform object,
+	// with the API token of the Git provider in its token key.
+	// +required
+	SecretRef meta.LocalObjectReference `json:"secretRef"`
+
+	// MaxCommits is the maximum number of commits recorded in the status,
+	// the most recent ones. Defaults to 20.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxCommits int `json:"maxCommits,omitempty"`
+
+	// MaxChangedFiles is the maximum number of changed files recorded in
+	// the status. Defaults to 100.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxChangedFiles int `json:"maxChangedFiles,omitempty"`
+}
+
+// RevisionDiff is what a revision of the source of a Terraform object
+// changes since its last applied revision.
+type RevisionDiff struct {
+	// From is the last applied revision.
+	From string `json:"from"`
+
+	// To is the revision of the source compared to the last applied one.
+	To string `json:"to"`
+
+	// Commits are the commits of the new revision which the last applied
+	// revision does not include, oldest first, up to maxCommits.
+	// +optional
+	Commits []RevisionDiffCommit `json:"commits,omitempty"`
+
+	// TotalCommits is the number of the commits of the new revision which
+	// the last applied revision does not include.
+	TotalCommits int `json:"totalCommits"`
+
+	// ChangedFiles are the paths of the files the commits change, up to
+	// maxChangedFiles.
+	// +optional
+	ChangedFiles []string `json:"changedFiles,omitempty"`
+
+	// TotalChangedFiles is the number of the files the commits change, as
+	// far as the Git provider lists them.
+	TotalChangedFiles int `json:"totalChangedFiles"`
+}
+
+// RevisionDiffCommit is a commit of a revision diff.
+type RevisionDiffCommit struct {
+	// SHA is the SHA of the commit.
+	SHA string `json:"sha"`
+
+	// Message is the first line of the message of the commit.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Author is the name of the author of the commit.
+	// +optional
+	Author string `json:"author,omitempty"`
+}
+
+// PullRequestReference is the pull request planned by a branch Terraform
+// object of the branch planner.
+type PullRequestReference struct {
+	// Repository is the path of the repository of the pull request, like
+	// org/name.
+	Repository string `json:"repository"`
+
+	// Number is the number of the pull request.
+	Number int `json:"number"`
+
+	// URL is the web page of the pull request.
+	// +optional
+	URL string `json:"url,omitempty"`
+}
+
+// ProviderUpgrade is the schedule of the upgrades of the providers of a
+// Terraform object.
+type ProviderUpgrade struct {
+	// Interval is the time between two upgrades of the providers, e.g. 168h
+	// for weekly upgrades. The plan following an upgrade shows the changes
+	// of the new versions of the providers.
+	// +required
+	Interval metav1.Duration `json:"interval"`
+}
+
+// RefreshSpec is the schedule of the refreshes of the state of a Terraform
+// object, split from its plans.
+type RefreshSpec struct {
+	// Interval is the time between two refreshes of the state with
+	// terraform apply -refresh-only, which updates the state from the
+	// providers without changing any resource. The state is only refreshed
+	// by the plans when not set.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// SkipOnPlan runs the plans and the drift detections with
+	// -refresh=false, comparing the configuration to the last refreshed
+	// state without calling the APIs of the providers. The plans of a
+	// destroy always refresh the state.
+	// +optional
+	SkipOnPlan bool `json:"skipOnPlan,omitempty"`
+
+	// FullPlanInterval is the time between two plans which still refresh
+	// the state when skipOnPlan is set, e.g. 24h. All the plans skip the
+	// refresh when not set.
+	// +optional
+	FullPlanInterval *metav1.Duration `json:"fullPlanInterval,omitempty"`
+}
+
+// RefreshStatus is the time of the last refreshes of the state of a
+// Terraform object with spec.refresh.
+type RefreshStatus struct {
+	// LastRefreshedAt is the time the state was last refreshed on the
+	// schedule of spec.refresh.interval.
+	// +optional
+	LastRefreshedAt *metav1.Time `json:"lastRefreshedAt,omitempty"`
+
+	// LastFullPlanAt is the time of the last plan or drift detection which
+	// refreshed the state.
+	// +optional
+	LastFullPlanAt *metav1.Time `json:"lastFullPlanAt,omitempty"`
+}
+
+// QuarantineSpec is when a repeatedly failing Terraform object is
+// quarantined, and how often it is retried then.
+type QuarantineSpec struct {
+	// Threshold is the number of consecutive failures after which the object
+	// is quarantined. Defaults to 5.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Threshold int32 `json:"threshold,omitempty"`
+
+	// Backoff is the time between two retries of the quarantined object.
+	// Defaults to 1h.
+	// +optional
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+}
+
+// OnErrorSpec is how the reconciliations failing with a class of error are
+// retried.
+type OnErrorSpec struct {
+	// StateLocked is a Terraform command which could not acquire the lock
+	// of the state.
+	// +optional
+	StateLocked *ErrorRetry `json:"stateLocked,omitempty"`
+
+	// QuotaExceeded is a reconciliation held back by the runner quota of the
+	// namespace or by the capacity of the runners.
+	// +optional
+	QuotaExceeded *ErrorRetry `json:"quotaExceeded,omitempty"`
+
+	// RunnerFailed is a runner pod which crashed or was OOM-killed.
+	// +optional
+	RunnerFailed *ErrorRetry `json:"runnerFailed,omitempty"`
+
+	// BackendAccessDenied is a backend the object is not allowed to use.
+	// +optional
+	BackendAccessDenied *ErrorRetry `json:"backendAccessDenied,omitempty"`
+
+	// DependencyNotReady is a dependency of the object which is not ready.
+	// +optional
+	DependencyNotReady *ErrorRetry `json:"dependencyNotReady,omitempty"`
+
+	// InitFailed is a failure of terraform init.
+	// +optional
+	InitFailed *ErrorRetry `json:"initFailed,omitempty"`
+
+	// PlanFailed is a failure of terraform plan, including the plans of the
+	// drift detection.
+	// +optional
+	PlanFailed *ErrorRetry `json:"planFailed,omitempty"`
+
+	// ApplyFailed is a failure of terraform apply or destroy.
+	// +optional
+	ApplyFailed *ErrorRetry `json:"applyFailed,omitempty"`
+}
+
+// ErrorRetry is when a failed reconciliation is retried.
+type ErrorRetry struct {
+	// RetryAfter is the time to wait before retrying the reconciliation.
+	// +required
+	RetryAfter metav1.Duration `json:"retryAfter"`
+}
+
+// The classes of errors of spec.onError.
+const (
+	ErrorClassStateLocked         = "stateLocked"
+	ErrorClassQuotaExceeded       = "quotaExceeded"
+	ErrorClassRunnerFailed        = "runnerFailed"
+	ErrorClassBackendAccessDenied = "backendAccessDenied"
+	ErrorClassDependencyNotReady  = "dependencyNotReady"
+	ErrorClassInitFailed          = "initFailed"
+	ErrorClassPlanFailed          = "planFailed"
+	ErrorClassApplyFailed         = "applyFailed"
+)
+
+// QuarantineStatus is the quarantine of a repeatedly failing Terraform
+// object.
+type QuarantineStatus struct {
+	// Since is the time the object was quarantined.
+	Since metav1.Time `json:"since"`
+
+	// NextRetryAt is the time of the next retry of the object.
+	NextRetryAt metav1.Time `json:"nextRetryAt"`
+
+	// Revision is the source revision which failed.
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
+	// Generation is the generation of the object which failed.
+	// +optional
+	Generation int64 `json:"generation,omitempty"`
+}
+
+// ProviderLockStatus is the dependency lock of the providers of a Terraform
+// object, pinned between the upgrades of spec.providerUpgrade.
+type ProviderLockStatus struct {
+	// ConfigMapName is the name of the ConfigMap holding the dependency lock
+	// file of the last upgrade.
+	ConfigMapName string `json:"configMapName"`
+
+	// LastUpgradedAt is the time the providers were last upgraded.
+	LastUpgradedAt metav1.Time `json:"lastUpgradedAt"`
+
+	// Providers are the versions of the providers by address, as of the last
+	// upgrade.
+	// +optional
+	Providers map[string]string `json:"providers,omitempty"`
+}
+
+// RegistryCredentials is the API token of a module registry.
+type RegistryCredentials struct {
+	// Hostname is the hostname of the registry.
+	// +kubebuilder:default:=app.terraform.io
+	// +optional
+	Hostname string `json:"hostname,omitempty"`
+
+	// SecretRef references a Secret in the namespace of the Terraform object,
+	// with the API token in its token key.
+	// +required
+	SecretRef meta.LocalObjectReference `json:"secretRef"`
+}
+
+// RegistryModule is a module of a module registry, in the format of the
+// module sources of Terraform.
+type RegistryModule struct {
+	// Source is the address of the module in the registry, e.g.
+	// app.terraform.io/example-corp/vpc/aws. The hostname defaults to
+	// registry.terraform.io.
+	// +required
+	Source string `json:"source"`
+
+	// Version is a version constraint of the module, e.g. "~> 1.2". The
+	// latest version is used when empty.
+	// +optional
+	Version string `json:"version,omitempty"`
+}
+
+// SourceLayer is a source overlaid on the artifact of the source of a
+// Terraform object.
+type SourceLayer struct {
+	// SourceRef is the reference of the source of the layer.
+	// +required
+	SourceRef CrossNamespaceSourceReference `json:"sourceRef"`
+
+	// Path is the directory of the artifact of the layer to overlay.
+	// Defaults to the root of the artifact.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// TargetPath is the directory, in the artifact of the source, the layer
+	// is overlaid on. Defaults to the path of the Terraform object.
+	// +optional
+	TargetPath string `json:"targetPath,omitempty"`
+}
+
+// SourceLayerRevision is the revision of a source of a layered artifact.
+type SourceLayerRevision struct {
+	SourceRef CrossNamespaceSourceReference `json:"sourceRef"`
+	Revision  string                        `json:"revision"`
+}
+
+// EgressAudit configures the audit of the cloud API calls of the runner.
+type EgressAudit struct {
+	// ConfigMapName is the name of the ConfigMap, in the namespace of the
+	// Terraform object, the calls are written to. Defaults to
+	// <name>-egress-audit.
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+}
+
+// CredentialsProvider is a cloud provider supported by the credentials check.
+// +kubebuilder:validation:Enum=aws;gcp
+type CredentialsProvider string
+
+const (
+	// CredentialsProviderAWS checks the AWS credentials with STS GetCallerIdentity.
+	CredentialsProviderAWS CredentialsProvider = "aws"
+
+	// CredentialsProviderGCP checks the GCP access token with the token info endpoint.
+	CredentialsProviderGCP CredentialsProvider = "gcp"
+)
+
+// CredentialsCheckSpec configures the pre-flight credentials check.
+type CredentialsCheckSpec struct {
+	// Providers is the list of cloud providers whose credentials are checked.
+	// +kubebuilder:validation:MinItems=1
+	// +required
+	Providers []CredentialsProvider `json:"providers"`
+
+	// ExpiryWarningThreshold makes the controller emit an event with the
+	// CredentialsExpiring reason, when the checked credentials expire within
+	// the given duration. No event is emitted when not specified.
+	// +optional
+	ExpiryWarningThreshold *metav1.Duration `json:"expiryWarningThreshold,omitempty"`
+}
+
+// ResultsFormat is a report format of the results export.
+// +kubebuilder:validation:Enum=junit;sarif
+type ResultsFormat string
+
+const (
+	// ResultsFormatJUnit reports the validation of the configuration and
+	// every stage of the run as JUnit test suites.
+	ResultsFormatJUnit ResultsFormat = "junit"
+
+	// ResultsFormatSARIF reports the failed stages of the run, such as the
+	// policy checks, and the diagnostics of Terraform as SARIF results.
+	ResultsFormatSARIF ResultsFormat = "sarif"
+)
+
+// ResultsExportSpec configures the export of the results of each run.
+type ResultsExportSpec struct {
+	// ConfigMapName is the name of the ConfigMap the reports are written to.
+	// Defaults to tf-results-<workspace>-<name>.
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// Formats is the list of report formats to write.
+	// Defaults to all supported formats.
+	// +optional
+	Formats []ResultsFormat `json:"formats,omitempty"`
+
+	// Bucket is an S3 compatible bucket the reports of each run are also
+	// uploaded to, so that they are kept after the next run.
+	// +optional
+	Bucket *ResultsBucketSpec `json:"bucket,omitempty"`
+}
+
+// ResultsBucketSpec is an S3 compatible bucket the reports are uploaded to,
+// under <prefix>/<namespace>/<name>/<time of the run>/.
+type ResultsBucketSpec struct {
+	// Endpoint is the address of the S3 compatible API, e.g.
+	// s3.amazonaws.com or minio.minio:9000.
+	// +required
+	Endpoint string `json:"endpoint"`
+
+	// BucketName is the name of the bucket.
+	// +required
+	BucketName string `json:"bucketName"`
+
+	// Region is the region of the bucket. Defaults to us-east-1.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Prefix is the prefix of the keys of the reports.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Insecure connects to the endpoint over plain HTTP.
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+
+	// SecretRef is a Secret, in the namespace of the Terraform object, with
+	// the accesskey and the secretkey of the bucket.
+	// +optional
+	SecretRef *meta.LocalObjectReference `json:"secretRef,omitempty"`
+}
+
+// GetConfigMapName returns the name of the ConfigMap the reports of the
+// given Terraform object are written to.
+func (in ResultsExportSpec) GetConfigMapName(terraform *Terraform) string {
+	if in.ConfigMapName != "" {
+		return in.ConfigMapName
+	}
+	return "tf-results-" + terraform.WorkspaceName() + "-" + terraform.Name
+}
+
+// HasFormat returns true if the given report format is to be written.
+func (in ResultsExportSpec) HasFormat(format ResultsFormat) bool {
+	if len(in.Formats) == 0 {
+		return true
+	}
+	for _, f := range in.Formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
 type Webhook struct {
 	// +kubebuilder:validation:Enum=post-planning
 	// +kubebuilder:default:=post-planning
@@ -301,74 +1169,455 @@ type Webhook struct {
 	// +optional
 	ErrorMessageTemplate string `json:"errorMessageTemplate,omitempty"`
 
-	// +required
-	TestExpression string `json:"testExpression,omitempty"`
+	// +required
+	TestExpression string `json:"testExpression,omitempty"`
+}
+
+func (w Webhook) IsEnabled() bool {
+	return w.Enabled == nil || *w.Enabled
+}
+
+type PlanStatus struct {
+	// +optional
+	LastApplied string `json:"lastApplied,omitempty"`
+
+	// +optional
+	Pending string `json:"pending,omitempty"`
+
+	// +optional
+	IsDestroyPlan bool `json:"isDestroyPlan,omitempty"`
+
+	// +optional
+	IsDriftDetectionPlan bool `json:"isDriftDetectionPlan,omitempty"`
+
+	// Changes counts the resource changes of the pending plan.
+	// +optional
+	Changes *PlanChanges `json:"changes,omitempty"`
+
+	// PendingSince is the time the pending plan was planned.
+	// +optional
+	PendingSince *metav1.Time `json:"pendingSince,omitempty"`
+
+	// RemindedAt is the time of the last reminder of the pending plan.
+	// +optional
+	RemindedAt *metav1.Time `json:"remindedAt,omitempty"`
+
+	// EscalatedAt is the time the pending plan was escalated.
+	// +optional
+	EscalatedAt *metav1.Time `json:"escalatedAt,omitempty"`
+}
+
+// ApprovalEscalation reminds of a plan pending approval, escalates it, and
+// discards it, after the times since it was planned.
+type ApprovalEscalation struct {
+	// RemindAfter reminds of the plan pending approval once it has been
+	// pending for this duration, and again every time this duration elapses.
+	// +optional
+	RemindAfter *metav1.Duration `json:"remindAfter,omitempty"`
+
+	// EscalateAfter escalates the plan pending approval once it has been
+	// pending for this duration: its event has the error severity, for the
+	// alerts of the errors to forward it, and it is posted to the
+	// EscalationChannel of Slack.
+	// +optional
+	EscalateAfter *metav1.Duration `json:"escalateAfter,omitempty"`
+
+	// EscalationChannel is the Slack channel the escalated plans are posted
+	// to, besides the channel of the Terraform object.
+	// +optional
+	EscalationChannel string `json:"escalationChannel,omitempty"`
+
+	// PlanExpiry discards the plan pending approval once it has been pending
+	// for this duration. The next reconciliation plans again, from the
+	// current revision and state.
+	// +optional
+	PlanExpiry *metav1.Duration `json:"planExpiry,omitempty"`
+}
+
+// PlanChanges counts the resources a plan adds, changes and destroys.
+// A replaced resource counts as both added and destroyed.
+type PlanChanges struct {
+	Add     int32 `json:"add"`
+	Change  int32 `json:"change"`
+	Destroy int32 `json:"destroy"`
+}
+
+// TerraformStatus defines the observed state of Terraform
+type TerraformStatus struct {
+	meta.ReconcileRequestStatus `json:",inline"`
+
+	// ObservedGeneration is the last reconciled generation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// The last successfully applied revision.
+	// The revision format for Git sources is <branch|tag>/<commit-sha>.
+	// +optional
+	LastAppliedRevision string `json:"lastAppliedRevision,omitempty"`
+
+	// LastAttemptedRevision is the revision of the last reconciliation attempt.
+	// +optional
+	LastAttemptedRevision string `json:"lastAttemptedRevision,omitempty"`
+
+	// LastPlannedRevision is the revision used by the last planning process.
+	// The result could be either no plan change or a new plan generated.
+	// +optional
+	LastPlannedRevision string `json:"lastPlannedRevision,omitempty"`
+
+	// SourceLayerRevisions are the revisions of the source and of its
+	// layers the last attempted revision is made of, when the Terraform
+	// object has source layers.
+	// +optional
+	SourceLayerRevisions []SourceLayerRevision `json:"sourceLayerRevisions,omitempty"`
+
+	// LastDriftDetectedAt is the time when the last drift was detected
+	// +optional
+	LastDriftDetectedAt *metav1.Time `json:"lastDriftDetectedAt,omitempty"`
+
+	// LastAppliedByDriftDetectionAt is the time when the last drift was detected and
+	// terraform apply was performed as a result
+	// +optional
+	LastAppliedByDriftDetectionAt *metav1.Time `json:"lastAppliedByDriftDetectionAt,omitempty"`
+
+	// DriftPullRequest is the last pull request opened for a drift of the
+	// object, when spec.driftRemediation is set.
+	// +optional
+	DriftPullRequest *DriftPullRequest `json:"driftPullRequest,omitempty"`
+
+	// RevisionDiff is what the last revision of the source changes since
+	// the last applied revision, when spec.revisionDiff is set.
+	// +optional
+	RevisionDiff *RevisionDiff `json:"revisionDiff,omitempty"`
+
+	// PullRequest is the pull request planned by the object, when it is a
+	// branch Terraform object of the branch planner.
+	// +optional
+	PullRequest *PullRequestReference `json:"pullRequest,omitempty"`
+
+	// ProviderLock is the dependency lock of the providers, when
+	// spec.providerUpgrade is set.
+	// +optional
+	ProviderLock *ProviderLockStatus `json:"providerLock,omitempty"`
+
+	// Refresh is the time of the last refreshes of the state, when
+	// spec.refresh is set.
+	// +optional
+	Refresh *RefreshStatus `json:"refresh,omitempty"`
+
+	// ConsecutiveFailures is the number of reconciliations which failed in a
+	// row since the last successful one.
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+
+	// Quarantine is set while the object is quarantined after failing
+	// spec.quarantine.threshold times in a row.
+	// +optional
+	Quarantine *QuarantineStatus `json:"quarantine,omitempty"`
+
+	// +optional
+	AvailableOutputs []string `json:"availableOutputs,omitempty"`
+
+	// +optional
+	Plan PlanStatus `json:"plan,omitempty"`
+
+	// Inventory contains the list of Terraform resource object references that have been successfully applied.
+	// +optional
+	Inventory *ResourceInventory `json:"inventory,omitempty"`
+
+	// +optional
+	Lock LockStatus `json:"lock,omitempty"`
+
+	// VariableValidationErrors are the validation rules of the variables
+	// which failed the last plan.
+	// +optional
+	VariableValidationErrors []VariableValidationError `json:"variableValidationErrors,omitempty"`
+
+	// Errors are the diagnostics of the last failed plan or apply, as
+	// reported by Terraform.
+	// +optional
+	Errors []TerraformError `json:"errors,omitempty"`
+
+	// Summary is a concise, human-readable description of the state of
+	// the object, e.g. "plan pending approval: +3 ~1 -0 @ rev abc123".
+	// +optional
+	Summary string `json:"summary,omitempty"`
+
+	// RegistryModuleVersion is the version of spec.registryModule resolved
+	// by the last reconciliation.
+	// +optional
+	RegistryModuleVersion string `json:"registryModuleVersion,omitempty"`
+
+	// Progress is the live progress of the running plan or apply, as
+	// reported by the runner. It is cleared once the operation is over.
+	// +optional
+	Progress *TerraformProgress `json:"progress,omitempty"`
+
+	// ResourceUsage is the time and the resources the runner used in each
+	// stage of the last run, to size the resources of the runner pod.
+	// +optional
+	ResourceUsage *RunnerResourceUsage `json:"resourceUsage,omitempty"`
+
+	// Stages are the conditions of the stages of the reconciliations: Init,
+	// Plan, PolicyCheck, Apply and Health, each from its last run, with its
+	// result and its timing.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Stages []StageCondition `json:"stages,omitempty"`
+
+	// Waiting tells what the object waits for before its reconciliation can
+	// go on, and since when. It is cleared once the wait is over.
+	// +optional
+	Waiting *WaitingStatus `json:"waiting,omitempty"`
+
+	// Module documents the root module of the object: its description and
+	// the variables and outputs it declares, as read from the source.
+	// +optional
+	Module *ModuleStatus `json:"module,omitempty"`
+}
+
+// ModuleStatus documents a Terraform module, as read from its README and
+// its variable and output blocks.
+type ModuleStatus struct {
+	// Revision is the source revision the module was read from.
+	Revision string `json:"revision"`
+
+	// Description is the first paragraph of the README of the module.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Inputs are the variables of the module, by name.
+	// +optional
+	Inputs []ModuleInput `json:"inputs,omitempty"`
+
+	// Outputs are the outputs of the module, by name.
+	// +optional
+	Outputs []ModuleOutput `json:"outputs,omitempty"`
+}
+
+// ModuleInput is a variable of a Terraform module.
+type ModuleInput struct {
+	Name string `json:"name"`
+
+	// Type is the type constraint of the variable, as written in the
+	// module, e.g. list(string).
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Default is the default value of the variable, as written in the
+	// module. It is unset for a required variable.
+	// +optional
+	Default *string `json:"default,omitempty"`
+
+	// Required tells whether the variable has no default value.
+	// +optional
+	Required bool `json:"required,omitempty"`
+
+	// +optional
+	Sensitive bool `json:"sensitive,omitempty"`
+}
+
+// ModuleOutput is an output of a Terraform module.
+type ModuleOutput struct {
+	Name string `json:"name"`
+
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// +optional
+	Sensitive bool `json:"sensitive,omitempty"`
+}
+
+// WaitingStatus is what a Terraform object waits for.
+type WaitingStatus struct {
+	// Reason is what the object waits for: Dependencies, TerraformQuota,
+	// RunnerCapacity, RunnerOperation, StateLock, ApplyQuota or ClusterPause.
+	Reason string `json:"reason"`
+
+	// Message details the wait.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Since is the time the object started to wait for the reason.
+	Since metav1.Time `json:"since"`
+
+	// Position is the position of the object, from 1, among the objects of
+	// its namespace waiting for the same reason, by the time they started to
+	// wait. It is only set for the runner capacity.
+	// +optional
+	Position int32 `json:"position,omitempty"`
+}
+
+// RunnerResourceUsage is the resource usage of the runner in a run.
+type RunnerResourceUsage struct {
+	// Revision is the source revision of the run.
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
+	// Stages are the stages of the run, in the order they ran.
+	// +optional
+	Stages []StageResourceUsage `json:"stages,omitempty"`
+
+	// ObservedAt is the time the run ended.
+	ObservedAt metav1.Time `json:"observedAt"`
 }
 
-func (w Webhook) IsEnabled() bool {
-	return w.Enabled == nil || *w.Enabled
-}
+// StageCondition is the condition of the last run of a stage of the
+// reconciliations of a Terraform object.
+type StageCondition struct {
+	// Type is the stage: Init, Plan, PolicyCheck, Apply or Health.
+	Type string `json:"type"`
 
-type PlanStatus struct {
+	// Status is Unknown while the stage runs, True once it succeeded and
+	// False once it failed.
+	Status metav1.ConditionStatus `json:"status"`
+
+	// Reason is Progressing while the stage runs, Succeeded once it
+	// succeeded, and the reason of the Ready condition once it failed.
+	Reason string `json:"reason"`
+
+	// Message is the error of the stage once it failed.
 	// +optional
-	LastApplied string `json:"lastApplied,omitempty"`
+	Message string `json:"message,omitempty"`
 
+	// Revision is the source revision of the run.
 	// +optional
-	Pending string `json:"pending,omitempty"`
+	Revision string `json:"revision,omitempty"`
 
+	// StartedAt is the time the stage started.
+	StartedAt metav1.Time `json:"startedAt"`
+
+	// FinishedAt is the time the stage succeeded or failed.
 	// +optional
-	IsDestroyPlan bool `json:"isDestroyPlan,omitempty"`
+	FinishedAt *metav1.Time `json:"finishedAt,omitempty"`
 
+	// Duration is the time the stage took.
 	// +optional
-	IsDriftDetectionPlan bool `json:"isDriftDetectionPlan,omitempty"`
+	Duration *metav1.Duration `json:"duration,omitempty"`
 }
 
-// TerraformStatus defines the observed state of Terraform
-type TerraformStatus struct {
-	meta.ReconcileRequestStatus `json:",inline"`
+// StageResourceUsage is the resource usage of the runner in a stage of a
+// run: setup, refresh, drift-detection, plan or apply.
+type StageResourceUsage struct {
+	// Name is the name of the stage.
+	Name string `json:"name"`
 
-	// ObservedGeneration is the last reconciled generation.
-	// +optional
-	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Duration is the wall-clock time of the stage.
+	Duration metav1.Duration `json:"duration"`
 
+	// CPUTime is the CPU time the runner pod used in the stage, terraform
+	// and its providers included. It is not set when the runner does not
+	// report it.
 	// +optional
-	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	CPUTime *metav1.Duration `json:"cpuTime,omitempty"`
 
-	// The last successfully applied revision.
-	// The revision format for Git sources is <branch|tag>/<commit-sha>.
+	// PeakMemory is the peak memory of the runner pod since it started, at
+	// the end of the stage. It is not set when the runner does not report
+	// it.
 	// +optional
-	LastAppliedRevision string `json:"lastAppliedRevision,omitempty"`
+	PeakMemory *resource.Quantity `json:"peakMemory,omitempty"`
+}
 
-	// LastAttemptedRevision is the revision of the last reconciliation attempt.
-	// +optional
-	LastAttemptedRevision string `json:"lastAttemptedRevision,omitempty"`
+// TerraformProgress counts the resources a running plan, refresh or apply
+// went through.
+type TerraformProgress struct {
+	// Operation is the running operation, plan, refresh or apply.
+	Operation string `json:"operation"`
 
-	// LastPlannedRevision is the revision used by the last planning process.
-	// The result could be either no plan change or a new plan generated.
+	// Total is the number of resources the operation is expected to go
+	// through: the resources to refresh for a plan or a refresh, and the
+	// changes of the plan for an apply. It is zero when unknown.
 	// +optional
-	LastPlannedRevision string `json:"lastPlannedRevision,omitempty"`
+	Total int32 `json:"total,omitempty"`
 
-	// LastDriftDetectedAt is the time when the last drift was detected
+	// Completed is the number of resources the operation went through.
+	Completed int32 `json:"completed"`
+
+	// Percentage is the completed part of the total, when it is known.
 	// +optional
-	LastDriftDetectedAt *metav1.Time `json:"lastDriftDetectedAt,omitempty"`
+	Percentage int32 `json:"percentage,omitempty"`
 
-	// LastAppliedByDriftDetectionAt is the time when the last drift was detected and
-	// terraform apply was performed as a result
+	// CurrentResources are the addresses of some of the resources being
+	// changed.
 	// +optional
-	LastAppliedByDriftDetectionAt *metav1.Time `json:"lastAppliedByDriftDetectionAt,omitempty"`
+	CurrentResources []string `json:"currentResources,omitempty"`
+
+	// UpdatedAt is the time the progress was last reported.
+	UpdatedAt metav1.Time `json:"updatedAt"`
+}
+
+// ReadablePlanDiff is the name of a renderer of field-level diffs for the
+// human readable plan.
+// +kubebuilder:validation:Enum=kubernetes_manifest;kubernetes_manifest_dry_run
+type ReadablePlanDiff string
+
+const (
+	ReadablePlanDiffKubernetesManifest       ReadablePlanDiff = "kubernetes_manifest"
+	ReadablePlanDiffKubernetesManifestDryRun ReadablePlanDiff = "kubernetes_manifest_dry_run"
+)
+
+// DeletionPolicy determines how the finalizer of a Terraform object handles
+// its resources and its state.
+// +kubebuilder:validation:Enum=orphan;destroy;delete-state
+type DeletionPolicy string
+
+const (
+	DeletionPolicyOrphan      DeletionPolicy = "orphan"
+	DeletionPolicyDestroy     DeletionPolicy = "destroy"
+	DeletionPolicyDeleteState DeletionPolicy = "delete-state"
+)
+
+// EventVerbosity is the verbosity of the events of a Terraform object.
+// +kubebuilder:validation:Enum=errors-only;normal;debug
+type EventVerbosity string
 
+const (
+	EventVerbosityErrorsOnly EventVerbosity = "errors-only"
+	EventVerbosityNormal     EventVerbosity = "normal"
+	EventVerbosityDebug      EventVerbosity = "debug"
+)
+
+// VariableValidationError is a validation rule of a Terraform variable which
+// failed.
+type VariableValidationError struct {
+	// Variable is the name of the variable.
+	Variable string `json:"variable"`
+
+	// Message is the error message of the validation rule.
+	Message string `json:"message"`
+}
+
+// TerraformError is a diagnostic of Terraform, with its location in the
+// source when it has one.
+type TerraformError struct {
+	// Severity is error or warning.
+	Severity string `json:"severity"`
+
+	// Summary is the short description of the diagnostic.
+	Summary string `json:"summary"`
+
+	// Detail explains the diagnostic.
 	// +optional
-	AvailableOutputs []string `json:"availableOutputs,omitempty"`
+	Detail string `json:"detail,omitempty"`
 
+	// File is the path of the file of the diagnostic, from the root of the
+	// source.
 	// +optional
-	Plan PlanStatus `json:"plan,omitempty"`
+	File string `json:"file,omitempty"`
 
-	// Inventory contains the list of Terraform resource object references that have been successfully applied.
+	// Line is the line of the diagnostic in the file.
 	// +optional
-	Inventory *ResourceInventory `json:"inventory,omitempty"`
+	Line int32 `json:"line,omitempty"`
 
+	// Column is the column of the diagnostic in the line.
 	// +optional
-	Lock LockStatus `json:"lock,omitempty"`
+	Column int32 `json:"column,omitempty"`
 }
 
 // LockStatus defines the observed state of a Terraform State Lock
@@ -386,7 +1635,12 @@ type LockStatus struct {
 // +kubebuilder:subresource:status
 // +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
-// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description=""
+// +kubebuilder:printcolumn:name="Summary",type="string",JSONPath=".status.summary",description=""
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason",description="",priority=1
+// +kubebuilder:printcolumn:name="Pending Plan",type="string",JSONPath=".status.plan.pending",description="",priority=1
+// +kubebuilder:printcolumn:name="Progress",type="integer",JSONPath=".status.progress.percentage",description="",priority=1
+// +kubebuilder:printcolumn:name="Applied Revision",type="string",JSONPath=".status.lastAppliedRevision",description="",priority=1
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description="",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
 
 // Terraform is the Schema for the terraforms API
@@ -428,6 +1682,20 @@ type BackendConfigSpec struct {
 
 	// +optional
 	Labels map[string]string `json:"labels,omitempty"`
+
+	// SecretNamespace is the namespace of the Secret of the state, with the
+	// Kubernetes backend. Defaults to the namespace of the Terraform object.
+	// Keeping the state in another namespace lets the namespace of the
+	// Terraform object be deleted without losing the state.
+	// +optional
+	SecretNamespace string `json:"secretNamespace,omitempty"`
+
+	// KubeConfig is a Secret, in the namespace of the Terraform object, with
+	// the kubeconfig of the cluster to keep the state in, under the
+	// `value` key unless a key is set. It takes precedence over ConfigPath
+	// and InClusterConfig.
+	// +optional
+	KubeConfig *meta.SecretKeyReference `json:"kubeConfig,omitempty"`
 }
 
 // TFStateSpec allows the user to set ForceUnlock
@@ -461,6 +1729,13 @@ type TFStateSpec struct {
 	//
 	// +optional
 	LockIdentifier string `json:"lockIdentifier,omitempty"`
+
+	// DisablePlanLock plans without holding the state lock, for the plans of
+	// this object not to wait for the other users of the state. A plan never
+	// writes the state, so it is only safe to set on planOnly objects, as the
+	// branch planner does for the objects of the pull requests.
+	// +optional
+	DisablePlanLock bool `json:"disablePlanLock,omitempty"`
 }
 
 type ForceUnlockEnum string
@@ -481,19 +1756,71 @@ const (
 	DefaultWorkspaceName      = "default"
 )
 
+// CanaryApplySpec is the canary stage of the applies.
+type CanaryApplySpec struct {
+	// Targets are the addresses of the resources and the modules applied
+	// first, as in the -target option of terraform, e.g. module.canary.
+	// +kubebuilder:validation:MinItems=1
+	// +required
+	Targets []string `json:"targets"`
+
+	// HealthChecks are the health checks run once the canary is applied.
+	// Defaults to spec.healthChecks.
+	// +optional
+	HealthChecks []HealthCheck `json:"healthChecks,omitempty"`
+}
+
+// GetHealthChecks returns the health checks of the canary.
+func (in CanaryApplySpec) GetHealthChecks(terraform Terraform) []HealthCheck {
+	if len(in.HealthChecks) > 0 {
+		return in.HealthChecks
+	}
+	return terraform.Spec.HealthChecks
+}
+
 // The potential reasons that are associated with condition types
 const (
+	ApplyPausedReason               = "ApplyPaused"
 	ArtifactFailedReason            = "ArtifactFailed"
+	BackendAccessDeniedReason       = "BackendAccessDenied"
+	BackendConfigInvalidReason      = "BackendConfigInvalid"
+	CanaryFailedReason              = "CanaryFailed"
+	ConditionMappingFailedReason    = "ConditionMappingFailed"
+	ConditionMetReason              = "ConditionMet"
+	ConditionNotMetReason           = "ConditionNotMet"
+	ConflictDetectedReason          = "ConflictDetected"
+	ConsumersRollingOutReason       = "ConsumersRollingOut"
+	CredentialsExpiringReason       = "CredentialsExpiring"
+	CredentialsInvalidReason        = "CredentialsInvalid"
+	CredentialsValidReason          = "CredentialsValid"
 	DeletionBlockedByDependants     = "DeletionBlockedByDependantsReason"
 	DependencyNotReadyReason        = "DependencyNotReady"
 	DriftDetectedReason             = "DriftDetected"
 	DriftDetectionFailedReason      = "DriftDetectionFailed"
 	HealthChecksFailedReason        = "HealthChecksFailed"
+	MovedBlocksMissingReason        = "MovedBlocksMissing"
 	NoDriftReason                   = "NoDrift"
+	OutputsChangedReason            = "OutputsChanged"
 	OutputsWritingFailedReason      = "OutputsWritingFailed"
+	PauseAllowListedReason          = "PauseAllowListed"
+	PlanApprovalEscalatedReason     = "PlanApprovalEscalated"
+	PlanApprovalReminderReason      = "PlanApprovalReminder"
+	PlanChangesFailedReason         = "PlanChangesFailed"
+	PlanExpiredReason               = "PlanExpired"
+	PlanRejectedReason              = "PlanRejected"
 	PlannedNoChangesReason          = "TerraformPlannedNoChanges"
 	PlannedWithChangesReason        = "TerraformPlannedWithChanges"
 	PostPlanningWebhookFailedReason = "PostPlanningWebhookFailed"
+	QuarantinedReason               = "Quarantined"
+	QuotaExceededReason             = "QuotaExceeded"
+	ReadyWhenFailedReason           = "ReadyWhenFailed"
+	ReadyWhenNotMetReason           = "ReadyWhenNotMet"
+	ReconciliationInterruptedReason = "ReconciliationInterrupted"
+	RegistryModuleFailedReason      = "RegistryModuleFailed"
+	RemoteBackendRequiredReason     = "RemoteBackendRequired"
+	RunnerCrashedReason             = "RunnerCrashed"
+	RunnerOOMKilledReason           = "RunnerOOMKilled"
+	StalePlanReason                 = "StalePlan"
 	TFExecApplyFailedReason         = "TFExecApplyFailed"
 	TFExecApplySucceedReason        = "TerraformAppliedSucceed"
 	TFExecForceUnlockReason         = "ForceUnlock"
@@ -502,20 +1829,52 @@ const (
 	TFExecNewFailedReason           = "TFExecNewFailed"
 	TFExecOutputFailedReason        = "TFExecOutputFailed"
 	TFExecPlanFailedReason          = "TFExecPlanFailed"
+	TFExecRefreshFailedReason       = "TFExecRefreshFailed"
 	TemplateGenerationFailedReason  = "TemplateGenerationFailed"
+	VariableValidationFailedReason  = "VariableValidationFailed"
 	VarsGenerationFailedReason      = "VarsGenerationFailed"
 	WorkspaceSelectFailedReason     = "SelectWorkspaceFailed"
 )
 
+// The reasons a Terraform object waits for
+const (
+	WaitingForApplyQuota      = "ApplyQuota"
+	WaitingForClusterPause    = "ClusterPause"
+	WaitingForConsumers       = "Consumers"
+	WaitingForDependencies    = "Dependencies"
+	WaitingForRunnerCapacity  = "RunnerCapacity"
+	WaitingForRunnerOperation = "RunnerOperation"
+	WaitingForStateLock       = "StateLock"
+	WaitingForTerraformQuota  = "TerraformQuota"
+)
+
 // These constants are the Condition Types that the Terraform Resource works with
 const (
 	ConditionTypeApply       = "Apply"
+	ConditionTypeConflict    = "Conflict"
+	ConditionTypeCredentials = "Credentials"
 	ConditionTypeHealthCheck = "HealthCheck"
+	ConditionTypeMovedBlocks = "MovedBlocks"
 	ConditionTypeOutput      = "Output"
+	ConditionTypePaused      = "Paused"
 	ConditionTypePlan        = "Plan"
+	ConditionTypeQuarantined = "Quarantined"
+	ConditionTypeRunner      = "Runner"
 	ConditionTypeStateLocked = "StateLocked"
 )
 
+// The stages of the reconciliations, with a StageCondition each
+const (
+	StageInit        = "Init"
+	StagePlan        = "Plan"
+	StagePolicyCheck = "PolicyCheck"
+	StageApply       = "Apply"
+	StageHealth      = "Health"
+
+	StageFailedReason    = "Failed"
+	StageSucceededReason = "Succeeded"
+)
+
 // Webhook stages
 const (
 	PostPlanningWebhook = "post-planning"
@@ -640,11 +1999,23 @@ func TerraformPlannedWithChanges(terraform Terraform, revision string, forceOrAu
 		Message: trimString(message, MaxConditionMessageLength),
 	}
 	apimeta.SetStatusCondition(terraform.GetStatusConditions(), newCondition)
+	pendingSince := terraform.Status.Plan.PendingSince
+	remindedAt := terraform.Status.Plan.RemindedAt
+	escalatedAt := terraform.Status.Plan.EscalatedAt
+	if terraform.Status.Plan.Pending != planId || pendingSince == nil {
+		now := metav1.Now()
+		pendingSince = &now
+		remindedAt = nil
+		escalatedAt = nil
+	}
 	(&terraform).Status.Plan = PlanStatus{
 		LastApplied:          terraform.Status.Plan.LastApplied,
 		Pending:              planId, // pending plan id is always the short plan format.
 		IsDestroyPlan:        terraform.Spec.Destroy,
 		IsDriftDetectionPlan: terraform.HasDrift(),
+		PendingSince:         pendingSince,
+		RemindedAt:           remindedAt,
+		EscalatedAt:          escalatedAt,
 	}
 	if revision != "" {
 		(&terraform).Status.LastAttemptedRevision = revision
@@ -707,6 +2078,31 @@ func TerraformNotReady(terraform Terraform, revision, reason, message string) Te
 	return terraform
 }
 
+// TerraformVariableValidationFailed records the failed validation rules of
+// the variables, and sets the Ready condition to False.
+func TerraformVariableValidationFailed(terraform Terraform, revision string, validationErrors []VariableValidationError) Terraform {
+	terraform.Status.VariableValidationErrors = validationErrors
+
+	messages := make([]string, 0, len(validationErrors))
+	for _, validationError := range validationErrors {
+		messages = append(messages, fmt.Sprintf("var.%s: %s", validationError.Variable, validationError.Message))
+	}
+	return TerraformNotReady(terraform, revision, VariableValidationFailedReason,
+		"Invalid value for variable(s): "+strings.Join(messages, "; "))
+}
+
+// TerraformStalePlan sets the Ready condition to False when the pending plan
+// is not applied because the state changed since it was created. The plan is
+// discarded when replan is true, so that a new plan is created.
+func TerraformStalePlan(terraform Terraform, revision, message string, replan bool) Terraform {
+	terraform = TerraformNotReady(terraform, revision, StalePlanReason, message)
+	if replan {
+		terraform.Status.Plan.Pending = ""
+		terraform.Status.LastPlannedRevision = ""
+	}
+	return terraform
+}
+
 func TerraformAppliedFailResetPlanAndNotReady(terraform Terraform, revision, reason, message string) Terraform {
 	newCondition := metav1.Condition{
 		Type:    ConditionTypeApply,
@@ -754,6 +2150,153 @@ func TerraformHealthCheckSucceeded(terraform Terraform, message string) Terrafor
 	return terraform
 }
 
+// TerraformCredentialsValid sets the Credentials condition of the given
+// Terraform to true after a successful credentials check.
+func TerraformCredentialsValid(terraform Terraform, message string) Terraform {
+	newCondition := metav1.Condition{
+		Type:    ConditionTypeCredentials,
+		Status:  metav1.ConditionTrue,
+		Reason:  CredentialsValidReason,
+		Message: trimString(message, MaxConditionMessageLength),
+	}
+	apimeta.SetStatusCondition(terraform.GetStatusConditions(), newCondition)
+	return terraform
+}
+
+// TerraformCredentialsInvalid sets the Credentials condition of the given
+// Terraform to false after a failed credentials check, and marks it not ready.
+func TerraformCredentialsInvalid(terraform Terraform, revision, message string) Terraform {
+	newCondition := metav1.Condition{
+		Type:    ConditionTypeCredentials,
+		Status:  metav1.ConditionFalse,
+		Reason:  CredentialsInvalidReason,
+		Message: trimString(message, MaxConditionMessageLength),
+	}
+	apimeta.SetStatusCondition(terraform.GetStatusConditions(), newCondition)
+	return TerraformNotReady(terraform, revision, CredentialsInvalidReason, message)
+}
+
+// TerraformRunnerFailed sets the Runner condition of the given Terraform to
+// false after its runner pod crashed or was OOMKilled, and marks it not ready.
+func TerraformRunnerFailed(terraform Terraform, revision, reason, message string) Terraform {
+	newCondition := metav1.Condition{
+		Type:    ConditionTypeRunner,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: trimString(message, MaxConditionMessageLength),
+	}
+	apimeta.SetStatusCondition(terraform.GetStatusConditions(), newCondition)
+	return TerraformNotReady(terraform, revision, reason, message)
+}
+
+// TerraformQuarantined sets the Quarantined condition of the given Terraform
+// to true, and records until when it is quarantined. The time it was
+// quarantined is kept while it stays quarantined.
+func TerraformQuarantined(terraform Terraform, revision, message string, nextRetryAt time.Time) Terraform {
+	newCondition := metav1.Condition{
+		Type:    ConditionTypeQuarantined,
+		Status:  metav1.ConditionTrue,
+		Reason:  QuarantinedReason,
+		Message: trimString(message, MaxConditionMessageLength),
+	}
+	apimeta.SetStatusCondition(terraform.GetStatusConditions(), newCondition)
+
+	since := metav1.Now()
+	if terraform.Status.Quarantine != nil {
+		since = terraform.Status.Quarantine.Since
+	}
+	terraform.Status.Quarantine = &QuarantineStatus{
+		Since:       since,
+		NextRetryAt: metav1.NewTime(nextRetryAt),
+		Revision:    revision,
+		Generation:  terraform.Generation,
+	}
+	return terraform
+}
+
+// TerraformPaused sets the Paused condition of the given Terraform to true,
+// while the applies are paused cluster-wide.
+func TerraformPaused(terraform Terraform, message string) Terraform {
+	newCondition := metav1.Condition{
+		Type:    ConditionTypePaused,
+		Status:  metav1.ConditionTrue,
+		Reason:  ApplyPausedReason,
+		Message: trimString(message, MaxConditionMessageLength),
+	}
+	apimeta.SetStatusCondition(terraform.GetStatusConditions(), newCondition)
+	return terraform
+}
+
+// TerraformPauseAllowListed sets the Paused condition of the given Terraform
+// to false, while the applies are paused cluster-wide but it is on the
+// allow-list of the pause.
+func TerraformPauseAllowListed(terraform Terraform, message string) Terraform {
+	newCondition := metav1.Condition{
+		Type:    ConditionTypePaused,
+		Status:  metav1.ConditionFalse,
+		Reason:  PauseAllowListedReason,
+		Message: trimString(message, MaxConditionMessageLength),
+	}
+	apimeta.SetStatusCondition(terraform.GetStatusConditions(), newCondition)
+	return TerraformNotWaiting(terraform, WaitingForClusterPause)
+}
+
+// TerraformNotPaused removes the Paused condition of the given Terraform,
+// once the cluster-wide pause is over.
+func TerraformNotPaused(terraform Terraform) Terraform {
+	apimeta.RemoveStatusCondition(terraform.GetStatusConditions(), ConditionTypePaused)
+	return TerraformNotWaiting(terraform, WaitingForClusterPause)
+}
+
+// TerraformNotQuarantined releases the given Terraform from quarantine and
+// resets its count of consecutive failures.
+func TerraformNotQuarantined(terraform Terraform) Terraform {
+	apimeta.RemoveStatusCondition(terraform.GetStatusConditions(), ConditionTypeQuarantined)
+	terraform.Status.Quarantine = nil
+	terraform.Status.ConsecutiveFailures = 0
+	return terraform
+}
+
+// TerraformMovedBlocksMissing sets the MovedBlocks condition to False, when
+// the plan destroys resources and creates identical ones at other addresses.
+func TerraformMovedBlocksMissing(terraform Terraform, message string) Terraform {
+	newCondition := metav1.Condition{
+		Type:    ConditionTypeMovedBlocks,
+		Status:  metav1.ConditionFalse,
+		Reason:  MovedBlocksMissingReason,
+		Message: trimString(message, MaxConditionMessageLength),
+	}
+	apimeta.SetStatusCondition(terraform.GetStatusConditions(), newCondition)
+	return terraform
+}
+
+// TerraformMovedBlocksCovered removes the MovedBlocks condition, once a plan
+// moves no resource without a moved block.
+func TerraformMovedBlocksCovered(terraform Terraform) Terraform {
+	apimeta.RemoveStatusCondition(terraform.GetStatusConditions(), ConditionTypeMovedBlocks)
+	return terraform
+}
+
+// TerraformConflicted sets the Conflict condition, when other Terraform
+// objects manage the same resources or write to the same state.
+func TerraformConflicted(terraform Terraform, message string) Terraform {
+	newCondition := metav1.Condition{
+		Type:    ConditionTypeConflict,
+		Status:  metav1.ConditionTrue,
+		Reason:  ConflictDetectedReason,
+		Message: trimString(message, MaxConditionMessageLength),
+	}
+	apimeta.SetStatusCondition(terraform.GetStatusConditions(), newCondition)
+	return terraform
+}
+
+// TerraformConflictResolved removes the Conflict condition, once no other
+// Terraform object manages the resources or the state of the object.
+func TerraformConflictResolved(terraform Terraform) Terraform {
+	apimeta.RemoveStatusCondition(terraform.GetStatusConditions(), ConditionTypeConflict)
+	return terraform
+}
+
 // TerraformForceUnlock will set a new condition on the Terraform resource indicating
 // that we are attempting to force unlock it.
 func TerraformForceUnlock(terraform Terraform, message string) Terraform {
@@ -770,7 +2313,7 @@ func TerraformForceUnlock(terraform Terraform, message string) Terraform {
 	}
 
 	terraform.Status.Lock.Pending = ""
-	return terraform
+	return TerraformNotWaiting(terraform, WaitingForStateLock)
 }
 
 // TerraformStateLocked will set a new condition on the Terraform resource indicating
@@ -790,9 +2333,118 @@ func TerraformStateLocked(terraform Terraform, lockID, message string) Terraform
 	}
 
 	terraform.Status.Lock.Pending = lockID
+	return TerraformWaiting(terraform, WaitingForStateLock, newCondition.Message, 0)
+}
+
+// TerraformWaiting records what the Terraform object waits for. The time it
+// started to wait is kept while it waits for the same reason.
+func TerraformWaiting(terraform Terraform, reason, message string, position int32) Terraform {
+	since := metav1.Now()
+	if waiting := terraform.Status.Waiting; waiting != nil && waiting.Reason == reason {
+		since = waiting.Since
+	}
+	terraform.Status.Waiting = &WaitingStatus{
+		Reason:   reason,
+		Message:  trimString(message, MaxConditionMessageLength),
+		Since:    since,
+		Position: position,
+	}
+	return terraform
+}
+
+// TerraformNotWaiting clears the wait of the Terraform object when it is for
+// one of the reasons, or for any reason when none is given.
+func TerraformNotWaiting(terraform Terraform, reasons ...string) Terraform {
+	waiting := terraform.Status.Waiting
+	if waiting == nil {
+		return terraform
+	}
+	if len(reasons) == 0 {
+		terraform.Status.Waiting = nil
+		return terraform
+	}
+	for _, reason := range reasons {
+		if waiting.Reason == reason {
+			terraform.Status.Waiting = nil
+			break
+		}
+	}
+	return terraform
+}
+
+// TerraformStageStarted sets the condition of a stage to Unknown, until it
+// succeeds or fails.
+func TerraformStageStarted(terraform Terraform, stage, revision string) Terraform {
+	condition := StageCondition{
+		Type:      stage,
+		Status:    metav1.ConditionUnknown,
+		Reason:    meta.ProgressingReason,
+		Revision:  revision,
+		StartedAt: metav1.Now(),
+	}
+	for i := range terraform.Status.Stages {
+		if terraform.Status.Stages[i].Type == stage {
+			terraform.Status.Stages[i] = condition
+			return terraform
+		}
+	}
+	terraform.Status.Stages = append(terraform.Status.Stages, condition)
+	return terraform
+}
+
+// TerraformStageFinished sets the condition of a running stage to True, or to
+// False with the error when it failed, and the reason of the Ready condition.
+// A stage which is not running, e.g. finished already, is left as is.
+func TerraformStageFinished(terraform Terraform, stage string, err error) Terraform {
+	condition := terraform.GetStage(stage)
+	if condition == nil || condition.Status != metav1.ConditionUnknown {
+		return terraform
+	}
+
+	now := metav1.Now()
+	condition.FinishedAt = &now
+	condition.Duration = &metav1.Duration{Duration: now.Sub(condition.StartedAt.Time).Round(time.Millisecond)}
+	if err == nil {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = StageSucceededReason
+		condition.Message = ""
+		return terraform
+	}
+
+	condition.Status = metav1.ConditionFalse
+	condition.Reason = StageFailedReason
+	if ready := apimeta.FindStatusCondition(terraform.Status.Conditions, meta.ReadyCondition); ready != nil && ready.Status == metav1.ConditionFalse {
+		condition.Reason = ready.Reason
+	}
+	condition.Message = trimString(err.Error(), MaxConditionMessageLength)
+	return terraform
+}
+
+// TerraformStagesInterrupted sets the conditions of the stages left running
+// by an interrupted reconciliation to False.
+func TerraformStagesInterrupted(terraform Terraform, message string) Terraform {
+	for i := range terraform.Status.Stages {
+		condition := &terraform.Status.Stages[i]
+		if condition.Status != metav1.ConditionUnknown {
+			continue
+		}
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReconciliationInterruptedReason
+		condition.Message = trimString(message, MaxConditionMessageLength)
+	}
 	return terraform
 }
 
+// GetStage returns the condition of a stage, or nil if it never ran.
+func (in *Terraform) GetStage(stage string) *StageCondition {
+	for i := range in.Status.Stages {
+		if in.Status.Stages[i].Type == stage {
+			return &in.Status.Stages[i]
+		}
+	}
+	return nil
+}
+
 // HasDrift returns true if drift has been detected since the last successful apply
 func (in Terraform) HasDrift() bool {
 	for _, condition := range in.Status.Conditions {
@@ -806,6 +2458,126 @@ func (in Terraform) HasDrift() bool {
 	return false
 }
 
+// GetSummary returns a concise description of the state of the Terraform
+// object, e.g. "plan pending approval: +3 ~1 -0 @ rev abc123", to be shown
+// as a printer column.
+func (in Terraform) GetSummary() string {
+	ready := apimeta.FindStatusCondition(in.Status.Conditions, meta.ReadyCondition)
+	if ready == nil {
+		return ""
+	}
+
+	if in.Status.Waiting != nil {
+		return fmt.Sprintf("waiting for %s since %s", waitingSubjects[in.Status.Waiting.Reason],
+			in.Status.Waiting.Since.UTC().Format(time.RFC3339))
+	}
+
+	revision := in.Status.LastAttemptedRevision
+	var summary string
+	switch {
+	case ready.Status == metav1.ConditionUnknown && ready.Reason == meta.ProgressingReason:
+		return "reconciling"
+	case in.Status.Plan.Pending != "":
+		switch {
+		case in.Spec.PlanOnly:
+			summary = "planned"
+		case in.IsPlanPendingApproval():
+			summary = "plan pending approval"
+		default:
+			summary = "plan pending apply"
+		}
+		if changes := in.Status.Plan.Changes; changes != nil {
+			summary = fmt.Sprintf("%s: +%d ~%d -%d", summary, changes.Add, changes.Change, changes.Destroy)
+		}
+	case ready.Reason == DriftDetectedReason:
+		summary = "drift detected"
+	case ready.Status == metav1.ConditionFalse:
+		summary = "failed: " + ready.Reason
+	case ready.Reason == PlannedNoChangesReason || ready.Reason == NoDriftReason:
+		summary = "up to date"
+	default:
+		summary = "applied"
+		revision = in.Status.LastAppliedRevision
+	}
+
+	if revision != "" {
+		summary = fmt.Sprintf("%s @ rev %s", summary, shortRevision(revision))
+	}
+	return summary
+}
+
+// IsPlanPendingApproval tells whether the pending plan of the Terraform
+// object waits for an approval.
+func (in Terraform) IsPlanPendingApproval() bool {
+	pending := in.Status.Plan.Pending
+	return pending != "" &&
+		!in.Spec.PlanOnly &&
+		!in.Spec.Force &&
+		in.Spec.ApprovePlan != ApprovePlanAutoValue &&
+		(in.Spec.ApprovePlan == "" || !strings.HasPrefix(pending, in.Spec.ApprovePlan))
+}
+
+// waitingSubjects describe the reasons a Terraform object waits for.
+var waitingSubjects = map[string]string{
+	WaitingForApplyQuota:      "the apply quota of the namespace",
+	WaitingForClusterPause:    "the end of the cluster-wide pause",
+	WaitingForConsumers:       "the rollout of the consumers of its outputs",
+	WaitingForDependencies:    "its dependencies",
+	WaitingForRunnerCapacity:  "a runner of the namespace quota",
+	WaitingForRunnerOperation: "the operation of a previous controller",
+	WaitingForStateLock:       "the state lock",
+	WaitingForTerraformQuota:  "the Terraform object quota of the namespace",
+}
+
+// shortRevision returns the abbreviated commit SHA or digest of a source
+// revision, e.g. "abc1234" for "main@sha1:abc1234ef...".
+func shortRevision(revision string) string {
+	if i := strings.LastIndexAny(revision, ":/"); i >= 0 {
+		revision = revision[i+1:]
+	}
+	if len(revision) > 7 {
+		revision = revision[:7]
+	}
+	return revision
+}
+
+// GetWriteOutputsToSecrets returns all the Secrets the outputs are written
+// to, writeOutputsToSecret first.
+// IsSimulated tells whether the object plans in simulation: it has a
+// simulation profile and it only plans.
+func (in Terraform) IsSimulated() bool {
+	return in.Spec.Simulation != nil && in.Spec.PlanOnly
+}
+
+func (in Terraform) GetWriteOutputsToSecrets() []WriteOutputsToSecretSpec {
+	var specs []WriteOutputsToSecretSpec
+	if in.Spec.WriteOutputsToSecret != nil {
+		specs = append(specs, *in.Spec.WriteOutputsToSecret)
+	}
+	return append(specs, in.Spec.WriteOutputsToSecrets...)
+}
+
+// GetQuarantineThreshold returns the number of consecutive failures after
+// which the object is quarantined, or 0 when it is never quarantined.
+func (in Terraform) GetQuarantineThreshold() int32 {
+	if in.Spec.Quarantine == nil {
+		return 0
+	}
+	if in.Spec.Quarantine.Threshold > 0 {
+		return in.Spec.Quarantine.Threshold
+	}
+	return 5
+}
+
+// GetQuarantineBackoff returns the time between two retries of the object
+// while it is quarantined.
+func (in Terraform) GetQuarantineBackoff() time.Duration {
+	if in.Spec.Quarantine != nil && in.Spec.Quarantine.Backoff != nil {
+		return in.Spec.Quarantine.Backoff.Duration
+	}
+	return time.Hour
+}
+
 // GetDependsOn returns the list of dependencies, namespace scoped.
 func (in Terraform) GetDependsOn() []meta.NamespacedObjectReference {
 	return in.Spec.DependsOn
@@ -821,6 +2593,39 @@ func (in Terraform) GetRetryInterval() time.Duration {
 	return 15 * time.Second
 }
 
+// GetRetryAfterError returns the time to wait before retrying a
+// reconciliation failed with the given class of error, and whether
+// spec.onError sets it.
+func (in Terraform) GetRetryAfterError(class string) (time.Duration, bool) {
+	if in.Spec.OnError == nil {
+		return 0, false
+	}
+
+	var retry *ErrorRetry
+	switch class {
+	case ErrorClassStateLocked:
+		retry = in.Spec.OnError.StateLocked
+	case ErrorClassQuotaExceeded:
+		retry = in.Spec.OnError.QuotaExceeded
+	case ErrorClassRunnerFailed:
+		retry = in.Spec.OnError.RunnerFailed
+	case ErrorClassBackendAccessDenied:
+		retry = in.Spec.OnError.BackendAccessDenied
+	case ErrorClassDependencyNotReady:
+		retry = in.Spec.OnError.DependencyNotReady
+	case ErrorClassInitFailed:
+		retry = in.Spec.OnError.InitFailed
+	case ErrorClassPlanFailed:
+		retry = in.Spec.OnError.PlanFailed
+	case ErrorClassApplyFailed:
+		retry = in.Spec.OnError.ApplyFailed
+	}
+	if retry == nil {
+		return 0, false
+	}
+	return retry.RetryAfter.Duration, true
+}
+
 // GetStatusConditions returns a pointer to the Status.Conditions slice.
 func (in *Terraform) GetStatusConditions() *[]metav1.Condition {
 	return &in.Status.Conditions
@@ -856,6 +2661,60 @@ func (in *Terraform) GetRunnerHostname(ip string, clusterDomain string) string {
 	return fmt.Sprintf("%s.%s.pod.%s", prefix, in.Namespace, clusterDomain)
 }
 
+// GetExpiry returns the time at which the resources of the Terraform object
+// expire, from the TTL and DestroyAt, or nil if they never expire.
+func (in Terraform) GetExpiry() *time.Time {
+	var expiry *time.Time
+	if in.Spec.TTL != nil {
+		t := in.CreationTimestamp.Add(in.Spec.TTL.Duration)
+		expiry = &t
+	}
+	if in.Spec.DestroyAt != nil && (expiry == nil || in.Spec.DestroyAt.Time.Before(*expiry)) {
+		t := in.Spec.DestroyAt.Time
+		expiry = &t
+	}
+	return expiry
+}
+
+// HasExpired returns true if the resources of the Terraform object have
+// expired at the given time.
+func (in Terraform) HasExpired(now time.Time) bool {
+	expiry := in.GetExpiry()
+	return expiry != nil && !now.Before(*expiry)
+}
+
+// ShouldDestroyResourcesOnDeletion returns true if the resources must be
+// destroyed when the Terraform object gets deleted, either because it is
+// asked for, or because the object is deleted on expiry.
+func (in Terraform) ShouldDestroyResourcesOnDeletion() bool {
+	return in.GetDeletionPolicy() == DeletionPolicyDestroy || (in.Spec.DeleteOnExpiry && in.HasExpired(time.Now()))
+}
+
+// GetDeletionPolicy returns the deletion policy of the object, which
+// defaults to destroy with destroyResourcesOnDeletion, and to orphan
+// otherwise.
+func (in Terraform) GetDeletionPolicy() DeletionPolicy {
+	if in.Spec.DeletionPolicy != "" {
+		return in.Spec.DeletionPolicy
+	}
+	if in.Spec.DestroyResourcesOnDeletion {
+		return DeletionPolicyDestroy
+	}
+	return DeletionPolicyOrphan
+}
+
+// GetEgressAuditConfigMapName returns the name of the ConfigMap of the
+// egress audit, or an empty string when the audit is disabled.
+func (in Terraform) GetEgressAuditConfigMapName() string {
+	if in.Spec.EgressAudit == nil {
+		return ""
+	}
+	if in.Spec.EgressAudit.ConfigMapName != "" {
+		return in.Spec.EgressAudit.ConfigMapName
+	}
+	return in.Name + "-egress-audit"
+}
+
 func (in *TerraformSpec) GetAlwaysCleanupRunnerPod() bool {
 	if in.AlwaysCleanupRunnerPod == nil {
 		return true
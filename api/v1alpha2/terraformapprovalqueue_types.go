@@ -0,0 +1,152 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	TerraformApprovalQueueKind = "TerraformApprovalQueue"
+
+	// ApproversAnnotation lists the users and the groups, separated by
+	// commas, who are required to approve the plans of a Terraform object.
+	// It is published in the approval queues, for the dashboards and the
+	// chat-ops bots to route the approvals.
+	ApproversAnnotation = "infra.contrib.fluxcd.io/approvers"
+)
+
+// TerraformApprovalQueueSpec selects the Terraform objects listed in an
+// approval queue.
+type TerraformApprovalQueueSpec struct {
+	// NamespaceSelector selects the namespaces of the Terraform objects.
+	// All the namespaces are selected when unset.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Selector selects the Terraform objects by label. All the Terraform
+	// objects are selected when unset.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// PendingApproval is a plan of a Terraform object waiting for an approval.
+type PendingApproval struct {
+	// Namespace of the Terraform object.
+	// +required
+	Namespace string `json:"namespace"`
+
+	// Name of the Terraform object.
+	// +required
+	Name string `json:"name"`
+
+	// Plan is the ID of the pending plan, to set in spec.approvePlan.
+	// +required
+	Plan string `json:"plan"`
+
+	// Revision is the source revision of the pending plan.
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
+	// Since is the time the plan was planned.
+	// +optional
+	Since *metav1.Time `json:"since,omitempty"`
+
+	// Summary is the summary of the changes of the plan, as written by
+	// Terraform.
+	// +optional
+	Summary string `json:"summary,omitempty"`
+
+	// Changes counts the resource changes of the plan.
+	// +optional
+	Changes *PlanChanges `json:"changes,omitempty"`
+
+	// IsDestroyPlan tells whether the plan destroys the resources.
+	// +optional
+	IsDestroyPlan bool `json:"isDestroyPlan,omitempty"`
+
+	// Approvers are the users and the groups required to approve the plan,
+	// from the infra.contrib.fluxcd.io/approvers annotation of the object.
+	// +optional
+	Approvers []string `json:"approvers,omitempty"`
+}
+
+// TerraformApprovalQueueStatus lists the plans pending approval.
+type TerraformApprovalQueueStatus struct {
+	// ObservedGeneration is the last reconciled generation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Count is the number of plans pending approval.
+	// +optional
+	Count int32 `json:"count"`
+
+	// Oldest is the time the oldest plan pending approval was planned.
+	// +optional
+	Oldest *metav1.Time `json:"oldest,omitempty"`
+
+	// Pending are the plans pending approval, the oldest first.
+	// +optional
+	Pending []PendingApproval `json:"pending,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=tfapprovals
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Pending",type="integer",JSONPath=".status.count",description=""
+// +kubebuilder:printcolumn:name="Oldest",type="date",JSONPath=".status.oldest",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+
+// TerraformApprovalQueue is the Schema for the terraformapprovalqueues API.
+// It lists the plans pending approval of the Terraform objects of all the
+// namespaces it selects.
+type TerraformApprovalQueue struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TerraformApprovalQueueSpec `json:"spec,omitempty"`
+
+	// +optional
+	Status TerraformApprovalQueueStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TerraformApprovalQueueList contains a list of TerraformApprovalQueue
+type TerraformApprovalQueueList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TerraformApprovalQueue `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TerraformApprovalQueue{}, &TerraformApprovalQueueList{})
+}
+
+// GetApprovers returns the users and the groups required to approve the
+// plans of the Terraform object.
+func (in Terraform) GetApprovers() []string {
+	var approvers []string
+	for _, approver := range strings.Split(in.GetAnnotations()[ApproversAnnotation], ",") {
+		if approver = strings.TrimSpace(approver); approver != "" {
+			approvers = append(approvers, approver)
+		}
+	}
+	return approvers
+}
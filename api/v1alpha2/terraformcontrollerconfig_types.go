@@ -0,0 +1,204 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"github.com/fluxcd/pkg/apis/meta"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	TerraformControllerConfigKind = "TerraformControllerConfig"
+
+	// FeatureGateBreakTheGlass allows the break the glass sessions, as the
+	// --allow-break-the-glass flag does.
+	FeatureGateBreakTheGlass = "BreakTheGlass"
+
+	// FeatureGateRestrictedRunnerPods enforces the restricted Pod Security
+	// Standard on the runner pods, as the --restricted-runner-pods flag does.
+	FeatureGateRestrictedRunnerPods = "RestrictedRunnerPods"
+
+	ConfigInvalidReason = "ConfigInvalid"
+	ConfigLoadedReason  = "ConfigLoaded"
+)
+
+// FeatureGates are the feature gates a TerraformControllerConfig can set.
+var FeatureGates = []string{
+	FeatureGateBreakTheGlass,
+	FeatureGateRestrictedRunnerPods,
+}
+
+// TerraformControllerConfigSpec is the configuration of the controller and
+// of the branch planner which they reload without a restart. The settings it
+// leaves unset keep the values of the command-line flags.
+type TerraformControllerConfigSpec struct {
+	// Concurrency is the number of Terraform objects reconciled at once. It
+	// can be lowered at any time, but not raised above the --concurrent
+	// flag, which is the number of workers started with the controller.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Concurrency *int32 `json:"concurrency,omitempty"`
+
+	// Defaults are the defaults of the Terraform objects.
+	// +optional
+	Defaults *ControllerDefaults `json:"defaults,omitempty"`
+
+	// FeatureGates turn the features on or off by name: BreakTheGlass and
+	// RestrictedRunnerPods.
+	// +optional
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+
+	// AllowedCrossNamespaceRefs are the pairs of namespaces still allowed to
+	// refer to each other with --no-cross-namespace-refs.
+	// +optional
+	AllowedCrossNamespaceRefs []CrossNamespaceRefRule `json:"allowedCrossNamespaceRefs,omitempty"`
+
+	// Planner is the configuration of the branch planner.
+	// +optional
+	Planner *PlannerConfig `json:"planner,omitempty"`
+
+	// RunnerCA is the configuration of the CA of the mTLS connections
+	// between the controller and the runners.
+	// +optional
+	RunnerCA *RunnerCAConfig `json:"runnerCA,omitempty"`
+}
+
+// ControllerDefaults are the defaults of the Terraform objects set in a
+// TerraformControllerConfig.
+type ControllerDefaults struct {
+	// RunnerRuntimeClassName is the RuntimeClass of the runner pods of the
+	// Terraform objects which do not set one, as the
+	// --runner-runtime-class-name flag.
+	// +optional
+	RunnerRuntimeClassName string `json:"runnerRuntimeClassName,omitempty"`
+
+	// RunnerCreationTimeout is the time to wait for a runner pod to be
+	// ready, as the --runner-creation-timeout flag.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ms|s|m|h))+$"
+	// +optional
+	RunnerCreationTimeout *metav1.Duration `json:"runnerCreationTimeout,omitempty"`
+
+	// EventVerbosity is the verbosity of the events of the Terraform objects
+	// which do not set one, as the --event-verbosity flag.
+	// +optional
+	EventVerbosity EventVerbosity `json:"eventVerbosity,omitempty"`
+
+	// EventAggregationWindow is the time during which the events repeating
+	// an event of the same object are counted rather than recorded, as the
+	// --event-aggregation-window flag. Zero records them all.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ms|s|m|h))+$"
+	// +optional
+	EventAggregationWindow *metav1.Duration `json:"eventAggregationWindow,omitempty"`
+}
+
+// CrossNamespaceRefRule allows the Terraform objects of some namespaces to
+// refer to the objects of other namespaces.
+type CrossNamespaceRefRule struct {
+	// From is the namespace of the Terraform objects, as a shell pattern,
+	// e.g. team-*.
+	// +required
+	From string `json:"from"`
+
+	// To is the namespace of the objects they refer to, as a shell
+	// pattern, e.g. flux-system.
+	// +required
+	To string `json:"to"`
+}
+
+// PlannerConfig is the configuration of the branch planner set in a
+// TerraformControllerConfig.
+type PlannerConfig struct {
+	// PollingInterval is the time between two polls of the pull requests of
+	// a Terraform object, as the --polling-interval flag.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ms|s|m|h))+$"
+	// +optional
+	PollingInterval *metav1.Duration `json:"pollingInterval,omitempty"`
+}
+
+// RunnerCAConfig is the configuration of the CA of the runners set in a
+// TerraformControllerConfig.
+type RunnerCAConfig struct {
+	// RotationRequestedAt requests the rotation of the CA, without waiting
+	// for it to expire, each time it changes, e.g. to the current time. The
+	// certificates of the runners are re-issued, and the previous CA stays
+	// trusted for the --ca-trust-period.
+	// +optional
+	RotationRequestedAt string `json:"rotationRequestedAt,omitempty"`
+}
+
+// TerraformControllerConfigStatus is the observed state of a
+// TerraformControllerConfig.
+type TerraformControllerConfigStatus struct {
+	// ObservedGeneration is the last reconciled generation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions tell whether the config is valid. An invalid config is not
+	// used, and the flags apply until it is fixed.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// LastHandledRunnerCARotation is the last RotationRequestedAt of the
+	// runner CA handled by the controller.
+	// +optional
+	LastHandledRunnerCARotation string `json:"lastHandledRunnerCARotation,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=tfconfig
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+
+// TerraformControllerConfig is the Schema for the terraformcontrollerconfigs
+// API. The controller and the branch planner read the one named by their
+// --controller-config flag.
+type TerraformControllerConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TerraformControllerConfigSpec `json:"spec,omitempty"`
+
+	// +optional
+	Status TerraformControllerConfigStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TerraformControllerConfigList contains a list of TerraformControllerConfig
+type TerraformControllerConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TerraformControllerConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TerraformControllerConfig{}, &TerraformControllerConfigList{})
+}
+
+// IsValid tells whether the config was found valid for its generation.
+func (in TerraformControllerConfig) IsValid() bool {
+	if in.Status.ObservedGeneration != in.Generation {
+		return false
+	}
+	return apimeta.IsStatusConditionTrue(in.Status.Conditions, meta.ReadyCondition)
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"github.com/fluxcd/pkg/apis/meta"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	TerraformInstanceKind = "TerraformInstance"
+	TemplateIndexKey      = ".spec.templateRef.name"
+	TemplateLabel         = "infra.contrib.fluxcd.io/template"
+	InstanceLabel         = "infra.contrib.fluxcd.io/instance"
+)
+
+// The potential reasons that are associated with the TerraformInstance conditions.
+const (
+	InstanceVariablesInvalidReason  = "InstanceVariablesInvalid"
+	TerraformTemplateNotFoundReason = "TerraformTemplateNotFound"
+)
+
+// TerraformInstanceSpec defines the desired state of TerraformInstance
+type TerraformInstanceSpec struct {
+	// TemplateRef refers to the TerraformTemplate to instantiate.
+	// +required
+	TemplateRef TemplateReference `json:"templateRef"`
+
+	// Vars sets the variables exposed by the template.
+	// +optional
+	Vars []Variable `json:"vars,omitempty"`
+
+	// Suspend suspends the Terraform object of the instance.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// TemplateReference refers to a cluster-scoped TerraformTemplate.
+type TemplateReference struct {
+	// Name of the TerraformTemplate.
+	// +required
+	Name string `json:"name"`
+}
+
+// TerraformInstanceStatus defines the observed state of TerraformInstance
+type TerraformInstanceStatus struct {
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedTemplateGeneration is the generation of the template last
+	// used to render the Terraform object.
+	// +optional
+	ObservedTemplateGeneration int64 `json:"observedTemplateGeneration,omitempty"`
+
+	// Summary is the summary of the Terraform object of the instance.
+	// +optional
+	Summary string `json:"summary,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=tfinstance
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Template",type="string",JSONPath=".spec.templateRef.name",description=""
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
+// +kubebuilder:printcolumn:name="Summary",type="string",JSONPath=".status.summary",description=""
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description="",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+
+// TerraformInstance is the Schema for the terraforminstances API
+type TerraformInstance struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TerraformInstanceSpec   `json:"spec,omitempty"`
+	Status TerraformInstanceStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TerraformInstanceList contains a list of TerraformInstance
+type TerraformInstanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TerraformInstance `json:"items"`
+}
+
+// TerraformInstanceNotReady registers a failed rendering of the Terraform
+// object of the instance.
+func TerraformInstanceNotReady(instance TerraformInstance, reason, message string) TerraformInstance {
+	apimeta.SetStatusCondition(instance.GetStatusConditions(), metav1.Condition{
+		Type:    meta.ReadyCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: trimString(message, MaxConditionMessageLength),
+	})
+	instance.Status.Summary = "failed: " + reason
+	return instance
+}
+
+// TerraformInstanceFromTerraform mirrors the readiness of the Terraform
+// object of the instance.
+func TerraformInstanceFromTerraform(instance TerraformInstance, terraform Terraform) TerraformInstance {
+	condition := apimeta.FindStatusCondition(terraform.Status.Conditions, meta.ReadyCondition)
+	if condition == nil || terraform.Status.ObservedGeneration != terraform.Generation {
+		condition = &metav1.Condition{
+			Type:    meta.ReadyCondition,
+			Status:  metav1.ConditionUnknown,
+			Reason:  meta.ProgressingReason,
+			Message: "Reconciliation in progress",
+		}
+	}
+	apimeta.SetStatusCondition(instance.GetStatusConditions(), metav1.Condition{
+		Type:    meta.ReadyCondition,
+		Status:  condition.Status,
+		Reason:  condition.Reason,
+		Message: condition.Message,
+	})
+	instance.Status.Summary = terraform.Status.Summary
+	return instance
+}
+
+// GetStatusConditions returns a pointer to the Status.Conditions slice.
+func (in *TerraformInstance) GetStatusConditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+func init() {
+	SchemeBuilder.Register(&TerraformInstance{}, &TerraformInstanceList{})
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	TerraformNamespacePolicyKind = "TerraformNamespacePolicy"
+
+	// NamespacePolicyAnnotation lists the TerraformNamespacePolicies applied
+	// to a Terraform object by the mutating webhook.
+	NamespacePolicyAnnotation = "infra.contrib.fluxcd.io/namespace-policies"
+)
+
+// TerraformNamespacePolicySpec defines the defaults and the guardrails that
+// the mutating webhook applies to the Terraform objects of the namespace of
+// the policy.
+type TerraformNamespacePolicySpec struct {
+	// ServiceAccountName is the service account of the runner pods of the
+	// Terraform objects which do not set one.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// RunnerResources are the compute resources of the runner pods of the
+	// Terraform objects which do not set any.
+	// +optional
+	RunnerResources *corev1.ResourceRequirements `json:"runnerResources,omitempty"`
+
+	// MinInterval is the shortest reconciliation interval of the Terraform
+	// objects. A shorter interval is raised to MinInterval.
+	// +optional
+	MinInterval *metav1.Duration `json:"minInterval,omitempty"`
+
+	// DisallowAutoApprove removes the auto approval of the plans of the
+	// Terraform objects, so that they are applied only once approved.
+	// +optional
+	DisallowAutoApprove bool `json:"disallowAutoApprove,omitempty"`
+
+	// ApprovalEscalation reminds of the plans pending approval of the
+	// Terraform objects which do not set one, escalates them, and discards
+	// them when they are not approved in time. It is applied by the
+	// controller, whether the mutating webhook is enabled or not.
+	// +optional
+	ApprovalEscalation *ApprovalEscalation `json:"approvalEscalation,omitempty"`
+
+	// RunnerRuntimeClassName is the RuntimeClass of the runner pods of the
+	// Terraform objects, e.g. of gVisor or Kata Containers, to run untrusted
+	// modules in a sandbox. It replaces the runtime class the Terraform
+	// objects set, or unset, and is enforced by the controller whether the
+	// mutating webhook is enabled or not.
+	// +optional
+	RunnerRuntimeClassName string `json:"runnerRuntimeClassName,omitempty"`
+
+	// RestrictedRunnerPods enforces the restricted Pod Security Standard on
+	// all the containers of the runner pods, including the init containers
+	// of the Terraform objects, and removes the host namespaces, the host
+	// ports and the volumes the standard does not allow, such as hostPath,
+	// from the runner pods. It is enforced by the controller.
+	// +optional
+	RestrictedRunnerPods bool `json:"restrictedRunnerPods,omitempty"`
+
+	// RequireRemoteBackend rejects the Terraform objects keeping their
+	// state in a cluster, with the Kubernetes backend, or in their runner
+	// pods, with the local backend, rather than in a remote backend such as
+	// Terraform Cloud or a custom backend configuration. The mutating webhook
+	// rejects them when they are written, and the controller does not
+	// reconcile the existing ones.
+	// +optional
+	RequireRemoteBackend bool `json:"requireRemoteBackend,omitempty"`
+
+	// Quota limits the Terraform objects of the namespace. It is enforced by
+	// the controller, whether the mutating webhook is enabled or not.
+	// +optional
+	Quota *NamespaceQuota `json:"quota,omitempty"`
+
+	// ConditionMappings are extra conditions set on the Terraform objects of
+	// the namespace from their state, for external monitors to watch. They
+	// are set by the controller, whether the mutating webhook is enabled or
+	// not.
+	// +optional
+	ConditionMappings []ConditionMapping `json:"conditionMappings,omitempty"`
+}
+
+// NamespaceQuota limits the Terraform objects of a namespace, so that a
+// tenant cannot take up the runner capacity of the whole cluster. When
+// several policies set a limit, the lowest one applies.
+type NamespaceQuota struct {
+	// MaxTerraforms is the maximum number of Terraform objects of the
+	// namespace. The Terraform objects created after the first MaxTerraforms
+	// ones are not reconciled.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxTerraforms *int32 `json:"maxTerraforms,omitempty"`
+
+	// MaxConcurrentRunners is the maximum number of runner pods of the
+	// namespace at once. The Terraform objects wait for a runner pod to be
+	// deleted before creating theirs.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxConcurrentRunners *int32 `json:"maxConcurrentRunners,omitempty"`
+
+	// MaxAppliesPerHour is the maximum number of applies of the Terraform
+	// objects of the namespace over the last hour. The plans wait for the
+	// budget to free up before they are applied.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxAppliesPerHour *int32 `json:"maxAppliesPerHour,omitempty"`
+}
+
+// TerraformNamespacePolicyStatus defines the observed state of a
+// TerraformNamespacePolicy.
+type TerraformNamespacePolicyStatus struct {
+	// Applies are the times of the applies of the Terraform objects of the
+	// namespace over the last hour, counted against quota.maxAppliesPerHour.
+	// They are recorded by the controller, so that the budget outlives its
+	// restarts.
+	// +optional
+	Applies []metav1.Time `json:"applies,omitempty"`
+}
+
+// ConditionMapping maps the state of a Terraform object to a condition of a
+// type of its own, so that the monitors keyed on it do not depend on the
+// conditions and the reasons set by the controller.
+type ConditionMapping struct {
+	// Type is the type of the condition. It cannot be the type of a
+	// condition set by the controller.
+	// +kubebuilder:validation:Pattern=`^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$`
+	// +kubebuilder:validation:MaxLength=316
+	// +required
+	Type string `json:"type"`
+
+	// When is a CEL expression over the Terraform object, as `object`, its
+	// conditions by type, as `conditions`, and the current time, as `now`.
+	// The condition is True when the expression holds, and False otherwise.
+	// +required
+	When string `json:"when"`
+
+	// Reason is the reason of the condition when it is True. Defaults to
+	// ConditionMet.
+	// +kubebuilder:validation:Pattern=`^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$`
+	// +kubebuilder:validation:MaxLength=1024
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is the message of the condition when it is True.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=tfpolicy
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Service Account",type="string",JSONPath=".spec.serviceAccountName",description=""
+// +kubebuilder:printcolumn:name="Min Interval",type="string",JSONPath=".spec.minInterval",description=""
+// +kubebuilder:printcolumn:name="Disallow Auto Approve",type="boolean",JSONPath=".spec.disallowAutoApprove",description=""
+// +kubebuilder:printcolumn:name="Runtime Class",type="string",JSONPath=".spec.runnerRuntimeClassName",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+
+// TerraformNamespacePolicy is the Schema for the terraformnamespacepolicies API
+type TerraformNamespacePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TerraformNamespacePolicySpec `json:"spec,omitempty"`
+
+	// +optional
+	Status TerraformNamespacePolicyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TerraformNamespacePolicyList contains a list of TerraformNamespacePolicy
+type TerraformNamespacePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TerraformNamespacePolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TerraformNamespacePolicy{}, &TerraformNamespacePolicyList{})
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	TerraformTemplateKind = "TerraformTemplate"
+)
+
+// TerraformTemplateSpec defines a Terraform object, published by the platform
+// team, that the tenants instantiate with TerraformInstances.
+type TerraformTemplateSpec struct {
+	// Description of the template, for the tenants to pick the right one.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Template is the spec of the Terraform objects created for the instances
+	// of this template, in the namespace of each instance. A SourceRef without
+	// a namespace refers to a source in the namespace of the instance.
+	// +required
+	Template TerraformSpec `json:"template"`
+
+	// Variables lists the Terraform variables the instances may set. The
+	// instances may not set any other variable.
+	// +optional
+	Variables []TemplateVariable `json:"variables,omitempty"`
+}
+
+// TemplateVariable is a Terraform variable exposed to the instances of a
+// TerraformTemplate.
+type TemplateVariable struct {
+	// Name is the name of the variable
+	// +required
+	Name string `json:"name"`
+
+	// Description of the variable, for the tenants.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Required makes the instances fail when they do not set the variable.
+	// +optional
+	Required bool `json:"required,omitempty"`
+
+	// Default is the value of the variable when the instance does not set it.
+	// +optional
+	Default *apiextensionsv1.JSON `json:"default,omitempty"`
+
+	// AllowedValues restricts the values an instance can set. Any value is
+	// allowed when empty.
+	// +optional
+	AllowedValues []apiextensionsv1.JSON `json:"allowedValues,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=tftemplate
+// +kubebuilder:printcolumn:name="Description",type="string",JSONPath=".spec.description",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+
+// TerraformTemplate is the Schema for the terraformtemplates API
+type TerraformTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TerraformTemplateSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TerraformTemplateList contains a list of TerraformTemplate
+type TerraformTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TerraformTemplate `json:"items"`
+}
+
+// GetVariable returns the variable of the given name exposed by the template,
+// or nil if the template does not expose it.
+func (in TerraformTemplate) GetVariable(name string) *TemplateVariable {
+	for i := range in.Spec.Variables {
+		if in.Spec.Variables[i].Name == name {
+			return &in.Spec.Variables[i]
+		}
+	}
+	return nil
+}
+
+func init() {
+	SchemeBuilder.Register(&TerraformTemplate{}, &TerraformTemplateList{})
+}
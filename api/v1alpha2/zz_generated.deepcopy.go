@@ -29,6 +29,36 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApprovalEscalation) DeepCopyInto(out *ApprovalEscalation) {
+	*out = *in
+	if in.RemindAfter != nil {
+		in, out := &in.RemindAfter, &out.RemindAfter
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.EscalateAfter != nil {
+		in, out := &in.EscalateAfter, &out.EscalateAfter
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PlanExpiry != nil {
+		in, out := &in.PlanExpiry, &out.PlanExpiry
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalEscalation.
+func (in *ApprovalEscalation) DeepCopy() *ApprovalEscalation {
+	if in == nil {
+		return nil
+	}
+	out := new(ApprovalEscalation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackendConfigSpec) DeepCopyInto(out *BackendConfigSpec) {
 	*out = *in
@@ -39,6 +69,11 @@ func (in *BackendConfigSpec) DeepCopyInto(out *BackendConfigSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.KubeConfig != nil {
+		in, out := &in.KubeConfig, &out.KubeConfig
+		*out = new(meta.SecretKeyReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendConfigSpec.
@@ -71,6 +106,48 @@ func (in *BackendConfigsReference) DeepCopy() *BackendConfigsReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CABundleReference) DeepCopyInto(out *CABundleReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CABundleReference.
+func (in *CABundleReference) DeepCopy() *CABundleReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CABundleReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryApplySpec) DeepCopyInto(out *CanaryApplySpec) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HealthChecks != nil {
+		in, out := &in.HealthChecks, &out.HealthChecks
+		*out = make([]HealthCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryApplySpec.
+func (in *CanaryApplySpec) DeepCopy() *CanaryApplySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryApplySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudSpec) DeepCopyInto(out *CloudSpec) {
 	*out = *in
@@ -111,6 +188,86 @@ func (in *CloudWorkspacesSpec) DeepCopy() *CloudWorkspacesSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConditionMapping) DeepCopyInto(out *ConditionMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionMapping.
+func (in *ConditionMapping) DeepCopy() *ConditionMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(ConditionMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerDefaults) DeepCopyInto(out *ControllerDefaults) {
+	*out = *in
+	if in.RunnerCreationTimeout != nil {
+		in, out := &in.RunnerCreationTimeout, &out.RunnerCreationTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.EventAggregationWindow != nil {
+		in, out := &in.EventAggregationWindow, &out.EventAggregationWindow
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerDefaults.
+func (in *ControllerDefaults) DeepCopy() *ControllerDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialsCheckSpec) DeepCopyInto(out *CredentialsCheckSpec) {
+	*out = *in
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]CredentialsProvider, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExpiryWarningThreshold != nil {
+		in, out := &in.ExpiryWarningThreshold, &out.ExpiryWarningThreshold
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialsCheckSpec.
+func (in *CredentialsCheckSpec) DeepCopy() *CredentialsCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialsCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrossNamespaceRefRule) DeepCopyInto(out *CrossNamespaceRefRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrossNamespaceRefRule.
+func (in *CrossNamespaceRefRule) DeepCopy() *CrossNamespaceRefRule {
+	if in == nil {
+		return nil
+	}
+	out := new(CrossNamespaceRefRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CrossNamespaceSourceReference) DeepCopyInto(out *CrossNamespaceSourceReference) {
 	*out = *in
@@ -126,6 +283,99 @@ func (in *CrossNamespaceSourceReference) DeepCopy() *CrossNamespaceSourceReferen
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftPullRequest) DeepCopyInto(out *DriftPullRequest) {
+	*out = *in
+	in.OpenedAt.DeepCopyInto(&out.OpenedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftPullRequest.
+func (in *DriftPullRequest) DeepCopy() *DriftPullRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftPullRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftRemediation) DeepCopyInto(out *DriftRemediation) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftRemediation.
+func (in *DriftRemediation) DeepCopy() *DriftRemediation {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftRemediation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EgressAudit) DeepCopyInto(out *EgressAudit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressAudit.
+func (in *EgressAudit) DeepCopy() *EgressAudit {
+	if in == nil {
+		return nil
+	}
+	out := new(EgressAudit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ErrorRetry) DeepCopyInto(out *ErrorRetry) {
+	*out = *in
+	out.RetryAfter = in.RetryAfter
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ErrorRetry.
+func (in *ErrorRetry) DeepCopy() *ErrorRetry {
+	if in == nil {
+		return nil
+	}
+	out := new(ErrorRetry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecVarsSource) DeepCopyInto(out *ExecVarsSource) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxOutputSize != nil {
+		in, out := &in.MaxOutputSize, &out.MaxOutputSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecVarsSource.
+func (in *ExecVarsSource) DeepCopy() *ExecVarsSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecVarsSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FileMapping) DeepCopyInto(out *FileMapping) {
 	*out = *in
@@ -142,6 +392,27 @@ func (in *FileMapping) DeepCopy() *FileMapping {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitCredentials) DeepCopyInto(out *GitCredentials) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitCredentials.
+func (in *GitCredentials) DeepCopy() *GitCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(GitCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HealthCheck) DeepCopyInto(out *HealthCheck) {
 	*out = *in
@@ -178,209 +449,1424 @@ func (in *LockStatus) DeepCopy() *LockStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PlanStatus) DeepCopyInto(out *PlanStatus) {
+func (in *ModuleInput) DeepCopyInto(out *ModuleInput) {
 	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(string)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanStatus.
-func (in *PlanStatus) DeepCopy() *PlanStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModuleInput.
+func (in *ModuleInput) DeepCopy() *ModuleInput {
 	if in == nil {
 		return nil
 	}
-	out := new(PlanStatus)
+	out := new(ModuleInput)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReadInputsFromSecretSpec) DeepCopyInto(out *ReadInputsFromSecretSpec) {
+func (in *ModuleOutput) DeepCopyInto(out *ModuleOutput) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadInputsFromSecretSpec.
-func (in *ReadInputsFromSecretSpec) DeepCopy() *ReadInputsFromSecretSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModuleOutput.
+func (in *ModuleOutput) DeepCopy() *ModuleOutput {
 	if in == nil {
 		return nil
 	}
-	out := new(ReadInputsFromSecretSpec)
+	out := new(ModuleOutput)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceInventory) DeepCopyInto(out *ResourceInventory) {
+func (in *ModuleStatus) DeepCopyInto(out *ModuleStatus) {
 	*out = *in
-	if in.Entries != nil {
-		in, out := &in.Entries, &out.Entries
-		*out = make([]ResourceRef, len(*in))
+	if in.Inputs != nil {
+		in, out := &in.Inputs, &out.Inputs
+		*out = make([]ModuleInput, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]ModuleOutput, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceInventory.
-func (in *ResourceInventory) DeepCopy() *ResourceInventory {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModuleStatus.
+func (in *ModuleStatus) DeepCopy() *ModuleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceInventory)
+	out := new(ModuleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceRef) DeepCopyInto(out *ResourceRef) {
+func (in *NamespaceQuota) DeepCopyInto(out *NamespaceQuota) {
 	*out = *in
+	if in.MaxTerraforms != nil {
+		in, out := &in.MaxTerraforms, &out.MaxTerraforms
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxConcurrentRunners != nil {
+		in, out := &in.MaxConcurrentRunners, &out.MaxConcurrentRunners
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxAppliesPerHour != nil {
+		in, out := &in.MaxAppliesPerHour, &out.MaxAppliesPerHour
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRef.
-func (in *ResourceRef) DeepCopy() *ResourceRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceQuota.
+func (in *NamespaceQuota) DeepCopy() *NamespaceQuota {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceRef)
+	out := new(NamespaceQuota)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RunnerPodMetadata) DeepCopyInto(out *RunnerPodMetadata) {
+func (in *OnErrorSpec) DeepCopyInto(out *OnErrorSpec) {
 	*out = *in
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.StateLocked != nil {
+		in, out := &in.StateLocked, &out.StateLocked
+		*out = new(ErrorRetry)
+		**out = **in
 	}
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.QuotaExceeded != nil {
+		in, out := &in.QuotaExceeded, &out.QuotaExceeded
+		*out = new(ErrorRetry)
+		**out = **in
+	}
+	if in.RunnerFailed != nil {
+		in, out := &in.RunnerFailed, &out.RunnerFailed
+		*out = new(ErrorRetry)
+		**out = **in
+	}
+	if in.BackendAccessDenied != nil {
+		in, out := &in.BackendAccessDenied, &out.BackendAccessDenied
+		*out = new(ErrorRetry)
+		**out = **in
+	}
+	if in.DependencyNotReady != nil {
+		in, out := &in.DependencyNotReady, &out.DependencyNotReady
+		*out = new(ErrorRetry)
+		**out = **in
+	}
+	if in.InitFailed != nil {
+		in, out := &in.InitFailed, &out.InitFailed
+		*out = new(ErrorRetry)
+		**out = **in
+	}
+	if in.PlanFailed != nil {
+		in, out := &in.PlanFailed, &out.PlanFailed
+		*out = new(ErrorRetry)
+		**out = **in
+	}
+	if in.ApplyFailed != nil {
+		in, out := &in.ApplyFailed, &out.ApplyFailed
+		*out = new(ErrorRetry)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerPodMetadata.
-func (in *RunnerPodMetadata) DeepCopy() *RunnerPodMetadata {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnErrorSpec.
+func (in *OnErrorSpec) DeepCopy() *OnErrorSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RunnerPodMetadata)
+	out := new(OnErrorSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RunnerPodSpec) DeepCopyInto(out *RunnerPodSpec) {
+func (in *OutputExtraction) DeepCopyInto(out *OutputExtraction) {
 	*out = *in
-	if in.EnvFrom != nil {
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputExtraction.
+func (in *OutputExtraction) DeepCopy() *OutputExtraction {
+	if in == nil {
+		return nil
+	}
+	out := new(OutputExtraction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutputTemplate) DeepCopyInto(out *OutputTemplate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputTemplate.
+func (in *OutputTemplate) DeepCopy() *OutputTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(OutputTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutputsConsumers) DeepCopyInto(out *OutputsConsumers) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputsConsumers.
+func (in *OutputsConsumers) DeepCopy() *OutputsConsumers {
+	if in == nil {
+		return nil
+	}
+	out := new(OutputsConsumers)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingApproval) DeepCopyInto(out *PendingApproval) {
+	*out = *in
+	if in.Since != nil {
+		in, out := &in.Since, &out.Since
+		*out = (*in).DeepCopy()
+	}
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = new(PlanChanges)
+		**out = **in
+	}
+	if in.Approvers != nil {
+		in, out := &in.Approvers, &out.Approvers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingApproval.
+func (in *PendingApproval) DeepCopy() *PendingApproval {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingApproval)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanChanges) DeepCopyInto(out *PlanChanges) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanChanges.
+func (in *PlanChanges) DeepCopy() *PlanChanges {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanChanges)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanStatus) DeepCopyInto(out *PlanStatus) {
+	*out = *in
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = new(PlanChanges)
+		**out = **in
+	}
+	if in.PendingSince != nil {
+		in, out := &in.PendingSince, &out.PendingSince
+		*out = (*in).DeepCopy()
+	}
+	if in.RemindedAt != nil {
+		in, out := &in.RemindedAt, &out.RemindedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.EscalatedAt != nil {
+		in, out := &in.EscalatedAt, &out.EscalatedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanStatus.
+func (in *PlanStatus) DeepCopy() *PlanStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlannerConfig) DeepCopyInto(out *PlannerConfig) {
+	*out = *in
+	if in.PollingInterval != nil {
+		in, out := &in.PollingInterval, &out.PollingInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlannerConfig.
+func (in *PlannerConfig) DeepCopy() *PlannerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PlannerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderLockStatus) DeepCopyInto(out *ProviderLockStatus) {
+	*out = *in
+	in.LastUpgradedAt.DeepCopyInto(&out.LastUpgradedAt)
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderLockStatus.
+func (in *ProviderLockStatus) DeepCopy() *ProviderLockStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderLockStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderUpgrade) DeepCopyInto(out *ProviderUpgrade) {
+	*out = *in
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderUpgrade.
+func (in *ProviderUpgrade) DeepCopy() *ProviderUpgrade {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderUpgrade)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxySpec) DeepCopyInto(out *ProxySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxySpec.
+func (in *ProxySpec) DeepCopy() *ProxySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PullRequestReference) DeepCopyInto(out *PullRequestReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PullRequestReference.
+func (in *PullRequestReference) DeepCopy() *PullRequestReference {
+	if in == nil {
+		return nil
+	}
+	out := new(PullRequestReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuarantineSpec) DeepCopyInto(out *QuarantineSpec) {
+	*out = *in
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuarantineSpec.
+func (in *QuarantineSpec) DeepCopy() *QuarantineSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(QuarantineSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuarantineStatus) DeepCopyInto(out *QuarantineStatus) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+	in.NextRetryAt.DeepCopyInto(&out.NextRetryAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuarantineStatus.
+func (in *QuarantineStatus) DeepCopy() *QuarantineStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(QuarantineStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadInputsFromSecretSpec) DeepCopyInto(out *ReadInputsFromSecretSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadInputsFromSecretSpec.
+func (in *ReadInputsFromSecretSpec) DeepCopy() *ReadInputsFromSecretSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadInputsFromSecretSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RefreshSpec) DeepCopyInto(out *RefreshSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.FullPlanInterval != nil {
+		in, out := &in.FullPlanInterval, &out.FullPlanInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RefreshSpec.
+func (in *RefreshSpec) DeepCopy() *RefreshSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RefreshSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RefreshStatus) DeepCopyInto(out *RefreshStatus) {
+	*out = *in
+	if in.LastRefreshedAt != nil {
+		in, out := &in.LastRefreshedAt, &out.LastRefreshedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.LastFullPlanAt != nil {
+		in, out := &in.LastFullPlanAt, &out.LastFullPlanAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RefreshStatus.
+func (in *RefreshStatus) DeepCopy() *RefreshStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RefreshStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryCredentials) DeepCopyInto(out *RegistryCredentials) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryCredentials.
+func (in *RegistryCredentials) DeepCopy() *RegistryCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryModule) DeepCopyInto(out *RegistryModule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryModule.
+func (in *RegistryModule) DeepCopy() *RegistryModule {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryModule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceInventory) DeepCopyInto(out *ResourceInventory) {
+	*out = *in
+	if in.Entries != nil {
+		in, out := &in.Entries, &out.Entries
+		*out = make([]ResourceRef, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceInventory.
+func (in *ResourceInventory) DeepCopy() *ResourceInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceRef) DeepCopyInto(out *ResourceRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRef.
+func (in *ResourceRef) DeepCopy() *ResourceRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResultsBucketSpec) DeepCopyInto(out *ResultsBucketSpec) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(meta.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResultsBucketSpec.
+func (in *ResultsBucketSpec) DeepCopy() *ResultsBucketSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResultsBucketSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResultsExportSpec) DeepCopyInto(out *ResultsExportSpec) {
+	*out = *in
+	if in.Formats != nil {
+		in, out := &in.Formats, &out.Formats
+		*out = make([]ResultsFormat, len(*in))
+		copy(*out, *in)
+	}
+	if in.Bucket != nil {
+		in, out := &in.Bucket, &out.Bucket
+		*out = new(ResultsBucketSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResultsExportSpec.
+func (in *ResultsExportSpec) DeepCopy() *ResultsExportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResultsExportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RevisionDiff) DeepCopyInto(out *RevisionDiff) {
+	*out = *in
+	if in.Commits != nil {
+		in, out := &in.Commits, &out.Commits
+		*out = make([]RevisionDiffCommit, len(*in))
+		copy(*out, *in)
+	}
+	if in.ChangedFiles != nil {
+		in, out := &in.ChangedFiles, &out.ChangedFiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RevisionDiff.
+func (in *RevisionDiff) DeepCopy() *RevisionDiff {
+	if in == nil {
+		return nil
+	}
+	out := new(RevisionDiff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RevisionDiffCommit) DeepCopyInto(out *RevisionDiffCommit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RevisionDiffCommit.
+func (in *RevisionDiffCommit) DeepCopy() *RevisionDiffCommit {
+	if in == nil {
+		return nil
+	}
+	out := new(RevisionDiffCommit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RevisionDiffSpec) DeepCopyInto(out *RevisionDiffSpec) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RevisionDiffSpec.
+func (in *RevisionDiffSpec) DeepCopy() *RevisionDiffSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RevisionDiffSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerCAConfig) DeepCopyInto(out *RunnerCAConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerCAConfig.
+func (in *RunnerCAConfig) DeepCopy() *RunnerCAConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerCAConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerPodMetadata) DeepCopyInto(out *RunnerPodMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerPodMetadata.
+func (in *RunnerPodMetadata) DeepCopy() *RunnerPodMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerPodMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerPodSpec) DeepCopyInto(out *RunnerPodSpec) {
+	*out = *in
+	if in.EnvFrom != nil {
 		in, out := &in.EnvFrom, &out.EnvFrom
 		*out = make([]corev1.EnvFromSource, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Env != nil {
-		in, out := &in.Env, &out.Env
-		*out = make([]corev1.EnvVar, len(*in))
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]corev1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]corev1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HostAliases != nil {
+		in, out := &in.HostAliases, &out.HostAliases
+		*out = make([]corev1.HostAlias, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxySpec)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TrustedCABundle != nil {
+		in, out := &in.TrustedCABundle, &out.TrustedCABundle
+		*out = new(CABundleReference)
+		**out = **in
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerPodSpec.
+func (in *RunnerPodSpec) DeepCopy() *RunnerPodSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerPodSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerPodTemplate) DeepCopyInto(out *RunnerPodTemplate) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerPodTemplate.
+func (in *RunnerPodTemplate) DeepCopy() *RunnerPodTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerPodTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerResourceUsage) DeepCopyInto(out *RunnerResourceUsage) {
+	*out = *in
+	if in.Stages != nil {
+		in, out := &in.Stages, &out.Stages
+		*out = make([]StageResourceUsage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.ObservedAt.DeepCopyInto(&out.ObservedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerResourceUsage.
+func (in *RunnerResourceUsage) DeepCopy() *RunnerResourceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerResourceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SimulatedProvider) DeepCopyInto(out *SimulatedProvider) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SimulatedProvider.
+func (in *SimulatedProvider) DeepCopy() *SimulatedProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(SimulatedProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SimulationSpec) DeepCopyInto(out *SimulationSpec) {
+	*out = *in
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]SimulatedProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SimulationSpec.
+func (in *SimulationSpec) DeepCopy() *SimulationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SimulationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceLayer) DeepCopyInto(out *SourceLayer) {
+	*out = *in
+	out.SourceRef = in.SourceRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceLayer.
+func (in *SourceLayer) DeepCopy() *SourceLayer {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceLayer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceLayerRevision) DeepCopyInto(out *SourceLayerRevision) {
+	*out = *in
+	out.SourceRef = in.SourceRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceLayerRevision.
+func (in *SourceLayerRevision) DeepCopy() *SourceLayerRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceLayerRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StageCondition) DeepCopyInto(out *StageCondition) {
+	*out = *in
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
+	if in.FinishedAt != nil {
+		in, out := &in.FinishedAt, &out.FinishedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StageCondition.
+func (in *StageCondition) DeepCopy() *StageCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(StageCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StageResourceUsage) DeepCopyInto(out *StageResourceUsage) {
+	*out = *in
+	out.Duration = in.Duration
+	if in.CPUTime != nil {
+		in, out := &in.CPUTime, &out.CPUTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PeakMemory != nil {
+		in, out := &in.PeakMemory, &out.PeakMemory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StageResourceUsage.
+func (in *StageResourceUsage) DeepCopy() *StageResourceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(StageResourceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TFStateSpec) DeepCopyInto(out *TFStateSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TFStateSpec.
+func (in *TFStateSpec) DeepCopy() *TFStateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TFStateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateReference) DeepCopyInto(out *TemplateReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateReference.
+func (in *TemplateReference) DeepCopy() *TemplateReference {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateVariable) DeepCopyInto(out *TemplateVariable) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedValues != nil {
+		in, out := &in.AllowedValues, &out.AllowedValues
+		*out = make([]apiextensionsv1.JSON, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateVariable.
+func (in *TemplateVariable) DeepCopy() *TemplateVariable {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateVariable)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Terraform) DeepCopyInto(out *Terraform) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Terraform.
+func (in *Terraform) DeepCopy() *Terraform {
+	if in == nil {
+		return nil
+	}
+	out := new(Terraform)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Terraform) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformApprovalQueue) DeepCopyInto(out *TerraformApprovalQueue) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformApprovalQueue.
+func (in *TerraformApprovalQueue) DeepCopy() *TerraformApprovalQueue {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformApprovalQueue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TerraformApprovalQueue) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformApprovalQueueList) DeepCopyInto(out *TerraformApprovalQueueList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TerraformApprovalQueue, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformApprovalQueueList.
+func (in *TerraformApprovalQueueList) DeepCopy() *TerraformApprovalQueueList {
+	if in == nil {
+		return nil
 	}
-	if in.Affinity != nil {
-		in, out := &in.Affinity, &out.Affinity
-		*out = new(corev1.Affinity)
+	out := new(TerraformApprovalQueueList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TerraformApprovalQueueList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformApprovalQueueSpec) DeepCopyInto(out *TerraformApprovalQueueSpec) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]corev1.Toleration, len(*in))
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformApprovalQueueSpec.
+func (in *TerraformApprovalQueueSpec) DeepCopy() *TerraformApprovalQueueSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformApprovalQueueSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformApprovalQueueStatus) DeepCopyInto(out *TerraformApprovalQueueStatus) {
+	*out = *in
+	if in.Oldest != nil {
+		in, out := &in.Oldest, &out.Oldest
+		*out = (*in).DeepCopy()
+	}
+	if in.Pending != nil {
+		in, out := &in.Pending, &out.Pending
+		*out = make([]PendingApproval, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.VolumeMounts != nil {
-		in, out := &in.VolumeMounts, &out.VolumeMounts
-		*out = make([]corev1.VolumeMount, len(*in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformApprovalQueueStatus.
+func (in *TerraformApprovalQueueStatus) DeepCopy() *TerraformApprovalQueueStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformApprovalQueueStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformControllerConfig) DeepCopyInto(out *TerraformControllerConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformControllerConfig.
+func (in *TerraformControllerConfig) DeepCopy() *TerraformControllerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformControllerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TerraformControllerConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformControllerConfigList) DeepCopyInto(out *TerraformControllerConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TerraformControllerConfig, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Volumes != nil {
-		in, out := &in.Volumes, &out.Volumes
-		*out = make([]corev1.Volume, len(*in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformControllerConfigList.
+func (in *TerraformControllerConfigList) DeepCopy() *TerraformControllerConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformControllerConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TerraformControllerConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformControllerConfigSpec) DeepCopyInto(out *TerraformControllerConfigSpec) {
+	*out = *in
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Defaults != nil {
+		in, out := &in.Defaults, &out.Defaults
+		*out = new(ControllerDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AllowedCrossNamespaceRefs != nil {
+		in, out := &in.AllowedCrossNamespaceRefs, &out.AllowedCrossNamespaceRefs
+		*out = make([]CrossNamespaceRefRule, len(*in))
+		copy(*out, *in)
+	}
+	if in.Planner != nil {
+		in, out := &in.Planner, &out.Planner
+		*out = new(PlannerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RunnerCA != nil {
+		in, out := &in.RunnerCA, &out.RunnerCA
+		*out = new(RunnerCAConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformControllerConfigSpec.
+func (in *TerraformControllerConfigSpec) DeepCopy() *TerraformControllerConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformControllerConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformControllerConfigStatus) DeepCopyInto(out *TerraformControllerConfigStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.InitContainers != nil {
-		in, out := &in.InitContainers, &out.InitContainers
-		*out = make([]corev1.Container, len(*in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformControllerConfigStatus.
+func (in *TerraformControllerConfigStatus) DeepCopy() *TerraformControllerConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformControllerConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformError) DeepCopyInto(out *TerraformError) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformError.
+func (in *TerraformError) DeepCopy() *TerraformError {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformError)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformInstance) DeepCopyInto(out *TerraformInstance) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformInstance.
+func (in *TerraformInstance) DeepCopy() *TerraformInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TerraformInstance) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformInstanceList) DeepCopyInto(out *TerraformInstanceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TerraformInstance, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.HostAliases != nil {
-		in, out := &in.HostAliases, &out.HostAliases
-		*out = make([]corev1.HostAlias, len(*in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformInstanceList.
+func (in *TerraformInstanceList) DeepCopy() *TerraformInstanceList {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformInstanceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TerraformInstanceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformInstanceSpec) DeepCopyInto(out *TerraformInstanceSpec) {
+	*out = *in
+	out.TemplateRef = in.TemplateRef
+	if in.Vars != nil {
+		in, out := &in.Vars, &out.Vars
+		*out = make([]Variable, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerPodSpec.
-func (in *RunnerPodSpec) DeepCopy() *RunnerPodSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformInstanceSpec.
+func (in *TerraformInstanceSpec) DeepCopy() *TerraformInstanceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RunnerPodSpec)
+	out := new(TerraformInstanceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RunnerPodTemplate) DeepCopyInto(out *RunnerPodTemplate) {
+func (in *TerraformInstanceStatus) DeepCopyInto(out *TerraformInstanceStatus) {
 	*out = *in
-	in.Metadata.DeepCopyInto(&out.Metadata)
-	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerPodTemplate.
-func (in *RunnerPodTemplate) DeepCopy() *RunnerPodTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformInstanceStatus.
+func (in *TerraformInstanceStatus) DeepCopy() *TerraformInstanceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RunnerPodTemplate)
+	out := new(TerraformInstanceStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TFStateSpec) DeepCopyInto(out *TFStateSpec) {
+func (in *TerraformList) DeepCopyInto(out *TerraformList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Terraform, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TFStateSpec.
-func (in *TFStateSpec) DeepCopy() *TFStateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformList.
+func (in *TerraformList) DeepCopy() *TerraformList {
 	if in == nil {
 		return nil
 	}
-	out := new(TFStateSpec)
+	out := new(TerraformList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TerraformList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Terraform) DeepCopyInto(out *Terraform) {
+func (in *TerraformNamespacePolicy) DeepCopyInto(out *TerraformNamespacePolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -388,18 +1874,18 @@ func (in *Terraform) DeepCopyInto(out *Terraform) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Terraform.
-func (in *Terraform) DeepCopy() *Terraform {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformNamespacePolicy.
+func (in *TerraformNamespacePolicy) DeepCopy() *TerraformNamespacePolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(Terraform)
+	out := new(TerraformNamespacePolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Terraform) DeepCopyObject() runtime.Object {
+func (in *TerraformNamespacePolicy) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -407,40 +1893,128 @@ func (in *Terraform) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TerraformList) DeepCopyInto(out *TerraformList) {
+func (in *TerraformNamespacePolicyList) DeepCopyInto(out *TerraformNamespacePolicyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Terraform, len(*in))
+		*out = make([]TerraformNamespacePolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformList.
-func (in *TerraformList) DeepCopy() *TerraformList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformNamespacePolicyList.
+func (in *TerraformNamespacePolicyList) DeepCopy() *TerraformNamespacePolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(TerraformList)
+	out := new(TerraformNamespacePolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TerraformList) DeepCopyObject() runtime.Object {
+func (in *TerraformNamespacePolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformNamespacePolicySpec) DeepCopyInto(out *TerraformNamespacePolicySpec) {
+	*out = *in
+	if in.RunnerResources != nil {
+		in, out := &in.RunnerResources, &out.RunnerResources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MinInterval != nil {
+		in, out := &in.MinInterval, &out.MinInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ApprovalEscalation != nil {
+		in, out := &in.ApprovalEscalation, &out.ApprovalEscalation
+		*out = new(ApprovalEscalation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Quota != nil {
+		in, out := &in.Quota, &out.Quota
+		*out = new(NamespaceQuota)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConditionMappings != nil {
+		in, out := &in.ConditionMappings, &out.ConditionMappings
+		*out = make([]ConditionMapping, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformNamespacePolicySpec.
+func (in *TerraformNamespacePolicySpec) DeepCopy() *TerraformNamespacePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformNamespacePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformNamespacePolicyStatus) DeepCopyInto(out *TerraformNamespacePolicyStatus) {
+	*out = *in
+	if in.Applies != nil {
+		in, out := &in.Applies, &out.Applies
+		*out = make([]v1.Time, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformNamespacePolicyStatus.
+func (in *TerraformNamespacePolicyStatus) DeepCopy() *TerraformNamespacePolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformNamespacePolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformProgress) DeepCopyInto(out *TerraformProgress) {
+	*out = *in
+	if in.CurrentResources != nil {
+		in, out := &in.CurrentResources, &out.CurrentResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.UpdatedAt.DeepCopyInto(&out.UpdatedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformProgress.
+func (in *TerraformProgress) DeepCopy() *TerraformProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TerraformSpec) DeepCopyInto(out *TerraformSpec) {
 	*out = *in
+	if in.ApprovalEscalation != nil {
+		in, out := &in.ApprovalEscalation, &out.ApprovalEscalation
+		*out = new(ApprovalEscalation)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.BackendConfig != nil {
 		in, out := &in.BackendConfig, &out.BackendConfig
 		*out = new(BackendConfigSpec)
@@ -488,7 +2062,17 @@ func (in *TerraformSpec) DeepCopyInto(out *TerraformSpec) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.OnError != nil {
+		in, out := &in.OnError, &out.OnError
+		*out = new(OnErrorSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	out.SourceRef = in.SourceRef
+	if in.SourceLayers != nil {
+		in, out := &in.SourceLayers, &out.SourceLayers
+		*out = make([]SourceLayer, len(*in))
+		copy(*out, *in)
+	}
 	if in.ReadInputsFromSecrets != nil {
 		in, out := &in.ReadInputsFromSecrets, &out.ReadInputsFromSecrets
 		*out = make([]ReadInputsFromSecretSpec, len(*in))
@@ -499,11 +2083,58 @@ func (in *TerraformSpec) DeepCopyInto(out *TerraformSpec) {
 		*out = new(WriteOutputsToSecretSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.WriteOutputsToSecrets != nil {
+		in, out := &in.WriteOutputsToSecrets, &out.WriteOutputsToSecrets
+		*out = make([]WriteOutputsToSecretSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DriftRemediation != nil {
+		in, out := &in.DriftRemediation, &out.DriftRemediation
+		*out = new(DriftRemediation)
+		**out = **in
+	}
+	if in.RevisionDiff != nil {
+		in, out := &in.RevisionDiff, &out.RevisionDiff
+		*out = new(RevisionDiffSpec)
+		**out = **in
+	}
+	if in.ProviderUpgrade != nil {
+		in, out := &in.ProviderUpgrade, &out.ProviderUpgrade
+		*out = new(ProviderUpgrade)
+		**out = **in
+	}
+	if in.Quarantine != nil {
+		in, out := &in.Quarantine, &out.Quarantine
+		*out = new(QuarantineSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CliConfigSecretRef != nil {
 		in, out := &in.CliConfigSecretRef, &out.CliConfigSecretRef
 		*out = new(corev1.SecretReference)
 		**out = **in
 	}
+	if in.GitCredentials != nil {
+		in, out := &in.GitCredentials, &out.GitCredentials
+		*out = new(GitCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RegistryCredentials != nil {
+		in, out := &in.RegistryCredentials, &out.RegistryCredentials
+		*out = make([]RegistryCredentials, len(*in))
+		copy(*out, *in)
+	}
+	if in.RegistryModule != nil {
+		in, out := &in.RegistryModule, &out.RegistryModule
+		*out = new(RegistryModule)
+		**out = **in
+	}
+	if in.EgressAudit != nil {
+		in, out := &in.EgressAudit, &out.EgressAudit
+		*out = new(EgressAudit)
+		**out = **in
+	}
 	if in.HealthChecks != nil {
 		in, out := &in.HealthChecks, &out.HealthChecks
 		*out = make([]HealthCheck, len(*in))
@@ -511,6 +2142,20 @@ func (in *TerraformSpec) DeepCopyInto(out *TerraformSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.CanaryApply != nil {
+		in, out := &in.CanaryApply, &out.CanaryApply
+		*out = new(CanaryApplySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DestroyAt != nil {
+		in, out := &in.DestroyAt, &out.DestroyAt
+		*out = (*in).DeepCopy()
+	}
 	if in.AlwaysCleanupRunnerPod != nil {
 		in, out := &in.AlwaysCleanupRunnerPod, &out.AlwaysCleanupRunnerPod
 		*out = new(bool)
@@ -521,6 +2166,11 @@ func (in *TerraformSpec) DeepCopyInto(out *TerraformSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.Refresh != nil {
+		in, out := &in.Refresh, &out.Refresh
+		*out = new(RefreshSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.RunnerPodTemplate.DeepCopyInto(&out.RunnerPodTemplate)
 	if in.TFState != nil {
 		in, out := &in.TFState, &out.TFState
@@ -532,6 +2182,11 @@ func (in *TerraformSpec) DeepCopyInto(out *TerraformSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReadablePlanDiffs != nil {
+		in, out := &in.ReadablePlanDiffs, &out.ReadablePlanDiffs
+		*out = make([]ReadablePlanDiff, len(*in))
+		copy(*out, *in)
+	}
 	if in.Webhooks != nil {
 		in, out := &in.Webhooks, &out.Webhooks
 		*out = make([]Webhook, len(*in))
@@ -549,6 +2204,21 @@ func (in *TerraformSpec) DeepCopyInto(out *TerraformSpec) {
 		*out = new(apiextensionsv1.JSON)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Simulation != nil {
+		in, out := &in.Simulation, &out.Simulation
+		*out = new(SimulationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CredentialsCheck != nil {
+		in, out := &in.CredentialsCheck, &out.CredentialsCheck
+		*out = new(CredentialsCheckSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResultsExport != nil {
+		in, out := &in.ResultsExport, &out.ResultsExport
+		*out = new(ResultsExportSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformSpec.
@@ -572,6 +2242,11 @@ func (in *TerraformStatus) DeepCopyInto(out *TerraformStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SourceLayerRevisions != nil {
+		in, out := &in.SourceLayerRevisions, &out.SourceLayerRevisions
+		*out = make([]SourceLayerRevision, len(*in))
+		copy(*out, *in)
+	}
 	if in.LastDriftDetectedAt != nil {
 		in, out := &in.LastDriftDetectedAt, &out.LastDriftDetectedAt
 		*out = (*in).DeepCopy()
@@ -580,18 +2255,85 @@ func (in *TerraformStatus) DeepCopyInto(out *TerraformStatus) {
 		in, out := &in.LastAppliedByDriftDetectionAt, &out.LastAppliedByDriftDetectionAt
 		*out = (*in).DeepCopy()
 	}
+	if in.DriftPullRequest != nil {
+		in, out := &in.DriftPullRequest, &out.DriftPullRequest
+		*out = new(DriftPullRequest)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RevisionDiff != nil {
+		in, out := &in.RevisionDiff, &out.RevisionDiff
+		*out = new(RevisionDiff)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PullRequest != nil {
+		in, out := &in.PullRequest, &out.PullRequest
+		*out = new(PullRequestReference)
+		**out = **in
+	}
+	if in.ProviderLock != nil {
+		in, out := &in.ProviderLock, &out.ProviderLock
+		*out = new(ProviderLockStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Refresh != nil {
+		in, out := &in.Refresh, &out.Refresh
+		*out = new(RefreshStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Quarantine != nil {
+		in, out := &in.Quarantine, &out.Quarantine
+		*out = new(QuarantineStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AvailableOutputs != nil {
 		in, out := &in.AvailableOutputs, &out.AvailableOutputs
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	out.Plan = in.Plan
+	in.Plan.DeepCopyInto(&out.Plan)
 	if in.Inventory != nil {
 		in, out := &in.Inventory, &out.Inventory
 		*out = new(ResourceInventory)
 		(*in).DeepCopyInto(*out)
 	}
 	out.Lock = in.Lock
+	if in.VariableValidationErrors != nil {
+		in, out := &in.VariableValidationErrors, &out.VariableValidationErrors
+		*out = make([]VariableValidationError, len(*in))
+		copy(*out, *in)
+	}
+	if in.Errors != nil {
+		in, out := &in.Errors, &out.Errors
+		*out = make([]TerraformError, len(*in))
+		copy(*out, *in)
+	}
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(TerraformProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceUsage != nil {
+		in, out := &in.ResourceUsage, &out.ResourceUsage
+		*out = new(RunnerResourceUsage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Stages != nil {
+		in, out := &in.Stages, &out.Stages
+		*out = make([]StageCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Waiting != nil {
+		in, out := &in.Waiting, &out.Waiting
+		*out = new(WaitingStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Module != nil {
+		in, out := &in.Module, &out.Module
+		*out = new(ModuleStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformStatus.
@@ -604,6 +2346,87 @@ func (in *TerraformStatus) DeepCopy() *TerraformStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformTemplate) DeepCopyInto(out *TerraformTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformTemplate.
+func (in *TerraformTemplate) DeepCopy() *TerraformTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TerraformTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformTemplateList) DeepCopyInto(out *TerraformTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TerraformTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformTemplateList.
+func (in *TerraformTemplateList) DeepCopy() *TerraformTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TerraformTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformTemplateSpec) DeepCopyInto(out *TerraformTemplateSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+	if in.Variables != nil {
+		in, out := &in.Variables, &out.Variables
+		*out = make([]TemplateVariable, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformTemplateSpec.
+func (in *TerraformTemplateSpec) DeepCopy() *TerraformTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Variable) DeepCopyInto(out *Variable) {
 	*out = *in
@@ -629,9 +2452,29 @@ func (in *Variable) DeepCopy() *Variable {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VariableValidationError) DeepCopyInto(out *VariableValidationError) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VariableValidationError.
+func (in *VariableValidationError) DeepCopy() *VariableValidationError {
+	if in == nil {
+		return nil
+	}
+	out := new(VariableValidationError)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VarsReference) DeepCopyInto(out *VarsReference) {
 	*out = *in
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		*out = new(ExecVarsSource)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.VarsKeys != nil {
 		in, out := &in.VarsKeys, &out.VarsKeys
 		*out = make([]string, len(*in))
@@ -649,6 +2492,22 @@ func (in *VarsReference) DeepCopy() *VarsReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WaitingStatus) DeepCopyInto(out *WaitingStatus) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WaitingStatus.
+func (in *WaitingStatus) DeepCopy() *WaitingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WaitingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Webhook) DeepCopyInto(out *Webhook) {
 	*out = *in
@@ -691,6 +2550,21 @@ func (in *WriteOutputsToSecretSpec) DeepCopyInto(out *WriteOutputsToSecretSpec)
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Extract != nil {
+		in, out := &in.Extract, &out.Extract
+		*out = make([]OutputExtraction, len(*in))
+		copy(*out, *in)
+	}
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = make([]OutputTemplate, len(*in))
+		copy(*out, *in)
+	}
+	if in.Consumers != nil {
+		in, out := &in.Consumers, &out.Consumers
+		*out = new(OutputsConsumers)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WriteOutputsToSecretSpec.
@@ -19,7 +19,8 @@ func getClusterClient() (*dynamic.DynamicClient, client.Client, error) {
 		return nil, nil, fmt.Errorf("unable to get dynamic cluster client: %w", err)
 	}
 
-	clusterClient, err := client.New(clusterConfig, client.Options{})
+	// the polling server watches its config with it, to reload it on changes
+	clusterClient, err := client.NewWithWatch(clusterConfig, client.Options{})
 	if err != nil {
 		return nil, nil, fmt.Errorf("unable to get cluster client: %w", err)
 	}
@@ -6,12 +6,18 @@ import (
 
 	"github.com/fluxcd/pkg/runtime/logger"
 	flag "github.com/spf13/pflag"
-	"github.com/weaveworks/tf-controller/internal/server/polling"
+	"github.com/weaveworks/tf-controller/planner"
 )
 
 type applicationOptions struct {
 	pollingConfigMap string
 	pollingInterval  time.Duration
+	controllerConfig string
+	requiredLabel    string
+
+	statusAPIBindAddress string
+
+	fips bool
 
 	logOptions logger.Options
 
@@ -24,13 +30,29 @@ func parseFlags() *applicationOptions {
 	opts := &applicationOptions{}
 
 	flag.StringVar(&opts.pollingConfigMap,
-		"polling-configmap", polling.DefaultConfigMapName,
+		"polling-configmap", planner.DefaultConfigMapName,
 		"Namespace and name of the ConfigMap for the polling service.")
 
 	flag.DurationVar(&opts.pollingInterval,
-		"polling-interval", polling.DefaultPollingInterval,
+		"polling-interval", planner.DefaultPollingInterval,
 		"Wait between two request to the same Terraform object.")
 
+	flag.StringVar(&opts.controllerConfig,
+		"controller-config", "",
+		"Name of the TerraformControllerConfig overriding the polling interval. Empty to use the flag alone.")
+
+	flag.StringVar(&opts.requiredLabel,
+		"required-label", "",
+		"Label the pull requests must carry to be planned, e.g. terraform-plan, unless their Terraform object overrides it with an annotation. Empty to plan all of them.")
+
+	flag.StringVar(&opts.statusAPIBindAddress,
+		"status-api-bind-address", ":9090",
+		"The address the plan status API and the config validation endpoint bind to. Empty to disable them.")
+
+	flag.BoolVar(&opts.fips,
+		"fips", false,
+		"Require the FIPS 140-2 validated cryptography of a GOEXPERIMENT=boringcrypto build.")
+
 	opts.logOptions.BindFlags(flag.CommandLine)
 
 	flag.Parse()
@@ -6,16 +6,23 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/weaveworks/tf-controller/internal/informer/bbp"
+	"github.com/weaveworks/tf-controller/planner"
 	"k8s.io/client-go/dynamic"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func startInformer(ctx context.Context, log logr.Logger, dynamicClient *dynamic.DynamicClient, clusterClient client.Client) error {
+func startInformer(ctx context.Context, log logr.Logger, dynamicClient *dynamic.DynamicClient, clusterClient client.Client, opts *applicationOptions) error {
 	informer, err := bbp.NewInformer(log, dynamicClient, clusterClient)
 	if err != nil {
 		return fmt.Errorf("failed to create informer: %w", err)
 	}
 
+	configMap, err := planner.ParseConfigMapReference(opts.pollingConfigMap)
+	if err != nil {
+		return err
+	}
+	informer.SetConfigMap(configMap)
+
 	if err := informer.Start(ctx); err != nil {
 		return err
 	}
@@ -10,6 +10,7 @@ import (
 	sourcev1b2 "github.com/fluxcd/source-controller/api/v1beta2"
 	"github.com/go-logr/logr"
 	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/internal/fips"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 	cgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -37,6 +38,14 @@ func main() {
 		WithValues("version", BuildVersion, "sha", BuildSHA)
 	logger.SetLogger(log)
 
+	if opts.fips {
+		if err := fips.Enable(); err != nil {
+			log.Error(err, "unable to enable the FIPS mode")
+			os.Exit(1)
+		}
+		log.Info("FIPS mode enabled")
+	}
+
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
 
 	dynamicClusterClient, clusterClient, err := getClusterClient()
@@ -58,7 +67,7 @@ func main() {
 
 	informerLog := log.WithName("informer")
 	informerLog.Info("Starting branch-based planner informer")
-	if err := startInformer(ctx, informerLog, dynamicClusterClient, clusterClient); err != nil {
+	if err := startInformer(ctx, informerLog, dynamicClusterClient, clusterClient, opts); err != nil {
 		informerLog.Error(err, "branch-based planner informer failed")
 	}
 	// once the informer exits, make sure the goroutine above is also
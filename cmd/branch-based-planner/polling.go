@@ -2,27 +2,65 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/go-logr/logr"
-	"github.com/weaveworks/tf-controller/internal/server/polling"
+	"github.com/weaveworks/tf-controller/planner"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func startPollingServer(ctx context.Context, log logr.Logger, clusterClient client.Client, opts *applicationOptions) error {
-	server, err := polling.New(
-		polling.WithLogger(log),
-		polling.WithClusterClient(clusterClient),
-		polling.WithConfigMap(opts.pollingConfigMap),
-		polling.WithPollingInterval(opts.pollingInterval),
+	server, err := planner.New(
+		planner.WithLogger(log),
+		planner.WithClusterClient(clusterClient),
+		planner.WithConfigMap(opts.pollingConfigMap),
+		planner.WithPollingInterval(opts.pollingInterval),
+		planner.WithControllerConfig(opts.controllerConfig),
+		planner.WithRequiredLabel(opts.requiredLabel),
 	)
 	if err != nil {
 		return fmt.Errorf("problem configuring the polling server: %w", err)
 	}
 
+	if opts.statusAPIBindAddress != "" {
+		go startStatusAPI(ctx, log.WithName("status-api"), server, opts.statusAPIBindAddress)
+	}
+
 	if err := server.Start(ctx); err != nil {
 		return fmt.Errorf("problem running the polling server: %w", err)
 	}
 
 	return nil
 }
+
+// startStatusAPI serves the plan status API, the link API, the webhooks of
+// the Git providers and the validation of the config until the context is
+// cancelled.
+func startStatusAPI(ctx context.Context, log logr.Logger, server *planner.Server, addr string) {
+	mux := http.NewServeMux()
+	mux.Handle("/validate", server.ValidateHandler())
+	mux.Handle("/v1/terraform/", server.LinkHandler())
+	mux.Handle("/v1/webhook", server.WebhookHandler())
+	mux.Handle("/", server.StatusHandler())
+
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = httpServer.Shutdown(shutdownCtx)
+	}()
+
+	log.Info("Starting plan status API", "address", addr)
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Error(err, "plan status API failed")
+	}
+}
@@ -17,7 +17,10 @@ limitations under the License.
 package main
 
 import (
+	"crypto/tls"
+	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/weaveworks/tf-controller/mtls"
@@ -35,10 +38,17 @@ import (
 	flag "github.com/spf13/pflag"
 	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
 	"github.com/weaveworks/tf-controller/controllers"
+	"github.com/weaveworks/tf-controller/internal/fips"
+	"github.com/weaveworks/tf-controller/internal/selfcheck"
+	"github.com/weaveworks/tf-controller/internal/server/slack"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -73,25 +83,42 @@ func init() {
 
 func main() {
 	var (
-		metricsAddr              string
-		eventsAddr               string
-		healthAddr               string
-		concurrent               int
-		requeueDependency        time.Duration
-		clientOptions            client.Options
-		logOptions               logger.Options
-		leaderElectionOptions    leaderelection.Options
-		watchAllNamespaces       bool
-		httpRetry                int
-		caValidityDuration       time.Duration
-		certValidityDuration     time.Duration
-		rotationCheckFrequency   time.Duration
-		runnerGRPCPort           int
-		runnerCreationTimeout    time.Duration
-		runnerGRPCMaxMessageSize int
-		allowBreakTheGlass       bool
-		clusterDomain            string
-		aclOptions               acl.Options
+		metricsAddr               string
+		eventsAddr                string
+		healthAddr                string
+		concurrent                int
+		requeueDependency         time.Duration
+		clientOptions             client.Options
+		logOptions                logger.Options
+		leaderElectionOptions     leaderelection.Options
+		watchAllNamespaces        bool
+		httpRetry                 int
+		caValidityDuration        time.Duration
+		certValidityDuration      time.Duration
+		rotationCheckFrequency    time.Duration
+		caTrustPeriod             time.Duration
+		runnerGRPCPort            int
+		runnerCreationTimeout     time.Duration
+		runnerGRPCMaxMessageSize  int
+		allowBreakTheGlass        bool
+		clusterDomain             string
+		aclOptions                acl.Options
+		enableNamespacePolicies   bool
+		enableDryRun              bool
+		webhookPort               int
+		webhookCertDir            string
+		slackSecret               string
+		slackAddr                 string
+		startupReconcileRate      float64
+		runnerRuntimeClassName    string
+		restrictedRunnerPods      bool
+		fipsMode                  bool
+		runnerSourceCacheHostPath string
+		pauseConfigMap            string
+		controllerConfig          string
+		eventVerbosity            string
+		eventAggregationWindow    time.Duration
+		rbacSelfCheck             bool
 	)
 
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
@@ -108,11 +135,44 @@ func main() {
 		"(Deprecated) The duration that the mTLS certificate that the runner pod should be valid for.")
 	flag.DurationVar(&rotationCheckFrequency, "cert-rotation-check-frequency", 30*time.Minute,
 		"The interval that the mTLS certificate rotator should check the certificate validity.")
+	flag.DurationVar(&caTrustPeriod, "ca-trust-period", 2*time.Hour,
+		"How long the previous CA stays trusted once the mTLS certificate rotator replaces it, 0 to trust it until it expires.")
 	flag.IntVar(&runnerGRPCPort, "runner-grpc-port", 30000, "The port which will be exposed on the runner pod for gRPC connections.")
 	flag.DurationVar(&runnerCreationTimeout, "runner-creation-timeout", 120*time.Second, "Timeout for creating a runner pod.")
 	flag.IntVar(&runnerGRPCMaxMessageSize, "runner-grpc-max-message-size", 4, "The maximum message size for gRPC connections in MiB.")
 	flag.BoolVar(&allowBreakTheGlass, "allow-break-the-glass", false, "Allow break the glass mode.")
 	flag.StringVar(&clusterDomain, "cluster-domain", "cluster.local", "The cluster domain used by the cluster.")
+	flag.BoolVar(&enableNamespacePolicies, "enable-namespace-policies", false,
+		"Serve the mutating webhook which applies the TerraformNamespacePolicies to the Terraform objects.")
+	flag.BoolVar(&enableDryRun, "enable-dry-run", false,
+		"Serve the validating webhook which plans the changes of the Terraform objects with the dry run annotation before accepting them.")
+	flag.IntVar(&webhookPort, "webhook-port", 9443, "The port the webhook server binds to.")
+	flag.StringVar(&webhookCertDir, "webhook-cert-dir", "", "The directory of the certificate and key of the webhook server.")
+	flag.StringVar(&slackSecret, "slack-secret", "",
+		"The namespace/name of the Secret of the Slack app to notify of the plans pending approval. Slack is disabled when empty.")
+	flag.StringVar(&slackAddr, "slack-addr", ":9446", "The address the Slack interactions endpoint binds to.")
+	flag.Float64Var(&startupReconcileRate, "startup-reconcile-rate", 10,
+		"The number of Terraform objects per second enqueued by order of priority when the controller starts, 0 for no limit.")
+	flag.StringVar(&runnerRuntimeClassName, "runner-runtime-class-name", "",
+		"The RuntimeClass of the runner pods of the Terraform objects which do not set one, e.g. gvisor to run them in a sandbox.")
+	flag.BoolVar(&restrictedRunnerPods, "restricted-runner-pods", false,
+		"Enforce the restricted Pod Security Standard on all the containers of the runner pods, including the init containers of the Terraform objects.")
+	flag.StringVar(&runnerSourceCacheHostPath, "runner-source-cache-host-path", "",
+		"The directory of the nodes caching the artifacts of the sources for the runner pods, which skip their download when reconciling the same revision again. The artifacts are not cached when empty.")
+	flag.BoolVar(&fipsMode, "fips", false,
+		"Require the FIPS 140-2 validated cryptography of a GOEXPERIMENT=boringcrypto build, for the controller and the runners.")
+	flag.StringVar(&pauseConfigMap, "pause-configmap", "",
+		"The namespace/name of the ConfigMap pausing the applies cluster-wide, except for the objects of its allow-list. The applies are never paused when empty.")
+	flag.StringVar(&controllerConfig, "controller-config", "",
+		"The name of the TerraformControllerConfig overriding the flags of the controller, reloaded when it changes. The flags apply alone when empty.")
+
+	flag.StringVar(&eventVerbosity, "event-verbosity", string(infrav1.EventVerbosityNormal),
+		"The verbosity of the events of the Terraform objects which do not set one: errors-only, normal or debug.")
+	flag.DurationVar(&eventAggregationWindow, "event-aggregation-window", 0,
+		"The time during which the events repeating an event of the same Terraform object are counted rather than recorded, 0 to record them all.")
+
+	flag.BoolVar(&rbacSelfCheck, "rbac-self-check", true,
+		"Verify at startup that the controller is granted the RBAC permissions of its configured features, and exit with the list of the missing ones otherwise. The cross-namespace references are disabled when their permissions are missing.")
 
 	clientOptions.BindFlags(flag.CommandLine)
 	logOptions.BindFlags(flag.CommandLine)
@@ -122,6 +182,31 @@ func main() {
 
 	ctrl.SetLogger(logger.NewLogger(logOptions))
 
+	if fipsMode {
+		if err := fips.Enable(); err != nil {
+			setupLog.Error(err, "unable to enable the FIPS mode")
+			os.Exit(1)
+		}
+		setupLog.Info("FIPS mode enabled")
+	}
+
+	var pauseConfigMapRef types.NamespacedName
+	if pauseConfigMap != "" {
+		parts := strings.SplitN(pauseConfigMap, "/", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			setupLog.Error(fmt.Errorf("expected namespace/name: %q", pauseConfigMap), "invalid pause ConfigMap reference")
+			os.Exit(1)
+		}
+		pauseConfigMapRef = types.NamespacedName{Namespace: parts[0], Name: parts[1]}
+	}
+
+	switch infrav1.EventVerbosity(eventVerbosity) {
+	case infrav1.EventVerbosityErrorsOnly, infrav1.EventVerbosityNormal, infrav1.EventVerbosityDebug:
+	default:
+		setupLog.Error(fmt.Errorf("expected errors-only, normal or debug: %q", eventVerbosity), "invalid event verbosity")
+		os.Exit(1)
+	}
+
 	runtimeNamespace := os.Getenv("RUNTIME_NAMESPACE")
 
 	watchNamespace := ""
@@ -130,6 +215,29 @@ func main() {
 	}
 
 	restConfig := client.GetConfigOrDie(clientOptions)
+
+	if rbacSelfCheck {
+		authorizationClient, err := authorizationv1client.NewForConfig(restConfig)
+		if err != nil {
+			setupLog.Error(err, "unable to create the authorization client")
+			os.Exit(1)
+		}
+		features := selfcheck.Features{
+			WatchNamespace:   watchNamespace,
+			ControllerConfig: controllerConfig != "",
+			PauseConfigMap:   pauseConfigMapRef,
+			SlackSecret:      namespacedNameOf(slackSecret),
+		}
+		if leaderElectionOptions.Enable {
+			features.LeaderElectionNamespace = runtimeNamespace
+		}
+		aclOptions.NoCrossNamespaceRefs, err = checkRBAC(setupLog, authorizationClient.SelfSubjectAccessReviews(), features, aclOptions.NoCrossNamespaceRefs)
+		if err != nil {
+			setupLog.Error(err, "RBAC self-check failed, grant the missing permissions or disable the check with --rbac-self-check=false")
+			os.Exit(1)
+		}
+	}
+
 	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Scheme:                        scheme,
 		MetricsBindAddress:            metricsAddr,
@@ -142,6 +250,13 @@ func main() {
 		LeaderElectionID:              "1953de50.contrib.fluxcd.io",
 		Namespace:                     watchNamespace,
 		Logger:                        ctrl.Log,
+		WebhookServer: webhook.NewServer(webhook.Options{
+			Port:    webhookPort,
+			CertDir: webhookCertDir,
+			TLSOpts: []func(*tls.Config){
+				func(config *tls.Config) { fips.Restrict(config) },
+			},
+		}),
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
@@ -169,6 +284,7 @@ func main() {
 		CAValidityDuration:            caValidityDuration,
 		RotationCheckFrequency:        rotationCheckFrequency,
 		LookaheadInterval:             4 * rotationCheckFrequency, // we do 4 rotation checks ahead
+		TrustPeriod:                   caTrustPeriod,
 		TriggerCARotation:             make(chan mtls.Trigger),
 		TriggerNamespaceTLSGeneration: make(chan mtls.Trigger),
 		ClusterDomain:                 clusterDomain,
@@ -186,25 +302,112 @@ func main() {
 		os.Exit(1)
 	}
 
+	// the controller-runtime client cannot read the logs of the runner pods
+	podLogs, err := corev1client.NewForConfig(restConfig)
+	if err != nil {
+		setupLog.Error(err, "unable to create the pod logs client")
+		os.Exit(1)
+	}
+
 	reconciler := &controllers.TerraformReconciler{
-		Client:                   mgr.GetClient(),
-		Scheme:                   mgr.GetScheme(),
-		EventRecorder:            eventRecorder,
-		Metrics:                  metricsH,
-		StatusPoller:             polling.NewStatusPoller(mgr.GetClient(), mgr.GetRESTMapper(), polling.Options{}),
-		CertRotator:              rotator,
-		RunnerGRPCPort:           runnerGRPCPort,
-		RunnerCreationTimeout:    runnerCreationTimeout,
-		RunnerGRPCMaxMessageSize: runnerGRPCMaxMessageSize,
-		AllowBreakTheGlass:       allowBreakTheGlass,
-		ClusterDomain:            clusterDomain,
-		NoCrossNamespaceRefs:     aclOptions.NoCrossNamespaceRefs,
+		Client:                    mgr.GetClient(),
+		Scheme:                    mgr.GetScheme(),
+		EventRecorder:             eventRecorder,
+		Metrics:                   metricsH,
+		StatusPoller:              polling.NewStatusPoller(mgr.GetClient(), mgr.GetRESTMapper(), polling.Options{}),
+		APIReader:                 mgr.GetAPIReader(),
+		PodLogs:                   podLogs,
+		CertRotator:               rotator,
+		RunnerGRPCPort:            runnerGRPCPort,
+		RunnerCreationTimeout:     runnerCreationTimeout,
+		RunnerGRPCMaxMessageSize:  runnerGRPCMaxMessageSize,
+		AllowBreakTheGlass:        allowBreakTheGlass,
+		ClusterDomain:             clusterDomain,
+		NoCrossNamespaceRefs:      aclOptions.NoCrossNamespaceRefs,
+		StartupReconcileRate:      startupReconcileRate,
+		RunnerRuntimeClassName:    runnerRuntimeClassName,
+		RestrictedRunnerPods:      restrictedRunnerPods,
+		FIPS:                      fipsMode,
+		RunnerSourceCacheHostPath: runnerSourceCacheHostPath,
+		PauseConfigMap:            pauseConfigMapRef,
+		EventVerbosity:            infrav1.EventVerbosity(eventVerbosity),
+		EventAggregationWindow:    eventAggregationWindow,
+	}
+
+	if controllerConfig != "" {
+		reconciler.Config = controllers.NewControllerConfig()
+		if err = (&controllers.TerraformControllerConfigReconciler{
+			Client:      mgr.GetClient(),
+			Scheme:      mgr.GetScheme(),
+			Name:        controllerConfig,
+			Config:      reconciler.Config,
+			CertRotator: rotator,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "TerraformControllerConfig")
+			os.Exit(1)
+		}
+	}
+
+	if slackSecret != "" {
+		slackServer, err := slack.New(
+			slack.WithLogger(ctrl.Log.WithName("slack")),
+			slack.WithClusterClient(mgr.GetClient()),
+			slack.WithEventRecorder(eventRecorder),
+			slack.WithSecret(slackSecret),
+		)
+		if err != nil {
+			setupLog.Error(err, "unable to set up Slack")
+			os.Exit(1)
+		}
+		reconciler.PlanNotifier = slackServer
+		go startSlackInteractions(signalHandlerContext, ctrl.Log.WithName("slack"), slackServer, slackAddr)
 	}
 
 	if err = reconciler.SetupWithManager(mgr, concurrent, httpRetry); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Terraform")
 		os.Exit(1)
 	}
+
+	if err = (&controllers.TerraformInstanceReconciler{
+		Client:        mgr.GetClient(),
+		Scheme:        mgr.GetScheme(),
+		EventRecorder: eventRecorder,
+	}).SetupWithManager(mgr, concurrent); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TerraformInstance")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.TerraformApprovalQueueReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TerraformApprovalQueue")
+		os.Exit(1)
+	}
+
+	if enableNamespacePolicies {
+		if err = (&controllers.NamespacePolicyDefaulter{
+			Client: mgr.GetClient(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "TerraformNamespacePolicy")
+			os.Exit(1)
+		}
+	}
+	if enableDryRun {
+		if err = (&controllers.DryRunValidator{
+			Client: mgr.GetClient(),
+			Scheme: mgr.GetScheme(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DryRun")
+			os.Exit(1)
+		}
+	}
+	if enableNamespacePolicies || enableDryRun {
+		if err := mgr.AddReadyzCheck("webhook", mgr.GetWebhookServer().StartedChecker()); err != nil {
+			setupLog.Error(err, "unable to set up webhook ready check")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if os.Getenv("INSECURE_LOCAL_RUNNER") == "1" {
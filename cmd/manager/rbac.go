@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/weaveworks/tf-controller/internal/selfcheck"
+	"k8s.io/apimachinery/pkg/types"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+// checkRBAC verifies that the controller is granted the permissions of its
+// configured features, and returns whether the cross-namespace references
+// are disabled: when the flag disables them, or when the controller lacks
+// their permissions.
+func checkRBAC(log logr.Logger, reviews authorizationv1client.SelfSubjectAccessReviewInterface, features selfcheck.Features, noCrossNamespaceRefs bool) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if err := selfcheck.Verify(ctx, reviews, selfcheck.Required(features)); err != nil {
+		return noCrossNamespaceRefs, err
+	}
+	if noCrossNamespaceRefs {
+		return true, nil
+	}
+
+	missing, err := selfcheck.Missing(ctx, reviews, selfcheck.CrossNamespaceRefs())
+	if err != nil {
+		return noCrossNamespaceRefs, err
+	}
+	if len(missing) > 0 {
+		log.Info("Disabling the cross-namespace references, the controller lacks their permissions",
+			"missing", (&selfcheck.MissingPermissionsError{Permissions: missing}).Error())
+		return true, nil
+	}
+	return false, nil
+}
+
+// namespacedNameOf parses a namespace/name flag, or returns an empty name
+// when it is not one.
+func namespacedNameOf(value string) types.NamespacedName {
+	namespace, name, ok := strings.Cut(value, "/")
+	if !ok {
+		return types.NamespacedName{}
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}
+}
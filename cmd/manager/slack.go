@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/weaveworks/tf-controller/internal/server/slack"
+)
+
+// startSlackInteractions serves the interactions of the Slack app until the
+// context is cancelled.
+func startSlackInteractions(ctx context.Context, log logr.Logger, server *slack.Server, addr string) {
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           server.InteractionsHandler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = httpServer.Shutdown(shutdownCtx)
+	}()
+
+	log.Info("Starting Slack interactions server", "address", addr)
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Error(err, "Slack interactions server failed")
+	}
+}
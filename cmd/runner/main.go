@@ -25,6 +25,7 @@ import (
 
 	"github.com/fluxcd/pkg/runtime/logger"
 	flag "github.com/spf13/pflag"
+	"github.com/weaveworks/tf-controller/internal/fips"
 	"github.com/weaveworks/tf-controller/mtls"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
@@ -58,11 +59,15 @@ func main() {
 		grpcPort           int
 		tlsSecretName      string
 		grpcMaxMessageSize int
+		fipsMode           bool
+		sourceCacheDir     string
 	)
 
 	flag.IntVar(&grpcPort, "grpc-port", 30000, "The port on which to expose the grpc endpoint.")
 	flag.StringVar(&tlsSecretName, "tls-secret-name", "", "The TLS secret name.")
 	flag.IntVar(&grpcMaxMessageSize, "grpc-max-message-size", 4, "The maximum size of gRPC messages in MiB.")
+	flag.BoolVar(&fipsMode, "fips", false, "Require the FIPS 140-2 validated cryptography of a GOEXPERIMENT=boringcrypto build.")
+	flag.StringVar(&sourceCacheDir, "source-cache-dir", "", "The directory of the source cache of the node. The artifacts are not cached when empty.")
 	flag.Parse()
 
 	addr := fmt.Sprintf(":%d", grpcPort)
@@ -85,9 +90,15 @@ func main() {
 		signal.Stop(sigterm)
 	}()
 
-	log.Println("Starting the runner...", "version", BuildVersion, "sha", BuildSHA)
+	if fipsMode {
+		if err := fips.Enable(); err != nil {
+			log.Fatal(err.Error())
+		}
+	}
+
+	log.Println("Starting the runner...", "version", BuildVersion, "sha", BuildSHA, "fips", fips.Enabled())
 
-	err := mtls.RunnerServe(podNamespace, addr, tlsSecretName, sigterm, grpcMaxMessageSize)
+	err := mtls.RunnerServe(podNamespace, addr, tlsSecretName, sigterm, grpcMaxMessageSize, sourceCacheDir)
 	if err != nil {
 		log.Fatal(err.Error())
 	}
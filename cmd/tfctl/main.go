@@ -3,6 +3,7 @@ package main
 import (
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -53,15 +54,23 @@ func newRootCommand() *cobra.Command {
 	// bind flags to config
 	config.BindPFlags(rootCmd.PersistentFlags())
 
+	rootCmd.AddCommand(buildAuditPlanCmd(app))
 	rootCmd.AddCommand(buildCreateCmd(app))
 	rootCmd.AddCommand(buildDeleteCmd(app))
+	rootCmd.AddCommand(buildDescribeModuleCmd(app))
+	rootCmd.AddCommand(buildDoctorCmd(app))
+	rootCmd.AddCommand(buildExportCmd(app))
 	rootCmd.AddCommand(buildForceUnlockCmd(app))
 	rootCmd.AddCommand(buildInstallCmd(app))
+	rootCmd.AddCommand(buildPlanCmd(app))
+	rootCmd.AddCommand(buildProgressCmd(app))
 	rootCmd.AddCommand(buildReconcileCmd(app))
 	rootCmd.AddCommand(buildApprovePlanCmd(app))
 	rootCmd.AddCommand(buildReplanCmd(app))
 	rootCmd.AddCommand(buildResumeCmd(app))
+	rootCmd.AddCommand(buildRollbackCmd(app))
 	rootCmd.AddCommand(buildSuspendCmd(app))
+	rootCmd.AddCommand(buildUICmd(app))
 	rootCmd.AddCommand(buildUninstallCmd(app))
 	rootCmd.AddCommand(buildVersionCmd(app))
 
@@ -129,18 +138,24 @@ func buildUninstallCmd(app *tfctl.CLI) *cobra.Command {
 var reconcileExamples = `
   # Reconcile a Terraform resource
   tfctl reconcile --namespace=default my-resource
+
+  # Reconcile the source of a Terraform resource first, then the resource itself
+  tfctl reconcile --namespace=default my-resource --with-source
 `
 
 func buildReconcileCmd(app *tfctl.CLI) *cobra.Command {
-	return &cobra.Command{
+	reconcile := &cobra.Command{
 		Use:     "reconcile NAME",
 		Short:   "Trigger a reconcile of the provided resource",
 		Example: strings.Trim(reconcileExamples, "\n"),
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return app.Reconcile(os.Stdout, args[0])
+			return app.Reconcile(os.Stdout, args[0], viper.GetBool("with-source"))
 		},
 	}
+	reconcile.Flags().Bool("with-source", false, "Reconcile the source of the resource and wait for it before reconciling the resource.")
+	viper.BindPFlags(reconcile.Flags())
+	return reconcile
 }
 
 var suspendExamples = `
@@ -263,18 +278,24 @@ func buildGetTerraformCmd(app *tfctl.CLI) *cobra.Command {
 var deleteExamples = `
   # Delete a Terraform resource
   tfctl delete my-resource
+
+  # Delete a Terraform resource the controller cannot finalize, keeping its resources and state
+  tfctl delete my-resource --force --keep-state
 `
 
 func buildDeleteCmd(app *tfctl.CLI) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "delete NAME",
 		Short:   "Delete a Terraform resource",
-		Example: strings.Trim(getTerraformExamples, "\n"),
+		Example: strings.Trim(deleteExamples, "\n"),
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return app.DeleteTerraform(os.Stdout, args[0])
+			return app.DeleteTerraform(os.Stdout, args[0], viper.GetBool("force"), viper.GetBool("keep-state"))
 		},
 	}
+	cmd.Flags().Bool("force", false, "Remove the finalizer of the resource, skipping its deletion policy. Requires --keep-state.")
+	cmd.Flags().Bool("keep-state", false, "Acknowledge that the Terraform resources and state are kept when forcing the deletion.")
+	viper.BindPFlags(cmd.Flags())
 	return cmd
 }
 
@@ -352,6 +373,201 @@ func buildReplanCmd(app *tfctl.CLI) *cobra.Command {
 	return replan
 }
 
+var planExamples = `
+  # Plan the feature/x branch of the GitRepository of a Terraform resource, and show the plan
+  tfctl plan my-resource --branch feature/x
+
+  # Plan a branch for a week, without waiting for the plan
+  tfctl plan my-resource --branch feature/x --expiry 168h --wait 0
+`
+
+func buildPlanCmd(app *tfctl.CLI) *cobra.Command {
+	plan := &cobra.Command{
+		Use:     "plan NAME",
+		Short:   "Plan a branch of the GitRepository of a Terraform resource ad hoc, before opening a pull request",
+		Example: strings.Trim(planExamples, "\n"),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.PlanBranch(os.Stdout, args[0], viper.GetString("branch"), viper.GetDuration("expiry"), viper.GetDuration("wait"))
+		},
+	}
+	plan.Flags().String("branch", "", "Branch of the GitRepository to plan")
+	plan.Flags().Duration("expiry", 24*time.Hour, "How long to keep the plan of the branch, before it is deleted")
+	plan.Flags().Duration("wait", 5*time.Minute, "How long to wait for the plan to show it, 0 not to wait")
+	plan.MarkFlagRequired("branch")
+	viper.BindPFlags(plan.Flags())
+	return plan
+}
+
+var rollbackExamples = `
+  # Roll back a Terraform resource to a commit of its GitRepository
+  tfctl rollback my-resource --to-revision b8e362c206e3d0cbb7ed22ced771a0056455a2fb
+
+  # Roll back a Terraform resource to a tag of its GitRepository
+  tfctl rollback my-resource --to-revision v1.2.0
+`
+
+func buildRollbackCmd(app *tfctl.CLI) *cobra.Command {
+	rollback := &cobra.Command{
+		Use:     "rollback NAME",
+		Short:   "Roll back a Terraform resource to a previous revision, and show the plan to approve",
+		Example: strings.Trim(rollbackExamples, "\n"),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.Rollback(os.Stdout, args[0], viper.GetString("to-revision"))
+		},
+	}
+	rollback.Flags().String("to-revision", "", "Commit SHA, tag or Git reference to roll back to, e.g. the lastAppliedRevision of a previous apply")
+	rollback.MarkFlagRequired("to-revision")
+	viper.BindPFlags(rollback.Flags())
+	return rollback
+}
+
+var exportExamples = `
+  # Print the manifest of a Terraform resource
+  tfctl export my-resource
+
+  # Write a support bundle of a Terraform resource to my-bundle.tar.gz
+  tfctl export my-resource --bundle --output my-bundle.tar.gz
+`
+
+func buildExportCmd(app *tfctl.CLI) *cobra.Command {
+	export := &cobra.Command{
+		Use:     "export NAME",
+		Short:   "Export a Terraform resource, or a support bundle of it",
+		Example: strings.Trim(exportExamples, "\n"),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.Export(os.Stdout, args[0], viper.GetBool("bundle"), viper.GetString("output"))
+		},
+	}
+	export.Flags().Bool("bundle", false, "Write a support bundle with the resource, its source, conditions, events, plan and runner pod, without the values of its Secrets")
+	export.Flags().StringP("output", "o", "", "File of the support bundle, - for stdout (default <namespace>-<name>-bundle.tar.gz)")
+	viper.BindPFlags(export.Flags())
+	return export
+}
+
+var progressExamples = `
+  # Print the progress of the plan or apply of a Terraform resource
+  tfctl progress my-resource
+
+  # Print the progress of a Terraform resource until its plan or apply is over
+  tfctl progress my-resource --watch
+`
+
+func buildProgressCmd(app *tfctl.CLI) *cobra.Command {
+	progress := &cobra.Command{
+		Use:     "progress NAME",
+		Short:   "Show the progress of the plan or apply of a Terraform resource",
+		Example: strings.Trim(progressExamples, "\n"),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.Progress(os.Stdout, args[0], viper.GetBool("watch"))
+		},
+	}
+	progress.Flags().BoolP("watch", "w", false, "Watch the progress until the plan or apply is over")
+	viper.BindPFlags(progress.Flags())
+	return progress
+}
+
+var describeModuleExamples = `
+  # Show the description, the inputs and the outputs of the module of a Terraform resource
+  tfctl describe-module my-resource
+`
+
+func buildDescribeModuleCmd(app *tfctl.CLI) *cobra.Command {
+	return &cobra.Command{
+		Use:     "describe-module NAME",
+		Short:   "Show the description, the inputs and the outputs of the module of a Terraform resource",
+		Example: strings.Trim(describeModuleExamples, "\n"),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.DescribeModule(os.Stdout, args[0])
+		},
+	}
+}
+
+var auditPlanExamples = `
+  # Plan all the Terraform resources of the namespace and report their drift and pending changes
+  tfctl audit-plan --all
+
+  # Audit the production Terraform resources of all the namespaces, and write the report to a ConfigMap
+  tfctl audit-plan --all --all-namespaces --selector env=prod --report-configmap weekly-audit
+
+  # Report the last plans of the Terraform resources, without planning them again
+  tfctl audit-plan --all --refresh=false
+`
+
+func buildAuditPlanCmd(app *tfctl.CLI) *cobra.Command {
+	auditPlan := &cobra.Command{
+		Use:     "audit-plan [NAME...]",
+		Short:   "Plan Terraform resources off-schedule, without applying them, and report their drift and pending changes",
+		Example: strings.Trim(auditPlanExamples, "\n"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.AuditPlan(os.Stdout, tfctl.AuditPlanOptions{
+				Names:           args,
+				All:             viper.GetBool("all"),
+				AllNamespaces:   viper.GetBool("all-namespaces"),
+				Selector:        viper.GetString("selector"),
+				Refresh:         viper.GetBool("refresh"),
+				Timeout:         viper.GetDuration("timeout"),
+				ReportConfigMap: viper.GetString("report-configmap"),
+			})
+		},
+	}
+	auditPlan.Flags().Bool("all", false, "Audit all the Terraform resources of the namespace")
+	auditPlan.Flags().BoolP("all-namespaces", "A", false, "With --all, audit the Terraform resources of all the namespaces")
+	auditPlan.Flags().StringP("selector", "l", "", "With --all, audit the Terraform resources matching the label selector")
+	auditPlan.Flags().Bool("refresh", true, "Plan the resources again, except those the controller would apply, rather than reporting their last plan")
+	auditPlan.Flags().Duration("timeout", 30*time.Minute, "How long to wait for the plans to be over")
+	auditPlan.Flags().String("report-configmap", "", "Name of the ConfigMap of the namespace to write the report to")
+	viper.BindPFlags(auditPlan.Flags())
+	return auditPlan
+}
+
+var uiExamples = `
+  # Show the Terraform resources of the flux-system namespace
+  tfctl ui
+
+  # Show the Terraform resources of all the namespaces
+  tfctl ui --all-namespaces
+`
+
+func buildUICmd(app *tfctl.CLI) *cobra.Command {
+	ui := &cobra.Command{
+		Use:     "ui",
+		Short:   "Show a dashboard of the Terraform resources, to view, approve or replan their plans, and suspend or resume them",
+		Example: strings.Trim(uiExamples, "\n"),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.UI(os.Stdin, os.Stdout, viper.GetBool("all-namespaces"))
+		},
+	}
+	ui.Flags().BoolP("all-namespaces", "A", false, "Show the Terraform resources of all the namespaces")
+	viper.BindPFlags(ui.Flags())
+	return ui
+}
+
+var doctorExamples = `
+  # Check the installation of tf-controller in the flux-system namespace
+  tfctl doctor
+
+  # Check the installation of tf-controller in another namespace
+  tfctl doctor --namespace tf-system
+`
+
+func buildDoctorCmd(app *tfctl.CLI) *cobra.Command {
+	return &cobra.Command{
+		Use:     "doctor",
+		Short:   "Check the installation of tf-controller for misconfigurations",
+		Example: strings.Trim(doctorExamples, "\n"),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.Doctor(os.Stdout)
+		},
+	}
+}
+
 func buildBreakTheGlassCmd(app *tfctl.CLI) *cobra.Command {
 	breakTheGlass := &cobra.Command{
 		Use:     "break-glass",
@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+type fakePlanNotifier struct {
+	reminders []string
+}
+
+func (n *fakePlanNotifier) NotifyPlanPending(ctx context.Context, terraform infrav1.Terraform, planID string) error {
+	return nil
+}
+
+func (n *fakePlanNotifier) RemindPlanPending(ctx context.Context, terraform infrav1.Terraform, planID string, pendingFor time.Duration, escalationChannel string) error {
+	n.reminders = append(n.reminders, escalationChannel)
+	return nil
+}
+
+func TestEscalatePendingPlan(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	now := time.Now().Truncate(time.Second)
+	terraform := pendingTerraform("prod", "network", "plan-main-abc", now)
+	terraform.Spec.Interval = metav1.Duration{Duration: 10 * time.Minute}
+	policy := &infrav1.TerraformNamespacePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "approvals", Namespace: "prod"},
+		Spec: infrav1.TerraformNamespacePolicySpec{
+			ApprovalEscalation: &infrav1.ApprovalEscalation{
+				RemindAfter:       &metav1.Duration{Duration: 4 * time.Hour},
+				EscalateAfter:     &metav1.Duration{Duration: 24 * time.Hour},
+				EscalationChannel: "C999",
+				PlanExpiry:        &metav1.Duration{Duration: 72 * time.Hour},
+			},
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithStatusSubresource(&infrav1.Terraform{}).
+		WithObjects(terraform, policy).
+		Build()
+	recorder := record.NewFakeRecorder(10)
+	notifier := &fakePlanNotifier{}
+	r := &TerraformReconciler{Client: c, APIReader: c, EventRecorder: recorder, PlanNotifier: notifier}
+
+	// nothing is due yet: requeue at the first reminder
+	discarded, result, err := r.escalatePendingPlan(ctx, terraform, "main@sha1:abc", now.Add(time.Hour))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(discarded).To(BeFalse())
+	g.Expect(result.RequeueAfter).To(Equal(3 * time.Hour))
+	g.Expect(notifier.reminders).To(BeEmpty())
+
+	// the reminder, then the next one
+	_, result, err = r.escalatePendingPlan(ctx, terraform, "main@sha1:abc", now.Add(5*time.Hour))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(4 * time.Hour))
+	g.Expect(notifier.reminders).To(Equal([]string{""}))
+	g.Expect(recorder.Events).To(Receive(HavePrefix("Normal PlanApprovalReminder Plan plan-main-abc has been pending approval for 5h0m0s")))
+	g.Expect(terraform.Status.Plan.RemindedAt.Time).To(BeTemporally("==", now.Add(5*time.Hour)))
+
+	// the escalation, once
+	_, _, err = r.escalatePendingPlan(ctx, terraform, "main@sha1:abc", now.Add(25*time.Hour))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(notifier.reminders).To(Equal([]string{"", "C999"}))
+	g.Expect(recorder.Events).To(Receive(HavePrefix("Warning PlanApprovalEscalated Plan plan-main-abc has been pending approval for 25h0m0s")))
+	_, _, err = r.escalatePendingPlan(ctx, terraform, "main@sha1:abc", now.Add(30*time.Hour))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(notifier.reminders).To(Equal([]string{"", "C999", ""}))
+
+	var stored infrav1.Terraform
+	g.Expect(c.Get(ctx, types.NamespacedName{Namespace: "prod", Name: "network"}, &stored)).To(Succeed())
+	g.Expect(stored.Status.Plan.EscalatedAt).ToNot(BeNil())
+
+	// the expiry discards the plan
+	discarded, result, err = r.escalatePendingPlan(ctx, terraform, "main@sha1:abc", now.Add(72*time.Hour))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(discarded).To(BeTrue())
+	g.Expect(result.RequeueAfter).To(Equal(10 * time.Minute))
+	g.Expect(c.Get(ctx, types.NamespacedName{Namespace: "prod", Name: "network"}, &stored)).To(Succeed())
+	g.Expect(stored.Status.Plan.Pending).To(BeEmpty())
+	g.Expect(stored.Status.Plan.EscalatedAt).To(BeNil())
+
+	// the escalation of the object overrides the one of the policy
+	terraform = pendingTerraform("prod", "network", "plan-main-def", now)
+	terraform.Spec.ApprovalEscalation = &infrav1.ApprovalEscalation{}
+	_, result, err = r.escalatePendingPlan(ctx, terraform, "main@sha1:def", now.Add(100*time.Hour))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(BeZero())
+}
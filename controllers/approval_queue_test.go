@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func pendingTerraform(namespace, name, plan string, since time.Time) *infrav1.Terraform {
+	pendingSince := metav1.NewTime(since)
+	return &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Status: infrav1.TerraformStatus{
+			LastPlannedRevision: "main@sha1:abc",
+			Plan: infrav1.PlanStatus{
+				Pending:      plan,
+				PendingSince: &pendingSince,
+				Changes:      &infrav1.PlanChanges{Add: 3, Change: 1},
+			},
+		},
+	}
+}
+
+func TestApprovalQueue(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	now := time.Now().Truncate(time.Second)
+	older := pendingTerraform("prod", "network", "plan-main-abc", now.Add(-time.Hour))
+	older.Annotations = map[string]string{infrav1.ApproversAnnotation: "alice@example.com, platform-team"}
+	newer := pendingTerraform("dev", "network", "plan-main-def", now)
+	approved := pendingTerraform("prod", "database", "plan-main-abc", now)
+	approved.Spec.ApprovePlan = "plan-main"
+	auto := pendingTerraform("prod", "cache", "plan-main-abc", now)
+	auto.Spec.ApprovePlan = infrav1.ApprovePlanAutoValue
+	sandbox := pendingTerraform("sandbox", "network", "plan-main-abc", now)
+
+	queue := &infrav1.TerraformApprovalQueue{
+		ObjectMeta: metav1.ObjectMeta{Name: "all"},
+		Spec: infrav1.TerraformApprovalQueueSpec{
+			NamespaceSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+				Key:      "environment",
+				Operator: metav1.LabelSelectorOpExists,
+			}}},
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithStatusSubresource(&infrav1.TerraformApprovalQueue{}).
+		WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod", Labels: map[string]string{"environment": "prod"}}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev", Labels: map[string]string{"environment": "dev"}}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "sandbox"}},
+			older, newer, approved, auto, sandbox, queue,
+		).Build()
+	r := &TerraformApprovalQueueReconciler{Client: c, Scheme: scheme}
+
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "all"}})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(c.Get(ctx, types.NamespacedName{Name: "all"}, queue)).To(Succeed())
+	g.Expect(queue.Status.Count).To(Equal(int32(2)))
+	g.Expect(queue.Status.Oldest.Time).To(BeTemporally("==", now.Add(-time.Hour)))
+	g.Expect(queue.Status.Pending).To(HaveLen(2))
+	g.Expect(queue.Status.Pending[0].Namespace).To(Equal("prod"))
+	g.Expect(queue.Status.Pending[0].Plan).To(Equal("plan-main-abc"))
+	g.Expect(queue.Status.Pending[0].Revision).To(Equal("main@sha1:abc"))
+	g.Expect(queue.Status.Pending[0].Summary).To(Equal("Plan: 3 to add, 1 to change, 0 to destroy."))
+	g.Expect(queue.Status.Pending[0].Approvers).To(Equal([]string{"alice@example.com", "platform-team"}))
+	g.Expect(queue.Status.Pending[1].Namespace).To(Equal("dev"))
+
+	// the approved plan leaves the queue
+	older.Spec.ApprovePlan = "plan-main-abc"
+	g.Expect(c.Update(ctx, older)).To(Succeed())
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "all"}})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(c.Get(ctx, types.NamespacedName{Name: "all"}, queue)).To(Succeed())
+	g.Expect(queue.Status.Count).To(Equal(int32(1)))
+	g.Expect(queue.Status.Pending[0].Namespace).To(Equal("dev"))
+}
@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestStateSecretNamespace(t *testing.T) {
+	g := NewWithT(t)
+
+	terraform := infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "team-a"}}
+	g.Expect(stateSecretNamespace(terraform)).To(Equal("team-a"))
+
+	terraform.Spec.BackendConfig = &infrav1.BackendConfigSpec{SecretSuffix: "helloworld", InClusterConfig: true, SecretNamespace: "tf-state"}
+	g.Expect(stateSecretNamespace(terraform)).To(Equal("tf-state"))
+	g.Expect(backendConfigPath(terraform)).To(BeEmpty())
+
+	terraform.Spec.BackendConfig.KubeConfig = &meta.SecretKeyReference{Name: "state-cluster"}
+	g.Expect(backendConfigPath(terraform)).To(Equal("/etc/tf-runner/backend/kubeconfig"))
+	g.Expect(backendKubeConfigVolume(*terraform.Spec.BackendConfig.KubeConfig).Secret.Items).To(Equal([]corev1.KeyToPath{{Key: "value", Path: "kubeconfig"}}))
+}
+
+func TestCheckBackendAccess(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(authorizationv1.AddToScheme(scheme)).To(Succeed())
+
+	var reviews []authorizationv1.ResourceAttributes
+	r := &TerraformReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				review := obj.(*authorizationv1.SubjectAccessReview)
+				reviews = append(reviews, *review.Spec.ResourceAttributes)
+				// the runner of team-a may write secrets in tf-state, but not leases
+				review.Status.Allowed = review.Spec.User == "system:serviceaccount:team-a:tf-runner" &&
+					review.Spec.ResourceAttributes.Namespace == "tf-state" &&
+					review.Spec.ResourceAttributes.Resource == "secrets"
+				return nil
+			},
+		}).Build(),
+	}
+
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "team-a"},
+		Spec: infrav1.TerraformSpec{
+			BackendConfig: &infrav1.BackendConfigSpec{SecretSuffix: "helloworld", InClusterConfig: true},
+		},
+	}
+
+	// the state in the namespace of the object needs no check
+	g.Expect(r.checkBackendAccess(context.TODO(), terraform)).To(Succeed())
+	g.Expect(reviews).To(BeEmpty())
+
+	terraform.Spec.BackendConfig.SecretNamespace = "tf-state"
+	g.Expect(r.checkBackendAccess(context.TODO(), terraform)).To(MatchError(
+		"service account system:serviceaccount:team-a:tf-runner cannot create leases in namespace tf-state to keep the state"))
+	g.Expect(reviews).To(HaveLen(3))
+
+	// the access to another cluster is up to its kubeconfig
+	reviews = nil
+	terraform.Spec.BackendConfig.KubeConfig = &meta.SecretKeyReference{Name: "state-cluster"}
+	g.Expect(r.checkBackendAccess(context.TODO(), terraform)).To(Succeed())
+	g.Expect(reviews).To(BeEmpty())
+
+	// cross-namespace references can be disabled
+	r.NoCrossNamespaceRefs = true
+	terraform.Spec.BackendConfig.KubeConfig = nil
+	g.Expect(r.checkBackendAccess(context.TODO(), terraform)).To(MatchError(ContainSubstring("cross-namespace references have been disabled")))
+	g.Expect(reviews).To(BeEmpty())
+}
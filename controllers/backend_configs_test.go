@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestValidateBackendConfigs(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+	r := &TerraformReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "backend-creds", Namespace: "flux-system"},
+				Data:       map[string][]byte{"access_key": []byte("AKIA"), "secret_key": []byte("s3cr3t")},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "backend-config", Namespace: "flux-system"},
+				Data:       map[string]string{"bucket": "tf-state"},
+				BinaryData: map[string][]byte{"region": []byte("eu-west-1")},
+			},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "empty", Namespace: "flux-system"}},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "not-backend", Namespace: "flux-system"},
+				Data:       map[string]string{"backend.tf": "terraform {}"},
+			},
+		).Build(),
+	}
+
+	validate := func(refs ...infrav1.BackendConfigsReference) error {
+		return r.validateBackendConfigs(context.TODO(), infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+			Spec:       infrav1.TerraformSpec{BackendConfigsFrom: refs},
+		})
+	}
+	expectInvalid := func(err error, message string) {
+		var invalid *backendConfigError
+		g.Expect(errors.As(err, &invalid)).To(BeTrue())
+		g.Expect(err.Error()).To(Equal(message))
+	}
+
+	g.Expect(validate()).To(Succeed())
+	g.Expect(validate(
+		infrav1.BackendConfigsReference{Kind: "Secret", Name: "backend-creds", Keys: []string{"access_key", "secret_key"}},
+		infrav1.BackendConfigsReference{Kind: "ConfigMap", Name: "backend-config", Keys: []string{"bucket", "region"}},
+		infrav1.BackendConfigsReference{Kind: "ConfigMap", Name: "missing", Optional: true},
+		infrav1.BackendConfigsReference{Kind: "ConfigMap", Name: "empty", Optional: true},
+	)).To(Succeed())
+
+	expectInvalid(
+		validate(infrav1.BackendConfigsReference{Kind: "Secret", Name: "backend-creds", Keys: []string{"access_key", "token"}}),
+		`spec.backendConfigsFrom[0]: key "token" not found in Secret flux-system/backend-creds`,
+	)
+	expectInvalid(
+		validate(
+			infrav1.BackendConfigsReference{Kind: "Secret", Name: "backend-creds"},
+			infrav1.BackendConfigsReference{Kind: "Secret", Name: "missing"},
+		),
+		"spec.backendConfigsFrom[1]: Secret flux-system/missing not found",
+	)
+	// an optional reference only tolerates a missing object, not a missing key
+	expectInvalid(
+		validate(infrav1.BackendConfigsReference{Kind: "ConfigMap", Name: "backend-config", Keys: []string{"prefix"}, Optional: true}),
+		`spec.backendConfigsFrom[0]: key "prefix" not found in ConfigMap flux-system/backend-config`,
+	)
+	expectInvalid(
+		validate(infrav1.BackendConfigsReference{Kind: "ConfigMap", Name: "empty"}),
+		"spec.backendConfigsFrom[0]: ConfigMap flux-system/empty has no data",
+	)
+	expectInvalid(
+		validate(infrav1.BackendConfigsReference{Kind: "ConfigMap", Name: "not-backend"}),
+		`spec.backendConfigsFrom[0]: key "backend.tf" of ConfigMap flux-system/not-backend is not a valid backend configuration attribute name`,
+	)
+	expectInvalid(
+		validate(infrav1.BackendConfigsReference{Kind: "Secret", Name: "backend-creds", Keys: []string{"access key"}}),
+		`spec.backendConfigsFrom[0]: key "access key" is not a valid backend configuration attribute name`,
+	)
+
+	cloud := infrav1.Terraform{Spec: infrav1.TerraformSpec{
+		Cloud:              &infrav1.CloudSpec{Organization: "weaveworks"},
+		BackendConfigsFrom: []infrav1.BackendConfigsReference{{Kind: "Secret", Name: "backend-creds"}},
+	}}
+	expectInvalid(
+		r.validateBackendConfigs(context.TODO(), cloud),
+		"spec.backendConfigsFrom cannot be used with spec.cloud, Terraform Cloud does not accept -backend-config",
+	)
+
+	// transient errors are not validation errors
+	r.Client = fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			return errors.New("connection refused")
+		},
+	}).Build()
+	err := validate(infrav1.BackendConfigsReference{Kind: "Secret", Name: "backend-creds"})
+	g.Expect(err).To(HaveOccurred())
+	var invalid *backendConfigError
+	g.Expect(errors.As(err, &invalid)).To(BeFalse())
+}
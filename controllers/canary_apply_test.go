@@ -0,0 +1,233 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	tfjson "github.com/hashicorp/terraform-json"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/runner"
+	"google.golang.org/grpc"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	. "github.com/onsi/gomega"
+)
+
+// canaryRunnerClient plans the changes of planned which are not applied yet,
+// and records the plan and apply requests. It fails the apply requests when
+// err is set. Its tfplan file holds the approved changes at first.
+type canaryRunnerClient struct {
+	runner.RunnerClient
+	planned map[string]tfjson.Action
+	files   map[string]map[string]tfjson.Action
+	applied map[string]bool
+	plans   []*runner.PlanRequest
+	applies []*runner.ApplyRequest
+	err     error
+}
+
+func newCanaryRunnerClient(approved map[string]tfjson.Action) *canaryRunnerClient {
+	return &canaryRunnerClient{
+		planned: approved,
+		files:   map[string]map[string]tfjson.Action{runner.TFPlanName: approved},
+		applied: map[string]bool{},
+	}
+}
+
+func (c *canaryRunnerClient) Plan(ctx context.Context, in *runner.PlanRequest, opts ...grpc.CallOption) (*runner.PlanReply, error) {
+	c.plans = append(c.plans, in)
+	changes := map[string]tfjson.Action{}
+	for address, action := range c.planned {
+		if c.applied[address] {
+			continue
+		}
+		targeted := len(in.Targets) == 0
+		for _, target := range in.Targets {
+			targeted = targeted || strings.HasPrefix(address, target)
+		}
+		if targeted {
+			changes[address] = action
+		}
+	}
+	c.files[in.Out] = changes
+	return &runner.PlanReply{Message: "ok", PlanCreated: true}, nil
+}
+
+func (c *canaryRunnerClient) ShowPlanFile(ctx context.Context, in *runner.ShowPlanFileRequest, opts ...grpc.CallOption) (*runner.ShowPlanFileReply, error) {
+	plan := tfjson.Plan{FormatVersion: "1.2"}
+	for address, action := range c.files[in.Filename] {
+		plan.ResourceChanges = append(plan.ResourceChanges, &tfjson.ResourceChange{
+			Address: address,
+			Change:  &tfjson.Change{Actions: tfjson.Actions{action}},
+		})
+	}
+	data, err := json.Marshal(plan)
+	if err != nil {
+		return nil, err
+	}
+	return &runner.ShowPlanFileReply{JsonOutput: data}, nil
+}
+
+func (c *canaryRunnerClient) Apply(ctx context.Context, in *runner.ApplyRequest, opts ...grpc.CallOption) (*runner.ApplyReply, error) {
+	c.applies = append(c.applies, in)
+	if c.err != nil {
+		return nil, c.err
+	}
+	for address := range c.files[in.DirOrPlan] {
+		c.applied[address] = true
+	}
+	return &runner.ApplyReply{Message: "ok"}, nil
+}
+
+// canaryApproved are the changes of the approved plan of canaryTerraform.
+func canaryApproved() map[string]tfjson.Action {
+	return map[string]tfjson.Action{
+		"module.eu_west_1.aws_instance.web": tfjson.ActionUpdate,
+		"module.us_east_1.aws_instance.web": tfjson.ActionUpdate,
+	}
+}
+
+func canaryTerraform(healthCheckURL string) infrav1.Terraform {
+	return infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+		Spec: infrav1.TerraformSpec{
+			CanaryApply: &infrav1.CanaryApplySpec{
+				Targets: []string{"module.eu_west_1"},
+				HealthChecks: []infrav1.HealthCheck{{
+					Name: "eu-west-1",
+					Type: infrav1.HealthCheckTypeHttpGet,
+					URL:  healthCheckURL,
+				}},
+			},
+		},
+	}
+}
+
+func TestApplyCanary(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}))
+	defer server.Close()
+
+	recorder := record.NewFakeRecorder(10)
+	r := &TerraformReconciler{EventRecorder: recorder}
+	runnerClient := newCanaryRunnerClient(canaryApproved())
+
+	terraform := canaryTerraform(server.URL)
+	g.Expect(r.shouldApplyCanary(terraform)).To(BeTrue())
+
+	applyRequest := &runner.ApplyRequest{TfInstance: "instance", Parallelism: 2, DirOrPlan: "tfplan", RefreshBeforeApply: true}
+	_, err := r.applyCanary(ctx, terraform, runnerClient, "main@sha1:abc", applyRequest)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(<-recorder.Events).To(ContainSubstring("Canary applied successfully: module.eu_west_1"))
+
+	// the canary is planned into a plan file, which is applied
+	g.Expect(runnerClient.plans).To(HaveLen(2))
+	g.Expect(runnerClient.plans[0].Out).To(Equal(canaryPlanName))
+	g.Expect(runnerClient.plans[0].Targets).To(Equal([]string{"module.eu_west_1"}))
+	g.Expect(runnerClient.applies).To(HaveLen(1))
+	g.Expect(runnerClient.applies[0].DirOrPlan).To(Equal(canaryPlanName))
+	g.Expect(runnerClient.applies[0].Targets).To(BeEmpty())
+	g.Expect(runnerClient.applies[0].Parallelism).To(Equal(int32(2)))
+	g.Expect(runnerClient.applied).To(HaveKey("module.eu_west_1.aws_instance.web"))
+
+	// the remainder is planned into the plan file applied next
+	g.Expect(runnerClient.plans[1].Out).To(Equal("tfplan"))
+	g.Expect(runnerClient.plans[1].Targets).To(BeEmpty())
+	g.Expect(applyRequest.DirOrPlan).To(Equal("tfplan"))
+	g.Expect(applyRequest.RefreshBeforeApply).To(BeFalse())
+	g.Expect(applyRequest.ExpectedChanges).To(Equal(int32(1)))
+	g.Expect(runnerClient.files["tfplan"]).To(HaveKey("module.us_east_1.aws_instance.web"))
+}
+
+func TestApplyCanaryUnapprovedChanges(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	r := &TerraformReconciler{EventRecorder: record.NewFakeRecorder(10)}
+	terraform := canaryTerraform("")
+	terraform.Spec.CanaryApply.HealthChecks = nil
+	terraform.Spec.ApprovePlan = "plan-main-abc"
+	terraform.Status.Plan.Pending = "plan-main-abc"
+
+	// the canary would replace a resource the approved plan updates
+	runnerClient := newCanaryRunnerClient(canaryApproved())
+	runnerClient.planned = canaryApproved()
+	runnerClient.planned["module.eu_west_1.aws_instance.web"] = tfjson.ActionDelete
+	result, err := r.applyCanary(ctx, terraform, runnerClient, "main@sha1:abc", &runner.ApplyRequest{TfInstance: "instance"})
+	g.Expect(err).To(MatchError(ContainSubstring("the canary changes resources differently than the approved plan (module.eu_west_1.aws_instance.web)")))
+	g.Expect(runnerClient.applies).To(BeEmpty())
+	g.Expect(result.Status.Plan.Pending).To(BeEmpty())
+	ready := apimeta.FindStatusCondition(result.Status.Conditions, meta.ReadyCondition)
+	g.Expect(ready.Reason).To(Equal(infrav1.StalePlanReason))
+
+	// the remainder changes a resource the approved plan does not
+	runnerClient = newCanaryRunnerClient(canaryApproved())
+	runnerClient.planned = canaryApproved()
+	runnerClient.planned["module.us_east_1.aws_s3_bucket.logs"] = tfjson.ActionCreate
+	applyRequest := &runner.ApplyRequest{TfInstance: "instance", DirOrPlan: "tfplan"}
+	result, err = r.applyCanary(ctx, terraform, runnerClient, "main@sha1:abc", applyRequest)
+	g.Expect(err).To(MatchError(ContainSubstring("the remainder of the plan changes resources differently than the approved plan (module.us_east_1.aws_s3_bucket.logs)")))
+	g.Expect(runnerClient.applies).To(HaveLen(1))
+	g.Expect(result.Status.Plan.Pending).To(BeEmpty())
+	g.Expect(result.Status.LastPlannedRevision).To(BeEmpty())
+
+	// the plans approved automatically apply the new plan
+	terraform.Spec.ApprovePlan = infrav1.ApprovePlanAutoValue
+	runnerClient = newCanaryRunnerClient(canaryApproved())
+	runnerClient.planned = canaryApproved()
+	runnerClient.planned["module.us_east_1.aws_s3_bucket.logs"] = tfjson.ActionCreate
+	applyRequest = &runner.ApplyRequest{TfInstance: "instance", DirOrPlan: "tfplan"}
+	_, err = r.applyCanary(ctx, terraform, runnerClient, "main@sha1:abc", applyRequest)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(applyRequest.DirOrPlan).To(Equal("tfplan"))
+	g.Expect(applyRequest.ExpectedChanges).To(Equal(int32(2)))
+}
+
+func TestApplyCanaryHalts(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	recorder := record.NewFakeRecorder(10)
+	r := &TerraformReconciler{EventRecorder: recorder}
+
+	// the health checks of the canary fail
+	terraform := canaryTerraform(server.URL)
+	terraform.Status.Plan.Pending = "plan-main-abc"
+	result, err := r.applyCanary(ctx, terraform, newCanaryRunnerClient(canaryApproved()), "main@sha1:abc", &runner.ApplyRequest{})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(result.Status.Plan.Pending).To(BeEmpty())
+	ready := apimeta.FindStatusCondition(result.Status.Conditions, meta.ReadyCondition)
+	g.Expect(ready).ToNot(BeNil())
+	g.Expect(ready.Reason).To(Equal(infrav1.CanaryFailedReason))
+	g.Expect(<-recorder.Events).To(ContainSubstring("HTTP health check error: eu-west-1"))
+	g.Expect(<-recorder.Events).To(ContainSubstring(infrav1.CanaryFailedReason))
+
+	// the canary apply fails
+	runnerClient := newCanaryRunnerClient(canaryApproved())
+	runnerClient.err = errors.New("boom")
+	result, err = r.applyCanary(ctx, terraform, runnerClient, "main@sha1:abc", &runner.ApplyRequest{})
+	g.Expect(err).To(MatchError(ContainSubstring("error running the canary Apply: boom")))
+	g.Expect(runnerClient.applies).To(HaveLen(1))
+	g.Expect(runnerClient.plans).To(HaveLen(1))
+	ready = apimeta.FindStatusCondition(result.Status.Conditions, meta.ReadyCondition)
+	g.Expect(ready.Reason).To(Equal(infrav1.CanaryFailedReason))
+
+	// the destroy plans are applied at once
+	terraform.Status.Plan.IsDestroyPlan = true
+	g.Expect(r.shouldApplyCanary(terraform)).To(BeFalse())
+}
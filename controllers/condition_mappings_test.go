@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestMapConditions(t *testing.T) {
+	g := NewWithT(t)
+
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "tf", Namespace: "prod", Generation: 3},
+		Spec:       infrav1.TerraformSpec{ApprovePlan: "plan-main-abc"},
+		Status: infrav1.TerraformStatus{
+			Plan: infrav1.PlanStatus{Pending: "plan-main-abc1234"},
+			Conditions: []metav1.Condition{
+				{Type: meta.ReadyCondition, Status: metav1.ConditionUnknown, Reason: infrav1.PlannedWithChangesReason},
+			},
+		},
+	}
+	// a Saturday
+	now := time.Date(2023, 7, 8, 10, 0, 0, 0, time.UTC)
+
+	conditions := mapConditions(terraform, []infrav1.ConditionMapping{
+		{
+			Type: "ChangeFreezeViolated",
+			When: `now.getDayOfWeek() in [0, 6] && has(object.status.plan.pending) && ` +
+				`object.status.plan.pending.startsWith(object.spec.approvePlan)`,
+			Reason:  "ApplyRequestedDuringFreeze",
+			Message: "An apply is requested during the weekend freeze",
+		},
+		{
+			Type: "ChangesPending",
+			When: `conditions['Ready'].reason == 'TerraformPlannedWithChanges'`,
+		},
+		{
+			Type: "Drifted",
+			When: `conditions['Ready'].reason == 'DriftDetected'`,
+		},
+		{
+			Type: "Broken",
+			When: `object.status.missing == 'x'`,
+		},
+		{
+			Type: meta.ReadyCondition,
+			When: `true`,
+		},
+	}, now)
+
+	g.Expect(conditions).To(Equal([]metav1.Condition{
+		{Type: "ChangeFreezeViolated", Status: metav1.ConditionTrue, Reason: "ApplyRequestedDuringFreeze",
+			Message: "An apply is requested during the weekend freeze", ObservedGeneration: 3},
+		{Type: "ChangesPending", Status: metav1.ConditionTrue, Reason: infrav1.ConditionMetReason, ObservedGeneration: 3},
+		{Type: "Drifted", Status: metav1.ConditionFalse, Reason: infrav1.ConditionNotMetReason, ObservedGeneration: 3},
+		{Type: "Broken", Status: metav1.ConditionUnknown, Reason: infrav1.ConditionMappingFailedReason,
+			Message: "unable to evaluate the condition expression: no such key: missing", ObservedGeneration: 3},
+	}))
+
+	// on a Monday
+	conditions = mapConditions(terraform, []infrav1.ConditionMapping{{
+		Type: "ChangeFreezeViolated",
+		When: `now.getDayOfWeek() in [0, 6]`,
+	}}, now.Add(48*time.Hour))
+	g.Expect(conditions[0].Status).To(Equal(metav1.ConditionFalse))
+
+	_, err := evaluateConditionMapping(`object.metadata.name`, terraform, now)
+	g.Expect(err).To(MatchError(ContainSubstring("must return a bool")))
+}
@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestCheckConflicts(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	stack := func(namespace, name string, identifiers ...string) *infrav1.Terraform {
+		terraform := &infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: infrav1.TerraformSpec{
+				BackendConfig: &infrav1.BackendConfigSpec{SecretSuffix: name, InClusterConfig: true},
+			},
+			Status: infrav1.TerraformStatus{Inventory: &infrav1.ResourceInventory{}},
+		}
+		for _, identifier := range identifiers {
+			terraform.Status.Inventory.Entries = append(terraform.Status.Inventory.Entries,
+				infrav1.ResourceRef{Name: "aws_s3_bucket.logs", Type: "aws_s3_bucket", Identifier: identifier})
+		}
+		return terraform
+	}
+	network := stack("team-a", "network", "logs-a")
+	storage := stack("team-b", "storage", "logs-a", "logs-b")
+	preview := stack("team-b", "storage-pr-1", "logs-b")
+	preview.Spec.PlanOnly = true
+
+	recorder := record.NewFakeRecorder(10)
+	r := &TerraformReconciler{EventRecorder: recorder}
+	r.Client = fake.NewClientBuilder().WithScheme(scheme).
+		WithIndex(&infrav1.Terraform{}, infrav1.ManagedResourceIndexKey, r.IndexByManagedResource()).
+		WithObjects(network, storage, preview).Build()
+
+	checked := r.checkConflicts(ctx, *storage, "main@sha1:abc")
+	condition := apimeta.FindStatusCondition(checked.Status.Conditions, infrav1.ConditionTypeConflict)
+	g.Expect(condition).ToNot(BeNil())
+	g.Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+	g.Expect(condition.Reason).To(Equal(infrav1.ConflictDetectedReason))
+	g.Expect(condition.Message).To(Equal("resource aws_s3_bucket logs-a is also managed by team-a/network"))
+	g.Expect(<-recorder.Events).To(ContainSubstring(infrav1.ConflictDetectedReason))
+
+	// the event is sent once per conflict
+	checked = r.checkConflicts(ctx, checked, "main@sha1:abc")
+	g.Expect(recorder.Events).To(BeEmpty())
+
+	// the same state Secret conflicts too
+	network.Spec.BackendConfig.SecretSuffix = "storage"
+	network.Spec.BackendConfig.SecretNamespace = "team-b"
+	network.Status.Inventory = nil
+	g.Expect(r.Update(ctx, network)).To(Succeed())
+	checked = r.checkConflicts(ctx, checked, "main@sha1:abc")
+	g.Expect(apimeta.FindStatusCondition(checked.Status.Conditions, infrav1.ConditionTypeConflict).Message).
+		To(Equal("the state Secret team-b/tfstate-default-storage is also managed by team-a/network"))
+
+	// the condition is removed once the conflict is resolved
+	g.Expect(r.Delete(ctx, network)).To(Succeed())
+	checked = r.checkConflicts(ctx, checked, "main@sha1:def")
+	g.Expect(apimeta.FindStatusCondition(checked.Status.Conditions, infrav1.ConditionTypeConflict)).To(BeNil())
+}
+
+func TestS3StateKey(t *testing.T) {
+	g := NewWithT(t)
+
+	backend := `
+terraform {
+  backend "s3" {
+    bucket = "tf-states"
+    key    = "network/terraform.tfstate"
+    region = "eu-west-1"
+  }
+}`
+	bucket, key, ok := s3StateKey(backend, "default")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(bucket).To(Equal("tf-states"))
+	g.Expect(key).To(Equal("network/terraform.tfstate"))
+
+	_, key, _ = s3StateKey(backend, "staging")
+	g.Expect(key).To(Equal("env:/staging/network/terraform.tfstate"))
+
+	// the keys set by the backend configs are not known
+	_, _, ok = s3StateKey(`terraform {
+  backend "s3" {}
+}`, "default")
+	g.Expect(ok).To(BeFalse())
+}
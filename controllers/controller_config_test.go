@@ -0,0 +1,174 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/mtls"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestControllerConfigOverridesFlags(t *testing.T) {
+	g := NewWithT(t)
+
+	r := &TerraformReconciler{
+		RunnerRuntimeClassName: "gvisor",
+		RunnerCreationTimeout:  2 * time.Minute,
+		NoCrossNamespaceRefs:   true,
+	}
+
+	// no config, the flags apply
+	g.Expect(r.runnerRuntimeClassName()).To(Equal("gvisor"))
+	g.Expect(r.runnerCreationTimeout()).To(Equal(2 * time.Minute))
+	g.Expect(r.featureGate(infrav1.FeatureGateBreakTheGlass, true)).To(BeTrue())
+	g.Expect(r.crossNamespaceRefAllowed("team-a", "team-a")).To(BeTrue())
+	g.Expect(r.crossNamespaceRefAllowed("team-a", "flux-system")).To(BeFalse())
+
+	r.Config = NewControllerConfig()
+	r.Config.Load(&infrav1.TerraformControllerConfigSpec{
+		Defaults: &infrav1.ControllerDefaults{
+			RunnerRuntimeClassName: "kata",
+			RunnerCreationTimeout:  &metav1.Duration{Duration: 5 * time.Minute},
+		},
+		FeatureGates: map[string]bool{infrav1.FeatureGateBreakTheGlass: false},
+		AllowedCrossNamespaceRefs: []infrav1.CrossNamespaceRefRule{
+			{From: "team-*", To: "flux-system"},
+		},
+	})
+	g.Expect(r.runnerRuntimeClassName()).To(Equal("kata"))
+	g.Expect(r.runnerCreationTimeout()).To(Equal(5 * time.Minute))
+	g.Expect(r.featureGate(infrav1.FeatureGateBreakTheGlass, true)).To(BeFalse())
+	g.Expect(r.featureGate(infrav1.FeatureGateRestrictedRunnerPods, true)).To(BeTrue())
+	g.Expect(r.crossNamespaceRefAllowed("team-a", "flux-system")).To(BeTrue())
+	g.Expect(r.crossNamespaceRefAllowed("flux-system", "team-a")).To(BeFalse())
+
+	// the config is unloaded
+	r.Config.Load(nil)
+	g.Expect(r.runnerRuntimeClassName()).To(Equal("gvisor"))
+}
+
+func TestControllerConfigConcurrency(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	config := NewControllerConfig()
+	config.Load(&infrav1.TerraformControllerConfigSpec{Concurrency: int32Ptr(1)})
+
+	release, err := config.Acquire(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// the second reconcile waits for the first one
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = config.Acquire(timeoutCtx)
+	g.Expect(err).To(MatchError(context.DeadlineExceeded))
+
+	acquired := make(chan func())
+	go func() {
+		release, _ := config.Acquire(ctx)
+		acquired <- release
+	}()
+	g.Consistently(acquired, 50*time.Millisecond).ShouldNot(Receive())
+	release()
+	var second func()
+	g.Eventually(acquired).Should(Receive(&second))
+
+	// raising the concurrency lets the waiting reconciles in at once
+	go func() {
+		release, _ := config.Acquire(ctx)
+		acquired <- release
+	}()
+	g.Consistently(acquired, 50*time.Millisecond).ShouldNot(Receive())
+	config.Load(&infrav1.TerraformControllerConfigSpec{Concurrency: int32Ptr(2)})
+	g.Eventually(acquired).Should(Receive())
+}
+
+func TestTerraformControllerConfigReconciler(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	object := &infrav1.TerraformControllerConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "tf-controller", Generation: 1},
+		Spec: infrav1.TerraformControllerConfigSpec{
+			FeatureGates: map[string]bool{infrav1.FeatureGateRestrictedRunnerPods: true},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithStatusSubresource(&infrav1.TerraformControllerConfig{}).
+		WithObjects(object).Build()
+	r := &TerraformControllerConfigReconciler{Client: c, Scheme: scheme, Name: "tf-controller", Config: NewControllerConfig()}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "tf-controller"}}
+
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(r.Config.Spec()).ToNot(BeNil())
+	g.Expect(r.Config.Spec().FeatureGates).To(HaveKeyWithValue(infrav1.FeatureGateRestrictedRunnerPods, true))
+	g.Expect(c.Get(ctx, req.NamespacedName, object)).To(Succeed())
+	g.Expect(object.IsValid()).To(BeTrue())
+
+	// an invalid config falls back to the flags
+	object.Spec.FeatureGates = map[string]bool{"Teleport": true}
+	g.Expect(c.Update(ctx, object)).To(Succeed())
+	_, err = r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(r.Config.Spec()).To(BeNil())
+	g.Expect(c.Get(ctx, req.NamespacedName, object)).To(Succeed())
+	ready := apimeta.FindStatusCondition(object.Status.Conditions, meta.ReadyCondition)
+	g.Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+	g.Expect(ready.Reason).To(Equal(infrav1.ConfigInvalidReason))
+	g.Expect(ready.Message).To(Equal("unknown feature gates: Teleport"))
+}
+
+func TestTerraformControllerConfigReconcilerRotatesRunnerCA(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	object := &infrav1.TerraformControllerConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "tf-controller", Generation: 1},
+		Spec: infrav1.TerraformControllerConfigSpec{
+			RunnerCA: &infrav1.RunnerCAConfig{RotationRequestedAt: "2023-10-01T10:00:00Z"},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithStatusSubresource(&infrav1.TerraformControllerConfig{}).
+		WithObjects(object).Build()
+
+	rotations := 0
+	rotator := &mtls.CertRotator{TriggerCARotation: make(chan mtls.Trigger)}
+	go func() {
+		for trigger := range rotator.TriggerCARotation {
+			rotations++
+			trigger.Ready <- &mtls.TriggerResult{}
+		}
+	}()
+	defer close(rotator.TriggerCARotation)
+
+	r := &TerraformControllerConfigReconciler{Client: c, Scheme: scheme, Name: "tf-controller", Config: NewControllerConfig(), CertRotator: rotator}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "tf-controller"}}
+
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(c.Get(ctx, req.NamespacedName, object)).To(Succeed())
+	g.Expect(object.Status.LastHandledRunnerCARotation).To(Equal("2023-10-01T10:00:00Z"))
+
+	// the rotation is handled once
+	_, err = r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rotations).To(Equal(1))
+}
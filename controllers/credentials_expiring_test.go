@@ -0,0 +1,39 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/weaveworks/tf-controller/runner"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestCredentialsExpiring(t *testing.T) {
+	g := NewWithT(t)
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	threshold := &metav1.Duration{Duration: 24 * time.Hour}
+
+	credentials := &runner.ProviderCredentials{
+		Provider:  "aws",
+		Valid:     true,
+		Identity:  "arn:aws:iam::123456789012:user/runner",
+		ExpiresAt: "2023-06-01T12:00:00Z",
+	}
+
+	msg, expiring := credentialsExpiring(credentials, threshold, now)
+	g.Expect(expiring).To(BeTrue())
+	g.Expect(msg).To(ContainSubstring("arn:aws:iam::123456789012:user/runner"))
+
+	_, expiring = credentialsExpiring(credentials, nil, now)
+	g.Expect(expiring).To(BeFalse())
+
+	credentials.ExpiresAt = "2023-06-10T00:00:00Z"
+	_, expiring = credentialsExpiring(credentials, threshold, now)
+	g.Expect(expiring).To(BeFalse())
+
+	credentials.ExpiresAt = ""
+	_, expiring = credentialsExpiring(credentials, threshold, now)
+	g.Expect(expiring).To(BeFalse())
+}
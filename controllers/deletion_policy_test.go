@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestInClusterStateSecretName(t *testing.T) {
+	g := NewWithT(t)
+	r := &TerraformReconciler{}
+
+	terraform := infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"}}
+	name, ok := r.inClusterStateSecretName(terraform)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(name).To(Equal("tfstate-default-helloworld"))
+
+	terraform.Spec.Workspace = "dev"
+	terraform.Spec.BackendConfig = &infrav1.BackendConfigSpec{SecretSuffix: "shared", InClusterConfig: true}
+	name, ok = r.inClusterStateSecretName(terraform)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(name).To(Equal("tfstate-dev-shared"))
+
+	terraform.Spec.BackendConfig = &infrav1.BackendConfigSpec{SecretSuffix: "shared", ConfigPath: "/kubeconfig"}
+	_, ok = r.inClusterStateSecretName(terraform)
+	g.Expect(ok).To(BeFalse())
+
+	terraform.Spec.BackendConfig = &infrav1.BackendConfigSpec{SecretSuffix: "shared", InClusterConfig: true, KubeConfig: &meta.SecretKeyReference{Name: "state-cluster"}}
+	_, ok = r.inClusterStateSecretName(terraform)
+	g.Expect(ok).To(BeFalse())
+
+	terraform.Spec.BackendConfig = &infrav1.BackendConfigSpec{CustomConfiguration: `backend "s3" {}`, InClusterConfig: true}
+	_, ok = r.inClusterStateSecretName(terraform)
+	g.Expect(ok).To(BeFalse())
+
+	terraform.Spec.BackendConfig = nil
+	terraform.Spec.Cloud = &infrav1.CloudSpec{Organization: "org"}
+	_, ok = r.inClusterStateSecretName(terraform)
+	g.Expect(ok).To(BeFalse())
+}
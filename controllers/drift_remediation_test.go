@@ -0,0 +1,78 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/planner/provider"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/gomega"
+)
+
+type fakeDriftProvider struct {
+	provider.Provider
+	prs    []provider.PullRequest
+	opened []provider.NewPullRequest
+}
+
+func (p *fakeDriftProvider) ListPullRequests(ctx context.Context, repo provider.Repository) ([]provider.PullRequest, error) {
+	return p.prs, nil
+}
+
+func (p *fakeDriftProvider) CreatePullRequest(ctx context.Context, repo provider.Repository, newPR provider.NewPullRequest) (*provider.PullRequest, error) {
+	p.opened = append(p.opened, newPR)
+	pr := provider.PullRequest{
+		Repository: repo,
+		Number:     len(p.prs) + 1,
+		BaseBranch: newPR.BaseBranch,
+		HeadBranch: newPR.HeadBranch,
+	}
+	p.prs = append(p.prs, pr)
+	return &pr, nil
+}
+
+func TestOpenDriftPullRequest(t *testing.T) {
+	g := NewWithT(t)
+
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "network", Namespace: "prod"},
+		Spec: infrav1.TerraformSpec{
+			Path:             "./envs/prod",
+			DriftRemediation: &infrav1.DriftRemediation{},
+		},
+	}
+	repo := provider.Repository{Org: "org", Name: "infra"}
+	drift := "  ~ resource \"aws_vpc\" \"main\" {\n      ~ tags = {}\n    }\n"
+	now := time.Date(2023, 7, 8, 10, 0, 0, 0, time.UTC)
+	gitProvider := &fakeDriftProvider{prs: []provider.PullRequest{{Number: 1, HeadBranch: "feature"}}}
+
+	pr, err := openDriftPullRequest(context.TODO(), gitProvider, repo, terraform, "main", "main@sha1:abc", drift, now)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(pr.Number).To(Equal(2))
+	g.Expect(gitProvider.opened).To(HaveLen(1))
+
+	opened := gitProvider.opened[0]
+	g.Expect(opened.Title).To(Equal("Drift of Terraform prod/network"))
+	g.Expect(opened.BaseBranch).To(Equal("main"))
+	g.Expect(opened.HeadBranch).To(Equal("tf-controller/drift/prod/network/" + driftDigest(drift)))
+	g.Expect(opened.Files).To(HaveKey("envs/prod/drift/network.md"))
+	g.Expect(opened.Body).To(Equal("# Drift of Terraform prod/network\n\n" +
+		"A drift was detected on 2023-07-08T10:00:00Z, at revision `main@sha1:abc`. It is not applied: " +
+		"change the configuration to account for it, or revert the changes of the infrastructure.\n\n" +
+		"## Plan\n\n```\n~ resource \"aws_vpc\" \"main\" {\n      ~ tags = {}\n    }\n```\n"))
+
+	// the open pull request of the object is the one to remediate any drift in
+	pr, err = openDriftPullRequest(context.TODO(), gitProvider, repo, terraform, "main", "main@sha1:def", "another drift", now)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(pr.Number).To(Equal(2))
+	g.Expect(gitProvider.opened).To(HaveLen(1))
+
+	terraform.Spec.DriftRemediation.ReportPath = "DRIFT.md"
+	gitProvider.prs = nil
+	_, err = openDriftPullRequest(context.TODO(), gitProvider, repo, terraform, "main", "main@sha1:def", "another drift", now)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(gitProvider.opened[1].Files).To(HaveKey("DRIFT.md"))
+}
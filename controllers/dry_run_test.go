@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestDryRunValidator(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	old := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system", UID: "uid"},
+		Spec: infrav1.TerraformSpec{
+			Interval:    metav1.Duration{Duration: time.Minute},
+			Path:        "./terraform",
+			ApprovePlan: infrav1.ApprovePlanAutoValue,
+			Vars:        []infrav1.Variable{{Name: "size", Value: jsonValue(`"small"`)}},
+		},
+	}
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(old.DeepCopy()).
+		WithStatusSubresource(&infrav1.Terraform{}).Build()
+	v := &DryRunValidator{Client: kubeClient, Scheme: scheme}
+
+	changed := old.DeepCopy()
+	changed.Annotations = map[string]string{infrav1.DryRunAnnotation: "true"}
+	changed.Spec.Vars[0].Value = jsonValue(`"large"`)
+
+	// the change is denied, and planned in a copy of the object
+	_, err := v.ValidateUpdate(ctx, old, changed)
+	g.Expect(err).To(MatchError(ContainSubstring("planning the change")))
+
+	var list infrav1.TerraformList
+	g.Expect(kubeClient.List(ctx, &list, client.MatchingLabels{infrav1.DryRunOfLabel: "helloworld"})).To(Succeed())
+	g.Expect(list.Items).To(HaveLen(1))
+	planned := &list.Items[0]
+	g.Expect(planned.Name).To(HavePrefix("helloworld-dry-run-"))
+	g.Expect(planned.OwnerReferences).To(HaveLen(1))
+	g.Expect(planned.Spec.PlanOnly).To(BeTrue())
+	g.Expect(planned.Spec.ApprovePlan).To(BeEmpty())
+	g.Expect(planned.Spec.DeletionPolicy).To(Equal(infrav1.DeletionPolicyOrphan))
+	g.Expect(planned.Spec.BackendConfig.SecretSuffix).To(Equal("helloworld"))
+	g.Expect(planned.Spec.Vars[0].Value).To(Equal(jsonValue(`"large"`)))
+
+	// the change is denied with the summary of the plan, once planned
+	_, err = v.ValidateUpdate(ctx, old, changed)
+	g.Expect(err).To(MatchError(ContainSubstring("being planned")))
+
+	apimeta.SetStatusCondition(&planned.Status.Conditions, metav1.Condition{
+		Type:   meta.ReadyCondition,
+		Status: metav1.ConditionUnknown,
+		Reason: infrav1.PlannedWithChangesReason,
+	})
+	planned.Status.Plan.Changes = &infrav1.PlanChanges{Add: 1, Change: 2}
+	g.Expect(kubeClient.Status().Update(ctx, planned)).To(Succeed())
+
+	_, err = v.ValidateUpdate(ctx, old, changed)
+	g.Expect(err).To(MatchError(ContainSubstring("Plan: 1 to add, 2 to change, 0 to destroy")))
+
+	// the change is accepted without the annotation, and the dry runs deleted
+	accepted := changed.DeepCopy()
+	accepted.Annotations = nil
+	_, err = v.ValidateUpdate(ctx, old, accepted)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(kubeClient.List(ctx, &list, client.MatchingLabels{infrav1.DryRunOfLabel: "helloworld"})).To(Succeed())
+	g.Expect(list.Items).To(BeEmpty())
+}
@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eventv1 "github.com/fluxcd/pkg/apis/event/v1beta1"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestEventVerbosity(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	recorder := record.NewFakeRecorder(10)
+	r := &TerraformReconciler{EventRecorder: recorder, EventVerbosity: infrav1.EventVerbosityErrorsOnly}
+	terraform := infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system", UID: "uid"}}
+
+	// the flag applies to the objects which do not set a verbosity
+	r.event(ctx, terraform, "", eventv1.EventSeverityInfo, "Plan generated", nil)
+	r.event(ctx, terraform, "", eventv1.EventSeverityError, "Plan failed", nil)
+	g.Expect(recorder.Events).To(Receive(Equal("Warning error Plan failed")))
+	g.Expect(recorder.Events).ToNot(Receive())
+
+	// the config overrides the flag, and the object the config
+	r.Config = NewControllerConfig()
+	r.Config.Load(&infrav1.TerraformControllerConfigSpec{
+		Defaults: &infrav1.ControllerDefaults{EventVerbosity: infrav1.EventVerbosityNormal},
+	})
+	r.event(ctx, terraform, "", eventv1.EventSeverityInfo, "Plan generated", nil)
+	r.event(ctx, terraform, "", eventv1.EventSeverityTrace, "No drift detected", nil)
+	g.Expect(recorder.Events).To(Receive(Equal("Normal info Plan generated")))
+	g.Expect(recorder.Events).ToNot(Receive())
+
+	terraform.Spec.EventVerbosity = infrav1.EventVerbosityDebug
+	r.event(ctx, terraform, "", eventv1.EventSeverityTrace, "No drift detected", nil)
+	g.Expect(recorder.Events).To(Receive(Equal("Trace trace No drift detected")))
+}
+
+func TestEventAggregation(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	recorder := record.NewFakeRecorder(10)
+	r := &TerraformReconciler{EventRecorder: recorder, EventAggregationWindow: time.Hour}
+	terraform := infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system", UID: "uid"}}
+
+	// the identical events are counted within the window
+	for i := 0; i < 3; i++ {
+		r.event(ctx, terraform, "", eventv1.EventSeverityError, "Drift detected", nil)
+	}
+	r.event(ctx, terraform, "", eventv1.EventSeverityError, "Plan failed", nil)
+	g.Expect(recorder.Events).To(Receive(Equal("Warning error Drift detected")))
+	g.Expect(recorder.Events).To(Receive(Equal("Warning error Plan failed")))
+	g.Expect(recorder.Events).ToNot(Receive())
+
+	// the events of the other objects are not
+	other := terraform
+	other.UID = "other"
+	r.event(ctx, other, "", eventv1.EventSeverityError, "Drift detected", nil)
+	g.Expect(recorder.Events).To(Receive(Equal("Warning error Drift detected")))
+
+	// the next one past the window carries the count
+	now := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+	a := &eventAggregator{}
+	record, _, _ := a.admit("uid", eventv1.EventSeverityError, "DriftDetected", "Drift detected", now, time.Hour)
+	g.Expect(record).To(BeTrue())
+	record, _, _ = a.admit("uid", eventv1.EventSeverityError, "DriftDetected", "Drift detected", now.Add(30*time.Minute), time.Hour)
+	g.Expect(record).To(BeFalse())
+	record, repeated, since := a.admit("uid", eventv1.EventSeverityError, "DriftDetected", "Drift detected", now.Add(time.Hour), time.Hour)
+	g.Expect(record).To(BeTrue())
+	g.Expect(repeated).To(Equal(1))
+	g.Expect(aggregatedEventMessage("Drift detected", repeated, since)).To(Equal(
+		"Drift detected\n\n(repeated 1 time(s) since 2023-10-01T10:00:00Z)"))
+
+	// debug records them all
+	terraform.Spec.EventVerbosity = infrav1.EventVerbosityDebug
+	r.event(ctx, terraform, "", eventv1.EventSeverityError, "Drift detected", nil)
+	g.Expect(recorder.Events).To(Receive(Equal("Warning error Drift detected")))
+
+	a.forget("uid")
+	g.Expect(a.events).ToNot(HaveKey(BeEquivalentTo("uid")))
+}
@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestExpire(t *testing.T) {
+	g := NewWithT(t)
+
+	terraform := infrav1.Terraform{}
+	terraform.Status.Plan.Pending = "plan-main-abc1234"
+	terraform = expire(terraform)
+	g.Expect(terraform.Spec.Destroy).To(BeTrue())
+	g.Expect(terraform.Spec.ApprovePlan).To(Equal(infrav1.ApprovePlanAutoValue))
+	g.Expect(terraform.Status.Plan.Pending).To(BeEmpty())
+
+	// a pending destroy plan is kept, to be applied
+	terraform.Status.Plan.Pending = "plan-main-abc1234"
+	terraform.Status.Plan.IsDestroyPlan = true
+	g.Expect(expire(terraform).Status.Plan.Pending).To(Equal("plan-main-abc1234"))
+}
+
+func TestRequeueBeforeExpiry(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	terraform := infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-time.Hour))}}
+	result := ctrl.Result{RequeueAfter: 10 * time.Minute}
+	g.Expect(requeueBeforeExpiry(terraform, result, now)).To(Equal(result))
+
+	terraform.Spec.TTL = &metav1.Duration{Duration: time.Hour + 5*time.Minute}
+	g.Expect(requeueBeforeExpiry(terraform, result, now)).To(Equal(ctrl.Result{RequeueAfter: 5 * time.Minute}))
+	g.Expect(requeueBeforeExpiry(terraform, ctrl.Result{}, now)).To(Equal(ctrl.Result{RequeueAfter: 5 * time.Minute}))
+
+	terraform.Spec.TTL = &metav1.Duration{Duration: 2 * time.Hour}
+	g.Expect(requeueBeforeExpiry(terraform, result, now)).To(Equal(result))
+
+	// already expired
+	terraform.Spec.TTL = &metav1.Duration{Duration: time.Minute}
+	g.Expect(requeueBeforeExpiry(terraform, result, now)).To(Equal(result))
+
+	// the plan of a branch is requeued when it expires
+	terraform.Spec.TTL = nil
+	terraform.Labels = map[string]string{infrav1.BranchPlanOfLabel: "helloworld"}
+	terraform.Annotations = map[string]string{infrav1.BranchPlanExpiresAtAnnotation: now.Add(3 * time.Minute).Format(time.RFC3339)}
+	g.Expect(requeueBeforeExpiry(terraform, result, now)).To(Equal(ctrl.Result{RequeueAfter: 3 * time.Minute}))
+	g.Expect(branchPlanExpired(terraform, now)).To(BeFalse())
+	g.Expect(branchPlanExpired(terraform, now.Add(3*time.Minute))).To(BeTrue())
+}
@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestReadModule(t *testing.T) {
+	g := NewWithT(t)
+
+	artifact := tarGz(g, map[string]string{
+		"stacks/network/README.md": "Network\n=======\n\n[![ci](https://ci/badge.svg)](https://ci)\n\nCreates the VPC and\nthe subnets of an environment.\n\n## Usage\n",
+		"stacks/network/variables.tf": `
+variable "cidr" {
+  type        = string
+  description = "CIDR block of the VPC"
+}
+
+variable "azs" {
+  type    = list(string)
+  default = ["eu-west-1a", "eu-west-1b"]
+}
+
+variable "token" {
+  sensitive = true
+  default   = null
+}
+`,
+		"stacks/network/outputs.tf": `
+output "vpc_id" {
+  description = <<-EOT
+    ID of the VPC
+  EOT
+  value = aws_vpc.main.id
+}
+`,
+		"stacks/network/modules/subnet/variables.tf": `variable "ignored" {}`,
+		"stacks/dns/main.tf":                         `variable "ignored" {}`,
+	})
+
+	module, err := readModule(artifact, "./stacks/network", "main@sha1:abc")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(module.Revision).To(Equal("main@sha1:abc"))
+	g.Expect(module.Description).To(Equal("Creates the VPC and the subnets of an environment."))
+
+	azsDefault, tokenDefault := `["eu-west-1a", "eu-west-1b"]`, "null"
+	g.Expect(module.Inputs).To(Equal([]infrav1.ModuleInput{
+		{Name: "azs", Type: "list(string)", Default: &azsDefault},
+		{Name: "cidr", Type: "string", Description: "CIDR block of the VPC", Required: true},
+		{Name: "token", Default: &tokenDefault, Sensitive: true},
+	}))
+	g.Expect(module.Outputs).To(Equal([]infrav1.ModuleOutput{
+		{Name: "vpc_id", Description: "ID of the VPC"},
+	}))
+
+	_, err = readModule(tarGz(g, map[string]string{"main.tf": `variable "broken" {`}), "./", "main@sha1:abc")
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestReadmeDescription(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(readmeDescription(nil)).To(BeEmpty())
+	g.Expect(readmeDescription([]byte("# Title\n<img src=\"logo.png\">\nFirst paragraph.\n# Next"))).To(Equal("First paragraph."))
+
+	description := readmeDescription([]byte(strings.Repeat("é", 2000)))
+	g.Expect([]rune(description)).To(HaveLen(maxModuleDescriptionLength))
+	g.Expect(description).To(HaveSuffix("..."))
+}
@@ -0,0 +1,53 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/runner"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestMovedBlocks(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	recorder := record.NewFakeRecorder(10)
+	r := &TerraformReconciler{EventRecorder: recorder}
+
+	terraform := infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "storage", Namespace: "flux-system"}}
+	terraform = infrav1.TerraformPlannedWithChanges(terraform, "main@sha1:abc", false, "Plan generated")
+
+	terraform = r.recordSuspectedMoves(ctx, terraform, "main@sha1:abc", []*runner.ResourceMove{
+		{From: "aws_s3_bucket.logs", To: "module.storage.aws_s3_bucket.logs"},
+	})
+	condition := apimeta.FindStatusCondition(terraform.Status.Conditions, infrav1.ConditionTypeMovedBlocks)
+	g.Expect(condition).ToNot(BeNil())
+	g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+	g.Expect(condition.Reason).To(Equal(infrav1.MovedBlocksMissingReason))
+	g.Expect(condition.Message).To(Equal("1 resource is destroyed and created again, identical, at another address, " +
+		"add moved blocks to move rather than replace them: moved { from = aws_s3_bucket.logs, to = module.storage.aws_s3_bucket.logs }"))
+	g.Expect(<-recorder.Events).To(ContainSubstring(infrav1.MovedBlocksMissingReason))
+
+	// the plan is applied, unless the moved blocks are required
+	_, err := checkMovedBlocks(terraform, "main@sha1:abc")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	terraform.Spec.RequireMovedBlocks = true
+	halted, err := checkMovedBlocks(terraform, "main@sha1:abc")
+	g.Expect(err).To(MatchError(ContainSubstring("is not applied: 1 resource is destroyed")))
+	g.Expect(halted.Status.Plan.Pending).To(Equal(terraform.Status.Plan.Pending))
+	g.Expect(apimeta.FindStatusCondition(halted.Status.Conditions, meta.ReadyCondition).Reason).To(Equal(infrav1.MovedBlocksMissingReason))
+
+	// the next plan with the moved blocks removes the condition
+	terraform = r.recordSuspectedMoves(ctx, terraform, "main@sha1:def", nil)
+	g.Expect(apimeta.FindStatusCondition(terraform.Status.Conditions, infrav1.ConditionTypeMovedBlocks)).To(BeNil())
+	_, err = checkMovedBlocks(terraform, "main@sha1:def")
+	g.Expect(err).ToNot(HaveOccurred())
+}
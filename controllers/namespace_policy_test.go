@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNamespacePolicyDefaulter(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	resources := &corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+	}
+	policies := []runtime.Object{
+		&infrav1.TerraformNamespacePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "b-guardrails", Namespace: "team-a"},
+			Spec: infrav1.TerraformNamespacePolicySpec{
+				ServiceAccountName:     "ignored",
+				MinInterval:            &metav1.Duration{Duration: 10 * time.Minute},
+				DisallowAutoApprove:    true,
+				RunnerRuntimeClassName: "gvisor",
+			},
+		},
+		&infrav1.TerraformNamespacePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-defaults", Namespace: "team-a"},
+			Spec: infrav1.TerraformNamespacePolicySpec{
+				ServiceAccountName: "team-a-runner",
+				RunnerResources:    resources,
+				MinInterval:        &metav1.Duration{Duration: 5 * time.Minute},
+			},
+		},
+	}
+	d := &NamespacePolicyDefaulter{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(policies...).Build(),
+	}
+
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "team-a"},
+		Spec: infrav1.TerraformSpec{
+			Interval:    metav1.Duration{Duration: time.Minute},
+			ApprovePlan: infrav1.ApprovePlanAutoValue,
+		},
+	}
+	g.Expect(d.Default(context.Background(), terraform)).To(Succeed())
+	g.Expect(terraform.Spec.ServiceAccountName).To(Equal("team-a-runner"))
+	g.Expect(terraform.Spec.RunnerPodTemplate.Spec.Resources).To(Equal(resources))
+	g.Expect(terraform.Spec.Interval.Duration).To(Equal(10 * time.Minute))
+	g.Expect(terraform.Spec.ApprovePlan).To(BeEmpty())
+	g.Expect(terraform.Spec.RunnerPodTemplate.Spec.RuntimeClassName).To(Equal(stringPtr("gvisor")))
+	g.Expect(terraform.Annotations).To(HaveKeyWithValue(infrav1.NamespacePolicyAnnotation, "a-defaults,b-guardrails"))
+
+	// the values set by the tenant are kept, as long as they are allowed
+	terraform = &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "team-a"},
+		Spec: infrav1.TerraformSpec{
+			Interval:           metav1.Duration{Duration: time.Hour},
+			ApprovePlan:        "plan-main-abcdef",
+			ServiceAccountName: "custom",
+		},
+	}
+	g.Expect(d.Default(context.Background(), terraform)).To(Succeed())
+	g.Expect(terraform.Spec.ServiceAccountName).To(Equal("custom"))
+	g.Expect(terraform.Spec.Interval.Duration).To(Equal(time.Hour))
+	g.Expect(terraform.Spec.ApprovePlan).To(Equal("plan-main-abcdef"))
+
+	// the runtime class of the policies cannot be opted out of
+	terraform.Spec.RunnerPodTemplate.Spec.RuntimeClassName = stringPtr("runc")
+	g.Expect(d.Default(context.Background(), terraform)).To(Succeed())
+	g.Expect(terraform.Spec.RunnerPodTemplate.Spec.RuntimeClassName).To(Equal(stringPtr("gvisor")))
+
+	// no policy in the namespace
+	terraform = &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "team-b"},
+		Spec:       infrav1.TerraformSpec{ApprovePlan: infrav1.ApprovePlanAutoValue},
+	}
+	g.Expect(d.Default(context.Background(), terraform)).To(Succeed())
+	g.Expect(terraform.Spec.ApprovePlan).To(Equal(infrav1.ApprovePlanAutoValue))
+	g.Expect(terraform.Annotations).To(BeNil())
+}
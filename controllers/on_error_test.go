@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/runner"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestWithStateLock(t *testing.T) {
+	g := NewWithT(t)
+
+	st, err := status.New(codes.Internal, "Error acquiring the state lock").WithDetails(&runner.PlanReply{StateLockIdentifier: "f2ab685b"})
+	g.Expect(err).ToNot(HaveOccurred())
+	locked := withStateLock(st.Err(), fmt.Errorf("error running Plan: %s", st.Err()))
+	g.Expect(locked).To(BeAssignableToTypeOf(&stateLockedError{}))
+	g.Expect(locked.Error()).To(HavePrefix("error running Plan: "))
+
+	st, err = status.New(codes.Internal, "invalid configuration").WithDetails(&runner.PlanReply{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(withStateLock(st.Err(), st.Err())).To(Equal(st.Err()))
+
+	plain := fmt.Errorf("connection refused")
+	g.Expect(withStateLock(plain, plain)).To(Equal(plain))
+}
+
+func TestRetryAfterError(t *testing.T) {
+	g := NewWithT(t)
+
+	notReady := func(reason string) infrav1.Terraform {
+		terraform := infrav1.Terraform{
+			Spec: infrav1.TerraformSpec{
+				RetryInterval: &metav1.Duration{Duration: 15 * time.Second},
+				OnError: &infrav1.OnErrorSpec{
+					StateLocked:   &infrav1.ErrorRetry{RetryAfter: metav1.Duration{Duration: 2 * time.Minute}},
+					QuotaExceeded: &infrav1.ErrorRetry{RetryAfter: metav1.Duration{Duration: time.Hour}},
+					PlanFailed:    &infrav1.ErrorRetry{RetryAfter: metav1.Duration{Duration: 5 * time.Minute}},
+				},
+			},
+		}
+		terraform.Status.Conditions = []metav1.Condition{{Type: meta.ReadyCondition, Status: metav1.ConditionFalse, Reason: reason}}
+		return terraform
+	}
+
+	planErr := fmt.Errorf("error running Plan: exit status 1")
+	terraform := notReady(infrav1.TFExecPlanFailedReason)
+	g.Expect(errorClass(terraform, planErr)).To(Equal(infrav1.ErrorClassPlanFailed))
+	g.Expect(retryAfterError(terraform, planErr, terraform.GetRetryInterval())).To(Equal(5 * time.Minute))
+
+	// the state lock wins over the plan failure it causes
+	locked := &stateLockedError{err: planErr}
+	g.Expect(errorClass(terraform, locked)).To(Equal(infrav1.ErrorClassStateLocked))
+	g.Expect(retryAfterError(terraform, locked, terraform.GetRetryInterval())).To(Equal(2 * time.Minute))
+
+	quota := &quotaExceededError{retryAfter: 30 * time.Second}
+	g.Expect(retryAfterError(terraform, quota, quota.retryAfter)).To(Equal(time.Hour))
+
+	// the classes which are not set fall back
+	terraform = notReady(infrav1.TFExecApplyFailedReason)
+	g.Expect(errorClass(terraform, planErr)).To(Equal(infrav1.ErrorClassApplyFailed))
+	g.Expect(retryAfterError(terraform, planErr, terraform.GetRetryInterval())).To(Equal(15 * time.Second))
+
+	terraform = notReady(infrav1.ArtifactFailedReason)
+	g.Expect(errorClass(terraform, planErr)).To(BeEmpty())
+
+	terraform.Spec.OnError = nil
+	g.Expect(retryAfterError(terraform, quota, quota.retryAfter)).To(Equal(30 * time.Second))
+}
@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRollingOutConsumers(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(appsv1.AddToScheme(scheme)).To(Succeed())
+
+	labels := map[string]string{"app": "api"}
+	rolledOut := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "prod", Labels: labels, Generation: 2},
+		Spec:       appsv1.DeploymentSpec{Replicas: pointer.Int32(2)},
+		Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2},
+	}
+	rollingOut := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "prod", Labels: labels, Generation: 3},
+		Spec:       appsv1.StatefulSetSpec{Replicas: pointer.Int32(3)},
+		Status:     appsv1.StatefulSetStatus{ObservedGeneration: 3, ReadyReplicas: 3, CurrentRevision: "worker-1", UpdateRevision: "worker-2"},
+	}
+	unselected := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "prod", Generation: 2},
+		Status:     appsv1.DaemonSetStatus{ObservedGeneration: 1},
+	}
+	otherNamespace := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "dev", Labels: labels, Generation: 2},
+		Status:     appsv1.DeploymentStatus{ObservedGeneration: 1},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(rolledOut, rollingOut, unselected, otherNamespace).Build()
+	r := &TerraformReconciler{Client: c, APIReader: c}
+
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "database", Namespace: "prod"},
+		Spec: infrav1.TerraformSpec{
+			WriteOutputsToSecret: &infrav1.WriteOutputsToSecretSpec{Name: "database-outputs"},
+		},
+	}
+
+	// no consumers, no gate
+	g.Expect(r.rollingOutConsumers(ctx, terraform)).To(BeEmpty())
+
+	terraform.Spec.WriteOutputsToSecret.Consumers = &infrav1.OutputsConsumers{
+		Selector: metav1.LabelSelector{MatchLabels: labels},
+	}
+	g.Expect(r.rollingOutConsumers(ctx, terraform)).To(Equal([]string{"StatefulSet/worker"}))
+}
+
+func TestWorkloadsRollingOut(t *testing.T) {
+	g := NewWithT(t)
+
+	deployment := appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Generation: 1},
+		Spec:       appsv1.DeploymentSpec{Replicas: pointer.Int32(3)},
+		Status:     appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 4, UpdatedReplicas: 3, AvailableReplicas: 3},
+	}
+	// an old pod is still terminating
+	g.Expect(deploymentRollingOut(deployment)).To(BeTrue())
+	deployment.Status.Replicas = 3
+	g.Expect(deploymentRollingOut(deployment)).To(BeFalse())
+	deployment.Generation = 2
+	g.Expect(deploymentRollingOut(deployment)).To(BeTrue())
+
+	statefulSet := appsv1.StatefulSet{
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: pointer.Int32(3),
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: pointer.Int32(2)},
+			},
+		},
+		Status: appsv1.StatefulSetStatus{ReadyReplicas: 3, UpdatedReplicas: 1, CurrentRevision: "a", UpdateRevision: "b"},
+	}
+	// the pods above the partition are updated
+	g.Expect(statefulSetRollingOut(statefulSet)).To(BeFalse())
+	statefulSet.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType}
+	g.Expect(statefulSetRollingOut(statefulSet)).To(BeFalse())
+	statefulSet.Status.ReadyReplicas = 2
+	g.Expect(statefulSetRollingOut(statefulSet)).To(BeTrue())
+
+	daemonSet := appsv1.DaemonSet{
+		Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, UpdatedNumberScheduled: 2, NumberAvailable: 3},
+	}
+	g.Expect(daemonSetRollingOut(daemonSet)).To(BeTrue())
+	daemonSet.Status.UpdatedNumberScheduled = 3
+	g.Expect(daemonSetRollingOut(daemonSet)).To(BeFalse())
+}
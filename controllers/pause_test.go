@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"testing"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseClusterPause(t *testing.T) {
+	g := NewWithT(t)
+
+	pause := parseClusterPause(&corev1.ConfigMap{Data: map[string]string{
+		"paused":    "true",
+		"reason":    "INC-1234",
+		"allowList": "infra/dns\n# the whole namespace\nteam-a/*\n",
+	}})
+	g.Expect(pause.paused).To(BeTrue())
+	g.Expect(pause.message()).To(Equal("Applies are paused cluster-wide: INC-1234"))
+
+	tf := func(namespace, name string) infrav1.Terraform {
+		return infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+	g.Expect(pause.holds(tf("infra", "dns"))).To(BeFalse())
+	g.Expect(pause.holds(tf("team-a", "app"))).To(BeFalse())
+	g.Expect(pause.holds(tf("infra", "vpc"))).To(BeTrue())
+
+	g.Expect(parseClusterPause(&corev1.ConfigMap{}).paused).To(BeFalse())
+	g.Expect(parseClusterPause(&corev1.ConfigMap{Data: map[string]string{"paused": "false"}}).holds(tf("infra", "vpc"))).To(BeFalse())
+
+	// an invalid ConfigMap pauses all the applies
+	pause = parseClusterPause(&corev1.ConfigMap{Data: map[string]string{"paused": "yes"}})
+	g.Expect(pause.paused).To(BeTrue())
+	pause = parseClusterPause(&corev1.ConfigMap{Data: map[string]string{"paused": "true", "allowList": "dns"}})
+	g.Expect(pause.holds(tf("infra", "dns"))).To(BeTrue())
+	g.Expect(pause.reason).To(ContainSubstring("namespace/name"))
+}
+
+func TestReconcilePauseCondition(t *testing.T) {
+	g := NewWithT(t)
+
+	terraform := infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Namespace: "infra", Name: "vpc"}}
+	pause := clusterPause{paused: true, reason: "INC-1234", allowList: []string{"infra/dns"}}
+
+	terraform, toggled := reconcilePauseCondition(terraform, pause)
+	g.Expect(toggled).To(Equal("Applies are paused cluster-wide: INC-1234"))
+	condition := apimeta.FindStatusCondition(terraform.Status.Conditions, infrav1.ConditionTypePaused)
+	g.Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+	g.Expect(condition.Reason).To(Equal(infrav1.ApplyPausedReason))
+
+	// toggled only once
+	terraform, toggled = reconcilePauseCondition(terraform, pause)
+	g.Expect(toggled).To(BeEmpty())
+
+	terraform = infrav1.TerraformWaiting(terraform, infrav1.WaitingForClusterPause, "Apply held back", 0)
+	terraform, toggled = reconcilePauseCondition(terraform, clusterPause{})
+	g.Expect(toggled).To(Equal("Applies resumed, the cluster-wide pause is over"))
+	g.Expect(apimeta.FindStatusCondition(terraform.Status.Conditions, infrav1.ConditionTypePaused)).To(BeNil())
+	g.Expect(terraform.Status.Waiting).To(BeNil())
+
+	terraform, toggled = reconcilePauseCondition(terraform, clusterPause{})
+	g.Expect(toggled).To(BeEmpty())
+
+	allowed := infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Namespace: "infra", Name: "dns"}}
+	allowed, toggled = reconcilePauseCondition(allowed, pause)
+	g.Expect(toggled).To(Equal("Applies are paused cluster-wide: INC-1234, the object is on the allow-list"))
+	condition = apimeta.FindStatusCondition(allowed.Status.Conditions, infrav1.ConditionTypePaused)
+	g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+	g.Expect(condition.Reason).To(Equal(infrav1.PauseAllowListedReason))
+}
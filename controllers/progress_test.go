@@ -0,0 +1,31 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/weaveworks/tf-controller/runner"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestProgressFromReply(t *testing.T) {
+	g := NewWithT(t)
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	progress := progressFromReply(&runner.ProgressReply{
+		Operation: "apply",
+		Total:     8,
+		Completed: 2,
+		Current:   []string{"a.1", "a.2", "a.3", "a.4", "a.5", "a.6"},
+	}, now)
+	g.Expect(progress.Operation).To(Equal("apply"))
+	g.Expect(progress.Percentage).To(Equal(int32(25)))
+	g.Expect(progress.CurrentResources).To(Equal([]string{"a.1", "a.2", "a.3", "a.4", "a.5"}))
+	g.Expect(progress.UpdatedAt.Time).To(Equal(now))
+
+	// without a total, there is no percentage
+	progress = progressFromReply(&runner.ProgressReply{Operation: "plan", Completed: 3}, now)
+	g.Expect(progress.Completed).To(Equal(int32(3)))
+	g.Expect(progress.Percentage).To(BeZero())
+}
@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+const testDependencyLock = `# This file is maintained automatically by "terraform init".
+# Manual edits may be lost in future updates.
+
+provider "registry.terraform.io/hashicorp/aws" {
+  version     = "5.1.3"
+  constraints = "~> 5.1.0"
+  hashes = [
+    "h1:abc=",
+  ]
+}
+
+provider "registry.terraform.io/hashicorp/random" {
+  version = "3.5.1"
+}
+`
+
+func TestProviderUpgrade(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	recorder := record.NewFakeRecorder(10)
+	r := &TerraformReconciler{
+		Client:        fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Scheme:        scheme,
+		EventRecorder: recorder,
+	}
+
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "network", Namespace: "prod", UID: "uid"},
+		Spec: infrav1.TerraformSpec{
+			ProviderUpgrade: &infrav1.ProviderUpgrade{Interval: metav1.Duration{Duration: 7 * 24 * time.Hour}},
+		},
+		Status: infrav1.TerraformStatus{
+			ProviderLock: &infrav1.ProviderLockStatus{
+				Providers: map[string]string{
+					"registry.terraform.io/hashicorp/aws":   "5.1.0",
+					"registry.terraform.io/hashicorp/local": "2.4.0",
+				},
+			},
+		},
+	}
+	now := time.Date(2023, 7, 8, 10, 0, 0, 0, time.UTC)
+
+	terraform, err := r.recordProviderUpgrade(context.TODO(), terraform, "main@sha1:abc", testDependencyLock, now)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(terraform.Status.ProviderLock.ConfigMapName).To(Equal("network-tf-provider-lock"))
+	g.Expect(terraform.Status.ProviderLock.LastUpgradedAt.Time).To(Equal(now))
+	g.Expect(terraform.Status.ProviderLock.Providers).To(Equal(map[string]string{
+		"registry.terraform.io/hashicorp/aws":    "5.1.3",
+		"registry.terraform.io/hashicorp/random": "3.5.1",
+	}))
+	g.Expect(recorder.Events).To(Receive(HavePrefix("Normal info Providers upgraded: " +
+		"registry.terraform.io/hashicorp/aws 5.1.0 -> 5.1.3, " +
+		"registry.terraform.io/hashicorp/local 2.4.0 (removed), " +
+		"registry.terraform.io/hashicorp/random 3.5.1 (new)")))
+
+	var configMap corev1.ConfigMap
+	g.Expect(r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "prod", Name: "network-tf-provider-lock"}, &configMap)).To(Succeed())
+	g.Expect(configMap.Data[providerLockKey]).To(Equal(testDependencyLock))
+	g.Expect(configMap.OwnerReferences).To(HaveLen(1))
+
+	// the providers are pinned until the next upgrade
+	lock, err := r.pinnedProviderLock(context.TODO(), terraform, now.Add(24*time.Hour))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(lock).To(Equal(testDependencyLock))
+
+	lock, err = r.pinnedProviderLock(context.TODO(), terraform, now.Add(7*24*time.Hour))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(lock).To(BeEmpty())
+
+	terraform.Spec.ProviderUpgrade = nil
+	lock, err = r.pinnedProviderLock(context.TODO(), terraform, now.Add(24*time.Hour))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(lock).To(BeEmpty())
+}
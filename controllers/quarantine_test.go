@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRecordReconcileFailure(t *testing.T) {
+	g := NewWithT(t)
+	now := time.Now()
+
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "tf", Namespace: "flux-system", Generation: 2},
+		Spec: infrav1.TerraformSpec{
+			Quarantine: &infrav1.QuarantineSpec{Threshold: 3, Backoff: &metav1.Duration{Duration: 2 * time.Hour}},
+		},
+	}
+
+	var quarantined bool
+	for i := 0; i < 2; i++ {
+		terraform, quarantined = recordReconcileFailure(terraform, "main/abc", now)
+		g.Expect(quarantined).To(BeFalse())
+	}
+	g.Expect(terraform.Status.ConsecutiveFailures).To(Equal(int32(2)))
+	g.Expect(terraform.Status.Quarantine).To(BeNil())
+
+	terraform, quarantined = recordReconcileFailure(terraform, "main/abc", now)
+	g.Expect(quarantined).To(BeTrue())
+	g.Expect(terraform.Status.Quarantine.Revision).To(Equal("main/abc"))
+	g.Expect(terraform.Status.Quarantine.Generation).To(Equal(int64(2)))
+	g.Expect(terraform.Status.Quarantine.NextRetryAt.Time).To(BeTemporally("~", now.Add(2*time.Hour), time.Second))
+	condition := apimeta.FindStatusCondition(terraform.Status.Conditions, infrav1.ConditionTypeQuarantined)
+	g.Expect(condition).ToNot(BeNil())
+	g.Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+	g.Expect(condition.Message).To(Equal("Quarantined after 3 consecutive failures, next retry in 2h0m0s"))
+
+	// quarantined again on the failure of the retry, since the same time
+	since := terraform.Status.Quarantine.Since
+	terraform, quarantined = recordReconcileFailure(terraform, "main/abc", now.Add(2*time.Hour))
+	g.Expect(quarantined).To(BeTrue())
+	g.Expect(terraform.Status.Quarantine.Since).To(Equal(since))
+
+	terraform = recordReconcileSuccess(terraform)
+	g.Expect(terraform.Status.ConsecutiveFailures).To(BeZero())
+	g.Expect(terraform.Status.Quarantine).To(BeNil())
+	g.Expect(apimeta.FindStatusCondition(terraform.Status.Conditions, infrav1.ConditionTypeQuarantined)).To(BeNil())
+}
+
+func TestRecordReconcileFailureWithoutQuarantine(t *testing.T) {
+	g := NewWithT(t)
+
+	terraform := infrav1.Terraform{}
+	var quarantined bool
+	for i := 0; i < 10; i++ {
+		terraform, quarantined = recordReconcileFailure(terraform, "main/abc", time.Now())
+		g.Expect(quarantined).To(BeFalse())
+	}
+	g.Expect(terraform.Status.ConsecutiveFailures).To(Equal(int32(10)))
+}
+
+func TestQuarantineHold(t *testing.T) {
+	g := NewWithT(t)
+	now := time.Now()
+
+	quarantinedTerraform := func() infrav1.Terraform {
+		terraform := infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Generation: 2},
+			Spec:       infrav1.TerraformSpec{Quarantine: &infrav1.QuarantineSpec{}},
+		}
+		terraform.Status.ConsecutiveFailures = 5
+		return infrav1.TerraformQuarantined(terraform, "main/abc", "Quarantined", now.Add(time.Hour))
+	}
+
+	terraform, hold := quarantineHold(quarantinedTerraform(), "main/abc", false, now)
+	g.Expect(hold).To(Equal(time.Hour))
+	g.Expect(terraform.Status.Quarantine).ToNot(BeNil())
+
+	// retried once the backoff is over, still quarantined
+	terraform, hold = quarantineHold(quarantinedTerraform(), "main/abc", false, now.Add(time.Hour))
+	g.Expect(hold).To(BeZero())
+	g.Expect(terraform.Status.ConsecutiveFailures).To(Equal(int32(5)))
+
+	// released by a new revision, a change of the spec or a reconcile request
+	terraform, hold = quarantineHold(quarantinedTerraform(), "main/def", false, now)
+	g.Expect(hold).To(BeZero())
+	g.Expect(terraform.Status.Quarantine).To(BeNil())
+	g.Expect(terraform.Status.ConsecutiveFailures).To(BeZero())
+
+	changed := quarantinedTerraform()
+	changed.Generation = 3
+	terraform, hold = quarantineHold(changed, "main/abc", false, now)
+	g.Expect(hold).To(BeZero())
+	g.Expect(terraform.Status.Quarantine).To(BeNil())
+
+	terraform, hold = quarantineHold(quarantinedTerraform(), "main/abc", true, now)
+	g.Expect(hold).To(BeZero())
+	g.Expect(terraform.Status.Quarantine).To(BeNil())
+}
+
+func TestIsReconcileFailure(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(isReconcileFailure(nil)).To(BeFalse())
+	g.Expect(isReconcileFailure(errors.New(infrav1.DriftDetectedReason))).To(BeFalse())
+	g.Expect(isReconcileFailure(fmt.Errorf("wrapped: %w", &quotaExceededError{message: "quota"}))).To(BeFalse())
+	g.Expect(isReconcileFailure(&applyPausedError{message: "paused"})).To(BeFalse())
+	g.Expect(isReconcileFailure(errors.New("error running Plan"))).To(BeTrue())
+}
@@ -0,0 +1,212 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func quotaTestReconciler(g *WithT, objects ...client.Object) *TerraformReconciler {
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).
+		WithStatusSubresource(&infrav1.TerraformNamespacePolicy{}).Build()
+	return &TerraformReconciler{Client: c, APIReader: c}
+}
+
+func quotaTerraform(name string, created time.Time) *infrav1.Terraform {
+	return &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a", CreationTimestamp: metav1.NewTime(created)},
+	}
+}
+
+func TestNamespaceQuota(t *testing.T) {
+	g := NewWithT(t)
+
+	r := quotaTestReconciler(g,
+		&infrav1.TerraformNamespacePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "team-a"},
+			Spec: infrav1.TerraformNamespacePolicySpec{Quota: &infrav1.NamespaceQuota{
+				MaxTerraforms:        int32Ptr(10),
+				MaxConcurrentRunners: int32Ptr(2),
+			}},
+		},
+		&infrav1.TerraformNamespacePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "team-a"},
+			Spec: infrav1.TerraformNamespacePolicySpec{Quota: &infrav1.NamespaceQuota{
+				MaxTerraforms:     int32Ptr(5),
+				MaxAppliesPerHour: int32Ptr(3),
+			}},
+		},
+		&infrav1.TerraformNamespacePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: "team-a"},
+		},
+	)
+
+	quota, err := r.namespaceQuota(context.TODO(), "team-a")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(quota).To(Equal(infrav1.NamespaceQuota{
+		MaxTerraforms:        int32Ptr(5),
+		MaxConcurrentRunners: int32Ptr(2),
+		MaxAppliesPerHour:    int32Ptr(3),
+	}))
+
+	quota, err = r.namespaceQuota(context.TODO(), "team-b")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(quota).To(Equal(infrav1.NamespaceQuota{}))
+}
+
+func TestCheckRunnerQuota(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Now()
+	first, second, third := quotaTerraform("first", now.Add(-2*time.Hour)), quotaTerraform("second", now.Add(-time.Hour)), quotaTerraform("third", now)
+	runnerPod := func(name string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "-tf-runner",
+			Namespace: "team-a",
+			Labels:    map[string]string{infrav1.RunnerLabel: "team-a"},
+		}}
+	}
+
+	r := quotaTestReconciler(g, first, second, third, runnerPod("first"),
+		&infrav1.TerraformNamespacePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: "team-a"},
+			Spec: infrav1.TerraformNamespacePolicySpec{Quota: &infrav1.NamespaceQuota{
+				MaxTerraforms:        int32Ptr(2),
+				MaxConcurrentRunners: int32Ptr(1),
+			}},
+		},
+	)
+
+	// the first one already has its runner
+	_, err := r.checkRunnerQuota(context.TODO(), *first)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// the second one waits for the runner of the first one
+	var quotaExceeded *quotaExceededError
+	_, err = r.checkRunnerQuota(context.TODO(), *second)
+	g.Expect(errors.As(err, &quotaExceeded)).To(BeTrue())
+	g.Expect(quotaExceeded.Error()).To(ContainSubstring("allows 1 concurrent runners"))
+	g.Expect(quotaExceeded.retryAfter).To(Equal(quotaRetryInterval))
+	g.Expect(quotaExceeded.waitingFor).To(Equal(infrav1.WaitingForRunnerCapacity))
+
+	// the third one is beyond the maximum number of Terraform objects
+	_, err = r.checkRunnerQuota(context.TODO(), *third)
+	g.Expect(errors.As(err, &quotaExceeded)).To(BeTrue())
+	g.Expect(quotaExceeded.Error()).To(ContainSubstring("allows 2 Terraform objects, this one is number 3"))
+	g.Expect(quotaExceeded.waitingFor).To(Equal(infrav1.WaitingForTerraformQuota))
+}
+
+func TestCheckRunnerQuotaReservesTheRunner(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Now()
+	first, second := quotaTerraform("first", now.Add(-time.Hour)), quotaTerraform("second", now)
+	r := quotaTestReconciler(g, first, second,
+		&infrav1.TerraformNamespacePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: "team-a"},
+			Spec: infrav1.TerraformNamespacePolicySpec{Quota: &infrav1.NamespaceQuota{
+				MaxConcurrentRunners: int32Ptr(1),
+			}},
+		},
+	)
+
+	// the runner of the first one is not created yet, but reserved
+	release, err := r.checkRunnerQuota(context.TODO(), *first)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var quotaExceeded *quotaExceededError
+	_, err = r.checkRunnerQuota(context.TODO(), *second)
+	g.Expect(errors.As(err, &quotaExceeded)).To(BeTrue())
+
+	release()
+	_, err = r.checkRunnerQuota(context.TODO(), *second)
+	g.Expect(err).ToNot(HaveOccurred())
+}
+
+func TestWaitingPosition(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Now().Truncate(time.Second)
+	waiting := func(name, reason string, since time.Time) *infrav1.Terraform {
+		terraform := quotaTerraform(name, now)
+		terraform.Status.Waiting = &infrav1.WaitingStatus{Reason: reason, Since: metav1.NewTime(since)}
+		return terraform
+	}
+	first := waiting("first", infrav1.WaitingForRunnerCapacity, now.Add(-time.Hour))
+	second := waiting("second", infrav1.WaitingForRunnerCapacity, now.Add(-time.Minute))
+	third := waiting("third", infrav1.WaitingForRunnerCapacity, now.Add(-time.Minute))
+	locked := waiting("locked", infrav1.WaitingForStateLock, now.Add(-2*time.Hour))
+
+	r := quotaTestReconciler(g, first, second, third, locked, quotaTerraform("running", now))
+
+	for _, tt := range []struct {
+		terraform *infrav1.Terraform
+		position  int32
+	}{
+		{first, 1},
+		{second, 2},
+		{third, 3},
+		{locked, 1},
+	} {
+		position, err := r.waitingPosition(context.TODO(), *tt.terraform)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(position).To(Equal(tt.position), tt.terraform.Name)
+	}
+}
+
+func TestCheckApplyQuota(t *testing.T) {
+	g := NewWithT(t)
+
+	terraform := quotaTerraform("helloworld", time.Now())
+	r := quotaTestReconciler(g, terraform,
+		&infrav1.TerraformNamespacePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: "team-a"},
+			Spec: infrav1.TerraformNamespacePolicySpec{Quota: &infrav1.NamespaceQuota{
+				MaxAppliesPerHour: int32Ptr(2),
+			}},
+		},
+	)
+
+	// the applies are recorded to the second
+	now := time.Now().Truncate(time.Second)
+	g.Expect(r.checkApplyQuota(context.TODO(), *terraform, now.Add(-50*time.Minute))).To(Succeed())
+	g.Expect(r.checkApplyQuota(context.TODO(), *terraform, now.Add(-10*time.Minute))).To(Succeed())
+
+	var quotaExceeded *quotaExceededError
+	err := r.checkApplyQuota(context.TODO(), *terraform, now)
+	g.Expect(errors.As(err, &quotaExceeded)).To(BeTrue())
+	g.Expect(quotaExceeded.retryAfter).To(Equal(10 * time.Minute))
+
+	// the applies are recorded in the policy, for a restarted controller
+	// to count them
+	restarted := &TerraformReconciler{Client: r.Client, APIReader: r.APIReader}
+	err = restarted.checkApplyQuota(context.TODO(), *terraform, now)
+	g.Expect(errors.As(err, &quotaExceeded)).To(BeTrue())
+
+	var policy infrav1.TerraformNamespacePolicy
+	g.Expect(r.Client.Get(context.TODO(), client.ObjectKey{Namespace: "team-a", Name: "quota"}, &policy)).To(Succeed())
+	g.Expect(policy.Status.Applies).To(HaveLen(2))
+
+	// the first apply is out of the window after an hour
+	g.Expect(restarted.checkApplyQuota(context.TODO(), *terraform, now.Add(10*time.Minute))).To(Succeed())
+	g.Expect(r.Client.Get(context.TODO(), client.ObjectKey{Namespace: "team-a", Name: "quota"}, &policy)).To(Succeed())
+	g.Expect(policy.Status.Applies).To(HaveLen(2))
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-exec/tfexec"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestEvaluateReadyWhen(t *testing.T) {
+	g := NewWithT(t)
+
+	outputs := map[string]tfexec.OutputMeta{
+		"instance_count": {Type: json.RawMessage(`"number"`), Value: json.RawMessage(`3`)},
+		"ratio":          {Type: json.RawMessage(`"number"`), Value: json.RawMessage(`0.5`)},
+		"endpoint":       {Type: json.RawMessage(`"string"`), Value: json.RawMessage(`"https://example.com"`)},
+		"subnets":        {Type: json.RawMessage(`["list","string"]`), Value: json.RawMessage(`["a","b"]`)},
+	}
+	conditions := []metav1.Condition{
+		{Type: infrav1.ConditionTypeApply, Status: metav1.ConditionTrue, Reason: infrav1.TFExecApplySucceedReason},
+	}
+
+	for _, tt := range []struct {
+		expression string
+		ready      bool
+	}{
+		{`outputs.instance_count >= 3 && conditions['Apply'].status == 'True'`, true},
+		{`outputs.instance_count > 3`, false},
+		{`outputs.ratio < 1.0`, true},
+		{`outputs.endpoint.startsWith('https://')`, true},
+		{`size(outputs.subnets) == 2`, true},
+		{`'HealthCheck' in conditions`, false},
+	} {
+		ready, err := evaluateReadyWhen(tt.expression, outputs, conditions)
+		g.Expect(err).ToNot(HaveOccurred(), tt.expression)
+		g.Expect(ready).To(Equal(tt.ready), tt.expression)
+	}
+
+	_, err := evaluateReadyWhen(`outputs.instance_count`, outputs, conditions)
+	g.Expect(err).To(MatchError(ContainSubstring("must return a bool")))
+
+	_, err = evaluateReadyWhen(`outputs.instance_count >=`, outputs, conditions)
+	g.Expect(err).To(MatchError(ContainSubstring("invalid readiness expression")))
+
+	_, err = evaluateReadyWhen(`outputs.missing == 1`, outputs, conditions)
+	g.Expect(err).To(MatchError(ContainSubstring("unable to evaluate")))
+}
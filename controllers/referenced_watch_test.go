@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestIndexByReferenced(t *testing.T) {
+	g := NewWithT(t)
+
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+		Spec: infrav1.TerraformSpec{
+			VarsFrom: []infrav1.VarsReference{
+				{Kind: "Secret", Name: "vars-secret"},
+				{Kind: "ConfigMap", Name: "vars-cm"},
+			},
+			BackendConfigsFrom: []infrav1.BackendConfigsReference{
+				{Kind: "Secret", Name: "backend-secret"},
+			},
+			GitCredentials:     &infrav1.GitCredentials{SecretRef: meta.LocalObjectReference{Name: "git-credentials"}},
+			CliConfigSecretRef: &corev1.SecretReference{Name: "cli-config", Namespace: "tf-system"},
+		},
+	}
+
+	r := &TerraformReconciler{}
+	g.Expect(r.IndexByReferenced("Secret")(terraform)).To(Equal([]string{
+		"flux-system/vars-secret",
+		"flux-system/backend-secret",
+		"flux-system/git-credentials",
+		"tf-system/cli-config",
+	}))
+	g.Expect(r.IndexByReferenced("ConfigMap")(terraform)).To(Equal([]string{
+		"flux-system/vars-cm",
+	}))
+}
+
+func TestReferencedDataChangePredicate(t *testing.T) {
+	g := NewWithT(t)
+	p := ReferencedDataChangePredicate{}
+
+	oldSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vars", ResourceVersion: "1"},
+		Data:       map[string][]byte{"region": []byte("eu-west-1")},
+	}
+
+	relabeled := oldSecret.DeepCopy()
+	relabeled.ResourceVersion = "2"
+	relabeled.Labels = map[string]string{"team": "infra"}
+	g.Expect(p.Update(event.UpdateEvent{ObjectOld: oldSecret, ObjectNew: relabeled})).To(BeFalse())
+
+	changed := oldSecret.DeepCopy()
+	changed.Data["region"] = []byte("us-east-1")
+	g.Expect(p.Update(event.UpdateEvent{ObjectOld: oldSecret, ObjectNew: changed})).To(BeTrue())
+
+	oldConfigMap := &corev1.ConfigMap{Data: map[string]string{"region": "eu-west-1"}}
+	newConfigMap := &corev1.ConfigMap{Data: map[string]string{"region": "us-east-1"}}
+	g.Expect(p.Update(event.UpdateEvent{ObjectOld: oldConfigMap, ObjectNew: newConfigMap})).To(BeTrue())
+	g.Expect(p.Update(event.UpdateEvent{ObjectOld: oldConfigMap, ObjectNew: oldConfigMap.DeepCopy()})).To(BeFalse())
+}
@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestShouldRefresh(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Now()
+	r := &TerraformReconciler{}
+	terraform := infrav1.Terraform{}
+	g.Expect(r.shouldRefresh(terraform, now)).To(BeFalse())
+
+	terraform.Spec.Refresh = &infrav1.RefreshSpec{Interval: &metav1.Duration{Duration: time.Hour}}
+	g.Expect(r.shouldRefresh(terraform, now)).To(BeTrue())
+
+	terraform.Status.Refresh = &infrav1.RefreshStatus{LastRefreshedAt: &metav1.Time{Time: now.Add(-30 * time.Minute)}}
+	g.Expect(r.shouldRefresh(terraform, now)).To(BeFalse())
+	g.Expect(r.shouldRefresh(terraform, now.Add(30*time.Minute))).To(BeTrue())
+
+	// a pending plan would become stale
+	terraform.Status.Plan.Pending = "plan-main-abc"
+	g.Expect(r.shouldRefresh(terraform, now.Add(time.Hour))).To(BeFalse())
+	terraform.Status.Plan.Pending = ""
+
+	terraform.Spec.PlanOnly = true
+	g.Expect(r.shouldRefresh(terraform, now.Add(time.Hour))).To(BeFalse())
+}
+
+func TestPlanRefreshes(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Now()
+	terraform := infrav1.Terraform{}
+	g.Expect(planRefreshes(terraform, now)).To(BeTrue())
+
+	terraform.Spec.Refresh = &infrav1.RefreshSpec{Interval: &metav1.Duration{Duration: time.Hour}}
+	g.Expect(planRefreshes(terraform, now)).To(BeTrue())
+
+	terraform.Spec.Refresh.SkipOnPlan = true
+	g.Expect(planRefreshes(terraform, now)).To(BeFalse())
+
+	terraform.Spec.Refresh.FullPlanInterval = &metav1.Duration{Duration: 24 * time.Hour}
+	g.Expect(planRefreshes(terraform, now)).To(BeTrue())
+
+	terraform = recordFullPlan(terraform, true, now)
+	g.Expect(terraform.Status.Refresh.LastFullPlanAt.Time).To(Equal(now))
+	g.Expect(planRefreshes(terraform, now.Add(time.Hour))).To(BeFalse())
+	g.Expect(planRefreshes(terraform, now.Add(24*time.Hour))).To(BeTrue())
+
+	// the plans without refresh are not full plans
+	terraform = recordFullPlan(terraform, false, now.Add(time.Hour))
+	g.Expect(terraform.Status.Refresh.LastFullPlanAt.Time).To(Equal(now))
+}
+
+func TestRequeueBeforeRefresh(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Now()
+	result := ctrl.Result{RequeueAfter: 6 * time.Hour}
+	terraform := infrav1.Terraform{}
+	g.Expect(requeueBeforeRefresh(terraform, result, now)).To(Equal(result))
+
+	terraform.Spec.Refresh = &infrav1.RefreshSpec{Interval: &metav1.Duration{Duration: time.Hour}}
+	terraform.Status.Refresh = &infrav1.RefreshStatus{LastRefreshedAt: &metav1.Time{Time: now.Add(-20 * time.Minute)}}
+	g.Expect(requeueBeforeRefresh(terraform, result, now).RequeueAfter).To(Equal(40 * time.Minute))
+	g.Expect(requeueBeforeRefresh(terraform, ctrl.Result{RequeueAfter: 10 * time.Minute}, now).RequeueAfter).To(Equal(10 * time.Minute))
+
+	// a refresh overdue, e.g. held back by a pending plan, waits for another interval
+	terraform.Status.Refresh.LastRefreshedAt = &metav1.Time{Time: now.Add(-2 * time.Hour)}
+	g.Expect(requeueBeforeRefresh(terraform, result, now).RequeueAfter).To(Equal(time.Hour))
+}
@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRegistryCredentialsEnvs(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+	r := &TerraformReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "hcp-token", Namespace: "flux-system"},
+				Data:       map[string][]byte{"token": []byte("atlasv1.token")},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "tfe-token", Namespace: "flux-system"},
+				Data:       map[string][]byte{"token": []byte("tfe-token")},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "empty", Namespace: "flux-system"},
+			},
+		).Build(),
+	}
+
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+		Spec: infrav1.TerraformSpec{
+			RegistryCredentials: []infrav1.RegistryCredentials{
+				{SecretRef: meta.LocalObjectReference{Name: "hcp-token"}},
+				{Hostname: "tfe.my-corp.com", SecretRef: meta.LocalObjectReference{Name: "tfe-token"}},
+			},
+		},
+	}
+	envs, err := r.registryCredentialsEnvs(context.TODO(), terraform)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(envs).To(Equal(map[string]string{
+		"TF_TOKEN_app_terraform_io": "atlasv1.token",
+		"TF_TOKEN_tfe_my__corp_com": "tfe-token",
+	}))
+
+	terraform.Spec.RegistryCredentials = []infrav1.RegistryCredentials{{SecretRef: meta.LocalObjectReference{Name: "empty"}}}
+	_, err = r.registryCredentialsEnvs(context.TODO(), terraform)
+	g.Expect(err).To(MatchError(ContainSubstring("has no token key")))
+
+	terraform.Spec.RegistryCredentials = []infrav1.RegistryCredentials{{SecretRef: meta.LocalObjectReference{Name: "missing"}}}
+	_, err = r.registryCredentialsEnvs(context.TODO(), terraform)
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestClusterBackend(t *testing.T) {
+	g := NewWithT(t)
+
+	for _, tt := range []struct {
+		spec infrav1.TerraformSpec
+		want string
+	}{
+		{spec: infrav1.TerraformSpec{}, want: "the default Kubernetes backend"},
+		{spec: infrav1.TerraformSpec{BackendConfig: &infrav1.BackendConfigSpec{SecretSuffix: "prod", InClusterConfig: true}}, want: "the Kubernetes backend"},
+		{spec: infrav1.TerraformSpec{BackendConfig: &infrav1.BackendConfigSpec{
+			CustomConfiguration: `backend "kubernetes" { secret_suffix = "prod" }`,
+		}}, want: "the kubernetes backend of its custom configuration"},
+		{spec: infrav1.TerraformSpec{BackendConfig: &infrav1.BackendConfigSpec{
+			CustomConfiguration: `backend  "local" {}`,
+		}}, want: "the local backend of its custom configuration"},
+		{spec: infrav1.TerraformSpec{BackendConfig: &infrav1.BackendConfigSpec{
+			CustomConfiguration: `backend "s3" { bucket = "state" }`,
+		}}, want: ""},
+		{spec: infrav1.TerraformSpec{BackendConfig: &infrav1.BackendConfigSpec{Disable: true}}, want: ""},
+		{spec: infrav1.TerraformSpec{Cloud: &infrav1.CloudSpec{Organization: "org"}}, want: ""},
+	} {
+		g.Expect(clusterBackend(infrav1.Terraform{Spec: tt.spec})).To(Equal(tt.want))
+	}
+}
+
+func TestRemoteBackendViolation(t *testing.T) {
+	g := NewWithT(t)
+
+	r := quotaTestReconciler(g,
+		&infrav1.TerraformNamespacePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "resilience", Namespace: "prod"},
+			Spec:       infrav1.TerraformNamespacePolicySpec{RequireRemoteBackend: true},
+		},
+		&infrav1.TerraformNamespacePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "guardrails", Namespace: "dev"},
+			Spec:       infrav1.TerraformNamespacePolicySpec{DisallowAutoApprove: true},
+		},
+	)
+
+	terraform := infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "prod"}}
+	violation, err := r.remoteBackendViolation(context.TODO(), terraform)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(violation).To(Equal("The namespace policy resilience requires a remote backend, the state of the Terraform object is kept with the default Kubernetes backend"))
+
+	terraform.Spec.BackendConfig = &infrav1.BackendConfigSpec{CustomConfiguration: `backend "gcs" { bucket = "state" }`}
+	violation, err = r.remoteBackendViolation(context.TODO(), terraform)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(violation).To(BeEmpty())
+
+	// the policies of other namespaces do not apply
+	terraform = infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "dev"}}
+	violation, err = r.remoteBackendViolation(context.TODO(), terraform)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(violation).To(BeEmpty())
+}
+
+func TestNamespacePolicyDefaulterRequiresRemoteBackend(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	d := &NamespacePolicyDefaulter{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(&infrav1.TerraformNamespacePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "resilience", Namespace: "prod"},
+			Spec:       infrav1.TerraformNamespacePolicySpec{RequireRemoteBackend: true},
+		}).Build(),
+	}
+
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "prod"},
+		Spec: infrav1.TerraformSpec{
+			BackendConfig: &infrav1.BackendConfigSpec{SecretSuffix: "helloworld", InClusterConfig: true},
+		},
+	}
+	err := d.Default(context.Background(), terraform)
+	g.Expect(apierrors.IsForbidden(err)).To(BeTrue())
+	g.Expect(err.Error()).To(ContainSubstring("requires a remote backend"))
+
+	terraform.Spec.BackendConfig = &infrav1.BackendConfigSpec{CustomConfiguration: `backend "s3" { bucket = "state" }`}
+	g.Expect(d.Default(context.Background(), terraform)).To(Succeed())
+
+	// an object being deleted is let through, for its finalizer to be removed
+	now := metav1.Now()
+	terraform.DeletionTimestamp = &now
+	terraform.Spec.BackendConfig = nil
+	g.Expect(d.Default(context.Background(), terraform)).To(Succeed())
+}
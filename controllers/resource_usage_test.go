@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/runner"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/gomega"
+)
+
+// usageRunnerClient replies to GetResourceUsage with the given replies, in
+// order.
+type usageRunnerClient struct {
+	runner.RunnerClient
+	replies []*runner.GetResourceUsageReply
+	err     error
+}
+
+func (c *usageRunnerClient) GetResourceUsage(ctx context.Context, in *runner.GetResourceUsageRequest, opts ...grpc.CallOption) (*runner.GetResourceUsageReply, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	reply := c.replies[0]
+	c.replies = c.replies[1:]
+	return reply, nil
+}
+
+func TestResourceUsage(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	usage := newResourceUsage(&usageRunnerClient{replies: []*runner.GetResourceUsageReply{
+		{CpuSeconds: 1.5, PeakMemoryBytes: 64 << 20},
+		{CpuSeconds: 4, PeakMemoryBytes: 128 << 20},
+		{CpuSeconds: 4, PeakMemoryBytes: 128 << 20},
+		{CpuSeconds: 10.25, PeakMemoryBytes: 512 << 20},
+	}})
+	usage.measure(ctx, stageSetup)()
+	usage.measure(ctx, stagePlan)()
+
+	terraform := &infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "usage", Namespace: "flux-system"}}
+	usage.record(terraform, "main@sha1:abc")
+
+	g.Expect(terraform.Status.ResourceUsage).ToNot(BeNil())
+	g.Expect(terraform.Status.ResourceUsage.Revision).To(Equal("main@sha1:abc"))
+	stages := terraform.Status.ResourceUsage.Stages
+	g.Expect(stages).To(HaveLen(2))
+	g.Expect(stages[0].Name).To(Equal(stageSetup))
+	g.Expect(stages[0].CPUTime.Duration).To(Equal(2500 * time.Millisecond))
+	g.Expect(stages[0].PeakMemory.String()).To(Equal("128Mi"))
+	g.Expect(stages[1].Name).To(Equal(stagePlan))
+	g.Expect(stages[1].CPUTime.Duration).To(Equal(6250 * time.Millisecond))
+	g.Expect(stages[1].PeakMemory.String()).To(Equal("512Mi"))
+
+	g.Expect(testutil.ToFloat64(runnerStageCPUSeconds.WithLabelValues("flux-system", "usage", stagePlan))).To(Equal(6.25))
+	g.Expect(testutil.ToFloat64(runnerPeakMemoryBytes.WithLabelValues("flux-system", "usage", stagePlan))).To(Equal(float64(512 << 20)))
+
+	deleteResourceUsageMetrics(*terraform)
+	g.Expect(testutil.CollectAndCount(runnerStageDurationSeconds, "tf_controller_runner_stage_duration_seconds")).To(BeZero())
+}
+
+func TestResourceUsageOfAnOlderRunner(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	runnerClient := &usageRunnerClient{err: status.Error(codes.Unimplemented, "GetResourceUsage requires runner protocol version 9")}
+	usage := newResourceUsage(runnerClient)
+	usage.measure(ctx, stageSetup)()
+	usage.measure(ctx, stageApply)()
+
+	terraform := &infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "older-runner", Namespace: "flux-system"}}
+	usage.record(terraform, "main@sha1:abc")
+
+	// only the durations are recorded
+	stages := terraform.Status.ResourceUsage.Stages
+	g.Expect(stages).To(HaveLen(2))
+	g.Expect(stages[1].Name).To(Equal(stageApply))
+	g.Expect(stages[1].CPUTime).To(BeNil())
+	g.Expect(stages[1].PeakMemory).To(BeNil())
+	g.Expect(usage.unsupported).To(BeTrue())
+}
+
+func TestRecordResourceTimings(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	terraform := infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "timings", Namespace: "flux-system"}}
+	recordResourceTimings(ctx, terraform, []*runner.ResourceTiming{
+		{Address: "aws_instance.web", Action: "create", Seconds: 181.5},
+		{Address: "aws_instance.old", Action: "delete", Seconds: 1.5},
+	})
+	g.Expect(testutil.ToFloat64(resourceApplyDurationSeconds.WithLabelValues("flux-system", "timings", "aws_instance.web", "create"))).To(Equal(181.5))
+
+	// the next apply replaces the timings, unless its runner reports none
+	recordResourceTimings(ctx, terraform, []*runner.ResourceTiming{
+		{Address: "aws_instance.web", Action: "update", Seconds: 2},
+	})
+	recordResourceTimings(ctx, terraform, nil)
+	g.Expect(testutil.CollectAndCount(resourceApplyDurationSeconds, "tf_controller_resource_apply_duration_seconds")).To(Equal(1))
+
+	deleteResourceTimingMetrics(terraform)
+	g.Expect(testutil.CollectAndCount(resourceApplyDurationSeconds, "tf_controller_resource_apply_duration_seconds")).To(BeZero())
+}
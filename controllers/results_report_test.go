@@ -0,0 +1,125 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestResultsReports(t *testing.T) {
+	g := NewWithT(t)
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+		Spec:       infrav1.TerraformSpec{Path: "./terraform"},
+		Status: infrav1.TerraformStatus{
+			Conditions: []metav1.Condition{
+				{Type: "Ready", Status: metav1.ConditionFalse, Reason: infrav1.HealthChecksFailedReason},
+				{Type: infrav1.ConditionTypePlan, Status: metav1.ConditionTrue, Reason: infrav1.PlannedWithChangesReason, Message: "Plan generated"},
+				{Type: infrav1.ConditionTypeApply, Status: metav1.ConditionTrue, Reason: infrav1.TFExecApplySucceedReason, Message: "Applied successfully"},
+				{Type: infrav1.ConditionTypeHealthCheck, Status: metav1.ConditionFalse, Reason: infrav1.HealthChecksFailedReason, Message: "unable to reach https://example.com"},
+			},
+		},
+	}
+
+	junit, err := junitReport(terraform, "main/abc1234", now)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(junit).To(ContainSubstring(`<testsuite name="flux-system/helloworld/Validate" tests="1" failures="0" skipped="0" timestamp="2023-06-01T00:00:00Z">`))
+	g.Expect(junit).To(ContainSubstring(`<testsuite name="flux-system/helloworld/Plan" tests="1" failures="0" skipped="0" timestamp="2023-06-01T00:00:00Z">`))
+	g.Expect(junit).To(ContainSubstring(`<testsuite name="flux-system/helloworld/HealthCheck" tests="1" failures="1" skipped="0" timestamp="2023-06-01T00:00:00Z">`))
+	g.Expect(junit).To(ContainSubstring(`<property name="revision" value="main/abc1234"></property>`))
+	g.Expect(junit).To(ContainSubstring(`<failure message="HealthChecksFailed" type="HealthChecksFailed">unable to reach https://example.com</failure>`))
+
+	sarif, err := sarifReport(terraform, "main/abc1234")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var report sarifLog
+	g.Expect(json.Unmarshal([]byte(sarif), &report)).To(Succeed())
+	g.Expect(report.Runs).To(HaveLen(1))
+	g.Expect(report.Runs[0].Results).To(HaveLen(1))
+	g.Expect(report.Runs[0].Results[0].RuleID).To(Equal("HealthCheck/HealthChecksFailed"))
+	g.Expect(report.Runs[0].Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI).To(Equal("./terraform"))
+
+	// the failed validation of the configuration
+	terraform.Status.Conditions[1] = metav1.Condition{Type: infrav1.ConditionTypePlan, Status: metav1.ConditionFalse, Reason: infrav1.TFExecPlanFailedReason}
+	terraform.Status.VariableValidationErrors = []infrav1.VariableValidationError{{Variable: "region", Message: "unknown region"}}
+	terraform.Status.Errors = []infrav1.TerraformError{
+		{Severity: "error", Summary: "Unsupported argument", Detail: `An argument named "foo" is not expected here.`, File: "terraform/main.tf", Line: 12, Column: 3},
+		{Severity: "error", Summary: "Error creating the bucket"},
+	}
+
+	junit, err = junitReport(terraform, "main/abc1234", now)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(junit).To(ContainSubstring(`<testsuite name="flux-system/helloworld/Validate" tests="2" failures="2" skipped="0" timestamp="2023-06-01T00:00:00Z">`))
+	g.Expect(junit).To(ContainSubstring(`<testcase name="variable region" classname="flux-system/helloworld">`))
+	g.Expect(junit).To(ContainSubstring(`<testcase name="terraform/main.tf:12" classname="flux-system/helloworld">`))
+
+	sarif, err = sarifReport(terraform, "main/abc1234")
+	g.Expect(err).ToNot(HaveOccurred())
+	report = sarifLog{}
+	g.Expect(json.Unmarshal([]byte(sarif), &report)).To(Succeed())
+	g.Expect(report.Runs[0].Results).To(HaveLen(5))
+	g.Expect(report.Runs[0].Results[2].RuleID).To(Equal("Validate/VariableValidationFailed"))
+	g.Expect(report.Runs[0].Results[3].RuleID).To(Equal("Terraform/error"))
+	g.Expect(report.Runs[0].Results[3].Locations[0].PhysicalLocation).To(Equal(sarifPhysicalLocation{
+		ArtifactLocation: sarifArtifactLocation{URI: "terraform/main.tf"},
+		Region:           &sarifRegion{StartLine: 12, StartColumn: 3},
+	}))
+	g.Expect(report.Runs[0].Results[4].Locations[0].PhysicalLocation.ArtifactLocation.URI).To(Equal("./terraform"))
+	g.Expect(report.Runs[0].Tool.Driver.Rules).To(HaveLen(4))
+}
+
+func TestUploadResults(t *testing.T) {
+	g := NewWithT(t)
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	uploads := map[string]string{}
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		uploads[req.Method+" "+req.URL.Path] = string(body)
+		authorization = req.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	r := &TerraformReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "results-bucket", Namespace: "flux-system"},
+			Data:       map[string][]byte{"accesskey": []byte("AKIAEXAMPLE"), "secretkey": []byte("secret")},
+		}).Build(),
+	}
+
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+		Spec: infrav1.TerraformSpec{ResultsExport: &infrav1.ResultsExportSpec{Bucket: &infrav1.ResultsBucketSpec{
+			Endpoint:   server.URL,
+			BucketName: "results",
+			Prefix:     "/tf-controller/",
+			SecretRef:  &meta.LocalObjectReference{Name: "results-bucket"},
+		}}},
+	}
+
+	data := map[string]string{resultsJUnitKey: "<testsuites></testsuites>", resultsSARIFKey: "{}"}
+	g.Expect(r.uploadResults(context.TODO(), terraform, "main/abc1234", now, data)).To(Succeed())
+	g.Expect(uploads).To(Equal(map[string]string{
+		"PUT /results/tf-controller/flux-system/helloworld/20230601T000000Z/junit.xml":     "<testsuites></testsuites>",
+		"PUT /results/tf-controller/flux-system/helloworld/20230601T000000Z/results.sarif": "{}",
+	}))
+	g.Expect(authorization).To(ContainSubstring("Credential=AKIAEXAMPLE/"))
+}
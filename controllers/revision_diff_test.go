@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	sourcev1b2 "github.com/fluxcd/source-controller/api/v1beta2"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/planner/provider"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNewRevisionDiff(t *testing.T) {
+	g := NewWithT(t)
+
+	comparison := &provider.Comparison{
+		Commits: []provider.Commit{
+			{Sha: "1111111111", Message: "Add a subnet", Author: "Jane"},
+			{Sha: "2222222222", Message: "Resize the VPC", Author: "John"},
+			{Sha: "3333333333", Message: "Tag the subnets", Author: "Jane"},
+		},
+		Files:        []string{"main.tf", "vpc.tf", "subnets.tf"},
+		TotalCommits: 3,
+	}
+
+	// the most recent commits and the first files are kept
+	diff := newRevisionDiff("main@sha1:aaa", "main@sha1:3333333333", comparison, 2, 1)
+	g.Expect(diff.Commits).To(Equal([]infrav1.RevisionDiffCommit{
+		{SHA: "2222222222", Message: "Resize the VPC", Author: "John"},
+		{SHA: "3333333333", Message: "Tag the subnets", Author: "Jane"},
+	}))
+	g.Expect(diff.TotalCommits).To(Equal(3))
+	g.Expect(diff.ChangedFiles).To(Equal([]string{"main.tf"}))
+	g.Expect(diff.TotalChangedFiles).To(Equal(3))
+
+	g.Expect(revisionDiffMessage(diff)).To(Equal(
+		"Revision main@sha1:3333333333 includes 3 commit(s) since the last applied revision main@sha1:aaa, changing 3 file(s): main.tf, ...\n" +
+			"2222222 Resize the VPC\n" +
+			"3333333 Tag the subnets"))
+
+	diff = newRevisionDiff("main@sha1:aaa", "main@sha1:3333333333", comparison, 0, 0)
+	g.Expect(diff.Commits).To(HaveLen(3))
+	g.Expect(diff.ChangedFiles).To(HaveLen(3))
+
+	g.Expect(revisionSHA("main@sha1:3333333333")).To(Equal("3333333333"))
+	g.Expect(revisionSHA("main/3333333333")).To(Equal("3333333333"))
+}
+
+func TestReconcileRevisionDiff(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	recorder := record.NewFakeRecorder(10)
+	r := &TerraformReconciler{EventRecorder: recorder}
+
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+		Spec: infrav1.TerraformSpec{
+			RevisionDiff: &infrav1.RevisionDiffSpec{SecretRef: meta.LocalObjectReference{Name: "github-token"}},
+		},
+		Status: infrav1.TerraformStatus{LastAppliedRevision: "main@sha1:aaa"},
+	}
+	source := &sourcev1.GitRepository{
+		Status: sourcev1.GitRepositoryStatus{Artifact: &sourcev1.Artifact{Revision: "main@sha1:aaa"}},
+	}
+
+	// nothing changed since the last apply
+	_, changed := r.reconcileRevisionDiff(ctx, terraform, source)
+	g.Expect(changed).To(BeFalse())
+
+	// the diff of the revision is kept
+	source.Status.Artifact.Revision = "main@sha1:bbb"
+	terraform.Status.RevisionDiff = &infrav1.RevisionDiff{From: "main@sha1:aaa", To: "main@sha1:bbb", TotalCommits: 1}
+	_, changed = r.reconcileRevisionDiff(ctx, terraform, source)
+	g.Expect(changed).To(BeFalse())
+
+	// the diff of an applied revision is cleared
+	source.Status.Artifact.Revision = "main@sha1:aaa"
+	result, changed := r.reconcileRevisionDiff(ctx, terraform, source)
+	g.Expect(changed).To(BeTrue())
+	g.Expect(result.Status.RevisionDiff).To(BeNil())
+
+	// a revision which cannot be compared clears the diff of the previous
+	// one, with an event
+	oci := &sourcev1b2.OCIRepository{
+		Status: sourcev1b2.OCIRepositoryStatus{Artifact: &sourcev1.Artifact{Revision: "latest@sha256:ccc"}},
+	}
+	result, changed = r.reconcileRevisionDiff(ctx, terraform, oci)
+	g.Expect(changed).To(BeTrue())
+	g.Expect(result.Status.RevisionDiff).To(BeNil())
+	g.Expect(recorder.Events).To(Receive(ContainSubstring("revision diffs require a GitRepository source")))
+
+	// the diff is cleared without the spec
+	terraform.Spec.RevisionDiff = nil
+	result, changed = r.reconcileRevisionDiff(ctx, terraform, source)
+	g.Expect(changed).To(BeTrue())
+	g.Expect(result.Status.RevisionDiff).To(BeNil())
+}
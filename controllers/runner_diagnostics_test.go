@@ -0,0 +1,107 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func runnerPodWithStatus(status corev1.ContainerStatus) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld-tf-runner", Namespace: "flux-system"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name: "tf-runner",
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{
+			Phase:             corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{status},
+		},
+	}
+}
+
+func TestGetRunnerFailure(t *testing.T) {
+	g := NewWithT(t)
+
+	healthy := runnerPodWithStatus(corev1.ContainerStatus{
+		Name:  "tf-runner",
+		State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+	})
+	g.Expect(getRunnerFailure(healthy)).To(BeNil())
+
+	restartedAfterOOM := runnerPodWithStatus(corev1.ContainerStatus{
+		Name:                 "tf-runner",
+		State:                corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"}},
+		RestartCount:         1,
+	})
+	failure := getRunnerFailure(restartedAfterOOM)
+	g.Expect(failure).ToNot(BeNil())
+	g.Expect(failure.oomKilled()).To(BeTrue())
+	g.Expect(failure.previous).To(BeTrue())
+	g.Expect(failure.memoryLimit).To(Equal("512Mi"))
+
+	evicted := runnerPodWithStatus(corev1.ContainerStatus{Name: "tf-runner"})
+	evicted.Status.Phase = corev1.PodFailed
+	evicted.Status.Reason = "Evicted"
+	failure = getRunnerFailure(evicted)
+	g.Expect(failure).ToNot(BeNil())
+	g.Expect(failure.reason).To(Equal("Evicted"))
+	g.Expect(failure.oomKilled()).To(BeFalse())
+}
+
+func TestHandleRunnerPodFailure(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system", UID: "uid"},
+	}
+	runnerPod := runnerPodWithStatus(corev1.ContainerStatus{
+		Name:  "tf-runner",
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"}},
+	})
+
+	r := &TerraformReconciler{
+		Client:  fake.NewClientBuilder().WithScheme(scheme).WithObjects(&runnerPod).Build(),
+		Scheme:  scheme,
+		PodLogs: kubefake.NewSimpleClientset().CoreV1(),
+	}
+
+	err := r.handleRunnerPodFailure(ctx, terraform, runnerPod)
+	var failedErr *runnerPodFailedError
+	g.Expect(errors.As(err, &failedErr)).To(BeTrue())
+	g.Expect(failedErr.reason()).To(Equal(infrav1.RunnerOOMKilledReason))
+	g.Expect(failedErr.Error()).To(ContainSubstring("a memory limit of 512Mi"))
+
+	var diagnostics corev1.ConfigMap
+	g.Expect(r.Get(ctx, types.NamespacedName{Namespace: "flux-system", Name: "helloworld-tf-runner-diagnostics"}, &diagnostics)).To(Succeed())
+	g.Expect(diagnostics.Data).To(HaveKeyWithValue("reason", "OOMKilled"))
+	g.Expect(diagnostics.Data).To(HaveKeyWithValue("exitCode", "137"))
+	g.Expect(diagnostics.Data).To(HaveKeyWithValue("logs", "fake logs"))
+	g.Expect(diagnostics.OwnerReferences[0].UID).To(Equal(types.UID("uid")))
+
+	err = r.Get(ctx, types.NamespacedName{Namespace: "flux-system", Name: "helloworld-tf-runner"}, &corev1.Pod{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
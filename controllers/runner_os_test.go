@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"testing"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestWindowsRunnerPodSpec(t *testing.T) {
+	g := NewWithT(t)
+
+	r := &TerraformReconciler{RunnerGRPCPort: 30000, RunnerRuntimeClassName: "gvisor"}
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "team-a"},
+	}
+
+	// linux by default
+	spec := r.runnerPodSpec(terraform, "runner.tls-123")
+	g.Expect(spec.OS).To(BeNil())
+	g.Expect(*spec.RuntimeClassName).To(Equal("gvisor"))
+	g.Expect(spec.Containers[0].SecurityContext.SeccompProfile).ToNot(BeNil())
+
+	t.Setenv("RUNNER_POD_WINDOWS_IMAGE", "ghcr.io/weaveworks/tf-runner-windows:v0.16.0")
+	terraform.Spec.RunnerPodTemplate.Spec.OS = corev1.Windows
+	terraform.Spec.RunnerPodTemplate.Spec.NodeSelector = map[string]string{"pool": "windows"}
+	terraform.Spec.RunnerPodTemplate.Spec.Env = []corev1.EnvVar{{Name: "TMP", Value: `D:\tmp`}}
+	terraform.Spec.RunnerPodTemplate.Spec.InitContainers = []corev1.Container{{
+		Name:            "install-tools",
+		SecurityContext: &corev1.SecurityContext{RunAsUser: int64Ptr(1000), Privileged: boolPtr(false)},
+	}}
+
+	spec = r.runnerPodSpec(terraform, "runner.tls-123")
+	g.Expect(spec.OS).To(Equal(&corev1.PodOS{Name: corev1.Windows}))
+	g.Expect(spec.NodeSelector).To(Equal(map[string]string{"pool": "windows", "kubernetes.io/os": "windows"}))
+	g.Expect(terraform.Spec.RunnerPodTemplate.Spec.NodeSelector).To(HaveLen(1))
+	g.Expect(spec.RuntimeClassName).To(BeNil())
+
+	container := spec.Containers[0]
+	g.Expect(container.Image).To(Equal("ghcr.io/weaveworks/tf-runner-windows:v0.16.0"))
+	g.Expect(container.SecurityContext).To(Equal(windowsRunnerSecurityContext()))
+	g.Expect(container.Env).To(ContainElements(
+		corev1.EnvVar{Name: "TMP", Value: `D:\tmp`},
+		corev1.EnvVar{Name: "TEMP", Value: `C:\tmp`},
+		corev1.EnvVar{Name: "USERPROFILE", Value: `C:\home\runner`},
+	))
+	g.Expect(spec.InitContainers[0].SecurityContext).To(Equal(&corev1.SecurityContext{}))
+	g.Expect(terraform.Spec.RunnerPodTemplate.Spec.InitContainers[0].SecurityContext.RunAsUser).ToNot(BeNil())
+
+	// the restricted Pod Security Standard sets none of its Linux settings
+	runnerSandbox{restricted: true}.apply(&spec)
+	g.Expect(spec.SecurityContext.SeccompProfile).To(BeNil())
+	g.Expect(*spec.SecurityContext.RunAsNonRoot).To(BeTrue())
+	for _, container := range append(spec.InitContainers, spec.Containers...) {
+		g.Expect(container.SecurityContext.Capabilities).To(BeNil())
+		g.Expect(container.SecurityContext.SeccompProfile).To(BeNil())
+		g.Expect(container.SecurityContext.AllowPrivilegeEscalation).To(BeNil())
+		g.Expect(*container.SecurityContext.RunAsNonRoot).To(BeTrue())
+	}
+
+	// the node selector of the template may choose the operating system
+	terraform.Spec.RunnerPodTemplate.Spec.NodeSelector = map[string]string{"kubernetes.io/os": "windows", "kubernetes.io/arch": "amd64"}
+	spec = r.runnerPodSpec(terraform, "runner.tls-123")
+	g.Expect(spec.NodeSelector).To(Equal(terraform.Spec.RunnerPodTemplate.Spec.NodeSelector))
+
+	// the image of the template is used as is
+	terraform.Spec.RunnerPodTemplate.Spec.Image = "registry.example.com/tf-runner-windows:custom"
+	spec = r.runnerPodSpec(terraform, "runner.tls-123")
+	g.Expect(spec.Containers[0].Image).To(Equal("registry.example.com/tf-runner-windows:custom"))
+}
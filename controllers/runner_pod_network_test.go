@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"testing"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRunnerPodSpecNetworkSettings(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv("HTTPS_PROXY", "http://controller-proxy:3128")
+	t.Setenv("NO_PROXY", "10.0.0.0/8")
+
+	ndots := "2"
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+		Spec: infrav1.TerraformSpec{
+			RunnerPodTemplate: infrav1.RunnerPodTemplate{
+				Spec: infrav1.RunnerPodSpec{
+					Proxy: &infrav1.ProxySpec{
+						HTTPSProxy: "http://tenant-proxy:3128",
+					},
+					DNSPolicy: corev1.DNSNone,
+					DNSConfig: &corev1.PodDNSConfig{
+						Nameservers: []string{"10.0.0.10"},
+						Options:     []corev1.PodDNSConfigOption{{Name: "ndots", Value: &ndots}},
+					},
+					TrustedCABundle: &infrav1.CABundleReference{
+						Kind: "ConfigMap",
+						Name: "corporate-ca",
+						Key:  "bundle.pem",
+					},
+				},
+			},
+		},
+	}
+
+	reconciler := &TerraformReconciler{}
+	spec := reconciler.runnerPodSpec(terraform, "runner.tls-123")
+
+	g.Expect(spec.DNSPolicy).To(Equal(corev1.DNSNone))
+	g.Expect(spec.DNSConfig.Nameservers).To(Equal([]string{"10.0.0.10"}))
+
+	env := spec.Containers[0].Env
+	g.Expect(env).To(ContainElement(corev1.EnvVar{Name: "HTTPS_PROXY", Value: "http://tenant-proxy:3128"}))
+	g.Expect(env).To(ContainElement(corev1.EnvVar{Name: "NO_PROXY", Value: "10.0.0.0/8"}))
+	g.Expect(env).To(ContainElement(corev1.EnvVar{Name: "SSL_CERT_DIR", Value: "/etc/ssl/certs:/etc/tf-runner/trusted-ca"}))
+
+	g.Expect(spec.Volumes).To(ContainElement(corev1.Volume{
+		Name: "trusted-ca",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "corporate-ca"},
+				Items:                []corev1.KeyToPath{{Key: "bundle.pem", Path: "ca.crt"}},
+			},
+		},
+	}))
+	g.Expect(spec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+		Name:      "trusted-ca",
+		MountPath: "/etc/tf-runner/trusted-ca",
+		ReadOnly:  true,
+	}))
+}
@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRunnerSandbox(t *testing.T) {
+	g := NewWithT(t)
+
+	r := quotaTestReconciler(g,
+		&infrav1.TerraformNamespacePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "team-a"},
+			Spec:       infrav1.TerraformNamespacePolicySpec{RunnerRuntimeClassName: "kata", RestrictedRunnerPods: true},
+		},
+		&infrav1.TerraformNamespacePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "team-a"},
+			Spec:       infrav1.TerraformNamespacePolicySpec{RunnerRuntimeClassName: "gvisor"},
+		},
+	)
+
+	sandbox, err := r.runnerSandbox(context.TODO(), "team-a")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(sandbox).To(Equal(runnerSandbox{runtimeClassName: "gvisor", restricted: true}))
+
+	sandbox, err = r.runnerSandbox(context.TODO(), "team-b")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(sandbox).To(Equal(runnerSandbox{}))
+
+	r.RestrictedRunnerPods = true
+	sandbox, err = r.runnerSandbox(context.TODO(), "team-b")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(sandbox).To(Equal(runnerSandbox{restricted: true}))
+}
+
+func TestRunnerSandboxApply(t *testing.T) {
+	g := NewWithT(t)
+
+	r := &TerraformReconciler{RunnerGRPCPort: 30000, RunnerRuntimeClassName: "gvisor"}
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "team-a"},
+	}
+	terraform.Spec.RunnerPodTemplate.Spec.InitContainers = []corev1.Container{{
+		Name:            "install-tools",
+		Ports:           []corev1.ContainerPort{{ContainerPort: 8080, HostPort: 8080}},
+		VolumeMounts:    []corev1.VolumeMount{{Name: "docker", MountPath: "/var/run/docker.sock"}, {Name: "cache", MountPath: "/cache"}},
+		SecurityContext: &corev1.SecurityContext{RunAsUser: int64Ptr(0), Privileged: boolPtr(true)},
+	}}
+	terraform.Spec.RunnerPodTemplate.Spec.Volumes = []corev1.Volume{
+		{Name: "docker", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/run/docker.sock"}}},
+		{Name: "cache", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+	}
+	terraform.Spec.RunnerPodTemplate.Spec.VolumeMounts = []corev1.VolumeMount{{Name: "docker", MountPath: "/var/run/docker.sock"}}
+
+	// the runtime class of the controller is the default one
+	spec := r.runnerPodSpec(terraform, "runner.tls-123")
+	g.Expect(spec.RuntimeClassName).To(Equal(stringPtr("gvisor")))
+	terraform.Spec.RunnerPodTemplate.Spec.RuntimeClassName = stringPtr("runc")
+	spec = r.runnerPodSpec(terraform, "runner.tls-123")
+	g.Expect(spec.RuntimeClassName).To(Equal(stringPtr("runc")))
+
+	runnerSandbox{}.apply(&spec)
+	g.Expect(spec.RuntimeClassName).To(Equal(stringPtr("runc")))
+	g.Expect(spec.SecurityContext).To(BeNil())
+
+	spec.HostNetwork = true
+	spec.HostPID = true
+	runnerSandbox{runtimeClassName: "kata", restricted: true}.apply(&spec)
+	g.Expect(spec.RuntimeClassName).To(Equal(stringPtr("kata")))
+	g.Expect(spec.SecurityContext.RunAsNonRoot).To(Equal(boolPtr(true)))
+	g.Expect(spec.SecurityContext.SeccompProfile.Type).To(Equal(corev1.SeccompProfileTypeRuntimeDefault))
+	g.Expect(spec.InitContainers[0].SecurityContext).To(Equal(&corev1.SecurityContext{
+		Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		Privileged:               boolPtr(false),
+		RunAsNonRoot:             boolPtr(true),
+		AllowPrivilegeEscalation: boolPtr(false),
+		SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}))
+	g.Expect(spec.HostNetwork).To(BeFalse())
+	g.Expect(spec.HostPID).To(BeFalse())
+	for _, volume := range spec.Volumes {
+		g.Expect(volume.HostPath).To(BeNil())
+	}
+	g.Expect(spec.Volumes).To(ContainElement(HaveField("Name", "cache")))
+	g.Expect(spec.InitContainers[0].Ports[0].HostPort).To(BeZero())
+	g.Expect(spec.InitContainers[0].VolumeMounts).To(Equal([]corev1.VolumeMount{{Name: "cache", MountPath: "/cache"}}))
+	g.Expect(spec.Containers[0].VolumeMounts).ToNot(ContainElement(HaveField("Name", "docker")))
+	g.Expect(spec.Containers[0].SecurityContext.RunAsUser).To(Equal(int64Ptr(65532)))
+	g.Expect(spec.Containers[0].SecurityContext.ReadOnlyRootFilesystem).To(Equal(boolPtr(true)))
+
+	// the Terraform object is left as it is
+	g.Expect(terraform.Spec.RunnerPodTemplate.Spec.InitContainers[0].SecurityContext.Privileged).To(Equal(boolPtr(true)))
+	g.Expect(terraform.Spec.RunnerPodTemplate.Spec.InitContainers[0].VolumeMounts).To(HaveLen(2))
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
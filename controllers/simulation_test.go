@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"testing"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRunnerPodSpecSimulation(t *testing.T) {
+	g := NewWithT(t)
+
+	r := &TerraformReconciler{RunnerGRPCPort: 30000}
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld-pr-7", Namespace: "flux-system"},
+		Spec: infrav1.TerraformSpec{
+			ServiceAccountName: "tf-runner-prod",
+			Simulation: &infrav1.SimulationSpec{
+				Providers:          []infrav1.SimulatedProvider{{Name: "aws", Endpoints: map[string]string{"s3": "http://localstack:4566"}}},
+				Env:                []corev1.EnvVar{{Name: "AWS_ACCESS_KEY_ID", Value: "test"}},
+				ServiceAccountName: "tf-runner-simulation",
+			},
+		},
+	}
+	terraform.Spec.RunnerPodTemplate.Spec.Env = []corev1.EnvVar{{Name: "AWS_ACCESS_KEY_ID", ValueFrom: &corev1.EnvVarSource{
+		SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "aws"}, Key: "id"},
+	}}}
+	terraform.Spec.RunnerPodTemplate.Spec.EnvFrom = []corev1.EnvFromSource{{
+		SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "aws"}},
+	}}
+
+	// an object which applies keeps its credentials
+	spec := r.runnerPodSpec(terraform, "runner.tls-123")
+	g.Expect(spec.ServiceAccountName).To(Equal("tf-runner-prod"))
+	g.Expect(spec.Containers[0].EnvFrom).To(HaveLen(1))
+	g.Expect(spec.Containers[0].Env).To(ContainElement(terraform.Spec.RunnerPodTemplate.Spec.Env[0]))
+
+	// a plan only object plans in simulation, without them
+	terraform.Spec.PlanOnly = true
+	spec = r.runnerPodSpec(terraform, "runner.tls-123")
+	g.Expect(spec.ServiceAccountName).To(Equal("tf-runner-simulation"))
+	g.Expect(spec.Containers[0].EnvFrom).To(BeEmpty())
+	g.Expect(spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "AWS_ACCESS_KEY_ID", Value: "test"}))
+	g.Expect(spec.Containers[0].Env).ToNot(ContainElement(terraform.Spec.RunnerPodTemplate.Spec.Env[0]))
+}
@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"testing"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestSourceCacheRunnerPodSpec(t *testing.T) {
+	g := NewWithT(t)
+
+	r := &TerraformReconciler{RunnerGRPCPort: 30000}
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "team-a"},
+	}
+
+	// no cache by default
+	spec := r.runnerPodSpec(terraform, "runner.tls-123")
+	g.Expect(spec.Volumes).ToNot(ContainElement(HaveField("Name", sourceCacheVolumeName)))
+	g.Expect(spec.Containers[0].Args).ToNot(ContainElement("--source-cache-dir"))
+
+	r.RunnerSourceCacheHostPath = "/var/cache/tf-controller"
+	spec = r.runnerPodSpec(terraform, "runner.tls-123")
+	g.Expect(spec.Volumes).To(ContainElement(sourceCacheVolume("/var/cache/tf-controller")))
+	g.Expect(spec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: sourceCacheVolumeName, MountPath: sourceCacheMountPath}))
+	g.Expect(spec.Containers[0].Args).To(ContainElements("--source-cache-dir", sourceCacheMountPath))
+
+	// the restricted Pod Security Standard does not allow the cache
+	runnerSandbox{restricted: true}.apply(&spec)
+	g.Expect(spec.Volumes).ToNot(ContainElement(HaveField("Name", sourceCacheVolumeName)))
+	g.Expect(spec.Containers[0].VolumeMounts).ToNot(ContainElement(HaveField("Name", sourceCacheVolumeName)))
+}
+
+func TestSourceCacheDigest(t *testing.T) {
+	g := NewWithT(t)
+
+	digest := "sha256:80ddfd18eb96f7d31cadc1a8a5171c6e2d95df3f6c23b0ed9cd8dddf6dba1406"
+	source := &sourcev1.GitRepository{
+		Status: sourcev1.GitRepositoryStatus{
+			Artifact: &sourcev1.Artifact{Revision: "main@sha1:b8e362c206e3d0cbb7ed22ced771a0056455a2fb", Digest: digest},
+		},
+	}
+
+	r := &TerraformReconciler{}
+	g.Expect(r.sourceCacheDigest(source)).To(BeEmpty())
+
+	r.RunnerSourceCacheHostPath = "/var/cache/tf-controller"
+	g.Expect(r.sourceCacheDigest(source)).To(Equal(digest))
+
+	// the artifacts of the layered sources are made by the controller
+	g.Expect(r.sourceCacheDigest(&layeredSource{Source: source, artifact: &sourcev1.Artifact{}})).To(BeEmpty())
+
+	source.Status.Artifact.Digest = "sha512:abc"
+	g.Expect(r.sourceCacheDigest(source)).To(BeEmpty())
+}
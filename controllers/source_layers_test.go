@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"testing"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	sourcev1b2 "github.com/fluxcd/source-controller/api/v1beta2"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func tarGz(g *WithT, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, content := range files {
+		g.Expect(tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg})).To(Succeed())
+		_, err := tarWriter.Write([]byte(content))
+		g.Expect(err).ToNot(HaveOccurred())
+	}
+	g.Expect(tarWriter.Close()).To(Succeed())
+	g.Expect(gzipWriter.Close()).To(Succeed())
+	return buf.Bytes()
+}
+
+func untarGz(g *WithT, data []byte) map[string]string {
+	files := map[string]string{}
+	g.Expect(readTarGz(data, func(name string, entry tarEntry) error {
+		files[name] = string(entry.content)
+		return nil
+	})).To(Succeed())
+	return files
+}
+
+func TestOverlayArtifacts(t *testing.T) {
+	g := NewWithT(t)
+
+	base := tarGz(g, map[string]string{
+		"./modules/vpc/main.tf":          "module",
+		"./modules/vpc/terraform.tfvars": "cidr = \"10.0.0.0/16\"",
+	})
+	overlays := []overlay{
+		{
+			tarGz:      tarGz(g, map[string]string{"envs/prod/terraform.tfvars": "cidr = \"10.1.0.0/16\"", "envs/dev/terraform.tfvars": "dev"}),
+			path:       "envs/prod",
+			targetPath: "./modules/vpc",
+		},
+		{
+			tarGz:      tarGz(g, map[string]string{"backend.tf": "backend", "../escape.tf": "escape"}),
+			targetPath: "modules/vpc",
+		},
+	}
+
+	result, err := overlayArtifacts(base, overlays)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(untarGz(g, result.Bytes())).To(Equal(map[string]string{
+		"modules/vpc/main.tf":          "module",
+		"modules/vpc/terraform.tfvars": "cidr = \"10.1.0.0/16\"",
+		"modules/vpc/backend.tf":       "backend",
+		"modules/vpc/escape.tf":        "escape",
+	}))
+
+	// the same artifacts give the same result
+	again, err := overlayArtifacts(base, overlays)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(again.Bytes()).To(Equal(result.Bytes()))
+}
+
+func TestReconcileSourceLayers(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(sourcev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(sourcev1b2.AddToScheme(scheme)).To(Succeed())
+
+	source := &sourcev1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "base", Namespace: "flux-system"},
+		Status: sourcev1.GitRepositoryStatus{
+			Artifact: &sourcev1.Artifact{Revision: "main@sha1:b8e362c206e3d0cbb7ed22ced771a0056455a2fb", URL: "http://source-controller/base.tar.gz"},
+		},
+	}
+	overlay := &sourcev1b2.OCIRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "flux-system"},
+		Status: sourcev1b2.OCIRepositoryStatus{
+			Artifact: &sourcev1.Artifact{Revision: "v1@sha256:6e3fa1a6c5a1b8c7e0a3b3d2f6a2b6d4e3f0a1b2c3d4e5f6a7b8c9d0e1f2a3b4"},
+		},
+	}
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "vpc", Namespace: "flux-system"},
+		Spec: infrav1.TerraformSpec{
+			Path:      "./modules/vpc",
+			SourceRef: infrav1.CrossNamespaceSourceReference{Kind: "GitRepository", Name: "base"},
+			SourceLayers: []infrav1.SourceLayer{{
+				SourceRef: infrav1.CrossNamespaceSourceReference{Kind: "OCIRepository", Name: "prod"},
+			}},
+		},
+	}
+
+	r := &TerraformReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(source, overlay).WithStatusSubresource(overlay).Build(),
+	}
+
+	sourceObj, revisions, err := r.reconcileSourceLayers(context.TODO(), terraform, source)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(revisions).To(Equal([]infrav1.SourceLayerRevision{
+		{SourceRef: terraform.Spec.SourceRef, Revision: "main@sha1:b8e362c206e3d0cbb7ed22ced771a0056455a2fb"},
+		{SourceRef: terraform.Spec.SourceLayers[0].SourceRef, Revision: "v1@sha256:6e3fa1a6c5a1b8c7e0a3b3d2f6a2b6d4e3f0a1b2c3d4e5f6a7b8c9d0e1f2a3b4"},
+	}))
+	g.Expect(sourceObj.GetArtifact().Revision).To(MatchRegexp(`^main@sha256:[0-9a-f]{64}$`))
+	g.Expect(sourceObj.(*layeredSource).layers[0].targetPath).To(Equal("./modules/vpc"))
+
+	// a new revision of a layer is a new revision of the layered artifact
+	layeredRevision := sourceObj.GetArtifact().Revision
+	overlay.Status.Artifact.Revision = "v2@sha256:0000000000000000000000000000000000000000000000000000000000000000"
+	g.Expect(r.Client.Status().Update(context.TODO(), overlay)).To(Succeed())
+	sourceObj, _, err = r.reconcileSourceLayers(context.TODO(), terraform, source)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(sourceObj.GetArtifact().Revision).ToNot(Equal(layeredRevision))
+
+	// the source is used as is without layers
+	terraform.Spec.SourceLayers = nil
+	sourceObj, revisions, err = r.reconcileSourceLayers(context.TODO(), terraform, source)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(sourceObj).To(Equal(source))
+	g.Expect(revisions).To(BeNil())
+}
@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	sourcev1b2 "github.com/fluxcd/source-controller/api/v1beta2"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestPinnedSourceRef(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(pinnedSourceRef("b8e362c206e3d0cbb7ed22ced771a0056455a2fb")).To(Equal(&sourcev1.GitRepositoryRef{Commit: "b8e362c206e3d0cbb7ed22ced771a0056455a2fb"}))
+	g.Expect(pinnedSourceRef("refs/pull/42/head")).To(Equal(&sourcev1.GitRepositoryRef{Name: "refs/pull/42/head"}))
+	g.Expect(pinnedSourceRef("v1.2.0")).To(Equal(&sourcev1.GitRepositoryRef{Tag: "v1.2.0"}))
+	g.Expect(pinnedSourceRef("b8e362c")).To(Equal(&sourcev1.GitRepositoryRef{Tag: "b8e362c"}))
+}
+
+func TestReconcileSourceOverride(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(sourcev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	source := &sourcev1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+		Spec: sourcev1.GitRepositorySpec{
+			URL:       "https://github.com/tf-controller/helloworld",
+			Reference: &sourcev1.GitRepositoryRef{Branch: "main"},
+			SecretRef: &meta.LocalObjectReference{Name: "git-credentials"},
+		},
+		Status: sourcev1.GitRepositoryStatus{
+			Artifact: &sourcev1.Artifact{Revision: "main@sha1:0000000000000000000000000000000000000000"},
+		},
+	}
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system", UID: "uid"},
+		Spec: infrav1.TerraformSpec{
+			SourceRevisionOverride: "v1.2.0",
+		},
+	}
+
+	r := &TerraformReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(source).WithStatusSubresource(source).Build(),
+		Scheme: scheme,
+	}
+
+	// the pinned copy has no artifact until it is fetched
+	sourceObj, err := r.reconcileSourceOverride(context.TODO(), terraform, source)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(sourceObj.GetArtifact()).To(BeNil())
+
+	pinned := &sourcev1.GitRepository{}
+	g.Expect(r.Get(context.TODO(), client.ObjectKey{Namespace: "flux-system", Name: "helloworld-pinned"}, pinned)).To(Succeed())
+	g.Expect(pinned.Spec.URL).To(Equal(source.Spec.URL))
+	g.Expect(pinned.Spec.SecretRef).To(Equal(source.Spec.SecretRef))
+	g.Expect(pinned.Spec.Reference).To(Equal(&sourcev1.GitRepositoryRef{Tag: "v1.2.0"}))
+	g.Expect(metav1.IsControlledBy(pinned, &terraform)).To(BeTrue())
+
+	// the artifact of the pinned copy is used once fetched
+	pinned.Status.ObservedGeneration = pinned.Generation
+	pinned.Status.Artifact = &sourcev1.Artifact{Revision: "v1.2.0@sha1:b8e362c206e3d0cbb7ed22ced771a0056455a2fb"}
+	g.Expect(r.Status().Update(context.TODO(), pinned)).To(Succeed())
+
+	sourceObj, err = r.reconcileSourceOverride(context.TODO(), terraform, source)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(sourceObj.GetArtifact().Revision).To(Equal("v1.2.0@sha1:b8e362c206e3d0cbb7ed22ced771a0056455a2fb"))
+
+	// the pinned copy is deleted with the override
+	terraform.Spec.SourceRevisionOverride = ""
+	sourceObj, err = r.reconcileSourceOverride(context.TODO(), terraform, source)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(sourceObj).To(Equal(source))
+	err = r.Get(context.TODO(), client.ObjectKey{Namespace: "flux-system", Name: "helloworld-pinned"}, pinned)
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+	// only a GitRepository can be pinned
+	terraform.Spec.SourceRevisionOverride = "v1.2.0"
+	_, err = r.reconcileSourceOverride(context.TODO(), terraform, &sourcev1b2.Bucket{})
+	g.Expect(err).To(MatchError(ContainSubstring("only supported with a GitRepository source")))
+}
@@ -1,6 +1,9 @@
 package controllers
 
 import (
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
@@ -64,3 +67,26 @@ func (SecretDeletePredicate) Update(e event.UpdateEvent) bool {
 func (SecretDeletePredicate) Generic(e event.GenericEvent) bool {
 	return false
 }
+
+// ReferencedDataChangePredicate filters the updates of Secrets and ConfigMaps
+// referenced by Terraform objects, to only those changing their data.
+type ReferencedDataChangePredicate struct {
+	predicate.Funcs
+}
+
+func (ReferencedDataChangePredicate) Update(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+
+	switch oldObj := e.ObjectOld.(type) {
+	case *corev1.Secret:
+		newObj, ok := e.ObjectNew.(*corev1.Secret)
+		return ok && (!reflect.DeepEqual(oldObj.Data, newObj.Data) || !reflect.DeepEqual(oldObj.StringData, newObj.StringData))
+	case *corev1.ConfigMap:
+		newObj, ok := e.ObjectNew.(*corev1.ConfigMap)
+		return ok && (!reflect.DeepEqual(oldObj.Data, newObj.Data) || !reflect.DeepEqual(oldObj.BinaryData, newObj.BinaryData))
+	}
+
+	return false
+}
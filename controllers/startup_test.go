@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	. "github.com/onsi/gomega"
+)
+
+func startupTerraform(namespace, name string, priority int32, pendingPlan string) *infrav1.Terraform {
+	return &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, UID: types.UID(namespace + "/" + name)},
+		Spec:       infrav1.TerraformSpec{Priority: priority},
+		Status:     infrav1.TerraformStatus{Plan: infrav1.PlanStatus{Pending: pendingPlan}},
+	}
+}
+
+func TestSortByStartupOrder(t *testing.T) {
+	g := NewWithT(t)
+
+	terraforms := []infrav1.Terraform{
+		*startupTerraform("sandbox", "b", -10, ""),
+		*startupTerraform("dev", "b", 0, ""),
+		*startupTerraform("dev", "a", 0, ""),
+		*startupTerraform("staging", "a", 0, "plan-main-abc"),
+		*startupTerraform("prod", "a", 100, ""),
+		*startupTerraform("prod", "b", 100, "plan-main-abc"),
+	}
+	sortByStartupOrder(terraforms)
+
+	var order []string
+	for _, terraform := range terraforms {
+		order = append(order, terraform.Namespace+"/"+terraform.Name)
+	}
+	g.Expect(order).To(Equal([]string{"prod/b", "prod/a", "staging/a", "dev/a", "dev/b", "sandbox/b"}))
+}
+
+func TestStartupQueue(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		startupTerraform("dev", "a", 0, ""),
+		startupTerraform("prod", "a", 10, ""),
+	).Build()
+
+	q := newStartupQueue(c, 0)
+	predicate := q.predicate()
+	created := func(terraform *infrav1.Terraform) bool {
+		return predicate.Create(event.CreateEvent{Object: terraform})
+	}
+
+	// the create events before the listing are of objects part of it
+	g.Expect(created(startupTerraform("dev", "a", 0, ""))).To(BeFalse())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- q.Start(ctx) }()
+
+	var enqueued []string
+	for i := 0; i < 2; i++ {
+		select {
+		case e := <-q.events:
+			enqueued = append(enqueued, e.Object.GetNamespace()+"/"+e.Object.GetName())
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for the startup events")
+		}
+	}
+	g.Expect(enqueued).To(Equal([]string{"prod/a", "dev/a"}))
+	g.Expect(<-done).To(Succeed())
+
+	g.Expect(created(startupTerraform("prod", "a", 10, ""))).To(BeFalse())
+	g.Expect(created(startupTerraform("prod", "new", 0, ""))).To(BeTrue())
+}
+
+func TestRecoverInterrupted(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+	interrupted := func(name string) *infrav1.Terraform {
+		terraform := infrav1.TerraformProgressing(*startupTerraform("dev", name, 0, ""), "Applying")
+		terraform.Status.LastAttemptedRevision = "main@sha1:abc"
+		terraform = infrav1.TerraformStageStarted(terraform, infrav1.StageApply, "main@sha1:abc")
+		return &terraform
+	}
+	applying := interrupted("applying")
+	planning := interrupted("planning")
+	ready := startupTerraform("dev", "ready", 0, "")
+	apimeta.SetStatusCondition(&ready.Status.Conditions, metav1.Condition{
+		Type: meta.ReadyCondition, Status: metav1.ConditionTrue, Reason: infrav1.TFExecApplySucceedReason,
+	})
+	runner := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "planning-tf-runner", Namespace: "dev"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+
+	recorder := record.NewFakeRecorder(10)
+	r := &TerraformReconciler{EventRecorder: recorder}
+	r.Client = fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(applying, planning, ready, runner).
+		WithStatusSubresource(&infrav1.Terraform{}).Build()
+	r.APIReader = r.Client
+
+	// the object progressing without a runner pod is reset
+	recovered, err := r.recoverInterrupted(ctx, applying)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(recovered).To(BeTrue())
+
+	var got infrav1.Terraform
+	g.Expect(r.Get(ctx, client.ObjectKeyFromObject(applying), &got)).To(Succeed())
+	condition := apimeta.FindStatusCondition(got.Status.Conditions, meta.ReadyCondition)
+	g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+	g.Expect(condition.Reason).To(Equal(infrav1.ReconciliationInterruptedReason))
+	g.Expect(condition.Message).To(ContainSubstring("(Applying)"))
+	g.Expect(got.Status.LastAttemptedRevision).To(Equal("main@sha1:abc"))
+	g.Expect(got.GetStage(infrav1.StageApply).Status).To(Equal(metav1.ConditionFalse))
+	g.Expect(got.GetStage(infrav1.StageApply).Reason).To(Equal(infrav1.ReconciliationInterruptedReason))
+	g.Expect(recorder.Events).To(Receive(HavePrefix("Warning ReconciliationInterrupted")))
+
+	// the object with a live runner pod, or not progressing, is left as is
+	for _, terraform := range []*infrav1.Terraform{planning, ready} {
+		recovered, err = r.recoverInterrupted(ctx, terraform)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(recovered).To(BeFalse())
+	}
+	g.Expect(recorder.Events).To(BeEmpty())
+}
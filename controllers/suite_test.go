@@ -187,6 +187,7 @@ func TestMain(m *testing.M) {
 		Scheme:                   k8sManager.GetScheme(),
 		EventRecorder:            k8sManager.GetEventRecorderFor("tf-controller"),
 		StatusPoller:             polling.NewStatusPoller(k8sManager.GetClient(), k8sManager.GetRESTMapper(), polling.Options{}),
+		APIReader:                k8sManager.GetAPIReader(),
 		CertRotator:              rotator,
 		RunnerGRPCPort:           30000,
 		RunnerCreationTimeout:    120 * time.Second,
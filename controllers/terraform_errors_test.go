@@ -0,0 +1,26 @@
+package controllers
+
+import (
+	"testing"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/runner"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestTerraformErrors(t *testing.T) {
+	g := NewWithT(t)
+
+	terraform := infrav1.Terraform{Spec: infrav1.TerraformSpec{Path: "./envs/prod"}}
+	g.Expect(terraformErrors(terraform, nil)).To(BeNil())
+
+	errs := terraformErrors(terraform, []*runner.Diagnostic{
+		{Severity: "error", Summary: "Unsupported argument", Filename: "main.tf", Line: 12, Column: 3},
+		{Severity: "error", Summary: "creating S3 Bucket (logs): BucketAlreadyExists"},
+	})
+	g.Expect(errs).To(Equal([]infrav1.TerraformError{
+		{Severity: "error", Summary: "Unsupported argument", File: "envs/prod/main.tf", Line: 12, Column: 3},
+		{Severity: "error", Summary: "creating S3 Bucket (logs): BucketAlreadyExists"},
+	}))
+}
@@ -0,0 +1,223 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/fluxcd/pkg/runtime/predicates"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// TerraformApprovalQueueReconciler reconciles a TerraformApprovalQueue
+// object, by listing the plans pending approval of the Terraform objects it
+// selects in its status.
+type TerraformApprovalQueueReconciler struct {
+	client.Client
+
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=infra.contrib.fluxcd.io,resources=terraformapprovalqueues,verbs=get;list;watch
+//+kubebuilder:rbac:groups=infra.contrib.fluxcd.io,resources=terraformapprovalqueues/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+func (r *TerraformApprovalQueueReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var queue infrav1.TerraformApprovalQueue
+	if err := r.Get(ctx, req.NamespacedName, &queue); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !queue.ObjectMeta.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	original := queue.DeepCopy()
+
+	pending, err := r.pendingApprovals(ctx, queue)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	queue.Status.Pending = pending
+	queue.Status.Count = int32(len(pending))
+	queue.Status.Oldest = nil
+	if len(pending) > 0 {
+		queue.Status.Oldest = pending[0].Since
+	}
+	queue.Status.ObservedGeneration = queue.Generation
+	if reflect.DeepEqual(original.Status, queue.Status) {
+		return ctrl.Result{}, nil
+	}
+
+	statusOpts := &client.SubResourcePatchOptions{
+		PatchOptions: client.PatchOptions{
+			FieldManager: "tf-controller",
+		},
+	}
+	return ctrl.Result{}, r.Status().Patch(ctx, &queue, client.MergeFrom(original), statusOpts)
+}
+
+// pendingApprovals returns the plans pending approval of the Terraform
+// objects selected by the queue, the oldest first.
+func (r *TerraformApprovalQueueReconciler) pendingApprovals(ctx context.Context, queue infrav1.TerraformApprovalQueue) ([]infrav1.PendingApproval, error) {
+	selector := labels.Everything()
+	if queue.Spec.Selector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(queue.Spec.Selector); err != nil {
+			return nil, fmt.Errorf("invalid selector: %w", err)
+		}
+	}
+
+	var namespaces map[string]bool
+	if queue.Spec.NamespaceSelector != nil {
+		namespaceSelector, err := metav1.LabelSelectorAsSelector(queue.Spec.NamespaceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid namespace selector: %w", err)
+		}
+		var list corev1.NamespaceList
+		if err := r.List(ctx, &list, client.MatchingLabelsSelector{Selector: namespaceSelector}); err != nil {
+			return nil, err
+		}
+		namespaces = make(map[string]bool, len(list.Items))
+		for _, namespace := range list.Items {
+			namespaces[namespace.Name] = true
+		}
+	}
+
+	var list infrav1.TerraformList
+	if err := r.List(ctx, &list, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+
+	var pending []infrav1.PendingApproval
+	for _, terraform := range list.Items {
+		if namespaces != nil && !namespaces[terraform.Namespace] {
+			continue
+		}
+		if approval := pendingApproval(terraform); approval != nil {
+			pending = append(pending, *approval)
+		}
+	}
+
+	sort.SliceStable(pending, func(i, j int) bool {
+		si, sj := pending[i].Since, pending[j].Since
+		switch {
+		case si != nil && sj != nil && !si.Equal(sj):
+			return si.Before(sj)
+		case (si == nil) != (sj == nil):
+			return sj == nil
+		case pending[i].Namespace != pending[j].Namespace:
+			return pending[i].Namespace < pending[j].Namespace
+		default:
+			return pending[i].Name < pending[j].Name
+		}
+	})
+	return pending, nil
+}
+
+// pendingApproval returns the plan of the Terraform object pending
+// approval, or nil when there is none.
+func pendingApproval(terraform infrav1.Terraform) *infrav1.PendingApproval {
+	if !terraform.DeletionTimestamp.IsZero() || !terraform.IsPlanPendingApproval() {
+		return nil
+	}
+	return &infrav1.PendingApproval{
+		Namespace:     terraform.Namespace,
+		Name:          terraform.Name,
+		Plan:          terraform.Status.Plan.Pending,
+		Revision:      terraform.Status.LastPlannedRevision,
+		Since:         terraform.Status.Plan.PendingSince,
+		Summary:       planSummary(terraform.Status.Plan),
+		Changes:       terraform.Status.Plan.Changes,
+		IsDestroyPlan: terraform.Status.Plan.IsDestroyPlan,
+		Approvers:     terraform.GetApprovers(),
+	}
+}
+
+// planSummary summarizes the changes of a plan the way Terraform does.
+func planSummary(plan infrav1.PlanStatus) string {
+	if plan.Changes == nil {
+		return ""
+	}
+	return fmt.Sprintf("Plan: %d to add, %d to change, %d to destroy.", plan.Changes.Add, plan.Changes.Change, plan.Changes.Destroy)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TerraformApprovalQueueReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	recoverPanic := true
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&infrav1.TerraformApprovalQueue{}, builder.WithPredicates(
+			predicate.Or(predicate.GenerationChangedPredicate{}, predicates.ReconcileRequestedPredicate{}),
+		)).
+		Watches(
+			&infrav1.Terraform{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForAllQueues),
+			builder.WithPredicates(pendingApprovalChangedPredicate{}),
+		).
+		Watches(
+			&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForAllQueues),
+			builder.WithPredicates(predicate.LabelChangedPredicate{}),
+		).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: 1,
+			RecoverPanic:            &recoverPanic,
+		}).
+		Complete(r)
+}
+
+func (r *TerraformApprovalQueueReconciler) requestsForAllQueues(ctx context.Context, _ client.Object) []reconcile.Request {
+	log := ctrl.LoggerFrom(ctx)
+
+	var list infrav1.TerraformApprovalQueueList
+	if err := r.List(ctx, &list); err != nil {
+		log.Error(err, "failed to list the approval queues")
+		return nil
+	}
+
+	reqs := make([]reconcile.Request, 0, len(list.Items))
+	for _, queue := range list.Items {
+		reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: queue.Name}})
+	}
+	return reqs
+}
+
+// pendingApprovalChangedPredicate filters the updates of the Terraform
+// objects which change neither their plan pending approval nor their labels.
+type pendingApprovalChangedPredicate struct {
+	predicate.Funcs
+}
+
+func (pendingApprovalChangedPredicate) Create(e event.CreateEvent) bool {
+	terraform, ok := e.Object.(*infrav1.Terraform)
+	return ok && terraform.IsPlanPendingApproval()
+}
+
+func (pendingApprovalChangedPredicate) Update(e event.UpdateEvent) bool {
+	oldTerraform, ok := e.ObjectOld.(*infrav1.Terraform)
+	if !ok {
+		return false
+	}
+	newTerraform, ok := e.ObjectNew.(*infrav1.Terraform)
+	if !ok {
+		return false
+	}
+	approval := pendingApproval(*newTerraform)
+	return !reflect.DeepEqual(pendingApproval(*oldTerraform), approval) ||
+		(approval != nil && !reflect.DeepEqual(oldTerraform.Labels, newTerraform.Labels))
+}
@@ -19,11 +19,13 @@ package controllers
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -39,6 +41,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/hashicorp/go-retryablehttp"
 	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/internal/correlation"
 	"github.com/weaveworks/tf-controller/mtls"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -47,6 +50,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	kuberecorder "k8s.io/client-go/tools/record"
 	"k8s.io/client-go/tools/reference"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/polling"
@@ -58,6 +62,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // TerraformReconciler reconciles a Terraform object
@@ -69,8 +74,12 @@ type TerraformReconciler struct {
 	httpClient        *retryablehttp.Client
 	statusManager     string
 	requeueDependency time.Duration
+	quotas            namespaceQuotas
+	events            eventAggregator
 
 	StatusPoller             *polling.StatusPoller
+	APIReader                client.Reader
+	PodLogs                  corev1client.PodsGetter
 	Scheme                   *runtime.Scheme
 	CertRotator              *mtls.CertRotator
 	RunnerGRPCPort           int
@@ -79,6 +88,45 @@ type TerraformReconciler struct {
 	AllowBreakTheGlass       bool
 	ClusterDomain            string
 	NoCrossNamespaceRefs     bool
+	PlanNotifier             PlanNotifier
+	// StartupReconcileRate is the number of Terraform objects per second
+	// enqueued when the controller starts, or 0 for no limit.
+	StartupReconcileRate float64
+	// RunnerRuntimeClassName is the RuntimeClass of the runner pods of the
+	// Terraform objects which do not set one.
+	RunnerRuntimeClassName string
+	// RestrictedRunnerPods enforces the restricted Pod Security Standard on
+	// all the containers of the runner pods.
+	RestrictedRunnerPods bool
+	// FIPS makes the runners refuse to start when they are not built with
+	// the FIPS 140-2 validated cryptography.
+	FIPS bool
+	// RunnerSourceCacheHostPath is the directory of the nodes caching the
+	// artifacts of the sources for their runner pods. Unset, the artifacts
+	// are downloaded for every reconciliation.
+	RunnerSourceCacheHostPath string
+	// PauseConfigMap is the ConfigMap pausing the applies cluster-wide,
+	// except for the objects of its allow-list. Unset, the applies are
+	// never paused.
+	PauseConfigMap types.NamespacedName
+	// EventVerbosity is the verbosity of the events of the Terraform objects
+	// which do not set one. Unset, it is normal.
+	EventVerbosity infrav1.EventVerbosity
+	// EventAggregationWindow is the time during which the events repeating
+	// an event of the same object are counted rather than recorded. Zero
+	// records them all.
+	EventAggregationWindow time.Duration
+	// Config is the TerraformControllerConfig overriding the flags above,
+	// and limiting the concurrency. Unset, the flags apply.
+	Config *ControllerConfig
+}
+
+// PlanNotifier notifies of the plans pending a manual approval.
+type PlanNotifier interface {
+	NotifyPlanPending(ctx context.Context, terraform infrav1.Terraform, planID string) error
+	// RemindPlanPending notifies again of a plan pending for a while, and
+	// to the escalation channel too when it is not empty.
+	RemindPlanPending(ctx context.Context, terraform infrav1.Terraform, planID string, pendingFor time.Duration, escalationChannel string) error
 }
 
 //+kubebuilder:rbac:groups=infra.contrib.fluxcd.io,resources=terraforms,verbs=get;list;watch;create;update;patch;delete
@@ -88,6 +136,11 @@ type TerraformReconciler struct {
 //+kubebuilder:rbac:groups=source.toolkit.fluxcd.io,resources=buckets/status;gitrepositories/status;ocirepositories/status,verbs=get
 //+kubebuilder:rbac:groups="",resources=configmaps;secrets;serviceaccounts,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;create;delete
+//+kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+//+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+//+kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get
+//+kubebuilder:rbac:groups=apps,resources=daemonsets;deployments;statefulsets,verbs=get;list
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -101,7 +154,7 @@ type TerraformReconciler struct {
 func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	reconcileStart := time.Now()
 	reconciliationLoopID := uuid.New().String()
-	log := ctrl.LoggerFrom(ctx, "reconciliation-loop-id", reconciliationLoopID, "start-time", reconcileStart)
+	log := ctrl.LoggerFrom(ctx, correlation.RunIDKey, reconciliationLoopID, "start-time", reconcileStart)
 	ctx = ctrl.LoggerInto(ctx, log)
 	traceLog := log.V(logger.TraceLevel).WithValues("function", "TerraformReconciler.Reconcile")
 	traceLog.Info("Reconcile Start")
@@ -119,14 +172,40 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		traceLog.Info("Ready Signal Received")
 	}
 
+	if r.Config != nil {
+		traceLog.Info("Wait for the concurrency of the controller config")
+		release, err := r.Config.Acquire(ctx)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		defer release()
+	}
+
 	traceLog.Info("Fetch Terraform Resource", "namespacedName", req.NamespacedName)
 	var terraform infrav1.Terraform
 	if err := r.Get(ctx, req.NamespacedName, &terraform); err != nil {
 		traceLog.Error(err, "Hit an error", "namespacedName", req.NamespacedName)
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	run := correlation.FromObject(&terraform)
+	run.RunID = reconciliationLoopID
+	if run.PullRequest != "" {
+		log = log.WithValues(correlation.PullRequestKey, run.PullRequest)
+		ctx = ctrl.LoggerInto(ctx, log)
+	}
 	log.Info(fmt.Sprintf(">> Started Generation: %d", terraform.GetGeneration()))
 
+	// Record the handled reconcile request, so that "flux reconcile" and the
+	// sync buttons of the UIs can observe when their request has been handled.
+	// The value is carried over by every status patch of this reconciliation.
+	traceLog.Info("Check for a reconcile request annotation")
+	reconcileRequested := false
+	if v, ok := meta.ReconcileAnnotationValue(terraform.GetAnnotations()); ok && v != terraform.Status.GetLastHandledReconcileRequest() {
+		traceLog.Info("Reconcile requested", "requestedAt", v)
+		terraform.Status.SetLastHandledReconcileRequest(v)
+		reconcileRequested = true
+	}
+
 	// Record suspended status metric
 	traceLog.Info("Defer metrics for suspended records")
 	defer r.recordSuspensionMetric(ctx, terraform)
@@ -151,6 +230,19 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, nil
 	}
 
+	// Reflect the cluster-wide pause of the applies on the object.
+	traceLog.Info("Check the cluster-wide pause")
+	var pauseToggled string
+	terraform, pauseToggled = reconcilePauseCondition(terraform, r.getClusterPause(ctx))
+	if pauseToggled != "" {
+		if err := r.patchStatus(ctx, req.NamespacedName, terraform.Status); err != nil {
+			log.Error(err, "unable to update status for the cluster-wide pause")
+			return ctrl.Result{Requeue: true}, err
+		}
+		log.Info(pauseToggled)
+		r.event(ctx, terraform, terraform.Status.LastAttemptedRevision, eventv1.EventSeverityInfo, pauseToggled, nil)
+	}
+
 	// Examine if the object is under deletion
 	if isBeingDeleted(terraform) {
 		dependants := []string{}
@@ -172,6 +264,37 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 	}
 
+	// Delete the ad-hoc plan of a branch once expired, its source copy is
+	// garbage collected with it.
+	if !isBeingDeleted(terraform) && branchPlanExpired(terraform, time.Now()) {
+		msg := "The branch plan has expired, deleting it"
+		log.Info(msg)
+		r.event(ctx, terraform, terraform.Status.LastAttemptedRevision, eventv1.EventSeverityInfo, msg, nil)
+		if err := r.Delete(ctx, &terraform); err != nil {
+			log.Error(err, "unable to delete the expired branch plan")
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Destroy the resources once expired, and delete the object if asked for.
+	traceLog.Info("Check if the Terraform resource has expired")
+	if !isBeingDeleted(terraform) && terraform.HasExpired(time.Now()) {
+		if terraform.Spec.DeleteOnExpiry {
+			msg := "The Terraform object has expired, deleting it and destroying its resources"
+			log.Info(msg)
+			r.event(ctx, terraform, terraform.Status.LastAttemptedRevision, eventv1.EventSeverityInfo, msg, nil)
+			if err := r.Delete(ctx, &terraform); err != nil {
+				log.Error(err, "unable to delete the expired Terraform object")
+				return ctrl.Result{}, client.IgnoreNotFound(err)
+			}
+			return ctrl.Result{}, nil
+		}
+
+		log.Info("The Terraform object has expired, destroying its resources")
+		terraform = expire(terraform)
+	}
+
 	// resolve source reference
 	log.Info("getting source")
 	sourceObj, err := r.getSource(ctx, terraform)
@@ -211,6 +334,34 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 	}
 
+	// pin the source to the revision override
+	if sourceObj, err = r.reconcileSourceOverride(ctx, terraform, sourceObj); err != nil {
+		msg := fmt.Sprintf("Unable to pin the source to revision '%s': %s", terraform.Spec.SourceRevisionOverride, err.Error())
+		terraform = infrav1.TerraformNotReady(terraform, "", infrav1.ArtifactFailedReason, msg)
+		if err := r.patchStatus(ctx, req.NamespacedName, terraform.Status); err != nil {
+			log.Error(err, "unable to update status for source override failed")
+			return ctrl.Result{Requeue: true}, err
+		}
+		r.recordReadinessMetric(ctx, terraform)
+		log.Info(msg)
+		return ctrl.Result{RequeueAfter: terraform.GetRetryInterval()}, nil
+	}
+
+	// overlay the source layers on the source
+	var layerRevisions []infrav1.SourceLayerRevision
+	if sourceObj, layerRevisions, err = r.reconcileSourceLayers(ctx, terraform, sourceObj); err != nil {
+		msg := fmt.Sprintf("Unable to overlay the source layers: %s", err.Error())
+		terraform = infrav1.TerraformNotReady(terraform, "", infrav1.ArtifactFailedReason, msg)
+		if err := r.patchStatus(ctx, req.NamespacedName, terraform.Status); err != nil {
+			log.Error(err, "unable to update status for source layers failed")
+			return ctrl.Result{Requeue: true}, err
+		}
+		r.recordReadinessMetric(ctx, terraform)
+		log.Info(msg)
+		return ctrl.Result{RequeueAfter: terraform.GetRetryInterval()}, nil
+	}
+	terraform.Status.SourceLayerRevisions = layerRevisions
+
 	// sourceObj does not exist, return early
 	traceLog.Info("Check we have a source object")
 	if sourceObj.GetArtifact() == nil {
@@ -227,11 +378,34 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{RequeueAfter: terraform.GetRetryInterval()}, nil
 	}
 
+	// carry the run to the logs of this reconciliation and of the runner
+	run.Revision = sourceObj.GetArtifact().Revision
+	log = log.WithValues(correlation.RevisionKey, run.Revision)
+	ctx = correlation.OutgoingContext(ctrl.LoggerInto(ctx, log), run)
+
+	// Hold back the quarantined objects until their next retry.
+	if !isBeingDeleted(terraform) {
+		var hold time.Duration
+		terraform, hold = quarantineHold(terraform, sourceObj.GetArtifact().Revision, reconcileRequested, time.Now())
+		if hold > 0 {
+			log.Info("reconciliation is held back by the quarantine of the object", "nextRetryAt", terraform.Status.Quarantine.NextRetryAt)
+			if reconcileRequested {
+				if err := r.patchStatus(ctx, req.NamespacedName, terraform.Status); err != nil {
+					log.Error(err, "unable to update status to record the handled reconcile request")
+					return ctrl.Result{Requeue: true}, err
+				}
+			}
+			return ctrl.Result{RequeueAfter: hold}, nil
+		}
+		recordQuarantineMetric(terraform)
+	}
+
 	// check dependencies, if not being deleted
 	if len(terraform.Spec.DependsOn) > 0 && !isBeingDeleted(terraform) {
 		if err := r.checkDependencies(sourceObj, terraform); err != nil {
 			terraform = infrav1.TerraformNotReady(
 				terraform, sourceObj.GetArtifact().Revision, infrav1.DependencyNotReadyReason, err.Error())
+			terraform = infrav1.TerraformWaiting(terraform, infrav1.WaitingForDependencies, err.Error(), 0)
 
 			if err := r.patchStatus(ctx, req.NamespacedName, terraform.Status); err != nil {
 				log.Error(err, "unable to update status for dependency not ready")
@@ -239,14 +413,52 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			}
 			// we can't rely on exponential backoff because it will prolong the execution too much,
 			// instead we requeue on a fix interval.
-			msg := fmt.Sprintf("Dependencies do not meet ready condition, retrying in %s", terraform.GetRetryInterval().String())
+			retryAfter := retryAfterError(terraform, err, terraform.GetRetryInterval())
+			msg := fmt.Sprintf("Dependencies do not meet ready condition, retrying in %s", retryAfter.String())
 			log.Info(msg)
 			r.event(ctx, terraform, sourceObj.GetArtifact().Revision, eventv1.EventSeverityInfo, msg, nil)
 			r.recordReadinessMetric(ctx, terraform)
 
-			return ctrl.Result{RequeueAfter: terraform.GetRetryInterval()}, nil
+			return ctrl.Result{RequeueAfter: retryAfter}, nil
 		}
 		log.Info("All dependencies are ready, proceeding with reconciliation")
+		terraform = infrav1.TerraformNotWaiting(terraform, infrav1.WaitingForDependencies)
+	}
+
+	// validate the backend configuration sources before starting a runner
+	if !isBeingDeleted(terraform) {
+		if err := r.validateBackendConfigs(ctx, terraform); err != nil {
+			var invalid *backendConfigError
+			if !errors.As(err, &invalid) {
+				log.Error(err, "unable to validate the backend configuration")
+				return ctrl.Result{}, err
+			}
+
+			terraform = infrav1.TerraformNotReady(
+				terraform, sourceObj.GetArtifact().Revision, infrav1.BackendConfigInvalidReason, err.Error())
+			if err := r.patchStatus(ctx, req.NamespacedName, terraform.Status); err != nil {
+				log.Error(err, "unable to update status for invalid backend configuration")
+				return ctrl.Result{Requeue: true}, err
+			}
+			msg := fmt.Sprintf("Invalid backend configuration: %s", err.Error())
+			log.Info(msg)
+			r.event(ctx, terraform, sourceObj.GetArtifact().Revision, eventv1.EventSeverityError, msg, nil)
+			r.recordReadinessMetric(ctx, terraform)
+
+			// the referenced Secrets and ConfigMaps are watched, fixing them triggers a reconciliation
+			return ctrl.Result{RequeueAfter: terraform.GetRetryInterval()}, nil
+		}
+	}
+
+	// record what a new revision changes since the last apply
+	if !isBeingDeleted(terraform) {
+		var changed bool
+		if terraform, changed = r.reconcileRevisionDiff(ctx, terraform, sourceObj); changed {
+			if err := r.patchStatus(ctx, req.NamespacedName, terraform.Status); err != nil {
+				log.Error(err, "unable to update status with the revision diff")
+				return ctrl.Result{Requeue: true}, err
+			}
+		}
 	}
 
 	// Skip update the status if the ready condition is still unknown
@@ -310,9 +522,77 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		if terraform.Status.Plan.Pending != "" &&
 			!r.forceOrAutoApply(terraform) &&
 			!r.shouldApply(terraform) {
+			// acknowledge the reconcile request, even if there's nothing to do
+			if reconcileRequested {
+				if err := r.patchStatus(ctx, req.NamespacedName, terraform.Status); err != nil {
+					log.Error(err, "unable to update status to record the handled reconcile request")
+					return ctrl.Result{Requeue: true}, err
+				}
+			}
+			discarded, result, err := r.escalatePendingPlan(ctx, &terraform, sourceObj.GetArtifact().Revision, time.Now())
+			if err != nil {
+				log.Error(err, "unable to escalate the pending plan")
+				return ctrl.Result{Requeue: true}, err
+			}
+			if discarded {
+				log.Info("the pending plan expired before it was approved, and is discarded")
+				return result, nil
+			}
 			log.Info("reconciliation is stopped to wait for a manual approve")
-			return ctrl.Result{}, nil
+			return requeueBeforeExpiry(terraform, result, time.Now()), nil
+		}
+	}
+
+	// Refuse the Terraform objects keeping their state in the cluster when
+	// the policies of their namespace require a remote backend.
+	if !isBeingDeleted(terraform) {
+		violation, err := r.remoteBackendViolation(ctx, terraform)
+		if err != nil {
+			log.Error(err, "unable to check the remote backend policies")
+			return ctrl.Result{Requeue: true}, err
+		}
+		if violation != "" {
+			terraform = infrav1.TerraformNotReady(terraform, sourceObj.GetArtifact().Revision, infrav1.RemoteBackendRequiredReason, violation)
+			if err := r.patchStatus(ctx, req.NamespacedName, terraform.Status); err != nil {
+				log.Error(err, "unable to update status for remote backend required")
+				return ctrl.Result{Requeue: true}, err
+			}
+			r.recordReadinessMetric(ctx, terraform)
+			log.Info(violation)
+			r.event(ctx, terraform, sourceObj.GetArtifact().Revision, eventv1.EventSeverityError, violation, nil)
+			// a change of the backend is a new generation, reconciled anyway
+			return ctrl.Result{RequeueAfter: terraform.Spec.Interval.Duration}, nil
+		}
+	}
+
+	// Hold back the Terraform objects beyond the quota of their namespace.
+	if !isBeingDeleted(terraform) {
+		var quotaExceeded *quotaExceededError
+		releaseRunner, err := r.checkRunnerQuota(ctx, terraform)
+		defer releaseRunner()
+		if errors.As(err, &quotaExceeded) {
+			terraform = infrav1.TerraformNotReady(terraform, sourceObj.GetArtifact().Revision, infrav1.QuotaExceededReason, quotaExceeded.Error())
+			terraform = infrav1.TerraformWaiting(terraform, quotaExceeded.waitingFor, quotaExceeded.Error(), 0)
+			if quotaExceeded.waitingFor == infrav1.WaitingForRunnerCapacity {
+				if position, err := r.waitingPosition(ctx, terraform); err == nil {
+					terraform.Status.Waiting.Position = position
+				} else {
+					log.Error(err, "unable to get the position of the object among the waiting ones")
+				}
+			}
+			if err := r.patchStatus(ctx, req.NamespacedName, terraform.Status); err != nil {
+				log.Error(err, "unable to update status for quota exceeded")
+				return ctrl.Result{Requeue: true}, err
+			}
+			r.recordReadinessMetric(ctx, terraform)
+			log.Info(quotaExceeded.Error())
+			r.event(ctx, terraform, sourceObj.GetArtifact().Revision, eventv1.EventSeverityError, quotaExceeded.Error(), nil)
+			return ctrl.Result{RequeueAfter: retryAfterError(terraform, quotaExceeded, quotaExceeded.retryAfter)}, nil
+		} else if err != nil {
+			log.Error(err, "unable to check the namespace quota")
+			return ctrl.Result{Requeue: true}, err
 		}
+		terraform = infrav1.TerraformNotWaiting(terraform, infrav1.WaitingForTerraformQuota, infrav1.WaitingForRunnerCapacity)
 	}
 
 	// Create Runner Pod.
@@ -320,6 +600,36 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	traceLog.Info("Fetch/Create Runner pod for this Terraform resource")
 	runnerClient, closeConn, err := r.LookupOrCreateRunner(ctx, terraform, sourceObj.GetArtifact().Revision)
 	if err != nil {
+		var inFlight *runnerOperationInFlightError
+		if errors.As(err, &inFlight) {
+			log.Info("waiting for the runner to finish an operation started by a previous controller instance",
+				"operation", inFlight.operation, "holder", inFlight.holder)
+			terraform = infrav1.TerraformWaiting(terraform, infrav1.WaitingForRunnerOperation, inFlight.Error(), 0)
+			if err := r.patchStatus(ctx, req.NamespacedName, terraform.Status); err != nil {
+				log.Error(err, "unable to update status for the runner operation in flight")
+				return ctrl.Result{Requeue: true}, err
+			}
+			return ctrl.Result{RequeueAfter: inFlight.retryAfter}, nil
+		}
+		var runnerFailed *runnerPodFailedError
+		if errors.As(err, &runnerFailed) {
+			revision := sourceObj.GetArtifact().Revision
+			terraform = infrav1.TerraformRunnerFailed(terraform, revision, runnerFailed.reason(), runnerFailed.Error())
+			var quarantined bool
+			terraform, quarantined = recordReconcileFailure(terraform, revision, time.Now())
+			if err := r.patchStatus(ctx, req.NamespacedName, terraform.Status); err != nil {
+				log.Error(err, "unable to update status for the failed runner")
+				return ctrl.Result{Requeue: true}, err
+			}
+			r.recordReadinessMetric(ctx, terraform)
+			recordQuarantineMetric(terraform)
+			r.event(ctx, terraform, revision, eventv1.EventSeverityError, runnerFailed.Error(), nil)
+			if quarantined {
+				r.eventQuarantined(ctx, terraform, revision)
+				return ctrl.Result{RequeueAfter: terraform.GetQuarantineBackoff()}, nil
+			}
+			return ctrl.Result{RequeueAfter: retryAfterError(terraform, runnerFailed, terraform.GetRetryInterval())}, nil
+		}
 		log.Error(err, "unable to lookup or create runner")
 		if closeConn != nil {
 			if err := closeConn(); err != nil {
@@ -329,6 +639,7 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, err
 	}
 	log.Info("runner is running")
+	terraform = infrav1.TerraformNotWaiting(terraform, infrav1.WaitingForRunnerOperation)
 
 	traceLog.Info("Defer function to handle clean up")
 	defer func(ctx context.Context, cli client.Client, terraform infrav1.Terraform) {
@@ -404,6 +715,34 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	// reconcile Terraform by applying the latest revision
 	traceLog.Info("Run reconcile for the Terraform resource")
 	reconciledTerraform, reconcileErr := r.reconcile(ctx, runnerClient, *terraform.DeepCopy(), sourceObj, reconciliationLoopID)
+	if reconcileErr != nil && os.Getenv("INSECURE_LOCAL_RUNNER") != "1" {
+		traceLog.Info("Check if the run failed because the Runner pod crashed")
+		var runnerFailed *runnerPodFailedError
+		if err := r.checkRunnerPodFailure(ctx, terraform); errors.As(err, &runnerFailed) {
+			failedTerraform := infrav1.TerraformRunnerFailed(*reconciledTerraform, sourceObj.GetArtifact().Revision, runnerFailed.reason(), runnerFailed.Error())
+			reconciledTerraform, reconcileErr = &failedTerraform, runnerFailed
+		} else if err != nil {
+			log.Error(err, "unable to check the runner pod")
+		}
+	} else if reconcileErr == nil {
+		apimeta.RemoveStatusCondition(&reconciledTerraform.Status.Conditions, infrav1.ConditionTypeRunner)
+		reconciledTerraform.Status.Waiting = nil
+	}
+	quarantined := false
+	if isReconcileFailure(reconcileErr) {
+		traceLog.Info("Count the failure of the reconciliation")
+		*reconciledTerraform, quarantined = recordReconcileFailure(*reconciledTerraform, sourceObj.GetArtifact().Revision, time.Now())
+	} else if reconcileErr == nil {
+		*reconciledTerraform = recordReconcileSuccess(*reconciledTerraform)
+	}
+	if reconcileErr == nil {
+		traceLog.Info("Check for the conflicts with other Terraform objects")
+		*reconciledTerraform = r.checkConflicts(ctx, *reconciledTerraform, sourceObj.GetArtifact().Revision)
+	}
+	if reconciledTerraform != nil && reconciledTerraform.Spec.ResultsExport != nil {
+		traceLog.Info("Export the results of the run")
+		r.exportResults(ctx, runnerClient, *reconciledTerraform, sourceObj.GetArtifact().Revision)
+	}
 	traceLog.Info("Patch the status of the Terraform resource")
 	if err := r.patchStatus(ctx, req.NamespacedName, reconciledTerraform.Status); err != nil {
 		log.Error(err, "unable to update status after the reconciliation is complete")
@@ -412,9 +751,27 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	traceLog.Info("Record the readiness metrics")
 	r.recordReadinessMetric(ctx, *reconciledTerraform)
+	recordQuarantineMetric(*reconciledTerraform)
 
 	traceLog.Info("Check for reconciliation errors")
-	if reconcileErr != nil && reconcileErr.Error() == infrav1.DriftDetectedReason {
+	var quotaExceeded *quotaExceededError
+	var applyPaused *applyPausedError
+	var consumersRollingOut *consumersRollingOutError
+	if errors.As(reconcileErr, &quotaExceeded) {
+		log.Info(quotaExceeded.Error())
+		r.event(ctx, *reconciledTerraform, sourceObj.GetArtifact().Revision, eventv1.EventSeverityError, quotaExceeded.Error(), nil)
+		return ctrl.Result{RequeueAfter: retryAfterError(*reconciledTerraform, quotaExceeded, quotaExceeded.retryAfter)}, nil
+	} else if errors.As(reconcileErr, &applyPaused) {
+		// the toggle of the pause is the event, the pause ConfigMap
+		// enqueues the object again once it changes
+		log.Info(applyPaused.Error())
+		return ctrl.Result{RequeueAfter: terraform.GetRetryInterval()}, nil
+	} else if errors.As(reconcileErr, &consumersRollingOut) {
+		// the plan is applied once the rollout of the consumers is over
+		log.Info(consumersRollingOut.Error())
+		r.eventWithReason(ctx, *reconciledTerraform, sourceObj.GetArtifact().Revision, eventv1.EventSeverityInfo, infrav1.ConsumersRollingOutReason, consumersRollingOut.Error(), nil)
+		return ctrl.Result{RequeueAfter: terraform.GetRetryInterval()}, nil
+	} else if reconcileErr != nil && reconcileErr.Error() == infrav1.DriftDetectedReason {
 		log.Error(reconcileErr, fmt.Sprintf("Drift detected after %s, next try in %s",
 			time.Since(reconcileStart).String(),
 			terraform.GetRetryInterval().String()),
@@ -422,15 +779,20 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			sourceObj.GetArtifact().Revision)
 		return ctrl.Result{RequeueAfter: terraform.GetRetryInterval()}, nil
 	} else if reconcileErr != nil {
-		// broadcast the reconciliation failure and requeue at the specified retry interval
+		// broadcast the reconciliation failure and requeue at the retry interval of its error
+		retryAfter := retryAfterError(*reconciledTerraform, reconcileErr, terraform.GetRetryInterval())
 		log.Error(reconcileErr, fmt.Sprintf("Reconciliation failed after %s, next try in %s",
 			time.Since(reconcileStart).String(),
-			terraform.GetRetryInterval().String()),
+			retryAfter.String()),
 			"revision",
 			sourceObj.GetArtifact().Revision)
 		traceLog.Info("Record an event for the failure")
 		r.event(ctx, *reconciledTerraform, sourceObj.GetArtifact().Revision, eventv1.EventSeverityError, reconcileErr.Error(), nil)
-		return ctrl.Result{RequeueAfter: terraform.GetRetryInterval()}, nil
+		if quarantined {
+			r.eventQuarantined(ctx, *reconciledTerraform, sourceObj.GetArtifact().Revision)
+			return ctrl.Result{RequeueAfter: terraform.GetQuarantineBackoff()}, nil
+		}
+		return ctrl.Result{RequeueAfter: retryAfter}, nil
 	}
 
 	log.Info(fmt.Sprintf("Reconciliation completed. Generation: %d", reconciledTerraform.GetGeneration()))
@@ -438,12 +800,13 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	traceLog.Info("Check for pending plan and forceOrAutoApply")
 	if reconciledTerraform.Status.Plan.Pending != "" && !r.forceOrAutoApply(*reconciledTerraform) {
 		log.Info("Reconciliation is stopped to wait for manual operations")
-		return ctrl.Result{}, nil
+		return requeueBeforeExpiry(terraform, ctrl.Result{}, time.Now()), nil
 	}
 
-	// next reconcile is .Spec.Interval in the future
+	// next reconcile is .Spec.Interval in the future, or the next refresh of the state
 	log.Info("requeue after interval", "interval", terraform.Spec.Interval.Duration.String())
-	return ctrl.Result{RequeueAfter: terraform.Spec.Interval.Duration}, nil
+	result := requeueBeforeRefresh(*reconciledTerraform, ctrl.Result{RequeueAfter: terraform.Spec.Interval.Duration}, time.Now())
+	return requeueBeforeExpiry(terraform, result, time.Now()), nil
 }
 
 func isBeingDeleted(terraform infrav1.Terraform) bool {
@@ -470,6 +833,25 @@ func (r *TerraformReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurrentRe
 		return fmt.Errorf("failed setting index fields: %w", err)
 	}
 
+	// Index the Terraforms by the Secrets and ConfigMaps they read variables,
+	// backend configurations and the CLI configuration from.
+	if err := mgr.GetCache().IndexField(context.TODO(), &infrav1.Terraform{}, infrav1.SecretIndexKey,
+		r.IndexByReferenced("Secret")); err != nil {
+		return fmt.Errorf("failed setting index fields: %w", err)
+	}
+
+	if err := mgr.GetCache().IndexField(context.TODO(), &infrav1.Terraform{}, infrav1.ConfigMapIndexKey,
+		r.IndexByReferenced("ConfigMap")); err != nil {
+		return fmt.Errorf("failed setting index fields: %w", err)
+	}
+
+	// Index the Terraforms by the resources they manage, to detect the
+	// objects managing the same resources.
+	if err := mgr.GetCache().IndexField(context.TODO(), &infrav1.Terraform{}, infrav1.ManagedResourceIndexKey,
+		r.IndexByManagedResource()); err != nil {
+		return fmt.Errorf("failed setting index fields: %w", err)
+	}
+
 	// Configure the retryable http client used for fetching artifacts.
 	// By default, it retries 10 times within a 3.5 minutes window.
 	httpClient := retryablehttp.NewClient()
@@ -482,15 +864,34 @@ func (r *TerraformReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurrentRe
 	r.requeueDependency = 30 * time.Second
 	recoverPanic := true
 
-	return ctrl.NewControllerManagedBy(mgr).
+	// Enqueue the Terraforms by priority when the controller starts.
+	startup := newStartupQueue(mgr.GetClient(), r.StartupReconcileRate)
+	startup.recover = r.recoverInterrupted
+	if err := mgr.Add(startup); err != nil {
+		return fmt.Errorf("failed adding the startup queue: %w", err)
+	}
+
+	blder := ctrl.NewControllerManagedBy(mgr)
+	if r.PauseConfigMap.Name != "" {
+		blder = blder.Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForPauseChange),
+			builder.WithPredicates(predicate.NewPredicateFuncs(r.isPauseConfigMap), ReferencedDataChangePredicate{}),
+		)
+	}
+
+	return blder.
 		For(&infrav1.Terraform{}, builder.WithPredicates(
 			predicate.Or(predicate.GenerationChangedPredicate{}, predicates.ReconcileRequestedPredicate{}),
+			startup.predicate(),
 		)).
+		WatchesRawSource(&source.Channel{Source: startup.events}, &handler.EnqueueRequestForObject{}).
 		Watches(
 			&sourcev1.GitRepository{},
 			handler.EnqueueRequestsFromMapFunc(r.requestsForRevisionChangeOf(infrav1.GitRepositoryIndexKey)),
 			builder.WithPredicates(SourceRevisionChangePredicate{}),
 		).
+		Owns(&sourcev1.GitRepository{}, builder.WithPredicates(SourceRevisionChangePredicate{})).
 		Watches(
 			&sourcev1b2.Bucket{},
 			handler.EnqueueRequestsFromMapFunc(r.requestsForRevisionChangeOf(infrav1.BucketIndexKey)),
@@ -506,6 +907,16 @@ func (r *TerraformReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurrentRe
 			handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &infrav1.Terraform{}, handler.OnlyControllerOwner()),
 			builder.WithPredicates(SecretDeletePredicate{}),
 		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForReferenceChangeOf(infrav1.SecretIndexKey)),
+			builder.WithPredicates(ReferencedDataChangePredicate{}),
+		).
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForReferenceChangeOf(infrav1.ConfigMapIndexKey)),
+			builder.WithPredicates(ReferencedDataChangePredicate{}),
+		).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: maxConcurrentReconciles,
 			RecoverPanic:            &recoverPanic,
@@ -555,8 +966,8 @@ func (r *TerraformReconciler) checkDependencies(source sourcev1.Source, terrafor
 			return fmt.Errorf("dependency '%s' is not updated yet", dName)
 		}
 
-		if tf.Spec.WriteOutputsToSecret != nil {
-			outputSecret := tf.Spec.WriteOutputsToSecret.Name
+		for _, wots := range tf.GetWriteOutputsToSecrets() {
+			outputSecret := wots.Name
 			outputSecretName := types.NamespacedName{
 				Namespace: tf.GetNamespace(),
 				Name:      outputSecret,
@@ -571,6 +982,32 @@ func (r *TerraformReconciler) checkDependencies(source sourcev1.Source, terrafor
 	return nil
 }
 
+// requestsForReferenceChangeOf enqueues the Terraforms referencing a Secret
+// or a ConfigMap which data has changed, so that they get re-planned
+// without waiting for the next interval.
+func (r *TerraformReconciler) requestsForReferenceChangeOf(indexKey string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		log := ctrl.LoggerFrom(ctx)
+
+		var list infrav1.TerraformList
+		if err := r.List(ctx, &list, client.MatchingFields{
+			indexKey: client.ObjectKeyFromObject(obj).String(),
+		}); err != nil {
+			log.Error(err, "failed to list objects for reference change")
+			return nil
+		}
+
+		reqs := make([]reconcile.Request, 0, len(list.Items))
+		for _, t := range list.Items {
+			if t.Spec.Suspend {
+				continue
+			}
+			reqs = append(reqs, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&t)})
+		}
+		return reqs
+	}
+}
+
 func (r *TerraformReconciler) requestsForRevisionChangeOf(indexKey string) handler.MapFunc {
 	return func(ctx context.Context, obj client.Object) []reconcile.Request {
 		log := ctrl.LoggerFrom(ctx)
@@ -618,22 +1055,28 @@ func (r *TerraformReconciler) requestsForRevisionChangeOf(indexKey string) handl
 }
 
 func (r *TerraformReconciler) getSource(ctx context.Context, terraform infrav1.Terraform) (sourcev1.Source, error) {
+	return r.getSourceByRef(ctx, terraform, terraform.Spec.SourceRef)
+}
+
+// getSourceByRef returns the source referenced by the Terraform object, as
+// its source or one of its source layers.
+func (r *TerraformReconciler) getSourceByRef(ctx context.Context, terraform infrav1.Terraform, sourceRef infrav1.CrossNamespaceSourceReference) (sourcev1.Source, error) {
 	var sourceObj sourcev1.Source
 	sourceNamespace := terraform.GetNamespace()
-	if terraform.Spec.SourceRef.Namespace != "" {
-		sourceNamespace = terraform.Spec.SourceRef.Namespace
+	if sourceRef.Namespace != "" {
+		sourceNamespace = sourceRef.Namespace
 	}
 	namespacedName := types.NamespacedName{
 		Namespace: sourceNamespace,
-		Name:      terraform.Spec.SourceRef.Name,
+		Name:      sourceRef.Name,
 	}
-	if r.NoCrossNamespaceRefs && namespacedName.Namespace != terraform.GetNamespace() {
+	if !r.crossNamespaceRefAllowed(terraform.GetNamespace(), namespacedName.Namespace) {
 		return sourceObj, acl.AccessDeniedError(
-			fmt.Sprintf("cannot access %s/%s, cross-namespace references have been disabled", terraform.Spec.SourceRef.Kind, namespacedName),
+			fmt.Sprintf("cannot access %s/%s, cross-namespace references have been disabled", sourceRef.Kind, namespacedName),
 		)
 	}
 
-	switch terraform.Spec.SourceRef.Kind {
+	switch sourceRef.Kind {
 	case sourcev1.GitRepositoryKind:
 		var repository sourcev1.GitRepository
 		err := r.Client.Get(ctx, namespacedName, &repository)
@@ -666,7 +1109,7 @@ func (r *TerraformReconciler) getSource(ctx context.Context, terraform infrav1.T
 		sourceObj = &repository
 	default:
 		return sourceObj, fmt.Errorf("source `%s` kind '%s' not supported",
-			terraform.Spec.SourceRef.Name, terraform.Spec.SourceRef.Kind)
+			sourceRef.Name, sourceRef.Kind)
 	}
 	return sourceObj, nil
 }
@@ -768,6 +1211,8 @@ func (r *TerraformReconciler) patchStatus(ctx context.Context, objectKey types.N
 	traceLog.Info("Update data and send Patch request")
 	patch := client.MergeFrom(terraform.DeepCopy())
 	terraform.Status = newStatus
+	r.setMappedConditions(ctx, &terraform)
+	terraform.Status.Summary = terraform.GetSummary()
 	statusOpts := &client.SubResourcePatchOptions{
 		PatchOptions: client.PatchOptions{
 			FieldManager: "tf-controller",
@@ -783,21 +1228,82 @@ func (r *TerraformReconciler) IndexBy(kind string) func(o client.Object) []strin
 			panic(fmt.Sprintf("Expected a Terraform, got %T", o))
 		}
 
-		if terraform.Spec.SourceRef.Kind == kind {
+		sourceRefs := []infrav1.CrossNamespaceSourceReference{terraform.Spec.SourceRef}
+		for _, layer := range terraform.Spec.SourceLayers {
+			sourceRefs = append(sourceRefs, layer.SourceRef)
+		}
+
+		var keys []string
+		for _, sourceRef := range sourceRefs {
+			if sourceRef.Kind != kind {
+				continue
+			}
 			namespace := terraform.GetNamespace()
-			if terraform.Spec.SourceRef.Namespace != "" {
-				namespace = terraform.Spec.SourceRef.Namespace
+			if sourceRef.Namespace != "" {
+				namespace = sourceRef.Namespace
 			}
-			return []string{fmt.Sprintf("%s/%s", namespace, terraform.Spec.SourceRef.Name)}
+			keys = append(keys, fmt.Sprintf("%s/%s", namespace, sourceRef.Name))
+		}
+		return keys
+	}
+}
+
+// IndexByReferenced indexes the Terraforms by the Secrets or ConfigMaps,
+// depending on the kind, referenced by varsFrom, backendConfigsFrom,
+// gitCredentials and cliConfigSecretRef.
+func (r *TerraformReconciler) IndexByReferenced(kind string) func(o client.Object) []string {
+	return func(o client.Object) []string {
+		terraform, ok := o.(*infrav1.Terraform)
+		if !ok {
+			panic(fmt.Sprintf("Expected a Terraform, got %T", o))
 		}
 
-		return nil
+		namespace := terraform.GetNamespace()
+		var keys []string
+		for _, ref := range terraform.Spec.VarsFrom {
+			if ref.Kind == kind {
+				keys = append(keys, fmt.Sprintf("%s/%s", namespace, ref.Name))
+			}
+		}
+		for _, ref := range terraform.Spec.BackendConfigsFrom {
+			if ref.Kind == kind {
+				keys = append(keys, fmt.Sprintf("%s/%s", namespace, ref.Name))
+			}
+		}
+		if creds := terraform.Spec.GitCredentials; creds != nil && kind == "Secret" {
+			keys = append(keys, fmt.Sprintf("%s/%s", namespace, creds.SecretRef.Name))
+		}
+		if ref := terraform.Spec.CliConfigSecretRef; ref != nil && kind == "Secret" {
+			if ref.Namespace != "" {
+				namespace = ref.Namespace
+			}
+			keys = append(keys, fmt.Sprintf("%s/%s", namespace, ref.Name))
+		}
+
+		return keys
 	}
 }
 
 func (r *TerraformReconciler) event(ctx context.Context, terraform infrav1.Terraform, revision, severity, msg string, metadata map[string]string) {
 	log := ctrl.LoggerFrom(ctx)
 	traceLog := log.V(logger.TraceLevel).WithValues("function", "TerraformReconciler.event")
+	traceLog.Info("Set reason to severity")
+	reason := severity
+	traceLog.Info("Check if we have a status condition")
+	if c := apimeta.FindStatusCondition(terraform.Status.Conditions, meta.ReadyCondition); c != nil {
+		traceLog.Info("Set the reason to the status condition reason")
+		reason = c.Reason
+	}
+
+	r.eventWithReason(ctx, terraform, revision, severity, reason, msg, metadata)
+}
+
+// eventWithReason records an event with its own reason, rather than the
+// reason of the Ready condition, for the notices which can be told apart
+// from the reconciliation results.
+func (r *TerraformReconciler) eventWithReason(ctx context.Context, terraform infrav1.Terraform, revision, severity, reason, msg string, metadata map[string]string) {
+	log := ctrl.LoggerFrom(ctx)
+	traceLog := log.V(logger.TraceLevel).WithValues("function", "TerraformReconciler.eventWithReason")
 	traceLog.Info("If metadata is nil set to an empty map")
 	if metadata == nil {
 		traceLog.Info("Is nil, set to an empty map")
@@ -809,12 +1315,25 @@ func (r *TerraformReconciler) event(ctx context.Context, terraform infrav1.Terra
 		metadata[infrav1.GroupVersion.Group+"/revision"] = revision
 	}
 
-	traceLog.Info("Set reason to severity")
-	reason := severity
-	traceLog.Info("Check if we have a status condition")
-	if c := apimeta.FindStatusCondition(terraform.Status.Conditions, meta.ReadyCondition); c != nil {
-		traceLog.Info("Set the reason to the status condition reason")
-		reason = c.Reason
+	traceLog.Info("Check the verbosity of the events")
+	verbosity := r.eventVerbosity(terraform)
+	if !eventVerbose(verbosity, severity) {
+		traceLog.Info("Skip the event below the verbosity", "verbosity", verbosity)
+		return
+	}
+
+	traceLog.Info("Aggregate the repeated events")
+	if verbosity != infrav1.EventVerbosityDebug {
+		window := r.eventAggregationWindow()
+		record, repeated, since := r.events.admit(terraform.UID, severity, reason, msg, time.Now(), window)
+		if !record {
+			traceLog.Info("Skip the event repeated within the aggregation window", "window", window)
+			return
+		}
+		if repeated > 0 {
+			msg = aggregatedEventMessage(msg, repeated, since)
+			metadata[eventRepeatedKey] = strconv.Itoa(repeated)
+		}
 	}
 
 	traceLog.Info("Set the event type to Normal")
@@ -823,6 +1342,9 @@ func (r *TerraformReconciler) event(ctx context.Context, terraform infrav1.Terra
 	if severity == eventv1.EventSeverityError {
 		traceLog.Info("Set event type to Warning")
 		eventType = "Warning"
+	} else if severity == eventv1.EventSeverityTrace {
+		traceLog.Info("Set event type to Trace")
+		eventType = eventv1.EventTypeTrace
 	}
 
 	traceLog.Info("Add new annotated event")
@@ -49,6 +49,11 @@ func (r *TerraformReconciler) apply(ctx context.Context, terraform infrav1.Terra
 	log := ctrl.LoggerFrom(ctx)
 	objectKey := types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Name}
 
+	terraform, err := checkMovedBlocks(terraform, revision)
+	if err != nil {
+		return terraform, err
+	}
+
 	terraform = infrav1.TerraformProgressing(terraform, "Applying")
 	if err := r.patchStatus(ctx, objectKey, terraform.Status); err != nil {
 		log.Error(err, "unable to update status before Terraform applying")
@@ -77,7 +82,15 @@ func (r *TerraformReconciler) apply(ctx context.Context, terraform infrav1.Terra
 
 	log.Info(fmt.Sprintf("load tf plan: %s", loadTFPlanReply.Message))
 
+	if !r.backendCompletelyDisable(terraform) {
+		terraform, err = r.checkPlanFreshness(ctx, terraform, tfInstance, runnerClient, revision)
+		if err != nil {
+			return terraform, err
+		}
+	}
+
 	terraform = infrav1.TerraformApplying(terraform, revision, "Apply started")
+	terraform.Status.Progress = nil
 	if err := r.patchStatus(ctx, objectKey, terraform.Status); err != nil {
 		log.Error(err, "error recording apply status: %s", err)
 		return terraform, err
@@ -89,6 +102,9 @@ func (r *TerraformReconciler) apply(ctx context.Context, terraform infrav1.Terra
 		RefreshBeforeApply: terraform.Spec.RefreshBeforeApply,
 		Targets:            terraform.Spec.Targets,
 	}
+	if changes := terraform.Status.Plan.Changes; changes != nil {
+		applyRequest.ExpectedChanges = changes.Add + changes.Change + changes.Destroy
+	}
 	if r.backendCompletelyDisable(terraform) {
 		// do nothing
 	} else {
@@ -126,7 +142,7 @@ func (r *TerraformReconciler) apply(ctx context.Context, terraform infrav1.Terra
 				r.event(ctx, terraform, revision, eventv1.EventSeverityError, msg, nil)
 			}
 
-			err = fmt.Errorf("error running Destroy: %s", err)
+			err = withStateLock(err, fmt.Errorf("error running Destroy: %s", err))
 			return infrav1.TerraformAppliedFailResetPlanAndNotReady(
 				terraform,
 				revision,
@@ -136,12 +152,23 @@ func (r *TerraformReconciler) apply(ctx context.Context, terraform infrav1.Terra
 		}
 		isDestroyApplied = true
 	} else {
+		if r.shouldApplyCanary(terraform) {
+			terraform, err = r.applyCanary(ctx, terraform, runnerClient, revision, applyRequest)
+			if err != nil {
+				return terraform, err
+			}
+		}
+
 		eventSent := false
+		stopProgress := r.watchProgress(ctx, objectKey, tfInstance, runnerClient)
 		applyReply, err := runnerClient.Apply(ctx, applyRequest)
+		stopProgress()
 		if err != nil {
 			if st, ok := status.FromError(err); ok {
 				for _, detail := range st.Details() {
-					if reply, ok := detail.(*runner.ApplyReply); ok {
+					if reply, ok := detail.(*runner.ApplyReply); ok && reply.StateLockIdentifier == "" {
+						terraform.Status.Errors = terraformErrors(terraform, reply.Diagnostics)
+					} else if ok {
 						msg := fmt.Sprintf("Apply error: State locked with Lock Identifier %s", reply.StateLockIdentifier)
 						r.event(ctx, terraform, revision, eventv1.EventSeverityError, msg, nil)
 						eventSent = true
@@ -155,7 +182,7 @@ func (r *TerraformReconciler) apply(ctx context.Context, terraform infrav1.Terra
 				r.event(ctx, terraform, revision, eventv1.EventSeverityError, msg, nil)
 			}
 
-			err = fmt.Errorf("error running Apply: %s", err)
+			err = withStateLock(err, fmt.Errorf("error running Apply: %s", err))
 			return infrav1.TerraformAppliedFailResetPlanAndNotReady(
 				terraform,
 				revision,
@@ -164,6 +191,8 @@ func (r *TerraformReconciler) apply(ctx context.Context, terraform infrav1.Terra
 			), err
 		}
 		log.Info(fmt.Sprintf("apply: %s", applyReply.Message))
+		terraform.Status.Errors = nil
+		recordResourceTimings(ctx, terraform, applyReply.ResourceTimings)
 
 		isDestroyApplied = terraform.Status.Plan.IsDestroyPlan
 
@@ -0,0 +1,150 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	eventv1 "github.com/fluxcd/pkg/apis/event/v1beta1"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// approvalEscalation returns the approval escalation of the Terraform object,
+// or, when it does not set one, the one of the first TerraformNamespacePolicy
+// of its namespace setting one, by name.
+func (r *TerraformReconciler) approvalEscalation(ctx context.Context, terraform infrav1.Terraform) (*infrav1.ApprovalEscalation, error) {
+	if terraform.Spec.ApprovalEscalation != nil {
+		return terraform.Spec.ApprovalEscalation, nil
+	}
+
+	var policies infrav1.TerraformNamespacePolicyList
+	if err := r.APIReader.List(ctx, &policies, client.InNamespace(terraform.Namespace)); err != nil {
+		if apimeta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to list the namespace policies: %w", err)
+	}
+
+	sort.Slice(policies.Items, func(i, j int) bool {
+		return policies.Items[i].Name < policies.Items[j].Name
+	})
+	for _, policy := range policies.Items {
+		if policy.Spec.ApprovalEscalation != nil {
+			return policy.Spec.ApprovalEscalation, nil
+		}
+	}
+	return nil, nil
+}
+
+// escalatePendingPlan reminds of the plan of the Terraform object pending
+// approval, escalates it, or discards it, once it has been pending for the
+// durations of its approval escalation. It returns whether the plan was
+// discarded, and the result requeueing the object when the next of them is
+// due.
+func (r *TerraformReconciler) escalatePendingPlan(ctx context.Context, terraform *infrav1.Terraform, revision string, now time.Time) (bool, ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	plan := &terraform.Status.Plan
+	if plan.Pending == "" || plan.PendingSince == nil {
+		return false, ctrl.Result{}, nil
+	}
+
+	escalation, err := r.approvalEscalation(ctx, *terraform)
+	if err != nil || escalation == nil {
+		return false, ctrl.Result{}, err
+	}
+
+	objectKey := types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Name}
+	planID := plan.Pending
+	pendingSince := plan.PendingSince.Time
+	pendingFor := now.Sub(pendingSince).Round(time.Minute)
+
+	if expiry := durationOf(escalation.PlanExpiry); expiry > 0 && !now.Before(pendingSince.Add(expiry)) {
+		msg := fmt.Sprintf("Plan %s discarded: not approved within %s", planID, expiry)
+		plan.Pending = ""
+		plan.PendingSince = nil
+		plan.RemindedAt = nil
+		plan.EscalatedAt = nil
+		*terraform = infrav1.TerraformNotReady(*terraform, revision, infrav1.PlanExpiredReason, msg)
+		if err := r.patchStatus(ctx, objectKey, terraform.Status); err != nil {
+			return false, ctrl.Result{}, err
+		}
+		r.eventWithReason(ctx, *terraform, revision, eventv1.EventSeverityInfo, infrav1.PlanExpiredReason, msg, nil)
+		return true, ctrl.Result{RequeueAfter: terraform.Spec.Interval.Duration}, nil
+	}
+
+	notified := false
+	escalateAfter := durationOf(escalation.EscalateAfter)
+	remindAfter := durationOf(escalation.RemindAfter)
+	if escalateAfter > 0 && plan.EscalatedAt == nil && !now.Before(pendingSince.Add(escalateAfter)) {
+		msg := fmt.Sprintf("Plan %s has been pending approval for %s", planID, pendingFor)
+		r.eventWithReason(ctx, *terraform, revision, eventv1.EventSeverityError, infrav1.PlanApprovalEscalatedReason, msg, nil)
+		if r.PlanNotifier != nil {
+			if err := r.PlanNotifier.RemindPlanPending(ctx, *terraform, planID, pendingFor, escalation.EscalationChannel); err != nil {
+				log.Error(err, "unable to escalate the pending plan")
+			}
+		}
+		escalatedAt := metav1.NewTime(now)
+		plan.EscalatedAt = &escalatedAt
+		plan.RemindedAt = &escalatedAt
+		notified = true
+	} else if remindAfter > 0 && !now.Before(lastReminder(*plan).Add(remindAfter)) {
+		msg := fmt.Sprintf("Plan %s has been pending approval for %s", planID, pendingFor)
+		r.eventWithReason(ctx, *terraform, revision, eventv1.EventSeverityInfo, infrav1.PlanApprovalReminderReason, msg, nil)
+		if r.PlanNotifier != nil {
+			if err := r.PlanNotifier.RemindPlanPending(ctx, *terraform, planID, pendingFor, ""); err != nil {
+				log.Error(err, "unable to remind of the pending plan")
+			}
+		}
+		remindedAt := metav1.NewTime(now)
+		plan.RemindedAt = &remindedAt
+		notified = true
+	}
+	if notified {
+		if err := r.patchStatus(ctx, objectKey, terraform.Status); err != nil {
+			return false, ctrl.Result{}, err
+		}
+	}
+
+	// requeue when the next reminder, the escalation or the expiry is due
+	var next time.Time
+	due := func(at time.Time) {
+		if at.After(now) && (next.IsZero() || at.Before(next)) {
+			next = at
+		}
+	}
+	if remindAfter > 0 {
+		due(lastReminder(*plan).Add(remindAfter))
+	}
+	if escalateAfter > 0 && plan.EscalatedAt == nil {
+		due(pendingSince.Add(escalateAfter))
+	}
+	if expiry := durationOf(escalation.PlanExpiry); expiry > 0 {
+		due(pendingSince.Add(expiry))
+	}
+	if next.IsZero() {
+		return false, ctrl.Result{}, nil
+	}
+	return false, ctrl.Result{RequeueAfter: next.Sub(now)}, nil
+}
+
+// lastReminder returns the time of the last reminder of the pending plan, or
+// the time it was planned when it has not been reminded of yet.
+func lastReminder(plan infrav1.PlanStatus) time.Time {
+	if plan.RemindedAt != nil {
+		return plan.RemindedAt.Time
+	}
+	return plan.PendingSince.Time
+}
+
+func durationOf(d *metav1.Duration) time.Duration {
+	if d == nil {
+		return 0
+	}
+	return d.Duration
+}
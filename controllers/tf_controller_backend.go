@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
@@ -29,39 +30,85 @@ func (r *TerraformReconciler) setupTerraform(ctx context.Context, runnerClient r
 	tfInstance := "0"
 	tmpDir := ""
 
+	if err := r.checkBackendAccess(ctx, terraform); err != nil {
+		return infrav1.TerraformNotReady(
+			terraform,
+			revision,
+			infrav1.BackendAccessDeniedReason,
+			err.Error(),
+		), tfInstance, tmpDir, err
+	}
+
 	terraform = infrav1.TerraformProgressing(terraform, "Initializing")
 	if err := r.patchStatus(ctx, objectKey, terraform.Status); err != nil {
 		log.Error(err, "unable to update status before Terraform initialization")
 		return terraform, tfInstance, tmpDir, err
 	}
 
-	// download artifact and extract files
-	buf, err := r.downloadAsBytes(sourceObj.GetArtifact())
-	if err != nil {
-		return infrav1.TerraformNotReady(
-			terraform,
-			revision,
-			infrav1.ArtifactFailedReason,
-			err.Error(),
-		), tfInstance, tmpDir, err
+	// the documentation of the root module is read from the downloaded
+	// artifact, once per revision
+	if terraform.Spec.RegistryModule != nil {
+		terraform.Status.Module = nil
+	}
+	documented := terraform.Spec.RegistryModule != nil ||
+		(terraform.Status.Module != nil && terraform.Status.Module.Revision == revision)
+
+	// the runner extracts the artifact from the source cache of its node
+	// when it has it, and it is not downloaded
+	digest := r.sourceCacheDigest(sourceObj)
+	var uploadAndExtractReply *runner.UploadAndExtractReply
+	if digest != "" && documented {
+		reply, err := r.extractCachedSource(ctx, runnerClient, terraform, digest)
+		if err != nil {
+			return infrav1.TerraformNotReady(
+				terraform,
+				revision,
+				infrav1.ArtifactFailedReason,
+				err.Error(),
+			), tfInstance, tmpDir, err
+		}
+		uploadAndExtractReply = reply
 	}
 
-	// we fix timeout of UploadAndExtract to be 30s
-	// ctx30s, cancelCtx30s := context.WithTimeout(ctx, 30*time.Second)
-	// defer cancelCtx30s()
-	uploadAndExtractReply, err := runnerClient.UploadAndExtract(ctx, &runner.UploadAndExtractRequest{
-		Namespace: terraform.Namespace,
-		Name:      terraform.Name,
-		TarGz:     buf.Bytes(),
-		Path:      terraform.Spec.Path,
-	})
-	if err != nil {
-		return infrav1.TerraformNotReady(
-			terraform,
-			revision,
-			infrav1.ArtifactFailedReason,
-			err.Error(),
-		), tfInstance, tmpDir, err
+	if uploadAndExtractReply == nil {
+		// download artifact and extract files
+		buf, err := r.downloadSource(sourceObj)
+		if err != nil {
+			return infrav1.TerraformNotReady(
+				terraform,
+				revision,
+				infrav1.ArtifactFailedReason,
+				err.Error(),
+			), tfInstance, tmpDir, err
+		}
+
+		// document the root module for the UIs, once per revision
+		if !documented {
+			if module, err := readModule(buf.Bytes(), terraform.Spec.Path, revision); err != nil {
+				log.Error(err, "unable to read the documentation of the module")
+			} else {
+				terraform.Status.Module = module
+			}
+		}
+
+		// we fix timeout of UploadAndExtract to be 30s
+		// ctx30s, cancelCtx30s := context.WithTimeout(ctx, 30*time.Second)
+		// defer cancelCtx30s()
+		uploadAndExtractReply, err = runnerClient.UploadAndExtract(ctx, &runner.UploadAndExtractRequest{
+			Namespace: terraform.Namespace,
+			Name:      terraform.Name,
+			TarGz:     buf.Bytes(),
+			Path:      terraform.Spec.Path,
+			Digest:    digest,
+		})
+		if err != nil {
+			return infrav1.TerraformNotReady(
+				terraform,
+				revision,
+				infrav1.ArtifactFailedReason,
+				err.Error(),
+			), tfInstance, tmpDir, err
+		}
 	}
 	workingDir := uploadAndExtractReply.WorkingDir
 	tmpDir = uploadAndExtractReply.TmpDir
@@ -91,9 +138,9 @@ terraform {
 }
 `,
 			terraform.Spec.BackendConfig.SecretSuffix,
-			terraform.Spec.BackendConfig.InClusterConfig,
-			terraform.Spec.BackendConfig.ConfigPath,
-			terraform.Namespace,
+			terraform.Spec.BackendConfig.InClusterConfig && terraform.Spec.BackendConfig.KubeConfig == nil,
+			backendConfigPath(terraform),
+			stateSecretNamespace(terraform),
 			getLabelsAsHCL(terraform.Labels, 6))
 	} else if DisableTFK8SBackend && terraform.Spec.BackendConfig == nil {
 		backendConfig = `
@@ -147,6 +194,24 @@ terraform {
 		log.Info(fmt.Sprintf("write backend config: %s", writeBackendConfigReply.Message))
 	}
 
+	if terraform.IsSimulated() {
+		writeSimulationConfigReply, err := runnerClient.WriteSimulationConfig(ctx,
+			&runner.WriteSimulationConfigRequest{
+				DirPath:          workingDir,
+				SimulationConfig: []byte(terraform.Spec.Simulation.ToHCL()),
+			})
+		if err != nil {
+			err = fmt.Errorf("cannot write the simulation config: %s", err.Error())
+			return infrav1.TerraformNotReady(
+				terraform,
+				revision,
+				infrav1.TFExecNewFailedReason,
+				err.Error(),
+			), tfInstance, tmpDir, err
+		}
+		log.Info(fmt.Sprintf("write simulation config: %s", writeSimulationConfigReply.Message))
+	}
+
 	var tfrcFilepath string
 	if terraform.Spec.CliConfigSecretRef != nil {
 		cliConfigSecretRef := *(terraform.Spec.CliConfigSecretRef.DeepCopy())
@@ -171,6 +236,26 @@ terraform {
 		tfrcFilepath = processCliConfigReply.FilePath
 	}
 
+	var gitEnvs map[string]string
+	if terraform.Spec.GitCredentials != nil {
+		processGitCredentialsReply, err := runnerClient.ProcessGitCredentials(ctx, &runner.ProcessGitCredentialsRequest{
+			DirPath:   tmpDir,
+			Namespace: terraform.Namespace,
+			Name:      terraform.Spec.GitCredentials.SecretRef.Name,
+			Hosts:     terraform.Spec.GitCredentials.Hosts,
+		})
+		if err != nil {
+			err = fmt.Errorf("cannot process git credentials: %s", err.Error())
+			return infrav1.TerraformNotReady(
+				terraform,
+				revision,
+				infrav1.TFExecNewFailedReason,
+				err.Error(),
+			), tfInstance, tmpDir, err
+		}
+		gitEnvs = processGitCredentialsReply.Envs
+	}
+
 	lookPathReply, err := runnerClient.LookPath(ctx,
 		&runner.LookPathRequest{
 			File: "terraform",
@@ -257,6 +342,23 @@ terraform {
 		envs["TF_CLI_CONFIG_FILE"] = tfrcFilepath
 	}
 
+	for k, v := range gitEnvs {
+		envs[k] = v
+	}
+
+	registryEnvs, err := r.registryCredentialsEnvs(ctx, terraform)
+	if err != nil {
+		return infrav1.TerraformNotReady(
+			terraform,
+			revision,
+			infrav1.TFExecInitFailedReason,
+			err.Error(),
+		), tfInstance, tmpDir, err
+	}
+	for k, v := range registryEnvs {
+		envs[k] = v
+	}
+
 	// SetEnv returns a nil for the first return values if there is an error, so
 	// let's ignore that as it's not used elsewhere.
 	if _, err := runnerClient.SetEnv(ctx,
@@ -301,8 +403,17 @@ terraform {
 		}
 	}
 
+	if terraform.Spec.RegistryModule != nil {
+		terraform, err = r.fetchRegistryModule(ctx, terraform, tfInstance, runnerClient, revision)
+		if err != nil {
+			return terraform, tfInstance, tmpDir, err
+		}
+		log.Info("fetched registry module", "source", terraform.Spec.RegistryModule.Source, "version", terraform.Status.RegistryModuleVersion)
+	}
+
 	generateVarsForTFReply, err := runnerClient.GenerateVarsForTF(ctx, &runner.GenerateVarsForTFRequest{
 		WorkingDir: workingDir,
+		ExecVars:   hasExecVars(terraform),
 	})
 	if err != nil {
 		// transient error?
@@ -345,6 +456,21 @@ terraform {
 		initRequest.ForceCopy = false
 	}
 
+	// the providers are pinned between the upgrades of spec.providerUpgrade
+	pinnedLock, err := r.pinnedProviderLock(ctx, terraform, time.Now())
+	if err != nil {
+		return infrav1.TerraformNotReady(
+			terraform,
+			revision,
+			infrav1.TFExecInitFailedReason,
+			err.Error(),
+		), tfInstance, tmpDir, err
+	}
+	if pinnedLock != "" {
+		initRequest.Upgrade = false
+		initRequest.DependencyLock = pinnedLock
+	}
+
 	initReply, err := runnerClient.Init(ctx, initRequest)
 	if err != nil {
 		if st, ok := status.FromError(err); ok {
@@ -355,7 +481,7 @@ terraform {
 			}
 		}
 
-		err = fmt.Errorf("error running Init: %s", err)
+		err = withStateLock(err, fmt.Errorf("error running Init: %s", err))
 
 		return infrav1.TerraformNotReady(
 			terraform,
@@ -366,6 +492,18 @@ terraform {
 	}
 	log.Info(fmt.Sprintf("init reply: %s", initReply.Message))
 
+	if terraform.Spec.ProviderUpgrade != nil && initReply.Upgraded {
+		terraform, err = r.recordProviderUpgrade(ctx, terraform, revision, initReply.DependencyLock, time.Now())
+		if err != nil {
+			return infrav1.TerraformNotReady(
+				terraform,
+				revision,
+				infrav1.TFExecInitFailedReason,
+				err.Error(),
+			), tfInstance, tmpDir, err
+		}
+	}
+
 	log.Info("tfexec initialized terraform")
 
 	workspaceRequest := &runner.WorkspaceRequest{
@@ -429,3 +567,14 @@ func getLabelsAsHCL(labels map[string]string, indent int) string {
 
 	return strings.TrimSpace(result)
 }
+
+// hasExecVars tells whether the Terraform object sets variables from the
+// output of a command, which the older runners do not support.
+func hasExecVars(terraform infrav1.Terraform) bool {
+	for _, vf := range terraform.Spec.VarsFrom {
+		if vf.Kind == "Exec" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	"github.com/fluxcd/pkg/runtime/acl"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	backendKubeConfigVolumeName = "backend-kubeconfig"
+	backendKubeConfigMountPath  = "/etc/tf-runner/backend"
+	backendKubeConfigPath       = backendKubeConfigMountPath + "/kubeconfig"
+)
+
+// stateSecretNamespace returns the namespace of the Secret of the state,
+// with the Kubernetes backend.
+func stateSecretNamespace(terraform infrav1.Terraform) string {
+	if backendConfig := terraform.Spec.BackendConfig; backendConfig != nil && backendConfig.SecretNamespace != "" {
+		return backendConfig.SecretNamespace
+	}
+	return terraform.Namespace
+}
+
+// backendConfigPath returns the path of the kubeconfig of the Kubernetes
+// backend, which is mounted in the runner pod when a kubeconfig Secret is
+// referenced.
+func backendConfigPath(terraform infrav1.Terraform) string {
+	if terraform.Spec.BackendConfig.KubeConfig != nil {
+		return backendKubeConfigPath
+	}
+	return terraform.Spec.BackendConfig.ConfigPath
+}
+
+// backendKubeConfigVolume projects the kubeconfig key of the referenced
+// Secret to a single file.
+func backendKubeConfigVolume(kubeConfig meta.SecretKeyReference) v1.Volume {
+	key := kubeConfig.Key
+	if key == "" {
+		key = "value"
+	}
+	return v1.Volume{
+		Name: backendKubeConfigVolumeName,
+		VolumeSource: v1.VolumeSource{
+			Secret: &v1.SecretVolumeSource{
+				SecretName: kubeConfig.Name,
+				Items:      []v1.KeyToPath{{Key: key, Path: "kubeconfig"}},
+			},
+		},
+	}
+}
+
+// checkBackendAccess checks that the runner service account of the
+// Terraform object may write its state, when the Kubernetes backend keeps it
+// in another namespace of the cluster. The access to another cluster is up to
+// the credentials of its kubeconfig.
+func (r *TerraformReconciler) checkBackendAccess(ctx context.Context, terraform infrav1.Terraform) error {
+	backendConfig := terraform.Spec.BackendConfig
+	if backendConfig == nil || backendConfig.CustomConfiguration != "" || backendConfig.KubeConfig != nil {
+		return nil
+	}
+
+	namespace := stateSecretNamespace(terraform)
+	if namespace == terraform.Namespace {
+		return nil
+	}
+	if !r.crossNamespaceRefAllowed(terraform.Namespace, namespace) {
+		return acl.AccessDeniedError(
+			fmt.Sprintf("cannot keep the state in namespace %s, cross-namespace references have been disabled", namespace),
+		)
+	}
+
+	serviceAccountName := terraform.Spec.ServiceAccountName
+	if serviceAccountName == "" {
+		serviceAccountName = "tf-runner"
+	}
+	username := fmt.Sprintf("system:serviceaccount:%s:%s", terraform.Namespace, serviceAccountName)
+
+	// the Kubernetes backend keeps the state in a Secret, and locks it with a Lease
+	for _, resource := range []authorizationv1.ResourceAttributes{
+		{Namespace: namespace, Resource: "secrets", Verb: "create"},
+		{Namespace: namespace, Resource: "secrets", Verb: "update"},
+		{Namespace: namespace, Group: "coordination.k8s.io", Resource: "leases", Verb: "create"},
+	} {
+		resource := resource
+		review := &authorizationv1.SubjectAccessReview{
+			Spec: authorizationv1.SubjectAccessReviewSpec{
+				User:               username,
+				Groups:             []string{"system:serviceaccounts", "system:serviceaccounts:" + terraform.Namespace},
+				ResourceAttributes: &resource,
+			},
+		}
+		if err := r.Client.Create(ctx, review); err != nil {
+			return fmt.Errorf("unable to check the access to the state in namespace %s: %w", namespace, err)
+		}
+		if !review.Status.Allowed {
+			return acl.AccessDeniedError(
+				fmt.Sprintf("service account %s cannot %s %s in namespace %s to keep the state",
+					username, resource.Verb, resource.Resource, namespace),
+			)
+		}
+	}
+	return nil
+}
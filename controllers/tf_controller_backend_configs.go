@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// backendConfigKeyPattern matches the names of the attributes of a backend
+// configuration, which are the only keys terraform init accepts in
+// -backend-config=key=value.
+var backendConfigKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// backendConfigError is returned when spec.backendConfigsFrom cannot produce
+// a valid backend configuration. It won't heal by retrying the run, only by
+// fixing the Terraform object or the objects it references.
+type backendConfigError struct {
+	message string
+}
+
+func (e *backendConfigError) Error() string {
+	return e.message
+}
+
+func invalidBackendConfig(format string, args ...interface{}) error {
+	return &backendConfigError{message: fmt.Sprintf(format, args...)}
+}
+
+// validateBackendConfigs checks the sources of spec.backendConfigsFrom the
+// same way the runner maps them to terraform init, so that a missing key
+// fails the reconciliation right away instead of during the init of a run.
+func (r *TerraformReconciler) validateBackendConfigs(ctx context.Context, terraform infrav1.Terraform) error {
+	if len(terraform.Spec.BackendConfigsFrom) == 0 {
+		return nil
+	}
+	if terraform.Spec.Cloud != nil {
+		return invalidBackendConfig("spec.backendConfigsFrom cannot be used with spec.cloud, Terraform Cloud does not accept -backend-config")
+	}
+
+	for i, ref := range terraform.Spec.BackendConfigsFrom {
+		field := fmt.Sprintf("spec.backendConfigsFrom[%d]", i)
+		objectKey := types.NamespacedName{Namespace: terraform.Namespace, Name: ref.Name}
+
+		var obj client.Object
+		switch ref.Kind {
+		case "Secret":
+			obj = &corev1.Secret{}
+		case "ConfigMap":
+			obj = &corev1.ConfigMap{}
+		default:
+			return invalidBackendConfig("%s: unsupported kind %q, expected Secret or ConfigMap", field, ref.Kind)
+		}
+
+		for _, key := range ref.Keys {
+			if !backendConfigKeyPattern.MatchString(key) {
+				return invalidBackendConfig("%s: key %q is not a valid backend configuration attribute name", field, key)
+			}
+		}
+
+		if err := r.Client.Get(ctx, objectKey, obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				if ref.Optional {
+					continue
+				}
+				return invalidBackendConfig("%s: %s %s not found", field, ref.Kind, objectKey)
+			}
+			return fmt.Errorf("unable to get %s %s referenced by %s: %w", ref.Kind, objectKey, field, err)
+		}
+
+		keys := backendConfigDataKeys(obj)
+		if len(ref.Keys) == 0 {
+			if len(keys) == 0 && !ref.Optional {
+				return invalidBackendConfig("%s: %s %s has no data", field, ref.Kind, objectKey)
+			}
+			for _, key := range sortedKeys(keys) {
+				if !backendConfigKeyPattern.MatchString(key) {
+					return invalidBackendConfig("%s: key %q of %s %s is not a valid backend configuration attribute name", field, key, ref.Kind, objectKey)
+				}
+			}
+			continue
+		}
+
+		for _, key := range ref.Keys {
+			if !keys[key] {
+				return invalidBackendConfig("%s: key %q not found in %s %s", field, key, ref.Kind, objectKey)
+			}
+		}
+	}
+
+	return nil
+}
+
+// backendConfigDataKeys returns the keys of a Secret or of a ConfigMap the
+// runner reads the backend configuration from.
+func backendConfigDataKeys(obj client.Object) map[string]bool {
+	keys := map[string]bool{}
+	switch o := obj.(type) {
+	case *corev1.Secret:
+		for key := range o.Data {
+			keys[key] = true
+		}
+	case *corev1.ConfigMap:
+		for key := range o.Data {
+			keys[key] = true
+		}
+		for key := range o.BinaryData {
+			keys[key] = true
+		}
+	}
+	return keys
+}
+
+func sortedKeys(keys map[string]bool) []string {
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
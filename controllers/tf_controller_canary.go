@@ -0,0 +1,204 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	eventv1 "github.com/fluxcd/pkg/apis/event/v1beta1"
+	tfjson "github.com/hashicorp/terraform-json"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/runner"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// canaryPlanName is the plan file of the canary targets, next to the
+// approved plan.
+const canaryPlanName = "tfplan-canary"
+
+// shouldApplyCanary tells whether the plan is applied in two stages. The
+// destroy plans, and the objects without a backend, which have no saved
+// plan, are applied at once.
+func (r *TerraformReconciler) shouldApplyCanary(terraform infrav1.Terraform) bool {
+	return terraform.Spec.CanaryApply != nil &&
+		!terraform.Spec.Destroy &&
+		!terraform.Status.Plan.IsDestroyPlan &&
+		!r.backendCompletelyDisable(terraform)
+}
+
+// applyCanary applies the canary targets of the plan, runs the health checks
+// of the canary, and plans the remainder into the approved plan file, for
+// applyRequest to apply it. It returns an error, with the object not ready,
+// when the remainder of the plan must not be applied.
+//
+// The saved plan cannot be applied in part, so the canary targets, and then
+// the remainder, are planned again into plan files, which are applied rather
+// than the configuration. Unless the plan is approved automatically, each
+// stage must only make the changes of the approved plan: otherwise the plan
+// is discarded, to be planned and approved again.
+func (r *TerraformReconciler) applyCanary(ctx context.Context, terraform infrav1.Terraform, runnerClient runner.RunnerClient, revision string, applyRequest *runner.ApplyRequest) (infrav1.Terraform, error) {
+	log := ctrl.LoggerFrom(ctx)
+	canary := terraform.Spec.CanaryApply
+	targets := strings.Join(canary.Targets, ", ")
+
+	approved, err := showPlanChanges(ctx, runnerClient, applyRequest.TfInstance, runner.TFPlanName)
+	if err != nil {
+		return r.canaryFailed(ctx, terraform, revision, "unable to read the approved plan", err)
+	}
+
+	canaryChanges, err := planChangesOf(ctx, runnerClient, applyRequest.TfInstance, canaryPlanName, canary.Targets)
+	if err != nil {
+		return r.canaryFailed(ctx, terraform, revision, "error planning the canary", err)
+	}
+	expected := map[string]string{}
+	for address := range canaryChanges {
+		expected[address] = approved[address]
+	}
+	if differing := differingChanges(expected, canaryChanges); len(differing) > 0 && !r.forceOrAutoApply(terraform) {
+		return r.unapprovedCanaryChanges(ctx, terraform, revision, "the canary", differing)
+	}
+
+	_, err = runnerClient.Apply(ctx, &runner.ApplyRequest{
+		TfInstance:      applyRequest.TfInstance,
+		DirOrPlan:       canaryPlanName,
+		Parallelism:     applyRequest.Parallelism,
+		ExpectedChanges: int32(len(canaryChanges)),
+	})
+	if err != nil {
+		return r.canaryFailed(ctx, terraform, revision, "error running the canary Apply", err)
+	}
+	log.Info("canary applied", "targets", targets)
+
+	if healthChecks := canary.GetHealthChecks(terraform); len(healthChecks) > 0 {
+		canaryTerraform := terraform.DeepCopy()
+		canaryTerraform.Spec.HealthChecks = healthChecks
+		if _, err := r.doHealthChecks(ctx, *canaryTerraform, revision, runnerClient); err != nil {
+			msg := fmt.Sprintf("Canary health checks failed, the remainder of the plan is not applied: %s", err.Error())
+			r.eventWithReason(ctx, terraform, revision, eventv1.EventSeverityError, infrav1.CanaryFailedReason, msg, nil)
+			return infrav1.TerraformAppliedFailResetPlanAndNotReady(
+				terraform,
+				revision,
+				infrav1.CanaryFailedReason,
+				msg,
+			), fmt.Errorf("canary health checks failed: %w", err)
+		}
+	}
+
+	r.event(ctx, terraform, revision, eventv1.EventSeverityInfo, fmt.Sprintf("Canary applied successfully: %s", targets), nil)
+
+	remainder, err := planChangesOf(ctx, runnerClient, applyRequest.TfInstance, runner.TFPlanName, terraform.Spec.Targets)
+	if err != nil {
+		return r.canaryFailed(ctx, terraform, revision, "error planning the remainder of the plan", err)
+	}
+	expected = map[string]string{}
+	for address, actions := range approved {
+		if _, ok := canaryChanges[address]; !ok {
+			expected[address] = actions
+		}
+	}
+	if differing := differingChanges(expected, remainder); len(differing) > 0 && !r.forceOrAutoApply(terraform) {
+		return r.unapprovedCanaryChanges(ctx, terraform, revision, "the remainder of the plan", differing)
+	}
+
+	// the remainder is planned with the targets and the refresh of the
+	// spec, which cannot be set again when applying the plan file
+	applyRequest.DirOrPlan = runner.TFPlanName
+	applyRequest.Targets = nil
+	applyRequest.RefreshBeforeApply = false
+	applyRequest.ExpectedChanges = int32(len(remainder))
+	return terraform, nil
+}
+
+// canaryFailed halts the apply: the plan is discarded, and the object is not
+// ready with the CanaryFailed reason.
+func (r *TerraformReconciler) canaryFailed(ctx context.Context, terraform infrav1.Terraform, revision, action string, runnerErr error) (infrav1.Terraform, error) {
+	msg := fmt.Sprintf("Canary apply error, the remainder of the plan is not applied: %s", runnerErr.Error())
+	r.eventWithReason(ctx, terraform, revision, eventv1.EventSeverityError, infrav1.CanaryFailedReason, msg, nil)
+	err := withStateLock(runnerErr, fmt.Errorf("%s: %s", action, runnerErr))
+	return infrav1.TerraformAppliedFailResetPlanAndNotReady(
+		terraform,
+		revision,
+		infrav1.CanaryFailedReason,
+		err.Error(),
+	), err
+}
+
+// unapprovedCanaryChanges discards the plan when a stage of the canary apply
+// would make changes which were not approved, so that it is planned and
+// approved again.
+func (r *TerraformReconciler) unapprovedCanaryChanges(ctx context.Context, terraform infrav1.Terraform, revision, stage string, differing []string) (infrav1.Terraform, error) {
+	msg := fmt.Sprintf("plan %s is stale: %s changes resources differently than the approved plan (%s), planning again",
+		terraform.Status.Plan.Pending, stage, strings.Join(differing, ", "))
+	r.eventWithReason(ctx, terraform, revision, eventv1.EventSeverityError, infrav1.StalePlanReason, msg, nil)
+	return infrav1.TerraformStalePlan(terraform, revision, msg, true), errors.New(msg)
+}
+
+// planChangesOf plans the targets into the plan file, and returns its
+// changes.
+func planChangesOf(ctx context.Context, runnerClient runner.RunnerClient, tfInstance, planName string, targets []string) (map[string]string, error) {
+	if _, err := runnerClient.Plan(ctx, &runner.PlanRequest{
+		TfInstance: tfInstance,
+		Out:        planName,
+		Refresh:    true,
+		Targets:    targets,
+	}); err != nil {
+		return nil, err
+	}
+	return showPlanChanges(ctx, runnerClient, tfInstance, planName)
+}
+
+// showPlanChanges returns the actions of the plan file by the address of
+// the resources it changes. The reads of the data sources, which are not
+// changes, are left out.
+func showPlanChanges(ctx context.Context, runnerClient runner.RunnerClient, tfInstance, planName string) (map[string]string, error) {
+	reply, err := runnerClient.ShowPlanFile(ctx, &runner.ShowPlanFileRequest{
+		TfInstance: tfInstance,
+		Filename:   planName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var plan tfjson.Plan
+	if err := json.Unmarshal(reply.JsonOutput, &plan); err != nil {
+		return nil, fmt.Errorf("unable to decode the plan %s: %w", planName, err)
+	}
+
+	changes := map[string]string{}
+	for _, rc := range plan.ResourceChanges {
+		if rc.Change == nil || rc.Change.Actions.NoOp() || rc.Change.Actions.Read() {
+			continue
+		}
+		address := rc.Address
+		if rc.DeposedKey != "" {
+			address += " (deposed " + rc.DeposedKey + ")"
+		}
+		actions := make([]string, 0, len(rc.Change.Actions))
+		for _, action := range rc.Change.Actions {
+			actions = append(actions, string(action))
+		}
+		changes[address] = strings.Join(actions, ",")
+	}
+	return changes, nil
+}
+
+// differingChanges returns the sorted addresses of the resources which the
+// plans change differently.
+func differingChanges(expected, actual map[string]string) []string {
+	var differing []string
+	for address, actions := range actual {
+		if expected[address] != actions {
+			differing = append(differing, address)
+		}
+	}
+	for address := range expected {
+		if _, ok := actual[address]; !ok {
+			differing = append(differing, address)
+		}
+	}
+	sort.Strings(differing)
+	return differing
+}
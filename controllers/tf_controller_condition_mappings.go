@@ -0,0 +1,150 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	"github.com/google/cel-go/cel"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// controllerConditionTypes are the types of the conditions set by the
+// controller, which the condition mappings cannot override.
+var controllerConditionTypes = map[string]bool{
+	meta.ReadyCondition:              true,
+	meta.ReconcilingCondition:        true,
+	meta.StalledCondition:            true,
+	infrav1.ConditionTypeApply:       true,
+	infrav1.ConditionTypeConflict:    true,
+	infrav1.ConditionTypeCredentials: true,
+	infrav1.ConditionTypeHealthCheck: true,
+	infrav1.ConditionTypeOutput:      true,
+	infrav1.ConditionTypePlan:        true,
+	infrav1.ConditionTypeRunner:      true,
+	infrav1.ConditionTypeStateLocked: true,
+}
+
+// setMappedConditions sets the conditions mapped by the
+// TerraformNamespacePolicies of the namespace of the Terraform object from
+// its state. A mapping failing to evaluate sets its condition to Unknown,
+// rather than failing the reconciliation.
+func (r *TerraformReconciler) setMappedConditions(ctx context.Context, terraform *infrav1.Terraform) {
+	log := ctrl.LoggerFrom(ctx)
+
+	var policies infrav1.TerraformNamespacePolicyList
+	if err := r.APIReader.List(ctx, &policies, client.InNamespace(terraform.Namespace)); err != nil {
+		if !apimeta.IsNoMatchError(err) {
+			log.Error(err, "unable to list the namespace policies, the mapped conditions are not updated")
+		}
+		return
+	}
+
+	var mappings []infrav1.ConditionMapping
+	for _, policy := range policies.Items {
+		mappings = append(mappings, policy.Spec.ConditionMappings...)
+	}
+	if len(mappings) == 0 {
+		return
+	}
+
+	for _, condition := range mapConditions(terraform, mappings, time.Now()) {
+		apimeta.SetStatusCondition(&terraform.Status.Conditions, condition)
+	}
+}
+
+// mapConditions evaluates the condition mappings over the Terraform object,
+// and returns their conditions. The mappings of the types of the conditions
+// set by the controller are ignored.
+func mapConditions(terraform *infrav1.Terraform, mappings []infrav1.ConditionMapping, now time.Time) []metav1.Condition {
+	var conditions []metav1.Condition
+	for _, mapping := range mappings {
+		if controllerConditionTypes[mapping.Type] {
+			continue
+		}
+
+		condition := metav1.Condition{
+			Type:               mapping.Type,
+			Status:             metav1.ConditionFalse,
+			Reason:             infrav1.ConditionNotMetReason,
+			ObservedGeneration: terraform.Generation,
+		}
+		met, err := evaluateConditionMapping(mapping.When, terraform, now)
+		switch {
+		case err != nil:
+			condition.Status = metav1.ConditionUnknown
+			condition.Reason = infrav1.ConditionMappingFailedReason
+			condition.Message = err.Error()
+		case met:
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = mapping.Reason
+			if condition.Reason == "" {
+				condition.Reason = infrav1.ConditionMetReason
+			}
+			condition.Message = mapping.Message
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions
+}
+
+// evaluateConditionMapping evaluates the expression of a condition mapping
+// over the Terraform object, its conditions and the current time.
+func evaluateConditionMapping(expression string, terraform *infrav1.Terraform, now time.Time) (bool, error) {
+	env, err := cel.NewEnv(
+		cel.Variable("object", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("conditions", cel.MapType(cel.StringType, cel.MapType(cel.StringType, cel.StringType))),
+		cel.Variable("now", cel.TimestampType),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return false, fmt.Errorf("invalid condition expression: %w", issues.Err())
+	}
+	if ast.OutputType() != cel.BoolType {
+		return false, fmt.Errorf("the condition expression must return a bool, not %s", ast.OutputType())
+	}
+
+	program, err := env.Program(ast)
+	if err != nil {
+		return false, fmt.Errorf("invalid condition expression: %w", err)
+	}
+
+	object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(terraform)
+	if err != nil {
+		return false, fmt.Errorf("unable to convert the object: %w", err)
+	}
+
+	conditionsByType := map[string]map[string]string{}
+	for _, condition := range terraform.Status.Conditions {
+		conditionsByType[condition.Type] = map[string]string{
+			"type":    condition.Type,
+			"status":  string(condition.Status),
+			"reason":  condition.Reason,
+			"message": condition.Message,
+		}
+	}
+
+	result, _, err := program.Eval(map[string]interface{}{
+		"object":     object,
+		"conditions": conditionsByType,
+		"now":        now,
+	})
+	if err != nil {
+		return false, fmt.Errorf("unable to evaluate the condition expression: %w", err)
+	}
+	met, ok := result.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("the condition expression must return a bool, not %v", result.Type())
+	}
+	return met, nil
+}
@@ -0,0 +1,283 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	"github.com/fluxcd/pkg/runtime/predicates"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/mtls"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// ControllerConfig is the TerraformControllerConfig in use, shared by the
+// reconciler of the config, which loads it, and the Terraform reconciler,
+// which reads it. Until a valid config is loaded, the flags apply.
+type ControllerConfig struct {
+	mu   sync.Mutex
+	spec *infrav1.TerraformControllerConfigSpec
+
+	// active is the number of reconciles in progress, limited by the
+	// concurrency of the config, and changed is closed to wake up the
+	// reconciles waiting for a slot when it may have been freed.
+	active  int
+	changed chan struct{}
+}
+
+// NewControllerConfig returns a ControllerConfig with no config loaded.
+func NewControllerConfig() *ControllerConfig {
+	return &ControllerConfig{changed: make(chan struct{})}
+}
+
+// Spec returns the spec of the config in use, or nil when none is loaded.
+func (c *ControllerConfig) Spec() *infrav1.TerraformControllerConfigSpec {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.spec
+}
+
+// Load uses the spec from now on, or the flags when it is nil.
+func (c *ControllerConfig) Load(spec *infrav1.TerraformControllerConfigSpec) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.spec = spec
+	c.broadcast()
+}
+
+// Acquire waits for the concurrency of the config to allow one more
+// reconcile, and returns the function releasing its slot. The workers above
+// the concurrency wait here, for a lowered concurrency to apply at once.
+func (c *ControllerConfig) Acquire(ctx context.Context) (func(), error) {
+	for {
+		c.mu.Lock()
+		if c.spec == nil || c.spec.Concurrency == nil || c.active < int(*c.spec.Concurrency) {
+			c.active++
+			c.mu.Unlock()
+			return c.release, nil
+		}
+		changed := c.changed
+		c.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+func (c *ControllerConfig) release() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.active--
+	c.broadcast()
+}
+
+// broadcast wakes up the reconciles waiting for a slot. c.mu must be held.
+func (c *ControllerConfig) broadcast() {
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+// featureGate returns whether the feature is on, from the feature gates of
+// the config, or from its flag when the config does not set it.
+func (r *TerraformReconciler) featureGate(name string, flag bool) bool {
+	if spec := r.Config.Spec(); spec != nil {
+		if enabled, ok := spec.FeatureGates[name]; ok {
+			return enabled
+		}
+	}
+	return flag
+}
+
+// runnerRuntimeClassName returns the default RuntimeClass of the runner pods.
+func (r *TerraformReconciler) runnerRuntimeClassName() string {
+	if spec := r.Config.Spec(); spec != nil && spec.Defaults != nil && spec.Defaults.RunnerRuntimeClassName != "" {
+		return spec.Defaults.RunnerRuntimeClassName
+	}
+	return r.RunnerRuntimeClassName
+}
+
+// runnerCreationTimeout returns the time to wait for a runner pod to be
+// ready.
+func (r *TerraformReconciler) runnerCreationTimeout() time.Duration {
+	if spec := r.Config.Spec(); spec != nil && spec.Defaults != nil && spec.Defaults.RunnerCreationTimeout != nil {
+		return spec.Defaults.RunnerCreationTimeout.Duration
+	}
+	return r.RunnerCreationTimeout
+}
+
+// crossNamespaceRefAllowed returns whether the objects of a namespace may
+// refer to the objects of another one: always, unless the cross-namespace
+// references are disabled, and then only for the pairs the config allows.
+func (r *TerraformReconciler) crossNamespaceRefAllowed(from, to string) bool {
+	if !r.NoCrossNamespaceRefs || from == to {
+		return true
+	}
+	spec := r.Config.Spec()
+	if spec == nil {
+		return false
+	}
+	for _, rule := range spec.AllowedCrossNamespaceRefs {
+		fromMatched, _ := path.Match(rule.From, from)
+		toMatched, _ := path.Match(rule.To, to)
+		if fromMatched && toMatched {
+			return true
+		}
+	}
+	return false
+}
+
+// TerraformControllerConfigReconciler reconciles the
+// TerraformControllerConfig of the controller, by validating it and loading
+// it in the ControllerConfig when it is valid.
+type TerraformControllerConfigReconciler struct {
+	client.Client
+
+	Scheme *runtime.Scheme
+	// Name is the name of the TerraformControllerConfig of the controller.
+	Name   string
+	Config *ControllerConfig
+	// CertRotator rotates the CA of the runners on the requests of the
+	// config.
+	CertRotator *mtls.CertRotator
+}
+
+//+kubebuilder:rbac:groups=infra.contrib.fluxcd.io,resources=terraformcontrollerconfigs,verbs=get;list;watch
+//+kubebuilder:rbac:groups=infra.contrib.fluxcd.io,resources=terraformcontrollerconfigs/status,verbs=get;update;patch
+
+func (r *TerraformControllerConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	var config infrav1.TerraformControllerConfig
+	if err := r.Get(ctx, req.NamespacedName, &config); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			log.Info("the controller config was deleted, using the flags")
+			r.Config.Load(nil)
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	original := config.DeepCopy()
+
+	if err := validateControllerConfig(config.Spec); err != nil {
+		log.Error(err, "invalid controller config, using the flags")
+		r.Config.Load(nil)
+		apimeta.SetStatusCondition(&config.Status.Conditions, metav1.Condition{
+			Type:    meta.ReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.ConfigInvalidReason,
+			Message: err.Error(),
+		})
+	} else {
+		log.Info("loaded the controller config", "generation", config.Generation)
+		r.Config.Load(config.Spec.DeepCopy())
+		apimeta.SetStatusCondition(&config.Status.Conditions, metav1.Condition{
+			Type:    meta.ReadyCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  infrav1.ConfigLoadedReason,
+			Message: "Config loaded",
+		})
+
+		if requestedAt := runnerCARotationRequest(config); requestedAt != "" && r.CertRotator != nil {
+			if err := r.CertRotator.Rotate(ctx); err != nil {
+				return ctrl.Result{}, fmt.Errorf("unable to rotate the runner CA: %w", err)
+			}
+			log.Info("rotated the runner CA", "requestedAt", requestedAt)
+			config.Status.LastHandledRunnerCARotation = requestedAt
+		}
+	}
+	config.Status.ObservedGeneration = config.Generation
+	if reflect.DeepEqual(original.Status, config.Status) {
+		return ctrl.Result{}, nil
+	}
+
+	statusOpts := &client.SubResourcePatchOptions{
+		PatchOptions: client.PatchOptions{
+			FieldManager: "tf-controller",
+		},
+	}
+	return ctrl.Result{}, r.Status().Patch(ctx, &config, client.MergeFrom(original), statusOpts)
+}
+
+// runnerCARotationRequest returns the rotation of the runner CA requested by
+// the config and not handled yet, if any.
+func runnerCARotationRequest(config infrav1.TerraformControllerConfig) string {
+	if config.Spec.RunnerCA == nil {
+		return ""
+	}
+	if requestedAt := config.Spec.RunnerCA.RotationRequestedAt; requestedAt != config.Status.LastHandledRunnerCARotation {
+		return requestedAt
+	}
+	return ""
+}
+
+// validateControllerConfig returns the mistakes of the config the schema of
+// the CRD does not catch.
+func validateControllerConfig(spec infrav1.TerraformControllerConfigSpec) error {
+	var errs []string
+
+	var unknown []string
+	for name := range spec.FeatureGates {
+		known := false
+		for _, gate := range infrav1.FeatureGates {
+			known = known || gate == name
+		}
+		if !known {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		errs = append(errs, fmt.Sprintf("unknown feature gates: %s", strings.Join(unknown, ", ")))
+	}
+
+	for i, rule := range spec.AllowedCrossNamespaceRefs {
+		if _, err := path.Match(rule.From, ""); err != nil {
+			errs = append(errs, fmt.Sprintf("allowedCrossNamespaceRefs[%d].from: invalid pattern %q", i, rule.From))
+		}
+		if _, err := path.Match(rule.To, ""); err != nil {
+			errs = append(errs, fmt.Sprintf("allowedCrossNamespaceRefs[%d].to: invalid pattern %q", i, rule.To))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TerraformControllerConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	recoverPanic := true
+	isControllerConfig := predicate.NewPredicateFuncs(func(object client.Object) bool {
+		return object.GetName() == r.Name
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&infrav1.TerraformControllerConfig{}, builder.WithPredicates(
+			isControllerConfig,
+			predicate.Or(predicate.GenerationChangedPredicate{}, predicates.ReconcileRequestedPredicate{}),
+		)).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: 1,
+			RecoverPanic:            &recoverPanic,
+		}).
+		Complete(r)
+}
@@ -0,0 +1,186 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	eventv1 "github.com/fluxcd/pkg/apis/event/v1beta1"
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/zclconf/go-cty/cty"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// maxConflictsInMessage is the number of conflicts listed in the message of
+// the Conflict condition, the others are counted.
+const maxConflictsInMessage = 5
+
+// IndexByManagedResource indexes the Terraform objects by the resources of
+// their inventory, as <type>/<identifier>, and by the state they write to.
+// The plan only objects write nothing, like the branches of the branch
+// planner sharing the state of their original.
+func (r *TerraformReconciler) IndexByManagedResource() func(o client.Object) []string {
+	return func(o client.Object) []string {
+		terraform, ok := o.(*infrav1.Terraform)
+		if !ok {
+			panic(fmt.Sprintf("Expected a Terraform, got %T", o))
+		}
+		return r.managedResourceKeys(*terraform)
+	}
+}
+
+func (r *TerraformReconciler) managedResourceKeys(terraform infrav1.Terraform) []string {
+	if terraform.Spec.PlanOnly {
+		return nil
+	}
+
+	var keys []string
+	if terraform.Status.Inventory != nil {
+		for _, entry := range terraform.Status.Inventory.Entries {
+			if entry.Type == "" || entry.Identifier == "" {
+				continue
+			}
+			keys = append(keys, entry.Type+"/"+entry.Identifier)
+		}
+	}
+	if state, ok := r.stateLocation(terraform); ok {
+		keys = append(keys, state)
+	}
+	return keys
+}
+
+// stateLocation returns the location of the state of an object, for the
+// Kubernetes backend the controller configures in the cluster, and for the
+// S3 backends of the custom configurations.
+func (r *TerraformReconciler) stateLocation(terraform infrav1.Terraform) (string, bool) {
+	if name, ok := r.inClusterStateSecretName(terraform); ok {
+		namespace := terraform.GetNamespace()
+		if backendConfig := terraform.Spec.BackendConfig; backendConfig != nil && backendConfig.SecretNamespace != "" {
+			namespace = backendConfig.SecretNamespace
+		}
+		return fmt.Sprintf("backend.kubernetes/%s/%s", namespace, name), true
+	}
+
+	backendConfig := terraform.Spec.BackendConfig
+	if backendConfig == nil || backendConfig.CustomConfiguration == "" {
+		return "", false
+	}
+	bucket, key, ok := s3StateKey(backendConfig.CustomConfiguration, terraform.WorkspaceName())
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("backend.s3/%s/%s", bucket, key), true
+}
+
+// s3StateKey returns the bucket and the key of the state of a workspace in
+// the S3 backend of a custom backend configuration. The bucket and the key
+// must be written literally, the ones of the backend configs are not read.
+func s3StateKey(customConfiguration, workspace string) (string, string, bool) {
+	file, diags := hclsyntax.ParseConfig([]byte(customConfiguration), "backend.tf", hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return "", "", false
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return "", "", false
+	}
+
+	for _, block := range body.Blocks {
+		if block.Type != "terraform" {
+			continue
+		}
+		for _, backend := range block.Body.Blocks {
+			if backend.Type != "backend" || len(backend.Labels) != 1 || backend.Labels[0] != "s3" {
+				continue
+			}
+			attributes := backend.Body.Attributes
+			bucket, key := literalString(attributes["bucket"]), literalString(attributes["key"])
+			if bucket == "" || key == "" {
+				return "", "", false
+			}
+			if workspace != infrav1.DefaultWorkspaceName {
+				prefix := literalString(attributes["workspace_key_prefix"])
+				if prefix == "" {
+					prefix = "env:"
+				}
+				key = path.Join(prefix, workspace, key)
+			}
+			return bucket, key, true
+		}
+	}
+	return "", "", false
+}
+
+// literalString returns the value of an attribute set to a literal string.
+func literalString(attribute *hclsyntax.Attribute) string {
+	if attribute == nil {
+		return ""
+	}
+	value, diags := attribute.Expr.Value(nil)
+	if diags.HasErrors() || value.Type() != cty.String || value.IsNull() || !value.IsKnown() {
+		return ""
+	}
+	return value.AsString()
+}
+
+// checkConflicts sets the Conflict condition when other Terraform objects
+// manage the same resources, or write to the same state, with a warning
+// event when the conflict starts or changes. The stacks would otherwise
+// revert the changes of each other on every apply.
+func (r *TerraformReconciler) checkConflicts(ctx context.Context, terraform infrav1.Terraform, revision string) infrav1.Terraform {
+	log := ctrl.LoggerFrom(ctx)
+
+	objectKey := types.NamespacedName{Namespace: terraform.GetNamespace(), Name: terraform.GetName()}
+	var conflicts []string
+	for _, key := range r.managedResourceKeys(terraform) {
+		var list infrav1.TerraformList
+		if err := r.List(ctx, &list, client.MatchingFields{infrav1.ManagedResourceIndexKey: key}); err != nil {
+			log.Error(err, "unable to check the conflicts")
+			return terraform
+		}
+
+		var others []string
+		for _, other := range list.Items {
+			if otherKey := client.ObjectKeyFromObject(&other); otherKey != objectKey {
+				others = append(others, otherKey.String())
+			}
+		}
+		if len(others) > 0 {
+			sort.Strings(others)
+			conflicts = append(conflicts, fmt.Sprintf("%s is also managed by %s", describeManagedResource(key), strings.Join(others, ", ")))
+		}
+	}
+
+	if len(conflicts) == 0 {
+		return infrav1.TerraformConflictResolved(terraform)
+	}
+
+	msg := strings.Join(conflicts, "; ")
+	if len(conflicts) > maxConflictsInMessage {
+		msg = fmt.Sprintf("%s; and %d more conflicts", strings.Join(conflicts[:maxConflictsInMessage], "; "), len(conflicts)-maxConflictsInMessage)
+	}
+
+	if current := apimeta.FindStatusCondition(terraform.Status.Conditions, infrav1.ConditionTypeConflict); current == nil || current.Message != msg {
+		r.eventWithReason(ctx, terraform, revision, eventv1.EventSeverityError, infrav1.ConflictDetectedReason, msg, nil)
+	}
+	return infrav1.TerraformConflicted(terraform, msg)
+}
+
+// describeManagedResource describes an index key of a managed resource.
+func describeManagedResource(key string) string {
+	kind, location, _ := strings.Cut(key, "/")
+	switch kind {
+	case "backend.kubernetes":
+		return fmt.Sprintf("the state Secret %s", location)
+	case "backend.s3":
+		return fmt.Sprintf("the state s3://%s", location)
+	}
+	return fmt.Sprintf("resource %s %s", kind, location)
+}
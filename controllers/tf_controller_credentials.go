@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	eventv1 "github.com/fluxcd/pkg/apis/event/v1beta1"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/runner"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// checkCredentials asks the runner to verify the cloud credentials before
+// Terraform is initialized. Invalid credentials make the object not ready
+// with the CredentialsInvalid reason, while credentials about to expire
+// emit an info event with the CredentialsExpiring reason, so that they can
+// be routed to an alert without failing the reconciliation.
+func (r *TerraformReconciler) checkCredentials(ctx context.Context, runnerClient runner.RunnerClient, terraform infrav1.Terraform, revision string) (infrav1.Terraform, error) {
+	log := ctrl.LoggerFrom(ctx)
+	spec := terraform.Spec.CredentialsCheck
+
+	providers := make([]string, 0, len(spec.Providers))
+	for _, provider := range spec.Providers {
+		providers = append(providers, string(provider))
+	}
+
+	reply, err := runnerClient.CheckCredentials(ctx, &runner.CheckCredentialsRequest{Providers: providers})
+	if err != nil {
+		log.Error(err, "unable to check credentials")
+		return terraform, err
+	}
+
+	var invalid, valid []string
+	for _, credentials := range reply.Credentials {
+		if !credentials.Valid {
+			invalid = append(invalid, fmt.Sprintf("%s: %s", credentials.Provider, credentials.Message))
+			continue
+		}
+
+		valid = append(valid, fmt.Sprintf("%s (%s)", credentials.Provider, credentials.Identity))
+		if msg, expiring := credentialsExpiring(credentials, spec.ExpiryWarningThreshold, time.Now()); expiring {
+			log.Info(msg)
+			r.eventWithReason(ctx, terraform, revision, eventv1.EventSeverityInfo, infrav1.CredentialsExpiringReason, msg, nil)
+		}
+	}
+
+	if len(invalid) > 0 {
+		err := fmt.Errorf("invalid credentials: %s", strings.Join(invalid, "; "))
+		return infrav1.TerraformCredentialsInvalid(terraform, revision, err.Error()), err
+	}
+
+	return infrav1.TerraformCredentialsValid(terraform, "Credentials are valid: "+strings.Join(valid, ", ")), nil
+}
+
+// credentialsExpiring returns a warning message if the given credentials
+// expire within the threshold.
+func credentialsExpiring(credentials *runner.ProviderCredentials, threshold *metav1.Duration, now time.Time) (string, bool) {
+	if threshold == nil || credentials.ExpiresAt == "" {
+		return "", false
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, credentials.ExpiresAt)
+	if err != nil {
+		return "", false
+	}
+
+	if expiresAt.Sub(now) > threshold.Duration {
+		return "", false
+	}
+
+	return fmt.Sprintf("Credentials of provider %s (%s) expire at %s",
+		credentials.Provider, credentials.Identity, credentials.ExpiresAt), true
+}
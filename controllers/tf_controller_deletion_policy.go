@@ -0,0 +1,25 @@
+package controllers
+
+import (
+	"os"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+)
+
+// inClusterStateSecretName returns the name of the Secret of the state,
+// when the object uses the Kubernetes backend the controller configures in
+// the cluster.
+func (r *TerraformReconciler) inClusterStateSecretName(terraform infrav1.Terraform) (string, bool) {
+	if r.backendCompletelyDisable(terraform) {
+		return "", false
+	}
+
+	backendConfig := terraform.Spec.BackendConfig
+	switch {
+	case backendConfig == nil && os.Getenv("DISABLE_TF_K8S_BACKEND") != "1":
+		return "tfstate-" + terraform.WorkspaceName() + "-" + terraform.Name, true
+	case backendConfig != nil && backendConfig.CustomConfiguration == "" && backendConfig.InClusterConfig && backendConfig.KubeConfig == nil:
+		return "tfstate-" + terraform.WorkspaceName() + "-" + backendConfig.SecretSuffix, true
+	}
+	return "", false
+}
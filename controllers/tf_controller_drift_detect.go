@@ -5,6 +5,7 @@ import (
 	"fmt"
 	eventv1 "github.com/fluxcd/pkg/apis/event/v1beta1"
 	"strings"
+	"time"
 
 	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
 	"github.com/weaveworks/tf-controller/runner"
@@ -68,17 +69,30 @@ func (r *TerraformReconciler) detectDrift(ctx context.Context, terraform infrav1
 		Refresh:    true,
 		Targets:    terraform.Spec.Targets,
 	}
+	if terraform.Spec.TFState != nil {
+		planRequest.DisableLock = terraform.Spec.TFState.DisablePlanLock
+	}
 	if r.backendCompletelyDisable(terraform) {
 		planRequest.Out = ""
 		planRequest.Refresh = true
 	}
 
+	// compare to the last refreshed state between the full plans of
+	// spec.refresh
+	detectedAt := time.Now()
+	if !r.backendCompletelyDisable(terraform) && !planRefreshes(terraform, detectedAt) {
+		log.Info("detect drift without refreshing the state")
+		planRequest.Refresh = false
+	}
+
 	eventSent := false
 	planReply, err := runnerClient.Plan(ctx, planRequest)
 	if err != nil {
 		if st, ok := status.FromError(err); ok {
 			for _, detail := range st.Details() {
-				if reply, ok := detail.(*runner.PlanReply); ok {
+				if reply, ok := detail.(*runner.PlanReply); ok && reply.StateLockIdentifier == "" {
+					terraform.Status.Errors = terraformErrors(terraform, reply.Diagnostics)
+				} else if ok {
 					msg := fmt.Sprintf("Drift detection error: State locked with Lock Identifier %s", reply.StateLockIdentifier)
 					r.event(ctx, terraform, revision, eventv1.EventSeverityError, msg, nil)
 					eventSent = true
@@ -92,7 +106,7 @@ func (r *TerraformReconciler) detectDrift(ctx context.Context, terraform infrav1
 			r.event(ctx, terraform, revision, eventv1.EventSeverityError, msg, nil)
 		}
 
-		err = fmt.Errorf("error running Plan: %s", err)
+		err = withStateLock(err, fmt.Errorf("error running Plan: %s", err))
 		return infrav1.TerraformNotReady(
 			terraform,
 			revision,
@@ -100,6 +114,7 @@ func (r *TerraformReconciler) detectDrift(ctx context.Context, terraform infrav1
 			err.Error(),
 		), err
 	}
+	terraform = recordFullPlan(terraform, planRequest.Refresh, detectedAt)
 	drifted := planReply.Drifted
 	log.Info(fmt.Sprintf("plan for drift: %s found drift: %v", planReply.Message, planReply.Drifted))
 
@@ -135,6 +150,7 @@ func (r *TerraformReconciler) detectDrift(ctx context.Context, terraform infrav1
 		return terraform, fmt.Errorf(infrav1.DriftDetectedReason)
 	}
 
+	r.event(ctx, terraform, revision, eventv1.EventSeverityTrace, "No drift detected", nil)
 	terraform = infrav1.TerraformNoDrift(terraform, revision, infrav1.NoDriftReason, "No drift")
 	return terraform, nil
 }
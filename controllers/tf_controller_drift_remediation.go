@@ -0,0 +1,144 @@
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	eventv1 "github.com/fluxcd/pkg/apis/event/v1beta1"
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/planner/provider"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	driftRemediationTokenKey = "token"
+
+	// driftBranchPrefix is the prefix of the head branches of the drift pull
+	// requests, followed by the namespace and the name of the object.
+	driftBranchPrefix = "tf-controller/drift/"
+)
+
+// remediateDrift opens a pull request documenting the drift detected on the
+// Terraform object, unless one is already opened for it.
+func (r *TerraformReconciler) remediateDrift(ctx context.Context, terraform infrav1.Terraform, sourceObj sourcev1.Source, revision string) (infrav1.Terraform, error) {
+	log := ctrl.LoggerFrom(ctx)
+	remediation := terraform.Spec.DriftRemediation
+
+	var drift string
+	if ready := apimeta.FindStatusCondition(terraform.Status.Conditions, meta.ReadyCondition); ready != nil {
+		drift = ready.Message
+	}
+	digest := driftDigest(drift)
+	if terraform.Status.DriftPullRequest != nil && terraform.Status.DriftPullRequest.DriftDigest == digest {
+		log.Info("a pull request is already opened for the drift", "pullRequest", terraform.Status.DriftPullRequest.URL)
+		return terraform, nil
+	}
+
+	repository, ok := sourceObj.(*sourcev1.GitRepository)
+	if !ok {
+		return terraform, fmt.Errorf("drift remediation requires a GitRepository source, not a %s", sourceObj.GetObjectKind().GroupVersionKind().Kind)
+	}
+	baseBranch := remediation.BaseBranch
+	if baseBranch == "" && repository.Spec.Reference != nil {
+		baseBranch = repository.Spec.Reference.Branch
+	}
+	if baseBranch == "" {
+		return terraform, fmt.Errorf("drift remediation requires a base branch, the source %s does not reference one", repository.Name)
+	}
+
+	var secret corev1.Secret
+	secretKey := types.NamespacedName{Namespace: terraform.Namespace, Name: remediation.SecretRef.Name}
+	if err := r.Client.Get(ctx, secretKey, &secret); err != nil {
+		return terraform, fmt.Errorf("unable to get the Git provider token of the drift remediation: %w", err)
+	}
+	token, ok := secret.Data[driftRemediationTokenKey]
+	if !ok || len(token) == 0 {
+		return terraform, fmt.Errorf("the secret %s has no %s key for the drift remediation", secretKey, driftRemediationTokenKey)
+	}
+
+	gitProvider, repo, err := provider.FromURL(
+		repository.Spec.URL,
+		provider.WithLogger(log),
+		provider.WithToken(provider.APITokenType, string(token)),
+	)
+	if err != nil {
+		return terraform, fmt.Errorf("unable to get the Git provider of %s: %w", repository.Spec.URL, err)
+	}
+
+	pr, err := openDriftPullRequest(ctx, gitProvider, repo, terraform, baseBranch, revision, drift, time.Now())
+	if err != nil {
+		return terraform, err
+	}
+
+	terraform.Status.DriftPullRequest = &infrav1.DriftPullRequest{
+		Number:      pr.Number,
+		URL:         pr.Link,
+		Branch:      pr.HeadBranch,
+		DriftDigest: digest,
+		OpenedAt:    metav1.Now(),
+	}
+	r.event(ctx, terraform, revision, eventv1.EventSeverityInfo,
+		fmt.Sprintf("Drift pull request #%d opened: %s", pr.Number, pr.Link), nil)
+	return terraform, nil
+}
+
+// openDriftPullRequest opens the pull request of a drift, with its report. A
+// pull request of the object which is still open is returned instead, as it
+// is the one to remediate the drift in.
+func openDriftPullRequest(ctx context.Context, gitProvider provider.Provider, repo provider.Repository, terraform infrav1.Terraform, baseBranch, revision, drift string, now time.Time) (*provider.PullRequest, error) {
+	objectPrefix := fmt.Sprintf("%s%s/%s/", driftBranchPrefix, terraform.Namespace, terraform.Name)
+
+	prs, err := gitProvider.ListPullRequests(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	for _, pr := range prs {
+		if strings.HasPrefix(pr.HeadBranch, objectPrefix) {
+			pr := pr
+			return &pr, nil
+		}
+	}
+
+	reportPath := terraform.Spec.DriftRemediation.ReportPath
+	if reportPath == "" {
+		reportPath = path.Join(terraform.Spec.Path, "drift", terraform.Name+".md")
+	}
+	report := driftReport(terraform, revision, drift, now)
+
+	return gitProvider.CreatePullRequest(ctx, repo, provider.NewPullRequest{
+		Title:         fmt.Sprintf("Drift of Terraform %s/%s", terraform.Namespace, terraform.Name),
+		Body:          report,
+		BaseBranch:    baseBranch,
+		HeadBranch:    objectPrefix + driftDigest(drift),
+		CommitMessage: fmt.Sprintf("Report the drift of Terraform %s/%s", terraform.Namespace, terraform.Name),
+		Files:         map[string][]byte{reportPath: []byte(report)},
+	})
+}
+
+// driftReport describes a drift in Markdown, for the pull request and the
+// file it commits.
+func driftReport(terraform infrav1.Terraform, revision, drift string, now time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Drift of Terraform %s/%s\n\n", terraform.Namespace, terraform.Name)
+	fmt.Fprintf(&b, "A drift was detected on %s, at revision `%s`. It is not applied: ", now.UTC().Format(time.RFC3339), revision)
+	b.WriteString("change the configuration to account for it, or revert the changes of the infrastructure.\n\n")
+	b.WriteString("## Plan\n\n```\n")
+	b.WriteString(strings.TrimSpace(drift))
+	b.WriteString("\n```\n")
+	return b.String()
+}
+
+// driftDigest identifies a drift by its plan.
+func driftDigest(drift string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(drift)))[:12]
+}
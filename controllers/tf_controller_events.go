@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	eventv1 "github.com/fluxcd/pkg/apis/event/v1beta1"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// eventRepeatedKey is the metadata of an event telling how many identical
+// events were aggregated into it.
+var eventRepeatedKey = infrav1.GroupVersion.Group + "/repeated"
+
+// eventVerbosity returns the verbosity of the events of a Terraform object,
+// from its spec, from the defaults of the config, or from the flag.
+func (r *TerraformReconciler) eventVerbosity(terraform infrav1.Terraform) infrav1.EventVerbosity {
+	if terraform.Spec.EventVerbosity != "" {
+		return terraform.Spec.EventVerbosity
+	}
+	if spec := r.Config.Spec(); spec != nil && spec.Defaults != nil && spec.Defaults.EventVerbosity != "" {
+		return spec.Defaults.EventVerbosity
+	}
+	if r.EventVerbosity != "" {
+		return r.EventVerbosity
+	}
+	return infrav1.EventVerbosityNormal
+}
+
+// eventAggregationWindow returns the time during which the repeated events
+// are aggregated.
+func (r *TerraformReconciler) eventAggregationWindow() time.Duration {
+	if spec := r.Config.Spec(); spec != nil && spec.Defaults != nil && spec.Defaults.EventAggregationWindow != nil {
+		return spec.Defaults.EventAggregationWindow.Duration
+	}
+	return r.EventAggregationWindow
+}
+
+// eventVerbose returns whether an event of the severity is recorded at the
+// verbosity.
+func eventVerbose(verbosity infrav1.EventVerbosity, severity string) bool {
+	switch verbosity {
+	case infrav1.EventVerbosityErrorsOnly:
+		return severity == eventv1.EventSeverityError
+	case infrav1.EventVerbosityDebug:
+		return true
+	default:
+		return severity != eventv1.EventSeverityTrace
+	}
+}
+
+// eventAggregator counts the events of a Terraform object repeating one
+// recorded less than the aggregation window ago, rather than recording them.
+// The next identical event recorded after the window carries their count.
+type eventAggregator struct {
+	mu     sync.Mutex
+	events map[types.UID]map[string]*aggregatedEvent
+}
+
+type aggregatedEvent struct {
+	recordedAt time.Time
+	repeated   int
+}
+
+// admit returns whether an event is recorded, and how many identical
+// events were aggregated since the last one recorded, and when it was.
+func (a *eventAggregator) admit(uid types.UID, severity, reason, msg string, now time.Time, window time.Duration) (bool, int, time.Time) {
+	if window <= 0 {
+		return true, 0, time.Time{}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.events == nil {
+		a.events = map[types.UID]map[string]*aggregatedEvent{}
+	}
+	events := a.events[uid]
+	if events == nil {
+		events = map[string]*aggregatedEvent{}
+		a.events[uid] = events
+	}
+
+	key := severity + "/" + reason + "/" + msg
+	if event, ok := events[key]; ok && now.Sub(event.recordedAt) < window {
+		event.repeated++
+		return false, 0, time.Time{}
+	}
+
+	// the other events of the object are forgotten once past the window
+	var repeated int
+	var since time.Time
+	if event, ok := events[key]; ok {
+		repeated, since = event.repeated, event.recordedAt
+	}
+	for k, event := range events {
+		if now.Sub(event.recordedAt) >= window {
+			delete(events, k)
+		}
+	}
+	events[key] = &aggregatedEvent{recordedAt: now}
+	return true, repeated, since
+}
+
+// forget drops the events of a deleted Terraform object.
+func (a *eventAggregator) forget(uid types.UID) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.events, uid)
+}
+
+// aggregatedEventMessage returns the message of an event which identical
+// events were aggregated into.
+func aggregatedEventMessage(msg string, repeated int, since time.Time) string {
+	if repeated == 0 {
+		return msg
+	}
+	return fmt.Sprintf("%s\n\n(repeated %d time(s) since %s)", msg, repeated, since.UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,47 @@
+package controllers
+
+import (
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// expire returns the Terraform object to reconcile once its resources have
+// expired: a destroy is planned and applied without waiting for an approval.
+// A pending plan which does not destroy the resources is discarded, so that
+// it does not get applied instead.
+func expire(terraform infrav1.Terraform) infrav1.Terraform {
+	terraform.Spec.Destroy = true
+	terraform.Spec.ApprovePlan = infrav1.ApprovePlanAutoValue
+	if !terraform.Status.Plan.IsDestroyPlan {
+		terraform.Status.Plan.Pending = ""
+	}
+	return terraform
+}
+
+// branchPlanExpired returns true if the Terraform object is the ad-hoc
+// plan of a branch which has expired at the given time.
+func branchPlanExpired(terraform infrav1.Terraform, now time.Time) bool {
+	expiry := terraform.GetBranchPlanExpiry()
+	return expiry != nil && !now.Before(*expiry)
+}
+
+// requeueBeforeExpiry shortens the requeue of the result, so that the
+// Terraform object gets reconciled when its resources expire, or when it
+// expires as the plan of a branch.
+func requeueBeforeExpiry(terraform infrav1.Terraform, result ctrl.Result, now time.Time) ctrl.Result {
+	expiry := terraform.GetExpiry()
+	if branchExpiry := terraform.GetBranchPlanExpiry(); branchExpiry != nil && (expiry == nil || branchExpiry.Before(*expiry)) {
+		expiry = branchExpiry
+	}
+	if expiry == nil || !now.Before(*expiry) {
+		return result
+	}
+
+	untilExpiry := expiry.Sub(now)
+	if result.RequeueAfter == 0 || untilExpiry < result.RequeueAfter {
+		result.RequeueAfter = untilExpiry
+	}
+	return result
+}
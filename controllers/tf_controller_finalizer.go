@@ -6,6 +6,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"strings"
 
+	eventv1 "github.com/fluxcd/pkg/apis/event/v1beta1"
 	"github.com/fluxcd/pkg/runtime/logger"
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
@@ -25,7 +26,7 @@ func (r *TerraformReconciler) finalize(ctx context.Context, terraform infrav1.Te
 
 	// TODO how to completely delete without planning?
 	traceLog.Info("Check if we need to Destroy on Delete")
-	if terraform.Spec.DestroyResourcesOnDeletion {
+	if terraform.ShouldDestroyResourcesOnDeletion() {
 
 		for _, finalizer := range terraform.GetFinalizers() {
 			if strings.HasPrefix(finalizer, infrav1.TFDependencyOfPrefix) {
@@ -112,6 +113,16 @@ func (r *TerraformReconciler) finalize(ctx context.Context, terraform infrav1.Te
 		outputSecretName = terraform.Spec.WriteOutputsToSecret.Name
 	}
 
+	stateSecretName := ""
+	if terraform.GetDeletionPolicy() == infrav1.DeletionPolicyDeleteState {
+		var ok bool
+		if stateSecretName, ok = r.inClusterStateSecretName(terraform); !ok {
+			msg := "the state is kept: the delete-state deletion policy only supports the in-cluster Kubernetes backend"
+			log.Info(msg)
+			r.event(ctx, terraform, terraform.Status.LastAttemptedRevision, eventv1.EventSeverityError, msg, nil)
+		}
+	}
+
 	traceLog.Info("Finalize the secrets")
 	finalizeSecretsReply, err := runnerClient.FinalizeSecrets(ctx, &runner.FinalizeSecretsRequest{
 		Namespace:                terraform.Namespace,
@@ -119,14 +130,16 @@ func (r *TerraformReconciler) finalize(ctx context.Context, terraform infrav1.Te
 		Workspace:                terraform.WorkspaceName(),
 		HasSpecifiedOutputSecret: hasSpecifiedOutputSecret,
 		OutputSecretName:         outputSecretName,
+		StateSecretName:          stateSecretName,
+		StateSecretNamespace:     stateSecretNamespace(terraform),
 	})
 	traceLog.Info("Check for an error")
 	if err != nil {
 		traceLog.Info("Try getting a status from the error")
 		if e, ok := status.FromError(err); ok {
 			switch e.Code() {
-			case codes.Internal:
-				// transient error
+			case codes.Internal, codes.Unimplemented:
+				// transient error, or a runner too old to delete the state
 				traceLog.Info("Internal error, transient, requeue")
 				return terraform, controllerruntime.Result{Requeue: true}, err
 			case codes.NotFound:
@@ -144,6 +157,10 @@ func (r *TerraformReconciler) finalize(ctx context.Context, terraform infrav1.Te
 	// Record deleted status
 	traceLog.Info("Record the deleted status")
 	r.recordReadinessMetric(ctx, terraform)
+	deleteResourceUsageMetrics(terraform)
+	deleteResourceTimingMetrics(terraform)
+	deleteQuarantineMetric(terraform)
+	r.events.forget(terraform.UID)
 
 	traceLog.Info("Get the Terraform resource")
 	if err := r.Get(ctx, objectKey, &terraform); err != nil {
@@ -0,0 +1,166 @@
+package controllers
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// maxModuleDescriptionLength is the maximum length of the description of a
+// module, taken from its README.
+const maxModuleDescriptionLength = 1024
+
+// readModule documents the root module at modulePath of a source artifact,
+// from its README and from the variable and output blocks of its .tf files.
+func readModule(tarGz []byte, modulePath, revision string) (*infrav1.ModuleStatus, error) {
+	dir := cleanArtifactPath(modulePath)
+	module := &infrav1.ModuleStatus{Revision: revision}
+
+	var readme []byte
+	err := readTarGz(tarGz, func(name string, entry tarEntry) error {
+		if cleanArtifactPath(path.Dir(name)) != dir {
+			return nil
+		}
+		base := path.Base(name)
+		switch {
+		case strings.EqualFold(base, "README.md"):
+			readme = entry.content
+		case strings.HasSuffix(base, ".tf"):
+			inputs, outputs, err := readModuleFile(base, entry.content)
+			if err != nil {
+				return err
+			}
+			module.Inputs = append(module.Inputs, inputs...)
+			module.Outputs = append(module.Outputs, outputs...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	module.Description = readmeDescription(readme)
+	sort.Slice(module.Inputs, func(i, j int) bool { return module.Inputs[i].Name < module.Inputs[j].Name })
+	sort.Slice(module.Outputs, func(i, j int) bool { return module.Outputs[i].Name < module.Outputs[j].Name })
+	return module, nil
+}
+
+// readModuleFile reads the variable and output blocks of a .tf file. The
+// types and the default values are kept as written.
+func readModuleFile(name string, content []byte) ([]infrav1.ModuleInput, []infrav1.ModuleOutput, error) {
+	file, diags := hclsyntax.ParseConfig(content, name, hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return nil, nil, fmt.Errorf("unable to parse %s: %s", name, diags.Error())
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, nil, nil
+	}
+
+	var inputs []infrav1.ModuleInput
+	var outputs []infrav1.ModuleOutput
+	for _, block := range body.Blocks {
+		if len(block.Labels) != 1 {
+			continue
+		}
+		attributes := block.Body.Attributes
+
+		switch block.Type {
+		case "variable":
+			input := infrav1.ModuleInput{
+				Name:        block.Labels[0],
+				Description: stringAttribute(attributes["description"], content),
+				Sensitive:   boolAttribute(attributes["sensitive"]),
+				Required:    true,
+			}
+			if attribute, ok := attributes["type"]; ok {
+				input.Type = sourceText(attribute, content)
+			}
+			if attribute, ok := attributes["default"]; ok {
+				value := sourceText(attribute, content)
+				input.Default = &value
+				input.Required = false
+			}
+			inputs = append(inputs, input)
+		case "output":
+			outputs = append(outputs, infrav1.ModuleOutput{
+				Name:        block.Labels[0],
+				Description: stringAttribute(attributes["description"], content),
+				Sensitive:   boolAttribute(attributes["sensitive"]),
+			})
+		}
+	}
+
+	return inputs, outputs, nil
+}
+
+// sourceText returns the expression of an attribute as written.
+func sourceText(attribute *hclsyntax.Attribute, content []byte) string {
+	return strings.TrimSpace(string(attribute.Expr.Range().SliceBytes(content)))
+}
+
+// stringAttribute returns the value of a string attribute, or its expression
+// as written when it is not a literal string, e.g. a heredoc with templates.
+func stringAttribute(attribute *hclsyntax.Attribute, content []byte) string {
+	if attribute == nil {
+		return ""
+	}
+	value, diags := attribute.Expr.Value(nil)
+	if diags.HasErrors() || value.IsNull() || !value.IsKnown() || value.Type() != cty.String {
+		return sourceText(attribute, content)
+	}
+	return strings.TrimSpace(value.AsString())
+}
+
+func boolAttribute(attribute *hclsyntax.Attribute) bool {
+	if attribute == nil {
+		return false
+	}
+	value, diags := attribute.Expr.Value(nil)
+	return !diags.HasErrors() && value.IsKnown() && value.Type() == cty.Bool && value.True()
+}
+
+// readmeDescription returns the first paragraph of a README, skipping its
+// headings, badges and HTML.
+func readmeDescription(readme []byte) string {
+	var paragraph []string
+	scanner := bufio.NewScanner(bytes.NewReader(readme))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			if len(paragraph) > 0 {
+				return trimDescription(strings.Join(paragraph, " "))
+			}
+		case strings.HasPrefix(line, "==="), strings.HasPrefix(line, "---"):
+			// the underline of a heading, or a rule
+			paragraph = paragraph[:0]
+		case strings.HasPrefix(line, "#"),
+			strings.HasPrefix(line, "[!["),
+			strings.HasPrefix(line, "!["),
+			strings.HasPrefix(line, "<"):
+			if len(paragraph) > 0 {
+				return trimDescription(strings.Join(paragraph, " "))
+			}
+		default:
+			paragraph = append(paragraph, line)
+		}
+	}
+	return trimDescription(strings.Join(paragraph, " "))
+}
+
+func trimDescription(description string) string {
+	runes := []rune(description)
+	if len(runes) <= maxModuleDescriptionLength {
+		return description
+	}
+	return string(runes[:maxModuleDescriptionLength-3]) + "..."
+}
@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	eventv1 "github.com/fluxcd/pkg/apis/event/v1beta1"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/runner"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// recordSuspectedMoves sets the MovedBlocks condition from the resources the
+// plan destroys and creates again, identical, at another address, with a
+// warning event suggesting the moved blocks to add.
+func (r *TerraformReconciler) recordSuspectedMoves(ctx context.Context, terraform infrav1.Terraform, revision string, moves []*runner.ResourceMove) infrav1.Terraform {
+	if len(moves) == 0 {
+		return infrav1.TerraformMovedBlocksCovered(terraform)
+	}
+
+	msg := suspectedMovesMessage(moves)
+	r.eventWithReason(ctx, terraform, revision, eventv1.EventSeverityError, infrav1.MovedBlocksMissingReason, msg, nil)
+	return infrav1.TerraformMovedBlocksMissing(terraform, msg)
+}
+
+// suspectedMovesMessage suggests the moved blocks which would move the
+// resources rather than replace them.
+func suspectedMovesMessage(moves []*runner.ResourceMove) string {
+	blocks := make([]string, 0, len(moves))
+	for _, move := range moves {
+		blocks = append(blocks, fmt.Sprintf("moved { from = %s, to = %s }", move.From, move.To))
+	}
+
+	subject := "1 resource is"
+	if len(moves) > 1 {
+		subject = fmt.Sprintf("%d resources are", len(moves))
+	}
+	return fmt.Sprintf("%s destroyed and created again, identical, at another address, add moved blocks to move rather than replace them: %s",
+		subject, strings.Join(blocks, "; "))
+}
+
+// checkMovedBlocks halts the apply of a plan which destroys and creates
+// again identical resources with spec.requireMovedBlocks. The plan is kept
+// pending, as planning the same revision again would not add the moved
+// blocks.
+func checkMovedBlocks(terraform infrav1.Terraform, revision string) (infrav1.Terraform, error) {
+	if !terraform.Spec.RequireMovedBlocks {
+		return terraform, nil
+	}
+
+	condition := apimeta.FindStatusCondition(terraform.Status.Conditions, infrav1.ConditionTypeMovedBlocks)
+	if condition == nil || condition.Status != metav1.ConditionFalse {
+		return terraform, nil
+	}
+
+	msg := fmt.Sprintf("plan %s is not applied: %s", terraform.Status.Plan.Pending, condition.Message)
+	return infrav1.TerraformNotReady(terraform, revision, infrav1.MovedBlocksMissingReason, msg), errors.New(msg)
+}
@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"google.golang.org/grpc/status"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+)
+
+// stateLockedError is a Terraform command of the runner which could not
+// acquire the lock of the state.
+type stateLockedError struct {
+	err error
+}
+
+func (e *stateLockedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *stateLockedError) Unwrap() error {
+	return e.err
+}
+
+// withStateLock returns err as a stateLockedError when the runner replied
+// runnerErr with the identifier of the lock of the state.
+func withStateLock(runnerErr error, err error) error {
+	if st, ok := status.FromError(runnerErr); ok {
+		for _, detail := range st.Details() {
+			if reply, ok := detail.(interface{ GetStateLockIdentifier() string }); ok && reply.GetStateLockIdentifier() != "" {
+				return &stateLockedError{err: err}
+			}
+		}
+	}
+	return err
+}
+
+// errorClass returns the class of spec.onError of the error of a failed
+// reconciliation, or "" when it has none.
+func errorClass(terraform infrav1.Terraform, err error) string {
+	var stateLocked *stateLockedError
+	var quotaExceeded *quotaExceededError
+	var runnerFailed *runnerPodFailedError
+	switch {
+	case errors.As(err, &stateLocked):
+		return infrav1.ErrorClassStateLocked
+	case errors.As(err, &quotaExceeded):
+		return infrav1.ErrorClassQuotaExceeded
+	case errors.As(err, &runnerFailed):
+		return infrav1.ErrorClassRunnerFailed
+	}
+
+	ready := apimeta.FindStatusCondition(terraform.Status.Conditions, meta.ReadyCondition)
+	if ready == nil {
+		return ""
+	}
+	switch ready.Reason {
+	case infrav1.BackendAccessDeniedReason:
+		return infrav1.ErrorClassBackendAccessDenied
+	case infrav1.DependencyNotReadyReason:
+		return infrav1.ErrorClassDependencyNotReady
+	case infrav1.TFExecInitFailedReason:
+		return infrav1.ErrorClassInitFailed
+	case infrav1.TFExecPlanFailedReason, infrav1.DriftDetectionFailedReason:
+		return infrav1.ErrorClassPlanFailed
+	case infrav1.TFExecApplyFailedReason:
+		return infrav1.ErrorClassApplyFailed
+	}
+	return ""
+}
+
+// retryAfterError returns the time to wait before retrying a reconciliation
+// failed with err: the time spec.onError sets for its class, or fallback.
+func retryAfterError(terraform infrav1.Terraform, err error, fallback time.Duration) time.Duration {
+	if retryAfter, ok := terraform.GetRetryAfterError(errorClass(terraform, err)); ok {
+		return retryAfter
+	}
+	return fallback
+}
@@ -1,11 +1,16 @@
 package controllers
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
+	"text/template"
 
+	"github.com/Masterminds/sprig/v3"
 	eventv1 "github.com/fluxcd/pkg/apis/event/v1beta1"
 
 	"github.com/hashicorp/terraform-exec/tfexec"
@@ -16,6 +21,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/jsonpath"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
@@ -32,12 +38,14 @@ func convertOutputs(outputs map[string]*runner.OutputMeta) map[string]tfexec.Out
 }
 
 func (r *TerraformReconciler) outputsMayBeDrifted(ctx context.Context, terraform infrav1.Terraform) (bool, error) {
-	if terraform.Spec.WriteOutputsToSecret != nil {
-		outputsSecretKey := types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Spec.WriteOutputsToSecret.Name}
+	for _, wots := range terraform.GetWriteOutputsToSecrets() {
+		outputsSecretKey := types.NamespacedName{Namespace: terraform.Namespace, Name: wots.Name}
 		var outputsSecret corev1.Secret
 		err := r.Client.Get(ctx, outputsSecretKey, &outputsSecret)
 		if err != nil && apierrors.IsNotFound(err) {
 			return true, nil
+		} else if err != nil {
+			return false, err
 		}
 
 		keysInSecret := []string{}
@@ -46,24 +54,32 @@ func (r *TerraformReconciler) outputsMayBeDrifted(ctx context.Context, terraform
 		}
 		sort.Strings(keysInSecret)
 
-		keysInSpec := terraform.Spec.WriteOutputsToSecret.Outputs
-		if len(keysInSpec) == 0 {
-			keysInSpec = terraform.Status.AvailableOutputs
+		var keysInSpec []string
+		if wots.Format == infrav1.OutputsFormatJSON {
+			keysInSpec = append(keysInSpec, wots.GetKey())
+		} else if len(wots.Outputs) > 0 {
+			keysInSpec = append(keysInSpec, wots.Outputs...)
+		} else {
+			keysInSpec = append(keysInSpec, terraform.Status.AvailableOutputs...)
+		}
+		for _, extraction := range wots.Extract {
+			keysInSpec = append(keysInSpec, extraction.Name)
+		}
+		for _, outputTemplate := range wots.Templates {
+			keysInSpec = append(keysInSpec, outputTemplate.Name)
 		}
 		sort.Strings(keysInSpec)
 
 		if strings.Join(keysInSecret, ",") != strings.Join(keysInSpec, ",") {
 			return true, nil
 		}
-
-		return false, err
 	}
 
 	return false, nil
 }
 
 func (r *TerraformReconciler) shouldWriteOutputs(terraform infrav1.Terraform, outputs map[string]tfexec.OutputMeta) bool {
-	if terraform.Spec.WriteOutputsToSecret != nil && len(outputs) > 0 {
+	if len(terraform.GetWriteOutputsToSecrets()) > 0 && len(outputs) > 0 {
 		return true
 	}
 
@@ -133,76 +149,268 @@ func (r *TerraformReconciler) obtainOutputs(ctx context.Context, terraform infra
 func (r *TerraformReconciler) writeOutput(ctx context.Context, terraform infrav1.Terraform, runnerClient runner.RunnerClient, outputs map[string]tfexec.OutputMeta, revision string) (infrav1.Terraform, error) {
 	log := ctrl.LoggerFrom(ctx)
 
-	wots := terraform.Spec.WriteOutputsToSecret
-	data := map[string][]byte{}
+	written := false
+	secretNames := map[string]bool{}
+	for _, wots := range terraform.GetWriteOutputsToSecrets() {
+		if secretNames[wots.Name] {
+			err := fmt.Errorf("the outputs are written to the secret '%s' more than once", wots.Name)
+			return infrav1.TerraformNotReady(
+				terraform,
+				revision,
+				infrav1.OutputsWritingFailedReason,
+				err.Error(),
+			), err
+		}
+		secretNames[wots.Name] = true
 
-	var filteredOutputs map[string]tfexec.OutputMeta
-	if len(wots.Outputs) == 0 {
-		filteredOutputs = outputs
-	} else {
-		if result, err := filterOutputs(outputs, wots.Outputs); err != nil {
+		data, err := outputsSecretData(wots, outputs)
+		if err != nil {
 			return infrav1.TerraformNotReady(
 				terraform,
 				revision,
 				infrav1.OutputsWritingFailedReason,
 				err.Error(),
 			), err
-		} else {
-			filteredOutputs = result
+		}
+
+		if len(data) == 0 || terraform.Spec.Destroy == true {
+			continue
+		}
+
+		writeOutputsReply, err := runnerClient.WriteOutputs(ctx, &runner.WriteOutputsRequest{
+			Namespace:   terraform.Namespace,
+			Name:        terraform.Name,
+			SecretName:  wots.Name,
+			Uuid:        string(terraform.UID),
+			Data:        data,
+			Labels:      wots.Labels,
+			Annotations: wots.Annotations,
+			Checksums:   wots.Checksums,
+		})
+		if err != nil {
+			return infrav1.TerraformNotReady(
+				terraform,
+				revision,
+				infrav1.OutputsWritingFailedReason,
+				err.Error(),
+			), err
+		}
+		log.Info(fmt.Sprintf("write outputs to %s: %s, changed: %v", wots.Name, writeOutputsReply.Message, writeOutputsReply.Changed))
+		written = true
+
+		if writeOutputsReply.Changed {
+			keysWritten := []string{}
+			for k, _ := range data {
+				keysWritten = append(keysWritten, k)
+			}
+			sort.Strings(keysWritten)
+			msg := fmt.Sprintf("Outputs written.\n%d output(s) to %s: %s", len(keysWritten), wots.Name, strings.Join(keysWritten, ", "))
+			r.event(ctx, terraform, revision, eventv1.EventSeverityInfo, msg, nil)
+		}
+		if len(writeOutputsReply.ChangedKeys) > 0 {
+			msg := fmt.Sprintf("%d output(s) of %s changed: %s", len(writeOutputsReply.ChangedKeys), wots.Name, strings.Join(writeOutputsReply.ChangedKeys, ", "))
+			r.eventWithReason(ctx, terraform, revision, eventv1.EventSeverityInfo, infrav1.OutputsChangedReason, msg,
+				map[string]string{"secret": wots.Name, "changedKeys": strings.Join(writeOutputsReply.ChangedKeys, ",")})
 		}
 	}
 
-	for outputOrAlias, outputMeta := range filteredOutputs {
-		ct, err := ctyjson.UnmarshalType(outputMeta.Type)
+	if !written {
+		return infrav1.TerraformOutputsWritten(terraform, revision, "No Outputs written"), nil
+	}
+	return infrav1.TerraformOutputsWritten(terraform, revision, "Outputs written"), nil
+}
+
+// outputsSecretData returns the data of an outputs secret: the outputs
+// selected in the format of the secret, and the values extracted from them
+// and the templates over them.
+func outputsSecretData(wots infrav1.WriteOutputsToSecretSpec, outputs map[string]tfexec.OutputMeta) (map[string][]byte, error) {
+	data := map[string][]byte{}
+
+	var filteredOutputs map[string]tfexec.OutputMeta
+	if len(wots.Outputs) == 0 {
+		filteredOutputs = outputs
+	} else {
+		result, err := filterOutputs(outputs, wots.Outputs)
 		if err != nil {
-			return terraform, err
+			return nil, err
 		}
+		filteredOutputs = result
+	}
 
-		if ct == cty.String {
-			cv, err := ctyjson.Unmarshal(outputMeta.Value, ct)
+	if wots.Format == infrav1.OutputsFormatJSON {
+		document, err := outputsDocument(filteredOutputs)
+		if err != nil {
+			return nil, err
+		}
+		data[wots.GetKey()] = document
+	} else {
+		for outputOrAlias, outputMeta := range filteredOutputs {
+			ct, err := ctyjson.UnmarshalType(outputMeta.Type)
 			if err != nil {
-				return terraform, err
+				return nil, err
+			}
+
+			if ct == cty.String {
+				cv, err := ctyjson.Unmarshal(outputMeta.Value, ct)
+				if err != nil {
+					return nil, err
+				}
+				data[outputOrAlias] = []byte(cv.AsString())
+			} else {
+				data[outputOrAlias] = outputMeta.Value
+				data[outputOrAlias+"__type"] = outputMeta.Type
 			}
-			data[outputOrAlias] = []byte(cv.AsString())
-		} else {
-			data[outputOrAlias] = outputMeta.Value
-			data[outputOrAlias+"__type"] = outputMeta.Type
 		}
 	}
 
-	if len(data) == 0 || terraform.Spec.Destroy == true {
-		return infrav1.TerraformOutputsWritten(terraform, revision, "No Outputs written"), nil
+	extracted, err := extractOutputs(outputs, wots.Extract)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range extracted {
+		data[key] = value
 	}
 
-	writeOutputsReply, err := runnerClient.WriteOutputs(ctx, &runner.WriteOutputsRequest{
-		Namespace:   terraform.Namespace,
-		Name:        terraform.Name,
-		SecretName:  terraform.Spec.WriteOutputsToSecret.Name,
-		Uuid:        string(terraform.UID),
-		Data:        data,
-		Labels:      terraform.Spec.WriteOutputsToSecret.Labels,
-		Annotations: terraform.Spec.WriteOutputsToSecret.Annotations,
-	})
+	rendered, err := renderOutputTemplates(outputs, wots.Templates)
 	if err != nil {
-		return infrav1.TerraformNotReady(
-			terraform,
-			revision,
-			infrav1.OutputsWritingFailedReason,
-			err.Error(),
-		), err
+		return nil, err
+	}
+	for key, value := range rendered {
+		data[key] = value
+	}
+
+	return data, nil
+}
+
+// outputsDocument returns the outputs as a single JSON object, keyed by
+// output name, which keeps the values as Terraform encodes them.
+func outputsDocument(outputs map[string]tfexec.OutputMeta) ([]byte, error) {
+	document := map[string]json.RawMessage{}
+	for name, outputMeta := range outputs {
+		document[name] = outputMeta.Value
+	}
+	return json.Marshal(document)
+}
+
+// extractOutputs returns the values selected in the outputs by the
+// extractions, keyed by extraction name. String values are returned as is,
+// or decoded, other values are encoded as JSON.
+func extractOutputs(outputs map[string]tfexec.OutputMeta, extractions []infrav1.OutputExtraction) (map[string][]byte, error) {
+	result := map[string][]byte{}
+	for _, extraction := range extractions {
+		outputMeta, ok := outputs[extraction.Output]
+		if !ok {
+			return nil, fmt.Errorf("output '%s' to extract '%s' from not found", extraction.Output, extraction.Name)
+		}
+
+		output, err := decodeOutputJSON(outputMeta.Value)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode output '%s': %w", extraction.Output, err)
+		}
+
+		value := output
+		if extraction.JSONPath != "" {
+			jp := jsonpath.New(extraction.Name)
+			if err := jp.Parse(extraction.JSONPath); err != nil {
+				return nil, fmt.Errorf("invalid JSONPath expression '%s' of '%s': %w", extraction.JSONPath, extraction.Name, err)
+			}
+			results, err := jp.FindResults(output)
+			if err != nil {
+				return nil, fmt.Errorf("unable to extract '%s' from output '%s': %w", extraction.Name, extraction.Output, err)
+			}
+
+			var values []interface{}
+			for _, r := range results {
+				for _, v := range r {
+					values = append(values, v.Interface())
+				}
+			}
+			if len(values) == 0 {
+				return nil, fmt.Errorf("no value of output '%s' matches the JSONPath expression '%s' of '%s'", extraction.Output, extraction.JSONPath, extraction.Name)
+			}
+
+			value = values
+			if len(values) == 1 {
+				value = values[0]
+			}
+		}
+
+		if extraction.Decode != "" {
+			str, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("unable to decode '%s': the value is not a string", extraction.Name)
+			}
+			switch extraction.Decode {
+			case infrav1.OutputDecodingBase64:
+				decoded, err := base64.StdEncoding.DecodeString(str)
+				if err != nil {
+					return nil, fmt.Errorf("unable to decode '%s' from base64: %w", extraction.Name, err)
+				}
+				result[extraction.Name] = decoded
+			default:
+				return nil, fmt.Errorf("unsupported decoding '%s' of '%s'", extraction.Decode, extraction.Name)
+			}
+			continue
+		}
+
+		if str, ok := value.(string); ok {
+			result[extraction.Name] = []byte(str)
+			continue
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode '%s': %w", extraction.Name, err)
+		}
+		result[extraction.Name] = encoded
+	}
+	return result, nil
+}
+
+// renderOutputTemplates returns the text of the templates over the values of
+// the outputs, keyed by template name.
+func renderOutputTemplates(outputs map[string]tfexec.OutputMeta, templates []infrav1.OutputTemplate) (map[string][]byte, error) {
+	result := map[string][]byte{}
+	if len(templates) == 0 {
+		return result, nil
 	}
-	log.Info(fmt.Sprintf("write outputs: %s, changed: %v", writeOutputsReply.Message, writeOutputsReply.Changed))
 
-	if writeOutputsReply.Changed {
-		keysWritten := []string{}
-		for k, _ := range data {
-			keysWritten = append(keysWritten, k)
+	values := map[string]interface{}{}
+	for name, outputMeta := range outputs {
+		value, err := decodeOutputJSON(outputMeta.Value)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode output '%s': %w", name, err)
 		}
-		msg := fmt.Sprintf("Outputs written.\n%d output(s): %s", len(keysWritten), strings.Join(keysWritten, ", "))
-		r.event(ctx, terraform, revision, eventv1.EventSeverityInfo, msg, nil)
+		values[name] = value
 	}
 
-	return infrav1.TerraformOutputsWritten(terraform, revision, "Outputs written"), nil
+	for _, outputTemplate := range templates {
+		tmpl, err := template.New(outputTemplate.Name).
+			Option("missingkey=error").
+			Funcs(sprig.TxtFuncMap()).
+			Parse(outputTemplate.Template)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template '%s': %w", outputTemplate.Name, err)
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, values); err != nil {
+			return nil, fmt.Errorf("unable to render template '%s': %w", outputTemplate.Name, err)
+		}
+		result[outputTemplate.Name] = buf.Bytes()
+	}
+	return result, nil
+}
+
+// decodeOutputJSON decodes the JSON value of an output, with numbers as
+// json.Number to write them back unchanged.
+func decodeOutputJSON(value []byte) (interface{}, error) {
+	var output interface{}
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.UseNumber()
+	if err := decoder.Decode(&output); err != nil {
+		return nil, err
+	}
+	return output, nil
 }
 
 func filterOutputs(outputs map[string]tfexec.OutputMeta, outputsToWrite []string) (map[string]tfexec.OutputMeta, error) {
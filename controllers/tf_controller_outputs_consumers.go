@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// consumersRollingOutError is returned when a consumer of the outputs Secret
+// of a Terraform object is rolling out, which holds back its apply.
+type consumersRollingOutError struct {
+	message string
+}
+
+func (e *consumersRollingOutError) Error() string {
+	return e.message
+}
+
+// rollingOutConsumers returns the consumers of the outputs Secrets of the
+// Terraform object which are rolling out, as kind/name. The workloads are
+// read from the API server rather than from the cache of the manager, not to
+// watch every workload of the cluster for the few objects with consumers.
+func (r *TerraformReconciler) rollingOutConsumers(ctx context.Context, terraform infrav1.Terraform) ([]string, error) {
+	rollingOut := map[string]bool{}
+	for _, secret := range terraform.GetWriteOutputsToSecrets() {
+		if secret.Consumers == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(&secret.Consumers.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector of the consumers of the Secret %s: %w", secret.Name, err)
+		}
+		if err := r.listRollingOutConsumers(ctx, terraform.Namespace, selector, rollingOut); err != nil {
+			return nil, err
+		}
+	}
+
+	var names []string
+	for name := range rollingOut {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// listRollingOutConsumers adds the workloads of the selector which are
+// rolling out to the set.
+func (r *TerraformReconciler) listRollingOutConsumers(ctx context.Context, namespace string, selector labels.Selector, rollingOut map[string]bool) error {
+	opts := []client.ListOption{client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector}}
+
+	var deployments appsv1.DeploymentList
+	if err := r.APIReader.List(ctx, &deployments, opts...); err != nil {
+		return fmt.Errorf("unable to list the deployments consuming the outputs: %w", err)
+	}
+	for _, deployment := range deployments.Items {
+		if deploymentRollingOut(deployment) {
+			rollingOut["Deployment/"+deployment.Name] = true
+		}
+	}
+
+	var statefulSets appsv1.StatefulSetList
+	if err := r.APIReader.List(ctx, &statefulSets, opts...); err != nil {
+		return fmt.Errorf("unable to list the statefulsets consuming the outputs: %w", err)
+	}
+	for _, statefulSet := range statefulSets.Items {
+		if statefulSetRollingOut(statefulSet) {
+			rollingOut["StatefulSet/"+statefulSet.Name] = true
+		}
+	}
+
+	var daemonSets appsv1.DaemonSetList
+	if err := r.APIReader.List(ctx, &daemonSets, opts...); err != nil {
+		return fmt.Errorf("unable to list the daemonsets consuming the outputs: %w", err)
+	}
+	for _, daemonSet := range daemonSets.Items {
+		if daemonSetRollingOut(daemonSet) {
+			rollingOut["DaemonSet/"+daemonSet.Name] = true
+		}
+	}
+	return nil
+}
+
+// deploymentRollingOut tells whether the deployment has not finished rolling
+// out, as `kubectl rollout status` does.
+func deploymentRollingOut(deployment appsv1.Deployment) bool {
+	if deployment.Generation > deployment.Status.ObservedGeneration {
+		return true
+	}
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	status := deployment.Status
+	return status.UpdatedReplicas < replicas ||
+		status.Replicas > status.UpdatedReplicas ||
+		status.AvailableReplicas < status.UpdatedReplicas
+}
+
+// statefulSetRollingOut tells whether the statefulset has not finished
+// rolling out, as `kubectl rollout status` does. The statefulsets updated on
+// delete only roll out when their pods are deleted, so only their readiness
+// is checked.
+func statefulSetRollingOut(statefulSet appsv1.StatefulSet) bool {
+	if statefulSet.Generation > statefulSet.Status.ObservedGeneration {
+		return true
+	}
+	replicas := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+	status := statefulSet.Status
+	if status.ReadyReplicas < replicas {
+		return true
+	}
+	if statefulSet.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		return false
+	}
+	if rollingUpdate := statefulSet.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil && rollingUpdate.Partition != nil {
+		return status.UpdatedReplicas < replicas-*rollingUpdate.Partition
+	}
+	return status.UpdateRevision != "" && status.CurrentRevision != status.UpdateRevision
+}
+
+// daemonSetRollingOut tells whether the daemonset has not finished rolling
+// out, as `kubectl rollout status` does.
+func daemonSetRollingOut(daemonSet appsv1.DaemonSet) bool {
+	if daemonSet.Generation > daemonSet.Status.ObservedGeneration {
+		return true
+	}
+	if daemonSet.Spec.UpdateStrategy.Type == appsv1.OnDeleteDaemonSetStrategyType {
+		return false
+	}
+	status := daemonSet.Status
+	return status.UpdatedNumberScheduled < status.DesiredNumberScheduled ||
+		status.NumberAvailable < status.DesiredNumberScheduled
+}
@@ -0,0 +1,170 @@
+package controllers
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// applyPausedError is returned when the cluster-wide pause holds back the
+// apply of a Terraform object.
+type applyPausedError struct {
+	message string
+}
+
+func (e *applyPausedError) Error() string {
+	return e.message
+}
+
+// clusterPause is the cluster-wide pause of the applies, read from the pause
+// ConfigMap of the controller.
+type clusterPause struct {
+	paused bool
+	reason string
+	// allowList are the namespace/name patterns of the Terraform objects
+	// still applied during the pause.
+	allowList []string
+}
+
+// parseClusterPause reads the pause from the data of the pause ConfigMap:
+//
+//	paused: "true"
+//	reason: INC-1234 database failover
+//	allowList: |
+//	  infra/dns
+//	  team-a/*
+//
+// An invalid ConfigMap pauses all the applies, for a mistake made during an
+// incident not to lift the freeze.
+func parseClusterPause(configMap *corev1.ConfigMap) clusterPause {
+	data := configMap.Data
+
+	paused := false
+	if value := strings.TrimSpace(data["paused"]); value != "" {
+		var err error
+		if paused, err = strconv.ParseBool(value); err != nil {
+			return clusterPause{paused: true, reason: fmt.Sprintf("invalid pause ConfigMap, paused must be a boolean: %q", value)}
+		}
+	}
+
+	pause := clusterPause{paused: paused, reason: strings.TrimSpace(data["reason"])}
+	scanner := bufio.NewScanner(strings.NewReader(data["allowList"]))
+	for scanner.Scan() {
+		pattern := strings.TrimSpace(scanner.Text())
+		if pattern == "" || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil || strings.Count(pattern, "/") != 1 {
+			return clusterPause{paused: true, reason: fmt.Sprintf("invalid pause ConfigMap, the allow-list expects namespace/name patterns: %q", pattern)}
+		}
+		pause.allowList = append(pause.allowList, pattern)
+	}
+
+	return pause
+}
+
+// allows reports whether the Terraform object is on the allow-list.
+func (p clusterPause) allows(terraform infrav1.Terraform) bool {
+	key := terraform.Namespace + "/" + terraform.Name
+	for _, pattern := range p.allowList {
+		if ok, _ := path.Match(pattern, key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// holds reports whether the pause holds back the applies of the Terraform
+// object.
+func (p clusterPause) holds(terraform infrav1.Terraform) bool {
+	return p.paused && !p.allows(terraform)
+}
+
+func (p clusterPause) message() string {
+	if p.reason == "" {
+		return "Applies are paused cluster-wide"
+	}
+	return fmt.Sprintf("Applies are paused cluster-wide: %s", p.reason)
+}
+
+// getClusterPause returns the cluster-wide pause. The applies are not paused
+// without a pause ConfigMap.
+func (r *TerraformReconciler) getClusterPause(ctx context.Context) clusterPause {
+	if r.PauseConfigMap.Name == "" {
+		return clusterPause{}
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := r.Get(ctx, r.PauseConfigMap, configMap); apierrors.IsNotFound(err) {
+		return clusterPause{}
+	} else if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "unable to get the pause ConfigMap, pausing the applies", "configMap", r.PauseConfigMap)
+		return clusterPause{paused: true, reason: "unable to get the pause ConfigMap"}
+	}
+
+	return parseClusterPause(configMap)
+}
+
+// reconcilePauseCondition reflects the cluster-wide pause on the Paused
+// condition of the Terraform object, and returns the message of the toggle
+// of the pause for the object, if it toggled.
+func reconcilePauseCondition(terraform infrav1.Terraform, pause clusterPause) (infrav1.Terraform, string) {
+	current := apimeta.FindStatusCondition(terraform.Status.Conditions, infrav1.ConditionTypePaused)
+
+	switch {
+	case !pause.paused:
+		if current == nil {
+			return terraform, ""
+		}
+		return infrav1.TerraformNotPaused(terraform), "Applies resumed, the cluster-wide pause is over"
+	case pause.allows(terraform):
+		msg := pause.message() + ", the object is on the allow-list"
+		if current != nil && current.Status == metav1.ConditionFalse && current.Message == msg {
+			return terraform, ""
+		}
+		return infrav1.TerraformPauseAllowListed(terraform, msg), msg
+	default:
+		msg := pause.message()
+		if current != nil && current.Status == metav1.ConditionTrue && current.Message == msg {
+			return terraform, ""
+		}
+		return infrav1.TerraformPaused(terraform, msg), msg
+	}
+}
+
+// requestsForPauseChange enqueues all the Terraform objects when the pause
+// ConfigMap changes, for their Paused condition to reflect it.
+func (r *TerraformReconciler) requestsForPauseChange(ctx context.Context, obj client.Object) []reconcile.Request {
+	var list infrav1.TerraformList
+	if err := r.List(ctx, &list); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "failed to list objects for pause change")
+		return nil
+	}
+
+	reqs := make([]reconcile.Request, 0, len(list.Items))
+	for _, t := range list.Items {
+		if t.Spec.Suspend {
+			continue
+		}
+		reqs = append(reqs, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&t)})
+	}
+	return reqs
+}
+
+// isPauseConfigMap reports whether the object is the pause ConfigMap.
+func (r *TerraformReconciler) isPauseConfigMap(obj client.Object) bool {
+	return types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()} == r.PauseConfigMap
+}
@@ -3,6 +3,9 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"path"
+	"time"
+
 	eventv1 "github.com/fluxcd/pkg/apis/event/v1beta1"
 	"github.com/weaveworks/tf-controller/api/planid"
 
@@ -35,6 +38,7 @@ func (r *TerraformReconciler) plan(ctx context.Context, terraform infrav1.Terraf
 
 	objectKey := types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Name}
 	terraform = infrav1.TerraformProgressing(terraform, "Terraform Planning")
+	terraform.Status.Progress = nil
 	if err := r.patchStatus(ctx, objectKey, terraform.Status); err != nil {
 		log.Error(err, "unable to update status before Terraform planning")
 		return terraform, err
@@ -48,6 +52,10 @@ func (r *TerraformReconciler) plan(ctx context.Context, terraform infrav1.Terraf
 		Refresh:    true, // be careful, refresh requires to be true by default
 		Targets:    terraform.Spec.Targets,
 	}
+	planStartedAt := time.Now()
+	if terraform.Spec.TFState != nil {
+		planRequest.DisableLock = terraform.Spec.TFState.DisablePlanLock
+	}
 
 	// if backend is disabled completely, there will be no plan output file (req.Out = "")
 	if r.backendCompletelyDisable(terraform) {
@@ -55,19 +63,33 @@ func (r *TerraformReconciler) plan(ctx context.Context, terraform infrav1.Terraf
 	}
 
 	// check if destroy is set to true or
-	// the object is being deleted and DestroyResourcesOnDeletion is set to true
-	if terraform.Spec.Destroy || (!terraform.ObjectMeta.DeletionTimestamp.IsZero() && terraform.Spec.DestroyResourcesOnDeletion) {
+	// the object is being deleted and its resources must be destroyed
+	if terraform.Spec.Destroy || (!terraform.ObjectMeta.DeletionTimestamp.IsZero() && terraform.ShouldDestroyResourcesOnDeletion()) {
 		log.Info("plan to destroy")
 		planRequest.Destroy = true
 	}
 
+	// skip the refresh between the full plans of spec.refresh, but never
+	// for a destroy
+	if !planRequest.Destroy && !planRefreshes(terraform, planStartedAt) {
+		log.Info("plan without refreshing the state")
+		planRequest.Refresh = false
+	}
+
+	stopProgress := r.watchProgress(ctx, objectKey, tfInstance, runnerClient)
 	planReply, err := runnerClient.Plan(ctx, planRequest)
+	stopProgress()
 	if err != nil {
 
 		eventSent := false
 		if st, ok := status.FromError(err); ok {
 			for _, detail := range st.Details() {
-				if reply, ok := detail.(*runner.PlanReply); ok {
+				if reply, ok := detail.(*runner.PlanReply); ok && len(reply.VariableValidationErrors) > 0 {
+					terraform.Status.Errors = terraformErrors(terraform, reply.Diagnostics)
+					return r.variableValidationFailed(ctx, terraform, revision, reply.VariableValidationErrors)
+				} else if ok && reply.StateLockIdentifier == "" {
+					terraform.Status.Errors = terraformErrors(terraform, reply.Diagnostics)
+				} else if ok {
 					msg := fmt.Sprintf("Plan error: State locked with Lock Identifier %s", reply.StateLockIdentifier)
 					r.event(ctx, terraform, revision, eventv1.EventSeverityError, msg, nil)
 					eventSent = true
@@ -80,7 +102,7 @@ func (r *TerraformReconciler) plan(ctx context.Context, terraform infrav1.Terraf
 			msg := fmt.Sprintf("Plan error: %s", err.Error())
 			r.event(ctx, terraform, revision, eventv1.EventSeverityError, msg, nil)
 		}
-		err = fmt.Errorf("error running Plan: %s", err)
+		err = withStateLock(err, fmt.Errorf("error running Plan: %s", err))
 		return infrav1.TerraformNotReady(
 			terraform,
 			revision,
@@ -89,6 +111,10 @@ func (r *TerraformReconciler) plan(ctx context.Context, terraform infrav1.Terraf
 		), err
 	}
 
+	terraform.Status.VariableValidationErrors = nil
+	terraform.Status.Errors = nil
+	terraform = recordFullPlan(terraform, planRequest.Refresh, planStartedAt)
+
 	drifted := planReply.Drifted
 	log.Info(fmt.Sprintf("plan: %s, found drift: %v", planReply.Message, drifted))
 
@@ -101,17 +127,23 @@ func (r *TerraformReconciler) plan(ctx context.Context, terraform infrav1.Terraf
 	}
 
 	if shouldProcessPostPlanningWebhooks(terraform) {
+		// the plan is over, the webhooks check it against the policies
+		terraform = infrav1.TerraformStageFinished(terraform, infrav1.StagePlan, nil)
+		terraform = infrav1.TerraformStageStarted(terraform, infrav1.StagePolicyCheck, revision)
+
 		log.Info("calling post planning webhooks ...")
 		terraform, err = r.processPostPlanningWebhooks(ctx, terraform, runnerClient, revision, tfInstance)
 		if err != nil {
 			log.Error(err, "failed during the process of post planning webhooks")
-			return infrav1.TerraformNotReady(
+			terraform = infrav1.TerraformNotReady(
 				terraform,
 				revision,
 				infrav1.PostPlanningWebhookFailedReason,
 				err.Error(),
-			), err
+			)
+			return infrav1.TerraformStageFinished(terraform, infrav1.StagePolicyCheck, err), err
 		}
+		terraform = infrav1.TerraformStageFinished(terraform, infrav1.StagePolicyCheck, nil)
 	}
 
 	saveTFPlanReply, err := runnerClient.SaveTFPlan(ctx, &runner.SaveTFPlanRequest{
@@ -132,6 +164,11 @@ func (r *TerraformReconciler) plan(ctx context.Context, terraform infrav1.Terraf
 		), err
 	}
 	log.Info(fmt.Sprintf("save tfplan: %s", saveTFPlanReply.Message))
+	if saveTFPlanReply.PlanChangesError != "" {
+		msg := fmt.Sprintf("Plan changes not published: %s", saveTFPlanReply.PlanChangesError)
+		log.Info(msg)
+		r.eventWithReason(ctx, terraform, revision, eventv1.EventSeverityError, infrav1.PlanChangesFailedReason, msg, nil)
+	}
 
 	if drifted {
 		forceOrAutoApply := r.forceOrAutoApply(terraform)
@@ -144,9 +181,64 @@ func (r *TerraformReconciler) plan(ctx context.Context, terraform infrav1.Terraf
 			r.event(ctx, terraform, revision, eventv1.EventSeverityInfo, msg, nil)
 		}
 		terraform = infrav1.TerraformPlannedWithChanges(terraform, revision, forceOrAutoApply, "Plan generated")
+		terraform.Status.Plan.Changes = &infrav1.PlanChanges{
+			Add:     planReply.ToAdd,
+			Change:  planReply.ToChange,
+			Destroy: planReply.ToDestroy,
+		}
+
+		if !forceOrAutoApply && r.PlanNotifier != nil {
+			if err := r.PlanNotifier.NotifyPlanPending(ctx, terraform, terraform.Status.Plan.Pending); err != nil {
+				log.Error(err, "unable to notify of the plan pending approval")
+			}
+		}
 	} else {
 		terraform = infrav1.TerraformPlannedNoChanges(terraform, revision, "Plan no changes")
 	}
 
+	terraform = r.recordSuspectedMoves(ctx, terraform, revision, planReply.SuspectedMoves)
 	return terraform, nil
 }
+
+// variableValidationFailed records the variables which failed their
+// validation rules, with an event for each of them, in place of the output of
+// the failed plan.
+func (r *TerraformReconciler) variableValidationFailed(ctx context.Context, terraform infrav1.Terraform, revision string, replyErrors []*runner.VariableValidationError) (infrav1.Terraform, error) {
+	validationErrors := make([]infrav1.VariableValidationError, 0, len(replyErrors))
+	for _, replyError := range replyErrors {
+		validationErrors = append(validationErrors, infrav1.VariableValidationError{
+			Variable: replyError.Variable,
+			Message:  replyError.Message,
+		})
+		msg := fmt.Sprintf("Plan error: invalid value for variable %s: %s", replyError.Variable, replyError.Message)
+		r.event(ctx, terraform, revision, eventv1.EventSeverityError, msg, nil)
+	}
+
+	terraform = infrav1.TerraformVariableValidationFailed(terraform, revision, validationErrors)
+	return terraform, fmt.Errorf("error running Plan: invalid value for %d variable(s)", len(validationErrors))
+}
+
+// terraformErrors converts the diagnostics of a failed plan or apply, with
+// their files from the root of the source rather than the path of the object.
+func terraformErrors(terraform infrav1.Terraform, diagnostics []*runner.Diagnostic) []infrav1.TerraformError {
+	if len(diagnostics) == 0 {
+		return nil
+	}
+
+	terraformErrors := make([]infrav1.TerraformError, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		file := diagnostic.Filename
+		if file != "" && !path.IsAbs(file) {
+			file = path.Join(terraform.Spec.Path, file)
+		}
+		terraformErrors = append(terraformErrors, infrav1.TerraformError{
+			Severity: diagnostic.Severity,
+			Summary:  diagnostic.Summary,
+			Detail:   diagnostic.Detail,
+			File:     file,
+			Line:     diagnostic.Line,
+			Column:   diagnostic.Column,
+		})
+	}
+	return terraformErrors
+}
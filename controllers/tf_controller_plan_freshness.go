@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/runner"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// checkPlanFreshness asks the runner whether the state in the backend
+// changed since the loaded plan was created. A stale plan is not applied:
+// it is discarded to be planned again with spec.autoReplanStalePlans, and
+// kept pending otherwise. Runners which do not implement the check are
+// trusted, as Terraform refuses to apply a stale plan itself.
+func (r *TerraformReconciler) checkPlanFreshness(ctx context.Context, terraform infrav1.Terraform, tfInstance string, runnerClient runner.RunnerClient, revision string) (infrav1.Terraform, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	reply, err := runnerClient.CheckPlanFreshness(ctx, &runner.CheckPlanFreshnessRequest{TfInstance: tfInstance})
+	if status.Code(err) == codes.Unimplemented {
+		log.Info("skipping the plan freshness check", "reason", err.Error())
+		return terraform, nil
+	} else if err != nil {
+		err = fmt.Errorf("unable to check the freshness of the plan: %s", err)
+		return infrav1.TerraformNotReady(terraform, revision, infrav1.TFExecApplyFailedReason, err.Error()), err
+	}
+
+	if !reply.Stale {
+		return terraform, nil
+	}
+
+	msg := fmt.Sprintf("plan %s is stale: the state changed since it was created (plan serial %d, state serial %d)",
+		terraform.Status.Plan.Pending, reply.PlanSerial, reply.StateSerial)
+	if reply.LineageChanged {
+		msg = fmt.Sprintf("plan %s is stale: the state was replaced since it was created", terraform.Status.Plan.Pending)
+	}
+	if terraform.Spec.AutoReplanStalePlans {
+		msg += ", planning again"
+	} else {
+		msg += ", run tfctl replan or set spec.autoReplanStalePlans to plan again"
+	}
+
+	err = errors.New(msg)
+	return infrav1.TerraformStalePlan(terraform, revision, msg, terraform.Spec.AutoReplanStalePlans), err
+}
@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/runner"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// progressPatchInterval throttles the status patches of the progress.
+	progressPatchInterval = 10 * time.Second
+	// maxProgressCurrentResources caps the resources in progress recorded
+	// in the status.
+	maxProgressCurrentResources = 5
+)
+
+// watchProgress records the progress of the plan or apply the runner is
+// about to run in status.progress, until the returned function is called.
+// The progress is best effort: runners which do not stream it are ignored,
+// and errors are only logged.
+func (r *TerraformReconciler) watchProgress(ctx context.Context, objectKey types.NamespacedName, tfInstance string, runnerClient runner.RunnerClient) func() {
+	log := ctrl.LoggerFrom(ctx)
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		stream, err := runnerClient.WatchProgress(watchCtx, &runner.WatchProgressRequest{TfInstance: tfInstance})
+		if status.Code(err) == codes.Unimplemented {
+			log.Info("skipping the progress", "reason", err.Error())
+			return
+		} else if err != nil {
+			log.Error(err, "unable to watch the progress")
+			return
+		}
+
+		var lastPatch time.Time
+		for {
+			reply, err := stream.Recv()
+			if err != nil {
+				if watchCtx.Err() == nil && status.Code(err) != codes.Canceled {
+					log.Error(err, "unable to receive the progress")
+				}
+				return
+			}
+			if reply.Done || time.Since(lastPatch) < progressPatchInterval {
+				continue
+			}
+
+			if err := r.patchProgress(watchCtx, objectKey, progressFromReply(reply, time.Now())); err != nil && watchCtx.Err() == nil {
+				log.Error(err, "unable to record the progress")
+			}
+			lastPatch = time.Now()
+		}
+	}()
+
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+func progressFromReply(reply *runner.ProgressReply, now time.Time) *infrav1.TerraformProgress {
+	progress := &infrav1.TerraformProgress{
+		Operation: reply.Operation,
+		Total:     reply.Total,
+		Completed: reply.Completed,
+		UpdatedAt: metav1.NewTime(now),
+	}
+	if reply.Total > 0 {
+		progress.Percentage = reply.Completed * 100 / reply.Total
+		if progress.Percentage > 100 {
+			progress.Percentage = 100
+		}
+	}
+	progress.CurrentResources = reply.Current
+	if len(progress.CurrentResources) > maxProgressCurrentResources {
+		progress.CurrentResources = progress.CurrentResources[:maxProgressCurrentResources]
+	}
+	return progress
+}
+
+// patchProgress patches status.progress only, so that it does not race with
+// the status the reconciliation holds. That status has no progress, and
+// clears it once patched.
+func (r *TerraformReconciler) patchProgress(ctx context.Context, objectKey types.NamespacedName, progress *infrav1.TerraformProgress) error {
+	var terraform infrav1.Terraform
+	if err := r.Get(ctx, objectKey, &terraform); err != nil {
+		return err
+	}
+
+	patch := client.MergeFrom(terraform.DeepCopy())
+	terraform.Status.Progress = progress
+	statusOpts := &client.SubResourcePatchOptions{
+		PatchOptions: client.PatchOptions{
+			FieldManager: "tf-controller",
+		},
+	}
+	return r.Status().Patch(ctx, &terraform, patch, statusOpts)
+}
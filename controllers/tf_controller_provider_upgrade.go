@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	eventv1 "github.com/fluxcd/pkg/apis/event/v1beta1"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=create;update;patch
+
+const (
+	providerLockSuffix = "-tf-provider-lock"
+	providerLockKey    = ".terraform.lock.hcl"
+)
+
+var providerLockVersionRe = regexp.MustCompile(`(?m)^provider "([^"]+)" \{\s*version\s*=\s*"([^"]+)"`)
+
+// pinnedProviderLock returns the dependency lock file the providers are
+// pinned to until their next upgrade, or an empty string when they are to be
+// upgraded.
+func (r *TerraformReconciler) pinnedProviderLock(ctx context.Context, terraform infrav1.Terraform, now time.Time) (string, error) {
+	if !providerPinned(terraform, now) {
+		return "", nil
+	}
+
+	var configMap v1.ConfigMap
+	key := types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Status.ProviderLock.ConfigMapName}
+	if err := r.Client.Get(ctx, key, &configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("unable to get the dependency lock of the providers: %w", err)
+	}
+	return configMap.Data[providerLockKey], nil
+}
+
+// providerPinned reports whether the providers of the object are pinned to
+// the versions of their last upgrade.
+func providerPinned(terraform infrav1.Terraform, now time.Time) bool {
+	upgrade := terraform.Spec.ProviderUpgrade
+	lock := terraform.Status.ProviderLock
+	if upgrade == nil || lock == nil {
+		return false
+	}
+	return now.Before(lock.LastUpgradedAt.Add(upgrade.Interval.Duration))
+}
+
+// recordProviderUpgrade stores the dependency lock file of an upgrade of the
+// providers in a ConfigMap owned by the Terraform object, with an event for
+// the providers whose versions changed.
+func (r *TerraformReconciler) recordProviderUpgrade(ctx context.Context, terraform infrav1.Terraform, revision, dependencyLock string, now time.Time) (infrav1.Terraform, error) {
+	configMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      terraform.Name + providerLockSuffix,
+			Namespace: terraform.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		configMap.Data = map[string]string{providerLockKey: dependencyLock}
+		return controllerutil.SetControllerReference(&terraform, configMap, r.Scheme)
+	})
+	if err != nil {
+		return terraform, fmt.Errorf("unable to store the dependency lock of the providers: %w", err)
+	}
+
+	providers := providerVersions(dependencyLock)
+	if previous := terraform.Status.ProviderLock; previous != nil {
+		if changes := providerVersionChanges(previous.Providers, providers); len(changes) > 0 {
+			msg := fmt.Sprintf("Providers upgraded: %s", strings.Join(changes, ", "))
+			r.event(ctx, terraform, revision, eventv1.EventSeverityInfo, msg, nil)
+		}
+	}
+
+	terraform.Status.ProviderLock = &infrav1.ProviderLockStatus{
+		ConfigMapName:  configMap.Name,
+		LastUpgradedAt: metav1.NewTime(now),
+		Providers:      providers,
+	}
+	return terraform, nil
+}
+
+// providerVersions returns the versions of the providers of a dependency
+// lock file by address.
+func providerVersions(dependencyLock string) map[string]string {
+	matches := providerLockVersionRe.FindAllStringSubmatch(dependencyLock, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	versions := make(map[string]string, len(matches))
+	for _, m := range matches {
+		versions[m[1]] = m[2]
+	}
+	return versions
+}
+
+// providerVersionChanges describes the providers added, upgraded or removed
+// between two dependency locks, in the order of their addresses.
+func providerVersionChanges(previous, current map[string]string) []string {
+	addresses := make([]string, 0, len(previous)+len(current))
+	for address := range previous {
+		addresses = append(addresses, address)
+	}
+	for address := range current {
+		if _, ok := previous[address]; !ok {
+			addresses = append(addresses, address)
+		}
+	}
+	sort.Strings(addresses)
+
+	var changes []string
+	for _, address := range addresses {
+		before, hadBefore := previous[address]
+		after, hasAfter := current[address]
+		switch {
+		case !hadBefore:
+			changes = append(changes, fmt.Sprintf("%s %s (new)", address, after))
+		case !hasAfter:
+			changes = append(changes, fmt.Sprintf("%s %s (removed)", address, before))
+		case before != after:
+			changes = append(changes, fmt.Sprintf("%s %s -> %s", address, before, after))
+		}
+	}
+	return changes
+}
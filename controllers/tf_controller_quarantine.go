@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	eventv1 "github.com/fluxcd/pkg/apis/event/v1beta1"
+	"github.com/prometheus/client_golang/prometheus"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var quarantinedObjects = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "tf_controller_quarantined",
+	Help: "Whether a Terraform object is quarantined after failing repeatedly (1) or not (0).",
+}, []string{"namespace", "name"})
+
+func init() {
+	metrics.Registry.MustRegister(quarantinedObjects)
+}
+
+// quarantineHold returns how long the reconciliation of a quarantined object
+// is held back. The object is released from quarantine, with its count of
+// failures reset, once its source revision or its spec changes, once a
+// reconciliation is requested, or once spec.quarantine is unset. It is retried
+// once its backoff is over, and quarantined again on its next failure.
+func quarantineHold(terraform infrav1.Terraform, revision string, reconcileRequested bool, now time.Time) (infrav1.Terraform, time.Duration) {
+	quarantine := terraform.Status.Quarantine
+	if quarantine == nil {
+		return terraform, 0
+	}
+
+	if terraform.Spec.Quarantine == nil ||
+		reconcileRequested ||
+		quarantine.Revision != revision ||
+		quarantine.Generation != terraform.Generation {
+		return infrav1.TerraformNotQuarantined(terraform), 0
+	}
+
+	if hold := quarantine.NextRetryAt.Sub(now); hold > 0 {
+		return terraform, hold
+	}
+	return terraform, 0
+}
+
+// isReconcileFailure reports whether a reconciliation failed, rather than
+// stopped on a drift, on the quota of its namespace, on the cluster-wide
+// pause or on the rollout of the consumers of its outputs.
+func isReconcileFailure(reconcileErr error) bool {
+	var quotaExceeded *quotaExceededError
+	var applyPaused *applyPausedError
+	var consumersRollingOut *consumersRollingOutError
+	return reconcileErr != nil &&
+		!errors.As(reconcileErr, &quotaExceeded) &&
+		!errors.As(reconcileErr, &applyPaused) &&
+		!errors.As(reconcileErr, &consumersRollingOut) &&
+		reconcileErr.Error() != infrav1.DriftDetectedReason
+}
+
+// recordReconcileFailure counts a failed reconciliation of the object, and
+// quarantines it once it failed spec.quarantine.threshold times in a row.
+func recordReconcileFailure(terraform infrav1.Terraform, revision string, now time.Time) (infrav1.Terraform, bool) {
+	terraform.Status.ConsecutiveFailures++
+
+	threshold := terraform.GetQuarantineThreshold()
+	if threshold == 0 || terraform.Status.ConsecutiveFailures < threshold {
+		return terraform, false
+	}
+
+	backoff := terraform.GetQuarantineBackoff()
+	msg := fmt.Sprintf("Quarantined after %d consecutive failures, next retry in %s",
+		terraform.Status.ConsecutiveFailures, backoff.String())
+	return infrav1.TerraformQuarantined(terraform, revision, msg, now.Add(backoff)), true
+}
+
+// recordReconcileSuccess resets the count of failures of the object after a
+// successful reconciliation, and releases it from quarantine.
+func recordReconcileSuccess(terraform infrav1.Terraform) infrav1.Terraform {
+	if terraform.Status.ConsecutiveFailures == 0 && terraform.Status.Quarantine == nil {
+		return terraform
+	}
+	return infrav1.TerraformNotQuarantined(terraform)
+}
+
+func recordQuarantineMetric(terraform infrav1.Terraform) {
+	labels := prometheus.Labels{"namespace": terraform.Namespace, "name": terraform.Name}
+	if terraform.Status.Quarantine != nil {
+		quarantinedObjects.With(labels).Set(1)
+	} else {
+		quarantinedObjects.With(labels).Set(0)
+	}
+}
+
+// eventQuarantined alerts that the object is quarantined.
+func (r *TerraformReconciler) eventQuarantined(ctx context.Context, terraform infrav1.Terraform, revision string) {
+	condition := apimeta.FindStatusCondition(terraform.Status.Conditions, infrav1.ConditionTypeQuarantined)
+	if condition == nil {
+		return
+	}
+	ctrl.LoggerFrom(ctx).Info(condition.Message)
+	r.event(ctx, terraform, revision, eventv1.EventSeverityError, condition.Message, nil)
+}
+
+// deleteQuarantineMetric deletes the quarantine metric of a deleted Terraform
+// object.
+func deleteQuarantineMetric(terraform infrav1.Terraform) {
+	quarantinedObjects.Delete(prometheus.Labels{"namespace": terraform.Namespace, "name": terraform.Name})
+}
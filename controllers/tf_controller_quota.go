@@ -0,0 +1,292 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	v1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=infra.contrib.fluxcd.io,resources=terraformnamespacepolicies/status,verbs=get;update;patch
+
+const (
+	// quotaRetryInterval is how often a Terraform object waiting for a
+	// runner of the quota of its namespace checks it again.
+	quotaRetryInterval = 30 * time.Second
+
+	appliesWindow = time.Hour
+)
+
+// quotaExceededError is returned when the quota of the namespace of a
+// Terraform object holds it back, until retryAfter.
+type quotaExceededError struct {
+	message    string
+	retryAfter time.Duration
+	// waitingFor is the reason the object waits for.
+	waitingFor string
+}
+
+func (e *quotaExceededError) Error() string {
+	return e.message
+}
+
+// namespaceQuotas serializes the checks of the quotas of the namespaces,
+// so that the Terraform objects reconciled concurrently cannot all pass
+// the same check. The runner pods of the objects which passed the runner
+// quota are reserved until the end of their reconciliation, as they are
+// not listed until they are created.
+type namespaceQuotas struct {
+	mu sync.Mutex
+	// runners are the reserved runner pod names of each namespace.
+	runners map[string]map[string]bool
+}
+
+// reserve reserves a runner pod of the namespace. mu must be held.
+func (q *namespaceQuotas) reserve(namespace, podName string) func() {
+	if q.runners == nil {
+		q.runners = map[string]map[string]bool{}
+	}
+	if q.runners[namespace] == nil {
+		q.runners[namespace] = map[string]bool{}
+	}
+	q.runners[namespace][podName] = true
+
+	return func() {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		delete(q.runners[namespace], podName)
+	}
+}
+
+// namespaceQuota returns the quota of the namespace, with the lowest limits
+// of the TerraformNamespacePolicies setting them. A cluster without the
+// TerraformNamespacePolicy CRD has no quota.
+func (r *TerraformReconciler) namespaceQuota(ctx context.Context, namespace string) (infrav1.NamespaceQuota, error) {
+	var quota infrav1.NamespaceQuota
+
+	policies, err := r.quotaPolicies(ctx, namespace)
+	if err != nil {
+		return quota, err
+	}
+
+	for _, policy := range policies {
+		if policy.Spec.Quota == nil {
+			continue
+		}
+		quota.MaxTerraforms = lowestLimit(quota.MaxTerraforms, policy.Spec.Quota.MaxTerraforms)
+		quota.MaxConcurrentRunners = lowestLimit(quota.MaxConcurrentRunners, policy.Spec.Quota.MaxConcurrentRunners)
+		quota.MaxAppliesPerHour = lowestLimit(quota.MaxAppliesPerHour, policy.Spec.Quota.MaxAppliesPerHour)
+	}
+	return quota, nil
+}
+
+// quotaPolicies returns the TerraformNamespacePolicies of the namespace, read
+// from the API server rather than the cache, for the budgets they record to
+// be up to date.
+func (r *TerraformReconciler) quotaPolicies(ctx context.Context, namespace string) ([]infrav1.TerraformNamespacePolicy, error) {
+	var policies infrav1.TerraformNamespacePolicyList
+	if err := r.APIReader.List(ctx, &policies, client.InNamespace(namespace)); err != nil {
+		if apimeta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to list the namespace policies: %w", err)
+	}
+	return policies.Items, nil
+}
+
+func lowestLimit(a, b *int32) *int32 {
+	if a == nil || (b != nil && *b < *a) {
+		return b
+	}
+	return a
+}
+
+// checkRunnerQuota checks that the Terraform object is within the maximum
+// number of Terraform objects of its namespace, and that it can have a
+// runner pod, before it gets one. The runner pod is reserved until release
+// is called, once the reconciliation is over.
+func (r *TerraformReconciler) checkRunnerQuota(ctx context.Context, terraform infrav1.Terraform) (release func(), err error) {
+	release = func() {}
+
+	quota, err := r.namespaceQuota(ctx, terraform.Namespace)
+	if err != nil {
+		return release, err
+	}
+
+	if quota.MaxTerraforms != nil {
+		var list infrav1.TerraformList
+		if err := r.Client.List(ctx, &list, client.InNamespace(terraform.Namespace)); err != nil {
+			return release, fmt.Errorf("unable to list the Terraform objects of the namespace: %w", err)
+		}
+		if rank := creationRank(list.Items, terraform); rank >= int(*quota.MaxTerraforms) {
+			return release, &quotaExceededError{
+				message: fmt.Sprintf("The namespace quota allows %d Terraform objects, this one is number %d",
+					*quota.MaxTerraforms, rank+1),
+				retryAfter: terraform.GetRetryInterval(),
+				waitingFor: infrav1.WaitingForTerraformQuota,
+			}
+		}
+	}
+
+	if quota.MaxConcurrentRunners != nil {
+		r.quotas.mu.Lock()
+		defer r.quotas.mu.Unlock()
+
+		var pods v1.PodList
+		if err := r.APIReader.List(ctx, &pods,
+			client.InNamespace(terraform.Namespace),
+			client.MatchingLabels{infrav1.RunnerLabel: terraform.Namespace},
+		); err != nil {
+			return release, fmt.Errorf("unable to list the runner pods of the namespace: %w", err)
+		}
+
+		runnerPodKey := getRunnerPodObjectKey(terraform)
+		runners := map[string]bool{}
+		for _, pod := range pods.Items {
+			if pod.Name == runnerPodKey.Name {
+				// the Terraform object already has its runner
+				return release, nil
+			}
+			if pod.DeletionTimestamp == nil {
+				runners[pod.Name] = true
+			}
+		}
+		// the runner pods about to be created by the objects reconciled
+		// concurrently
+		for podName := range r.quotas.runners[terraform.Namespace] {
+			if podName != runnerPodKey.Name {
+				runners[podName] = true
+			}
+		}
+		if len(runners) >= int(*quota.MaxConcurrentRunners) {
+			return release, &quotaExceededError{
+				message: fmt.Sprintf("The namespace quota allows %d concurrent runners, waiting for one to finish",
+					*quota.MaxConcurrentRunners),
+				retryAfter: quotaRetryInterval,
+				waitingFor: infrav1.WaitingForRunnerCapacity,
+			}
+		}
+
+		release = r.quotas.reserve(terraform.Namespace, runnerPodKey.Name)
+	}
+
+	return release, nil
+}
+
+// creationRank returns the rank of the Terraform object among the Terraform
+// objects of its namespace, by creation time.
+func creationRank(terraforms []infrav1.Terraform, terraform infrav1.Terraform) int {
+	sort.Slice(terraforms, func(i, j int) bool {
+		ti, tj := terraforms[i].CreationTimestamp, terraforms[j].CreationTimestamp
+		if !ti.Equal(&tj) {
+			return ti.Before(&tj)
+		}
+		return terraforms[i].Name < terraforms[j].Name
+	})
+
+	for i, t := range terraforms {
+		if t.Name == terraform.Name {
+			return i
+		}
+	}
+	return len(terraforms)
+}
+
+// waitingPosition returns the position of the waiting Terraform object among
+// the objects of its namespace waiting for the same reason, by the time they
+// started to wait.
+func (r *TerraformReconciler) waitingPosition(ctx context.Context, terraform infrav1.Terraform) (int32, error) {
+	waiting := terraform.Status.Waiting
+	var list infrav1.TerraformList
+	if err := r.Client.List(ctx, &list, client.InNamespace(terraform.Namespace)); err != nil {
+		return 0, fmt.Errorf("unable to list the Terraform objects of the namespace: %w", err)
+	}
+
+	position := int32(1)
+	for _, other := range list.Items {
+		otherWaiting := other.Status.Waiting
+		if other.Name == terraform.Name || otherWaiting == nil || otherWaiting.Reason != waiting.Reason {
+			continue
+		}
+		if otherWaiting.Since.Before(&waiting.Since) ||
+			(otherWaiting.Since.Equal(&waiting.Since) && other.Name < terraform.Name) {
+			position++
+		}
+	}
+	return position, nil
+}
+
+// checkApplyQuota checks that the namespace of the Terraform object has an
+// apply left in the last hour, and counts the apply if so. The applies are
+// recorded in the status of the policies setting maxAppliesPerHour, so
+// that the budget is kept across the restarts of the controller.
+func (r *TerraformReconciler) checkApplyQuota(ctx context.Context, terraform infrav1.Terraform, now time.Time) error {
+	r.quotas.mu.Lock()
+	defer r.quotas.mu.Unlock()
+
+	policies, err := r.quotaPolicies(ctx, terraform.Namespace)
+	if err != nil {
+		return err
+	}
+
+	var limit *int32
+	used, oldest := 0, time.Time{}
+	for i := range policies {
+		policy := &policies[i]
+		if policy.Spec.Quota == nil || policy.Spec.Quota.MaxAppliesPerHour == nil {
+			continue
+		}
+		limit = lowestLimit(limit, policy.Spec.Quota.MaxAppliesPerHour)
+		policy.Status.Applies = appliesInWindow(policy.Status.Applies, now)
+		// a policy created since has not seen all the applies
+		if len(policy.Status.Applies) > used {
+			used, oldest = len(policy.Status.Applies), policy.Status.Applies[0].Time
+		}
+	}
+	if limit == nil {
+		return nil
+	}
+
+	if used >= int(*limit) {
+		retryAfter := quotaRetryInterval
+		if !oldest.IsZero() {
+			retryAfter = oldest.Add(appliesWindow).Sub(now)
+		}
+		return &quotaExceededError{
+			message: fmt.Sprintf("The namespace quota allows %d applies per hour, the plan will be applied in %s",
+				*limit, retryAfter.Round(time.Second)),
+			retryAfter: retryAfter,
+			waitingFor: infrav1.WaitingForApplyQuota,
+		}
+	}
+
+	for i := range policies {
+		policy := &policies[i]
+		if policy.Spec.Quota == nil || policy.Spec.Quota.MaxAppliesPerHour == nil {
+			continue
+		}
+		policy.Status.Applies = append(policy.Status.Applies, metav1.NewTime(now))
+		if err := r.Client.Status().Update(ctx, policy); err != nil {
+			return fmt.Errorf("unable to record the apply in the namespace policy %s: %w", policy.Name, err)
+		}
+	}
+	return nil
+}
+
+// appliesInWindow returns the applies in the window ending now.
+func appliesInWindow(applies []metav1.Time, now time.Time) []metav1.Time {
+	var result []metav1.Time
+	for _, apply := range applies {
+		if now.Sub(apply.Time) < appliesWindow {
+			result = append(result, apply)
+		}
+	}
+	return result
+}
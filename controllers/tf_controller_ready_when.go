@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	"github.com/google/cel-go/cel"
+	"github.com/hashicorp/terraform-exec/tfexec"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/runner"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// checkReadyWhen evaluates the readiness expression of a ready Terraform
+// object, and sets the Ready condition to False when it does not hold.
+func (r *TerraformReconciler) checkReadyWhen(ctx context.Context, runnerClient runner.RunnerClient, terraform infrav1.Terraform, tfInstance string, revision string) (infrav1.Terraform, error) {
+	if terraform.Spec.ReadyWhen == "" || !apimeta.IsStatusConditionTrue(terraform.Status.Conditions, meta.ReadyCondition) {
+		return terraform, nil
+	}
+
+	outputReply, err := runnerClient.Output(ctx, &runner.OutputRequest{
+		TfInstance: tfInstance,
+	})
+	if err != nil {
+		err = fmt.Errorf("error running Output: %s", err)
+		return infrav1.TerraformNotReady(
+			terraform,
+			revision,
+			infrav1.TFExecOutputFailedReason,
+			err.Error(),
+		), err
+	}
+
+	ready, err := evaluateReadyWhen(terraform.Spec.ReadyWhen, convertOutputs(outputReply.Outputs), terraform.Status.Conditions)
+	if err != nil {
+		return infrav1.TerraformNotReady(
+			terraform,
+			revision,
+			infrav1.ReadyWhenFailedReason,
+			err.Error(),
+		), err
+	}
+	if !ready {
+		return infrav1.TerraformNotReady(
+			terraform,
+			revision,
+			infrav1.ReadyWhenNotMetReason,
+			fmt.Sprintf("The readiness expression is false: %s", terraform.Spec.ReadyWhen),
+		), nil
+	}
+	return terraform, nil
+}
+
+// evaluateReadyWhen evaluates the readiness expression over the values of
+// the outputs and the conditions of the object.
+func evaluateReadyWhen(expression string, outputs map[string]tfexec.OutputMeta, conditions []metav1.Condition) (bool, error) {
+	env, err := cel.NewEnv(
+		cel.Variable("outputs", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("conditions", cel.MapType(cel.StringType, cel.MapType(cel.StringType, cel.StringType))),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return false, fmt.Errorf("invalid readiness expression: %w", issues.Err())
+	}
+	if ast.OutputType() != cel.BoolType {
+		return false, fmt.Errorf("the readiness expression must return a bool, not %s", ast.OutputType())
+	}
+
+	program, err := env.Program(ast)
+	if err != nil {
+		return false, fmt.Errorf("invalid readiness expression: %w", err)
+	}
+
+	values := map[string]interface{}{}
+	for name, outputMeta := range outputs {
+		value, err := decodeOutputValue(outputMeta.Value)
+		if err != nil {
+			return false, fmt.Errorf("unable to decode output '%s': %w", name, err)
+		}
+		values[name] = value
+	}
+
+	conditionsByType := map[string]map[string]string{}
+	for _, condition := range conditions {
+		conditionsByType[condition.Type] = map[string]string{
+			"type":    condition.Type,
+			"status":  string(condition.Status),
+			"reason":  condition.Reason,
+			"message": condition.Message,
+		}
+	}
+
+	result, _, err := program.Eval(map[string]interface{}{
+		"outputs":    values,
+		"conditions": conditionsByType,
+	})
+	if err != nil {
+		return false, fmt.Errorf("unable to evaluate the readiness expression: %w", err)
+	}
+	ready, ok := result.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("the readiness expression must return a bool, not %v", result.Type())
+	}
+	return ready, nil
+}
+
+// decodeOutputValue decodes the JSON value of an output, with the whole
+// numbers as integers for them to compare with the integer literals of CEL.
+func decodeOutputValue(data []byte) (interface{}, error) {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return convertNumbers(value), nil
+}
+
+func convertNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case []interface{}:
+		for i := range v {
+			v[i] = convertNumbers(v[i])
+		}
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = convertNumbers(v[k])
+		}
+	}
+	return value
+}
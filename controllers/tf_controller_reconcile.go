@@ -18,10 +18,12 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	eventv1 "github.com/fluxcd/pkg/apis/event/v1beta1"
 	"github.com/fluxcd/pkg/apis/meta"
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
@@ -43,8 +45,25 @@ func (r *TerraformReconciler) reconcile(ctx context.Context, runnerClient runner
 
 		lastKnownAction string
 	)
+
+	usage := newResourceUsage(runnerClient)
+	defer usage.record(&terraform, revision)
+
+	if terraform.Spec.CredentialsCheck != nil {
+		log.Info("checking credentials")
+		terraform, err = r.checkCredentials(ctx, runnerClient, terraform, revision)
+		if err != nil {
+			log.Error(err, "error in credentials check")
+			return &terraform, err
+		}
+	}
+
 	log.Info("setting up terraform")
+	endStage := usage.measure(ctx, stageSetup)
+	terraform = infrav1.TerraformStageStarted(terraform, infrav1.StageInit, revision)
 	terraform, tfInstance, tmpDir, err = r.setupTerraform(ctx, runnerClient, terraform, sourceObj, revision, objectKey, reconciliationLoopID)
+	terraform = infrav1.TerraformStageFinished(terraform, infrav1.StageInit, err)
+	endStage()
 
 	lastKnownAction = "Setup"
 
@@ -64,7 +83,7 @@ func (r *TerraformReconciler) reconcile(ctx context.Context, runnerClient runner
 		return &terraform, err
 	}
 
-	if r.AllowBreakTheGlass {
+	if r.featureGate(infrav1.FeatureGateBreakTheGlass, r.AllowBreakTheGlass) {
 		// spec.breakTheGlass || annotation
 		breakTheGlass := terraform.Spec.BreakTheGlass
 		if terraform.Annotations != nil {
@@ -134,9 +153,31 @@ func (r *TerraformReconciler) reconcile(ctx context.Context, runnerClient runner
 		}
 	}
 
+	// refresh the state on its own schedule, split from the plans
+	if terraform.Spec.Refresh == nil {
+		terraform.Status.Refresh = nil
+	} else if r.shouldRefresh(terraform, time.Now()) {
+		endStage := usage.measure(ctx, stageRefresh)
+		terraform, err = r.refresh(ctx, terraform, tfInstance, runnerClient, revision)
+		endStage()
+		if err != nil {
+			log.Error(err, "error refreshing the state")
+			return &terraform, err
+		}
+
+		if err := r.patchStatus(ctx, objectKey, terraform.Status); err != nil {
+			log.Error(err, "unable to update status after refreshing the state")
+			return &terraform, err
+		}
+
+		lastKnownAction = "Refreshed"
+	}
+
 	if r.shouldDetectDrift(terraform, revision) {
 		var driftDetectionErr error // declared here to avoid shadowing on terraform variable
+		endStage := usage.measure(ctx, stageDriftDetection)
 		terraform, driftDetectionErr = r.detectDrift(ctx, terraform, tfInstance, runnerClient, revision)
+		endStage()
 
 		// immediately return if no drift - reconciliation will retry normally
 		if driftDetectionErr == nil {
@@ -152,7 +193,11 @@ func (r *TerraformReconciler) reconcile(ctx context.Context, runnerClient runner
 				return &terraform, err
 			}
 
-			return &terraform, nil
+			terraform, err = r.checkReadyWhen(ctx, runnerClient, terraform, tfInstance, revision)
+			if err != nil {
+				log.Error(err, "error checking the readiness expression")
+			}
+			return &terraform, err
 		}
 
 		// immediately return if err is not about drift
@@ -161,6 +206,18 @@ func (r *TerraformReconciler) reconcile(ctx context.Context, runnerClient runner
 			return &terraform, driftDetectionErr
 		}
 
+		// open a pull request for the drift rather than applying it
+		if terraform.Spec.DriftRemediation != nil {
+			var remediationErr error
+			terraform, remediationErr = r.remediateDrift(ctx, terraform, sourceObj, revision)
+			if remediationErr != nil {
+				log.Error(remediationErr, "unable to open a pull request for the drift")
+				r.event(ctx, terraform, revision, eventv1.EventSeverityError,
+					fmt.Sprintf("Drift remediation error: %s", remediationErr), nil)
+			}
+			return &terraform, driftDetectionErr
+		}
+
 		// immediately return if drift is detected, but it's not "force" or "auto"
 		if driftDetectionErr.Error() == infrav1.DriftDetectedReason && !r.forceOrAutoApply(terraform) {
 			log.Error(driftDetectionErr, "will not force / auto apply detected drift")
@@ -184,7 +241,11 @@ func (r *TerraformReconciler) reconcile(ctx context.Context, runnerClient runner
 
 	// if we should plan this Terraform CR, do so
 	if r.shouldPlan(terraform) {
+		endStage := usage.measure(ctx, stagePlan)
+		terraform = infrav1.TerraformStageStarted(terraform, infrav1.StagePlan, revision)
 		terraform, err = r.plan(ctx, terraform, tfInstance, runnerClient, revision)
+		terraform = infrav1.TerraformStageFinished(terraform, infrav1.StagePlan, err)
+		endStage()
 		if err != nil {
 			log.Error(err, "error planning")
 			return &terraform, err
@@ -200,7 +261,42 @@ func (r *TerraformReconciler) reconcile(ctx context.Context, runnerClient runner
 
 	// if we should apply the generated plan, do so
 	if r.shouldApply(terraform) {
+		if pause := r.getClusterPause(ctx); pause.holds(terraform) {
+			msg := fmt.Sprintf("Apply held back: %s", pause.message())
+			terraform = infrav1.TerraformPaused(terraform, pause.message())
+			terraform = infrav1.TerraformNotReady(terraform, revision, infrav1.ApplyPausedReason, msg)
+			terraform = infrav1.TerraformWaiting(terraform, infrav1.WaitingForClusterPause, msg, 0)
+			return &terraform, &applyPausedError{message: msg}
+		}
+		terraform = infrav1.TerraformNotWaiting(terraform, infrav1.WaitingForClusterPause)
+
+		if err := r.checkApplyQuota(ctx, terraform, time.Now()); err != nil {
+			var quotaExceeded *quotaExceededError
+			if errors.As(err, &quotaExceeded) {
+				terraform = infrav1.TerraformNotReady(terraform, revision, infrav1.QuotaExceededReason, quotaExceeded.Error())
+				terraform = infrav1.TerraformWaiting(terraform, quotaExceeded.waitingFor, quotaExceeded.Error(), 0)
+			}
+			return &terraform, err
+		}
+		terraform = infrav1.TerraformNotWaiting(terraform, infrav1.WaitingForApplyQuota)
+
+		rollingOut, err := r.rollingOutConsumers(ctx, terraform)
+		if err != nil {
+			return &terraform, err
+		}
+		if len(rollingOut) > 0 {
+			msg := fmt.Sprintf("Apply held back: the consumers of the outputs are rolling out: %s", strings.Join(rollingOut, ", "))
+			terraform = infrav1.TerraformNotReady(terraform, revision, infrav1.ConsumersRollingOutReason, msg)
+			terraform = infrav1.TerraformWaiting(terraform, infrav1.WaitingForConsumers, msg, 0)
+			return &terraform, &consumersRollingOutError{message: msg}
+		}
+		terraform = infrav1.TerraformNotWaiting(terraform, infrav1.WaitingForConsumers)
+
+		endStage := usage.measure(ctx, stageApply)
+		terraform = infrav1.TerraformStageStarted(terraform, infrav1.StageApply, revision)
 		terraform, err = r.apply(ctx, terraform, tfInstance, runnerClient, revision)
+		terraform = infrav1.TerraformStageFinished(terraform, infrav1.StageApply, err)
+		endStage()
 		if err != nil {
 			log.Error(err, "error applying")
 			return &terraform, err
@@ -224,8 +320,9 @@ func (r *TerraformReconciler) reconcile(ctx context.Context, runnerClient runner
 	lastKnownAction = "Outputs Processed"
 
 	if r.shouldDoHealthChecks(terraform) {
-
+		terraform = infrav1.TerraformStageStarted(terraform, infrav1.StageHealth, revision)
 		terraform, err = r.doHealthChecks(ctx, terraform, revision, runnerClient)
+		terraform = infrav1.TerraformStageFinished(terraform, infrav1.StageHealth, err)
 		if err != nil {
 			log.Error(err, "error with health check")
 			return &terraform, err
@@ -290,5 +387,11 @@ func (r *TerraformReconciler) reconcile(ctx context.Context, runnerClient runner
 		}
 	}
 
+	terraform, err = r.checkReadyWhen(ctx, runnerClient, terraform, tfInstance, revision)
+	if err != nil {
+		log.Error(err, "error checking the readiness expression")
+		return &terraform, err
+	}
+
 	return &terraform, nil
 }
@@ -0,0 +1,65 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	eventv1 "github.com/fluxcd/pkg/apis/event/v1beta1"
+	"github.com/fluxcd/pkg/apis/meta"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// recoverInterrupted resets a Terraform object left progressing by a
+// controller which stopped in the middle of its reconciliation, e.g. after a
+// crash or an eviction, when no runner pod is left to finish it. Its Ready
+// condition is set to False with the phase it was in, and an event recorded,
+// for the object to be reconciled from the start when it is enqueued. It
+// returns whether the object was reset.
+func (r *TerraformReconciler) recoverInterrupted(ctx context.Context, terraform *infrav1.Terraform) (bool, error) {
+	ready := apimeta.FindStatusCondition(terraform.Status.Conditions, meta.ReadyCondition)
+	if ready == nil || ready.Status != metav1.ConditionUnknown || ready.Reason != meta.ProgressingReason {
+		return false, nil
+	}
+
+	var pod corev1.Pod
+	err := r.Get(ctx, getRunnerPodObjectKey(*terraform), &pod)
+	if client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("unable to get the runner pod: %w", err)
+	}
+	if err == nil && runnerPodLive(pod) {
+		return false, nil
+	}
+
+	msg := fmt.Sprintf("The controller stopped during the last reconciliation (%s), it is reconciled again", ready.Message)
+	apimeta.SetStatusCondition(&terraform.Status.Conditions, metav1.Condition{
+		Type:    meta.ReadyCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  infrav1.ReconciliationInterruptedReason,
+		Message: msg,
+	})
+	*terraform = infrav1.TerraformStagesInterrupted(*terraform, msg)
+	if err := r.patchStatus(ctx, client.ObjectKeyFromObject(terraform), terraform.Status); err != nil {
+		return false, fmt.Errorf("unable to reset the status: %w", err)
+	}
+	r.recordReadinessMetric(ctx, *terraform)
+	r.eventWithReason(ctx, *terraform, terraform.Status.LastAttemptedRevision, eventv1.EventSeverityError,
+		infrav1.ReconciliationInterruptedReason, msg, nil)
+
+	ctrl.LoggerFrom(ctx).Info("reset the Terraform object interrupted during its reconciliation",
+		"terraform", client.ObjectKeyFromObject(terraform), "phase", ready.Message)
+	return true, nil
+}
+
+// runnerPodLive reports whether a runner pod may still be serving a
+// reconciliation.
+func runnerPodLive(pod corev1.Pod) bool {
+	if !pod.DeletionTimestamp.IsZero() {
+		return false
+	}
+	return pod.Status.Phase == corev1.PodPending || pod.Status.Phase == corev1.PodRunning
+}
@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	eventv1 "github.com/fluxcd/pkg/apis/event/v1beta1"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/runner"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// shouldRefresh reports whether the state of the object is due for a
+// refresh on the schedule of spec.refresh.interval. A pending plan is not
+// refreshed under, as it would become stale.
+func (r *TerraformReconciler) shouldRefresh(terraform infrav1.Terraform, now time.Time) bool {
+	refresh := terraform.Spec.Refresh
+	if refresh == nil || refresh.Interval == nil || refresh.Interval.Duration <= 0 {
+		return false
+	}
+	if terraform.Spec.PlanOnly || r.backendCompletelyDisable(terraform) || terraform.Status.Plan.Pending != "" {
+		return false
+	}
+	return !now.Before(nextRefresh(terraform))
+}
+
+// nextRefresh returns the time the state of the object is due for its next
+// refresh, or the zero time when it has never been refreshed.
+func nextRefresh(terraform infrav1.Terraform) time.Time {
+	if terraform.Status.Refresh == nil || terraform.Status.Refresh.LastRefreshedAt == nil {
+		return time.Time{}
+	}
+	return terraform.Status.Refresh.LastRefreshedAt.Add(terraform.Spec.Refresh.Interval.Duration)
+}
+
+// planRefreshes reports whether the next plan or drift detection of the
+// object refreshes the state, as they all do unless spec.refresh.skipOnPlan
+// is set, then only every spec.refresh.fullPlanInterval.
+func planRefreshes(terraform infrav1.Terraform, now time.Time) bool {
+	refresh := terraform.Spec.Refresh
+	if refresh == nil || !refresh.SkipOnPlan {
+		return true
+	}
+	if refresh.FullPlanInterval == nil || refresh.FullPlanInterval.Duration <= 0 {
+		return false
+	}
+	if terraform.Status.Refresh == nil || terraform.Status.Refresh.LastFullPlanAt == nil {
+		return true
+	}
+	return !now.Before(terraform.Status.Refresh.LastFullPlanAt.Add(refresh.FullPlanInterval.Duration))
+}
+
+// recordFullPlan records the time of a plan or of a drift detection which
+// refreshed the state, for the schedule of spec.refresh.fullPlanInterval.
+func recordFullPlan(terraform infrav1.Terraform, refreshed bool, now time.Time) infrav1.Terraform {
+	if terraform.Spec.Refresh == nil || !refreshed {
+		return terraform
+	}
+	if terraform.Status.Refresh == nil {
+		terraform.Status.Refresh = &infrav1.RefreshStatus{}
+	}
+	terraform.Status.Refresh.LastFullPlanAt = &metav1.Time{Time: now}
+	return terraform
+}
+
+// requeueBeforeRefresh shortens the requeue of the result, for the object to
+// be reconciled when its state is due for a refresh.
+func requeueBeforeRefresh(terraform infrav1.Terraform, result ctrl.Result, now time.Time) ctrl.Result {
+	refresh := terraform.Spec.Refresh
+	if refresh == nil || refresh.Interval == nil || refresh.Interval.Duration <= 0 {
+		return result
+	}
+
+	untilRefresh := nextRefresh(terraform).Sub(now)
+	if untilRefresh <= 0 {
+		untilRefresh = refresh.Interval.Duration
+	}
+	if result.RequeueAfter == 0 || untilRefresh < result.RequeueAfter {
+		result.RequeueAfter = untilRefresh
+	}
+	return result
+}
+
+func (r *TerraformReconciler) refresh(ctx context.Context, terraform infrav1.Terraform, tfInstance string, runnerClient runner.RunnerClient, revision string) (infrav1.Terraform, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	log.Info("calling refresh ...")
+
+	objectKey := types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Name}
+	terraform = infrav1.TerraformProgressing(terraform, "Terraform Refreshing")
+	terraform.Status.Progress = nil
+	if err := r.patchStatus(ctx, objectKey, terraform.Status); err != nil {
+		log.Error(err, "unable to update status before Terraform refresh")
+		return terraform, err
+	}
+
+	stopProgress := r.watchProgress(ctx, objectKey, tfInstance, runnerClient)
+	_, err := runnerClient.Refresh(ctx, &runner.RefreshRequest{
+		TfInstance: tfInstance,
+		Targets:    terraform.Spec.Targets,
+	})
+	stopProgress()
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			for _, detail := range st.Details() {
+				if reply, ok := detail.(*runner.RefreshReply); ok && reply.StateLockIdentifier == "" {
+					terraform.Status.Errors = terraformErrors(terraform, reply.Diagnostics)
+				} else if ok {
+					msg := fmt.Sprintf("Refresh error: State locked with Lock Identifier %s", reply.StateLockIdentifier)
+					r.event(ctx, terraform, revision, eventv1.EventSeverityError, msg, nil)
+					terraform = infrav1.TerraformStateLocked(terraform, reply.StateLockIdentifier, fmt.Sprintf("Terraform Locked with Lock Identifier: %s", reply.StateLockIdentifier))
+				}
+			}
+		}
+
+		err = withStateLock(err, fmt.Errorf("error running Refresh: %s", err))
+		return infrav1.TerraformNotReady(
+			terraform,
+			revision,
+			infrav1.TFExecRefreshFailedReason,
+			err.Error(),
+		), err
+	}
+
+	if terraform.Status.Refresh == nil {
+		terraform.Status.Refresh = &infrav1.RefreshStatus{}
+	}
+	terraform.Status.Refresh.LastRefreshedAt = &metav1.Time{Time: time.Now()}
+	return terraform, nil
+}
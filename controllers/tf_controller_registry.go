@@ -0,0 +1,65 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/runner"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	defaultRegistryCredentialsHostname = "app.terraform.io"
+	registryCredentialsTokenKey        = "token"
+)
+
+// registryCredentialsEnvs returns the environment variables which give
+// terraform the API tokens of the module registries, e.g.
+// TF_TOKEN_app_terraform_io for the HCP Terraform private registry.
+func (r *TerraformReconciler) registryCredentialsEnvs(ctx context.Context, terraform infrav1.Terraform) (map[string]string, error) {
+	envs := map[string]string{}
+	for _, credentials := range terraform.Spec.RegistryCredentials {
+		hostname := credentials.Hostname
+		if hostname == "" {
+			hostname = defaultRegistryCredentialsHostname
+		}
+
+		var secret corev1.Secret
+		secretKey := types.NamespacedName{Namespace: terraform.Namespace, Name: credentials.SecretRef.Name}
+		if err := r.Client.Get(ctx, secretKey, &secret); err != nil {
+			return nil, fmt.Errorf("unable to get the credentials of registry %s: %w", hostname, err)
+		}
+
+		token, ok := secret.Data[registryCredentialsTokenKey]
+		if !ok || len(token) == 0 {
+			return nil, fmt.Errorf("the secret %s has no %s key for the credentials of registry %s", secretKey, registryCredentialsTokenKey, hostname)
+		}
+		envs[runner.RegistryTokenEnv(hostname)] = string(token)
+	}
+	return envs, nil
+}
+
+// fetchRegistryModule makes the runner download spec.registryModule into the
+// working directory, and records the resolved version.
+func (r *TerraformReconciler) fetchRegistryModule(ctx context.Context, terraform infrav1.Terraform, tfInstance string, runnerClient runner.RunnerClient, revision string) (infrav1.Terraform, error) {
+	module := terraform.Spec.RegistryModule
+	reply, err := runnerClient.FetchRegistryModule(ctx, &runner.FetchRegistryModuleRequest{
+		TfInstance: tfInstance,
+		Source:     module.Source,
+		Version:    module.Version,
+	})
+	if err != nil {
+		err = fmt.Errorf("unable to fetch registry module %s: %s", module.Source, err)
+		return infrav1.TerraformNotReady(
+			terraform,
+			revision,
+			infrav1.RegistryModuleFailedReason,
+			err.Error(),
+		), err
+	}
+
+	terraform.Status.RegistryModuleVersion = reply.Version
+	return terraform, nil
+}
@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// clusterBackendRegexp matches the backends of a custom configuration
+// keeping the state in a cluster or in the runner pod.
+var clusterBackendRegexp = regexp.MustCompile(`backend\s+"(kubernetes|local)"`)
+
+// clusterBackend describes the backend keeping the state of a Terraform
+// object in a cluster or in its runner pod, or returns an empty string when
+// the state is kept remotely. The backend of a module whose backend
+// configuration is disabled is not known, and trusted to be remote.
+func clusterBackend(terraform infrav1.Terraform) string {
+	backendConfig := terraform.Spec.BackendConfig
+	switch {
+	case terraform.Spec.Cloud != nil:
+		return ""
+	case backendConfig == nil:
+		return "the default Kubernetes backend"
+	case backendConfig.Disable:
+		return ""
+	case backendConfig.CustomConfiguration != "":
+		if match := clusterBackendRegexp.FindStringSubmatch(backendConfig.CustomConfiguration); match != nil {
+			return fmt.Sprintf("the %s backend of its custom configuration", match[1])
+		}
+		return ""
+	default:
+		return "the Kubernetes backend"
+	}
+}
+
+// remoteBackendViolation returns why a Terraform object breaks the policies
+// requiring a remote backend, or an empty string if it does not.
+func remoteBackendViolation(terraform infrav1.Terraform, policies []infrav1.TerraformNamespacePolicy) string {
+	var names []string
+	for _, policy := range policies {
+		if policy.Spec.RequireRemoteBackend {
+			names = append(names, policy.Name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+
+	backend := clusterBackend(terraform)
+	if backend == "" {
+		return ""
+	}
+	sort.Strings(names)
+	return fmt.Sprintf("The namespace policy %s requires a remote backend, the state of the Terraform object is kept with %s",
+		strings.Join(names, ", "), backend)
+}
+
+// remoteBackendViolation returns why a Terraform object breaks the
+// TerraformNamespacePolicies of its namespace requiring a remote backend, or
+// an empty string if it does not. A cluster without the
+// TerraformNamespacePolicy CRD has no policies.
+func (r *TerraformReconciler) remoteBackendViolation(ctx context.Context, terraform infrav1.Terraform) (string, error) {
+	if clusterBackend(terraform) == "" {
+		return "", nil
+	}
+
+	var policies infrav1.TerraformNamespacePolicyList
+	if err := r.APIReader.List(ctx, &policies, client.InNamespace(terraform.Namespace)); err != nil {
+		if apimeta.IsNoMatchError(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("unable to list the namespace policies: %w", err)
+	}
+	return remoteBackendViolation(terraform, policies.Items), nil
+}
+
+// remoteBackendForbidden is the error of the webhook rejecting a Terraform
+// object which breaks the policies requiring a remote backend.
+func remoteBackendForbidden(terraform *infrav1.Terraform, violation string) error {
+	return apierrors.NewForbidden(infrav1.GroupVersion.WithResource("terraforms").GroupResource(), terraform.Name, fmt.Errorf("%s", violation))
+}
@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/runner"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// slowestResourcesLogged is the number of the slowest resources of an apply
+// written to the log.
+const slowestResourcesLogged = 3
+
+var resourceApplyDurationSeconds = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "tf_controller_resource_apply_duration_seconds",
+	Help: "Time terraform took for an action on a resource in the last apply of a Terraform object.",
+}, []string{"namespace", "name", "address", "action"})
+
+func init() {
+	metrics.Registry.MustRegister(resourceApplyDurationSeconds)
+}
+
+// recordResourceTimings sets the metrics of the times terraform took for the
+// resources of an apply, replacing the ones of the previous apply, and logs
+// the slowest ones. The runners which do not time the resources report none,
+// and the metrics of the previous apply are kept.
+func recordResourceTimings(ctx context.Context, terraform infrav1.Terraform, timings []*runner.ResourceTiming) {
+	if len(timings) == 0 {
+		return
+	}
+
+	deleteResourceTimingMetrics(terraform)
+	for _, timing := range timings {
+		resourceApplyDurationSeconds.With(prometheus.Labels{
+			"namespace": terraform.Namespace,
+			"name":      terraform.Name,
+			"address":   timing.Address,
+			"action":    timing.Action,
+		}).Set(timing.Seconds)
+	}
+
+	slowest := append([]*runner.ResourceTiming(nil), timings...)
+	sort.SliceStable(slowest, func(i, j int) bool { return slowest[i].Seconds > slowest[j].Seconds })
+	if len(slowest) > slowestResourcesLogged {
+		slowest = slowest[:slowestResourcesLogged]
+	}
+	described := make([]string, 0, len(slowest))
+	for _, timing := range slowest {
+		described = append(described, fmt.Sprintf("%s %s in %.1fs", timing.Action, timing.Address, timing.Seconds))
+	}
+	ctrl.LoggerFrom(ctx).Info("timed the resources of the apply", "resources", len(timings), "slowest", strings.Join(described, ", "))
+}
+
+// deleteResourceTimingMetrics deletes the metrics of the resources of a
+// Terraform object.
+func deleteResourceTimingMetrics(terraform infrav1.Terraform) {
+	resourceApplyDurationSeconds.DeletePartialMatch(prometheus.Labels{"namespace": terraform.Namespace, "name": terraform.Name})
+}
@@ -0,0 +1,130 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/runner"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	stageSetup          = "setup"
+	stageRefresh        = "refresh"
+	stageDriftDetection = "drift-detection"
+	stagePlan           = "plan"
+	stageApply          = "apply"
+)
+
+var (
+	runnerStageDurationSeconds = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "tf_controller_runner_stage_duration_seconds",
+		Help: "Wall-clock time of a stage of the last run of a Terraform object.",
+	}, []string{"namespace", "name", "stage"})
+	runnerStageCPUSeconds = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "tf_controller_runner_stage_cpu_seconds",
+		Help: "CPU time the runner pod used in a stage of the last run of a Terraform object.",
+	}, []string{"namespace", "name", "stage"})
+	runnerPeakMemoryBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "tf_controller_runner_peak_memory_bytes",
+		Help: "Peak memory of the runner pod at the end of a stage of the last run of a Terraform object.",
+	}, []string{"namespace", "name", "stage"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(runnerStageDurationSeconds, runnerStageCPUSeconds, runnerPeakMemoryBytes)
+}
+
+// resourceUsage measures the time and the resources the runner uses in the
+// stages of a run. The usage of the runner is best effort: the stages of
+// runners which do not report it only have a duration.
+type resourceUsage struct {
+	runnerClient runner.RunnerClient
+	unsupported  bool
+	stages       []infrav1.StageResourceUsage
+}
+
+func newResourceUsage(runnerClient runner.RunnerClient) *resourceUsage {
+	return &resourceUsage{runnerClient: runnerClient}
+}
+
+// measure starts measuring a stage, until the returned function is called.
+func (u *resourceUsage) measure(ctx context.Context, stage string) func() {
+	start := time.Now()
+	before, _ := u.runnerUsage(ctx)
+
+	return func() {
+		usage := infrav1.StageResourceUsage{
+			Name:     stage,
+			Duration: metav1.Duration{Duration: time.Since(start).Round(time.Millisecond)},
+		}
+		if after, ok := u.runnerUsage(ctx); ok {
+			if before != nil && after.CpuSeconds > 0 {
+				cpuTime := time.Duration((after.CpuSeconds - before.CpuSeconds) * float64(time.Second)).Round(time.Millisecond)
+				usage.CPUTime = &metav1.Duration{Duration: cpuTime}
+			}
+			if after.PeakMemoryBytes > 0 {
+				usage.PeakMemory = resource.NewQuantity(after.PeakMemoryBytes, resource.BinarySI)
+			}
+		}
+		u.stages = append(u.stages, usage)
+	}
+}
+
+func (u *resourceUsage) runnerUsage(ctx context.Context) (*runner.GetResourceUsageReply, bool) {
+	if u.unsupported {
+		return nil, false
+	}
+
+	reply, err := u.runnerClient.GetResourceUsage(ctx, &runner.GetResourceUsageRequest{})
+	if status.Code(err) == codes.Unimplemented {
+		ctrl.LoggerFrom(ctx).Info("skipping the resource usage of the runner", "reason", err.Error())
+		u.unsupported = true
+		return nil, false
+	} else if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "unable to get the resource usage of the runner")
+		return nil, false
+	}
+	return reply, true
+}
+
+// record sets the resource usage of the run in the status and in the
+// metrics of the Terraform object.
+func (u *resourceUsage) record(terraform *infrav1.Terraform, revision string) {
+	if len(u.stages) == 0 {
+		return
+	}
+
+	terraform.Status.ResourceUsage = &infrav1.RunnerResourceUsage{
+		Revision:   revision,
+		Stages:     u.stages,
+		ObservedAt: metav1.Now(),
+	}
+
+	for _, stage := range u.stages {
+		labels := prometheus.Labels{"namespace": terraform.Namespace, "name": terraform.Name, "stage": stage.Name}
+		runnerStageDurationSeconds.With(labels).Set(stage.Duration.Seconds())
+		if stage.CPUTime != nil {
+			runnerStageCPUSeconds.With(labels).Set(stage.CPUTime.Seconds())
+		}
+		if stage.PeakMemory != nil {
+			runnerPeakMemoryBytes.With(labels).Set(float64(stage.PeakMemory.Value()))
+		}
+	}
+}
+
+// deleteResourceUsageMetrics deletes the metrics of a deleted Terraform
+// object.
+func deleteResourceUsageMetrics(terraform infrav1.Terraform) {
+	labels := prometheus.Labels{"namespace": terraform.Namespace, "name": terraform.Name}
+	runnerStageDurationSeconds.DeletePartialMatch(labels)
+	runnerStageCPUSeconds.DeletePartialMatch(labels)
+	runnerPeakMemoryBytes.DeletePartialMatch(labels)
+}
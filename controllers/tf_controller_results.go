@@ -0,0 +1,438 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/fluxcd/pkg/apis/meta"
+	tfjson "github.com/hashicorp/terraform-json"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/runner"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	resultsJUnitKey           = "junit.xml"
+	resultsSARIFKey           = "results.sarif"
+	resultsRevisionAnnotation = "infra.contrib.fluxcd.io/revision"
+
+	// resultsValidateStage is the stage of the validation of the configuration,
+	// which has no condition of its own.
+	resultsValidateStage = "Validate"
+
+	resultsBucketAccessKey     = "accesskey"
+	resultsBucketSecretKey     = "secretkey"
+	resultsBucketDefaultRegion = "us-east-1"
+)
+
+// exportResults writes the outcome of each stage of the run, as recorded in
+// the status of the Terraform object, to the results ConfigMap, and to the
+// results bucket when there is one. A failed export does not fail the
+// reconciliation.
+func (r *TerraformReconciler) exportResults(ctx context.Context, runnerClient runner.RunnerClient, terraform infrav1.Terraform, revision string) {
+	log := ctrl.LoggerFrom(ctx)
+	spec := terraform.Spec.ResultsExport
+	now := time.Now()
+
+	data := map[string]string{}
+	if spec.HasFormat(infrav1.ResultsFormatJUnit) {
+		report, err := junitReport(terraform, revision, now)
+		if err != nil {
+			log.Error(err, "unable to generate the JUnit report")
+			return
+		}
+		data[resultsJUnitKey] = report
+	}
+	if spec.HasFormat(infrav1.ResultsFormatSARIF) {
+		report, err := sarifReport(terraform, revision)
+		if err != nil {
+			log.Error(err, "unable to generate the SARIF report")
+			return
+		}
+		data[resultsSARIFKey] = report
+	}
+
+	_, err := runnerClient.ExportResults(ctx, &runner.ExportResultsRequest{
+		Namespace:     terraform.Namespace,
+		Name:          terraform.Name,
+		ConfigMapName: spec.GetConfigMapName(&terraform),
+		Uuid:          string(terraform.UID),
+		Data:          data,
+		Annotations:   map[string]string{resultsRevisionAnnotation: revision},
+	})
+	if err != nil {
+		log.Error(err, "unable to export results")
+	}
+
+	if spec.Bucket != nil {
+		if err := r.uploadResults(ctx, terraform, revision, now, data); err != nil {
+			log.Error(err, "unable to upload the results to the bucket", "bucket", spec.Bucket.BucketName)
+		}
+	}
+}
+
+// uploadResults uploads the reports of the run to the results bucket, under
+// <prefix>/<namespace>/<name>/<time of the run>/.
+func (r *TerraformReconciler) uploadResults(ctx context.Context, terraform infrav1.Terraform, revision string, now time.Time, data map[string]string) error {
+	bucket := terraform.Spec.ResultsExport.Bucket
+
+	var accessKey, secretKey string
+	if bucket.SecretRef != nil {
+		var secret corev1.Secret
+		secretName := types.NamespacedName{Namespace: terraform.Namespace, Name: bucket.SecretRef.Name}
+		if err := r.Client.Get(ctx, secretName, &secret); err != nil {
+			return fmt.Errorf("unable to get the credentials of the bucket: %w", err)
+		}
+		accessKey = string(secret.Data[resultsBucketAccessKey])
+		secretKey = string(secret.Data[resultsBucketSecretKey])
+	}
+
+	client := resultsBucketClient(bucket, accessKey, secretKey)
+	keyPrefix := resultsBucketKeyPrefix(bucket, terraform, now)
+	for _, name := range []string{resultsJUnitKey, resultsSARIFKey} {
+		report, ok := data[name]
+		if !ok {
+			continue
+		}
+		_, err := client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket:   aws.String(bucket.BucketName),
+			Key:      aws.String(keyPrefix + name),
+			Body:     strings.NewReader(report),
+			Metadata: map[string]string{"revision": revision},
+		})
+		if err != nil {
+			return fmt.Errorf("unable to upload %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// resultsBucketClient returns the client of the S3 compatible API of the
+// results bucket.
+func resultsBucketClient(bucket *infrav1.ResultsBucketSpec, accessKey, secretKey string) *s3.Client {
+	scheme := "https://"
+	if bucket.Insecure {
+		scheme = "http://"
+	}
+	endpoint := bucket.Endpoint
+	if !strings.Contains(endpoint, "://") {
+		endpoint = scheme + endpoint
+	}
+	region := bucket.Region
+	if region == "" {
+		region = resultsBucketDefaultRegion
+	}
+
+	options := s3.Options{
+		Region:           region,
+		EndpointResolver: s3.EndpointResolverFromURL(endpoint),
+		UsePathStyle:     true,
+	}
+	if accessKey != "" || secretKey != "" {
+		options.Credentials = credentials.NewStaticCredentialsProvider(accessKey, secretKey, "")
+	} else {
+		options.Credentials = aws.AnonymousCredentials{}
+	}
+	return s3.New(options)
+}
+
+// resultsBucketKeyPrefix returns the prefix of the keys of the reports of a
+// run in the results bucket.
+func resultsBucketKeyPrefix(bucket *infrav1.ResultsBucketSpec, terraform infrav1.Terraform, now time.Time) string {
+	prefix := strings.Trim(bucket.Prefix, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	return fmt.Sprintf("%s%s/%s/%s/", prefix, terraform.Namespace, terraform.Name, now.UTC().Format("20060102T150405Z"))
+}
+
+// stageConditions returns the conditions of the Terraform object which
+// record the outcome of a stage, that is all but the Ready condition.
+func stageConditions(terraform infrav1.Terraform) []metav1.Condition {
+	var conditions []metav1.Condition
+	for _, condition := range terraform.Status.Conditions {
+		if condition.Type != meta.ReadyCondition {
+			conditions = append(conditions, condition)
+		}
+	}
+	return conditions
+}
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Name       string           `xml:"name,attr"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Timestamp  string          `xml:"timestamp,attr"`
+	Properties []junitProperty `xml:"properties>property"`
+	TestCases  []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// junitReport reports the validation of the configuration and every stage of
+// the run as test suites. The test case of a stage fails when its condition
+// is false and is skipped when its outcome is unknown.
+func junitReport(terraform infrav1.Terraform, revision string, now time.Time) (string, error) {
+	name := terraform.Namespace + "/" + terraform.Name
+	timestamp := now.UTC().Format(time.RFC3339)
+	properties := []junitProperty{
+		{Name: "revision", Value: revision},
+		{Name: "workspace", Value: terraform.WorkspaceName()},
+	}
+
+	report := junitTestSuites{Name: "tf-controller"}
+	if testCases := validateTestCases(terraform, name); len(testCases) > 0 {
+		suite := junitTestSuite{
+			Name:       name + "/" + resultsValidateStage,
+			Timestamp:  timestamp,
+			Properties: properties,
+			TestCases:  testCases,
+		}
+		for _, testCase := range testCases {
+			if testCase.Failure != nil {
+				suite.Failures++
+			}
+		}
+		suite.Tests = len(testCases)
+		report.TestSuites = append(report.TestSuites, suite)
+	}
+
+	for _, condition := range stageConditions(terraform) {
+		suite := junitTestSuite{
+			Name:       name + "/" + condition.Type,
+			Tests:      1,
+			Timestamp:  timestamp,
+			Properties: properties,
+		}
+		testCase := junitTestCase{
+			Name:      condition.Type,
+			ClassName: name,
+		}
+		switch condition.Status {
+		case metav1.ConditionFalse:
+			testCase.Failure = &junitMessage{Message: condition.Reason, Type: condition.Reason, Text: condition.Message}
+			suite.Failures++
+		case metav1.ConditionUnknown:
+			testCase.Skipped = &junitMessage{Message: condition.Message}
+			suite.Skipped++
+		default:
+			testCase.SystemOut = condition.Message
+		}
+		suite.TestCases = []junitTestCase{testCase}
+		report.TestSuites = append(report.TestSuites, suite)
+	}
+
+	out, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return xml.Header + string(out) + "\n", nil
+}
+
+// validateTestCases returns the test cases of the validation of the
+// configuration: a failed one for each failed validation rule of a variable
+// and each error of Terraform in a file of the configuration, or a passed
+// one when the configuration was planned without any. There are none when
+// the configuration was not planned yet.
+func validateTestCases(terraform infrav1.Terraform, name string) []junitTestCase {
+	var testCases []junitTestCase
+	for _, validationError := range terraform.Status.VariableValidationErrors {
+		testCases = append(testCases, junitTestCase{
+			Name:      "variable " + validationError.Variable,
+			ClassName: name,
+			Failure:   &junitMessage{Message: infrav1.VariableValidationFailedReason, Type: infrav1.VariableValidationFailedReason, Text: validationError.Message},
+		})
+	}
+	for _, terraformError := range terraform.Status.Errors {
+		if terraformError.File == "" || terraformError.Severity != string(tfjson.DiagnosticSeverityError) {
+			continue
+		}
+		testCases = append(testCases, junitTestCase{
+			Name:      fmt.Sprintf("%s:%d", terraformError.File, terraformError.Line),
+			ClassName: name,
+			Failure:   &junitMessage{Message: terraformError.Summary, Type: terraformError.Severity, Text: terraformError.Detail},
+		})
+	}
+
+	if len(testCases) == 0 && apimeta.IsStatusConditionTrue(terraform.Status.Conditions, infrav1.ConditionTypePlan) {
+		testCases = append(testCases, junitTestCase{
+			Name:      "configuration",
+			ClassName: name,
+		})
+	}
+	return testCases
+}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool       sarifTool         `json:"tool"`
+	Results    []sarifResult     `json:"results"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine   int32 `json:"startLine"`
+	StartColumn int32 `json:"startColumn,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// sarifReport reports every failed stage, like the post-planning webhooks
+// checking the policies, as an error result located at the path of the
+// Terraform program in the source, and every diagnostic of Terraform as a
+// result located in its file.
+func sarifReport(terraform infrav1.Terraform, revision string) (string, error) {
+	path := terraform.Spec.Path
+	if path == "" {
+		path = "."
+	}
+
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "tf-controller",
+			InformationURI: "https://github.com/weaveworks/tf-controller",
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+		Properties: map[string]string{
+			"terraform": terraform.Namespace + "/" + terraform.Name,
+			"revision":  revision,
+		},
+	}
+
+	rules := map[string]bool{}
+	addResult := func(ruleID, description, level, message string, location sarifPhysicalLocation) {
+		if !rules[ruleID] {
+			rules[ruleID] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+				ID:               ruleID,
+				ShortDescription: sarifMessage{Text: description},
+			})
+		}
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    ruleID,
+			Level:     level,
+			Message:   sarifMessage{Text: message},
+			Locations: []sarifLocation{{PhysicalLocation: location}},
+		})
+	}
+	programLocation := sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: path}}
+
+	for _, condition := range stageConditions(terraform) {
+		if condition.Status != metav1.ConditionFalse {
+			continue
+		}
+		addResult(condition.Type+"/"+condition.Reason, condition.Type+" failed: "+condition.Reason, "error", condition.Message, programLocation)
+	}
+
+	for _, validationError := range terraform.Status.VariableValidationErrors {
+		addResult(resultsValidateStage+"/"+infrav1.VariableValidationFailedReason, "A validation rule of a variable failed", "error",
+			fmt.Sprintf("variable %s: %s", validationError.Variable, validationError.Message), programLocation)
+	}
+
+	for _, terraformError := range terraform.Status.Errors {
+		level, description := "error", "Terraform reported an error"
+		if terraformError.Severity != string(tfjson.DiagnosticSeverityError) {
+			level, description = "warning", "Terraform reported a warning"
+		}
+		location := programLocation
+		if terraformError.File != "" {
+			location = sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: terraformError.File}}
+			if terraformError.Line > 0 {
+				location.Region = &sarifRegion{StartLine: terraformError.Line, StartColumn: terraformError.Column}
+			}
+		}
+		message := terraformError.Summary
+		if terraformError.Detail != "" {
+			message += ": " + terraformError.Detail
+		}
+		addResult("Terraform/"+level, description, level, message, location)
+	}
+
+	out, err := json.MarshalIndent(sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []sarifRun{run},
+	}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
@@ -0,0 +1,197 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	eventv1 "github.com/fluxcd/pkg/apis/event/v1beta1"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/planner/provider"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	revisionDiffTokenKey = "token"
+
+	defaultRevisionDiffMaxCommits      = 20
+	defaultRevisionDiffMaxChangedFiles = 100
+
+	// revisionDiffEventFiles is the number of changed files listed in the
+	// event of a revision diff, the others are in the status.
+	revisionDiffEventFiles = 10
+)
+
+// reconcileRevisionDiff records what a new revision of the source changes
+// since the last applied revision, in the status and in an event. It
+// returns whether the status changed. A revision diff is only informative,
+// failing to compare the revisions does not fail the reconciliation.
+func (r *TerraformReconciler) reconcileRevisionDiff(ctx context.Context, terraform infrav1.Terraform, sourceObj sourcev1.Source) (infrav1.Terraform, bool) {
+	log := ctrl.LoggerFrom(ctx)
+	if terraform.Spec.RevisionDiff == nil {
+		if terraform.Status.RevisionDiff == nil {
+			return terraform, false
+		}
+		terraform.Status.RevisionDiff = nil
+		return terraform, true
+	}
+
+	from, to := terraform.Status.LastAppliedRevision, sourceObj.GetArtifact().Revision
+	if from == "" || from == to {
+		// nothing was applied yet, or nothing changed since
+		if terraform.Status.RevisionDiff == nil {
+			return terraform, false
+		}
+		terraform.Status.RevisionDiff = nil
+		return terraform, true
+	}
+	if diff := terraform.Status.RevisionDiff; diff != nil && diff.From == from && diff.To == to {
+		return terraform, false
+	}
+
+	diff, err := r.diffRevisions(ctx, terraform, sourceObj, from, to)
+	if err != nil {
+		msg := fmt.Sprintf("Unable to compare the revision %s with the last applied revision %s: %s", to, from, err)
+		log.Error(err, "unable to compare the revisions", "from", from, "to", to)
+		r.event(ctx, terraform, to, eventv1.EventSeverityError, msg, nil)
+		// the diff of the previous revisions is not the one of this revision
+		if terraform.Status.RevisionDiff == nil {
+			return terraform, false
+		}
+		terraform.Status.RevisionDiff = nil
+		return terraform, true
+	}
+
+	terraform.Status.RevisionDiff = diff
+	r.event(ctx, terraform, to, eventv1.EventSeverityInfo, revisionDiffMessage(diff), nil)
+	return terraform, true
+}
+
+// diffRevisions compares two revisions of the GitRepository source of a
+// Terraform object with the API of its Git provider.
+func (r *TerraformReconciler) diffRevisions(ctx context.Context, terraform infrav1.Terraform, sourceObj sourcev1.Source, from, to string) (*infrav1.RevisionDiff, error) {
+	spec := terraform.Spec.RevisionDiff
+
+	repository, ok := sourceObj.(*sourcev1.GitRepository)
+	if !ok {
+		return nil, fmt.Errorf("revision diffs require a GitRepository source, not a %s", sourceObj.GetObjectKind().GroupVersionKind().Kind)
+	}
+	base, head := revisionSHA(from), revisionSHA(to)
+	if base == "" || head == "" {
+		return nil, fmt.Errorf("the revisions are not commits of a Git repository")
+	}
+
+	var secret corev1.Secret
+	secretKey := types.NamespacedName{Namespace: terraform.Namespace, Name: spec.SecretRef.Name}
+	if err := r.Client.Get(ctx, secretKey, &secret); err != nil {
+		return nil, fmt.Errorf("unable to get the Git provider token of the revision diff: %w", err)
+	}
+	token, ok := secret.Data[revisionDiffTokenKey]
+	if !ok || len(token) == 0 {
+		return nil, fmt.Errorf("the secret %s has no %s key for the revision diff", secretKey, revisionDiffTokenKey)
+	}
+
+	gitProvider, repo, err := provider.FromURL(
+		repository.Spec.URL,
+		provider.WithLogger(ctrl.LoggerFrom(ctx)),
+		provider.WithToken(provider.APITokenType, string(token)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the Git provider of %s: %w", repository.Spec.URL, err)
+	}
+	compare, ok := gitProvider.(provider.CompareProvider)
+	if !ok {
+		return nil, fmt.Errorf("the Git provider of %s does not compare commits", repository.Spec.URL)
+	}
+
+	comparison, err := compare.CompareCommits(ctx, repo, base, head)
+	if err != nil {
+		return nil, err
+	}
+
+	return newRevisionDiff(from, to, comparison, spec.MaxCommits, spec.MaxChangedFiles), nil
+}
+
+// newRevisionDiff returns the revision diff of a comparison, with its most
+// recent commits and its first changed files.
+func newRevisionDiff(from, to string, comparison *provider.Comparison, maxCommits, maxChangedFiles int) *infrav1.RevisionDiff {
+	if maxCommits <= 0 {
+		maxCommits = defaultRevisionDiffMaxCommits
+	}
+	if maxChangedFiles <= 0 {
+		maxChangedFiles = defaultRevisionDiffMaxChangedFiles
+	}
+
+	diff := &infrav1.RevisionDiff{
+		From:              from,
+		To:                to,
+		TotalCommits:      comparison.TotalCommits,
+		TotalChangedFiles: len(comparison.Files),
+	}
+	if diff.TotalCommits < len(comparison.Commits) {
+		diff.TotalCommits = len(comparison.Commits)
+	}
+
+	commits := comparison.Commits
+	if len(commits) > maxCommits {
+		commits = commits[len(commits)-maxCommits:]
+	}
+	for _, commit := range commits {
+		diff.Commits = append(diff.Commits, infrav1.RevisionDiffCommit{
+			SHA:     commit.Sha,
+			Message: commit.Message,
+			Author:  commit.Author,
+		})
+	}
+
+	files := comparison.Files
+	if len(files) > maxChangedFiles {
+		files = files[:maxChangedFiles]
+	}
+	diff.ChangedFiles = append(diff.ChangedFiles, files...)
+
+	return diff
+}
+
+// revisionDiffMessage is the message of the event of a revision diff.
+func revisionDiffMessage(diff *infrav1.RevisionDiff) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Revision %s includes %d commit(s) since the last applied revision %s, changing %d file(s)",
+		diff.To, diff.TotalCommits, diff.From, diff.TotalChangedFiles)
+
+	files := diff.ChangedFiles
+	if len(files) > revisionDiffEventFiles {
+		files = files[:revisionDiffEventFiles]
+	}
+	if len(files) > 0 {
+		fmt.Fprintf(&b, ": %s", strings.Join(files, ", "))
+		if diff.TotalChangedFiles > len(files) {
+			b.WriteString(", ...")
+		}
+	}
+
+	for _, commit := range diff.Commits {
+		fmt.Fprintf(&b, "\n%s %s", shortSHA(commit.SHA), commit.Message)
+	}
+	return b.String()
+}
+
+// revisionSHA returns the commit SHA of a revision of a GitRepository, e.g.
+// "abc1234ef..." for "main@sha1:abc1234ef...", or for the legacy
+// "main/abc1234ef...".
+func revisionSHA(revision string) string {
+	if i := strings.LastIndexAny(revision, ":/"); i >= 0 {
+		return revision[i+1:]
+	}
+	return revision
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
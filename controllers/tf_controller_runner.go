@@ -13,6 +13,7 @@ import (
 	"github.com/weaveworks/tf-controller/mtls"
 	"github.com/weaveworks/tf-controller/runner"
 	"google.golang.org/grpc"
+	coordinationv1 "k8s.io/api/coordination/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -115,7 +116,12 @@ func (r *TerraformReconciler) LookupOrCreateRunner(ctx context.Context, terrafor
 	traceLog.Info("Create a close connection function")
 	connClose := func() error { return conn.Close() }
 	traceLog.Info("Create a new Runner client")
-	runnerClient := runner.NewRunnerClient(conn)
+	runnerClient, protocolVersion, err := runner.NegotiateVersion(ctx, runner.NewRunnerClient(conn))
+	if err != nil {
+		traceLog.Error(err, "Hit an error")
+		return nil, connClose, err
+	}
+	log.Info("negotiated the runner protocol version", "runner-protocol-version", protocolVersion)
 	traceLog.Info("Return the client and close connection function")
 	return runnerClient, connClose, nil
 }
@@ -156,6 +162,14 @@ func (r *TerraformReconciler) getRunnerConnection(ctx context.Context, tlsSecret
 
 func (r *TerraformReconciler) runnerPodSpec(terraform infrav1.Terraform, tlsSecretName string) v1.PodSpec {
 	serviceAccountName := terraform.Spec.ServiceAccountName
+	templateEnv := terraform.Spec.RunnerPodTemplate.Spec.Env
+	templateEnvFrom := terraform.Spec.RunnerPodTemplate.Spec.EnvFrom
+	if terraform.IsSimulated() {
+		// the runner in simulation never gets the credentials of the object
+		serviceAccountName = terraform.Spec.Simulation.ServiceAccountName
+		templateEnv = terraform.Spec.Simulation.Env
+		templateEnvFrom = nil
+	}
 	if serviceAccountName == "" {
 		serviceAccountName = "tf-runner"
 	}
@@ -190,7 +204,41 @@ func (r *TerraformReconciler) runnerPodSpec(terraform infrav1.Terraform, tlsSecr
 		}
 	}
 
-	for _, env := range terraform.Spec.RunnerPodTemplate.Spec.Env {
+	if proxy := terraform.Spec.RunnerPodTemplate.Spec.Proxy; proxy != nil {
+		for envName, envValue := range map[string]string{
+			"HTTP_PROXY":  proxy.HTTPProxy,
+			"HTTPS_PROXY": proxy.HTTPSProxy,
+			"NO_PROXY":    proxy.NoProxy,
+		} {
+			if envValue != "" {
+				envvarsMap[envName] = v1.EnvVar{
+					Name:  envName,
+					Value: envValue,
+				}
+			}
+		}
+	}
+
+	if terraform.Spec.RunnerPodTemplate.Spec.TrustedCABundle != nil {
+		// Go programs, like Terraform and its providers, load the certificates
+		// of every directory of SSL_CERT_DIR in addition to the system bundle.
+		envvarsMap["SSL_CERT_DIR"] = v1.EnvVar{
+			Name:  "SSL_CERT_DIR",
+			Value: "/etc/ssl/certs:" + trustedCABundleMountPath,
+		}
+	}
+
+	windows := runnerPodOS(terraform) == v1.Windows
+	if windows {
+		for envName, envValue := range windowsRunnerEnv() {
+			envvarsMap[envName] = v1.EnvVar{
+				Name:  envName,
+				Value: envValue,
+			}
+		}
+	}
+
+	for _, env := range templateEnv {
 		envvarsMap[env.Name] = env
 	}
 
@@ -233,18 +281,71 @@ func (r *TerraformReconciler) runnerPodSpec(terraform infrav1.Terraform, tlsSecr
 		podVolumeMounts = append(podVolumeMounts, terraform.Spec.RunnerPodTemplate.Spec.VolumeMounts...)
 	}
 
-	return v1.PodSpec{
+	if caBundle := terraform.Spec.RunnerPodTemplate.Spec.TrustedCABundle; caBundle != nil {
+		podVolumes = append(podVolumes, trustedCABundleVolume(*caBundle))
+		podVolumeMounts = append(podVolumeMounts, v1.VolumeMount{
+			Name:      trustedCABundleVolumeName,
+			MountPath: trustedCABundleMountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	if r.RunnerSourceCacheHostPath != "" {
+		podVolumes = append(podVolumes, sourceCacheVolume(r.RunnerSourceCacheHostPath))
+		podVolumeMounts = append(podVolumeMounts, v1.VolumeMount{
+			Name:      sourceCacheVolumeName,
+			MountPath: sourceCacheMountPath,
+		})
+	}
+
+	if backendConfig := terraform.Spec.BackendConfig; backendConfig != nil && backendConfig.KubeConfig != nil {
+		podVolumes = append(podVolumes, backendKubeConfigVolume(*backendConfig.KubeConfig))
+		podVolumeMounts = append(podVolumeMounts, v1.VolumeMount{
+			Name:      backendKubeConfigVolumeName,
+			MountPath: backendKubeConfigMountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	runtimeClassName := terraform.Spec.RunnerPodTemplate.Spec.RuntimeClassName
+	// the default runtime class, like the one of gVisor, is for the Linux nodes
+	if defaultRuntimeClassName := r.runnerRuntimeClassName(); runtimeClassName == nil && defaultRuntimeClassName != "" && !windows {
+		runtimeClassName = &defaultRuntimeClassName
+	}
+
+	var resources v1.ResourceRequirements
+	if terraform.Spec.RunnerPodTemplate.Spec.Resources != nil {
+		resources = *terraform.Spec.RunnerPodTemplate.Spec.Resources
+	}
+
+	image := getRunnerPodImage(terraform.Spec.RunnerPodTemplate.Spec.Image)
+	// TODO: this security context might break OpenShift because of SCC. We need verification.
+	// TODO how to support it via Spec or Helm Chart
+	securityContext := &v1.SecurityContext{
+		Capabilities: &v1.Capabilities{
+			Drop: []v1.Capability{"ALL"},
+		},
+		AllowPrivilegeEscalation: &vFalse,
+		RunAsNonRoot:             &vTrue,
+		RunAsUser:                &vUser,
+		SeccompProfile: &v1.SeccompProfile{
+			Type: v1.SeccompProfileTypeRuntimeDefault,
+		},
+		ReadOnlyRootFilesystem: &vTrue,
+	}
+	if windows {
+		image = getWindowsRunnerPodImage(terraform.Spec.RunnerPodTemplate.Spec.Image)
+		securityContext = windowsRunnerSecurityContext()
+	}
+
+	spec := v1.PodSpec{
 		TerminationGracePeriodSeconds: gracefulTermPeriod,
 		InitContainers:                terraform.Spec.RunnerPodTemplate.Spec.InitContainers,
 		Containers: []v1.Container{
 			{
-				Name: "tf-runner",
-				Args: []string{
-					"--grpc-port", fmt.Sprintf("%d", r.RunnerGRPCPort),
-					"--tls-secret-name", tlsSecretName,
-					"--grpc-max-message-size", fmt.Sprintf("%d", r.RunnerGRPCMaxMessageSize),
-				},
-				Image:           getRunnerPodImage(terraform.Spec.RunnerPodTemplate.Spec.Image),
+				Name:            "tf-runner",
+				Args:            r.runnerArgs(tlsSecretName),
+				Image:           image,
 				ImagePullPolicy: v1.PullIfNotPresent,
 				Ports: []v1.ContainerPort{
 					{
@@ -252,23 +353,11 @@ func (r *TerraformReconciler) runnerPodSpec(terraform infrav1.Terraform, tlsSecr
 						ContainerPort: int32(r.RunnerGRPCPort),
 					},
 				},
-				Env:     envvars,
-				EnvFrom: terraform.Spec.RunnerPodTemplate.Spec.EnvFrom,
-				// TODO: this security context might break OpenShift because of SCC. We need verification.
-				// TODO how to support it via Spec or Helm Chart
-				SecurityContext: &v1.SecurityContext{
-					Capabilities: &v1.Capabilities{
-						Drop: []v1.Capability{"ALL"},
-					},
-					AllowPrivilegeEscalation: &vFalse,
-					RunAsNonRoot:             &vTrue,
-					RunAsUser:                &vUser,
-					SeccompProfile: &v1.SeccompProfile{
-						Type: v1.SeccompProfileTypeRuntimeDefault,
-					},
-					ReadOnlyRootFilesystem: &vTrue,
-				},
-				VolumeMounts: podVolumeMounts,
+				Env:             envvars,
+				EnvFrom:         templateEnvFrom,
+				Resources:       resources,
+				SecurityContext: securityContext,
+				VolumeMounts:    podVolumeMounts,
 			},
 		},
 		Volumes:            podVolumes,
@@ -277,7 +366,43 @@ func (r *TerraformReconciler) runnerPodSpec(terraform infrav1.Terraform, tlsSecr
 		Affinity:           terraform.Spec.RunnerPodTemplate.Spec.Affinity,
 		Tolerations:        terraform.Spec.RunnerPodTemplate.Spec.Tolerations,
 		HostAliases:        terraform.Spec.RunnerPodTemplate.Spec.HostAliases,
+		DNSPolicy:          terraform.Spec.RunnerPodTemplate.Spec.DNSPolicy,
+		DNSConfig:          terraform.Spec.RunnerPodTemplate.Spec.DNSConfig,
+		RuntimeClassName:   runtimeClassName,
+	}
+	if windows {
+		setWindowsRunnerPod(&spec)
 	}
+	return spec
+}
+
+const (
+	trustedCABundleVolumeName = "trusted-ca"
+	trustedCABundleMountPath  = "/etc/tf-runner/trusted-ca"
+)
+
+// trustedCABundleVolume projects the CA bundle key of the referenced
+// ConfigMap or Secret to a single file.
+func trustedCABundleVolume(caBundle infrav1.CABundleReference) v1.Volume {
+	key := caBundle.Key
+	if key == "" {
+		key = "ca.crt"
+	}
+	items := []v1.KeyToPath{{Key: key, Path: "ca.crt"}}
+
+	volume := v1.Volume{Name: trustedCABundleVolumeName}
+	if caBundle.Kind == "Secret" {
+		volume.VolumeSource.Secret = &v1.SecretVolumeSource{
+			SecretName: caBundle.Name,
+			Items:      items,
+		}
+	} else {
+		volume.VolumeSource.ConfigMap = &v1.ConfigMapVolumeSource{
+			LocalObjectReference: v1.LocalObjectReference{Name: caBundle.Name},
+			Items:                items,
+		}
+	}
+	return volume
 }
 
 func (r *TerraformReconciler) reconcileRunnerPod(ctx context.Context, terraform infrav1.Terraform, tlsSecret *v1.Secret, revision string) (string, error) {
@@ -295,7 +420,7 @@ func (r *TerraformReconciler) reconcileRunnerPod(ctx context.Context, terraform
 
 	const interval = time.Second * 15
 	traceLog.Info("Set interval", "interval", interval)
-	timeout := r.RunnerCreationTimeout // default is 120 seconds
+	timeout := r.runnerCreationTimeout() // default is 120 seconds
 	traceLog.Info("Set timeout", "timeout", timeout)
 	tlsSecretName := tlsSecret.Name
 	traceLog.Info("Set tlsSecretName", "tlsSecretName", tlsSecretName)
@@ -309,6 +434,11 @@ func (r *TerraformReconciler) reconcileRunnerPod(ctx context.Context, terraform
 
 		newRunnerPod := *runnerPodTemplate.DeepCopy()
 		newRunnerPod.Spec = r.runnerPodSpec(terraform, tlsSecretName)
+		sandbox, err := r.runnerSandbox(ctx, terraform.Namespace)
+		if err != nil {
+			return err
+		}
+		sandbox.apply(&newRunnerPod.Spec)
 		if err := r.Create(ctx, &newRunnerPod); err != nil {
 			return err
 		}
@@ -364,11 +494,18 @@ func (r *TerraformReconciler) reconcileRunnerPod(ctx context.Context, terraform
 			podState = stateMustBeDeleted
 			gracefulTermPeriod = int64(1) // force kill = 1 second
 		} else if label != tlsSecretName {
-			// this is the old pod, created by the previous instance of the controller
+			// this is the old pod, created by the previous instance of the controller.
+			// It must not be deleted while running an operation started by that instance.
+			if err := r.checkRunnerOperationInFlight(ctx, terraform); err != nil {
+				return "", err
+			}
 			podState = stateMustBeDeleted
 			gracefulTermPeriod = *terraform.Spec.RunnerTerminationGracePeriodSeconds // honor the value from the spec
 		} else if runnerPod.DeletionTimestamp != nil {
 			podState = stateTerminating
+		} else if err := r.handleRunnerPodFailure(ctx, terraform, runnerPod); err != nil {
+			// the failed pod is deleted, the next reconciliation creates a new one
+			return "", err
 		} else if runnerPod.Status.Phase == v1.PodRunning {
 			podState = stateRunning
 		}
@@ -465,6 +602,46 @@ func (r *TerraformReconciler) reconcileRunnerPod(ctx context.Context, terraform
 	return runnerPod.Status.PodIP, nil
 }
 
+// runnerOperationInFlightError is returned when the runner pod is running an
+// operation started by a previous instance of the controller.
+type runnerOperationInFlightError struct {
+	operation  string
+	holder     string
+	retryAfter time.Duration
+}
+
+func (e *runnerOperationInFlightError) Error() string {
+	return fmt.Sprintf("the runner %s is running the %s operation started by a previous controller instance", e.holder, e.operation)
+}
+
+// checkRunnerOperationInFlight returns a runnerOperationInFlightError if the
+// operation lease of the runner of the Terraform object is held. The lease
+// is read from the API server, to not cache all the leases of the cluster.
+func (r *TerraformReconciler) checkRunnerOperationInFlight(ctx context.Context, terraform infrav1.Terraform) error {
+	var lease coordinationv1.Lease
+	leaseKey := types.NamespacedName{Namespace: terraform.Namespace, Name: runner.OperationLeaseName(terraform.Name)}
+	if err := r.APIReader.Get(ctx, leaseKey, &lease); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get the runner operation lease: %w", err)
+	}
+
+	held, left := runner.OperationLeaseHeld(&lease, time.Now())
+	if !held {
+		return nil
+	}
+
+	inFlight := &runnerOperationInFlightError{
+		operation:  lease.Annotations[runner.OperationAnnotation],
+		retryAfter: left,
+	}
+	if lease.Spec.HolderIdentity != nil {
+		inFlight.holder = *lease.Spec.HolderIdentity
+	}
+	return inFlight
+}
+
 // reconcileRunnerSecret reconciles the runner secret used for mTLS
 //
 // It should create the secret if it doesn't exist and then verify that the cert is valid
@@ -504,3 +681,19 @@ func runnerPodInstance(revision string) (string, error) {
 
 	return fmt.Sprintf("tf-runner-%s", gitSHA[0:8]), nil
 }
+
+// runnerArgs returns the arguments of the runner container.
+func (r *TerraformReconciler) runnerArgs(tlsSecretName string) []string {
+	args := []string{
+		"--grpc-port", fmt.Sprintf("%d", r.RunnerGRPCPort),
+		"--tls-secret-name", tlsSecretName,
+		"--grpc-max-message-size", fmt.Sprintf("%d", r.RunnerGRPCMaxMessageSize),
+	}
+	if r.FIPS {
+		args = append(args, "--fips")
+	}
+	if r.RunnerSourceCacheHostPath != "" {
+		args = append(args, "--source-cache-dir", sourceCacheMountPath)
+	}
+	return args
+}
@@ -0,0 +1,210 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=create;update;patch
+
+const (
+	runnerContainerName          = "tf-runner"
+	runnerDiagnosticsSuffix      = "-tf-runner-diagnostics"
+	runnerDiagnosticsTailLines   = int64(200)
+	runnerDiagnosticsLimitBytes  = int64(64 * 1024)
+	runnerDiagnosticsLogsMissing = "the logs of the runner are not available"
+	oomKilledReason              = "OOMKilled"
+)
+
+// runnerFailure is how the runner container of a runner pod terminated.
+type runnerFailure struct {
+	reason      string
+	exitCode    int32
+	message     string
+	finishedAt  metav1.Time
+	memoryLimit string
+	// previous is true when the container was restarted since, and its
+	// logs are those of the previous container.
+	previous bool
+}
+
+func (f *runnerFailure) oomKilled() bool {
+	return f.reason == oomKilledReason
+}
+
+// runnerPodFailedError is returned when the runner pod crashed, or was
+// OOMKilled, and was deleted after its diagnostics were captured.
+type runnerPodFailedError struct {
+	failure         *runnerFailure
+	diagnosticsName string
+}
+
+func (e *runnerPodFailedError) Error() string {
+	if e.failure.oomKilled() {
+		limit := "no memory limit"
+		if e.failure.memoryLimit != "" {
+			limit = "a memory limit of " + e.failure.memoryLimit
+		}
+		return fmt.Sprintf("the runner pod was OOMKilled with %s: increase the memory limit in .spec.runnerPodTemplate.spec.resources, diagnostics in ConfigMap %s",
+			limit, e.diagnosticsName)
+	}
+	return fmt.Sprintf("the runner pod terminated with exit code %d (%s): diagnostics in ConfigMap %s",
+		e.failure.exitCode, e.failure.reason, e.diagnosticsName)
+}
+
+func (e *runnerPodFailedError) reason() string {
+	if e.failure.oomKilled() {
+		return infrav1.RunnerOOMKilledReason
+	}
+	return infrav1.RunnerCrashedReason
+}
+
+// getRunnerFailure returns how the runner container of the pod failed, or
+// nil if it did not. A container restarted after a failure counts as failed,
+// as it has lost the state of the run.
+func getRunnerFailure(pod v1.Pod) *runnerFailure {
+	failure := &runnerFailure{}
+	for _, container := range pod.Spec.Containers {
+		if container.Name == runnerContainerName {
+			if limit, ok := container.Resources.Limits[v1.ResourceMemory]; ok {
+				failure.memoryLimit = limit.String()
+			}
+		}
+	}
+
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != runnerContainerName {
+			continue
+		}
+
+		terminated := status.State.Terminated
+		if terminated == nil && status.LastTerminationState.Terminated != nil {
+			terminated = status.LastTerminationState.Terminated
+			failure.previous = true
+		}
+		if terminated == nil || (terminated.ExitCode == 0 && terminated.Reason != oomKilledReason) {
+			break
+		}
+
+		failure.reason = terminated.Reason
+		failure.exitCode = terminated.ExitCode
+		failure.message = terminated.Message
+		failure.finishedAt = terminated.FinishedAt
+		return failure
+	}
+
+	if pod.Status.Phase == v1.PodFailed {
+		// evicted, or failed before the container started
+		failure.reason = pod.Status.Reason
+		failure.message = pod.Status.Message
+		return failure
+	}
+
+	return nil
+}
+
+// handleRunnerPodFailure checks whether the runner pod of the Terraform
+// object crashed. If it did, it captures the termination reason and the last
+// logs of the runner into a ConfigMap, deletes the pod, and returns a
+// runnerPodFailedError.
+func (r *TerraformReconciler) handleRunnerPodFailure(ctx context.Context, terraform infrav1.Terraform, runnerPod v1.Pod) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	failure := getRunnerFailure(runnerPod)
+	if failure == nil {
+		return nil
+	}
+
+	failedErr := &runnerPodFailedError{failure: failure, diagnosticsName: terraform.Name + runnerDiagnosticsSuffix}
+	log.Info("the runner pod failed, capturing its diagnostics", "reason", failure.reason, "exit-code", failure.exitCode)
+
+	if err := r.writeRunnerDiagnostics(ctx, terraform, runnerPod, failure, failedErr.diagnosticsName); err != nil {
+		log.Error(err, "unable to write the runner diagnostics")
+	}
+
+	if err := r.Delete(ctx, &runnerPod,
+		client.GracePeriodSeconds(1), // force kill = 1 second
+		client.PropagationPolicy(metav1.DeletePropagationForeground),
+	); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete the failed runner pod: %w", err)
+	}
+
+	return failedErr
+}
+
+// checkRunnerPodFailure gets the runner pod of the Terraform object, and
+// handles its failure. It is used when a run fails, to tell a crashed runner
+// from a failed Terraform command.
+func (r *TerraformReconciler) checkRunnerPodFailure(ctx context.Context, terraform infrav1.Terraform) error {
+	var runnerPod v1.Pod
+	if err := r.Get(ctx, getRunnerPodObjectKey(terraform), &runnerPod); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	return r.handleRunnerPodFailure(ctx, terraform, runnerPod)
+}
+
+// writeRunnerDiagnostics writes the termination reason and the last logs of
+// the failed runner to a ConfigMap owned by the Terraform object. The
+// ConfigMap only keeps the diagnostics of the last failure.
+func (r *TerraformReconciler) writeRunnerDiagnostics(ctx context.Context, terraform infrav1.Terraform, runnerPod v1.Pod, failure *runnerFailure, name string) error {
+	logs := r.runnerLogs(ctx, runnerPod, failure.previous)
+
+	configMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: terraform.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		configMap.Data = map[string]string{
+			"pod":        runnerPod.Name,
+			"reason":     failure.reason,
+			"exitCode":   strconv.Itoa(int(failure.exitCode)),
+			"message":    failure.message,
+			"finishedAt": failure.finishedAt.UTC().Format(time.RFC3339),
+			"logs":       logs,
+		}
+		if failure.memoryLimit != "" {
+			configMap.Data["memoryLimit"] = failure.memoryLimit
+		}
+		return controllerutil.SetControllerReference(&terraform, configMap, r.Scheme)
+	})
+	return err
+}
+
+// runnerLogs returns the last lines of the logs of the runner container.
+func (r *TerraformReconciler) runnerLogs(ctx context.Context, runnerPod v1.Pod, previous bool) string {
+	if r.PodLogs == nil {
+		return runnerDiagnosticsLogsMissing
+	}
+
+	tailLines := runnerDiagnosticsTailLines
+	limitBytes := runnerDiagnosticsLimitBytes
+	stream, err := r.PodLogs.Pods(runnerPod.Namespace).GetLogs(runnerPod.Name, &v1.PodLogOptions{
+		Container:  runnerContainerName,
+		Previous:   previous,
+		TailLines:  &tailLines,
+		LimitBytes: &limitBytes,
+	}).Stream(ctx)
+	if err != nil {
+		return fmt.Sprintf("%s: %s", runnerDiagnosticsLogsMissing, err)
+	}
+	defer stream.Close()
+
+	logs, err := io.ReadAll(stream)
+	if err != nil {
+		return fmt.Sprintf("%s: %s", runnerDiagnosticsLogsMissing, err)
+	}
+	return string(logs)
+}
@@ -0,0 +1,128 @@
+package controllers
+
+import (
+	"os"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// windowsTempPath and windowsHomePath are the paths of the temp and home
+	// volumes in a Windows runner pod, whose mount paths without a drive,
+	// /tmp and /home/runner, are on the C: drive.
+	windowsTempPath = `C:\tmp`
+	windowsHomePath = `C:\home\runner`
+
+	// windowsRunnerUserName is the unprivileged user of the Windows images.
+	windowsRunnerUserName = "ContainerUser"
+
+	defaultWindowsRunnerPodImage = "ghcr.io/weaveworks/tf-runner-windows:latest"
+)
+
+// runnerPodOS returns the operating system of the runner pod of a Terraform
+// object, linux by default.
+func runnerPodOS(terraform infrav1.Terraform) v1.OSName {
+	if terraform.Spec.RunnerPodTemplate.Spec.OS == v1.Windows {
+		return v1.Windows
+	}
+	return v1.Linux
+}
+
+// getWindowsRunnerPodImage returns the image of a Windows runner pod: the one
+// of its template, or else the Windows runner image of the controller.
+func getWindowsRunnerPodImage(image string) string {
+	if image != "" {
+		return image
+	}
+	if image := os.Getenv("RUNNER_POD_WINDOWS_IMAGE"); image != "" {
+		return image
+	}
+	return defaultWindowsRunnerPodImage
+}
+
+// windowsRunnerEnv returns the environment variables pointing the runner,
+// terraform and its providers to the temp and home volumes on Windows, where
+// they are not found from TMPDIR and HOME.
+func windowsRunnerEnv() map[string]string {
+	return map[string]string{
+		"TMP":          windowsTempPath,
+		"TEMP":         windowsTempPath,
+		"HOME":         windowsHomePath,
+		"USERPROFILE":  windowsHomePath,
+		"APPDATA":      windowsHomePath + `\AppData\Roaming`,
+		"LOCALAPPDATA": windowsHomePath + `\AppData\Local`,
+	}
+}
+
+// windowsRunnerSecurityContext is the security context of the runner
+// container on Windows, which has none of the Linux settings of the one on
+// Linux.
+func windowsRunnerSecurityContext() *v1.SecurityContext {
+	vTrue := true
+	userName := windowsRunnerUserName
+	return &v1.SecurityContext{
+		RunAsNonRoot: &vTrue,
+		WindowsOptions: &v1.WindowsSecurityContextOptions{
+			RunAsUserName: &userName,
+		},
+	}
+}
+
+// setWindowsRunnerPod sets the operating system of a runner pod to Windows,
+// schedules it on the Windows nodes unless its node selector chooses the
+// operating system, and removes the Linux settings of the security contexts
+// of the pod and its containers, which the API server rejects on Windows.
+func setWindowsRunnerPod(spec *v1.PodSpec) {
+	spec.OS = &v1.PodOS{Name: v1.Windows}
+
+	if _, ok := spec.NodeSelector[v1.LabelOSStable]; !ok {
+		nodeSelector := map[string]string{v1.LabelOSStable: string(v1.Windows)}
+		for key, value := range spec.NodeSelector {
+			nodeSelector[key] = value
+		}
+		spec.NodeSelector = nodeSelector
+	}
+
+	if sc := spec.SecurityContext; sc != nil {
+		sc = sc.DeepCopy()
+		sc.SELinuxOptions = nil
+		sc.SeccompProfile = nil
+		sc.FSGroup = nil
+		sc.FSGroupChangePolicy = nil
+		sc.Sysctls = nil
+		sc.RunAsUser = nil
+		sc.RunAsGroup = nil
+		sc.SupplementalGroups = nil
+		spec.SecurityContext = sc
+	}
+
+	// the init containers may be the ones of the Terraform object, which
+	// must not be changed
+	spec.InitContainers = append([]v1.Container(nil), spec.InitContainers...)
+	for i := range spec.InitContainers {
+		removeLinuxSecurityContext(&spec.InitContainers[i])
+	}
+	for i := range spec.Containers {
+		removeLinuxSecurityContext(&spec.Containers[i])
+	}
+}
+
+// removeLinuxSecurityContext removes the Linux settings of the security
+// context of a container.
+func removeLinuxSecurityContext(container *v1.Container) {
+	if container.SecurityContext == nil {
+		return
+	}
+	sc := container.SecurityContext.DeepCopy()
+	sc.SELinuxOptions = nil
+	sc.SeccompProfile = nil
+	sc.Capabilities = nil
+	sc.ReadOnlyRootFilesystem = nil
+	sc.Privileged = nil
+	sc.AllowPrivilegeEscalation = nil
+	sc.ProcMount = nil
+	sc.RunAsUser = nil
+	sc.RunAsGroup = nil
+	container.SecurityContext = sc
+}
@@ -0,0 +1,160 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	v1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// runnerSandbox is how the runner pods of a namespace are isolated from the
+// node, whatever their Terraform objects set.
+type runnerSandbox struct {
+	runtimeClassName string
+	restricted       bool
+}
+
+// runnerSandbox returns the sandbox of the runner pods of the namespace, from
+// the flags of the controller and the TerraformNamespacePolicies. The runtime
+// class of the first policy setting one, by name, wins, as in the mutating
+// webhook.
+func (r *TerraformReconciler) runnerSandbox(ctx context.Context, namespace string) (runnerSandbox, error) {
+	sandbox := runnerSandbox{restricted: r.featureGate(infrav1.FeatureGateRestrictedRunnerPods, r.RestrictedRunnerPods)}
+
+	var policies infrav1.TerraformNamespacePolicyList
+	if err := r.APIReader.List(ctx, &policies, client.InNamespace(namespace)); err != nil {
+		if apimeta.IsNoMatchError(err) {
+			return sandbox, nil
+		}
+		return sandbox, fmt.Errorf("unable to list the namespace policies: %w", err)
+	}
+
+	sort.Slice(policies.Items, func(i, j int) bool {
+		return policies.Items[i].Name < policies.Items[j].Name
+	})
+	for _, policy := range policies.Items {
+		if sandbox.runtimeClassName == "" {
+			sandbox.runtimeClassName = policy.Spec.RunnerRuntimeClassName
+		}
+		sandbox.restricted = sandbox.restricted || policy.Spec.RestrictedRunnerPods
+	}
+	return sandbox, nil
+}
+
+// apply sets the runtime class of the sandbox on the runner pod spec, and
+// the settings of the restricted Pod Security Standard when it is restricted:
+// the host namespaces, the host ports and the volumes the standard does not
+// allow, such as hostPath, are removed from the pod.
+func (s runnerSandbox) apply(spec *v1.PodSpec) {
+	if s.runtimeClassName != "" {
+		runtimeClassName := s.runtimeClassName
+		spec.RuntimeClassName = &runtimeClassName
+	}
+	if !s.restricted {
+		return
+	}
+
+	spec.HostNetwork = false
+	spec.HostPID = false
+	spec.HostIPC = false
+
+	removed := map[string]bool{}
+	var volumes []v1.Volume
+	for _, volume := range spec.Volumes {
+		if !restrictedVolume(volume) {
+			removed[volume.Name] = true
+			continue
+		}
+		volumes = append(volumes, volume)
+	}
+	spec.Volumes = volumes
+
+	vTrue := true
+	if spec.SecurityContext == nil {
+		spec.SecurityContext = &v1.PodSecurityContext{}
+	}
+	spec.SecurityContext.RunAsNonRoot = &vTrue
+	if spec.SecurityContext.RunAsUser != nil && *spec.SecurityContext.RunAsUser == 0 {
+		spec.SecurityContext.RunAsUser = nil
+	}
+	spec.SecurityContext.SeccompProfile = &v1.SeccompProfile{Type: v1.SeccompProfileTypeRuntimeDefault}
+
+	// the init containers are the ones of the Terraform object, which must
+	// not be changed
+	spec.InitContainers = append([]v1.Container(nil), spec.InitContainers...)
+	for i := range spec.InitContainers {
+		restrictContainer(&spec.InitContainers[i], removed)
+	}
+	for i := range spec.Containers {
+		restrictContainer(&spec.Containers[i], removed)
+	}
+	if spec.OS != nil && spec.OS.Name == v1.Windows {
+		// the restricted Pod Security Standard exempts the Windows pods
+		// from its Linux settings
+		setWindowsRunnerPod(spec)
+	}
+}
+
+// restrictedVolume tells whether the restricted Pod Security Standard
+// allows the volume.
+func restrictedVolume(volume v1.Volume) bool {
+	source := volume.VolumeSource
+	return source.ConfigMap != nil ||
+		source.CSI != nil ||
+		source.DownwardAPI != nil ||
+		source.EmptyDir != nil ||
+		source.Ephemeral != nil ||
+		source.PersistentVolumeClaim != nil ||
+		source.Projected != nil ||
+		source.Secret != nil
+}
+
+// restrictContainer sets the security context of the container to the
+// settings of the restricted Pod Security Standard, and removes its host
+// ports and its mounts of the removed volumes.
+func restrictContainer(container *v1.Container, removed map[string]bool) {
+	var ports []v1.ContainerPort
+	for _, port := range container.Ports {
+		port.HostPort = 0
+		ports = append(ports, port)
+	}
+	container.Ports = ports
+
+	var mounts []v1.VolumeMount
+	for _, mount := range container.VolumeMounts {
+		if !removed[mount.Name] {
+			mounts = append(mounts, mount)
+		}
+	}
+	container.VolumeMounts = mounts
+
+	var devices []v1.VolumeDevice
+	for _, device := range container.VolumeDevices {
+		if !removed[device.Name] {
+			devices = append(devices, device)
+		}
+	}
+	container.VolumeDevices = devices
+
+	vFalse, vTrue := false, true
+	if container.SecurityContext == nil {
+		container.SecurityContext = &v1.SecurityContext{}
+	} else {
+		container.SecurityContext = container.SecurityContext.DeepCopy()
+	}
+	securityContext := container.SecurityContext
+	securityContext.Privileged = &vFalse
+	securityContext.AllowPrivilegeEscalation = &vFalse
+	securityContext.Capabilities = &v1.Capabilities{Drop: []v1.Capability{"ALL"}}
+	securityContext.RunAsNonRoot = &vTrue
+	if securityContext.RunAsUser != nil && *securityContext.RunAsUser == 0 {
+		securityContext.RunAsUser = nil
+	}
+	if securityContext.SeccompProfile == nil || securityContext.SeccompProfile.Type == v1.SeccompProfileTypeUnconfined {
+		securityContext.SeccompProfile = &v1.SeccompProfile{Type: v1.SeccompProfileTypeRuntimeDefault}
+	}
+}
@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/runner"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	v1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	sourceCacheVolumeName = "source-cache"
+	// sourceCacheMountPath is the directory of the source cache of the node
+	// in the runner pods.
+	sourceCacheMountPath = "/var/cache/tf-runner/sources"
+)
+
+// sourceCacheVolume returns the volume of the source cache of the nodes, at
+// the host path of the --runner-source-cache-host-path flag.
+func sourceCacheVolume(hostPath string) v1.Volume {
+	hostPathType := v1.HostPathDirectoryOrCreate
+	return v1.Volume{
+		Name: sourceCacheVolumeName,
+		VolumeSource: v1.VolumeSource{
+			HostPath: &v1.HostPathVolumeSource{
+				Path: hostPath,
+				Type: &hostPathType,
+			},
+		},
+	}
+}
+
+// sourceCacheDigest returns the digest the artifact of the source is cached
+// by on the nodes, or "" when it is not cached: without the source cache,
+// for the artifacts of the layered sources, which the controller makes, and
+// for the artifacts without a sha256 digest.
+func (r *TerraformReconciler) sourceCacheDigest(sourceObj sourcev1.Source) string {
+	if r.RunnerSourceCacheHostPath == "" {
+		return ""
+	}
+	if _, ok := sourceObj.(*layeredSource); ok {
+		return ""
+	}
+	artifact := sourceObj.GetArtifact()
+	if artifact == nil || !strings.HasPrefix(artifact.Digest, "sha256:") {
+		return ""
+	}
+	return artifact.Digest
+}
+
+// extractCachedSource extracts the artifact of the digest from the source
+// cache of the node of the runner, without downloading it. It returns nil
+// when the runner does not have it, or when it is too old to cache.
+func (r *TerraformReconciler) extractCachedSource(ctx context.Context, runnerClient runner.RunnerClient, terraform infrav1.Terraform, digest string) (*runner.UploadAndExtractReply, error) {
+	reply, err := runnerClient.UploadAndExtract(ctx, &runner.UploadAndExtractRequest{
+		Namespace: terraform.Namespace,
+		Name:      terraform.Name,
+		Path:      terraform.Spec.Path,
+		Digest:    digest,
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		ctrl.LoggerFrom(ctx).Info("extracted the artifact from the source cache", "digest", digest)
+		return reply, nil
+	case codes.NotFound, codes.Unimplemented:
+		return nil, nil
+	default:
+		return nil, err
+	}
+}
@@ -0,0 +1,260 @@
+package controllers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+)
+
+// layeredSource is the source of a Terraform object with source layers. Its
+// artifact has a revision made of the revisions of the source and of the
+// layers, so that a new revision of any of them is planned.
+type layeredSource struct {
+	sourcev1.Source
+	artifact *sourcev1.Artifact
+	layers   []sourceLayer
+}
+
+// sourceLayer is the artifact of a source layer, with the directory of it
+// to overlay on the directory of the artifact of the source.
+type sourceLayer struct {
+	artifact   *sourcev1.Artifact
+	path       string
+	targetPath string
+}
+
+func (s *layeredSource) GetArtifact() *sourcev1.Artifact {
+	return s.artifact
+}
+
+// reconcileSourceLayers returns the source of the Terraform object overlaid
+// with its source layers, and the revisions of the source and of the layers.
+// The source is returned as is when the object has no layers.
+func (r *TerraformReconciler) reconcileSourceLayers(ctx context.Context, terraform infrav1.Terraform, sourceObj sourcev1.Source) (sourcev1.Source, []infrav1.SourceLayerRevision, error) {
+	if len(terraform.Spec.SourceLayers) == 0 || sourceObj.GetArtifact() == nil {
+		return sourceObj, nil, nil
+	}
+
+	layered := &layeredSource{Source: sourceObj}
+	revisions := []infrav1.SourceLayerRevision{{
+		SourceRef: terraform.Spec.SourceRef,
+		Revision:  sourceObj.GetArtifact().Revision,
+	}}
+	for _, layer := range terraform.Spec.SourceLayers {
+		layerObj, err := r.getSourceByRef(ctx, terraform, layer.SourceRef)
+		if err != nil {
+			return sourceObj, nil, fmt.Errorf("unable to get source layer %s: %w", layer.SourceRef.String(), err)
+		}
+		if layerObj.GetArtifact() == nil {
+			return sourceObj, nil, fmt.Errorf("source layer %s is not ready, artifact not found", layer.SourceRef.String())
+		}
+
+		targetPath := layer.TargetPath
+		if targetPath == "" {
+			targetPath = terraform.Spec.Path
+		}
+		layered.layers = append(layered.layers, sourceLayer{
+			artifact:   layerObj.GetArtifact(),
+			path:       layer.Path,
+			targetPath: targetPath,
+		})
+		revisions = append(revisions, infrav1.SourceLayerRevision{
+			SourceRef: layer.SourceRef,
+			Revision:  layerObj.GetArtifact().Revision,
+		})
+	}
+
+	layered.artifact = sourceObj.GetArtifact().DeepCopy()
+	layered.artifact.Revision = layeredRevision(revisions, layered.layers)
+	layered.artifact.Digest = ""
+	layered.artifact.Size = nil
+	return layered, revisions, nil
+}
+
+// layeredRevision returns the revision of a layered artifact, in the
+// <reference>@sha256:<digest> format of the revisions of Flux, with the
+// reference of the source and the digest of the revisions and paths of the
+// source and of the layers.
+func layeredRevision(revisions []infrav1.SourceLayerRevision, layers []sourceLayer) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s\n", revisions[0].SourceRef.String(), revisions[0].Revision)
+	for i, layer := range layers {
+		fmt.Fprintf(h, "%s %s %s %s\n", revisions[i+1].SourceRef.String(), revisions[i+1].Revision, layer.path, layer.targetPath)
+	}
+
+	reference := "layered"
+	if ref, _, ok := strings.Cut(revisions[0].Revision, "@"); ok && ref != "" {
+		reference = ref
+	}
+	return fmt.Sprintf("%s@sha256:%x", reference, h.Sum(nil))
+}
+
+// downloadSource downloads the artifact of the source, overlaid with the
+// artifacts of its layers, which are downloaded in parallel.
+func (r *TerraformReconciler) downloadSource(sourceObj sourcev1.Source) (*bytes.Buffer, error) {
+	layered, ok := sourceObj.(*layeredSource)
+	if !ok {
+		return r.downloadAsBytes(sourceObj.GetArtifact())
+	}
+
+	artifacts := []*sourcev1.Artifact{layered.Source.GetArtifact()}
+	for _, layer := range layered.layers {
+		artifacts = append(artifacts, layer.artifact)
+	}
+	bufs := make([]*bytes.Buffer, len(artifacts))
+	errs := make([]error, len(artifacts))
+	var wg sync.WaitGroup
+	for i, artifact := range artifacts {
+		wg.Add(1)
+		go func(i int, artifact *sourcev1.Artifact) {
+			defer wg.Done()
+			bufs[i], errs[i] = r.downloadAsBytes(artifact)
+		}(i, artifact)
+	}
+	wg.Wait()
+
+	if errs[0] != nil {
+		return nil, errs[0]
+	}
+	overlays := make([]overlay, 0, len(layered.layers))
+	for i, layer := range layered.layers {
+		if err := errs[i+1]; err != nil {
+			return nil, fmt.Errorf("source layer %s: %w", layer.artifact.Revision, err)
+		}
+		overlays = append(overlays, overlay{tarGz: bufs[i+1].Bytes(), path: layer.path, targetPath: layer.targetPath})
+	}
+	return overlayArtifacts(bufs[0].Bytes(), overlays)
+}
+
+// overlay is the artifact of a layer, as a tar.gz, with the directory of it
+// to overlay on the directory of the base artifact.
+type overlay struct {
+	tarGz      []byte
+	path       string
+	targetPath string
+}
+
+type tarEntry struct {
+	header  *tar.Header
+	content []byte
+}
+
+// overlayArtifacts overlays the artifacts on the base artifact, in order,
+// and returns the result as a tar.gz with the entries sorted by path, for
+// the same artifacts to always give the same result.
+func overlayArtifacts(base []byte, overlays []overlay) (*bytes.Buffer, error) {
+	entries := map[string]tarEntry{}
+	if err := readTarGz(base, func(name string, entry tarEntry) error {
+		entries[name] = entry
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("unable to read the artifact of the source: %w", err)
+	}
+
+	for _, o := range overlays {
+		prefix := cleanArtifactPath(o.path)
+		targetPath := cleanArtifactPath(o.targetPath)
+		if err := readTarGz(o.tarGz, func(name string, entry tarEntry) error {
+			if entry.header.Typeflag != tar.TypeReg && entry.header.Typeflag != tar.TypeDir {
+				// only files and directories are overlaid
+				return nil
+			}
+			if prefix != "" {
+				if name != prefix && !strings.HasPrefix(name, prefix+"/") {
+					return nil
+				}
+				name = strings.TrimPrefix(strings.TrimPrefix(name, prefix), "/")
+			}
+			name = cleanArtifactPath(path.Join(targetPath, name))
+			if name == "" {
+				return nil
+			}
+			entries[name] = entry
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("unable to read the artifact of a source layer: %w", err)
+		}
+	}
+
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, name := range names {
+		entry := entries[name]
+		header := *entry.header
+		header.Name = name
+		if header.Typeflag == tar.TypeDir {
+			header.Name += "/"
+		}
+		if err := tarWriter.WriteHeader(&header); err != nil {
+			return nil, err
+		}
+		if _, err := tarWriter.Write(entry.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return nil, err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, err
+	}
+	return &buf, nil
+}
+
+// readTarGz calls fn with each entry of the tar.gz, by path relative to the
+// root of the archive. Paths leaving the root are kept within it.
+func readTarGz(data []byte, fn func(name string, entry tarEntry) error) error {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := cleanArtifactPath(header.Name)
+		if name == "" {
+			continue
+		}
+		content, err := io.ReadAll(tarReader)
+		if err != nil {
+			return err
+		}
+		if err := fn(name, tarEntry{header: header, content: content}); err != nil {
+			return err
+		}
+	}
+}
+
+// cleanArtifactPath returns the path relative to the root of an artifact,
+// without a leading ./ or /.
+func cleanArtifactPath(p string) string {
+	p = path.Clean("/" + p)
+	return strings.TrimPrefix(p, "/")
+}
@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+//+kubebuilder:rbac:groups=source.toolkit.fluxcd.io,resources=gitrepositories,verbs=create;update;patch;delete
+
+var commitSHARegexp = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// pinnedSourceName is the name of the GitRepository pinned to the
+// sourceRevisionOverride of a Terraform object, in its namespace.
+func pinnedSourceName(terraform infrav1.Terraform) string {
+	return terraform.GetName() + "-pinned"
+}
+
+// pinnedSourceRef returns the reference of the GitRepository to check out
+// the revision override, as a commit, a Git reference or a tag.
+func pinnedSourceRef(revision string) *sourcev1.GitRepositoryRef {
+	switch {
+	case commitSHARegexp.MatchString(revision):
+		return &sourcev1.GitRepositoryRef{Commit: revision}
+	case strings.HasPrefix(revision, "refs/"):
+		return &sourcev1.GitRepositoryRef{Name: revision}
+	default:
+		return &sourcev1.GitRepositoryRef{Tag: revision}
+	}
+}
+
+// reconcileSourceOverride returns the source to plan and apply. With a
+// sourceRevisionOverride, it is a copy of the GitRepository source owned by
+// the Terraform object and pinned to the revision, which the
+// source-controller fetches the artifact of. Without, the pinned copy is
+// deleted and the source is returned as is.
+func (r *TerraformReconciler) reconcileSourceOverride(ctx context.Context, terraform infrav1.Terraform, sourceObj sourcev1.Source) (sourcev1.Source, error) {
+	pinned := &sourcev1.GitRepository{}
+	pinned.SetNamespace(terraform.GetNamespace())
+	pinned.SetName(pinnedSourceName(terraform))
+
+	revision := terraform.Spec.SourceRevisionOverride
+	if revision == "" {
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(pinned), pinned); err != nil {
+			return sourceObj, client.IgnoreNotFound(err)
+		}
+		if !metav1.IsControlledBy(pinned, &terraform) {
+			return sourceObj, nil
+		}
+		if err := r.Client.Delete(ctx, pinned); err != nil && !apierrors.IsNotFound(err) {
+			return sourceObj, fmt.Errorf("unable to delete the pinned source: %w", err)
+		}
+		return sourceObj, nil
+	}
+
+	repository, ok := sourceObj.(*sourcev1.GitRepository)
+	if !ok {
+		return sourceObj, fmt.Errorf("sourceRevisionOverride is only supported with a %s source, not %s",
+			sourcev1.GitRepositoryKind, terraform.Spec.SourceRef.Kind)
+	}
+	if repository.GetNamespace() != terraform.GetNamespace() && repository.Spec.SecretRef != nil {
+		return sourceObj, fmt.Errorf("sourceRevisionOverride cannot use the credentials of the %s source in namespace %s",
+			sourcev1.GitRepositoryKind, repository.GetNamespace())
+	}
+
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, pinned, func() error {
+		if !pinned.CreationTimestamp.IsZero() && !metav1.IsControlledBy(pinned, &terraform) {
+			return fmt.Errorf("%s %s/%s already exists and is not owned by the Terraform object",
+				sourcev1.GitRepositoryKind, pinned.GetNamespace(), pinned.GetName())
+		}
+		pinned.Spec = *repository.Spec.DeepCopy()
+		pinned.Spec.Reference = pinnedSourceRef(revision)
+		return controllerutil.SetControllerReference(&terraform, pinned, r.Scheme)
+	}); err != nil {
+		return sourceObj, fmt.Errorf("unable to create or update the pinned source: %w", err)
+	}
+
+	// Until the source-controller fetches the revision, the artifact of the
+	// pinned copy is the one of its previous reference, if any.
+	if pinned.Generation != pinned.Status.ObservedGeneration {
+		pinned.Status.Artifact = nil
+	}
+	return pinned, nil
+}
@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// startupQueue enqueues the Terraform objects when the controller starts, by
+// order of priority and at a limited rate, rather than in the random order
+// and all at once as the informer lists them. The create events of the
+// objects it enqueues are dropped by its predicate.
+type startupQueue struct {
+	client client.Reader
+	// rate is the number of objects enqueued per second, or 0 to enqueue
+	// them all at once.
+	rate   float64
+	events chan event.GenericEvent
+	// recover resets an object left in the middle of a reconciliation by
+	// the previous run of the controller, before it is enqueued.
+	recover func(ctx context.Context, terraform *infrav1.Terraform) (bool, error)
+
+	mu sync.Mutex
+	// listed holds the UIDs of the objects listed at startup, and is nil
+	// until they are listed.
+	listed map[types.UID]bool
+}
+
+func newStartupQueue(c client.Reader, rate float64) *startupQueue {
+	return &startupQueue{
+		client: c,
+		rate:   rate,
+		events: make(chan event.GenericEvent),
+	}
+}
+
+// Start lists the Terraform objects, recovers the ones interrupted by the
+// previous run of the controller, and enqueues them by order of priority. It
+// is run by the manager once the replica is elected, like the controller.
+func (q *startupQueue) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("startup-queue")
+
+	var list infrav1.TerraformList
+	if err := q.client.List(ctx, &list); err != nil {
+		return fmt.Errorf("unable to list the Terraform objects: %w", err)
+	}
+	terraforms := list.Items
+	if q.recover != nil {
+		recovered := 0
+		for i := range terraforms {
+			ok, err := q.recover(ctx, &terraforms[i])
+			if err != nil {
+				log.Error(err, "unable to recover the Terraform object", "terraform", client.ObjectKeyFromObject(&terraforms[i]))
+			}
+			if ok {
+				recovered++
+			}
+		}
+		if recovered > 0 {
+			log.Info("reset the Terraform objects interrupted during their reconciliation", "count", recovered)
+		}
+	}
+	sortByStartupOrder(terraforms)
+
+	listed := make(map[types.UID]bool, len(terraforms))
+	for _, terraform := range terraforms {
+		listed[terraform.UID] = true
+	}
+	q.mu.Lock()
+	q.listed = listed
+	q.mu.Unlock()
+
+	log.Info("enqueuing the Terraform objects by priority", "count", len(terraforms), "rate", q.rate)
+	var tick <-chan time.Time
+	if q.rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / q.rate))
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for i := range terraforms {
+		if tick != nil && i > 0 {
+			select {
+			case <-tick:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+		select {
+		case q.events <- event.GenericEvent{Object: &terraforms[i]}:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+	log.Info("enqueued the Terraform objects", "count", len(terraforms))
+	return nil
+}
+
+// predicate drops the create events of the objects enqueued at startup. The
+// informer emits them for all the objects when the controller starts, and
+// the objects it knows of before the listing are part of the listing.
+func (q *startupQueue) predicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			q.mu.Lock()
+			defer q.mu.Unlock()
+			return q.listed != nil && !q.listed[e.Object.GetUID()]
+		},
+	}
+}
+
+// sortByStartupOrder sorts the Terraform objects by decreasing priority, then
+// with those having a plan pending first, so that the approved plans are
+// applied without waiting for the other objects to be planned.
+func sortByStartupOrder(terraforms []infrav1.Terraform) {
+	sort.SliceStable(terraforms, func(i, j int) bool {
+		a, b := &terraforms[i], &terraforms[j]
+		if a.Spec.Priority != b.Spec.Priority {
+			return a.Spec.Priority > b.Spec.Priority
+		}
+		if aPending, bPending := a.Status.Plan.Pending != "", b.Status.Plan.Pending != ""; aPending != bPending {
+			return aPending
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/hashicorp/go-cleanhttp"
 	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/internal/fips"
 	"github.com/weaveworks/tf-controller/runner"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/kustomize/kyaml/yaml"
@@ -166,10 +167,10 @@ func (r *TerraformReconciler) processPostPlanningWebhooks(ctx context.Context, t
 
 			log.Info("webhook TLS cert loaded", "path", tlsCertPath, "keypath", tlsKeyPath)
 
-			cli.Transport.(*http.Transport).TLSClientConfig = &tls.Config{
+			cli.Transport.(*http.Transport).TLSClientConfig = fips.Restrict(&tls.Config{
 				RootCAs:      caCertPool,
 				Certificates: []tls.Certificate{certificate},
-			}
+			})
 
 			log.Info("webhook TLS config set")
 		}
@@ -0,0 +1,214 @@
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+//+kubebuilder:webhook:path=/validate-infra-contrib-fluxcd-io-v1alpha2-terraform,mutating=false,failurePolicy=ignore,sideEffects=NoneOnDryRun,groups=infra.contrib.fluxcd.io,resources=terraforms,verbs=update,versions=v1alpha2,name=vterraform.infra.contrib.fluxcd.io,admissionReviewVersions=v1
+
+// DryRunValidator is the validating webhook which plans a change of the spec
+// of a Terraform object before accepting it, when the change carries the dry
+// run annotation. The new spec is planned in a plan only copy of the object,
+// against its state, and the change is denied with the summary of the plan,
+// for it to be applied again without the annotation once reviewed.
+type DryRunValidator struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+}
+
+var _ admission.CustomValidator = &DryRunValidator{}
+
+func (v *DryRunValidator) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&infrav1.Terraform{}).
+		WithValidator(v).
+		Complete()
+}
+
+// ValidateCreate accepts the new objects, there is no state to plan a change
+// against yet.
+func (v *DryRunValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *DryRunValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateUpdate denies a change of the spec with the dry run annotation
+// with the state of its plan, and deletes the copies of the object of the
+// previous dry runs once a change is accepted.
+func (v *DryRunValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	old, ok := oldObj.(*infrav1.Terraform)
+	if !ok {
+		return nil, fmt.Errorf("expected a Terraform object, got %T", oldObj)
+	}
+	terraform, ok := newObj.(*infrav1.Terraform)
+	if !ok {
+		return nil, fmt.Errorf("expected a Terraform object, got %T", newObj)
+	}
+
+	// a server-side dry run of the change has no side effects
+	dryRun := false
+	if req, err := admission.RequestFromContext(ctx); err == nil && req.DryRun != nil {
+		dryRun = *req.DryRun
+	}
+
+	if terraform.GetAnnotations()[infrav1.DryRunAnnotation] != "true" || equality.Semantic.DeepEqual(old.Spec, terraform.Spec) {
+		if dryRun || !terraform.DeletionTimestamp.IsZero() {
+			return nil, nil
+		}
+		// the dry runs are cleaned up on a best effort basis, they are
+		// deleted with the object anyway
+		if err := v.deleteDryRuns(ctx, terraform, ""); err != nil {
+			ctrl.LoggerFrom(ctx).Error(err, "unable to clean up the dry runs")
+		}
+		return nil, nil
+	}
+
+	hash, err := specHash(terraform.Spec)
+	if err != nil {
+		return nil, err
+	}
+	copyKey := client.ObjectKey{Namespace: terraform.GetNamespace(), Name: dryRunName(terraform, hash)}
+
+	planned := &infrav1.Terraform{}
+	err = v.Client.Get(ctx, copyKey, planned)
+	switch {
+	case err == nil:
+		return nil, dryRunResult(planned)
+	case client.IgnoreNotFound(err) != nil:
+		return nil, fmt.Errorf("unable to get the dry run of the change: %w", err)
+	case dryRun:
+		return nil, fmt.Errorf("the change is not planned yet, apply it without --dry-run for the dry run to plan it")
+	}
+
+	// the dry run of a previous change is replaced by the one of this change
+	if err := v.deleteDryRuns(ctx, terraform, copyKey.Name); err != nil {
+		return nil, err
+	}
+
+	planned = &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        copyKey.Name,
+			Namespace:   copyKey.Namespace,
+			Labels:      map[string]string{infrav1.DryRunOfLabel: terraform.GetName()},
+			Annotations: map[string]string{infrav1.DryRunSpecAnnotation: hash},
+		},
+		Spec: dryRunSpec(terraform),
+	}
+	if err := controllerutil.SetOwnerReference(old, planned, v.Scheme); err != nil {
+		return nil, err
+	}
+	if err := v.Client.Create(ctx, planned); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("unable to create the dry run of the change: %w", err)
+	}
+
+	return nil, fmt.Errorf("planning the change in the Terraform object %s, apply it again for the plan, and without the %s annotation to accept it", copyKey, infrav1.DryRunAnnotation)
+}
+
+// deleteDryRuns deletes the copies of a Terraform object of its dry runs,
+// but the one of the given name.
+func (v *DryRunValidator) deleteDryRuns(ctx context.Context, terraform *infrav1.Terraform, keep string) error {
+	var list infrav1.TerraformList
+	if err := v.Client.List(ctx, &list, client.InNamespace(terraform.GetNamespace()), client.MatchingLabels{infrav1.DryRunOfLabel: terraform.GetName()}); err != nil {
+		return fmt.Errorf("unable to list the dry runs: %w", err)
+	}
+
+	for i := range list.Items {
+		planned := &list.Items[i]
+		if planned.GetName() == keep {
+			continue
+		}
+		if err := v.Client.Delete(ctx, planned); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("unable to delete the dry run %s: %w", planned.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// dryRunResult returns the denial of a change with the state of the plan of
+// its dry run.
+func dryRunResult(planned *infrav1.Terraform) error {
+	key := client.ObjectKeyFromObject(planned)
+	ready := apimeta.FindStatusCondition(planned.Status.Conditions, meta.ReadyCondition)
+	switch {
+	case ready == nil || ready.Reason == meta.ProgressingReason:
+		return fmt.Errorf("the change is being planned in the Terraform object %s, apply it again for the plan", key)
+	case ready.Status == metav1.ConditionFalse:
+		return fmt.Errorf("the plan of the change failed in the Terraform object %s: %s: %s", key, ready.Reason, ready.Message)
+	case ready.Reason == infrav1.PlannedNoChangesReason:
+		return fmt.Errorf("dry run: the change plans no changes, apply it without the %s annotation to accept it", infrav1.DryRunAnnotation)
+	case ready.Reason == infrav1.PlannedWithChangesReason:
+		summary := "the change plans changes"
+		if changes := planned.Status.Plan.Changes; changes != nil {
+			summary = fmt.Sprintf("Plan: %d to add, %d to change, %d to destroy", changes.Add, changes.Change, changes.Destroy)
+		}
+		return fmt.Errorf("dry run: %s, see the plan of the Terraform object %s, and apply the change without the %s annotation to accept it", summary, key, infrav1.DryRunAnnotation)
+	}
+	return fmt.Errorf("the change is being planned in the Terraform object %s, apply it again for the plan", key)
+}
+
+// dryRunName is the name of the copy of a Terraform object planning a spec,
+// suffixed with its hash for each change to have a dry run of its own.
+func dryRunName(terraform *infrav1.Terraform, hash string) string {
+	return fmt.Sprintf("%s-dry-run-%s", terraform.GetName(), hash[:8])
+}
+
+func specHash(spec infrav1.TerraformSpec) (string, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return "", fmt.Errorf("unable to hash the spec: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// dryRunSpec derives the spec of the copy planning a change from the new
+// spec. The copy is only ever planned, against the state of the object,
+// without writing its outputs or calling its webhooks.
+func dryRunSpec(terraform *infrav1.Terraform) infrav1.TerraformSpec {
+	spec := *terraform.Spec.DeepCopy()
+	spec.PlanOnly = true
+	spec.StoreReadablePlan = "human"
+	spec.ApprovePlan = ""
+	spec.Force = false
+	spec.Suspend = false
+	spec.DestroyResourcesOnDeletion = false
+	spec.DeletionPolicy = infrav1.DeletionPolicyOrphan
+	spec.TTL = nil
+	spec.DestroyAt = nil
+	spec.DeleteOnExpiry = false
+	spec.WriteOutputsToSecret = nil
+	spec.WriteOutputsToSecrets = nil
+	spec.Webhooks = nil
+
+	if spec.BackendConfig == nil {
+		spec.BackendConfig = &infrav1.BackendConfigSpec{
+			SecretSuffix:    terraform.GetName(),
+			InClusterConfig: true,
+		}
+	}
+	spec.TFState = &infrav1.TFStateSpec{
+		ForceUnlock:     infrav1.ForceUnlockEnumNo,
+		DisablePlanLock: spec.Cloud == nil,
+	}
+
+	return spec
+}
@@ -0,0 +1,231 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/fluxcd/pkg/runtime/predicates"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kuberecorder "k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// TerraformInstanceReconciler reconciles a TerraformInstance object, by
+// rendering the TerraformTemplate it refers to into a Terraform object.
+type TerraformInstanceReconciler struct {
+	client.Client
+	kuberecorder.EventRecorder
+
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=infra.contrib.fluxcd.io,resources=terraforminstances,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=infra.contrib.fluxcd.io,resources=terraforminstances/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=infra.contrib.fluxcd.io,resources=terraformtemplates,verbs=get;list;watch
+
+func (r *TerraformInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	var instance infrav1.TerraformInstance
+	if err := r.Get(ctx, req.NamespacedName, &instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// The Terraform object is garbage collected along with the instance.
+	if !instance.ObjectMeta.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	original := instance.DeepCopy()
+
+	var template infrav1.TerraformTemplate
+	if err := r.Get(ctx, types.NamespacedName{Name: instance.Spec.TemplateRef.Name}, &template); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+
+		// The instance is requeued when the template gets created.
+		msg := fmt.Sprintf("TerraformTemplate '%s' not found", instance.Spec.TemplateRef.Name)
+		log.Info(msg)
+		instance = infrav1.TerraformInstanceNotReady(instance, infrav1.TerraformTemplateNotFoundReason, msg)
+		return ctrl.Result{}, r.patchStatus(ctx, original, instance)
+	}
+
+	spec, err := renderTerraformSpec(template, instance)
+	if err != nil {
+		r.Eventf(&instance, corev1.EventTypeWarning, infrav1.InstanceVariablesInvalidReason, err.Error())
+		instance = infrav1.TerraformInstanceNotReady(instance, infrav1.InstanceVariablesInvalidReason, err.Error())
+		return ctrl.Result{}, r.patchStatus(ctx, original, instance)
+	}
+
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      instance.Name,
+			Namespace: instance.Namespace,
+		},
+	}
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, terraform, func() error {
+		if !terraform.CreationTimestamp.IsZero() && !metav1.IsControlledBy(terraform, &instance) {
+			return fmt.Errorf("Terraform '%s' already exists and is not managed by the instance", terraform.Name)
+		}
+
+		if terraform.Labels == nil {
+			terraform.Labels = map[string]string{}
+		}
+		terraform.Labels[infrav1.TemplateLabel] = template.Name
+		terraform.Labels[infrav1.InstanceLabel] = instance.Name
+		terraform.Spec = spec
+
+		return controllerutil.SetControllerReference(&instance, terraform, r.Scheme)
+	})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to render the Terraform object: %w", err)
+	}
+	if op != controllerutil.OperationResultNone {
+		log.Info("rendered the Terraform object", "template", template.Name, "operation", op)
+	}
+
+	instance = infrav1.TerraformInstanceFromTerraform(instance, *terraform)
+	instance.Status.ObservedTemplateGeneration = template.Generation
+	return ctrl.Result{}, r.patchStatus(ctx, original, instance)
+}
+
+func (r *TerraformInstanceReconciler) patchStatus(ctx context.Context, original *infrav1.TerraformInstance, instance infrav1.TerraformInstance) error {
+	instance.Status.ObservedGeneration = instance.Generation
+	statusOpts := &client.SubResourcePatchOptions{
+		PatchOptions: client.PatchOptions{
+			FieldManager: "tf-controller",
+		},
+	}
+	return r.Status().Patch(ctx, &instance, client.MergeFrom(original), statusOpts)
+}
+
+// renderTerraformSpec returns the spec of the Terraform object of the
+// instance: the template, with the variables exposed by the template set
+// to the values of the instance, or to their defaults.
+func renderTerraformSpec(template infrav1.TerraformTemplate, instance infrav1.TerraformInstance) (infrav1.TerraformSpec, error) {
+	spec := *template.Spec.Template.DeepCopy()
+
+	var errs []string
+	values := map[string]infrav1.Variable{}
+	for _, v := range instance.Spec.Vars {
+		exposed := template.GetVariable(v.Name)
+		if exposed == nil {
+			errs = append(errs, fmt.Sprintf("variable '%s' is not exposed by the template", v.Name))
+			continue
+		}
+		if len(exposed.AllowedValues) > 0 && (v.Value == nil || !containsJSON(exposed.AllowedValues, *v.Value)) {
+			errs = append(errs, fmt.Sprintf("variable '%s' must be set to one of the values allowed by the template", v.Name))
+			continue
+		}
+		values[v.Name] = *v.DeepCopy()
+	}
+
+	var vars []infrav1.Variable
+	for _, exposed := range template.Spec.Variables {
+		if v, ok := values[exposed.Name]; ok {
+			vars = append(vars, v)
+		} else if exposed.Default != nil {
+			vars = append(vars, infrav1.Variable{Name: exposed.Name, Value: exposed.Default.DeepCopy()})
+		} else if exposed.Required {
+			errs = append(errs, fmt.Sprintf("variable '%s' is required", exposed.Name))
+		}
+	}
+
+	if len(errs) > 0 {
+		return spec, fmt.Errorf("invalid variables: %s", strings.Join(errs, ", "))
+	}
+
+	// The exposed variables take precedence over the variables of the same
+	// name set by the template.
+	fixed := spec.Vars[:0]
+	for _, v := range spec.Vars {
+		if template.GetVariable(v.Name) == nil {
+			fixed = append(fixed, v)
+		}
+	}
+	spec.Vars = append(fixed, vars...)
+	spec.Suspend = spec.Suspend || instance.Spec.Suspend
+
+	return spec, nil
+}
+
+// containsJSON reports whether values contains a value semantically equal to
+// value, regardless of the formatting of the JSON documents.
+func containsJSON(values []apiextensionsv1.JSON, value apiextensionsv1.JSON) bool {
+	var want interface{}
+	if err := json.Unmarshal(value.Raw, &want); err != nil {
+		return false
+	}
+	for _, v := range values {
+		var got interface{}
+		if err := json.Unmarshal(v.Raw, &got); err == nil && reflect.DeepEqual(got, want) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TerraformInstanceReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurrentReconciles int) error {
+	if err := mgr.GetCache().IndexField(context.TODO(), &infrav1.TerraformInstance{}, infrav1.TemplateIndexKey,
+		func(o client.Object) []string {
+			instance, ok := o.(*infrav1.TerraformInstance)
+			if !ok {
+				panic(fmt.Sprintf("Expected a TerraformInstance, got %T", o))
+			}
+			return []string{instance.Spec.TemplateRef.Name}
+		}); err != nil {
+		return fmt.Errorf("failed setting index fields: %w", err)
+	}
+
+	recoverPanic := true
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&infrav1.TerraformInstance{}, builder.WithPredicates(
+			predicate.Or(predicate.GenerationChangedPredicate{}, predicates.ReconcileRequestedPredicate{}),
+		)).
+		Owns(&infrav1.Terraform{}).
+		Watches(
+			&infrav1.TerraformTemplate{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForTemplateChange),
+		).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrentReconciles,
+			RecoverPanic:            &recoverPanic,
+		}).
+		Complete(r)
+}
+
+func (r *TerraformInstanceReconciler) requestsForTemplateChange(ctx context.Context, obj client.Object) []reconcile.Request {
+	log := ctrl.LoggerFrom(ctx)
+
+	var list infrav1.TerraformInstanceList
+	if err := r.List(ctx, &list, client.MatchingFields{
+		infrav1.TemplateIndexKey: obj.GetName(),
+	}); err != nil {
+		log.Error(err, "failed to list objects for template change")
+		return nil
+	}
+
+	reqs := make([]reconcile.Request, 0, len(list.Items))
+	for _, i := range list.Items {
+		reqs = append(reqs, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&i)})
+	}
+	return reqs
+}
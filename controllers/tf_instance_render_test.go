@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"testing"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/gomega"
+)
+
+func jsonValue(raw string) *apiextensionsv1.JSON {
+	return &apiextensionsv1.JSON{Raw: []byte(raw)}
+}
+
+func TestRenderTerraformSpec(t *testing.T) {
+	template := infrav1.TerraformTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "s3-bucket"},
+		Spec: infrav1.TerraformTemplateSpec{
+			Template: infrav1.TerraformSpec{
+				ApprovePlan: "auto",
+				Path:        "./modules/s3-bucket",
+				SourceRef: infrav1.CrossNamespaceSourceReference{
+					Kind:      "GitRepository",
+					Name:      "platform-modules",
+					Namespace: "flux-system",
+				},
+				Vars: []infrav1.Variable{
+					{Name: "encryption", Value: jsonValue(`"aws:kms"`)},
+					{Name: "region", Value: jsonValue(`"eu-west-1"`)},
+				},
+			},
+			Variables: []infrav1.TemplateVariable{
+				{Name: "bucket_name", Required: true},
+				{Name: "region", Default: jsonValue(`"eu-west-1"`), AllowedValues: []apiextensionsv1.JSON{
+					*jsonValue(`"eu-west-1"`), *jsonValue(`"us-east-1"`),
+				}},
+			},
+		},
+	}
+
+	t.Run("sets the exposed variables", func(t *testing.T) {
+		g := NewWithT(t)
+		instance := infrav1.TerraformInstance{
+			Spec: infrav1.TerraformInstanceSpec{
+				Vars: []infrav1.Variable{
+					{Name: "bucket_name", Value: jsonValue(`"team-a-artifacts"`)},
+					{Name: "region", Value: jsonValue(` "us-east-1"`)},
+				},
+				Suspend: true,
+			},
+		}
+
+		spec, err := renderTerraformSpec(template, instance)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(spec.ApprovePlan).To(Equal("auto"))
+		g.Expect(spec.SourceRef.Namespace).To(Equal("flux-system"))
+		g.Expect(spec.Suspend).To(BeTrue())
+		g.Expect(spec.Vars).To(Equal([]infrav1.Variable{
+			{Name: "encryption", Value: jsonValue(`"aws:kms"`)},
+			{Name: "bucket_name", Value: jsonValue(`"team-a-artifacts"`)},
+			{Name: "region", Value: jsonValue(` "us-east-1"`)},
+		}))
+		g.Expect(template.Spec.Template.Vars).To(HaveLen(2))
+	})
+
+	t.Run("uses the defaults", func(t *testing.T) {
+		g := NewWithT(t)
+		instance := infrav1.TerraformInstance{
+			Spec: infrav1.TerraformInstanceSpec{
+				Vars: []infrav1.Variable{{Name: "bucket_name", Value: jsonValue(`"team-a-artifacts"`)}},
+			},
+		}
+
+		spec, err := renderTerraformSpec(template, instance)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(spec.Vars).To(ContainElement(infrav1.Variable{Name: "region", Value: jsonValue(`"eu-west-1"`)}))
+	})
+
+	t.Run("rejects invalid variables", func(t *testing.T) {
+		g := NewWithT(t)
+		instance := infrav1.TerraformInstance{
+			Spec: infrav1.TerraformInstanceSpec{
+				Vars: []infrav1.Variable{
+					{Name: "encryption", Value: jsonValue(`"none"`)},
+					{Name: "region", Value: jsonValue(`"ap-south-1"`)},
+				},
+			},
+		}
+
+		_, err := renderTerraformSpec(template, instance)
+		g.Expect(err).To(MatchError("invalid variables: " +
+			"variable 'encryption' is not exposed by the template, " +
+			"variable 'region' must be set to one of the values allowed by the template, " +
+			"variable 'bucket_name' is required"))
+	})
+}
@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+//+kubebuilder:webhook:path=/mutate-infra-contrib-fluxcd-io-v1alpha2-terraform,mutating=true,failurePolicy=fail,sideEffects=None,groups=infra.contrib.fluxcd.io,resources=terraforms,verbs=create;update,versions=v1alpha2,name=mterraform.infra.contrib.fluxcd.io,admissionReviewVersions=v1
+
+//+kubebuilder:rbac:groups=infra.contrib.fluxcd.io,resources=terraformnamespacepolicies,verbs=get;list;watch
+
+// NamespacePolicyDefaulter is the mutating webhook which applies the
+// TerraformNamespacePolicies of a namespace to its Terraform objects, and
+// rejects the ones the policies do not allow.
+type NamespacePolicyDefaulter struct {
+	Client client.Reader
+}
+
+var _ admission.CustomDefaulter = &NamespacePolicyDefaulter{}
+
+func (d *NamespacePolicyDefaulter) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&infrav1.Terraform{}).
+		WithDefaulter(d).
+		Complete()
+}
+
+// Default applies the policies of the namespace of the Terraform object, and
+// rejects it when it breaks the policies requiring a remote backend.
+func (d *NamespacePolicyDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	terraform, ok := obj.(*infrav1.Terraform)
+	if !ok {
+		return fmt.Errorf("expected a Terraform object, got %T", obj)
+	}
+
+	var policies infrav1.TerraformNamespacePolicyList
+	if err := d.Client.List(ctx, &policies, client.InNamespace(terraform.Namespace)); err != nil {
+		return fmt.Errorf("unable to list the namespace policies: %w", err)
+	}
+	if len(policies.Items) == 0 {
+		return nil
+	}
+
+	applyNamespacePolicies(terraform, policies.Items)
+
+	// the objects being deleted are let through, for their finalizers to be
+	// removed
+	if terraform.DeletionTimestamp.IsZero() {
+		if violation := remoteBackendViolation(*terraform, policies.Items); violation != "" {
+			return remoteBackendForbidden(terraform, violation)
+		}
+	}
+	return nil
+}
+
+// applyNamespacePolicies applies the policies, in the order of their names.
+// The defaults of the first policy setting them win, while the guardrails of
+// all the policies apply: the longest MinInterval, and any
+// DisallowAutoApprove. The runner runtime class of the first policy setting
+// one replaces the one of the object, so that it cannot opt out of it.
+func applyNamespacePolicies(terraform *infrav1.Terraform, policies []infrav1.TerraformNamespacePolicy) {
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].Name < policies[j].Name
+	})
+
+	names := make([]string, 0, len(policies))
+	runtimeClassEnforced := false
+	for _, policy := range policies {
+		names = append(names, policy.Name)
+		spec := policy.Spec
+
+		if terraform.Spec.ServiceAccountName == "" && spec.ServiceAccountName != "" {
+			terraform.Spec.ServiceAccountName = spec.ServiceAccountName
+		}
+
+		if terraform.Spec.RunnerPodTemplate.Spec.Resources == nil && spec.RunnerResources != nil {
+			terraform.Spec.RunnerPodTemplate.Spec.Resources = spec.RunnerResources.DeepCopy()
+		}
+
+		if spec.MinInterval != nil && terraform.Spec.Interval.Duration < spec.MinInterval.Duration {
+			terraform.Spec.Interval = *spec.MinInterval
+		}
+
+		if spec.DisallowAutoApprove && terraform.Spec.ApprovePlan == infrav1.ApprovePlanAutoValue {
+			terraform.Spec.ApprovePlan = ""
+		}
+
+		if spec.RunnerRuntimeClassName != "" && !runtimeClassEnforced {
+			runtimeClassName := spec.RunnerRuntimeClassName
+			terraform.Spec.RunnerPodTemplate.Spec.RuntimeClassName = &runtimeClassName
+			runtimeClassEnforced = true
+		}
+	}
+
+	annotations := terraform.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[infrav1.NamespacePolicyAnnotation] = strings.Join(names, ",")
+	terraform.SetAnnotations(annotations)
+}
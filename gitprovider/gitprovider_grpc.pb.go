@@ -0,0 +1,327 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.19.4
+// source: gitprovider/gitprovider.proto
+
+package gitprovider
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// GitProviderClient is the client API for GitProvider service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GitProviderClient interface {
+	ListPullRequests(ctx context.Context, in *ListPullRequestsRequest, opts ...grpc.CallOption) (*ListPullRequestsReply, error)
+	AddCommentToPullRequest(ctx context.Context, in *AddCommentToPullRequestRequest, opts ...grpc.CallOption) (*AddCommentToPullRequestReply, error)
+	CreatePullRequest(ctx context.Context, in *CreatePullRequestRequest, opts ...grpc.CallOption) (*CreatePullRequestReply, error)
+	GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*GetCapabilitiesReply, error)
+	// SetCommitStatus is called with the commitStatuses capability.
+	SetCommitStatus(ctx context.Context, in *SetCommitStatusRequest, opts ...grpc.CallOption) (*SetCommitStatusReply, error)
+	// AddReactionToPullRequest is called with the reactions capability.
+	AddReactionToPullRequest(ctx context.Context, in *AddReactionToPullRequestRequest, opts ...grpc.CallOption) (*AddReactionToPullRequestReply, error)
+	// ListChangedFiles is called with the changedFiles capability.
+	ListChangedFiles(ctx context.Context, in *ListChangedFilesRequest, opts ...grpc.CallOption) (*ListChangedFilesReply, error)
+}
+
+type gitProviderClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGitProviderClient(cc grpc.ClientConnInterface) GitProviderClient {
+	return &gitProviderClient{cc}
+}
+
+func (c *gitProviderClient) ListPullRequests(ctx context.Context, in *ListPullRequestsRequest, opts ...grpc.CallOption) (*ListPullRequestsReply, error) {
+	out := new(ListPullRequestsReply)
+	err := c.cc.Invoke(ctx, "/gitprovider.GitProvider/ListPullRequests", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gitProviderClient) AddCommentToPullRequest(ctx context.Context, in *AddCommentToPullRequestRequest, opts ...grpc.CallOption) (*AddCommentToPullRequestReply, error) {
+	out := new(AddCommentToPullRequestReply)
+	err := c.cc.Invoke(ctx, "/gitprovider.GitProvider/AddCommentToPullRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gitProviderClient) CreatePullRequest(ctx context.Context, in *CreatePullRequestRequest, opts ...grpc.CallOption) (*CreatePullRequestReply, error) {
+	out := new(CreatePullRequestReply)
+	err := c.cc.Invoke(ctx, "/gitprovider.GitProvider/CreatePullRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gitProviderClient) GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*GetCapabilitiesReply, error) {
+	out := new(GetCapabilitiesReply)
+	err := c.cc.Invoke(ctx, "/gitprovider.GitProvider/GetCapabilities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gitProviderClient) SetCommitStatus(ctx context.Context, in *SetCommitStatusRequest, opts ...grpc.CallOption) (*SetCommitStatusReply, error) {
+	out := new(SetCommitStatusReply)
+	err := c.cc.Invoke(ctx, "/gitprovider.GitProvider/SetCommitStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gitProviderClient) AddReactionToPullRequest(ctx context.Context, in *AddReactionToPullRequestRequest, opts ...grpc.CallOption) (*AddReactionToPullRequestReply, error) {
+	out := new(AddReactionToPullRequestReply)
+	err := c.cc.Invoke(ctx, "/gitprovider.GitProvider/AddReactionToPullRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gitProviderClient) ListChangedFiles(ctx context.Context, in *ListChangedFilesRequest, opts ...grpc.CallOption) (*ListChangedFilesReply, error) {
+	out := new(ListChangedFilesReply)
+	err := c.cc.Invoke(ctx, "/gitprovider.GitProvider/ListChangedFiles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GitProviderServer is the server API for GitProvider service.
+// All implementations must embed UnimplementedGitProviderServer
+// for forward compatibility
+type GitProviderServer interface {
+	ListPullRequests(context.Context, *ListPullRequestsRequest) (*ListPullRequestsReply, error)
+	AddCommentToPullRequest(context.Context, *AddCommentToPullRequestRequest) (*AddCommentToPullRequestReply, error)
+	CreatePullRequest(context.Context, *CreatePullRequestRequest) (*CreatePullRequestReply, error)
+	GetCapabilities(context.Context, *GetCapabilitiesRequest) (*GetCapabilitiesReply, error)
+	// SetCommitStatus is called with the commitStatuses capability.
+	SetCommitStatus(context.Context, *SetCommitStatusRequest) (*SetCommitStatusReply, error)
+	// AddReactionToPullRequest is called with the reactions capability.
+	AddReactionToPullRequest(context.Context, *AddReactionToPullRequestRequest) (*AddReactionToPullRequestReply, error)
+	// ListChangedFiles is called with the changedFiles capability.
+	ListChangedFiles(context.Context, *ListChangedFilesRequest) (*ListChangedFilesReply, error)
+	mustEmbedUnimplementedGitProviderServer()
+}
+
+// UnimplementedGitProviderServer must be embedded to have forward compatible implementations.
+type UnimplementedGitProviderServer struct {
+}
+
+func (UnimplementedGitProviderServer) ListPullRequests(context.Context, *ListPullRequestsRequest) (*ListPullRequestsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPullRequests not implemented")
+}
+func (UnimplementedGitProviderServer) AddCommentToPullRequest(context.Context, *AddCommentToPullRequestRequest) (*AddCommentToPullRequestReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCommentToPullRequest not implemented")
+}
+func (UnimplementedGitProviderServer) CreatePullRequest(context.Context, *CreatePullRequestRequest) (*CreatePullRequestReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePullRequest not implemented")
+}
+func (UnimplementedGitProviderServer) GetCapabilities(context.Context, *GetCapabilitiesRequest) (*GetCapabilitiesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCapabilities not implemented")
+}
+func (UnimplementedGitProviderServer) SetCommitStatus(context.Context, *SetCommitStatusRequest) (*SetCommitStatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCommitStatus not implemented")
+}
+func (UnimplementedGitProviderServer) AddReactionToPullRequest(context.Context, *AddReactionToPullRequestRequest) (*AddReactionToPullRequestReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddReactionToPullRequest not implemented")
+}
+func (UnimplementedGitProviderServer) ListChangedFiles(context.Context, *ListChangedFilesRequest) (*ListChangedFilesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListChangedFiles not implemented")
+}
+func (UnimplementedGitProviderServer) mustEmbedUnimplementedGitProviderServer() {}
+
+// UnsafeGitProviderServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GitProviderServer will
+// result in compilation errors.
+type UnsafeGitProviderServer interface {
+	mustEmbedUnimplementedGitProviderServer()
+}
+
+func RegisterGitProviderServer(s grpc.ServiceRegistrar, srv GitProviderServer) {
+	s.RegisterService(&GitProvider_ServiceDesc, srv)
+}
+
+func _GitProvider_ListPullRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPullRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitProviderServer).ListPullRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitprovider.GitProvider/ListPullRequests",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitProviderServer).ListPullRequests(ctx, req.(*ListPullRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GitProvider_AddCommentToPullRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCommentToPullRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitProviderServer).AddCommentToPullRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitprovider.GitProvider/AddCommentToPullRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitProviderServer).AddCommentToPullRequest(ctx, req.(*AddCommentToPullRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GitProvider_CreatePullRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePullRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitProviderServer).CreatePullRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitprovider.GitProvider/CreatePullRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitProviderServer).CreatePullRequest(ctx, req.(*CreatePullRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GitProvider_GetCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitProviderServer).GetCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitprovider.GitProvider/GetCapabilities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitProviderServer).GetCapabilities(ctx, req.(*GetCapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GitProvider_SetCommitStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCommitStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitProviderServer).SetCommitStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitprovider.GitProvider/SetCommitStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitProviderServer).SetCommitStatus(ctx, req.(*SetCommitStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GitProvider_AddReactionToPullRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddReactionToPullRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitProviderServer).AddReactionToPullRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitprovider.GitProvider/AddReactionToPullRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitProviderServer).AddReactionToPullRequest(ctx, req.(*AddReactionToPullRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GitProvider_ListChangedFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChangedFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitProviderServer).ListChangedFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitprovider.GitProvider/ListChangedFiles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitProviderServer).ListChangedFiles(ctx, req.(*ListChangedFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GitProvider_ServiceDesc is the grpc.ServiceDesc for GitProvider service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GitProvider_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gitprovider.GitProvider",
+	HandlerType: (*GitProviderServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListPullRequests",
+			Handler:    _GitProvider_ListPullRequests_Handler,
+		},
+		{
+			MethodName: "AddCommentToPullRequest",
+			Handler:    _GitProvider_AddCommentToPullRequest_Handler,
+		},
+		{
+			MethodName: "CreatePullRequest",
+			Handler:    _GitProvider_CreatePullRequest_Handler,
+		},
+		{
+			MethodName: "GetCapabilities",
+			Handler:    _GitProvider_GetCapabilities_Handler,
+		},
+		{
+			MethodName: "SetCommitStatus",
+			Handler:    _GitProvider_SetCommitStatus_Handler,
+		},
+		{
+			MethodName: "AddReactionToPullRequest",
+			Handler:    _GitProvider_AddReactionToPullRequest_Handler,
+		},
+		{
+			MethodName: "ListChangedFiles",
+			Handler:    _GitProvider_ListChangedFiles_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "gitprovider/gitprovider.proto",
+}
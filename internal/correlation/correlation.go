@@ -0,0 +1,148 @@
+// Package correlation carries the fields identifying a run of a Terraform
+// object across the logs of the controller, the runner and the branch
+// planner, so that a run can be traced through the three of them.
+package correlation
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// The keys of the correlation fields in the logs.
+const (
+	NamespaceKey   = "namespace"
+	NameKey        = "name"
+	RevisionKey    = "revision"
+	RunIDKey       = "run-id"
+	PullRequestKey = "pr-number"
+)
+
+// PullRequestLabel is set by the branch planner on the Terraform objects
+// planning a pull request, to the number of the pull request.
+const PullRequestLabel = "infra.weave.works/pr-id"
+
+// metadataPrefix prefixes the gRPC metadata keys of the fields sent by the
+// controller to the runner.
+const metadataPrefix = "x-tf-controller-"
+
+// Fields identify a run of a Terraform object. Empty fields are left out of
+// the logs.
+type Fields struct {
+	Namespace   string
+	Name        string
+	Revision    string
+	RunID       string
+	PullRequest string
+}
+
+// FromObject returns the fields of a Terraform object, with the number of
+// the pull request it plans, if any.
+func FromObject(obj metav1.Object) Fields {
+	return Fields{
+		Namespace:   obj.GetNamespace(),
+		Name:        obj.GetName(),
+		PullRequest: obj.GetLabels()[PullRequestLabel],
+	}
+}
+
+// KeysAndValues returns the non-empty fields, as logr key-value pairs.
+func (f Fields) KeysAndValues() []interface{} {
+	var keysAndValues []interface{}
+	for _, field := range f.pairs() {
+		if field[1] != "" {
+			keysAndValues = append(keysAndValues, field[0], field[1])
+		}
+	}
+	return keysAndValues
+}
+
+// Logger returns the logger with the non-empty fields.
+func (f Fields) Logger(log logr.Logger) logr.Logger {
+	return log.WithValues(f.KeysAndValues()...)
+}
+
+func (f Fields) pairs() [][2]string {
+	return [][2]string{
+		{NamespaceKey, f.Namespace},
+		{NameKey, f.Name},
+		{RevisionKey, f.Revision},
+		{RunIDKey, f.RunID},
+		{PullRequestKey, f.PullRequest},
+	}
+}
+
+// OutgoingContext returns the context with the non-empty fields in the
+// metadata of the gRPC calls made with it.
+func OutgoingContext(ctx context.Context, f Fields) context.Context {
+	var kv []string
+	for _, field := range f.pairs() {
+		if field[1] != "" {
+			kv = append(kv, metadataPrefix+field[0], field[1])
+		}
+	}
+	if len(kv) == 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+// FromIncomingContext returns the fields in the metadata of a gRPC call.
+func FromIncomingContext(ctx context.Context) Fields {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return Fields{}
+	}
+	get := func(key string) string {
+		if values := md.Get(metadataPrefix + key); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+	return Fields{
+		Namespace:   get(NamespaceKey),
+		Name:        get(NameKey),
+		Revision:    get(RevisionKey),
+		RunID:       get(RunIDKey),
+		PullRequest: get(PullRequestKey),
+	}
+}
+
+// loggerContext returns the context with a logger carrying the fields of
+// the gRPC call.
+func loggerContext(ctx context.Context) context.Context {
+	f := FromIncomingContext(ctx)
+	if f == (Fields{}) {
+		return ctx
+	}
+	return ctrl.LoggerInto(ctx, f.Logger(ctrl.LoggerFrom(ctx)))
+}
+
+// UnaryServerInterceptor adds the fields of each gRPC call to the logger of
+// its context.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(loggerContext(ctx), req)
+	}
+}
+
+// StreamServerInterceptor adds the fields of each gRPC stream to the logger
+// of its context.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, ctx: loggerContext(ss.Context())})
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,57 @@
+package correlation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestFromObject(t *testing.T) {
+	g := NewWithT(t)
+
+	fields := FromObject(&metav1.ObjectMeta{
+		Namespace: "flux-system",
+		Name:      "helloworld-pr-42",
+		Labels:    map[string]string{PullRequestLabel: "42"},
+	})
+	g.Expect(fields).To(Equal(Fields{Namespace: "flux-system", Name: "helloworld-pr-42", PullRequest: "42"}))
+	g.Expect(fields.KeysAndValues()).To(Equal([]interface{}{
+		"namespace", "flux-system",
+		"name", "helloworld-pr-42",
+		"pr-number", "42",
+	}))
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	g := NewWithT(t)
+
+	sent := Fields{
+		Namespace: "flux-system",
+		Name:      "helloworld",
+		Revision:  "main@sha1:b8e362c206e3d0cbb7ed22ced771a0056455a2fb",
+		RunID:     "0b9e5f5e-4a43-4d4c-8e1b-3c4f1b6b1a2d",
+	}
+
+	// the metadata sent by the controller is received by the runner
+	md, _ := metadata.FromOutgoingContext(OutgoingContext(context.Background(), sent))
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+	g.Expect(FromIncomingContext(ctx)).To(Equal(sent))
+
+	var line string
+	log := funcr.New(func(prefix, args string) { line = args }, funcr.Options{})
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	_, err := UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		ctrl.LoggerFrom(ctx).Info("plan")
+		return nil, nil
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(line).To(Equal(`"level"=0 "msg"="plan" "namespace"="flux-system" "name"="helloworld" ` +
+		`"revision"="main@sha1:b8e362c206e3d0cbb7ed22ced771a0056455a2fb" "run-id"="0b9e5f5e-4a43-4d4c-8e1b-3c4f1b6b1a2d"`))
+}
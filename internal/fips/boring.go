@@ -0,0 +1,16 @@
+//go:build boringcrypto
+
+package fips
+
+import (
+	"crypto/boring"
+
+	// restrict TLS to the FIPS approved settings, whatever the configuration
+	_ "crypto/tls/fipsonly"
+)
+
+// Validated reports whether the cryptography of the binary is provided by
+// the FIPS 140-2 validated BoringCrypto module.
+func Validated() bool {
+	return boring.Enabled()
+}
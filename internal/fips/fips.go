@@ -0,0 +1,67 @@
+// Package fips restricts the cryptography of the controller, the branch
+// planner and the runner to FIPS 140-2 validated modules and approved
+// algorithms.
+//
+// The binaries use the FIPS 140-2 validated BoringCrypto module when built
+// with GOEXPERIMENT=boringcrypto. Their TLS is then restricted to the FIPS
+// approved versions, cipher suites and curves, and the --fips flag makes
+// them refuse to start when they are not built this way.
+package fips
+
+import (
+	"crypto/tls"
+	"errors"
+	"sync/atomic"
+)
+
+// ErrNotValidated is returned by Enable when the binary is not built with
+// the BoringCrypto module.
+var ErrNotValidated = errors.New("the FIPS mode requires a binary built with GOEXPERIMENT=boringcrypto")
+
+// CipherSuites are the TLS 1.2 cipher suites approved by FIPS 140-2. The
+// cipher suites of TLS 1.3 are not configurable, and all approved.
+var CipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+}
+
+// CurvePreferences are the elliptic curves approved by FIPS 140-2.
+var CurvePreferences = []tls.CurveID{
+	tls.CurveP256,
+	tls.CurveP384,
+}
+
+var enabled atomic.Bool
+
+// Enable turns the FIPS mode on, or returns ErrNotValidated when the binary
+// is not built with the BoringCrypto module.
+func Enable() error {
+	if !Validated() {
+		return ErrNotValidated
+	}
+	enabled.Store(true)
+	return nil
+}
+
+// Enabled reports whether the FIPS mode is on: always with the BoringCrypto
+// module, which restricts TLS to the approved settings on its own.
+func Enabled() bool {
+	return enabled.Load() || Validated()
+}
+
+// Restrict restricts a TLS configuration to the FIPS approved versions,
+// cipher suites and curves when the FIPS mode is on, and returns it.
+func Restrict(config *tls.Config) *tls.Config {
+	if !Enabled() {
+		return config
+	}
+
+	if config.MinVersion < tls.VersionTLS12 {
+		config.MinVersion = tls.VersionTLS12
+	}
+	config.CipherSuites = CipherSuites
+	config.CurvePreferences = CurvePreferences
+	return config
+}
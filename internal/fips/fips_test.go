@@ -0,0 +1,47 @@
+package fips
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestrict(t *testing.T) {
+	defer enabled.Store(false)
+
+	config := Restrict(&tls.Config{MinVersion: tls.VersionTLS10})
+	assert.Equal(t, Validated(), config.CipherSuites != nil)
+
+	enabled.Store(true)
+	config = Restrict(&tls.Config{MinVersion: tls.VersionTLS10})
+	assert.Equal(t, uint16(tls.VersionTLS12), config.MinVersion)
+	assert.Equal(t, CipherSuites, config.CipherSuites)
+	assert.Equal(t, CurvePreferences, config.CurvePreferences)
+
+	config = Restrict(&tls.Config{MinVersion: tls.VersionTLS13})
+	assert.Equal(t, uint16(tls.VersionTLS13), config.MinVersion)
+}
+
+func TestEnable(t *testing.T) {
+	defer enabled.Store(false)
+
+	err := Enable()
+	if Validated() {
+		assert.NoError(t, err)
+		assert.True(t, Enabled())
+	} else {
+		assert.ErrorIs(t, err, ErrNotValidated)
+		assert.False(t, Enabled())
+	}
+}
+
+func TestCipherSuitesAreSecure(t *testing.T) {
+	secure := map[uint16]bool{}
+	for _, suite := range tls.CipherSuites() {
+		secure[suite.ID] = true
+	}
+	for _, id := range CipherSuites {
+		assert.True(t, secure[id], tls.CipherSuiteName(id))
+	}
+}
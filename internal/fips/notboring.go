@@ -0,0 +1,9 @@
+//go:build !boringcrypto
+
+package fips
+
+// Validated reports whether the cryptography of the binary is provided by
+// the FIPS 140-2 validated BoringCrypto module.
+func Validated() bool {
+	return false
+}
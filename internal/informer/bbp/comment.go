@@ -0,0 +1,149 @@
+package bbp
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/template"
+
+	tfv1alpha2 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// CommentTemplateKey and CommentCollapseLinesKey are the keys of the
+	// planner ConfigMap customizing the plan comments: the Go template of
+	// the comments, and the number of lines above which the plan is
+	// collapsed.
+	CommentTemplateKey      = "commentTemplate"
+	CommentCollapseLinesKey = "commentCollapseLines"
+
+	// AnnotationCodeOwners on an original Terraform object lists the owners
+	// to mention in the plan comments of its branches, separated by commas
+	// or spaces, like "@org/network @alice". The planner copies it to the
+	// branch Terraform objects.
+	AnnotationCodeOwners = "infra.weave.works/code-owners"
+)
+
+// defaultCommentTemplate is the template of the plan comments when the
+// planner ConfigMap has none.
+const defaultCommentTemplate = `
+{{- if .Divergence }}> **Warning**
+> The plan may include changes which are not part of this pull request: {{ .Divergence }}.
+
+{{ end }}
+{{- if .Collapsed }}<details><summary>{{ or .Summary "Show the plan" }}</summary>
+
+{{ .Plan }}
+
+</details>
+{{- else }}{{ .Plan }}{{ end }}
+{{- if .CodeOwners }}
+
+cc {{ join .CodeOwners " " }}{{ end }}`
+
+// CommentData is the data of the template of a plan comment.
+type CommentData struct {
+	// Terraform is the branch Terraform object of the plan.
+	Terraform *tfv1alpha2.Terraform
+	// Plan is the readable plan, and PlanLines its number of lines.
+	Plan      string
+	PlanLines int
+	// Summary is the summary of the changes of the plan, when known.
+	Summary string
+	// Collapsed is true when the plan has more lines than the collapse
+	// threshold of the ConfigMap.
+	Collapsed bool
+	// Divergence describes the changes of the base branch which are not
+	// applied yet, and are part of the plan too.
+	Divergence string
+	// CodeOwners are the owners of the original Terraform object.
+	CodeOwners []string
+	// PullRequest is the pull request of the plan, when known.
+	PullRequest *tfv1alpha2.PullRequestReference
+}
+
+// CommentTemplate renders the plan comments.
+type CommentTemplate struct {
+	template      *template.Template
+	collapseLines int
+}
+
+// NewCommentTemplate parses the template of the plan comments, the default
+// one when text is empty. A collapseLines of zero never collapses the plan.
+func NewCommentTemplate(text string, collapseLines int) (*CommentTemplate, error) {
+	if text == "" {
+		text = defaultCommentTemplate
+	}
+	if collapseLines < 0 {
+		return nil, fmt.Errorf("%s must be a non-negative integer: %d", CommentCollapseLinesKey, collapseLines)
+	}
+
+	tmpl, err := template.New("comment").
+		Funcs(template.FuncMap{"join": strings.Join}).
+		Option("missingkey=error").
+		Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", CommentTemplateKey, err)
+	}
+	// The fields are only resolved on execution, a template referring to
+	// unknown ones is rejected here rather than on the first plan.
+	sample := CommentData{Terraform: &tfv1alpha2.Terraform{}, PullRequest: &tfv1alpha2.PullRequestReference{}}
+	if err := tmpl.Execute(io.Discard, sample); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", CommentTemplateKey, err)
+	}
+
+	return &CommentTemplate{template: tmpl, collapseLines: collapseLines}, nil
+}
+
+// ReadCommentTemplate returns the template of the plan comments of the
+// planner ConfigMap.
+func ReadCommentTemplate(configMap *corev1.ConfigMap) (*CommentTemplate, error) {
+	collapseLines := 0
+	if value := configMap.Data[CommentCollapseLinesKey]; value != "" {
+		var err error
+		if collapseLines, err = strconv.Atoi(value); err != nil {
+			return nil, fmt.Errorf("%s must be a non-negative integer: %q", CommentCollapseLinesKey, value)
+		}
+	}
+
+	return NewCommentTemplate(configMap.Data[CommentTemplateKey], collapseLines)
+}
+
+// Render renders the comment of the plan of a branch Terraform object. The
+// comment ends with the reference of the object, whatever the template, for
+// the UIs to link the pull request to its plan.
+func (t *CommentTemplate) Render(branchTF *tfv1alpha2.Terraform, planOutput []byte) ([]byte, error) {
+	plan := strings.TrimRight(string(planOutput), "\n")
+	data := CommentData{
+		Terraform:   branchTF,
+		Plan:        plan,
+		PlanLines:   strings.Count(plan, "\n") + 1,
+		Summary:     branchTF.Status.Summary,
+		CodeOwners:  codeOwners(branchTF),
+		PullRequest: branchTF.Status.PullRequest,
+	}
+	data.Collapsed = t.collapseLines > 0 && data.PlanLines > t.collapseLines
+	diverged := apimeta.FindStatusCondition(branchTF.Status.Conditions, ConditionTypeMainDiverged)
+	if diverged != nil && diverged.Status == metav1.ConditionTrue {
+		data.Divergence = diverged.Message
+	}
+
+	var body bytes.Buffer
+	if err := t.template.Execute(&body, data); err != nil {
+		return nil, fmt.Errorf("unable to render the comment template: %w", err)
+	}
+	writeCommentReference(&body, branchTF)
+	return body.Bytes(), nil
+}
+
+// codeOwners returns the owners of the annotation of a Terraform object.
+func codeOwners(terraform *tfv1alpha2.Terraform) []string {
+	return strings.FieldsFunc(terraform.GetAnnotations()[AnnotationCodeOwners], func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+}
@@ -1,14 +1,17 @@
 package bbp
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	tfv1alpha2 "github.com/weaveworks/tf-controller/api/v1alpha2"
-	"github.com/weaveworks/tf-controller/internal/git/provider"
+	"github.com/weaveworks/tf-controller/internal/correlation"
+	"github.com/weaveworks/tf-controller/planner/provider"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
@@ -22,6 +25,7 @@ type Informer struct {
 	handlers       cache.ResourceEventHandlerFuncs
 	log            logr.Logger
 	client         client.Client
+	configMap      types.NamespacedName
 
 	mux    *sync.RWMutex
 	synced bool
@@ -89,11 +93,28 @@ func (i *Informer) SetDeleteHandler(fn func(interface{})) {
 	i.handlers.DeleteFunc = fn
 }
 
+// SetConfigMap sets the planner ConfigMap, read for the template of the
+// plan comments.
+func (i *Informer) SetConfigMap(key types.NamespacedName) {
+	i.configMap = key
+}
+
 const (
 	AnnotationKey   = "terraform-conrtoller/branch-based-planner"
 	AnnotationValue = "true"
 )
 
+const (
+	// ConditionTypeMainDiverged is set on the branch Terraform objects by the
+	// planner, and tells whether the original object has changes of the base
+	// branch which are not applied yet. The plan of the branch, made against
+	// the state of the original, includes them too.
+	ConditionTypeMainDiverged = "MainDiverged"
+
+	MainPendingChangesReason = "MainPendingChanges"
+	MainUpToDateReason       = "MainUpToDate"
+)
+
 func (i *Informer) addHandler(obj interface{}) {}
 
 func (i *Informer) updateHandler(oldObj, newObj interface{}) {
@@ -123,30 +144,90 @@ func (i *Informer) updateHandler(oldObj, newObj interface{}) {
 		return
 	}
 
+	run := correlation.FromObject(current)
+	run.Revision = current.Status.LastPlannedRevision
+	log := run.Logger(i.log)
+
 	ctx := context.Background()
 
 	plan, err := i.getPlan(ctx, current)
 	if err != nil {
-		i.log.Error(err, "get plan output")
+		log.Error(err, "get plan output")
 
 		return
 	}
 
 	planOutput := plan.Data["tfplan"]
 	if len(planOutput) == 0 {
-		i.log.Info("Empty plan output")
+		log.Info("Empty plan output")
 
 		return
 	}
 
 	gitProvider, err := provider.New(provider.ProviderGitHub)
 	if err != nil {
-		i.log.Error(err, "unable to get provider", "provider", "github")
+		log.Error(err, "unable to get provider", "provider", "github")
 
 		return
 	}
 
-	gitProvider.AddCommentToPullRequest(ctx, provider.PullRequest{}, planOutput)
+	body, err := i.commentTemplate(ctx).Render(current, planOutput)
+	if err != nil {
+		log.Error(err, "unable to render the plan comment")
+
+		return
+	}
+
+	gitProvider.AddCommentToPullRequest(ctx, provider.PullRequest{}, body)
+}
+
+// writeCommentReference ends a plan comment with the reference of its
+// branch Terraform object, for the UIs to link the pull request to its plan.
+func writeCommentReference(body *bytes.Buffer, branchTF *tfv1alpha2.Terraform) {
+	key := client.ObjectKeyFromObject(branchTF)
+	fmt.Fprintf(body, "\n\n<sub>Planned by Terraform %s</sub>\n%s%s -->\n", key, commentReferencePrefix, key)
+}
+
+// commentTemplate returns the template of the plan comments of the planner
+// ConfigMap, and the default one when the ConfigMap is not set or invalid.
+func (i *Informer) commentTemplate(ctx context.Context) *CommentTemplate {
+	if i.configMap.Name != "" {
+		configMap := &corev1.ConfigMap{}
+		err := i.client.Get(ctx, i.configMap, configMap)
+		if err == nil {
+			var tmpl *CommentTemplate
+			if tmpl, err = ReadCommentTemplate(configMap); err == nil {
+				return tmpl
+			}
+		}
+		i.log.Error(err, "unable to read the comment template, using the default one", "configMap", i.configMap)
+	}
+
+	tmpl, _ := NewCommentTemplate("", 0)
+	return tmpl
+}
+
+// commentReferencePrefix starts the hidden reference of the branch
+// Terraform object at the end of a plan comment.
+const commentReferencePrefix = "<!-- tf-controller:terraform="
+
+// TerraformFromComment returns the branch Terraform object referenced by a
+// plan comment, and false when the comment has no reference.
+func TerraformFromComment(body string) (types.NamespacedName, bool) {
+	i := strings.LastIndex(body, commentReferencePrefix)
+	if i < 0 {
+		return types.NamespacedName{}, false
+	}
+
+	reference, _, ok := strings.Cut(body[i+len(commentReferencePrefix):], " -->")
+	if !ok {
+		return types.NamespacedName{}, false
+	}
+	namespace, name, ok := strings.Cut(reference, "/")
+	if !ok || namespace == "" || name == "" {
+		return types.NamespacedName{}, false
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}, true
 }
 
 func (i *Informer) deleteHandler(obj interface{}) {}
@@ -0,0 +1,86 @@
+package bbp
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	tfv1alpha2 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestCommentBody(t *testing.T) {
+	g := NewWithT(t)
+
+	branchTF := &tfv1alpha2.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "tf1-pr-7", Namespace: "default"},
+	}
+	tmpl, err := NewCommentTemplate("", 0)
+	g.Expect(err).ToNot(HaveOccurred())
+	rendered, err := tmpl.Render(branchTF, []byte("Plan: 1 to add, 0 to change, 0 to destroy."))
+	g.Expect(err).ToNot(HaveOccurred())
+	body := string(rendered)
+	g.Expect(body).To(HavePrefix("Plan: 1 to add"))
+	g.Expect(body).To(ContainSubstring("<sub>Planned by Terraform default/tf1-pr-7</sub>"))
+
+	key, ok := TerraformFromComment(body)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(key).To(Equal(types.NamespacedName{Namespace: "default", Name: "tf1-pr-7"}))
+
+	branchTF.Status.Conditions = []metav1.Condition{{
+		Type:    ConditionTypeMainDiverged,
+		Status:  metav1.ConditionTrue,
+		Message: "main@sha1:abc is not applied yet",
+	}}
+	rendered, err = tmpl.Render(branchTF, []byte("Plan: 1 to add, 0 to change, 0 to destroy."))
+	g.Expect(err).ToNot(HaveOccurred())
+	body = string(rendered)
+	g.Expect(strings.HasPrefix(body, "> **Warning**")).To(BeTrue())
+
+	_, ok = TerraformFromComment("Plan: 1 to add, 0 to change, 0 to destroy.")
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestCommentTemplate(t *testing.T) {
+	g := NewWithT(t)
+
+	branchTF := &tfv1alpha2.Terraform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "tf1-pr-7",
+			Namespace:   "default",
+			Annotations: map[string]string{AnnotationCodeOwners: "@org/network, @alice"},
+		},
+	}
+	branchTF.Status.Summary = "Plan: 2 to add, 0 to change, 0 to destroy"
+	plan := []byte("resource a\nresource b\nPlan: 2 to add, 0 to change, 0 to destroy.\n")
+
+	// the default template collapses the long plans and mentions the owners
+	tmpl, err := ReadCommentTemplate(&corev1.ConfigMap{Data: map[string]string{CommentCollapseLinesKey: "2"}})
+	g.Expect(err).ToNot(HaveOccurred())
+	body, err := tmpl.Render(branchTF, plan)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(body)).To(HavePrefix("<details><summary>Plan: 2 to add, 0 to change, 0 to destroy</summary>\n\nresource a\n"))
+	g.Expect(string(body)).To(ContainSubstring("</details>\n\ncc @org/network @alice\n\n<sub>Planned by Terraform default/tf1-pr-7</sub>"))
+
+	tmpl, err = ReadCommentTemplate(&corev1.ConfigMap{Data: map[string]string{
+		CommentTemplateKey: "## {{ .Terraform.Name }}\n{{ .Plan }}\n\nSee the [runbook](https://runbooks.example.com).",
+	}})
+	g.Expect(err).ToNot(HaveOccurred())
+	body, err = tmpl.Render(branchTF, plan)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(body)).To(HavePrefix("## tf1-pr-7\nresource a\n"))
+	key, ok := TerraformFromComment(string(body))
+	g.Expect(ok).To(BeTrue())
+	g.Expect(key).To(Equal(types.NamespacedName{Namespace: "default", Name: "tf1-pr-7"}))
+
+	_, err = ReadCommentTemplate(&corev1.ConfigMap{Data: map[string]string{CommentTemplateKey: "{{ .Plan "}})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid commentTemplate")))
+	_, err = ReadCommentTemplate(&corev1.ConfigMap{Data: map[string]string{CommentCollapseLinesKey: "many"}})
+	g.Expect(err).To(MatchError(`commentCollapseLines must be a non-negative integer: "many"`))
+
+	tmpl, err = ReadCommentTemplate(&corev1.ConfigMap{Data: map[string]string{CommentTemplateKey: "{{ .Missing }}"}})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid commentTemplate")))
+	g.Expect(tmpl).To(BeNil())
+}
@@ -0,0 +1,108 @@
+package selfcheck
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	infraGroup         = "infra.contrib.fluxcd.io"
+	sourceGroup        = "source.toolkit.fluxcd.io"
+	appsGroup          = "apps"
+	authorizationGroup = "authorization.k8s.io"
+	coordinationGroup  = "coordination.k8s.io"
+)
+
+// Features are the configured features of the controller which need
+// permissions of their own.
+type Features struct {
+	// WatchNamespace is the namespace the controller watches, or empty
+	// when it watches all of them.
+	WatchNamespace string
+	// LeaderElectionNamespace is the namespace of the leader election
+	// lease, or empty when the leader election is disabled.
+	LeaderElectionNamespace string
+	// ControllerConfig tells whether a TerraformControllerConfig overrides
+	// the flags.
+	ControllerConfig bool
+	// PauseConfigMap is the ConfigMap pausing the applies, if any.
+	PauseConfigMap types.NamespacedName
+	// SlackSecret is the Secret of the Slack app, if any.
+	SlackSecret types.NamespacedName
+}
+
+// Required returns the permissions the features need, and only those. The
+// webhooks of the namespace policies and of the dry runs need none of their
+// own, and neither do the Kubernetes backends of other clusters, whose
+// kubeconfig Secret is mounted in the runner pods by the kubelet.
+func Required(features Features) []Permission {
+	ns := features.WatchNamespace
+
+	permissions := grant("Terraform objects", infraGroup, []string{"terraforms"}, ns, "get", "list", "watch", "create", "update", "patch", "delete")
+	permissions = append(permissions, grant("Terraform objects", infraGroup, []string{"terraforms/status"}, ns, "update", "patch")...)
+	permissions = append(permissions, grant("Terraform objects", infraGroup, []string{"terraforms/finalizers"}, ns, "update")...)
+	permissions = append(permissions, grant("Terraform objects", "", []string{"events"}, ns, "create", "patch")...)
+	permissions = append(permissions, grant("sources", sourceGroup, []string{"buckets", "gitrepositories", "ocirepositories"}, ns, "get", "list", "watch")...)
+	permissions = append(permissions, grant("pinned sources", sourceGroup, []string{"gitrepositories"}, ns, "create", "update", "patch", "delete")...)
+	permissions = append(permissions, grant("variables and outputs", "", []string{"configmaps", "secrets"}, ns, "get", "list", "watch")...)
+	permissions = append(permissions, grant("runner pods", "", []string{"pods"}, ns, "get", "list", "watch", "create", "delete")...)
+	permissions = append(permissions, grant("runner pods", "", []string{"pods/log"}, ns, "get")...)
+	permissions = append(permissions, grant("runner diagnostics and provider locks", "", []string{"configmaps"}, ns, "create", "update", "patch")...)
+	permissions = append(permissions, grant("runner operations", coordinationGroup, []string{"leases"}, ns, "get")...)
+	permissions = append(permissions, grant("runner mTLS", "", []string{"secrets"}, ns, "create", "delete")...)
+	permissions = append(permissions, grant("outputs consumers", appsGroup, []string{"daemonsets", "deployments", "statefulsets"}, ns, "get", "list")...)
+	permissions = append(permissions, grant("namespace policies", infraGroup, []string{"terraformnamespacepolicies"}, ns, "get", "list", "watch")...)
+	permissions = append(permissions, grant("namespace policies", infraGroup, []string{"terraformnamespacepolicies/status"}, ns, "update")...)
+	permissions = append(permissions, grant("Terraform instances", infraGroup, []string{"terraforminstances"}, ns, "get", "list", "watch", "update", "patch")...)
+	permissions = append(permissions, grant("Terraform instances", infraGroup, []string{"terraforminstances/status"}, ns, "update", "patch")...)
+	// the templates, the approval queues and the namespaces are cluster-scoped
+	permissions = append(permissions, grant("Terraform instances", infraGroup, []string{"terraformtemplates"}, "", "get", "list", "watch")...)
+	permissions = append(permissions, grant("approval queues", infraGroup, []string{"terraformapprovalqueues"}, "", "get", "list", "watch")...)
+	permissions = append(permissions, grant("approval queues", infraGroup, []string{"terraformapprovalqueues/status"}, "", "update", "patch")...)
+	permissions = append(permissions, grant("approval queues", "", []string{"namespaces"}, "", "get", "list", "watch")...)
+
+	if features.LeaderElectionNamespace != "" {
+		permissions = append(permissions, grant("leader election", coordinationGroup, []string{"leases"}, features.LeaderElectionNamespace, "get", "create", "update")...)
+	}
+	if features.ControllerConfig {
+		permissions = append(permissions, grant("controller config", infraGroup, []string{"terraformcontrollerconfigs"}, "", "get", "list", "watch")...)
+		permissions = append(permissions, grant("controller config", infraGroup, []string{"terraformcontrollerconfigs/status"}, "", "update", "patch")...)
+	}
+	if features.PauseConfigMap.Name != "" {
+		permissions = append(permissions, grant("cluster-wide pause", "", []string{"configmaps"}, features.PauseConfigMap.Namespace, "get", "list", "watch")...)
+	}
+	if features.SlackSecret.Name != "" {
+		permissions = append(permissions, grant("Slack", "", []string{"secrets"}, features.SlackSecret.Namespace, "get", "list", "watch")...)
+		permissions = append(permissions, grant("Slack", authorizationGroup, []string{"subjectaccessreviews"}, "", "create")...)
+	}
+	return permissions
+}
+
+// CrossNamespaceRefs returns the permissions the cross-namespace references
+// need: reading the sources of all the namespaces, and reviewing the access
+// of the runners to the state kept in another namespace.
+func CrossNamespaceRefs() []Permission {
+	permissions := grant("cross-namespace references", sourceGroup, []string{"buckets", "gitrepositories", "ocirepositories"}, "", "get")
+	return append(permissions, grant("cross-namespace references", authorizationGroup, []string{"subjectaccessreviews"}, "", "create")...)
+}
+
+// grant returns the permissions of the verbs on the resources, which may
+// name a subresource after a slash.
+func grant(feature, group string, resources []string, namespace string, verbs ...string) []Permission {
+	var permissions []Permission
+	for _, resource := range resources {
+		resource, subresource, _ := strings.Cut(resource, "/")
+		for _, verb := range verbs {
+			permissions = append(permissions, Permission{
+				Feature:     feature,
+				Verb:        verb,
+				Group:       group,
+				Resource:    resource,
+				Subresource: subresource,
+				Namespace:   namespace,
+			})
+		}
+	}
+	return permissions
+}
@@ -0,0 +1,98 @@
+// Package selfcheck verifies at startup that the controller is granted the
+// RBAC permissions its configured features need, so that it can run with a
+// minimal role rather than cluster-admin, and fail fast with the precise list
+// of the missing permissions rather than on the first reconciliation using
+// them.
+package selfcheck
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+// Permission is a verb on a resource, in a namespace or cluster-wide when
+// the namespace is empty, needed by a feature of the controller.
+type Permission struct {
+	Feature     string
+	Verb        string
+	Group       string
+	Resource    string
+	Subresource string
+	Namespace   string
+}
+
+// String returns the permission as the rule granting it would read, e.g.
+// "list terraforms.infra.contrib.fluxcd.io (cluster-wide)".
+func (p Permission) String() string {
+	resource := p.Resource
+	if p.Group != "" {
+		resource += "." + p.Group
+	}
+	if p.Subresource != "" {
+		resource += "/" + p.Subresource
+	}
+	scope := "cluster-wide"
+	if p.Namespace != "" {
+		scope = "in namespace " + p.Namespace
+	}
+	return fmt.Sprintf("%s %s (%s)", p.Verb, resource, scope)
+}
+
+// MissingPermissionsError is returned by Verify when the controller lacks
+// permissions its features need.
+type MissingPermissionsError struct {
+	Permissions []Permission
+}
+
+func (e *MissingPermissionsError) Error() string {
+	missing := make([]string, 0, len(e.Permissions))
+	for _, permission := range e.Permissions {
+		missing = append(missing, permission.Feature+": "+permission.String())
+	}
+	return fmt.Sprintf("missing %d RBAC permission(s): %s", len(missing), strings.Join(missing, "; "))
+}
+
+// Missing reviews the permissions with SelfSubjectAccessReviews, which any
+// authenticated user may create, and returns the ones denied to the
+// controller.
+func Missing(ctx context.Context, reviews authorizationv1client.SelfSubjectAccessReviewInterface, permissions []Permission) ([]Permission, error) {
+	var missing []Permission
+	for _, permission := range permissions {
+		review, err := reviews.Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace:   permission.Namespace,
+					Verb:        permission.Verb,
+					Group:       permission.Group,
+					Resource:    permission.Resource,
+					Subresource: permission.Subresource,
+				},
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("unable to review the permission to %s: %w", permission, err)
+		}
+		if !review.Status.Allowed {
+			missing = append(missing, permission)
+		}
+	}
+	return missing, nil
+}
+
+// Verify returns a MissingPermissionsError listing the permissions denied to
+// the controller, if any.
+func Verify(ctx context.Context, reviews authorizationv1client.SelfSubjectAccessReviewInterface, permissions []Permission) error {
+	missing, err := Missing(ctx, reviews, permissions)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return &MissingPermissionsError{Permissions: missing}
+	}
+	return nil
+}
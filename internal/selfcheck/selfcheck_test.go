@@ -0,0 +1,97 @@
+package selfcheck
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// reviewsDenying returns SelfSubjectAccessReviews allowing all the
+// permissions but the denied ones.
+func reviewsDenying(denied ...Permission) *fake.Clientset {
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		attributes := review.Spec.ResourceAttributes
+		review.Status.Allowed = true
+		for _, permission := range denied {
+			if permission.Verb == attributes.Verb && permission.Group == attributes.Group &&
+				permission.Resource == attributes.Resource && permission.Subresource == attributes.Subresource &&
+				permission.Namespace == attributes.Namespace {
+				review.Status.Allowed = false
+			}
+		}
+		return true, review, nil
+	})
+	return clientset
+}
+
+func TestVerify(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	features := Features{WatchNamespace: "flux-system", LeaderElectionNamespace: "flux-system"}
+	permissions := Required(features)
+
+	allowed := reviewsDenying()
+	g.Expect(Verify(ctx, allowed.AuthorizationV1().SelfSubjectAccessReviews(), permissions)).To(Succeed())
+
+	podLogs := Permission{Verb: "get", Resource: "pods", Subresource: "log", Namespace: "flux-system"}
+	templates := Permission{Verb: "list", Group: "infra.contrib.fluxcd.io", Resource: "terraformtemplates"}
+	denying := reviewsDenying(podLogs, templates)
+	err := Verify(ctx, denying.AuthorizationV1().SelfSubjectAccessReviews(), permissions)
+	g.Expect(err).To(MatchError("missing 2 RBAC permission(s): " +
+		"runner pods: get pods/log (in namespace flux-system); " +
+		"Terraform instances: list terraformtemplates.infra.contrib.fluxcd.io (cluster-wide)"))
+}
+
+func TestRequired(t *testing.T) {
+	g := NewWithT(t)
+
+	has := func(permissions []Permission, verb, group, resource, namespace string) bool {
+		for _, permission := range permissions {
+			if permission.Verb == verb && permission.Group == group && permission.Resource == resource && permission.Namespace == namespace {
+				return true
+			}
+		}
+		return false
+	}
+
+	// only the permissions of the configured features are required
+	permissions := Required(Features{})
+	g.Expect(has(permissions, "watch", "infra.contrib.fluxcd.io", "terraforms", "")).To(BeTrue())
+	g.Expect(has(permissions, "list", "infra.contrib.fluxcd.io", "terraformcontrollerconfigs", "")).To(BeFalse())
+	g.Expect(has(permissions, "create", "coordination.k8s.io", "leases", "")).To(BeFalse())
+	g.Expect(has(permissions, "create", "authorization.k8s.io", "subjectaccessreviews", "")).To(BeFalse())
+
+	permissions = Required(Features{
+		WatchNamespace:          "flux-system",
+		LeaderElectionNamespace: "flux-system",
+		ControllerConfig:        true,
+		PauseConfigMap:          types.NamespacedName{Namespace: "ops", Name: "pause"},
+		SlackSecret:             types.NamespacedName{Namespace: "flux-system", Name: "slack"},
+	})
+	g.Expect(has(permissions, "watch", "infra.contrib.fluxcd.io", "terraforms", "")).To(BeFalse())
+	g.Expect(has(permissions, "watch", "infra.contrib.fluxcd.io", "terraforms", "flux-system")).To(BeTrue())
+	g.Expect(has(permissions, "list", "infra.contrib.fluxcd.io", "terraformcontrollerconfigs", "")).To(BeTrue())
+	g.Expect(has(permissions, "create", "coordination.k8s.io", "leases", "flux-system")).To(BeTrue())
+	g.Expect(has(permissions, "watch", "", "configmaps", "ops")).To(BeTrue())
+	g.Expect(has(permissions, "create", "authorization.k8s.io", "subjectaccessreviews", "")).To(BeTrue())
+}
+
+func TestMissingCrossNamespaceRefs(t *testing.T) {
+	g := NewWithT(t)
+
+	reviews := Permission{Verb: "create", Group: "authorization.k8s.io", Resource: "subjectaccessreviews"}
+	clientset := reviewsDenying(reviews)
+	missing, err := Missing(context.Background(), clientset.AuthorizationV1().SelfSubjectAccessReviews(), CrossNamespaceRefs())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(missing).To(HaveLen(1))
+	g.Expect(missing[0].String()).To(Equal("create subjectaccessreviews.authorization.k8s.io (cluster-wide)"))
+}
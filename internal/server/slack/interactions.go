@@ -0,0 +1,267 @@
+package slack
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// PlanApprovedByAnnotation records the Kubernetes user who approved the
+	// last plan of a Terraform object from Slack.
+	PlanApprovedByAnnotation = "infra.contrib.fluxcd.io/plan-approved-by"
+	// PlanRejectedByAnnotation records the Kubernetes user who rejected the
+	// last plan of a Terraform object from Slack.
+	PlanRejectedByAnnotation = "infra.contrib.fluxcd.io/plan-rejected-by"
+
+	// maxRequestAge is how old the timestamp of an interaction can be, to
+	// prevent replays.
+	maxRequestAge = 5 * time.Minute
+	maxBodySize   = 1 << 20
+)
+
+// interaction is the payload of a block_actions interaction.
+type interaction struct {
+	Type        string `json:"type"`
+	ResponseURL string `json:"response_url"`
+	User        struct {
+		ID string `json:"id"`
+	} `json:"user"`
+	Actions []struct {
+		ActionID string `json:"action_id"`
+		BlockID  string `json:"block_id"`
+		Value    string `json:"value"`
+	} `json:"actions"`
+}
+
+// InteractionsHandler serves the interactivity requests of the Slack app,
+// sent when the Approve or Reject button of a notification is clicked.
+// Requests are authenticated with the signing secret of the app, and are
+// handled once. The Slack user is authorized as its Kubernetes user to patch
+// the Terraform object.
+func (s *Server) InteractionsHandler() http.Handler {
+	return http.HandlerFunc(s.serveInteractions)
+}
+
+func (s *Server) serveInteractions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, "unable to read the request", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	config, err := s.readConfig(ctx)
+	if err != nil {
+		s.log.Error(err, "failed to read the config")
+		http.Error(w, "failed to read the config", http.StatusInternalServerError)
+		return
+	}
+	if config.SigningSecret == "" {
+		http.Error(w, fmt.Sprintf("the interactions are disabled: the secret has no %s key", SigningSecretKey), http.StatusServiceUnavailable)
+		return
+	}
+	if err := verifySignature(config.SigningSecret, r.Header, body, time.Now()); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if !s.replays.add(r.Header.Get("X-Slack-Signature"), time.Now()) {
+		http.Error(w, "request already handled", http.StatusConflict)
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+	var payload interaction
+	if err := json.Unmarshal([]byte(form.Get("payload")), &payload); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	// Slack expects the request to be acknowledged, the outcome is posted to
+	// the response URL.
+	w.WriteHeader(http.StatusOK)
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+
+	if payload.Type != "block_actions" {
+		return
+	}
+	for _, action := range payload.Actions {
+		if action.BlockID != actionsBlock {
+			continue
+		}
+
+		var value planAction
+		if err := json.Unmarshal([]byte(action.Value), &value); err != nil {
+			s.log.Error(err, "invalid action value", "value", action.Value)
+			continue
+		}
+
+		reply := s.review(ctx, config, payload.User.ID, action.ActionID, value)
+		if payload.ResponseURL == "" {
+			continue
+		}
+		if err := s.postURL(ctx, "", payload.ResponseURL, reply); err != nil {
+			s.log.Error(err, "failed to respond to the interaction")
+		}
+	}
+}
+
+// verifySignature verifies the signature of a request of Slack, computed
+// with the signing secret over its timestamp and body.
+func verifySignature(signingSecret string, header http.Header, body []byte, now time.Time) error {
+	timestamp := header.Get("X-Slack-Request-Timestamp")
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid request timestamp")
+	}
+	if age := now.Sub(time.Unix(seconds, 0)); age > maxRequestAge || age < -maxRequestAge {
+		return fmt.Errorf("request timestamp too old")
+	}
+
+	mac := hmac.New(sha256.New, []byte(signingSecret))
+	fmt.Fprintf(mac, "v0:%s:", timestamp)
+	mac.Write(body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(header.Get("X-Slack-Signature"))) {
+		return fmt.Errorf("invalid signature")
+	}
+	return nil
+}
+
+// review approves or rejects the plan as the Kubernetes user of the Slack
+// user, and returns the message to respond with. The notification is only
+// replaced once the plan is reviewed, for another user to review it
+// otherwise. A rejected plan is discarded.
+func (s *Server) review(ctx context.Context, config *Config, slackUserID, actionID string, value planAction) message {
+	ephemeral := func(format string, args ...interface{}) message {
+		return message{Text: fmt.Sprintf(format, args...), ResponseType: "ephemeral"}
+	}
+
+	key := types.NamespacedName{Namespace: value.Namespace, Name: value.Name}
+	log := s.log.WithValues("terraform", key, "plan", value.PlanID, "slackUser", slackUserID)
+
+	user, ok := config.Users[slackUserID]
+	if !ok || user.Username == "" {
+		return ephemeral("Your Slack user %s is not mapped to a Kubernetes user, ask an administrator to add it to the %s of the Slack app.", slackUserID, UsersKey)
+	}
+
+	allowed, err := s.authorize(ctx, user, key)
+	if err != nil {
+		log.Error(err, "failed to authorize the Slack user")
+		return ephemeral("Unable to check your permissions on `%s`.", key)
+	}
+	if !allowed {
+		log.Info("Slack user not allowed to review the plan", "username", user.Username)
+		return ephemeral("%s is not allowed to patch Terraform `%s`.", user.Username, key)
+	}
+
+	terraform := &infrav1.Terraform{}
+	if err := s.clusterClient.Get(ctx, key, terraform); err != nil {
+		log.Error(err, "failed to get the Terraform object")
+		return ephemeral("Unable to get Terraform `%s`.", key)
+	}
+	if terraform.Status.Plan.Pending != value.PlanID {
+		return message{Text: fmt.Sprintf("Plan `%s` of `%s` is no longer pending approval.", value.PlanID, key), ReplaceOriginal: true}
+	}
+
+	patch := client.MergeFrom(terraform.DeepCopy())
+	annotations := terraform.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	var verb, reason string
+	switch actionID {
+	case actionApprove:
+		verb, reason = "approved", "PlanApproved"
+		terraform.Spec.ApprovePlan = value.PlanID
+		annotations[PlanApprovedByAnnotation] = user.Username
+	case actionReject:
+		verb, reason = "rejected", infrav1.PlanRejectedReason
+		annotations[PlanRejectedByAnnotation] = user.Username
+	default:
+		return ephemeral("Unknown action %q.", actionID)
+	}
+	terraform.SetAnnotations(annotations)
+
+	if err := s.clusterClient.Patch(ctx, terraform, patch); err != nil {
+		log.Error(err, "failed to patch the Terraform object")
+		return ephemeral("Unable to patch Terraform `%s`.", key)
+	}
+
+	msg := fmt.Sprintf("Plan %s %s by %s from Slack (user %s)", value.PlanID, verb, user.Username, slackUserID)
+	if actionID == actionReject {
+		if err := s.discardPlan(ctx, terraform, msg); err != nil {
+			log.Error(err, "failed to discard the rejected plan")
+			return ephemeral("Unable to discard the plan of Terraform `%s`.", key)
+		}
+	}
+	log.Info(msg)
+	if s.recorder != nil {
+		s.recorder.Event(terraform, corev1.EventTypeNormal, reason, msg)
+	}
+
+	return message{Text: fmt.Sprintf("Plan `%s` of `%s` %s by <@%s>.", value.PlanID, key, verb, slackUserID), ReplaceOriginal: true}
+}
+
+// discardPlan discards the pending plan of the Terraform object, as its
+// expiry does, so that the rejected plan can no longer be approved. The
+// controller plans again, and the new plan needs its own approval.
+func (s *Server) discardPlan(ctx context.Context, terraform *infrav1.Terraform, msg string) error {
+	patch := client.MergeFrom(terraform.DeepCopy())
+	plan := &terraform.Status.Plan
+	plan.Pending = ""
+	plan.PendingSince = nil
+	plan.RemindedAt = nil
+	plan.EscalatedAt = nil
+	*terraform = infrav1.TerraformNotReady(*terraform, "", infrav1.PlanRejectedReason, msg)
+	return s.clusterClient.Status().Patch(ctx, terraform, patch)
+}
+
+// authorize checks that the Kubernetes user of the Slack user may patch the
+// Terraform object, as approving a plan with kubectl or tfctl requires.
+func (s *Server) authorize(ctx context.Context, user User, key types.NamespacedName) (bool, error) {
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			Groups: user.Groups,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: key.Namespace,
+				Name:      key.Name,
+				Group:     infrav1.GroupVersion.Group,
+				Resource:  "terraforms",
+				Verb:      "patch",
+			},
+		},
+	}
+	if err := s.clusterClient.Create(ctx, review); err != nil {
+		return false, err
+	}
+	return review.Status.Allowed, nil
+}
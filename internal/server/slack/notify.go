@@ -0,0 +1,129 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+)
+
+const (
+	actionApprove = "approve"
+	actionReject  = "reject"
+	actionsBlock  = "tf-controller-plan"
+)
+
+// planAction is the value of the buttons of a notification.
+type planAction struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	PlanID    string `json:"planId"`
+}
+
+type text struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type element struct {
+	Type     string `json:"type"`
+	ActionID string `json:"action_id"`
+	Style    string `json:"style,omitempty"`
+	Text     text   `json:"text"`
+	Value    string `json:"value"`
+}
+
+type block struct {
+	Type     string    `json:"type"`
+	BlockID  string    `json:"block_id,omitempty"`
+	Text     *text     `json:"text,omitempty"`
+	Elements []element `json:"elements,omitempty"`
+}
+
+type message struct {
+	Channel         string  `json:"channel,omitempty"`
+	Text            string  `json:"text"`
+	Blocks          []block `json:"blocks,omitempty"`
+	ReplaceOriginal bool    `json:"replace_original,omitempty"`
+	ResponseType    string  `json:"response_type,omitempty"`
+}
+
+// NotifyPlanPending posts the plan of the Terraform object pending approval
+// to its channel, with the buttons to approve or reject it. The channel is
+// the one of the Slack app, unless the object overrides it with an
+// annotation. Nothing is posted without a channel.
+func (s *Server) NotifyPlanPending(ctx context.Context, terraform infrav1.Terraform, planID string) error {
+	summary := fmt.Sprintf("Plan `%s` of `%s/%s` is pending approval", planID, terraform.Namespace, terraform.Name)
+	return s.postPlan(ctx, terraform, planID, summary, "")
+}
+
+// RemindPlanPending posts the plan of the Terraform object pending approval
+// for a while again, to its channel and, when it is escalated, to the
+// escalation channel too.
+func (s *Server) RemindPlanPending(ctx context.Context, terraform infrav1.Terraform, planID string, pendingFor time.Duration, escalationChannel string) error {
+	summary := fmt.Sprintf("Plan `%s` of `%s/%s` has been pending approval for %s", planID, terraform.Namespace, terraform.Name, pendingFor)
+	return s.postPlan(ctx, terraform, planID, summary, escalationChannel)
+}
+
+// postPlan posts the plan with the buttons to approve or reject it to the
+// channel of the Terraform object, and to the extra channel when it is not
+// empty.
+func (s *Server) postPlan(ctx context.Context, terraform infrav1.Terraform, planID, summary, extraChannel string) error {
+	config, err := s.readConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	channel := config.Channel
+	if c := terraform.GetAnnotations()[ChannelAnnotation]; c != "" {
+		channel = c
+	}
+	var channels []string
+	for _, c := range []string{channel, extraChannel} {
+		if c != "" {
+			channels = append(channels, c)
+		}
+	}
+	if len(channels) == 0 {
+		return nil
+	}
+	if config.BotToken == "" {
+		return fmt.Errorf("the Secret %s has no %s", s.secretRef, BotTokenKey)
+	}
+
+	value, err := json.Marshal(planAction{Namespace: terraform.Namespace, Name: terraform.Name, PlanID: planID})
+	if err != nil {
+		return err
+	}
+
+	details := summary
+	if revision := terraform.Status.LastAttemptedRevision; revision != "" {
+		details += fmt.Sprintf("\nRevision: `%s`", revision)
+	}
+	if changes := terraform.Status.Plan.Changes; changes != nil {
+		details += fmt.Sprintf("\n%d to add, %d to change, %d to destroy", changes.Add, changes.Change, changes.Destroy)
+	}
+
+	for _, channel := range channels {
+		if err := s.post(ctx, config.BotToken, "chat.postMessage", message{
+			Channel: channel,
+			Text:    summary,
+			Blocks: []block{
+				{Type: "section", Text: &text{Type: "mrkdwn", Text: details}},
+				{
+					Type:    "actions",
+					BlockID: actionsBlock,
+					Elements: []element{
+						{Type: "button", ActionID: actionApprove, Style: "primary", Text: text{Type: "plain_text", Text: "Approve"}, Value: string(value)},
+						{Type: "button", ActionID: actionReject, Style: "danger", Text: text{Type: "plain_text", Text: "Reject"}, Value: string(value)},
+					},
+				},
+			},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
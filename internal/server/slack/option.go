@@ -0,0 +1,62 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type Option func(s *Server) error
+
+func WithLogger(log logr.Logger) Option {
+	return func(s *Server) error {
+		s.log = log
+
+		return nil
+	}
+}
+
+func WithClusterClient(clusterClient client.Client) Option {
+	return func(s *Server) error {
+		s.clusterClient = clusterClient
+
+		return nil
+	}
+}
+
+func WithEventRecorder(recorder record.EventRecorder) Option {
+	return func(s *Server) error {
+		s.recorder = recorder
+
+		return nil
+	}
+}
+
+// WithSecret sets the namespace/name of the Secret of the Slack app.
+func WithSecret(secretName string) Option {
+	return func(s *Server) error {
+		parts := strings.SplitN(secretName, "/", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("invalid Secret reference, expected namespace/name: %q", secretName)
+		}
+
+		s.secretRef = client.ObjectKey{
+			Namespace: parts[0],
+			Name:      parts[1],
+		}
+
+		return nil
+	}
+}
+
+// WithAPIURL sets the URL of the Slack Web API.
+func WithAPIURL(url string) Option {
+	return func(s *Server) error {
+		s.apiURL = strings.TrimSuffix(url, "/")
+
+		return nil
+	}
+}
@@ -0,0 +1,41 @@
+package slack
+
+import (
+	"sync"
+	"time"
+)
+
+// replayCache remembers the signatures of the interactions handled while
+// their timestamp is recent enough to be accepted, so that a captured
+// request cannot be replayed to review a plan again. The signature covers
+// the timestamp and the body of the request, so it identifies the request.
+type replayCache struct {
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+func newReplayCache() *replayCache {
+	return &replayCache{seen: map[string]time.Time{}}
+}
+
+// add records the signature of a request, and tells whether it was not seen
+// before. The signatures are forgotten once their request would be refused
+// for its timestamp anyway.
+func (c *replayCache) add(signature string, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for seen, expiry := range c.seen {
+		if now.After(expiry) {
+			delete(c.seen, seen)
+		}
+	}
+
+	if _, ok := c.seen[signature]; ok {
+		return false
+	}
+	// the timestamp of a request may be ahead of the clock by up to the
+	// maximum age too
+	c.seen[signature] = now.Add(2 * maxRequestAge)
+	return true
+}
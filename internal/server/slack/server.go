@@ -0,0 +1,158 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	DefaultAPIURL = "https://slack.com/api"
+
+	// BotTokenKey is the key of the Secret holding the bot token of the
+	// Slack app, to post the notifications.
+	BotTokenKey = "botToken"
+	// SigningSecretKey is the key of the Secret holding the signing secret
+	// of the Slack app, to verify the interactions.
+	SigningSecretKey = "signingSecret"
+	// ChannelKey is the key of the Secret holding the default channel of
+	// the notifications.
+	ChannelKey = "channel"
+	// UsersKey is the key of the Secret mapping the Slack user IDs to the
+	// Kubernetes users their approvals are authorized as.
+	UsersKey = "users"
+
+	// ChannelAnnotation overrides the channel of the notifications of a
+	// Terraform object.
+	ChannelAnnotation = "infra.contrib.fluxcd.io/slack-channel"
+)
+
+// User is the Kubernetes identity of a Slack user.
+type User struct {
+	Username string   `json:"username"`
+	Groups   []string `json:"groups,omitempty"`
+}
+
+// Config is the configuration of the Slack app, read from its Secret.
+type Config struct {
+	BotToken      string
+	SigningSecret string
+	Channel       string
+	Users         map[string]User
+}
+
+// Server posts the plans pending approval to Slack, and serves the
+// interactions of their Approve and Reject buttons.
+type Server struct {
+	log           logr.Logger
+	clusterClient client.Client
+	recorder      record.EventRecorder
+	secretRef     client.ObjectKey
+	apiURL        string
+	httpClient    *http.Client
+	replays       *replayCache
+}
+
+func New(options ...Option) (*Server, error) {
+	server := &Server{
+		log:        logr.Discard(),
+		apiURL:     DefaultAPIURL,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		replays:    newReplayCache(),
+	}
+
+	for _, opt := range options {
+		if err := opt(server); err != nil {
+			return nil, err
+		}
+	}
+
+	if server.clusterClient == nil {
+		return nil, fmt.Errorf("a cluster client is required")
+	}
+	if server.secretRef.Name == "" {
+		return nil, fmt.Errorf("the Secret of the Slack app is required")
+	}
+
+	return server, nil
+}
+
+// readConfig reads the Secret of the Slack app on each use, so that it can
+// be rotated.
+func (s *Server) readConfig(ctx context.Context) (*Config, error) {
+	secret := &corev1.Secret{}
+	if err := s.clusterClient.Get(ctx, s.secretRef, secret); err != nil {
+		return nil, fmt.Errorf("unable to get Secret %s: %w", s.secretRef, err)
+	}
+
+	config := &Config{
+		BotToken:      string(secret.Data[BotTokenKey]),
+		SigningSecret: string(secret.Data[SigningSecretKey]),
+		Channel:       string(secret.Data[ChannelKey]),
+		Users:         map[string]User{},
+	}
+	if err := yaml.Unmarshal(secret.Data[UsersKey], &config.Users); err != nil {
+		return nil, fmt.Errorf("unable to parse the %s of Secret %s: %w", UsersKey, s.secretRef, err)
+	}
+
+	return config, nil
+}
+
+// post calls a method of the Slack Web API.
+func (s *Server) post(ctx context.Context, token, method string, body interface{}) error {
+	return s.postURL(ctx, token, s.apiURL+"/"+method, body)
+}
+
+func (s *Server) postURL(ctx context.Context, token, url string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	// the response URLs answer with a plain "ok"
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		return nil
+	}
+
+	var reply struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&reply); err != nil {
+		return fmt.Errorf("unable to decode the response: %w", err)
+	}
+	if !reply.OK {
+		return fmt.Errorf("slack API error: %s", reply.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,330 @@
+package slack
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+)
+
+const signingSecret = "8f742231b10e8888abcd99yyyzzz85a5"
+
+// slackAPI records the messages posted to it.
+type slackAPI struct {
+	*httptest.Server
+	messages chan message
+}
+
+func newSlackAPI(t *testing.T) *slackAPI {
+	api := &slackAPI{messages: make(chan message, 10)}
+	api.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg message
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		api.messages <- msg
+
+		if r.URL.Path == "/response" {
+			_, _ = io.WriteString(w, "ok")
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer xoxb-token" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, `{"ok":false,"error":"invalid_auth"}`)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"ok":true}`)
+	}))
+	t.Cleanup(api.Close)
+	return api
+}
+
+func newServer(g gomega.Gomega, api *slackAPI, recorder record.EventRecorder, allowed bool, objects ...client.Object) (*Server, client.Client) {
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(gomega.Succeed())
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "flux-system", Name: "slack"},
+		Data: map[string][]byte{
+			BotTokenKey:      []byte("xoxb-token"),
+			SigningSecretKey: []byte(signingSecret),
+			ChannelKey:       []byte("C000"),
+			UsersKey:         []byte("U123:\n  username: alice\n  groups: [platform]\n"),
+		},
+	}
+
+	interceptors := interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if review, ok := obj.(*authorizationv1.SubjectAccessReview); ok {
+				review.Status.Allowed = allowed && review.Spec.User == "alice" && review.Spec.ResourceAttributes.Verb == "patch"
+				return nil
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}
+	clusterClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(append(objects, secret)...).
+		WithStatusSubresource(&infrav1.Terraform{}).
+		WithInterceptorFuncs(interceptors).
+		Build()
+
+	server, err := New(
+		WithClusterClient(clusterClient),
+		WithEventRecorder(recorder),
+		WithSecret("flux-system/slack"),
+		WithAPIURL(api.URL),
+	)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	return server, clusterClient
+}
+
+func pendingTerraform() *infrav1.Terraform {
+	return &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "dev", Name: "helloworld"},
+		Status: infrav1.TerraformStatus{
+			LastAttemptedRevision: "main@sha1:b8e362c206e3d0cbb7ed22ced771a0056455a2fb",
+			Plan: infrav1.PlanStatus{
+				Pending: "plan-main-b8e362c206",
+				Changes: &infrav1.PlanChanges{Add: 1, Change: 2, Destroy: 3},
+			},
+		},
+	}
+}
+
+func signedRequest(payload string, now time.Time) *http.Request {
+	body := url.Values{"payload": {payload}}.Encode()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(signingSecret))
+	fmt.Fprintf(mac, "v0:%s:%s", timestamp, body)
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+	req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func interactionPayload(responseURL, userID, actionID, planID string) string {
+	value, _ := json.Marshal(planAction{Namespace: "dev", Name: "helloworld", PlanID: planID})
+	payload, _ := json.Marshal(map[string]interface{}{
+		"type":         "block_actions",
+		"response_url": responseURL,
+		"user":         map[string]string{"id": userID},
+		"actions": []map[string]string{
+			{"action_id": actionID, "block_id": actionsBlock, "value": string(value)},
+		},
+	})
+	return string(payload)
+}
+
+func Test_verifySignature(t *testing.T) {
+	g := gomega.NewWithT(t)
+	now := time.Now()
+
+	req := signedRequest("{}", now)
+	body, _ := io.ReadAll(req.Body)
+	g.Expect(verifySignature(signingSecret, req.Header, body, now)).To(gomega.Succeed())
+
+	g.Expect(verifySignature("another secret", req.Header, body, now)).ToNot(gomega.Succeed())
+	g.Expect(verifySignature(signingSecret, req.Header, append(body, 'x'), now)).ToNot(gomega.Succeed())
+	g.Expect(verifySignature(signingSecret, req.Header, body, now.Add(10*time.Minute))).ToNot(gomega.Succeed())
+}
+
+func Test_NotifyPlanPending(t *testing.T) {
+	g := gomega.NewWithT(t)
+	api := newSlackAPI(t)
+	server, _ := newServer(g, api, nil, true)
+
+	terraform := pendingTerraform()
+	g.Expect(server.NotifyPlanPending(context.TODO(), *terraform, terraform.Status.Plan.Pending)).To(gomega.Succeed())
+
+	msg := <-api.messages
+	g.Expect(msg.Channel).To(gomega.Equal("C000"))
+	g.Expect(msg.Text).To(gomega.ContainSubstring("plan-main-b8e362c206"))
+	g.Expect(msg.Blocks).To(gomega.HaveLen(2))
+	g.Expect(msg.Blocks[0].Text.Text).To(gomega.ContainSubstring("1 to add, 2 to change, 3 to destroy"))
+	g.Expect(msg.Blocks[1].Elements).To(gomega.HaveLen(2))
+
+	var value planAction
+	g.Expect(json.Unmarshal([]byte(msg.Blocks[1].Elements[0].Value), &value)).To(gomega.Succeed())
+	g.Expect(value).To(gomega.Equal(planAction{Namespace: "dev", Name: "helloworld", PlanID: "plan-main-b8e362c206"}))
+
+	// the channel of the annotation overrides the one of the app
+	terraform.SetAnnotations(map[string]string{ChannelAnnotation: "C111"})
+	g.Expect(server.NotifyPlanPending(context.TODO(), *terraform, terraform.Status.Plan.Pending)).To(gomega.Succeed())
+	msg = <-api.messages
+	g.Expect(msg.Channel).To(gomega.Equal("C111"))
+}
+
+func Test_serveInteractions(t *testing.T) {
+	g := gomega.NewWithT(t)
+	api := newSlackAPI(t)
+	recorder := record.NewFakeRecorder(10)
+	server, clusterClient := newServer(g, api, recorder, true, pendingTerraform())
+	handler := server.InteractionsHandler()
+
+	// an unsigned request is refused
+	req := signedRequest(interactionPayload(api.URL+"/response", "U123", actionApprove, "plan-main-b8e362c206"), time.Now())
+	req.Header.Set("X-Slack-Signature", "v0=00")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	g.Expect(rec.Code).To(gomega.Equal(http.StatusUnauthorized))
+
+	// an unmapped Slack user is told so, and the plan is left pending
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, signedRequest(interactionPayload(api.URL+"/response", "U999", actionApprove, "plan-main-b8e362c206"), time.Now()))
+	g.Expect(rec.Code).To(gomega.Equal(http.StatusOK))
+	reply := <-api.messages
+	g.Expect(reply.ResponseType).To(gomega.Equal("ephemeral"))
+	g.Expect(reply.ReplaceOriginal).To(gomega.BeFalse())
+
+	terraform := &infrav1.Terraform{}
+	g.Expect(clusterClient.Get(context.TODO(), client.ObjectKey{Namespace: "dev", Name: "helloworld"}, terraform)).To(gomega.Succeed())
+	g.Expect(terraform.Spec.ApprovePlan).To(gomega.BeEmpty())
+
+	// a mapped Slack user approves the plan
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, signedRequest(interactionPayload(api.URL+"/response", "U123", actionApprove, "plan-main-b8e362c206"), time.Now()))
+	g.Expect(rec.Code).To(gomega.Equal(http.StatusOK))
+	reply = <-api.messages
+	g.Expect(reply.ReplaceOriginal).To(gomega.BeTrue())
+	g.Expect(reply.Text).To(gomega.ContainSubstring("approved by <@U123>"))
+
+	g.Expect(clusterClient.Get(context.TODO(), client.ObjectKey{Namespace: "dev", Name: "helloworld"}, terraform)).To(gomega.Succeed())
+	g.Expect(terraform.Spec.ApprovePlan).To(gomega.Equal("plan-main-b8e362c206"))
+	g.Expect(terraform.GetAnnotations()).To(gomega.HaveKeyWithValue(PlanApprovedByAnnotation, "alice"))
+	g.Expect(<-recorder.Events).To(gomega.ContainSubstring("PlanApproved"))
+
+	// an old notification cannot approve a plan which is no longer pending
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, signedRequest(interactionPayload(api.URL+"/response", "U123", actionApprove, "plan-main-0000000000"), time.Now()))
+	reply = <-api.messages
+	g.Expect(reply.Text).To(gomega.ContainSubstring("no longer pending"))
+}
+
+func Test_serveInteractions_reject(t *testing.T) {
+	g := gomega.NewWithT(t)
+	api := newSlackAPI(t)
+	recorder := record.NewFakeRecorder(10)
+	server, clusterClient := newServer(g, api, recorder, true, pendingTerraform())
+	handler := server.InteractionsHandler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, signedRequest(interactionPayload(api.URL+"/response", "U123", actionReject, "plan-main-b8e362c206"), time.Now()))
+	g.Expect(rec.Code).To(gomega.Equal(http.StatusOK))
+	reply := <-api.messages
+	g.Expect(reply.ReplaceOriginal).To(gomega.BeTrue())
+	g.Expect(reply.Text).To(gomega.ContainSubstring("rejected by <@U123>"))
+	g.Expect(<-recorder.Events).To(gomega.ContainSubstring(infrav1.PlanRejectedReason))
+
+	// the rejected plan is discarded
+	terraform := &infrav1.Terraform{}
+	g.Expect(clusterClient.Get(context.TODO(), client.ObjectKey{Namespace: "dev", Name: "helloworld"}, terraform)).To(gomega.Succeed())
+	g.Expect(terraform.GetAnnotations()).To(gomega.HaveKeyWithValue(PlanRejectedByAnnotation, "alice"))
+	g.Expect(terraform.Spec.ApprovePlan).To(gomega.BeEmpty())
+	g.Expect(terraform.Status.Plan.Pending).To(gomega.BeEmpty())
+	g.Expect(terraform.Status.Conditions).To(gomega.ContainElement(gomega.HaveField("Reason", infrav1.PlanRejectedReason)))
+
+	// so it can no longer be approved
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, signedRequest(interactionPayload(api.URL+"/response", "U123", actionApprove, "plan-main-b8e362c206"), time.Now()))
+	reply = <-api.messages
+	g.Expect(reply.Text).To(gomega.ContainSubstring("no longer pending"))
+	g.Expect(clusterClient.Get(context.TODO(), client.ObjectKey{Namespace: "dev", Name: "helloworld"}, terraform)).To(gomega.Succeed())
+	g.Expect(terraform.Spec.ApprovePlan).To(gomega.BeEmpty())
+}
+
+func Test_serveInteractions_notAllowed(t *testing.T) {
+	g := gomega.NewWithT(t)
+	api := newSlackAPI(t)
+	server, clusterClient := newServer(g, api, nil, false, pendingTerraform())
+
+	rec := httptest.NewRecorder()
+	server.InteractionsHandler().ServeHTTP(rec, signedRequest(interactionPayload(api.URL+"/response", "U123", actionReject, "plan-main-b8e362c206"), time.Now()))
+	g.Expect(rec.Code).To(gomega.Equal(http.StatusOK))
+	reply := <-api.messages
+	g.Expect(reply.ResponseType).To(gomega.Equal("ephemeral"))
+	g.Expect(reply.Text).To(gomega.ContainSubstring("not allowed"))
+
+	terraform := &infrav1.Terraform{}
+	g.Expect(clusterClient.Get(context.TODO(), client.ObjectKey{Namespace: "dev", Name: "helloworld"}, terraform)).To(gomega.Succeed())
+	g.Expect(terraform.GetAnnotations()).ToNot(gomega.HaveKey(PlanRejectedByAnnotation))
+}
+
+func Test_serveInteractions_replay(t *testing.T) {
+	g := gomega.NewWithT(t)
+	api := newSlackAPI(t)
+	server, _ := newServer(g, api, nil, true, pendingTerraform())
+
+	now := time.Now()
+	payload := interactionPayload(api.URL+"/response", "U123", actionReject, "plan-main-b8e362c206")
+	rec := httptest.NewRecorder()
+	server.InteractionsHandler().ServeHTTP(rec, signedRequest(payload, now))
+	g.Expect(rec.Code).To(gomega.Equal(http.StatusOK))
+	<-api.messages
+
+	// the same signed request is refused while its timestamp is valid
+	rec = httptest.NewRecorder()
+	server.InteractionsHandler().ServeHTTP(rec, signedRequest(payload, now))
+	g.Expect(rec.Code).To(gomega.Equal(http.StatusConflict))
+}
+
+func Test_replayCache(t *testing.T) {
+	g := gomega.NewWithT(t)
+	cache := newReplayCache()
+	now := time.Now()
+
+	g.Expect(cache.add("v0=a", now)).To(gomega.BeTrue())
+	g.Expect(cache.add("v0=a", now.Add(time.Minute))).To(gomega.BeFalse())
+	g.Expect(cache.add("v0=b", now.Add(time.Minute))).To(gomega.BeTrue())
+
+	// forgotten once the request would be too old anyway
+	g.Expect(cache.add("v0=a", now.Add(2*maxRequestAge+time.Second))).To(gomega.BeTrue())
+	g.Expect(cache.add("v0=b", now.Add(2*maxRequestAge+time.Second))).To(gomega.BeFalse())
+}
+
+func Test_RemindPlanPending(t *testing.T) {
+	g := gomega.NewWithT(t)
+	api := newSlackAPI(t)
+	server, _ := newServer(g, api, nil, true)
+
+	terraform := pendingTerraform()
+	g.Expect(server.RemindPlanPending(context.TODO(), *terraform, terraform.Status.Plan.Pending, 26*time.Hour, "")).To(gomega.Succeed())
+	msg := <-api.messages
+	g.Expect(msg.Channel).To(gomega.Equal("C000"))
+	g.Expect(msg.Text).To(gomega.ContainSubstring("has been pending approval for 26h0m0s"))
+	g.Expect(msg.Blocks[1].Elements).To(gomega.HaveLen(2))
+
+	// an escalated plan is posted to the escalation channel too
+	g.Expect(server.RemindPlanPending(context.TODO(), *terraform, terraform.Status.Plan.Pending, 72*time.Hour, "C999")).To(gomega.Succeed())
+	g.Expect((<-api.messages).Channel).To(gomega.Equal("C000"))
+	g.Expect((<-api.messages).Channel).To(gomega.Equal("C999"))
+}
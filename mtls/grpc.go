@@ -4,6 +4,8 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
+	"github.com/weaveworks/tf-controller/internal/correlation"
+	"github.com/weaveworks/tf-controller/internal/fips"
 	"github.com/weaveworks/tf-controller/runner"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -34,7 +36,10 @@ func StartGRPCServerForTesting(server *runner.TerraformRunnerServer, namespace s
 		return err
 	}
 
-	grpcServer := grpc.NewServer(grpc.Creds(creds))
+	grpcServer := grpc.NewServer(grpc.Creds(creds),
+		grpc.UnaryInterceptor(correlation.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(correlation.StreamServerInterceptor()),
+	)
 
 	// local runner, use the same client as the manager
 	runner.RegisterRunnerServer(grpcServer, server)
@@ -67,10 +72,10 @@ func GetGRPCClientCredentials(secret *corev1.Secret) (credentials.TransportCrede
 		return nil, err
 	}
 
-	config := &tls.Config{
+	config := fips.Restrict(&tls.Config{
 		Certificates: []tls.Certificate{runnerCert},
 		RootCAs:      certPool,
-	}
+	})
 
 	return credentials.NewTLS(config), nil
 }
@@ -92,11 +97,11 @@ func GetGRPCServerCredentials(secret *corev1.Secret) (credentials.TransportCrede
 		return nil, err
 	}
 
-	config := &tls.Config{
+	config := fips.Restrict(&tls.Config{
 		ClientAuth:   tls.RequireAndVerifyClientCert,
 		Certificates: []tls.Certificate{serverCert},
 		ClientCAs:    certPool,
-	}
+	})
 
 	return credentials.NewTLS(config), nil
 }
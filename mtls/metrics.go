@@ -0,0 +1,63 @@
+package mtls
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// caMetrics are the figures of the CAs of the rotator, read when the metrics
+// are scraped rather than from the goroutine of the rotator.
+var caMetrics struct {
+	mu        sync.Mutex
+	createdAt time.Time
+	trusted   int
+}
+
+func init() {
+	metrics.Registry.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "tf_controller_runner_ca_age_seconds",
+			Help: "Age of the CA signing the certificates of the runners, in seconds.",
+		}, func() float64 {
+			caMetrics.mu.Lock()
+			defer caMetrics.mu.Unlock()
+			if caMetrics.createdAt.IsZero() {
+				return 0
+			}
+			return time.Since(caMetrics.createdAt).Seconds()
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "tf_controller_runner_trusted_cas",
+			Help: "Number of the CAs trusted by the runners and the controller, more than one during the trust period of a rotation.",
+		}, func() float64 {
+			caMetrics.mu.Lock()
+			defer caMetrics.mu.Unlock()
+			return float64(caMetrics.trusted)
+		}),
+	)
+}
+
+// recordCAMetrics records the age of the current CA and the number of the
+// trusted ones.
+func (cr *CertRotator) recordCAMetrics() {
+	n := len(cr.artifactCaches)
+	if n == 0 {
+		return
+	}
+
+	now := time.Now()
+	trusted := 0
+	for _, a := range cr.artifactCaches {
+		if a.retiresAt().After(now) {
+			trusted++
+		}
+	}
+
+	caMetrics.mu.Lock()
+	defer caMetrics.mu.Unlock()
+	caMetrics.createdAt = cr.artifactCaches[n-1].createdAt
+	caMetrics.trusted = trusted
+}
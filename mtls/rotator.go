@@ -35,6 +35,8 @@ const (
 	caKeyName  = "ca.key"
 )
 
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=create;delete
+
 var crLog = logf.Log.WithName("cert-rotation")
 var _ manager.Runnable = &CertRotator{}
 
@@ -58,6 +60,18 @@ type KeyPairArtifacts struct {
 type artifact struct {
 	ca         *KeyPairArtifacts
 	certSecret *corev1.Secret
+	createdAt  time.Time
+	// retireAt is the end of the trust period of a CA replaced by a newer
+	// one, before it expires, or zero while it is trusted until it expires.
+	retireAt time.Time
+}
+
+// retiresAt returns the time after which the CA is no longer trusted.
+func (a *artifact) retiresAt() time.Time {
+	if !a.retireAt.IsZero() && a.retireAt.Before(a.ca.validUntil) {
+		return a.retireAt
+	}
+	return a.ca.validUntil
 }
 
 type TriggerResult struct {
@@ -68,6 +82,9 @@ type TriggerResult struct {
 type Trigger struct {
 	Namespace string
 	Ready     chan *TriggerResult
+	// Force rotates the CA even when it is still valid, for
+	// TriggerCARotation.
+	Force bool
 }
 
 // CertRotator contains cert artifacts and a channel to close when the certs are ready.
@@ -85,6 +102,10 @@ type CertRotator struct {
 	// CertValidityDuration   time.Duration
 	RotationCheckFrequency time.Duration
 	LookaheadInterval      time.Duration
+	// TrustPeriod is how long a CA stays trusted once a newer CA replaces
+	// it, for the runners started with the certificates of the previous CA
+	// to keep serving the controller. Zero trusts it until it expires.
+	TrustPeriod time.Duration
 
 	TriggerCARotation             chan Trigger // trigger the CA rotation
 	TriggerNamespaceTLSGeneration chan Trigger // trigger namespace TLS generation
@@ -205,7 +226,11 @@ tickerLoop:
 	for {
 		select {
 		case trigger := <-cr.TriggerCARotation:
-			if err := cr.refreshCACertsIfNeeded(); err != nil {
+			refresh := cr.refreshCACertsIfNeeded
+			if trigger.Force {
+				refresh = cr.rotateCA
+			}
+			if err := refresh(); err != nil {
 				crLog.Error(err, "could not refresh cert")
 			}
 			// if no channel passing it, skip
@@ -221,15 +246,16 @@ tickerLoop:
 				crLog.Error(err, "could not refresh cert")
 			}
 
-			// GC: garbage collect the old CA artifacts
+			// GC: garbage collect the old CA artifacts, expired or at the end
+			// of their trust period, but never the current one
 			for {
-				if len(cr.artifactCaches) == 0 {
+				if len(cr.artifactCaches) <= 1 {
 					break
 				}
 
 				validUntil := cr.artifactCaches[0].ca.validUntil
 				// we must NOT use cr.lookaheadTime() here
-				if validUntil.Before(time.Now()) {
+				if cr.artifactCaches[0].retiresAt().Before(time.Now()) {
 					cr.artifactCaches = cr.artifactCaches[1:]
 					for _, namespace := range cr.GetKnownNamespaces() {
 						err := cr.writer.Delete(context.TODO(), &corev1.Secret{
@@ -246,6 +272,7 @@ tickerLoop:
 					break
 				}
 			}
+			cr.recordCAMetrics()
 
 		// trigger by Terraform CR reconciliation loop
 		case trigger := <-cr.TriggerNamespaceTLSGeneration:
@@ -416,19 +443,58 @@ func (cr *CertRotator) refreshCACertsIfNeeded() error {
 	}
 
 	if needRegeneration {
-		// generate new certs for all namespaces
-		for namespace := range cr.knownNamespaceTLSMap {
-			secret, err := cr.generateNamespaceTLS(namespace)
-			if err != nil {
-				crLog.Error(err, "could not generate TLS for namespace")
-			}
-			cr.knownNamespaceTLSMap[namespace] = &TriggerResult{Secret: secret, Err: err}
-		}
+		cr.reissueNamespaceTLS()
 	}
 
 	return nil
 }
 
+// rotateCA replaces the CA even when it is still valid, and re-issues the
+// certificates of the namespaces signed by the new CA. The previous CA stays
+// trusted for the trust period, by the runners of the new certificates and
+// by the controller.
+func (cr *CertRotator) rotateCA() error {
+	crLog.Info("rotating the CA")
+	if err := cr.refreshCertsInMemory(); err != nil {
+		return err
+	}
+	cr.reissueNamespaceTLS()
+	return nil
+}
+
+// reissueNamespaceTLS generates new certs for all namespaces, signed by the
+// current CA.
+func (cr *CertRotator) reissueNamespaceTLS() {
+	for _, namespace := range cr.GetKnownNamespaces() {
+		secret, err := cr.generateNamespaceTLS(namespace)
+		if err != nil {
+			crLog.Error(err, "could not generate TLS for namespace")
+		}
+		cr.SetKnownNamespaceTLS(namespace, &TriggerResult{Secret: secret, Err: err})
+	}
+}
+
+// Rotate triggers the rotation of the CA, even when it is still valid, and
+// waits for the certificates of the namespaces to be re-issued.
+func (cr *CertRotator) Rotate(ctx context.Context) error {
+	if cr.TriggerCARotation == nil {
+		return errors.New("the CA rotation is not enabled")
+	}
+
+	trigger := Trigger{Ready: make(chan *TriggerResult, 1), Force: true}
+	select {
+	case cr.TriggerCARotation <- trigger:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case result := <-trigger.Ready:
+		return result.Err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // GetRunnerTLSSecretName returns the name of the TLS Secret.
 // It is used by the controller to tell the runner the name of TLS.
 func (cr *CertRotator) GetRunnerTLSSecretName() (string, error) {
@@ -446,6 +512,13 @@ func (cr *CertRotator) refreshCertsInMemory() error {
 	now := time.Now()
 	begin := now.Add(-1 * time.Hour)
 	end := now.Add(cr.CAValidityDuration)
+	if n := len(cr.artifactCaches); n > 0 {
+		// the Secrets of the namespaces are named after the expiry of their
+		// CA, which must differ from the one of the previous CA
+		if last := cr.artifactCaches[n-1].ca.validUntil; !end.Truncate(time.Second).After(last) {
+			end = last.Add(time.Second)
+		}
+	}
 
 	var err error
 	caArtifacts, err = cr.createCACert(begin, end)
@@ -468,14 +541,42 @@ func (cr *CertRotator) refreshCertsInMemory() error {
 		},
 	}
 
+	// the previous CAs stay trusted for the trust period
+	if cr.TrustPeriod > 0 {
+		retireAt := now.Add(cr.TrustPeriod)
+		for _, previous := range cr.artifactCaches {
+			if previous.retireAt.IsZero() || previous.retireAt.After(retireAt) {
+				previous.retireAt = retireAt
+			}
+		}
+	}
+
 	cr.artifactCaches = append(cr.artifactCaches, &artifact{
 		ca:         caArtifacts,
 		certSecret: secret,
+		createdAt:  now,
 	})
+	cr.recordCAMetrics()
 
 	return nil
 }
 
+// trustBundle returns the PEM of the current CA, followed by the ones of the
+// previous CAs still trusted. The runners and the controller trust all of
+// them, and sign with the first one.
+func (cr *CertRotator) trustBundle() []byte {
+	n := len(cr.artifactCaches)
+	bundle := append([]byte{}, cr.artifactCaches[n-1].ca.CertPEM...)
+
+	now := time.Now()
+	for i := n - 2; i >= 0; i-- {
+		if previous := cr.artifactCaches[i]; previous.retiresAt().After(now) {
+			bundle = append(bundle, previous.ca.CertPEM...)
+		}
+	}
+	return bundle
+}
+
 func buildArtifactsFromSecret(secret *corev1.Secret) (caArtifacts *KeyPairArtifacts, certArtifacts *KeyPairArtifacts, err error) {
 	caArtifacts, err = parseArtifacts(caCertName, caKeyName, secret)
 	if err != nil {
@@ -659,7 +760,7 @@ func (cr *CertRotator) generateNamespaceTLS(namespace string) (*corev1.Secret, e
 		},
 		Type: corev1.SecretTypeTLS,
 		Data: map[string][]byte{
-			caCertName: caArtifacts.CertPEM,
+			caCertName: cr.trustBundle(),
 			caKeyName:  caArtifacts.KeyPEM,
 			certName:   cert,
 			keyName:    key,
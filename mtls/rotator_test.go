@@ -0,0 +1,110 @@
+package mtls
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newTestRotator(g *WithT) *CertRotator {
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+
+	return &CertRotator{
+		writer:               fake.NewClientBuilder().WithScheme(scheme).Build(),
+		knownNamespaceTLSMap: make(map[string]*TriggerResult),
+		CAName:               "tf-controller",
+		CAOrganization:       "weaveworks",
+		DNSName:              "tf-controller",
+		CAValidityDuration:   24 * time.Hour,
+		TrustPeriod:          time.Hour,
+		ClusterDomain:        "cluster.local",
+	}
+}
+
+// verifiedBy returns whether the cert of a Secret is signed by a CA of the
+// trust bundle of another one.
+func verifiedBy(g *WithT, secret, bundle *corev1.Secret) bool {
+	pool := x509.NewCertPool()
+	g.Expect(pool.AppendCertsFromPEM(bundle.Data[caCertName])).To(BeTrue())
+
+	block, _ := pem.Decode(secret.Data[certName])
+	cert, err := x509.ParseCertificate(block.Bytes)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = cert.Verify(x509.VerifyOptions{
+		DNSName:   "10-0-0-1.flux-system.pod.cluster.local",
+		Roots:     pool,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	})
+	return err == nil
+}
+
+func TestRotateCA(t *testing.T) {
+	g := NewWithT(t)
+
+	cr := newTestRotator(g)
+	g.Expect(cr.refreshCertsInMemory()).To(Succeed())
+	previous, err := cr.generateNamespaceTLS("flux-system")
+	g.Expect(err).ToNot(HaveOccurred())
+	cr.SetKnownNamespaceTLS("flux-system", &TriggerResult{Secret: previous})
+
+	g.Expect(cr.rotateCA()).To(Succeed())
+
+	result, ok := cr.GetKnownNamespaceTLS("flux-system")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(result.Err).ToNot(HaveOccurred())
+	current := result.Secret
+	g.Expect(current.Name).ToNot(Equal(previous.Name))
+	name, err := cr.GetRunnerTLSSecretName()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(name).To(Equal(current.Name))
+
+	// the certs are re-issued by the new CA, and the previous CA stays
+	// trusted for the trust period
+	g.Expect(verifiedBy(g, current, previous)).To(BeFalse())
+	g.Expect(verifiedBy(g, current, current)).To(BeTrue())
+	g.Expect(verifiedBy(g, previous, current)).To(BeTrue())
+	g.Expect(cr.artifactCaches[0].retiresAt()).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+	g.Expect(cr.artifactCaches[1].retiresAt()).To(Equal(cr.artifactCaches[1].ca.validUntil))
+
+	// the credentials sign with the new CA
+	_, err = GetGRPCClientCredentials(current)
+	g.Expect(err).ToNot(HaveOccurred())
+	ca, _, err := buildArtifactsFromSecret(current)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ca.Cert.Equal(cr.artifactCaches[1].ca.Cert)).To(BeTrue())
+
+	// at the end of the trust period, the previous CA is no longer trusted
+	cr.artifactCaches[0].retireAt = time.Now().Add(-time.Minute)
+	g.Expect(cr.trustBundle()).To(Equal(cr.artifactCaches[1].ca.CertPEM))
+	cr.recordCAMetrics()
+	g.Expect(caMetrics.trusted).To(Equal(1))
+}
+
+func TestRotate(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	g.Expect((&CertRotator{}).Rotate(ctx)).To(MatchError("the CA rotation is not enabled"))
+
+	cr := &CertRotator{TriggerCARotation: make(chan Trigger)}
+	go func() {
+		trigger := <-cr.TriggerCARotation
+		g.Expect(trigger.Force).To(BeTrue())
+		trigger.Ready <- &TriggerResult{}
+	}()
+	g.Expect(cr.Rotate(ctx)).To(Succeed())
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	g.Expect(cr.Rotate(timeoutCtx)).To(MatchError(context.DeadlineExceeded))
+}
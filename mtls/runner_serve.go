@@ -8,6 +8,7 @@ import (
 
 	sourcev1 "github.com/fluxcd/source-controller/api/v1beta1"
 	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/internal/correlation"
 	"github.com/weaveworks/tf-controller/runner"
 	"google.golang.org/grpc"
 	v1 "k8s.io/api/core/v1"
@@ -18,7 +19,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func RunnerServe(namespace, addr string, tlsSecretName string, sigterm chan os.Signal, maxMessageSizeInMiB int) error {
+func RunnerServe(namespace, addr string, tlsSecretName string, sigterm chan os.Signal, maxMessageSizeInMiB int, sourceCacheDir string) error {
 	scheme := runtime.NewScheme()
 
 	if err := clientgoscheme.AddToScheme(scheme); err != nil {
@@ -42,9 +43,10 @@ func RunnerServe(namespace, addr string, tlsSecretName string, sigterm chan os.S
 
 	// local runner, use the same client as the manager
 	runnerServer := &runner.TerraformRunnerServer{
-		Client: k8sClient,
-		Scheme: scheme,
-		Done:   sigterm,
+		Client:         k8sClient,
+		Scheme:         scheme,
+		Done:           sigterm,
+		SourceCacheDir: sourceCacheDir,
 	}
 
 	listener, err := net.Listen("tcp", addr)
@@ -66,7 +68,10 @@ func RunnerServe(namespace, addr string, tlsSecretName string, sigterm chan os.S
 
 	// 30 MB is the maximum allowed payload size for gRPC.
 	maxMsgSize := maxMessageSizeInMiB * 1024 * 1024
-	grpcServer := grpc.NewServer(grpc.Creds(credentials), grpc.MaxRecvMsgSize(maxMsgSize), grpc.MaxSendMsgSize(maxMsgSize))
+	grpcServer := grpc.NewServer(grpc.Creds(credentials), grpc.MaxRecvMsgSize(maxMsgSize), grpc.MaxSendMsgSize(maxMsgSize),
+		grpc.UnaryInterceptor(correlation.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(correlation.StreamServerInterceptor()),
+	)
 	runner.RegisterRunnerServer(grpcServer, runnerServer)
 
 	if err := grpcServer.Serve(listener); err != nil {
@@ -0,0 +1,334 @@
+package planner
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/internal/correlation"
+	"github.com/weaveworks/tf-controller/internal/informer/bbp"
+	"github.com/weaveworks/tf-controller/planner/provider"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	LabelBranchPlanner    = "infra.weave.works/branch-planner"
+	LabelPRID             = correlation.PullRequestLabel
+	LabelPrimaryResource  = "infra.weave.works/primary-resource"
+	LabelPrimaryNamespace = "infra.weave.works/primary-namespace"
+	// LabelMergeQueue marks the branch objects of the entries of a merge
+	// queue, apart from the ones of their pull requests.
+	LabelMergeQueue = "infra.weave.works/merge-queue"
+
+	// AnnotationBranchOutputs and AnnotationBranchNamespace on an original
+	// Terraform object override the branchOutputs and branchNamespace
+	// fields of the planner ConfigMap for its branches.
+	AnnotationBranchOutputs   = "infra.weave.works/branch-planner-outputs"
+	AnnotationBranchNamespace = "infra.weave.works/branch-planner-namespace"
+)
+
+// branchOptions are the options of the branch objects of an original
+// Terraform object.
+type branchOptions struct {
+	// namespace is the namespace of the branch objects.
+	namespace string
+	outputs   BranchOutputs
+}
+
+// branchOptionsFor returns the options of the branch objects of an original
+// Terraform object, from the config and the annotations of the original.
+func branchOptionsFor(config *Config, original *infrav1.Terraform) (branchOptions, error) {
+	options := branchOptions{
+		namespace: config.BranchNamespace,
+		outputs:   config.BranchOutputs,
+	}
+
+	annotations := original.GetAnnotations()
+	if value, ok := annotations[AnnotationBranchOutputs]; ok {
+		outputs, err := parseBranchOutputs(value)
+		if err != nil {
+			return branchOptions{}, fmt.Errorf("invalid %s annotation: %w", AnnotationBranchOutputs, err)
+		}
+		options.outputs = outputs
+	}
+	if value, ok := annotations[AnnotationBranchNamespace]; ok {
+		options.namespace = value
+	}
+
+	if options.namespace == "" {
+		options.namespace = original.GetNamespace()
+	}
+
+	if value, ok := annotations[AnnotationOCITag]; ok {
+		if _, err := parseOCITagTemplate(value); err != nil {
+			return branchOptions{}, fmt.Errorf("invalid %s annotation: %w", AnnotationOCITag, err)
+		}
+	}
+	if options.outputs == "" {
+		options.outputs = BranchOutputsDisabled
+	}
+	if errs := validation.IsDNS1123Label(options.namespace); len(errs) > 0 {
+		return branchOptions{}, fmt.Errorf("invalid branch namespace %q: %s", options.namespace, strings.Join(errs, ", "))
+	}
+
+	return options, nil
+}
+
+// scratch reports whether the branch objects are created in a scratch
+// namespace, rather than in the namespace of the original.
+func (o branchOptions) scratch(original *infrav1.Terraform) bool {
+	return o.namespace != original.GetNamespace()
+}
+
+// branchName is the name of both the Terraform and the source objects
+// created to plan a pull request. In a scratch namespace, the name is
+// prefixed with the namespace of the original, for the branches of
+// originals of different namespaces not to collide. The entry of a pull
+// request in a merge queue is suffixed with mq rather than pr, to be planned
+// alongside the pull request.
+func branchName(original *infrav1.Terraform, pr provider.PullRequest, options branchOptions) string {
+	return branchSuffixed(original, original.GetName(), pr, options)
+}
+
+func branchSuffixed(original *infrav1.Terraform, name string, pr provider.PullRequest, options branchOptions) string {
+	kind := "pr"
+	if pr.MergeQueue {
+		kind = "mq"
+	}
+	if options.scratch(original) {
+		return fmt.Sprintf("%s-%s-%s-%d", original.GetNamespace(), name, kind, pr.Number)
+	}
+	return fmt.Sprintf("%s-%s-%d", name, kind, pr.Number)
+}
+
+// branchKey is the key of the branch Terraform object of a pull request.
+func branchKey(original *infrav1.Terraform, pr provider.PullRequest, options branchOptions) client.ObjectKey {
+	return client.ObjectKey{Namespace: options.namespace, Name: branchName(original, pr, options)}
+}
+
+// branchWorkspace is the name of the Terraform workspace of a pull request,
+// with the workspace per branch option.
+func branchWorkspace(config *Config, pr provider.PullRequest) string {
+	return fmt.Sprintf("%s%d", config.BranchWorkspacePrefix, pr.Number)
+}
+
+func (s *Server) reconcileBranch(ctx context.Context, original *infrav1.Terraform, source source, pr provider.PullRequest, options branchOptions, sandboxServiceAccount, workspace string, queued bool) error {
+	branchSource, err := s.reconcileBranchSource(ctx, original, source, pr, options)
+	if err != nil {
+		return err
+	}
+
+	branchTF := &infrav1.Terraform{}
+	branchTF.SetNamespace(options.namespace)
+	branchTF.SetName(branchName(original, pr, options))
+
+	result, err := controllerutil.CreateOrUpdate(ctx, s.clusterClient, branchTF, func() error {
+		branchTF.SetLabels(branchLabels(original, pr))
+		annotations := map[string]string{bbp.AnnotationKey: bbp.AnnotationValue}
+		if owners, ok := original.GetAnnotations()[bbp.AnnotationCodeOwners]; ok {
+			annotations[bbp.AnnotationCodeOwners] = owners
+		}
+		branchTF.SetAnnotations(annotations)
+		branchTF.Spec = branchSpec(original, branchSource, pr, options)
+		if simulation := branchTF.Spec.Simulation; simulation != nil {
+			// A simulated plan runs against the emulated services, with
+			// none of the credentials of the original.
+			serviceAccountName := simulation.ServiceAccountName
+			if serviceAccountName == "" {
+				serviceAccountName = "tf-runner"
+			}
+			restrictSpec(&branchTF.Spec, serviceAccountName, branchTF.GetName())
+		}
+		if sandboxServiceAccount != "" {
+			restrictSpec(&branchTF.Spec, sandboxServiceAccount, branchTF.GetName())
+		}
+		if workspace != "" && branchTF.Spec.Cloud == nil {
+			// The workspaces of Terraform Cloud are configured by the
+			// cloud block instead.
+			branchTF.Spec.Workspace = workspace
+		}
+		if queued {
+			// A queued plan is held back by suspending its Terraform object.
+			branchTF.Spec.Suspend = true
+		}
+
+		if options.scratch(original) {
+			// An owner cannot be in another namespace, the branch objects
+			// are deleted with the labels of the original instead.
+			return nil
+		}
+		return controllerutil.SetControllerReference(original, branchTF, s.clusterClient.Scheme())
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create or update branch Terraform: %w", err)
+	}
+
+	if result == controllerutil.OperationResultCreated && s.features.reactions != nil && !pr.MergeQueue {
+		// the reaction only acknowledges the pull request, the plan goes on
+		// without it
+		if err := s.features.reactions.AddReactionToPullRequest(ctx, pr, s.features.reaction); err != nil {
+			s.log.Error(err, "failed to react to the pull request", "pullRequest", pr.Number)
+		}
+	}
+
+	if err := s.setPullRequestStatus(ctx, branchTF, pr); err != nil {
+		return err
+	}
+
+	// A branch planned in a workspace of its own does not include the
+	// pending changes of the original.
+	divergence := ""
+	if workspace == "" {
+		divergence = mainDivergence(original, source, pr)
+	}
+	if err := s.setDivergedCondition(ctx, branchTF, divergence); err != nil {
+		return err
+	}
+
+	if s.slots != nil {
+		return s.setQueuedCondition(ctx, branchTF, queued)
+	}
+
+	return nil
+}
+
+// setPullRequestStatus records the pull request of a branch Terraform object
+// in its status, for the UIs to link the plan to the pull request.
+func (s *Server) setPullRequestStatus(ctx context.Context, branchTF *infrav1.Terraform, pr provider.PullRequest) error {
+	reference := &infrav1.PullRequestReference{
+		Repository: pr.Repository.String(),
+		Number:     pr.Number,
+		URL:        pr.Link,
+	}
+	if reflect.DeepEqual(branchTF.Status.PullRequest, reference) {
+		return nil
+	}
+
+	patch := client.MergeFrom(branchTF.DeepCopy())
+	branchTF.Status.PullRequest = reference
+	if err := s.clusterClient.Status().Patch(ctx, branchTF, patch); err != nil {
+		return fmt.Errorf("unable to set the pull request: %w", err)
+	}
+
+	return nil
+}
+
+func branchLabels(original *infrav1.Terraform, pr provider.PullRequest) map[string]string {
+	labels := map[string]string{
+		LabelBranchPlanner:    "true",
+		LabelPRID:             strconv.Itoa(pr.Number),
+		LabelPrimaryResource:  original.GetName(),
+		LabelPrimaryNamespace: original.GetNamespace(),
+	}
+	if pr.MergeQueue {
+		labels[LabelMergeQueue] = "true"
+	}
+	return labels
+}
+
+// primaryNamespace returns the namespace of the original Terraform object
+// of a branch Terraform object.
+func primaryNamespace(branchTF *infrav1.Terraform) string {
+	if namespace := branchTF.GetLabels()[LabelPrimaryNamespace]; namespace != "" {
+		return namespace
+	}
+	return branchTF.GetNamespace()
+}
+
+// branchSpec derives the spec of a branch Terraform object from the
+// original. A branch is only ever planned, against the state of the
+// original.
+func branchSpec(original *infrav1.Terraform, branchSource source, pr provider.PullRequest, options branchOptions) infrav1.TerraformSpec {
+	spec := *original.Spec.DeepCopy()
+	spec.SourceRef = infrav1.CrossNamespaceSourceReference{
+		Kind:      sourceKind(branchSource),
+		Name:      branchSource.GetName(),
+		Namespace: branchSource.GetNamespace(),
+	}
+	spec.PlanOnly = true
+	spec.StoreReadablePlan = "human"
+	spec.ApprovePlan = ""
+	spec.Force = false
+	// Deleting a branch, when its pull request closes, never touches the
+	// resources or the state of the original.
+	spec.DestroyResourcesOnDeletion = false
+	spec.DeletionPolicy = infrav1.DeletionPolicyOrphan
+	// The expiry of the original is not the one of its branches, whose
+	// lifetime is the one of their pull requests.
+	spec.TTL = nil
+	spec.DestroyAt = nil
+	spec.DeleteOnExpiry = false
+
+	// The outputs of a preview plan never overwrite the Secrets of the
+	// original.
+	var outputs []infrav1.WriteOutputsToSecretSpec
+	if options.outputs == BranchOutputsDerived {
+		outputs = original.GetWriteOutputsToSecrets()
+		for i := range outputs {
+			outputs[i].Name = branchSuffixed(original, outputs[i].Name, pr, options)
+		}
+	}
+	spec.WriteOutputsToSecret = nil
+	spec.WriteOutputsToSecrets = outputs
+
+	if spec.BackendConfig == nil {
+		spec.BackendConfig = &infrav1.BackendConfigSpec{
+			SecretSuffix:    original.GetName(),
+			InClusterConfig: true,
+		}
+	}
+	// In a scratch namespace, the branch keeps planning against the state
+	// Secret of the original.
+	if options.scratch(original) && spec.BackendConfig.CustomConfiguration == "" && spec.BackendConfig.SecretNamespace == "" {
+		spec.BackendConfig.SecretNamespace = original.GetNamespace()
+	}
+
+	// The branches plan in parallel, without waiting for the state lock of
+	// the original, and never force unlock it. The workspaces of Terraform
+	// Cloud queue their runs anyway.
+	spec.TFState = &infrav1.TFStateSpec{
+		ForceUnlock:     infrav1.ForceUnlockEnumNo,
+		DisablePlanLock: spec.Cloud == nil,
+	}
+
+	return spec
+}
+
+// deleteStaleBranches deletes the branch objects of an original Terraform
+// object which are not active, across all the namespaces, for the branches
+// left in a namespace no longer selected for them to be deleted too.
+func (s *Server) deleteStaleBranches(ctx context.Context, original *infrav1.Terraform, active map[client.ObjectKey]bool) error {
+	var list infrav1.TerraformList
+	if err := s.clusterClient.List(ctx, &list,
+		client.MatchingLabels{
+			LabelBranchPlanner:   "true",
+			LabelPrimaryResource: original.GetName(),
+		},
+	); err != nil {
+		return fmt.Errorf("unable to list branch Terraform objects: %w", err)
+	}
+
+	for i := range list.Items {
+		branchTF := &list.Items[i]
+		if primaryNamespace(branchTF) != original.GetNamespace() || active[client.ObjectKeyFromObject(branchTF)] {
+			continue
+		}
+
+		correlation.FromObject(branchTF).Logger(s.log).Info("deleting branch Terraform")
+
+		if err := s.clusterClient.Delete(ctx, branchTF); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("unable to delete branch Terraform: %w", err)
+		}
+		if err := s.deleteBranchSource(ctx, branchTF); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
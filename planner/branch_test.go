@@ -0,0 +1,326 @@
+package planner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sourcev1b2 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/go-logr/logr"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/planner/provider"
+)
+
+func Test_reconcileWorkspacePerBranch(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(sourcev1b2.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(gomega.Succeed())
+
+	original := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "tf1", Namespace: "default", UID: "uid"},
+		Spec: infrav1.TerraformSpec{
+			SourceRef: infrav1.CrossNamespaceSourceReference{Kind: "GitRepository", Name: "source", Namespace: "default"},
+			BackendConfig: &infrav1.BackendConfigSpec{
+				SecretSuffix:    "tf1",
+				InClusterConfig: true,
+			},
+		},
+	}
+	source := &sourcev1b2.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "source", Namespace: "default"},
+		Spec:       sourcev1b2.GitRepositorySpec{URL: "https://github.com/org/repo"},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "branch-based-planner", Namespace: "default"},
+		Data:       map[string]string{"workspacePerBranch": "true"},
+	}
+
+	server, err := New(
+		WithLogger(logr.Discard()),
+		WithClusterClient(fake.NewClientBuilder().WithScheme(scheme).WithObjects(original, source, configMap).Build()),
+		WithConfigMap("default/branch-based-planner"),
+	)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	server.config, err = server.readConfig(ctx)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	expectToEqual(g, server.config.WorkspacePerBranch, true)
+	expectToEqual(g, server.config.BranchWorkspacePrefix, DefaultBranchWorkspacePrefix)
+
+	g.Expect(server.reconcile(ctx, original, source, []provider.PullRequest{{Number: 123, HeadBranch: "feature"}})).To(gomega.Succeed())
+
+	branchTF := &infrav1.Terraform{}
+	g.Expect(server.clusterClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "tf1-pr-123"}, branchTF)).To(gomega.Succeed())
+	expectToEqual(g, branchTF.Spec.Workspace, "pr-123")
+	expectToEqual(g, branchTF.Spec.BackendConfig.SecretSuffix, "tf1")
+
+	// the workspace is left to the cloud block
+	original.Spec.Cloud = &infrav1.CloudSpec{Organization: "org"}
+	g.Expect(server.reconcile(ctx, original, source, []provider.PullRequest{{Number: 124, HeadBranch: "other"}})).To(gomega.Succeed())
+	g.Expect(server.clusterClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "tf1-pr-124"}, branchTF)).To(gomega.Succeed())
+	expectToEqual(g, branchTF.Spec.Workspace, "")
+
+	configMap.Data["workspacePerBranch"] = "yes please"
+	g.Expect(server.clusterClient.Update(ctx, configMap)).To(gomega.Succeed())
+	_, err = server.readConfig(ctx)
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func Test_branchSpecPlansInParallel(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	original := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "tf1", Namespace: "default"},
+		Spec: infrav1.TerraformSpec{
+			TFState: &infrav1.TFStateSpec{
+				ForceUnlock:    infrav1.ForceUnlockEnumAuto,
+				LockIdentifier: "f2ab685b-f84d-ac0b-a125-378a22877e8d",
+			},
+		},
+	}
+	branchSource := &sourcev1b2.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "tf1-pr-1", Namespace: "default"},
+	}
+
+	// the branches share the state of the original, but not its lock
+	options := branchOptions{namespace: "default", outputs: BranchOutputsDisabled}
+	spec := branchSpec(original, branchSource, provider.PullRequest{Number: 1}, options)
+	expectToEqual(g, spec.BackendConfig.SecretSuffix, "tf1")
+	expectToEqual(g, *spec.TFState, infrav1.TFStateSpec{ForceUnlock: infrav1.ForceUnlockEnumNo, DisablePlanLock: true})
+	expectToEqual(g, original.Spec.TFState.ForceUnlock, infrav1.ForceUnlockEnumAuto)
+
+	original.Spec.Cloud = &infrav1.CloudSpec{Organization: "org"}
+	spec = branchSpec(original, branchSource, provider.PullRequest{Number: 1}, options)
+	expectToEqual(g, spec.TFState.DisablePlanLock, false)
+}
+
+func Test_branchSpecOrphansOnDeletion(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	branchSource := &sourcev1b2.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "tf1-pr-1", Namespace: "default"},
+	}
+	options := branchOptions{namespace: "default", outputs: BranchOutputsDisabled}
+
+	for _, policy := range []infrav1.DeletionPolicy{infrav1.DeletionPolicyDestroy, infrav1.DeletionPolicyDeleteState} {
+		original := &infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "tf1", Namespace: "default"},
+			Spec: infrav1.TerraformSpec{
+				DeletionPolicy:             policy,
+				DestroyResourcesOnDeletion: true,
+			},
+		}
+
+		// the branch shares the state of the original, it must never
+		// destroy its resources or delete its state
+		branchTF := infrav1.Terraform{Spec: branchSpec(original, branchSource, provider.PullRequest{Number: 1}, options)}
+		expectToEqual(g, branchTF.GetDeletionPolicy(), infrav1.DeletionPolicyOrphan)
+		expectToEqual(g, branchTF.Spec.DestroyResourcesOnDeletion, false)
+		expectToEqual(g, original.Spec.DeletionPolicy, policy)
+	}
+}
+
+func Test_branchSpecNeverExpires(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	destroyAt := metav1.Now()
+	original := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "tf1", Namespace: "default"},
+		Spec: infrav1.TerraformSpec{
+			TTL:            &metav1.Duration{Duration: time.Hour},
+			DestroyAt:      &destroyAt,
+			DeleteOnExpiry: true,
+		},
+	}
+	branchSource := &sourcev1b2.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "tf1-pr-1", Namespace: "default"},
+	}
+
+	branchTF := infrav1.Terraform{Spec: branchSpec(original, branchSource, provider.PullRequest{Number: 1}, branchOptions{namespace: "default", outputs: BranchOutputsDisabled})}
+	g.Expect(branchTF.GetExpiry()).To(gomega.BeNil())
+	expectToEqual(g, branchTF.Spec.DeleteOnExpiry, false)
+	expectToEqual(g, original.Spec.TTL.Duration, time.Hour)
+}
+
+func Test_branchSpecOutputs(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	original := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "tf1", Namespace: "default"},
+		Spec: infrav1.TerraformSpec{
+			WriteOutputsToSecret: &infrav1.WriteOutputsToSecretSpec{Name: "tf1-outputs"},
+			WriteOutputsToSecrets: []infrav1.WriteOutputsToSecretSpec{
+				{Name: "tf1-endpoints", Outputs: []string{"endpoint"}},
+			},
+		},
+	}
+	branchSource := &sourcev1b2.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "tf1-pr-1", Namespace: "default"},
+	}
+	pr := provider.PullRequest{Number: 1}
+
+	spec := branchSpec(original, branchSource, pr, branchOptions{namespace: "default", outputs: BranchOutputsDisabled})
+	g.Expect(spec.WriteOutputsToSecret).To(gomega.BeNil())
+	g.Expect(spec.WriteOutputsToSecrets).To(gomega.BeEmpty())
+
+	spec = branchSpec(original, branchSource, pr, branchOptions{namespace: "default", outputs: BranchOutputsDerived})
+	g.Expect(spec.WriteOutputsToSecret).To(gomega.BeNil())
+	g.Expect(spec.WriteOutputsToSecrets).To(gomega.HaveLen(2))
+	expectToEqual(g, spec.WriteOutputsToSecrets[0].Name, "tf1-outputs-pr-1")
+	expectToEqual(g, spec.WriteOutputsToSecrets[1].Name, "tf1-endpoints-pr-1")
+	expectToEqual(g, spec.WriteOutputsToSecrets[1].Outputs, []string{"endpoint"})
+	expectToEqual(g, original.Spec.WriteOutputsToSecret.Name, "tf1-outputs")
+
+	// in a scratch namespace, the names are prefixed with the namespace of
+	// the original, and the state is the one of the original
+	spec = branchSpec(original, branchSource, pr, branchOptions{namespace: "previews", outputs: BranchOutputsDerived})
+	expectToEqual(g, spec.WriteOutputsToSecrets[0].Name, "default-tf1-outputs-pr-1")
+	expectToEqual(g, spec.WriteOutputsToSecrets[1].Name, "default-tf1-endpoints-pr-1")
+	expectToEqual(g, spec.BackendConfig.SecretNamespace, "default")
+}
+
+func Test_branchOptionsFor(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	original := &infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "tf1", Namespace: "default"}}
+
+	options, err := branchOptionsFor(&Config{}, original)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	expectToEqual(g, options, branchOptions{namespace: "default", outputs: BranchOutputsDisabled})
+	expectToEqual(g, options.scratch(original), false)
+
+	config := &Config{BranchOutputs: BranchOutputsDerived, BranchNamespace: "previews"}
+	options, err = branchOptionsFor(config, original)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	expectToEqual(g, options, branchOptions{namespace: "previews", outputs: BranchOutputsDerived})
+	expectToEqual(g, branchName(original, provider.PullRequest{Number: 2}, options), "default-tf1-pr-2")
+
+	// the annotations of the original override the config
+	original.SetAnnotations(map[string]string{
+		AnnotationBranchOutputs:   "disabled",
+		AnnotationBranchNamespace: "",
+	})
+	options, err = branchOptionsFor(config, original)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	expectToEqual(g, options, branchOptions{namespace: "default", outputs: BranchOutputsDisabled})
+	expectToEqual(g, branchName(original, provider.PullRequest{Number: 2}, options), "tf1-pr-2")
+
+	original.SetAnnotations(map[string]string{AnnotationBranchOutputs: "sometimes"})
+	_, err = branchOptionsFor(config, original)
+	g.Expect(err).To(gomega.HaveOccurred())
+
+	original.SetAnnotations(map[string]string{AnnotationBranchNamespace: "Not_A_Namespace"})
+	_, err = branchOptionsFor(config, original)
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func Test_reconcileScratchNamespace(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(sourcev1b2.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(gomega.Succeed())
+
+	original := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "tf1",
+			Namespace:   "default",
+			UID:         "uid",
+			Annotations: map[string]string{AnnotationBranchNamespace: "previews"},
+		},
+		Spec: infrav1.TerraformSpec{
+			SourceRef: infrav1.CrossNamespaceSourceReference{Kind: "GitRepository", Name: "source", Namespace: "default"},
+		},
+	}
+	source := &sourcev1b2.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "source", Namespace: "default"},
+		Spec:       sourcev1b2.GitRepositorySpec{URL: "https://github.com/org/repo"},
+	}
+
+	server, err := New(
+		WithLogger(logr.Discard()),
+		WithClusterClient(fake.NewClientBuilder().WithScheme(scheme).WithObjects(original, source).Build()),
+	)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	g.Expect(server.reconcile(ctx, original, source, []provider.PullRequest{{Number: 7, HeadBranch: "feature"}})).To(gomega.Succeed())
+
+	branchTF := &infrav1.Terraform{}
+	g.Expect(server.clusterClient.Get(ctx, client.ObjectKey{Namespace: "previews", Name: "default-tf1-pr-7"}, branchTF)).To(gomega.Succeed())
+	g.Expect(branchTF.GetOwnerReferences()).To(gomega.BeEmpty())
+	expectToEqual(g, branchTF.GetLabels()[LabelPrimaryNamespace], "default")
+	expectToEqual(g, branchTF.Spec.SourceRef.Namespace, "previews")
+	expectToEqual(g, branchTF.Spec.BackendConfig.SecretNamespace, "default")
+
+	branchSource := &sourcev1b2.GitRepository{}
+	g.Expect(server.clusterClient.Get(ctx, client.ObjectKey{Namespace: "previews", Name: "default-tf1-pr-7"}, branchSource)).To(gomega.Succeed())
+
+	// the branches of a closed pull request are deleted from the scratch
+	// namespace
+	g.Expect(server.reconcile(ctx, original, source, nil)).To(gomega.Succeed())
+	err = server.clusterClient.Get(ctx, client.ObjectKeyFromObject(branchTF), branchTF)
+	g.Expect(apierrors.IsNotFound(err)).To(gomega.BeTrue())
+	err = server.clusterClient.Get(ctx, client.ObjectKeyFromObject(branchSource), branchSource)
+	g.Expect(apierrors.IsNotFound(err)).To(gomega.BeTrue())
+}
+
+func Test_reconcileSimulation(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(sourcev1b2.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(gomega.Succeed())
+
+	original := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "tf1", Namespace: "default", UID: "uid"},
+		Spec: infrav1.TerraformSpec{
+			SourceRef:          infrav1.CrossNamespaceSourceReference{Kind: "GitRepository", Name: "source", Namespace: "default"},
+			ServiceAccountName: "tf-runner-prod",
+			VarsFrom:           []infrav1.VarsReference{{Kind: "Secret", Name: "aws"}},
+			Simulation: &infrav1.SimulationSpec{
+				Providers: []infrav1.SimulatedProvider{{Name: "aws", Endpoints: map[string]string{"s3": "http://localstack:4566"}}},
+			},
+		},
+	}
+	original.Spec.RunnerPodTemplate.Spec.EnvFrom = []corev1.EnvFromSource{{
+		SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "aws"}},
+	}}
+	source := &sourcev1b2.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "source", Namespace: "default"},
+		Spec:       sourcev1b2.GitRepositorySpec{URL: "https://github.com/org/repo"},
+	}
+
+	server, err := New(
+		WithLogger(logr.Discard()),
+		WithClusterClient(fake.NewClientBuilder().WithScheme(scheme).WithObjects(original, source).Build()),
+	)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	g.Expect(server.reconcile(ctx, original, source, []provider.PullRequest{{Number: 7, HeadBranch: "feature"}})).To(gomega.Succeed())
+
+	branchTF := &infrav1.Terraform{}
+	g.Expect(server.clusterClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "tf1-pr-7"}, branchTF)).To(gomega.Succeed())
+	g.Expect(branchTF.IsSimulated()).To(gomega.BeTrue())
+	expectToEqual(g, branchTF.Spec.ServiceAccountName, "tf-runner")
+	g.Expect(branchTF.Spec.VarsFrom).To(gomega.BeEmpty())
+	g.Expect(branchTF.Spec.RunnerPodTemplate.Spec.EnvFrom).To(gomega.BeEmpty())
+	// the simulation plans against a state of its own
+	expectToEqual(g, branchTF.Spec.BackendConfig.SecretSuffix, "tf1-pr-7")
+}
@@ -0,0 +1,146 @@
+package planner
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/planner/provider"
+)
+
+// providerFeatures are the optional features of the Git provider of a
+// repository which the config uses, nil without the capability they need.
+type providerFeatures struct {
+	mergeQueue provider.MergeQueueProvider
+	statuses   provider.CommitStatusProvider
+	files      provider.ChangedFilesProvider
+	reactions  provider.ReactionProvider
+	reaction   provider.Reaction
+	// drafts is true when the pull requests are listed with their draft
+	// flag.
+	drafts bool
+}
+
+// changedFiles are the files a pull request changes at its head commit.
+type changedFiles struct {
+	sha   string
+	paths []string
+}
+
+// featuresOf returns the features of the provider of a repository which the
+// config uses. The provider lacking a capability, or failing to advertise
+// them, leaves the features out rather than failing the poll, and is logged
+// once per repository.
+func (s *Server) featuresOf(ctx context.Context, config *Config, gitProvider provider.Provider, repo provider.Repository) providerFeatures {
+	features := providerFeatures{}
+	if config == nil || !(config.MergeQueue || config.CommitStatuses || config.SkipDraftPullRequests || config.ChangedPathsOnly || config.PlanReaction != "") {
+		return features
+	}
+
+	capabilities, err := provider.CapabilitiesOf(ctx, gitProvider, repo)
+	if err != nil {
+		s.log.Error(err, "failed to get the capabilities of the git provider, going on without them", "repository", repo.String())
+		capabilities = provider.Capabilities{}
+	}
+
+	supports := func(enabled bool, capability provider.Capability, fallback string) bool {
+		if !enabled {
+			return false
+		}
+		if capabilities.Has(capability) {
+			return true
+		}
+
+		key := repo.String() + "/" + string(capability)
+		if !s.unsupported[key] {
+			if s.unsupported == nil {
+				s.unsupported = map[string]bool{}
+			}
+			s.unsupported[key] = true
+			s.log.Info("the git provider does not support "+string(capability)+", "+fallback, "repository", repo.String())
+		}
+		return false
+	}
+
+	if supports(config.MergeQueue, provider.CapabilityMergeQueues, "planning the pull requests only") {
+		features.mergeQueue, _ = gitProvider.(provider.MergeQueueProvider)
+	}
+	if supports(config.CommitStatuses || features.mergeQueue != nil, provider.CapabilityCommitStatuses, "not reporting the plans as commit statuses") {
+		features.statuses, _ = gitProvider.(provider.CommitStatusProvider)
+	}
+	if features.statuses == nil {
+		// the entries of a merge queue wait for their statuses
+		features.mergeQueue = nil
+	}
+	features.drafts = supports(config.SkipDraftPullRequests, provider.CapabilityDraftPullRequests, "planning the draft pull requests")
+	if supports(config.ChangedPathsOnly, provider.CapabilityChangedFiles, "planning the pull requests whatever their changes") {
+		features.files, _ = gitProvider.(provider.ChangedFilesProvider)
+	}
+	if supports(config.PlanReaction != "", provider.CapabilityReactions, "not reacting to the pull requests") {
+		features.reactions, _ = gitProvider.(provider.ReactionProvider)
+		features.reaction = config.PlanReaction
+	}
+
+	return features
+}
+
+// changesPath reports whether a pull request changes a file under the path
+// of the original Terraform object. The changed files are listed once per
+// head commit.
+func (s *Server) changesPath(ctx context.Context, files provider.ChangedFilesProvider, original *infrav1.Terraform, pr provider.PullRequest) (bool, error) {
+	key := changedFilesKey(pr)
+	changed, ok := s.changedFiles[key]
+	if !ok || changed.sha != pr.HeadSha {
+		paths, err := files.ListChangedFiles(ctx, pr)
+		if err != nil {
+			return false, err
+		}
+		changed = changedFiles{sha: pr.HeadSha, paths: paths}
+		if s.changedFiles == nil {
+			s.changedFiles = map[string]changedFiles{}
+		}
+		s.changedFiles[key] = changed
+	}
+
+	return underPath(original.Spec.Path, changed.paths), nil
+}
+
+// forgetChangedFiles forgets the changed files of the pull requests of a
+// repository which are not open anymore.
+func (s *Server) forgetChangedFiles(repo provider.Repository, prs []provider.PullRequest) {
+	open := map[string]bool{}
+	for _, pr := range prs {
+		open[changedFilesKey(pr)] = true
+	}
+
+	prefix := repo.String() + "#"
+	for key := range s.changedFiles {
+		if strings.HasPrefix(key, prefix) && !open[key] {
+			delete(s.changedFiles, key)
+		}
+	}
+}
+
+func changedFilesKey(pr provider.PullRequest) string {
+	return fmt.Sprintf("%s#%d", pr.Repository, pr.Number)
+}
+
+// underPath reports whether one of the paths is under the directory of a
+// Terraform path, relative to the root of the source.
+func underPath(dir string, paths []string) bool {
+	dir = path.Clean("/" + strings.TrimSpace(dir))
+	if dir == "/" {
+		return len(paths) > 0
+	}
+
+	for _, p := range paths {
+		p = path.Clean("/" + p)
+		if p == dir || strings.HasPrefix(p, dir+"/") {
+			return true
+		}
+	}
+
+	return false
+}
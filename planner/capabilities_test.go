@@ -0,0 +1,171 @@
+package planner
+
+import (
+	"context"
+	"testing"
+
+	sourcev1b2 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/go-logr/logr"
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/planner/provider"
+)
+
+// fakeCapableProvider advertises its capabilities, and records the changed
+// files listed and the reactions added.
+type fakeCapableProvider struct {
+	provider.Provider
+	capabilities provider.Capabilities
+	changed      map[int][]string
+	listed       int
+	reactions    []int
+}
+
+func (p *fakeCapableProvider) Capabilities(ctx context.Context, repo provider.Repository) (provider.Capabilities, error) {
+	return p.capabilities, nil
+}
+
+func (p *fakeCapableProvider) SetCommitStatus(ctx context.Context, repo provider.Repository, sha string, status provider.CommitStatus) error {
+	return nil
+}
+
+func (p *fakeCapableProvider) ListChangedFiles(ctx context.Context, pr provider.PullRequest) ([]string, error) {
+	p.listed++
+	return p.changed[pr.Number], nil
+}
+
+func (p *fakeCapableProvider) AddReactionToPullRequest(ctx context.Context, pr provider.PullRequest, reaction provider.Reaction) error {
+	p.reactions = append(p.reactions, pr.Number)
+	return nil
+}
+
+func Test_featuresOf(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctx := context.Background()
+	repo := provider.Repository{Org: "org", Name: "repo"}
+
+	server, err := New(WithLogger(logr.Discard()))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	config := &Config{
+		MergeQueue:            true,
+		CommitStatuses:        true,
+		SkipDraftPullRequests: true,
+		ChangedPathsOnly:      true,
+		PlanReaction:          provider.ReactionEyes,
+	}
+
+	// a provider without capabilities has none of the features
+	features := server.featuresOf(ctx, config, struct{ provider.Provider }{}, repo)
+	expectToEqual(g, features, providerFeatures{})
+	expectToEqual(g, len(server.unsupported), 5)
+
+	// the features of a provider are the ones it advertises
+	gitProvider := &fakeCapableProvider{capabilities: provider.Capabilities{
+		provider.CapabilityCommitStatuses: true,
+		provider.CapabilityChangedFiles:   true,
+	}}
+	features = server.featuresOf(ctx, config, gitProvider, repo)
+	g.Expect(features.statuses).ToNot(gomega.BeNil())
+	g.Expect(features.files).ToNot(gomega.BeNil())
+	g.Expect(features.mergeQueue).To(gomega.BeNil())
+	g.Expect(features.reactions).To(gomega.BeNil())
+	expectToEqual(g, features.drafts, false)
+
+	// the features are only used when the config enables them
+	features = server.featuresOf(ctx, &Config{CommitStatuses: true}, gitProvider, repo)
+	g.Expect(features.files).To(gomega.BeNil())
+}
+
+func Test_underPath(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	expectToEqual(g, underPath("./terraform", []string{"terraform/main.tf"}), true)
+	expectToEqual(g, underPath("terraform/", []string{"README.md", "terraform/modules/vpc/main.tf"}), true)
+	expectToEqual(g, underPath("./terraform", []string{"terraform-old/main.tf", "README.md"}), false)
+	expectToEqual(g, underPath("", []string{"README.md"}), true)
+	expectToEqual(g, underPath("./", nil), false)
+}
+
+func Test_reconcileWithCapabilities(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(sourcev1b2.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(gomega.Succeed())
+
+	original := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "tf1", Namespace: "default", UID: "uid"},
+		Spec: infrav1.TerraformSpec{
+			Path:      "./terraform",
+			SourceRef: infrav1.CrossNamespaceSourceReference{Kind: "GitRepository", Name: "source", Namespace: "default"},
+		},
+	}
+	source := &sourcev1b2.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "source", Namespace: "default"},
+		Spec: sourcev1b2.GitRepositorySpec{
+			URL:       "https://github.com/org/repo",
+			Reference: &sourcev1b2.GitRepositoryRef{Branch: "main"},
+		},
+	}
+
+	server, err := New(
+		WithLogger(logr.Discard()),
+		WithClusterClient(fake.NewClientBuilder().WithScheme(scheme).
+			WithStatusSubresource(&infrav1.Terraform{}, &sourcev1b2.GitRepository{}).
+			WithObjects(original, source).Build()),
+	)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	gitProvider := &fakeCapableProvider{
+		capabilities: provider.Capabilities{
+			provider.CapabilityChangedFiles:      true,
+			provider.CapabilityReactions:         true,
+			provider.CapabilityDraftPullRequests: true,
+		},
+		changed: map[int][]string{
+			1: {"terraform/main.tf"},
+			2: {"README.md"},
+		},
+	}
+	server.config = &Config{
+		ForkPolicy:            ForkPolicySkip,
+		SkipDraftPullRequests: true,
+		ChangedPathsOnly:      true,
+		PlanReaction:          provider.ReactionEyes,
+	}
+	server.features = server.featuresOf(ctx, server.config, gitProvider, provider.Repository{Org: "org", Name: "repo"})
+
+	prs := []provider.PullRequest{
+		{Number: 1, BaseBranch: "main", HeadBranch: "change", HeadSha: "a"},
+		{Number: 2, BaseBranch: "main", HeadBranch: "docs", HeadSha: "b"},
+		{Number: 3, BaseBranch: "main", HeadBranch: "draft", HeadSha: "c", Draft: true},
+	}
+	g.Expect(server.reconcile(ctx, original, source, prs)).To(gomega.Succeed())
+
+	// only the pull request changing the path is planned, and reacted to
+	branchTF := &infrav1.Terraform{}
+	g.Expect(server.clusterClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "tf1-pr-1"}, branchTF)).To(gomega.Succeed())
+	g.Expect(server.clusterClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "tf1-pr-2"}, branchTF)).ToNot(gomega.Succeed())
+	g.Expect(server.clusterClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "tf1-pr-3"}, branchTF)).ToNot(gomega.Succeed())
+	expectToEqual(g, gitProvider.reactions, []int{1})
+	expectToEqual(g, gitProvider.listed, 2)
+
+	// the changed files are listed again for a new head commit only, and the
+	// reaction is only added when the plan starts
+	prs[1].HeadSha = "d"
+	g.Expect(server.reconcile(ctx, original, source, prs)).To(gomega.Succeed())
+	expectToEqual(g, gitProvider.listed, 3)
+	expectToEqual(g, gitProvider.reactions, []int{1})
+
+	server.forgetChangedFiles(provider.Repository{}, prs[:1])
+	expectToEqual(g, len(server.changedFiles), 1)
+}
@@ -0,0 +1,363 @@
+package planner
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/weaveworks/tf-controller/internal/informer/bbp"
+	"github.com/weaveworks/tf-controller/planner/provider"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const DefaultConfigMapName = "default/branch-based-planner"
+
+// Example ConfigMap
+//
+// The secret is a reference to a secret with a 'token' key, an optional
+// 'statusAPIToken' key to enable the plan status API, and an optional
+// 'webhookSecret' key to enable the webhooks of the Git providers.
+//
+// ---
+// apiVersion: v1
+// kind: ConfigMap
+// metadata:
+//   name: branch-based-planner
+// data:
+//   # Secret to use to use GitHub API.
+//   # Key in the secret: token
+//   # Optional key for the plan status API: statusAPIToken
+//   # Optional key for the webhooks: webhookSecret
+//   secretNamespace: flux-system
+//   secretName: bbp-token
+//   # List of Terraform resources
+//   resources: |-
+//     - namespace: default
+//       name: tf1
+//     - namespace: default
+//       name: tf2
+//     - namespace: infra
+//       name: tfcore
+//     - namespace: team-a
+//       name: helloworld-tf
+//   # How to handle pull requests from forks: skip, restricted or label.
+//   forkPolicy: restricted
+//   # Service account the runner uses to plan fork pull requests with
+//   # the restricted policy.
+//   forkServiceAccountName: tf-runner-sandbox
+//   # Label a maintainer adds to a fork pull request to allow planning it
+//   # with the label policy.
+//   forkApprovalLabel: ok-to-plan
+//   # Maximum number of pull requests planned at the same time for a
+//   # repository, and for a namespace. Other pull requests are queued.
+//   maxConcurrentPlansPerRepository: "5"
+//   maxConcurrentPlansPerNamespace: "10"
+//   # Plan each pull request in a Terraform workspace of its own, in the
+//   # backend of the original Terraform object, named with the prefix and
+//   # the number of the pull request, like pr-123.
+//   workspacePerBranch: "true"
+//   branchWorkspacePrefix: pr-
+//   # Address of a gRPC plugin serving the Git provider of the repositories,
+//   # for a Git server without a built-in provider, and whether to connect
+//   # to it over TLS.
+//   gitProviderPlugin: git-provider-plugin.flux-system.svc:9090
+//   gitProviderPluginTLS: "false"
+//   # Whether the branch Terraform objects write the outputs of their plans
+//   # to Secrets: disabled, or derived to write them to Secrets named after
+//   # the ones of the original, suffixed with the pull request, like
+//   # tf1-outputs-pr-123.
+//   branchOutputs: disabled
+//   # Scratch namespace to create the branch objects in, instead of the
+//   # namespace of the original Terraform object.
+//   branchNamespace: tf-previews
+//   # Plan the entries of the merge queues too, at their speculative merge
+//   # commits, and report their plans as commit statuses for the queues to
+//   # require.
+//   mergeQueue: "true"
+//   # Report the plans of the pull requests as statuses of their head
+//   # commits, not only with the merge queues.
+//   commitStatuses: "true"
+//   # Do not plan the draft pull requests.
+//   skipDraftPullRequests: "true"
+//   # Plan only the pull requests changing files under the path of the
+//   # original Terraform object.
+//   changedPathsOnly: "true"
+//   # Reaction added to a pull request when its plan starts.
+//   planReaction: eyes
+//   # The options above use optional features of the Git servers. With a
+//   # provider lacking one, the planner goes on without it.
+//   # Go template of the plan comments, and the number of lines above which
+//   # the plan is collapsed. See the CommentData of the bbp package for the
+//   # fields of the template.
+//   commentTemplate: |-
+//     {{ .Plan }}
+//
+//     See the [runbook](https://runbooks.example.com/terraform).
+//   commentCollapseLines: "50"
+//   # Maximum rate of the writes of the planner to the API server, and the
+//   # number of writes made at once before the rate applies, for the
+//   # branches of an original with many pull requests to be updated in
+//   # batches. A rate of "0" does not limit the writes.
+//   branchWritesPerSecond: "10"
+//   branchWriteBurst: "20"
+//   # Go template of the OCI tags the head branches of the pull requests are
+//   # published to, for the Terraform objects whose source is an
+//   # OCIRepository. See OCITagData for the fields of the template.
+//   ociTagTemplate: pr-{{ .Number }}
+
+// ForkPolicy determines how pull requests from forked repositories are
+// handled, as their content cannot be trusted.
+type ForkPolicy string
+
+const (
+	// ForkPolicySkip does not plan pull requests from forks.
+	ForkPolicySkip ForkPolicy = "skip"
+	// ForkPolicyRestricted plans pull requests from forks with a sandbox
+	// service account, without any of the credentials of the original
+	// Terraform object.
+	ForkPolicyRestricted ForkPolicy = "restricted"
+	// ForkPolicyLabel plans pull requests from forks only after a
+	// maintainer has added the approval label.
+	ForkPolicyLabel ForkPolicy = "label"
+
+	DefaultForkApprovalLabel = "ok-to-plan"
+
+	DefaultBranchWorkspacePrefix = "pr-"
+)
+
+// BranchOutputs determines whether the branch Terraform objects write the
+// outputs of their plans to Secrets.
+type BranchOutputs string
+
+const (
+	// BranchOutputsDisabled writes no outputs for the branches.
+	BranchOutputsDisabled BranchOutputs = "disabled"
+	// BranchOutputsDerived writes the outputs of a branch to the Secrets of
+	// the original, with names suffixed with the pull request.
+	BranchOutputsDerived BranchOutputs = "derived"
+)
+
+func parseBranchOutputs(value string) (BranchOutputs, error) {
+	switch outputs := BranchOutputs(value); outputs {
+	case "":
+		return BranchOutputsDisabled, nil
+	case BranchOutputsDisabled, BranchOutputsDerived:
+		return outputs, nil
+	default:
+		return "", fmt.Errorf("unknown branch outputs mode: %q", value)
+	}
+}
+
+type Config struct {
+	Resources       []client.ObjectKey
+	SecretNamespace string
+	SecretName      string
+
+	ForkPolicy             ForkPolicy
+	ForkServiceAccountName string
+	ForkApprovalLabel      string
+
+	// MaxConcurrentPlansPerRepository and MaxConcurrentPlansPerNamespace
+	// limit the number of branch plans in flight. Zero means no limit.
+	MaxConcurrentPlansPerRepository int
+	MaxConcurrentPlansPerNamespace  int
+
+	// WorkspacePerBranch selects a workspace of the pull request, named
+	// with the BranchWorkspacePrefix, in the backend of the original.
+	WorkspacePerBranch    bool
+	BranchWorkspacePrefix string
+
+	// GitProviderPlugin is the address of the gRPC plugin serving the Git
+	// provider of all the repositories, instead of the built-in providers.
+	GitProviderPlugin    string
+	GitProviderPluginTLS bool
+
+	// BranchOutputs and BranchNamespace are the defaults of the branch
+	// objects, overridden by the annotations of the original Terraform
+	// objects. An empty BranchNamespace keeps the branch objects in the
+	// namespace of the original.
+	BranchOutputs   BranchOutputs
+	BranchNamespace string
+
+	// MergeQueue plans the entries of the merge queues of the repositories,
+	// with the providers supporting them.
+	MergeQueue bool
+
+	// CommitStatuses reports the plans of the pull requests as statuses of
+	// their head commits, SkipDraftPullRequests skips the drafts,
+	// ChangedPathsOnly the pull requests changing no file under the path of
+	// the original, and PlanReaction is added to the pull requests when
+	// their plans start, if not empty. They are ignored with the providers
+	// without the capabilities they need.
+	CommitStatuses        bool
+	SkipDraftPullRequests bool
+	ChangedPathsOnly      bool
+	PlanReaction          provider.Reaction
+
+	// BranchWritesPerSecond and BranchWriteBurst limit the writes of the
+	// planner to the API server. A nil BranchWritesPerSecond and a zero
+	// BranchWriteBurst are the defaults, and a rate of zero is no limit.
+	BranchWritesPerSecond *float64
+	BranchWriteBurst      int
+
+	// OCITagTemplate is the Go template of the OCI tags the head branches
+	// are published to, with an OCIRepository source, overridden by the
+	// annotations of the original Terraform objects. An empty
+	// OCITagTemplate is the DefaultOCITagTemplate.
+	OCITagTemplate string
+}
+
+// HasPlanLimits reports whether the number of branch plans in flight is
+// limited.
+func (c *Config) HasPlanLimits() bool {
+	return c.MaxConcurrentPlansPerRepository > 0 || c.MaxConcurrentPlansPerNamespace > 0
+}
+
+// readConfig returns the config passed with WithConfig, or reads it from the
+// ConfigMap.
+func (s *Server) readConfig(ctx context.Context) (*Config, error) {
+	if s.staticConfig != nil {
+		config := *s.staticConfig
+		return &config, nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err := s.clusterClient.Get(ctx, s.configMapRef, configMap)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get ConfigMap: %w", err)
+	}
+
+	config := &Config{}
+	config.SecretNamespace = configMap.Data["secretNamespace"]
+	config.SecretName = configMap.Data["secretName"]
+	resourceData := configMap.Data["resources"]
+
+	config.ForkPolicy = ForkPolicy(configMap.Data["forkPolicy"])
+	config.ForkServiceAccountName = configMap.Data["forkServiceAccountName"]
+	config.ForkApprovalLabel = configMap.Data["forkApprovalLabel"]
+
+	if config.MaxConcurrentPlansPerRepository, err = parseLimit(configMap.Data, "maxConcurrentPlansPerRepository"); err != nil {
+		return nil, err
+	}
+	if config.MaxConcurrentPlansPerNamespace, err = parseLimit(configMap.Data, "maxConcurrentPlansPerNamespace"); err != nil {
+		return nil, err
+	}
+
+	if value := configMap.Data["workspacePerBranch"]; value != "" {
+		if config.WorkspacePerBranch, err = strconv.ParseBool(value); err != nil {
+			return nil, fmt.Errorf("workspacePerBranch must be a boolean: %q", value)
+		}
+	}
+	config.BranchWorkspacePrefix = configMap.Data["branchWorkspacePrefix"]
+
+	config.GitProviderPlugin = configMap.Data["gitProviderPlugin"]
+	if value := configMap.Data["gitProviderPluginTLS"]; value != "" {
+		if config.GitProviderPluginTLS, err = strconv.ParseBool(value); err != nil {
+			return nil, fmt.Errorf("gitProviderPluginTLS must be a boolean: %q", value)
+		}
+	}
+
+	if config.BranchOutputs, err = parseBranchOutputs(configMap.Data["branchOutputs"]); err != nil {
+		return nil, err
+	}
+	config.BranchNamespace = configMap.Data["branchNamespace"]
+
+	if value := configMap.Data["mergeQueue"]; value != "" {
+		if config.MergeQueue, err = strconv.ParseBool(value); err != nil {
+			return nil, fmt.Errorf("mergeQueue must be a boolean: %q", value)
+		}
+	}
+
+	for key, value := range map[string]*bool{
+		"commitStatuses":        &config.CommitStatuses,
+		"skipDraftPullRequests": &config.SkipDraftPullRequests,
+		"changedPathsOnly":      &config.ChangedPathsOnly,
+	} {
+		if data := configMap.Data[key]; data != "" {
+			if *value, err = strconv.ParseBool(data); err != nil {
+				return nil, fmt.Errorf("%s must be a boolean: %q", key, data)
+			}
+		}
+	}
+	config.PlanReaction = provider.Reaction(configMap.Data["planReaction"])
+	config.OCITagTemplate = configMap.Data["ociTagTemplate"]
+
+	if err := parseWriteLimits(config, configMap.Data); err != nil {
+		return nil, err
+	}
+
+	// The template of the plan comments is read by the informer writing
+	// them, it is only validated here.
+	if _, err := bbp.ReadCommentTemplate(configMap); err != nil {
+		return nil, err
+	}
+
+	err = yaml.Unmarshal([]byte(resourceData), &config.Resources)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse resource list from ConfigMap: %w", err)
+	}
+
+	if err := completeConfig(config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// completeConfig sets the defaults of the fields a config leaves empty, and
+// checks its fork policy and its OCI tag template.
+func completeConfig(config *Config) error {
+	if config.SecretNamespace == "" {
+		config.SecretNamespace = "default"
+	}
+
+	switch config.ForkPolicy {
+	case "":
+		config.ForkPolicy = ForkPolicySkip
+	case ForkPolicySkip, ForkPolicyLabel:
+	case ForkPolicyRestricted:
+		if config.ForkServiceAccountName == "" {
+			return fmt.Errorf("forkServiceAccountName is required with the %q fork policy", ForkPolicyRestricted)
+		}
+	default:
+		return fmt.Errorf("unknown fork policy: %q", config.ForkPolicy)
+	}
+
+	if config.ForkApprovalLabel == "" {
+		config.ForkApprovalLabel = DefaultForkApprovalLabel
+	}
+
+	if config.BranchWorkspacePrefix == "" {
+		config.BranchWorkspacePrefix = DefaultBranchWorkspacePrefix
+	}
+
+	if config.OCITagTemplate != "" {
+		if _, err := parseOCITagTemplate(config.OCITagTemplate); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// gitProvider returns the Git provider of a repository from the factory of
+// the server.
+func (s *Server) gitProvider(config *Config, repoURL string, secret *corev1.Secret) (provider.Provider, provider.Repository, error) {
+	return s.gitProviders.GitProvider(config, repoURL, secret)
+}
+
+func parseLimit(data map[string]string, key string) (int, error) {
+	value, ok := data[key]
+	if !ok || value == "" {
+		return 0, nil
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative integer: %q", key, value)
+	}
+
+	return limit, nil
+}
@@ -0,0 +1,71 @@
+package planner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/internal/informer/bbp"
+	"github.com/weaveworks/tf-controller/planner/provider"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// mainDivergence describes the changes of the base branch which the original
+// Terraform object has not applied yet, or returns an empty string when it is
+// up to date. The branches plan against the state of the original, so their
+// plans include these changes too.
+func mainDivergence(original *infrav1.Terraform, source source, pr provider.PullRequest) string {
+	var divergences []string
+
+	if original.Status.Plan.Pending != "" {
+		pending := fmt.Sprintf("%s has a pending plan on %s which is not applied yet", original.GetName(), pr.BaseBranch)
+		if changes := original.Status.Plan.Changes; changes != nil {
+			pending += fmt.Sprintf(" (%d to add, %d to change, %d to destroy)", changes.Add, changes.Change, changes.Destroy)
+		}
+		divergences = append(divergences, pending)
+	} else if artifact := source.GetArtifact(); !original.Spec.PlanOnly && original.Status.LastAppliedRevision != "" &&
+		artifact != nil && artifact.Revision != original.Status.LastAppliedRevision {
+		divergences = append(divergences, fmt.Sprintf("%s is not applied at the latest revision of %s, %s, but at %s",
+			original.GetName(), pr.BaseBranch, artifact.Revision, original.Status.LastAppliedRevision))
+	}
+
+	if ready := apimeta.FindStatusCondition(original.Status.Conditions, meta.ReadyCondition); ready != nil &&
+		ready.Reason == infrav1.DriftDetectedReason {
+		divergences = append(divergences, fmt.Sprintf("%s has drifted from its state", original.GetName()))
+	}
+
+	return strings.Join(divergences, "; ")
+}
+
+// setDivergedCondition records on the branch Terraform object whether the
+// base branch has changes which are not applied yet.
+func (s *Server) setDivergedCondition(ctx context.Context, branchTF *infrav1.Terraform, divergence string) error {
+	condition := metav1.Condition{
+		Type:    bbp.ConditionTypeMainDiverged,
+		Status:  metav1.ConditionFalse,
+		Reason:  bbp.MainUpToDateReason,
+		Message: "The base branch has no changes pending",
+	}
+	if divergence != "" {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = bbp.MainPendingChangesReason
+		condition.Message = divergence
+	}
+
+	if current := apimeta.FindStatusCondition(branchTF.Status.Conditions, bbp.ConditionTypeMainDiverged); current != nil &&
+		current.Status == condition.Status && current.Message == condition.Message {
+		return nil
+	}
+
+	patch := client.MergeFrom(branchTF.DeepCopy())
+	apimeta.SetStatusCondition(branchTF.GetStatusConditions(), condition)
+	if err := s.clusterClient.Status().Patch(ctx, branchTF, patch); err != nil {
+		return fmt.Errorf("unable to set the diverged condition: %w", err)
+	}
+
+	return nil
+}
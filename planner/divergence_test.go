@@ -0,0 +1,102 @@
+package planner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	sourcev1b2 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/go-logr/logr"
+	"github.com/onsi/gomega"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/internal/informer/bbp"
+	"github.com/weaveworks/tf-controller/planner/provider"
+)
+
+func Test_mainDivergence(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	pr := provider.PullRequest{Number: 1, BaseBranch: "main"}
+	source := &sourcev1b2.GitRepository{
+		Status: sourcev1b2.GitRepositoryStatus{Artifact: &sourcev1.Artifact{Revision: "main@sha1:bbbbbbbb"}},
+	}
+	original := &infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "tf1"}}
+
+	original.Status.LastAppliedRevision = "main@sha1:bbbbbbbb"
+	expectToEqual(g, mainDivergence(original, source, pr), "")
+
+	original.Status.LastAppliedRevision = "main@sha1:aaaaaaaa"
+	expectToEqual(g, mainDivergence(original, source, pr),
+		"tf1 is not applied at the latest revision of main, main@sha1:bbbbbbbb, but at main@sha1:aaaaaaaa")
+
+	// plan-only objects are never applied
+	original.Spec.PlanOnly = true
+	expectToEqual(g, mainDivergence(original, source, pr), "")
+	original.Spec.PlanOnly = false
+
+	original.Status.Plan = infrav1.PlanStatus{
+		Pending: "plan-main-bbbbbbbb",
+		Changes: &infrav1.PlanChanges{Add: 1, Change: 2, Destroy: 0},
+	}
+	original.Status.Conditions = []metav1.Condition{{Type: meta.ReadyCondition, Status: metav1.ConditionFalse, Reason: infrav1.DriftDetectedReason}}
+	expectToEqual(g, mainDivergence(original, source, pr),
+		"tf1 has a pending plan on main which is not applied yet (1 to add, 2 to change, 0 to destroy); tf1 has drifted from its state")
+}
+
+func Test_reconcileMainDiverged(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(sourcev1b2.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(gomega.Succeed())
+
+	original := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "tf1", Namespace: "default", UID: "uid"},
+		Spec: infrav1.TerraformSpec{
+			SourceRef: infrav1.CrossNamespaceSourceReference{Kind: "GitRepository", Name: "source", Namespace: "default"},
+		},
+		Status: infrav1.TerraformStatus{Plan: infrav1.PlanStatus{Pending: "plan-main-bbbbbbbb"}},
+	}
+	source := &sourcev1b2.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "source", Namespace: "default"},
+		Spec:       sourcev1b2.GitRepositorySpec{URL: "https://github.com/org/repo"},
+	}
+
+	server, err := New(
+		WithLogger(logr.Discard()),
+		WithClusterClient(fake.NewClientBuilder().WithScheme(scheme).
+			WithStatusSubresource(&infrav1.Terraform{}).
+			WithObjects(original, source).Build()),
+	)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	pr := provider.PullRequest{Number: 7, BaseBranch: "main", HeadBranch: "feature"}
+	branchTF := &infrav1.Terraform{}
+	diverged := func() *metav1.Condition {
+		g.Expect(server.reconcile(ctx, original, source, []provider.PullRequest{pr})).To(gomega.Succeed())
+		g.Expect(server.clusterClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "tf1-pr-7"}, branchTF)).To(gomega.Succeed())
+		return apimeta.FindStatusCondition(branchTF.Status.Conditions, bbp.ConditionTypeMainDiverged)
+	}
+
+	condition := diverged()
+	g.Expect(condition).ToNot(gomega.BeNil())
+	expectToEqual(g, condition.Status, metav1.ConditionTrue)
+	expectToEqual(g, condition.Reason, bbp.MainPendingChangesReason)
+	expectToEqual(g, condition.Message, "tf1 has a pending plan on main which is not applied yet")
+
+	// once the plan of main is applied
+	original.Status.Plan.Pending = ""
+	condition = diverged()
+	expectToEqual(g, condition.Status, metav1.ConditionFalse)
+	expectToEqual(g, condition.Reason, bbp.MainUpToDateReason)
+}
@@ -0,0 +1,100 @@
+package planner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sourcev1b2 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/go-logr/logr"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	testingclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/planner/provider"
+)
+
+// fakeListProvider lists the same pull requests in any repository.
+type fakeListProvider struct {
+	provider.Provider
+	prs []provider.PullRequest
+}
+
+func (p *fakeListProvider) ListPullRequests(ctx context.Context, repo provider.Repository) ([]provider.PullRequest, error) {
+	return p.prs, nil
+}
+
+func Test_embedded(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(sourcev1b2.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(gomega.Succeed())
+
+	original := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "tf1", Namespace: "default", UID: "uid"},
+		Spec: infrav1.TerraformSpec{
+			Path:      "./terraform",
+			SourceRef: infrav1.CrossNamespaceSourceReference{Kind: "GitRepository", Name: "source", Namespace: "default"},
+		},
+	}
+	source := &sourcev1b2.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "source", Namespace: "default"},
+		Spec: sourcev1b2.GitRepositorySpec{
+			URL:       "https://git.example.com/org/repo",
+			Reference: &sourcev1b2.GitRepositoryRef{Branch: "main"},
+		},
+	}
+	clusterClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithStatusSubresource(&infrav1.Terraform{}, &sourcev1b2.GitRepository{}).
+		WithObjects(original, source).Build()
+
+	gitProvider := &fakeListProvider{prs: []provider.PullRequest{
+		{Number: 1, BaseBranch: "main", HeadBranch: "change", HeadSha: "a"},
+	}}
+	var repoURLs []string
+	factory := GitProviderFactoryFunc(func(config *Config, repoURL string, secret *corev1.Secret) (provider.Provider, provider.Repository, error) {
+		repoURLs = append(repoURLs, repoURL)
+		return gitProvider, provider.Repository{Org: "org", Name: "repo"}, nil
+	})
+	clock := testingclock.NewFakeClock(time.Now())
+
+	// the config is passed without a ConfigMap nor a Secret
+	server, err := New(
+		WithLogger(logr.Discard()),
+		WithClusterClient(clusterClient),
+		WithPollingInterval(time.Minute),
+		WithConfig(Config{Resources: []client.ObjectKey{{Namespace: "default", Name: "tf1"}}}),
+		WithGitProviderFactory(factory),
+		WithClock(clock),
+	)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	done := make(chan error)
+	go func() { done <- server.Start(ctx) }()
+
+	// the pull requests are planned at each tick of the clock
+	g.Eventually(clock.HasWaiters).Should(gomega.BeTrue())
+	clock.Step(time.Minute)
+
+	branchTF := &infrav1.Terraform{}
+	g.Eventually(func() error {
+		return clusterClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "tf1-pr-1"}, branchTF)
+	}).Should(gomega.Succeed())
+
+	cancel()
+	g.Eventually(done).Should(gomega.Receive(gomega.BeNil()))
+
+	expectToEqual(g, repoURLs, []string{source.Spec.URL})
+	expectToEqual(g, server.config.ForkPolicy, ForkPolicySkip)
+	expectToEqual(g, server.loadedAt.Time, clock.Now())
+}
@@ -0,0 +1,173 @@
+package planner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/planner/provider"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ConditionTypeQueued is set on the branch Terraform objects when the
+	// plan limits are configured, and tells whether the plan is waiting for
+	// other plans to complete.
+	ConditionTypeQueued = "Queued"
+
+	PlanQueuedReason   = "PlanQueued"
+	PlanAdmittedReason = "PlanAdmitted"
+)
+
+// planSlots counts the branch plans in flight, per repository and per
+// namespace, to admit new plans within the limits of the config.
+type planSlots struct {
+	maxPerRepository int
+	maxPerNamespace  int
+	perRepository    map[string]int
+	perNamespace     map[string]int
+}
+
+func newPlanSlots(config *Config) *planSlots {
+	return &planSlots{
+		maxPerRepository: config.MaxConcurrentPlansPerRepository,
+		maxPerNamespace:  config.MaxConcurrentPlansPerNamespace,
+		perRepository:    map[string]int{},
+		perNamespace:     map[string]int{},
+	}
+}
+
+// add counts a plan in flight.
+func (p *planSlots) add(repository, namespace string) {
+	p.perRepository[repository]++
+	p.perNamespace[namespace]++
+}
+
+// acquire counts a new plan in flight, unless it would exceed a limit.
+func (p *planSlots) acquire(repository, namespace string) bool {
+	if p.maxPerRepository > 0 && p.perRepository[repository] >= p.maxPerRepository {
+		return false
+	}
+	if p.maxPerNamespace > 0 && p.perNamespace[namespace] >= p.maxPerNamespace {
+		return false
+	}
+
+	p.add(repository, namespace)
+	return true
+}
+
+// planInFlight reports whether a branch Terraform object is planning, or
+// about to plan, the revision of its source.
+func planInFlight(branchTF *infrav1.Terraform, branchSource source) bool {
+	if branchTF.Spec.Suspend {
+		return false
+	}
+	if branchTF.Status.ObservedGeneration != branchTF.GetGeneration() {
+		return true
+	}
+
+	artifact := branchSource.GetArtifact()
+	if artifact == nil {
+		// A source which fails to fetch the branch never gets to plan.
+		ready := apimeta.FindStatusCondition(branchSource.GetConditions(), meta.ReadyCondition)
+		return ready == nil || ready.Status != metav1.ConditionFalse
+	}
+
+	return artifact.Revision != branchTF.Status.LastAttemptedRevision
+}
+
+// countPlansInFlight counts the plans in flight of all the branch Terraform
+// objects of the cluster.
+func (s *Server) countPlansInFlight(ctx context.Context, slots *planSlots) error {
+	var list infrav1.TerraformList
+	if err := s.clusterClient.List(ctx, &list, client.MatchingLabels{LabelBranchPlanner: "true"}); err != nil {
+		return fmt.Errorf("unable to list branch Terraform objects: %w", err)
+	}
+
+	for i := range list.Items {
+		branchTF := &list.Items[i]
+		branchSource, err := s.getBranchSource(ctx, branchTF)
+		if err != nil {
+			return err
+		}
+		if branchSource != nil && planInFlight(branchTF, branchSource) {
+			slots.add(repositoryURL(branchSource), primaryNamespace(branchTF))
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) getBranchSource(ctx context.Context, branchTF *infrav1.Terraform) (source, error) {
+	branchSource := newSource(branchTF.Spec.SourceRef.Kind)
+	if branchSource == nil {
+		return nil, nil
+	}
+	err := s.clusterClient.Get(ctx, client.ObjectKey{
+		Namespace: branchTF.Spec.SourceRef.Namespace,
+		Name:      branchTF.Spec.SourceRef.Name,
+	}, branchSource)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to get branch source: %w", err)
+	}
+
+	return branchSource, nil
+}
+
+// admit tells whether the plan of a pull request can run, or must be
+// queued. A running branch Terraform object is never queued again, so a
+// new commit on an admitted pull request is planned right away.
+func (s *Server) admit(ctx context.Context, slots *planSlots, original *infrav1.Terraform, source source, pr provider.PullRequest, options branchOptions) (bool, error) {
+	if original.Spec.Suspend {
+		return true, nil
+	}
+
+	branchTF := &infrav1.Terraform{}
+	err := s.clusterClient.Get(ctx, branchKey(original, pr, options), branchTF)
+	if err == nil && !branchTF.Spec.Suspend {
+		return true, nil
+	} else if err != nil && !apierrors.IsNotFound(err) {
+		return false, fmt.Errorf("unable to get branch Terraform: %w", err)
+	}
+
+	repoURL, err := originalRepositoryURL(original, source)
+	if err != nil {
+		return false, err
+	}
+	return slots.acquire(repoURL, original.GetNamespace()), nil
+}
+
+// setQueuedCondition records on the branch Terraform object whether its plan
+// is queued.
+func (s *Server) setQueuedCondition(ctx context.Context, branchTF *infrav1.Terraform, queued bool) error {
+	condition := metav1.Condition{
+		Type:    ConditionTypeQueued,
+		Status:  metav1.ConditionFalse,
+		Reason:  PlanAdmittedReason,
+		Message: "The plan is admitted",
+	}
+	if queued {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = PlanQueuedReason
+		condition.Message = "The plan waits for other plans of the repository or the namespace to complete"
+	}
+
+	if current := apimeta.FindStatusCondition(branchTF.Status.Conditions, ConditionTypeQueued); current != nil &&
+		current.Status == condition.Status && current.Reason == condition.Reason {
+		return nil
+	}
+
+	patch := client.MergeFrom(branchTF.DeepCopy())
+	apimeta.SetStatusCondition(branchTF.GetStatusConditions(), condition)
+	if err := s.clusterClient.Status().Patch(ctx, branchTF, patch); err != nil {
+		return fmt.Errorf("unable to set the queued condition: %w", err)
+	}
+
+	return nil
+}
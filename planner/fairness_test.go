@@ -0,0 +1,63 @@
+package planner
+
+import (
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	sourcev1b2 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+)
+
+func Test_planSlots(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	slots := newPlanSlots(&Config{MaxConcurrentPlansPerRepository: 2, MaxConcurrentPlansPerNamespace: 3})
+	slots.add("https://github.com/org/busy", "team-a")
+
+	expectToEqual(g, slots.acquire("https://github.com/org/busy", "team-a"), true)
+	expectToEqual(g, slots.acquire("https://github.com/org/busy", "team-a"), false)
+	expectToEqual(g, slots.acquire("https://github.com/org/busy", "team-b"), false)
+	expectToEqual(g, slots.acquire("https://github.com/org/quiet", "team-a"), true)
+	expectToEqual(g, slots.acquire("https://github.com/org/other", "team-a"), false)
+	expectToEqual(g, slots.acquire("https://github.com/org/other", "team-b"), true)
+
+	unlimited := newPlanSlots(&Config{})
+	for i := 0; i < 40; i++ {
+		expectToEqual(g, unlimited.acquire("https://github.com/org/busy", "team-a"), true)
+	}
+}
+
+func Test_planInFlight(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	branchTF := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Generation: 1},
+		Status: infrav1.TerraformStatus{
+			ObservedGeneration:    1,
+			LastAttemptedRevision: "pr-1@sha1:aaaaaaaa",
+		},
+	}
+	source := &sourcev1b2.GitRepository{
+		Status: sourcev1b2.GitRepositoryStatus{
+			Artifact: &sourcev1.Artifact{Revision: "pr-1@sha1:aaaaaaaa"},
+		},
+	}
+	expectToEqual(g, planInFlight(branchTF, source), false)
+
+	source.Status.Artifact.Revision = "pr-1@sha1:bbbbbbbb"
+	expectToEqual(g, planInFlight(branchTF, source), true)
+
+	branchTF.Spec.Suspend = true
+	expectToEqual(g, planInFlight(branchTF, source), false)
+
+	branchTF.Spec.Suspend = false
+	source.Status.Artifact = nil
+	expectToEqual(g, planInFlight(branchTF, source), true)
+
+	source.Status.Conditions = []metav1.Condition{{Type: meta.ReadyCondition, Status: metav1.ConditionFalse}}
+	expectToEqual(g, planInFlight(branchTF, source), false)
+}
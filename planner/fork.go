@@ -0,0 +1,79 @@
+package planner
+
+import (
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/planner/provider"
+)
+
+// planDecision tells whether a pull request should be planned, and
+// whether the plan has to run in the restricted sandbox. The reason is
+// set when the pull request is not planned.
+type planDecision struct {
+	plan       bool
+	restricted bool
+	reason     string
+}
+
+func decide(config *Config, pr provider.PullRequest) planDecision {
+	if pr.Draft && config.SkipDraftPullRequests {
+		return planDecision{reason: "draft pull requests are skipped"}
+	}
+
+	if !pr.Fork {
+		return planDecision{plan: true}
+	}
+
+	switch config.ForkPolicy {
+	case ForkPolicyRestricted:
+		return planDecision{plan: true, restricted: true}
+	case ForkPolicyLabel:
+		if pr.HasLabel(config.ForkApprovalLabel) {
+			return planDecision{plan: true}
+		}
+
+		return planDecision{reason: "pull request from a fork is missing the approval label " + config.ForkApprovalLabel}
+	default:
+		return planDecision{reason: "pull requests from forks are skipped"}
+	}
+}
+
+// restrictSpec removes every credential the original Terraform object
+// gives to the runner, and runs it with the sandbox service account
+// against a state of its own, so that untrusted code cannot read the
+// state of the original either.
+func restrictSpec(spec *infrav1.TerraformSpec, serviceAccountName, stateName string) {
+	spec.ServiceAccountName = serviceAccountName
+	spec.CliConfigSecretRef = nil
+	spec.GitCredentials = nil
+	spec.RegistryCredentials = nil
+	spec.ReadInputsFromSecrets = nil
+	spec.BackendConfigsFrom = nil
+	spec.BackendConfig = &infrav1.BackendConfigSpec{
+		SecretSuffix:    stateName,
+		InClusterConfig: true,
+	}
+	spec.Cloud = nil
+
+	spec.RunnerPodTemplate.Spec.Env = nil
+	spec.RunnerPodTemplate.Spec.EnvFrom = nil
+	spec.RunnerPodTemplate.Spec.Volumes = nil
+	spec.RunnerPodTemplate.Spec.VolumeMounts = nil
+
+	// the commands of the Exec varsFrom query systems with credentials of
+	// their own
+	varsFrom := spec.VarsFrom[:0]
+	for _, ref := range spec.VarsFrom {
+		if ref.Kind != "Secret" && ref.Kind != "Exec" {
+			varsFrom = append(varsFrom, ref)
+		}
+	}
+	spec.VarsFrom = varsFrom
+
+	vars := spec.Vars[:0]
+	for _, v := range spec.Vars {
+		if v.ValueFrom == nil || v.ValueFrom.SecretKeyRef == nil {
+			vars = append(vars, v)
+		}
+	}
+	spec.Vars = vars
+}
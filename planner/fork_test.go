@@ -0,0 +1,76 @@
+package planner
+
+import (
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/planner/provider"
+)
+
+func Test_decide(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	pr := provider.PullRequest{Number: 1}
+	fork := provider.PullRequest{Number: 2, Fork: true}
+	approvedFork := provider.PullRequest{Number: 3, Fork: true, Labels: []string{"ok-to-plan"}}
+
+	skip := &Config{ForkPolicy: ForkPolicySkip, ForkApprovalLabel: DefaultForkApprovalLabel}
+	expectToEqual(g, decide(skip, pr).plan, true)
+	expectToEqual(g, decide(skip, fork).plan, false)
+	expectToEqual(g, decide(skip, approvedFork).plan, false)
+
+	restricted := &Config{ForkPolicy: ForkPolicyRestricted, ForkServiceAccountName: "sandbox"}
+	expectToEqual(g, decide(restricted, pr), planDecision{plan: true})
+	expectToEqual(g, decide(restricted, fork), planDecision{plan: true, restricted: true})
+
+	label := &Config{ForkPolicy: ForkPolicyLabel, ForkApprovalLabel: DefaultForkApprovalLabel}
+	expectToEqual(g, decide(label, fork).plan, false)
+	expectToEqual(g, decide(label, approvedFork), planDecision{plan: true})
+
+	draft := provider.PullRequest{Number: 4, Draft: true}
+	expectToEqual(g, decide(skip, draft).plan, true)
+	expectToEqual(g, decide(&Config{SkipDraftPullRequests: true}, draft).plan, false)
+}
+
+func Test_restrictSpec(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	spec := infrav1.TerraformSpec{
+		ServiceAccountName: "tf-runner",
+		CliConfigSecretRef: &corev1.SecretReference{Name: "tfrc"},
+		GitCredentials:     &infrav1.GitCredentials{SecretRef: meta.LocalObjectReference{Name: "git-credentials"}},
+		RegistryCredentials: []infrav1.RegistryCredentials{
+			{Hostname: "app.terraform.io", SecretRef: meta.LocalObjectReference{Name: "tfc-token"}},
+		},
+		ReadInputsFromSecrets: []infrav1.ReadInputsFromSecretSpec{{Name: "network-outputs", As: "network"}},
+		VarsFrom: []infrav1.VarsReference{
+			{Kind: "Secret", Name: "aws-credentials"},
+			{Kind: "ConfigMap", Name: "settings"},
+			{Kind: "Exec", Name: "ipam", Exec: &infrav1.ExecVarsSource{Command: []string{"/plugins/ipam"}}},
+		},
+		Vars: []infrav1.Variable{
+			{Name: "region"},
+			{Name: "password", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{Key: "password"}}},
+		},
+		RunnerPodTemplate: infrav1.RunnerPodTemplate{
+			Spec: infrav1.RunnerPodSpec{
+				Env: []corev1.EnvVar{{Name: "AWS_SECRET_ACCESS_KEY", Value: "secret"}},
+			},
+		},
+	}
+
+	restrictSpec(&spec, "sandbox", "tf-pr-2")
+	expectToEqual(g, spec.ServiceAccountName, "sandbox")
+	g.Expect(spec.CliConfigSecretRef).To(gomega.BeNil())
+	g.Expect(spec.GitCredentials).To(gomega.BeNil())
+	g.Expect(spec.RegistryCredentials).To(gomega.BeEmpty())
+	g.Expect(spec.ReadInputsFromSecrets).To(gomega.BeEmpty())
+	g.Expect(spec.RunnerPodTemplate.Spec.Env).To(gomega.BeEmpty())
+	expectToEqual(g, spec.VarsFrom, []infrav1.VarsReference{{Kind: "ConfigMap", Name: "settings"}})
+	expectToEqual(g, spec.Vars, []infrav1.Variable{{Name: "region"}})
+	expectToEqual(g, spec.BackendConfig, &infrav1.BackendConfigSpec{SecretSuffix: "tf-pr-2", InClusterConfig: true})
+}
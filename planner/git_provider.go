@@ -0,0 +1,44 @@
+package planner
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/weaveworks/tf-controller/planner/provider"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GitProviderFactory returns the Git provider of a repository, with the
+// config in use and its Secret. A controller embedding the planner may pass
+// its own with WithGitProviderFactory, for Git servers without a built-in
+// provider.
+type GitProviderFactory interface {
+	GitProvider(config *Config, repoURL string, secret *corev1.Secret) (provider.Provider, provider.Repository, error)
+}
+
+// GitProviderFactoryFunc is a function used as a GitProviderFactory.
+type GitProviderFactoryFunc func(config *Config, repoURL string, secret *corev1.Secret) (provider.Provider, provider.Repository, error)
+
+// GitProvider calls the function.
+func (f GitProviderFactoryFunc) GitProvider(config *Config, repoURL string, secret *corev1.Secret) (provider.Provider, provider.Repository, error) {
+	return f(config, repoURL, secret)
+}
+
+// defaultGitProviders is the factory of the planner binary: the plugin of
+// the config when it has one, or the built-in provider of the Git server,
+// with the token of the Secret.
+type defaultGitProviders struct {
+	log logr.Logger
+}
+
+func (f defaultGitProviders) GitProvider(config *Config, repoURL string, secret *corev1.Secret) (provider.Provider, provider.Repository, error) {
+	options := []provider.ProviderOption{
+		provider.WithLogger(f.log),
+		provider.WithToken(provider.APITokenType, string(secret.Data["token"])),
+	}
+
+	if config != nil && config.GitProviderPlugin != "" {
+		options = append(options, provider.WithPluginTLS(config.GitProviderPluginTLS))
+		return provider.FromPlugin(config.GitProviderPlugin, repoURL, options...)
+	}
+
+	return provider.FromURL(repoURL, options...)
+}
@@ -0,0 +1,21 @@
+package planner
+
+import (
+	"strings"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+)
+
+// AnnotationRequiredLabel on an original Terraform object overrides the label
+// of WithRequiredLabel its pull requests must carry to be planned. An empty
+// value plans all of them.
+const AnnotationRequiredLabel = "infra.weave.works/branch-planner-required-label"
+
+// requiredLabelFor returns the label the pull requests of the original
+// Terraform object must carry to be planned, or "" when all of them are.
+func (s *Server) requiredLabelFor(original *infrav1.Terraform) string {
+	if label, ok := original.GetAnnotations()[AnnotationRequiredLabel]; ok {
+		return strings.TrimSpace(label)
+	}
+	return s.requiredLabel
+}
@@ -0,0 +1,75 @@
+package planner
+
+import (
+	"context"
+	"testing"
+
+	sourcev1b2 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/go-logr/logr"
+	"github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/planner/provider"
+)
+
+func Test_reconcileRequiredLabel(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(sourcev1b2.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(gomega.Succeed())
+
+	original := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "tf1", Namespace: "default", UID: "uid"},
+		Spec: infrav1.TerraformSpec{
+			SourceRef: infrav1.CrossNamespaceSourceReference{Kind: "GitRepository", Name: "source", Namespace: "default"},
+		},
+	}
+	source := &sourcev1b2.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "source", Namespace: "default"},
+		Spec:       sourcev1b2.GitRepositorySpec{URL: "https://github.com/org/repo"},
+	}
+
+	server, err := New(
+		WithLogger(logr.Discard()),
+		WithClusterClient(fake.NewClientBuilder().WithScheme(scheme).WithObjects(original, source).Build()),
+		WithRequiredLabel("terraform-plan"),
+	)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	labelled := provider.PullRequest{Number: 7, HeadBranch: "feature", Labels: []string{"terraform-plan"}}
+	unlabelled := provider.PullRequest{Number: 8, HeadBranch: "other"}
+	branchExists := func(name string) bool {
+		err := server.clusterClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, &infrav1.Terraform{})
+		if apierrors.IsNotFound(err) {
+			return false
+		}
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		return true
+	}
+
+	// only the pull requests with the label are planned
+	g.Expect(server.reconcile(ctx, original, source, []provider.PullRequest{labelled, unlabelled})).To(gomega.Succeed())
+	g.Expect(branchExists("tf1-pr-7")).To(gomega.BeTrue())
+	g.Expect(branchExists("tf1-pr-8")).To(gomega.BeFalse())
+
+	// removing the label deletes the branch objects, as closing the pull request
+	labelled.Labels = nil
+	g.Expect(server.reconcile(ctx, original, source, []provider.PullRequest{labelled, unlabelled})).To(gomega.Succeed())
+	g.Expect(branchExists("tf1-pr-7")).To(gomega.BeFalse())
+
+	// the annotation of the original overrides the label, an empty one plans
+	// all the pull requests
+	original.SetAnnotations(map[string]string{AnnotationRequiredLabel: ""})
+	g.Expect(server.reconcile(ctx, original, source, []provider.PullRequest{labelled, unlabelled})).To(gomega.Succeed())
+	g.Expect(branchExists("tf1-pr-7")).To(gomega.BeTrue())
+	g.Expect(branchExists("tf1-pr-8")).To(gomega.BeTrue())
+}
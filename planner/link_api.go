@@ -0,0 +1,114 @@
+package planner
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	linkAPIPrefix = "/v1/terraform/"
+	linkAPISuffix = "/pull-request"
+)
+
+// PullRequestLink links a branch Terraform object to its pull request and to
+// its original Terraform object, returned by the link API.
+type PullRequestLink struct {
+	Namespace        string `json:"namespace"`
+	Name             string `json:"name"`
+	Primary          string `json:"primary"`
+	PrimaryNamespace string `json:"primaryNamespace"`
+	Repository       string `json:"repository"`
+	PullRequest      int    `json:"pullRequest"`
+	URL              string `json:"url,omitempty"`
+}
+
+// LinkHandler serves the link API, for the UIs to go from the plan of a
+// branch Terraform object to its pull request:
+//
+//	GET /v1/terraform/{namespace}/{name}/pull-request
+//
+// The way back, from a pull request to its plans, is the plan status API.
+// Requests are authenticated with the bearer token of the plan status API.
+func (s *Server) LinkHandler() http.Handler {
+	return http.HandlerFunc(s.serveLink)
+}
+
+func (s *Server) serveLink(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	key, err := parseLinkPath(r.URL.Path)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	ctx := r.Context()
+	if status, err := s.authenticate(ctx, r); err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	branchTF := &infrav1.Terraform{}
+	if err := s.clusterClient.Get(ctx, key, branchTF); err != nil {
+		if apierrors.IsNotFound(err) {
+			http.Error(w, "no branch Terraform object found", http.StatusNotFound)
+			return
+		}
+		s.log.Error(err, "failed to get the branch Terraform object", "terraform", key)
+		http.Error(w, "failed to get the branch Terraform object", http.StatusInternalServerError)
+		return
+	}
+
+	link, ok := pullRequestLink(branchTF)
+	if !ok {
+		http.Error(w, "the Terraform object plans no pull request", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(link); err != nil {
+		s.log.Error(err, "failed to write the pull request link")
+	}
+}
+
+// parseLinkPath returns the branch Terraform object of a link path.
+func parseLinkPath(path string) (client.ObjectKey, error) {
+	if !strings.HasPrefix(path, linkAPIPrefix) || !strings.HasSuffix(path, linkAPISuffix) {
+		return client.ObjectKey{}, fmt.Errorf("not found")
+	}
+
+	parts := strings.Split(strings.TrimSuffix(strings.TrimPrefix(path, linkAPIPrefix), linkAPISuffix), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return client.ObjectKey{}, fmt.Errorf("expected /v1/terraform/{namespace}/{name}/pull-request")
+	}
+
+	return client.ObjectKey{Namespace: parts[0], Name: parts[1]}, nil
+}
+
+// pullRequestLink returns the link of a branch Terraform object, from the
+// pull request recorded in its status by the planner.
+func pullRequestLink(branchTF *infrav1.Terraform) (PullRequestLink, bool) {
+	if branchTF.GetLabels()[LabelBranchPlanner] != "true" || branchTF.Status.PullRequest == nil {
+		return PullRequestLink{}, false
+	}
+
+	pr := branchTF.Status.PullRequest
+	return PullRequestLink{
+		Namespace:        branchTF.GetNamespace(),
+		Name:             branchTF.GetName(),
+		Primary:          branchTF.GetLabels()[LabelPrimaryResource],
+		PrimaryNamespace: primaryNamespace(branchTF),
+		Repository:       pr.Repository,
+		PullRequest:      pr.Number,
+		URL:              pr.URL,
+	}, true
+}
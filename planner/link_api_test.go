@@ -0,0 +1,112 @@
+package planner
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/planner/provider"
+)
+
+func Test_parseLinkPath(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	key, err := parseLinkPath("/v1/terraform/default/tf1-pr-7/pull-request")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	expectToEqual(g, key, client.ObjectKey{Namespace: "default", Name: "tf1-pr-7"})
+
+	_, err = parseLinkPath("/v1/terraform/tf1-pr-7/pull-request")
+	g.Expect(err).To(gomega.HaveOccurred())
+
+	_, err = parseLinkPath("/v1/terraform/default/tf1-pr-7/plan")
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func Test_LinkHandler(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(gomega.Succeed())
+
+	branchTF := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tf1-pr-7",
+			Namespace: "default",
+			Labels: map[string]string{
+				LabelBranchPlanner:    "true",
+				LabelPRID:             "7",
+				LabelPrimaryResource:  "tf1",
+				LabelPrimaryNamespace: "infra",
+			},
+		},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "branch-based-planner", Namespace: "default"},
+		Data:       map[string]string{"secretName": "bbp-token"},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bbp-token", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("github"), StatusAPITokenKey: []byte("status")},
+	}
+
+	clusterClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithStatusSubresource(&infrav1.Terraform{}).
+		WithObjects(branchTF, configMap, secret).Build()
+	server, err := New(
+		WithLogger(logr.Discard()),
+		WithClusterClient(clusterClient),
+		WithConfigMap("default/branch-based-planner"),
+	)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	get := func(path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		server.LinkHandler().ServeHTTP(rec, req)
+		return rec
+	}
+
+	expectToEqual(g, get("/v1/terraform/default/tf1-pr-7/pull-request", "").Code, http.StatusUnauthorized)
+	expectToEqual(g, get("/v1/terraform/default/tf2-pr-7/pull-request", "status").Code, http.StatusNotFound)
+	// the planner has not recorded the pull request yet
+	expectToEqual(g, get("/v1/terraform/default/tf1-pr-7/pull-request", "status").Code, http.StatusNotFound)
+
+	pr := provider.PullRequest{
+		Repository: provider.Repository{Org: "org", Name: "repo"},
+		Number:     7,
+		Link:       "https://github.com/org/repo/pull/7",
+	}
+	g.Expect(server.setPullRequestStatus(ctx, branchTF, pr)).To(gomega.Succeed())
+
+	rec := get("/v1/terraform/default/tf1-pr-7/pull-request", "status")
+	expectToEqual(g, rec.Code, http.StatusOK)
+
+	var link PullRequestLink
+	g.Expect(json.Unmarshal(rec.Body.Bytes(), &link)).To(gomega.Succeed())
+	expectToEqual(g, link, PullRequestLink{
+		Namespace:        "default",
+		Name:             "tf1-pr-7",
+		Primary:          "tf1",
+		PrimaryNamespace: "infra",
+		Repository:       "org/repo",
+		PullRequest:      7,
+		URL:              "https://github.com/org/repo/pull/7",
+	})
+}
@@ -1,4 +1,4 @@
-package polling
+package planner
 
 import (
 	"context"
@@ -25,7 +25,7 @@ var (
 // testEnv (Kubernetes API), and stopping it after the tests.
 func TestMain(m *testing.M) {
 	testEnv := &envtest.Environment{
-		CRDDirectoryPaths:     []string{filepath.Join("..", "..", "..", "config", "crd", "bases")},
+		CRDDirectoryPaths:     []string{filepath.Join("..", "config", "crd", "bases")},
 		ErrorIfCRDPathMissing: true,
 	}
 	cfg, err := testEnv.Start()
@@ -0,0 +1,123 @@
+package planner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/planner/provider"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// AnnotationMergeQueueStatus records on a branch Terraform object the
+	// last status reported on its commit, as <sha>/<state>, not to report it
+	// again on every poll.
+	AnnotationMergeQueueStatus = "infra.weave.works/merge-queue-status"
+
+	// MergeQueueStatusContext prefixes the context of the commit statuses
+	// of the merge queue entries, followed by the namespace and the name of
+	// the original Terraform object, like tf-controller/plan/default/tf1.
+	// The contexts are the status checks to require for the merge queue.
+	MergeQueueStatusContext = "tf-controller/plan"
+
+	// maxStatusDescription is the longest description of a commit status
+	// GitHub accepts.
+	maxStatusDescription = 140
+)
+
+// mergeQueueStatusContext is the context of the commit statuses of the plans
+// of an original Terraform object.
+func mergeQueueStatusContext(original *infrav1.Terraform) string {
+	return fmt.Sprintf("%s/%s/%s", MergeQueueStatusContext, original.GetNamespace(), original.GetName())
+}
+
+// reportCommitStatuses reports the state of the plan of each pull request as
+// a status of its head commit, and of each merge queue entry as a status of
+// its speculative merge commit, for the queue to wait for the plan before
+// merging, and to remove the entry when it fails. The checks the queue
+// requires are required for the pull requests to enter it too.
+func (s *Server) reportCommitStatuses(ctx context.Context, gitProvider provider.CommitStatusProvider, original *infrav1.Terraform, entries []provider.PullRequest) error {
+	config := s.config
+	if config == nil {
+		config = &Config{}
+	}
+	options, err := branchOptionsFor(config, original)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		branchTF := &infrav1.Terraform{}
+		if err := s.clusterClient.Get(ctx, branchKey(original, entry, options), branchTF); apierrors.IsNotFound(err) {
+			// the entry is not planned, like the ones of other base branches
+			// or the pull requests from forks
+			continue
+		} else if err != nil {
+			return fmt.Errorf("unable to get the branch Terraform object: %w", err)
+		}
+
+		status, err := s.mergeQueueStatus(ctx, original, branchTF, entry)
+		if err != nil {
+			return err
+		}
+
+		reported := entry.HeadSha + "/" + string(status.State)
+		if branchTF.GetAnnotations()[AnnotationMergeQueueStatus] == reported {
+			continue
+		}
+
+		if err := gitProvider.SetCommitStatus(ctx, entry.Repository, entry.HeadSha, status); err != nil {
+			return fmt.Errorf("unable to report the plan of pull request %d: %w", entry.Number, err)
+		}
+
+		patch := client.MergeFrom(branchTF.DeepCopy())
+		annotations := branchTF.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[AnnotationMergeQueueStatus] = reported
+		branchTF.SetAnnotations(annotations)
+		if err := s.clusterClient.Patch(ctx, branchTF, patch); err != nil {
+			return fmt.Errorf("unable to record the merge queue status: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// mergeQueueStatus returns the commit status of the plan of a merge queue
+// entry or a pull request, pending until its head commit itself is planned.
+func (s *Server) mergeQueueStatus(ctx context.Context, original, branchTF *infrav1.Terraform, entry provider.PullRequest) (provider.CommitStatus, error) {
+	status := provider.CommitStatus{
+		State:       provider.CommitStatePending,
+		Context:     mergeQueueStatusContext(original),
+		Description: "Planning the commit",
+	}
+
+	branchSource, err := s.getBranchSource(ctx, branchTF)
+	if err != nil || branchSource == nil {
+		return status, err
+	}
+
+	plan := branchStatus(branchTF, branchSource)
+	switch {
+	case plan.State == PlanStateFailure:
+		status.State = provider.CommitStateFailure
+		status.Description = plan.Message
+	case plan.State == PlanStateSuccess && strings.Contains(plan.Revision, entry.HeadSha):
+		status.State = provider.CommitStateSuccess
+		status.Description = plan.Summary
+		if status.Description == "" {
+			status.Description = plan.Message
+		}
+	}
+
+	if len(status.Description) > maxStatusDescription {
+		status.Description = status.Description[:maxStatusDescription-3] + "..."
+	}
+
+	return status, nil
+}
@@ -0,0 +1,118 @@
+package planner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	sourcev1b2 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/go-logr/logr"
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/planner/provider"
+)
+
+type fakeMergeQueueProvider struct {
+	provider.MergeQueueProvider
+	statuses []provider.CommitStatus
+}
+
+func (p *fakeMergeQueueProvider) SetCommitStatus(ctx context.Context, repo provider.Repository, sha string, status provider.CommitStatus) error {
+	p.statuses = append(p.statuses, status)
+	return nil
+}
+
+func Test_reconcileMergeQueue(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(sourcev1b2.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(gomega.Succeed())
+
+	original := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "tf1", Namespace: "default", UID: "uid"},
+		Spec: infrav1.TerraformSpec{
+			SourceRef: infrav1.CrossNamespaceSourceReference{Kind: "GitRepository", Name: "source", Namespace: "default"},
+		},
+	}
+	source := &sourcev1b2.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "source", Namespace: "default"},
+		Spec: sourcev1b2.GitRepositorySpec{
+			URL:       "https://github.com/org/repo",
+			Reference: &sourcev1b2.GitRepositoryRef{Branch: "main"},
+		},
+	}
+
+	server, err := New(
+		WithLogger(logr.Discard()),
+		WithClusterClient(fake.NewClientBuilder().WithScheme(scheme).
+			WithStatusSubresource(&infrav1.Terraform{}, &sourcev1b2.GitRepository{}).
+			WithObjects(original, source).Build()),
+	)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	pr := provider.PullRequest{Number: 7, BaseBranch: "main", HeadBranch: "feature", HeadSha: "aaaaaaaa"}
+	entry := provider.PullRequest{
+		Number:     7,
+		BaseBranch: "main",
+		HeadBranch: "gh-readonly-queue/main/pr-7-cccccccc",
+		HeadSha:    "bbbbbbbb",
+		MergeQueue: true,
+	}
+	g.Expect(server.reconcile(ctx, original, source, []provider.PullRequest{pr, entry})).To(gomega.Succeed())
+
+	// the entry is planned alongside its pull request, at the merge commit
+	branchSource := &sourcev1b2.GitRepository{}
+	g.Expect(server.clusterClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "tf1-mq-7"}, branchSource)).To(gomega.Succeed())
+	expectToEqual(g, *branchSource.Spec.Reference, sourcev1b2.GitRepositoryRef{Branch: entry.HeadBranch, Commit: "bbbbbbbb"})
+	branchTF := &infrav1.Terraform{}
+	g.Expect(server.clusterClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "tf1-pr-7"}, branchTF)).To(gomega.Succeed())
+	g.Expect(server.clusterClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "tf1-mq-7"}, branchTF)).To(gomega.Succeed())
+	expectToEqual(g, branchTF.GetLabels()[LabelMergeQueue], "true")
+
+	gitProvider := &fakeMergeQueueProvider{}
+	g.Expect(server.reportCommitStatuses(ctx, gitProvider, original, []provider.PullRequest{entry})).To(gomega.Succeed())
+	expectToEqual(g, gitProvider.statuses, []provider.CommitStatus{{
+		State:       provider.CommitStatePending,
+		Context:     "tf-controller/plan/default/tf1",
+		Description: "Planning the commit",
+	}})
+
+	// the pending status is reported once
+	g.Expect(server.reportCommitStatuses(ctx, gitProvider, original, []provider.PullRequest{entry})).To(gomega.Succeed())
+	expectToEqual(g, len(gitProvider.statuses), 1)
+
+	revision := entry.HeadBranch + "@sha1:bbbbbbbb"
+	branchSource.Status.Artifact = &sourcev1.Artifact{Revision: revision}
+	g.Expect(server.clusterClient.Status().Update(ctx, branchSource)).To(gomega.Succeed())
+	g.Expect(server.clusterClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "tf1-mq-7"}, branchTF)).To(gomega.Succeed())
+	branchTF.Status.LastAttemptedRevision = revision
+	branchTF.Status.Summary = "Plan: 1 to add, 0 to change, 0 to destroy"
+	branchTF.Status.Conditions = []metav1.Condition{{
+		Type:               meta.ReadyCondition,
+		Status:             metav1.ConditionUnknown,
+		Reason:             infrav1.PlannedWithChangesReason,
+		LastTransitionTime: metav1.Now(),
+	}}
+	g.Expect(server.clusterClient.Status().Update(ctx, branchTF)).To(gomega.Succeed())
+
+	g.Expect(server.reportCommitStatuses(ctx, gitProvider, original, []provider.PullRequest{entry})).To(gomega.Succeed())
+	expectToEqual(g, len(gitProvider.statuses), 2)
+	expectToEqual(g, gitProvider.statuses[1].State, provider.CommitStateSuccess)
+	expectToEqual(g, gitProvider.statuses[1].Description, "Plan: 1 to add, 0 to change, 0 to destroy")
+
+	// the entries are left out of the plan status of their pull request
+	planStatus, err := server.planStatus(ctx, "org/repo", 7)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	expectToEqual(g, len(planStatus.Terraforms), 1)
+	expectToEqual(g, planStatus.Terraforms[0].Name, "tf1-pr-7")
+}
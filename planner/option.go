@@ -0,0 +1,137 @@
+package planner
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type Option func(s *Server) error
+
+func WithLogger(log logr.Logger) Option {
+	return func(s *Server) error {
+		s.log = log
+
+		return nil
+	}
+}
+
+func WithClusterClient(clusterClient client.Client) Option {
+	return func(s *Server) error {
+		s.clusterClient = clusterClient
+
+		return nil
+	}
+}
+
+func WithConfigMap(configMapName string) Option {
+	return func(s *Server) error {
+		key, err := ParseConfigMapReference(configMapName)
+		if err != nil {
+			return err
+		}
+
+		s.configMapRef = key
+
+		return nil
+	}
+}
+
+// ParseConfigMapReference parses a reference to the planner ConfigMap, as
+// <namespace>/<name>, or <name> in the default namespace.
+func ParseConfigMapReference(configMapName string) (client.ObjectKey, error) {
+	namespace := "default"
+	name := ""
+	parts := strings.SplitN(configMapName, "/", 2)
+
+	if len(parts) < 1 {
+		return client.ObjectKey{}, fmt.Errorf("invalid ConfigMap reference: %q", configMapName)
+	}
+
+	if len(parts) < 2 {
+		name = parts[0]
+	} else {
+		namespace = parts[0]
+		name = parts[1]
+	}
+
+	if name == "" || namespace == "" {
+		return client.ObjectKey{}, fmt.Errorf("invalid ConfigMap reference: %q", configMapName)
+	}
+
+	return client.ObjectKey{
+		Namespace: namespace,
+		Name:      name,
+	}, nil
+}
+
+// WithControllerConfig reads the polling interval from the
+// TerraformControllerConfig of the name, when it sets one.
+func WithControllerConfig(name string) Option {
+	return func(s *Server) error {
+		s.controllerConfig = name
+
+		return nil
+	}
+}
+
+// WithRequiredLabel plans only the pull requests carrying the label, unless
+// their original Terraform object overrides it with an annotation. The
+// branch objects of a pull request losing the label are deleted, as when it
+// is closed.
+func WithRequiredLabel(label string) Option {
+	return func(s *Server) error {
+		s.requiredLabel = label
+
+		return nil
+	}
+}
+
+func WithPollingInterval(interval time.Duration) Option {
+	return func(s *Server) error {
+		s.pollingInterval = interval
+
+		return nil
+	}
+}
+
+// WithConfig sets the config of the planner, instead of reading it from a
+// ConfigMap, for a controller embedding the planner. The defaults of the
+// ConfigMap apply to the fields it leaves empty. Without a SecretName, no
+// Secret is read, for a GitProviderFactory which authenticates by itself.
+func WithConfig(config Config) Option {
+	return func(s *Server) error {
+		if err := completeConfig(&config); err != nil {
+			return err
+		}
+
+		s.staticConfig = &config
+
+		return nil
+	}
+}
+
+// WithGitProviderFactory sets the factory of the Git providers of the
+// repositories, instead of the plugin of the config or the built-in
+// providers.
+func WithGitProviderFactory(factory GitProviderFactory) Option {
+	return func(s *Server) error {
+		s.gitProviders = factory
+
+		return nil
+	}
+}
+
+// WithClock sets the clock of the planner, which ticks its polling
+// interval, e.g. a fake clock in tests.
+func WithClock(clock clock.WithTicker) Option {
+	return func(s *Server) error {
+		s.clock = clock
+
+		return nil
+	}
+}
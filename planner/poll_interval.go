@@ -0,0 +1,114 @@
+package planner
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+)
+
+const (
+	// AnnotationPollInterval on an original Terraform object overrides the
+	// polling interval of its pull requests, e.g. "30s", for the busy
+	// repositories to be polled more often than the quiet ones.
+	AnnotationPollInterval = "infra.weave.works/poll-interval"
+
+	// minPollInterval is the shortest polling interval of the annotation,
+	// not to exhaust the rate limits of the Git providers.
+	minPollInterval = 5 * time.Second
+)
+
+// pollIntervalOf returns the polling interval of the annotation of the
+// original Terraform object, or 0 when it has none.
+func pollIntervalOf(original *infrav1.Terraform) (time.Duration, error) {
+	value, ok := original.GetAnnotations()[AnnotationPollInterval]
+	if !ok {
+		return 0, nil
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval <= 0 {
+		return 0, fmt.Errorf("invalid %s annotation %q, must be a positive duration", AnnotationPollInterval, value)
+	}
+	if interval < minPollInterval {
+		return minPollInterval, nil
+	}
+	return interval, nil
+}
+
+// pollSchedule tracks when the Terraform objects of the config were last
+// polled, and the polling intervals of those overriding the one of the
+// planner with their annotation, as of their last poll.
+type pollSchedule struct {
+	lastPolled map[types.NamespacedName]time.Time
+	intervals  map[types.NamespacedName]time.Duration
+}
+
+// setInterval records the polling interval of the Terraform object, 0 for
+// the one of the planner.
+func (p *pollSchedule) setInterval(resource types.NamespacedName, interval time.Duration) {
+	if interval <= 0 {
+		delete(p.intervals, resource)
+		return
+	}
+	if p.intervals == nil {
+		p.intervals = map[types.NamespacedName]time.Duration{}
+	}
+	p.intervals[resource] = interval
+}
+
+// polled records the time the Terraform object was polled.
+func (p *pollSchedule) polled(resource types.NamespacedName, at time.Time) {
+	if p.lastPolled == nil {
+		p.lastPolled = map[types.NamespacedName]time.Time{}
+	}
+	p.lastPolled[resource] = at
+}
+
+// due returns the Terraform objects of the config whose polling interval
+// has elapsed since their last poll, within half a tick of the polling loop
+// for the ticks not to be missed by a hair. The objects not polled yet are
+// due, and the ones no longer in the config are forgotten.
+func (p *pollSchedule) due(resources []types.NamespacedName, now time.Time, interval, tick time.Duration) []types.NamespacedName {
+	inConfig := map[types.NamespacedName]bool{}
+	var due []types.NamespacedName
+	for _, resource := range resources {
+		inConfig[resource] = true
+		last, ok := p.lastPolled[resource]
+		if !ok || now.Sub(last)+tick/2 >= p.intervalOf(resource, interval) {
+			due = append(due, resource)
+		}
+	}
+
+	for resource := range p.lastPolled {
+		if !inConfig[resource] {
+			delete(p.lastPolled, resource)
+		}
+	}
+	for resource := range p.intervals {
+		if !inConfig[resource] {
+			delete(p.intervals, resource)
+		}
+	}
+	return due
+}
+
+// tick returns the interval of the ticks of the polling loop: the shortest
+// of the polling interval of the planner and of the Terraform objects.
+func (p *pollSchedule) tick(interval time.Duration) time.Duration {
+	tick := interval
+	for _, resourceInterval := range p.intervals {
+		if resourceInterval < tick {
+			tick = resourceInterval
+		}
+	}
+	return tick
+}
+
+func (p *pollSchedule) intervalOf(resource types.NamespacedName, interval time.Duration) time.Duration {
+	if resourceInterval, ok := p.intervals[resource]; ok {
+		return resourceInterval
+	}
+	return interval
+}
@@ -0,0 +1,70 @@
+package planner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+)
+
+func Test_pollIntervalOf(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	original := &infrav1.Terraform{}
+	g.Expect(pollIntervalOf(original)).To(gomega.BeZero())
+
+	original.SetAnnotations(map[string]string{AnnotationPollInterval: "10s"})
+	g.Expect(pollIntervalOf(original)).To(gomega.Equal(10 * time.Second))
+
+	original.SetAnnotations(map[string]string{AnnotationPollInterval: "100ms"})
+	g.Expect(pollIntervalOf(original)).To(gomega.Equal(minPollInterval))
+
+	original.SetAnnotations(map[string]string{AnnotationPollInterval: "soon"})
+	_, err := pollIntervalOf(original)
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func Test_pollSchedule(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	busy := types.NamespacedName{Namespace: "flux-system", Name: "busy"}
+	quiet := types.NamespacedName{Namespace: "flux-system", Name: "quiet"}
+	resources := []types.NamespacedName{busy, quiet}
+	now := metav1.Now().Time
+
+	var schedule pollSchedule
+	tick := schedule.tick(30 * time.Second)
+	g.Expect(tick).To(gomega.Equal(30 * time.Second))
+
+	// the objects not polled yet are due
+	g.Expect(schedule.due(resources, now, 30*time.Second, tick)).To(gomega.Equal(resources))
+	for _, resource := range resources {
+		schedule.polled(resource, now)
+	}
+	schedule.setInterval(busy, 10*time.Second)
+	tick = schedule.tick(30 * time.Second)
+	g.Expect(tick).To(gomega.Equal(10 * time.Second))
+
+	// the busy object is polled at each tick, the quiet one every three,
+	// even when the ticks come a hair early
+	for i := 1; i <= 3; i++ {
+		at := now.Add(time.Duration(i)*tick - time.Millisecond)
+		due := schedule.due(resources, at, 30*time.Second, tick)
+		if i < 3 {
+			g.Expect(due).To(gomega.Equal([]types.NamespacedName{busy}))
+		} else {
+			g.Expect(due).To(gomega.Equal(resources))
+		}
+		for _, resource := range due {
+			schedule.polled(resource, at)
+		}
+	}
+
+	// the objects removed from the config are forgotten
+	g.Expect(schedule.due([]types.NamespacedName{quiet}, now, 30*time.Second, tick)).To(gomega.BeEmpty())
+	g.Expect(schedule.tick(30 * time.Second)).To(gomega.Equal(30 * time.Second))
+}
@@ -1,4 +1,4 @@
-package polling
+package planner
 
 import (
 	"context"
@@ -10,7 +10,7 @@ import (
 
 	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
 	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
-	"github.com/weaveworks/tf-controller/internal/git/provider"
+	"github.com/weaveworks/tf-controller/planner/provider"
 )
 
 // This checks poll can be called with a little setting-up, with no
@@ -0,0 +1,332 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/net/context"
+)
+
+const (
+	azureDevOpsAPI        = "dev.azure.com"
+	azureDevOpsAPIVersion = "7.0"
+	azureDevOpsBranchRef  = "refs/heads/"
+)
+
+var (
+	// azureDevOpsVisualStudioURLRe matches the HTTPS URLs of the repositories
+	// of the organizations of Azure DevOps still served at their former
+	// visualstudio.com domain, which go-git-url does not know, such as
+	// https://contoso.visualstudio.com/DefaultCollection/infra/_git/helloworld.
+	azureDevOpsVisualStudioURLRe = regexp.MustCompile(`^https://(?:[^@/]+@)?([^./]+)\.visualstudio\.com/(?:DefaultCollection/)?([^/]+)/_git/([^/]+?)/?$`)
+
+	// azureDevOpsVisualStudioSSHURLRe matches their SSH URLs, such as
+	// contoso@vs-ssh.visualstudio.com:v3/contoso/infra/helloworld.
+	azureDevOpsVisualStudioSSHURLRe = regexp.MustCompile(`^(?:ssh://)?[^@/]+@vs-ssh\.visualstudio\.com(?::22)?[:/]v3/([^/]+)/([^/]+)/([^/]+?)/?$`)
+)
+
+// AzureDevOpsProvider is the provider of the repositories of Azure DevOps
+// Repos, whose org is the organization of Azure DevOps, and project its
+// project.
+type AzureDevOpsProvider struct {
+	log        logr.Logger
+	apiToken   string
+	hostname   string
+	httpClient *http.Client
+}
+
+// azureDevOpsRepository returns the repository of a visualstudio.com URL of
+// Azure DevOps.
+func azureDevOpsRepository(repoURL string) (Repository, bool) {
+	match := azureDevOpsVisualStudioURLRe.FindStringSubmatch(repoURL)
+	if match == nil {
+		match = azureDevOpsVisualStudioSSHURLRe.FindStringSubmatch(repoURL)
+	}
+	if match == nil {
+		return Repository{}, false
+	}
+
+	project, err := url.PathUnescape(match[2])
+	if err != nil {
+		return Repository{}, false
+	}
+	name, err := url.PathUnescape(match[3])
+	if err != nil {
+		return Repository{}, false
+	}
+
+	return Repository{
+		URL:     repoURL,
+		Org:     match[1],
+		Project: project,
+		Name:    name,
+	}, true
+}
+
+type azureDevOpsPullRequest struct {
+	PullRequestID         int    `json:"pullRequestId"`
+	SourceRefName         string `json:"sourceRefName"`
+	TargetRefName         string `json:"targetRefName"`
+	IsDraft               bool   `json:"isDraft"`
+	LastMergeSourceCommit struct {
+		CommitID string `json:"commitId"`
+	} `json:"lastMergeSourceCommit"`
+	LastMergeTargetCommit struct {
+		CommitID string `json:"commitId"`
+	} `json:"lastMergeTargetCommit"`
+	// ForkSource is the ref of the fork of a pull request from a fork.
+	ForkSource *struct {
+		Name string `json:"name"`
+	} `json:"forkSource"`
+	Labels []struct {
+		Name   string `json:"name"`
+		Active bool   `json:"active"`
+	} `json:"labels"`
+}
+
+func (p AzureDevOpsProvider) ListPullRequests(ctx context.Context, repo Repository) ([]PullRequest, error) {
+	prs := []PullRequest{}
+	for page := 0; ; page++ {
+		if page >= maxPages {
+			p.log.Info("stopped listing the pull requests after the maximum number of pages", "repository", repo.String(), "pages", maxPages)
+			break
+		}
+
+		var out struct {
+			Value []azureDevOpsPullRequest `json:"value"`
+		}
+		query := url.Values{
+			"searchCriteria.status": {"active"},
+			"$top":                  {fmt.Sprint(pageSize)},
+			"$skip":                 {fmt.Sprint(page * pageSize)},
+		}
+		if err := p.call(ctx, http.MethodGet, repo, "pullrequests", query, nil, &out); err != nil {
+			return nil, fmt.Errorf("failed to list pull requests: %w", err)
+		}
+
+		for _, pr := range out.Value {
+			labels := []string{}
+			for _, label := range pr.Labels {
+				if label.Active {
+					labels = append(labels, label.Name)
+				}
+			}
+
+			prs = append(prs, PullRequest{
+				Repository: repo,
+				Number:     pr.PullRequestID,
+				BaseBranch: strings.TrimPrefix(pr.TargetRefName, azureDevOpsBranchRef),
+				HeadBranch: strings.TrimPrefix(pr.SourceRefName, azureDevOpsBranchRef),
+				BaseSha:    pr.LastMergeTargetCommit.CommitID,
+				HeadSha:    pr.LastMergeSourceCommit.CommitID,
+				Fork:       pr.ForkSource != nil,
+				Draft:      pr.IsDraft,
+				Labels:     labels,
+				Link:       fmt.Sprintf("%s/pullrequest/%d", p.webURL(repo), pr.PullRequestID),
+			})
+		}
+		if len(out.Value) < pageSize {
+			break
+		}
+	}
+
+	sort.Slice(prs, func(i, j int) bool {
+		return prs[i].Number < prs[j].Number
+	})
+
+	return prs, nil
+}
+
+// AddCommentToPullRequest comments a pull request in a new thread, closed
+// not to hold the completion of the pull requests of the branch policies
+// requiring the comments to be resolved.
+func (p AzureDevOpsProvider) AddCommentToPullRequest(ctx context.Context, pr PullRequest, body []byte) (*Comment, error) {
+	var out struct {
+		ID int `json:"id"`
+	}
+	in := map[string]interface{}{
+		"comments": []map[string]interface{}{{
+			"parentCommentId": 0,
+			"content":         string(body),
+			"commentType":     "text",
+		}},
+		"status": "closed",
+	}
+	if err := p.call(ctx, http.MethodPost, pr.Repository, fmt.Sprintf("pullRequests/%d/threads", pr.Number), nil, in, &out); err != nil {
+		return nil, fmt.Errorf("failed to comment pull request %d: %w", pr.Number, err)
+	}
+
+	return &Comment{
+		ID:   out.ID,
+		Link: fmt.Sprintf("%s?discussionId=%d", pr.Link, out.ID),
+	}, nil
+}
+
+// CreatePullRequest is not supported by the Azure DevOps provider.
+func (p AzureDevOpsProvider) CreatePullRequest(ctx context.Context, repo Repository, newPR NewPullRequest) (*PullRequest, error) {
+	return nil, fmt.Errorf("failed to create pull request: not supported by the Azure DevOps provider")
+}
+
+func (p AzureDevOpsProvider) SetCommitStatus(ctx context.Context, repo Repository, sha string, status CommitStatus) error {
+	state := "pending"
+	switch status.State {
+	case CommitStateSuccess:
+		state = "succeeded"
+	case CommitStateFailure:
+		state = "failed"
+	}
+
+	in := map[string]interface{}{
+		"state":       state,
+		"description": status.Description,
+		"targetUrl":   status.Link,
+		"context": map[string]string{
+			"name": status.Context,
+		},
+	}
+	if err := p.call(ctx, http.MethodPost, repo, fmt.Sprintf("commits/%s/statuses", url.PathEscape(sha)), nil, in, nil); err != nil {
+		return fmt.Errorf("failed to set commit status: %w", err)
+	}
+
+	return nil
+}
+
+// Capabilities returns the capabilities of Azure DevOps: the commit statuses
+// and the draft pull requests.
+func (p AzureDevOpsProvider) Capabilities(ctx context.Context, repo Repository) (Capabilities, error) {
+	return Capabilities{
+		CapabilityCommitStatuses:    true,
+		CapabilityDraftPullRequests: true,
+	}, nil
+}
+
+// baseURL returns the URL of the organization of a repository, without a
+// trailing slash.
+func (p AzureDevOpsProvider) baseURL(repo Repository) string {
+	endpoint := p.hostname
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+	return strings.TrimSuffix(endpoint, "/") + "/" + url.PathEscape(repo.Org)
+}
+
+// webURL returns the web page of a repository.
+func (p AzureDevOpsProvider) webURL(repo Repository) string {
+	return fmt.Sprintf("%s/%s/_git/%s", p.baseURL(repo), url.PathEscape(repo.Project), url.PathEscape(repo.Name))
+}
+
+// call calls the Git API of Azure DevOps at a path relative to a repository,
+// authenticated with the personal access token of the provider.
+func (p AzureDevOpsProvider) call(ctx context.Context, method string, repo Repository, path string, query url.Values, in, out interface{}) error {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("api-version", azureDevOpsAPIVersion)
+	endpoint := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/%s?%s",
+		p.baseURL(repo), url.PathEscape(repo.Project), url.PathEscape(repo.Name), path, query.Encode())
+
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	// a personal access token is the password of any user
+	req.SetBasicAuth("", p.apiToken)
+
+	res, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode >= http.StatusMultipleChoices {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(resBody, &apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("%s: %s", res.Status, apiErr.Message)
+		}
+		return fmt.Errorf("unexpected status %s", res.Status)
+	}
+	// an invalid token is redirected to the sign-in page, with a status 203
+	if res.StatusCode == http.StatusNonAuthoritativeInfo {
+		return fmt.Errorf("unauthorized: the token is invalid or expired")
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(resBody, out)
+}
+
+func (p *AzureDevOpsProvider) SetLogger(log logr.Logger) error {
+	p.log = log
+	if transport, ok := p.httpClient.Transport.(*retryTransport); ok {
+		transport.log = log
+	}
+
+	return nil
+}
+
+func (p *AzureDevOpsProvider) SetToken(tokenType, token string) error {
+	switch tokenType {
+	case APITokenType:
+		p.apiToken = token
+	default:
+		return fmt.Errorf("unknown token type: %s", tokenType)
+	}
+
+	return nil
+}
+
+// SetHostname sets the endpoint of the API, dev.azure.com by default.
+func (p *AzureDevOpsProvider) SetHostname(hostname string) error {
+	p.hostname = hostname
+
+	return nil
+}
+
+func (p *AzureDevOpsProvider) Setup() error {
+	if p.apiToken == "" {
+		return fmt.Errorf("missing required option: Token")
+	}
+
+	if p.hostname == "" {
+		p.hostname = azureDevOpsAPI
+	}
+
+	return nil
+}
+
+func newAzureDevOpsProvider() *AzureDevOpsProvider {
+	return &AzureDevOpsProvider{
+		log:        logr.Discard(),
+		httpClient: &http.Client{Timeout: 30 * time.Second, Transport: newRetryTransport(nil, logr.Discard())},
+	}
+}
@@ -0,0 +1,147 @@
+package provider_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/tf-controller/planner/provider"
+)
+
+type fakeAzureDevOps struct {
+	calls         []string
+	authorization string
+	body          map[string]interface{}
+}
+
+func (a *fakeAzureDevOps) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	a.calls = append(a.calls, req.Method+" "+req.URL.RequestURI())
+	a.authorization = req.Header.Get("Authorization")
+	a.body = nil
+
+	var out string
+	switch req.Method + " " + req.URL.Path {
+	case "GET /contoso/infra/_apis/git/repositories/helloworld/pullrequests":
+		out = `{"value": [{
+			"pullRequestId": 12,
+			"sourceRefName": "refs/heads/feature-12",
+			"targetRefName": "refs/heads/main",
+			"isDraft": true,
+			"lastMergeSourceCommit": {"commitId": "abc"},
+			"lastMergeTargetCommit": {"commitId": "def"},
+			"labels": [{"name": "terraform", "active": true}, {"name": "stale", "active": false}]
+		}, {
+			"pullRequestId": 3,
+			"sourceRefName": "refs/heads/feature-3",
+			"targetRefName": "refs/heads/main",
+			"lastMergeSourceCommit": {"commitId": "123"},
+			"lastMergeTargetCommit": {"commitId": "def"},
+			"forkSource": {"name": "refs/heads/feature-3"}
+		}]}`
+	case "POST /contoso/infra/_apis/git/repositories/helloworld/pullRequests/12/threads":
+		_ = json.NewDecoder(req.Body).Decode(&a.body)
+		out = `{"id": 42}`
+	case "POST /contoso/infra/_apis/git/repositories/helloworld/commits/abc/statuses":
+		_ = json.NewDecoder(req.Body).Decode(&a.body)
+		out = `{"id": 1}`
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		out = `{"message": "TF401019: The Git repository does not exist."}`
+	}
+	_, _ = w.Write([]byte(out))
+}
+
+func TestAzureDevOpsFromURL(t *testing.T) {
+	for _, url := range []string{
+		"https://dev.azure.com/contoso/infra/_git/helloworld",
+		"https://contoso@dev.azure.com/contoso/infra/_git/helloworld",
+		"git@ssh.dev.azure.com:v3/contoso/infra/helloworld",
+		"https://contoso.visualstudio.com/infra/_git/helloworld",
+		"https://contoso.visualstudio.com/DefaultCollection/infra/_git/helloworld",
+		"contoso@vs-ssh.visualstudio.com:v3/contoso/infra/helloworld",
+	} {
+		p, repo, err := provider.FromURL(url, provider.WithToken(provider.APITokenType, "token"))
+		assert.NoError(t, err, url)
+		assert.IsType(t, &provider.AzureDevOpsProvider{}, p, url)
+		assert.Equal(t, "contoso", repo.Org, url)
+		assert.Equal(t, "infra", repo.Project, url)
+		assert.Equal(t, "helloworld", repo.Name, url)
+
+		repo, err = provider.RepositoryFromURL(url)
+		assert.NoError(t, err, url)
+		assert.Equal(t, "infra", repo.Project, url)
+	}
+}
+
+func TestAzureDevOpsProvider(t *testing.T) {
+	azure := &fakeAzureDevOps{}
+	server := httptest.NewServer(azure)
+	defer server.Close()
+
+	p, repo, err := provider.FromURL("https://dev.azure.com/contoso/infra/_git/helloworld",
+		provider.WithToken(provider.APITokenType, "token"), provider.WithDomain(server.URL))
+	assert.NoError(t, err)
+
+	prs, err := p.ListPullRequests(context.Background(), repo)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"GET /contoso/infra/_apis/git/repositories/helloworld/pullrequests?%24skip=0&%24top=100&api-version=7.0&searchCriteria.status=active",
+	}, azure.calls)
+	// the personal access token is the password of any user
+	assert.Equal(t, "Basic OnRva2Vu", azure.authorization)
+	if assert.Len(t, prs, 2) {
+		assert.Equal(t, 3, prs[0].Number)
+		assert.True(t, prs[0].Fork)
+		assert.Equal(t, provider.PullRequest{
+			Repository: repo,
+			Number:     12,
+			BaseBranch: "main",
+			HeadBranch: "feature-12",
+			BaseSha:    "def",
+			HeadSha:    "abc",
+			Draft:      true,
+			Labels:     []string{"terraform"},
+			Link:       server.URL + "/contoso/infra/_git/helloworld/pullrequest/12",
+		}, prs[1])
+	}
+
+	comment, err := p.AddCommentToPullRequest(context.Background(), prs[1], []byte("plan"))
+	assert.NoError(t, err)
+	assert.Equal(t, &provider.Comment{ID: 42, Link: prs[1].Link + "?discussionId=42"}, comment)
+	assert.Equal(t, map[string]interface{}{
+		"comments": []interface{}{map[string]interface{}{"parentCommentId": float64(0), "content": "plan", "commentType": "text"}},
+		"status":   "closed",
+	}, azure.body)
+
+	statuses, ok := p.(provider.CommitStatusProvider)
+	if assert.True(t, ok) {
+		err = statuses.SetCommitStatus(context.Background(), repo, "abc", provider.CommitStatus{
+			State:       provider.CommitStateFailure,
+			Context:     "tf-controller/plan",
+			Description: "Plan failed",
+			Link:        "https://example.com/plan",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"state":       "failed",
+			"description": "Plan failed",
+			"targetUrl":   "https://example.com/plan",
+			"context":     map[string]interface{}{"name": "tf-controller/plan"},
+		}, azure.body)
+	}
+
+	capabilities, err := p.(provider.CapabilityProvider).Capabilities(context.Background(), repo)
+	assert.NoError(t, err)
+	assert.True(t, capabilities[provider.CapabilityCommitStatuses])
+	assert.True(t, capabilities[provider.CapabilityDraftPullRequests])
+	assert.False(t, capabilities[provider.CapabilityReactions])
+
+	_, err = p.AddCommentToPullRequest(context.Background(), prs[0], []byte("plan"))
+	assert.ErrorContains(t, err, "404 Not Found: TF401019: The Git repository does not exist.")
+
+	_, err = p.CreatePullRequest(context.Background(), repo, provider.NewPullRequest{BaseBranch: "main", HeadBranch: "drift"})
+	assert.ErrorContains(t, err, "not supported")
+}
@@ -0,0 +1,419 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/net/context"
+)
+
+const (
+	bitbucketCloudAPI = "api.bitbucket.org"
+	// bitbucketCloudPageSize is the largest page of pull requests of
+	// Bitbucket Cloud.
+	bitbucketCloudPageSize = 50
+)
+
+var (
+	// bitbucketServerURLRe matches the HTTPS clone URLs and the web URLs of
+	// the repositories of Bitbucket Server and Data Center, after the context
+	// path of the server, such as
+	// https://bitbucket.example.com/scm/infra/helloworld.git or
+	// https://bitbucket.example.com/projects/INFRA/repos/helloworld/browse.
+	bitbucketServerURLRe = regexp.MustCompile(`^(https?)://(?:[^@/]+@)?([^/]+(?:/[^/]+)*?)/(?:scm/([^/]+)/([^/]+?)(?:\.git)?|projects/([^/]+)/repos/([^/]+)(?:/.*)?)/?$`)
+
+	// bitbucketServerSSHURLRe matches the SSH clone URLs of Bitbucket Server
+	// and Data Center, on their default SSH port, such as
+	// ssh://git@bitbucket.example.com:7999/infra/helloworld.git.
+	bitbucketServerSSHURLRe = regexp.MustCompile(`^ssh://(?:[^@/]+@)?([^/:]+):7999/([^/]+)/([^/]+?)(?:\.git)?/?$`)
+)
+
+// BitbucketProvider is the provider of the repositories of Bitbucket Cloud,
+// or of Bitbucket Server and Data Center, whose REST APIs differ. Bitbucket
+// has no labels, so the pull requests cannot be filtered by label.
+type BitbucketProvider struct {
+	log        logr.Logger
+	server     bool
+	apiToken   string
+	hostname   string
+	httpClient *http.Client
+}
+
+// bitbucketServerRepository returns the repository of a Bitbucket Server URL
+// and the URL of the server. The org of the repository is the key of its
+// project.
+func bitbucketServerRepository(repoURL string) (Repository, string, bool) {
+	if match := bitbucketServerURLRe.FindStringSubmatch(repoURL); match != nil {
+		org, name := match[3], match[4]
+		if org == "" {
+			org, name = match[5], match[6]
+		}
+		return Repository{
+			URL:  repoURL,
+			Org:  org,
+			Name: name,
+		}, match[1] + "://" + match[2], true
+	}
+
+	if match := bitbucketServerSSHURLRe.FindStringSubmatch(repoURL); match != nil {
+		// the REST API is assumed to be served at the root of the host
+		return Repository{
+			URL:  repoURL,
+			Org:  match[2],
+			Name: match[3],
+		}, "https://" + match[1], true
+	}
+
+	return Repository{}, "", false
+}
+
+// bitbucketRef is the branch of a pull request of Bitbucket Cloud.
+type bitbucketRef struct {
+	Branch struct {
+		Name string `json:"name"`
+	} `json:"branch"`
+	Commit struct {
+		Hash string `json:"hash"`
+	} `json:"commit"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+type bitbucketPullRequest struct {
+	ID          int          `json:"id"`
+	Source      bitbucketRef `json:"source"`
+	Destination bitbucketRef `json:"destination"`
+	Links       struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+}
+
+// bitbucketServerRef is the branch of a pull request of Bitbucket Server.
+type bitbucketServerRef struct {
+	DisplayID    string `json:"displayId"`
+	LatestCommit string `json:"latestCommit"`
+	Repository   struct {
+		Slug    string `json:"slug"`
+		Project struct {
+			Key string `json:"key"`
+		} `json:"project"`
+	} `json:"repository"`
+}
+
+type bitbucketServerPullRequest struct {
+	ID      int                `json:"id"`
+	FromRef bitbucketServerRef `json:"fromRef"`
+	ToRef   bitbucketServerRef `json:"toRef"`
+	Links   struct {
+		Self []struct {
+			Href string `json:"href"`
+		} `json:"self"`
+	} `json:"links"`
+}
+
+func (p BitbucketProvider) ListPullRequests(ctx context.Context, repo Repository) ([]PullRequest, error) {
+	var prs []PullRequest
+	var err error
+	if p.server {
+		prs, err = p.listServerPullRequests(ctx, repo)
+	} else {
+		prs, err = p.listCloudPullRequests(ctx, repo)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull requests: %w", err)
+	}
+
+	sort.Slice(prs, func(i, j int) bool {
+		return prs[i].Number < prs[j].Number
+	})
+
+	return prs, nil
+}
+
+// listCloudPullRequests lists the open pull requests of a repository of
+// Bitbucket Cloud, following the next page links. Bitbucket Cloud returns
+// the short hashes of the commits.
+func (p BitbucketProvider) listCloudPullRequests(ctx context.Context, repo Repository) ([]PullRequest, error) {
+	prs := []PullRequest{}
+	path := fmt.Sprintf("2.0/repositories/%s/%s/pullrequests?state=OPEN&pagelen=%d",
+		url.PathEscape(repo.Org), url.PathEscape(repo.Name), bitbucketCloudPageSize)
+	for page := 0; path != ""; page++ {
+		if page >= maxPages {
+			p.log.Info("stopped listing the pull requests after the maximum number of pages", "repository", repo.String(), "pages", maxPages)
+			break
+		}
+
+		var out struct {
+			Values []bitbucketPullRequest `json:"values"`
+			Next   string                 `json:"next"`
+		}
+		if err := p.call(ctx, http.MethodGet, path, nil, &out); err != nil {
+			return nil, err
+		}
+
+		for _, pr := range out.Values {
+			prs = append(prs, PullRequest{
+				Repository: repo,
+				Number:     pr.ID,
+				BaseBranch: pr.Destination.Branch.Name,
+				HeadBranch: pr.Source.Branch.Name,
+				BaseSha:    pr.Destination.Commit.Hash,
+				HeadSha:    pr.Source.Commit.Hash,
+				Fork:       pr.Source.Repository.FullName != "" && !strings.EqualFold(pr.Source.Repository.FullName, pr.Destination.Repository.FullName),
+				Link:       pr.Links.HTML.Href,
+			})
+		}
+		// the token is only sent to the API
+		if out.Next != "" && !strings.HasPrefix(out.Next, p.baseURL()+"/") {
+			return nil, fmt.Errorf("unexpected next page %s", out.Next)
+		}
+		path = out.Next
+	}
+
+	return prs, nil
+}
+
+// listServerPullRequests lists the open pull requests of a repository of
+// Bitbucket Server, page by page.
+func (p BitbucketProvider) listServerPullRequests(ctx context.Context, repo Repository) ([]PullRequest, error) {
+	prs := []PullRequest{}
+	start := 0
+	for page := 0; ; page++ {
+		if page >= maxPages {
+			p.log.Info("stopped listing the pull requests after the maximum number of pages", "repository", repo.String(), "pages", maxPages)
+			break
+		}
+
+		var out struct {
+			Values        []bitbucketServerPullRequest `json:"values"`
+			IsLastPage    bool                         `json:"isLastPage"`
+			NextPageStart int                          `json:"nextPageStart"`
+		}
+		path := fmt.Sprintf("%s/pull-requests?state=OPEN&limit=%d&start=%d", bitbucketServerRepoPath(repo), pageSize, start)
+		if err := p.call(ctx, http.MethodGet, path, nil, &out); err != nil {
+			return nil, err
+		}
+
+		for _, pr := range out.Values {
+			link := ""
+			if len(pr.Links.Self) > 0 {
+				link = pr.Links.Self[0].Href
+			}
+			prs = append(prs, PullRequest{
+				Repository: repo,
+				Number:     pr.ID,
+				BaseBranch: pr.ToRef.DisplayID,
+				HeadBranch: pr.FromRef.DisplayID,
+				BaseSha:    pr.ToRef.LatestCommit,
+				HeadSha:    pr.FromRef.LatestCommit,
+				Fork: !strings.EqualFold(pr.FromRef.Repository.Project.Key, pr.ToRef.Repository.Project.Key) ||
+					pr.FromRef.Repository.Slug != pr.ToRef.Repository.Slug,
+				Link: link,
+			})
+		}
+		if out.IsLastPage || out.NextPageStart <= start {
+			break
+		}
+		start = out.NextPageStart
+	}
+
+	return prs, nil
+}
+
+func (p BitbucketProvider) AddCommentToPullRequest(ctx context.Context, pr PullRequest, body []byte) (*Comment, error) {
+	if p.server {
+		var out struct {
+			ID int `json:"id"`
+		}
+		in := map[string]string{"text": string(body)}
+		path := fmt.Sprintf("%s/pull-requests/%d/comments", bitbucketServerRepoPath(pr.Repository), pr.Number)
+		if err := p.call(ctx, http.MethodPost, path, in, &out); err != nil {
+			return nil, fmt.Errorf("failed to comment pull request %d: %w", pr.Number, err)
+		}
+
+		link := pr.Link
+		if link != "" {
+			link = fmt.Sprintf("%s/overview?commentId=%d", strings.TrimSuffix(link, "/overview"), out.ID)
+		}
+		return &Comment{
+			ID:   out.ID,
+			Link: link,
+		}, nil
+	}
+
+	var out struct {
+		ID    int `json:"id"`
+		Links struct {
+			HTML struct {
+				Href string `json:"href"`
+			} `json:"html"`
+		} `json:"links"`
+	}
+	in := map[string]map[string]string{"content": {"raw": string(body)}}
+	path := fmt.Sprintf("2.0/repositories/%s/%s/pullrequests/%d/comments",
+		url.PathEscape(pr.Repository.Org), url.PathEscape(pr.Repository.Name), pr.Number)
+	if err := p.call(ctx, http.MethodPost, path, in, &out); err != nil {
+		return nil, fmt.Errorf("failed to comment pull request %d: %w", pr.Number, err)
+	}
+
+	return &Comment{
+		ID:   out.ID,
+		Link: out.Links.HTML.Href,
+	}, nil
+}
+
+// CreatePullRequest is not supported by the Bitbucket provider.
+func (p BitbucketProvider) CreatePullRequest(ctx context.Context, repo Repository, newPR NewPullRequest) (*PullRequest, error) {
+	return nil, fmt.Errorf("failed to create pull request: not supported by the Bitbucket provider")
+}
+
+// bitbucketServerRepoPath returns the path of a repository in the REST API
+// of Bitbucket Server.
+func bitbucketServerRepoPath(repo Repository) string {
+	return fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s", url.PathEscape(repo.Org), url.PathEscape(repo.Name))
+}
+
+// baseURL returns the URL of the REST API, without a trailing slash.
+func (p BitbucketProvider) baseURL() string {
+	endpoint := p.hostname
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+	return strings.TrimSuffix(endpoint, "/")
+}
+
+// call calls the REST API of Bitbucket at a path relative to the API, or at
+// the absolute URL of a next page, authenticated with the token of the
+// provider.
+func (p BitbucketProvider) call(ctx context.Context, method, path string, in, out interface{}) error {
+	endpoint := path
+	if !strings.Contains(path, "://") {
+		endpoint = p.baseURL() + "/" + path
+	}
+
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	// an app password of Bitbucket Cloud is given with its username, as
+	// <username>:<app-password>, the access tokens alone
+	if username, password, ok := strings.Cut(p.apiToken, ":"); ok {
+		req.SetBasicAuth(username, password)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+p.apiToken)
+	}
+
+	res, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode >= http.StatusMultipleChoices {
+		// the errors of Bitbucket Cloud and of Bitbucket Server
+		var apiErr struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+			Errors []struct {
+				Message string `json:"message"`
+			} `json:"errors"`
+		}
+		if json.Unmarshal(resBody, &apiErr) == nil {
+			if apiErr.Error.Message != "" {
+				return fmt.Errorf("%s: %s", res.Status, apiErr.Error.Message)
+			}
+			if len(apiErr.Errors) > 0 {
+				return fmt.Errorf("%s: %s", res.Status, apiErr.Errors[0].Message)
+			}
+		}
+		return fmt.Errorf("unexpected status %s", res.Status)
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(resBody, out)
+}
+
+func (p *BitbucketProvider) SetLogger(log logr.Logger) error {
+	p.log = log
+	if transport, ok := p.httpClient.Transport.(*retryTransport); ok {
+		transport.log = log
+	}
+
+	return nil
+}
+
+func (p *BitbucketProvider) SetToken(tokenType, token string) error {
+	switch tokenType {
+	case APITokenType:
+		p.apiToken = token
+	default:
+		return fmt.Errorf("unknown token type: %s", tokenType)
+	}
+
+	return nil
+}
+
+// SetHostname sets the endpoint of the REST API, api.bitbucket.org for
+// Bitbucket Cloud and the URL of the server, with its context path, for
+// Bitbucket Server.
+func (p *BitbucketProvider) SetHostname(hostname string) error {
+	p.hostname = hostname
+
+	return nil
+}
+
+func (p *BitbucketProvider) Setup() error {
+	if p.apiToken == "" {
+		return fmt.Errorf("missing required option: Token")
+	}
+
+	if p.hostname == "" {
+		if p.server {
+			return fmt.Errorf("missing required option: Domain")
+		}
+		p.hostname = bitbucketCloudAPI
+	}
+
+	return nil
+}
+
+func newBitbucketProvider(server bool) *BitbucketProvider {
+	return &BitbucketProvider{
+		log:        logr.Discard(),
+		server:     server,
+		httpClient: &http.Client{Timeout: 30 * time.Second, Transport: newRetryTransport(nil, logr.Discard())},
+	}
+}
@@ -0,0 +1,179 @@
+package provider_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/tf-controller/planner/provider"
+)
+
+type fakeBitbucket struct {
+	server        *httptest.Server
+	calls         []string
+	authorization string
+	comment       map[string]interface{}
+}
+
+func (b *fakeBitbucket) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	b.calls = append(b.calls, req.Method+" "+req.URL.RequestURI())
+	b.authorization = req.Header.Get("Authorization")
+
+	var out string
+	switch req.Method + " " + req.URL.RequestURI() {
+	// Bitbucket Cloud
+	case "GET /2.0/repositories/infra/helloworld/pullrequests?state=OPEN&pagelen=50":
+		out = `{"values": [{
+			"id": 12,
+			"source": {"branch": {"name": "feature-12"}, "commit": {"hash": "abc"}, "repository": {"full_name": "infra/helloworld"}},
+			"destination": {"branch": {"name": "main"}, "commit": {"hash": "def"}, "repository": {"full_name": "infra/helloworld"}},
+			"links": {"html": {"href": "https://bitbucket.org/infra/helloworld/pull-requests/12"}}
+		}], "next": "` + b.server.URL + `/2.0/repositories/infra/helloworld/pullrequests?state=OPEN&pagelen=50&page=2"}`
+	case "GET /2.0/repositories/infra/helloworld/pullrequests?state=OPEN&pagelen=50&page=2":
+		out = `{"values": [{
+			"id": 3,
+			"source": {"branch": {"name": "feature-3"}, "commit": {"hash": "123"}, "repository": {"full_name": "someone/helloworld"}},
+			"destination": {"branch": {"name": "main"}, "commit": {"hash": "def"}, "repository": {"full_name": "infra/helloworld"}},
+			"links": {"html": {"href": "https://bitbucket.org/infra/helloworld/pull-requests/3"}}
+		}]}`
+	case "POST /2.0/repositories/infra/helloworld/pullrequests/12/comments":
+		_ = json.NewDecoder(req.Body).Decode(&b.comment)
+		out = `{"id": 42, "links": {"html": {"href": "https://bitbucket.org/infra/helloworld/pull-requests/12#comment-42"}}}`
+
+	// Bitbucket Server
+	case "GET /bitbucket/rest/api/1.0/projects/INFRA/repos/helloworld/pull-requests?state=OPEN&limit=100&start=0":
+		out = `{"values": [{
+			"id": 7,
+			"fromRef": {"displayId": "feature-7", "latestCommit": "abc", "repository": {"slug": "helloworld", "project": {"key": "INFRA"}}},
+			"toRef": {"displayId": "main", "latestCommit": "def", "repository": {"slug": "helloworld", "project": {"key": "INFRA"}}},
+			"links": {"self": [{"href": "https://bitbucket.example.com/bitbucket/projects/INFRA/repos/helloworld/pull-requests/7"}]}
+		}], "isLastPage": false, "nextPageStart": 1}`
+	case "GET /bitbucket/rest/api/1.0/projects/INFRA/repos/helloworld/pull-requests?state=OPEN&limit=100&start=1":
+		out = `{"values": [{
+			"id": 5,
+			"fromRef": {"displayId": "feature-5", "latestCommit": "123", "repository": {"slug": "helloworld", "project": {"key": "~SOMEONE"}}},
+			"toRef": {"displayId": "main", "latestCommit": "def", "repository": {"slug": "helloworld", "project": {"key": "INFRA"}}}
+		}], "isLastPage": true}`
+	case "POST /bitbucket/rest/api/1.0/projects/INFRA/repos/helloworld/pull-requests/7/comments":
+		_ = json.NewDecoder(req.Body).Decode(&b.comment)
+		out = `{"id": 21}`
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		out = `{"type": "error", "error": {"message": "Resource not found"}}`
+	}
+	_, _ = w.Write([]byte(out))
+}
+
+func TestBitbucketFromURL(t *testing.T) {
+	for _, tt := range []struct {
+		url        string
+		org        string
+		name       string
+		serverType provider.ProviderType
+	}{
+		{url: "https://bitbucket.org/infra/helloworld.git", org: "infra", name: "helloworld", serverType: provider.ProviderBitbucket},
+		{url: "git@bitbucket.org:infra/helloworld.git", org: "infra", name: "helloworld", serverType: provider.ProviderBitbucket},
+		{url: "https://bitbucket.example.com/scm/infra/helloworld.git", org: "infra", name: "helloworld", serverType: provider.ProviderBitbucketServer},
+		{url: "https://admin@bitbucket.example.com/bitbucket/scm/infra/helloworld.git", org: "infra", name: "helloworld", serverType: provider.ProviderBitbucketServer},
+		{url: "https://bitbucket.example.com/projects/INFRA/repos/helloworld/browse", org: "INFRA", name: "helloworld", serverType: provider.ProviderBitbucketServer},
+		{url: "ssh://git@bitbucket.example.com:7999/infra/helloworld.git", org: "infra", name: "helloworld", serverType: provider.ProviderBitbucketServer},
+	} {
+		p, repo, err := provider.FromURL(tt.url, provider.WithToken(provider.APITokenType, "token"))
+		assert.NoError(t, err, tt.url)
+		assert.IsType(t, &provider.BitbucketProvider{}, p, tt.url)
+		assert.Equal(t, tt.org, repo.Org, tt.url)
+		assert.Equal(t, tt.name, repo.Name, tt.url)
+
+		repo, err = provider.RepositoryFromURL(tt.url)
+		assert.NoError(t, err, tt.url)
+		assert.Equal(t, tt.org+"/"+tt.name, repo.String(), tt.url)
+	}
+}
+
+func TestBitbucketCloudProvider(t *testing.T) {
+	bitbucket := &fakeBitbucket{}
+	bitbucket.server = httptest.NewServer(bitbucket)
+	defer bitbucket.server.Close()
+
+	p, repo, err := provider.FromURL("https://bitbucket.org/infra/helloworld",
+		provider.WithToken(provider.APITokenType, "token"), provider.WithDomain(bitbucket.server.URL))
+	assert.NoError(t, err)
+
+	prs, err := p.ListPullRequests(context.Background(), repo)
+	assert.NoError(t, err)
+	assert.Len(t, bitbucket.calls, 2)
+	assert.Equal(t, "Bearer token", bitbucket.authorization)
+	if assert.Len(t, prs, 2) {
+		assert.Equal(t, 3, prs[0].Number)
+		assert.Equal(t, "feature-3", prs[0].HeadBranch)
+		assert.True(t, prs[0].Fork)
+		assert.Equal(t, provider.PullRequest{
+			Repository: repo,
+			Number:     12,
+			BaseBranch: "main",
+			HeadBranch: "feature-12",
+			BaseSha:    "def",
+			HeadSha:    "abc",
+			Link:       "https://bitbucket.org/infra/helloworld/pull-requests/12",
+		}, prs[1])
+	}
+
+	comment, err := p.AddCommentToPullRequest(context.Background(), prs[1], []byte("plan"))
+	assert.NoError(t, err)
+	assert.Equal(t, &provider.Comment{ID: 42, Link: "https://bitbucket.org/infra/helloworld/pull-requests/12#comment-42"}, comment)
+	assert.Equal(t, map[string]interface{}{"content": map[string]interface{}{"raw": "plan"}}, bitbucket.comment)
+
+	_, err = p.AddCommentToPullRequest(context.Background(), prs[0], []byte("plan"))
+	assert.ErrorContains(t, err, "404 Not Found: Resource not found")
+
+	// an app password is given with its username
+	p, _, err = provider.FromURL("https://bitbucket.org/infra/helloworld",
+		provider.WithToken(provider.APITokenType, "admin:app-password"), provider.WithDomain(bitbucket.server.URL))
+	assert.NoError(t, err)
+	_, err = p.ListPullRequests(context.Background(), repo)
+	assert.NoError(t, err)
+	assert.Equal(t, "Basic YWRtaW46YXBwLXBhc3N3b3Jk", bitbucket.authorization)
+}
+
+func TestBitbucketServerProvider(t *testing.T) {
+	bitbucket := &fakeBitbucket{}
+	bitbucket.server = httptest.NewServer(bitbucket)
+	defer bitbucket.server.Close()
+
+	p, repo, err := provider.FromURL("https://bitbucket.example.com/bitbucket/projects/INFRA/repos/helloworld",
+		provider.WithToken(provider.APITokenType, "token"), provider.WithDomain(bitbucket.server.URL+"/bitbucket"))
+	assert.NoError(t, err)
+
+	prs, err := p.ListPullRequests(context.Background(), repo)
+	assert.NoError(t, err)
+	assert.Len(t, bitbucket.calls, 2)
+	if assert.Len(t, prs, 2) {
+		assert.Equal(t, 5, prs[0].Number)
+		assert.True(t, prs[0].Fork)
+		assert.Equal(t, provider.PullRequest{
+			Repository: repo,
+			Number:     7,
+			BaseBranch: "main",
+			HeadBranch: "feature-7",
+			BaseSha:    "def",
+			HeadSha:    "abc",
+			Link:       "https://bitbucket.example.com/bitbucket/projects/INFRA/repos/helloworld/pull-requests/7",
+		}, prs[1])
+	}
+
+	comment, err := p.AddCommentToPullRequest(context.Background(), prs[1], []byte("plan"))
+	assert.NoError(t, err)
+	assert.Equal(t, &provider.Comment{
+		ID:   21,
+		Link: fmt.Sprintf("%s/overview?commentId=21", prs[1].Link),
+	}, comment)
+	assert.Equal(t, map[string]interface{}{"text": "plan"}, bitbucket.comment)
+
+	_, err = p.CreatePullRequest(context.Background(), repo, provider.NewPullRequest{BaseBranch: "main", HeadBranch: "drift"})
+	assert.ErrorContains(t, err, "not supported")
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"golang.org/x/net/context"
+)
+
+// Capability is an optional feature of a Git server. The planner only uses
+// the features the provider of a repository advertises, and degrades
+// without the others rather than failing.
+type Capability string
+
+const (
+	// CapabilityCommitStatuses is the reporting of statuses on commits, the
+	// checks of the pull requests.
+	CapabilityCommitStatuses = Capability("commitStatuses")
+	// CapabilityDraftPullRequests is the draft flag of the pull requests,
+	// set on the listed pull requests.
+	CapabilityDraftPullRequests = Capability("draftPullRequests")
+	// CapabilityReactions is the adding of reactions to pull requests.
+	CapabilityReactions = Capability("reactions")
+	// CapabilityChangedFiles is the listing of the files a pull request
+	// changes.
+	CapabilityChangedFiles = Capability("changedFiles")
+	// CapabilityMergeQueues is the listing of the entries of merge queues.
+	CapabilityMergeQueues = Capability("mergeQueues")
+)
+
+// Capabilities is the set of the capabilities of a provider.
+type Capabilities map[Capability]bool
+
+// Has reports whether the set includes the capability.
+func (c Capabilities) Has(capability Capability) bool {
+	return c[capability]
+}
+
+// CapabilityProvider is implemented by the providers with optional
+// capabilities, which may depend on the Git server of a repository, like
+// the ones of a plugin.
+type CapabilityProvider interface {
+	Capabilities(ctx context.Context, repo Repository) (Capabilities, error)
+}
+
+// CapabilitiesOf returns the capabilities of the provider of a repository,
+// none for a provider without optional capabilities.
+func CapabilitiesOf(ctx context.Context, p Provider, repo Repository) (Capabilities, error) {
+	capable, ok := p.(CapabilityProvider)
+	if !ok {
+		return Capabilities{}, nil
+	}
+
+	return capable.Capabilities(ctx, repo)
+}
+
+// CommitStatusProvider is implemented by the providers with the
+// CapabilityCommitStatuses.
+type CommitStatusProvider interface {
+	// SetCommitStatus sets a status of a commit.
+	SetCommitStatus(ctx context.Context, repo Repository, sha string, status CommitStatus) error
+}
+
+// Reaction is the content of a reaction to a pull request.
+type Reaction string
+
+const (
+	ReactionEyes     = Reaction("eyes")
+	ReactionRocket   = Reaction("rocket")
+	ReactionThumbsUp = Reaction("+1")
+)
+
+// ReactionProvider is implemented by the providers with the
+// CapabilityReactions.
+type ReactionProvider interface {
+	AddReactionToPullRequest(ctx context.Context, pr PullRequest, reaction Reaction) error
+}
+
+// ChangedFilesProvider is implemented by the providers with the
+// CapabilityChangedFiles.
+type ChangedFilesProvider interface {
+	// ListChangedFiles returns the paths of the files a pull request
+	// changes, including the previous paths of the renamed files.
+	ListChangedFiles(ctx context.Context, pr PullRequest) ([]string, error)
+}
@@ -0,0 +1,395 @@
+package provider
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/go-logr/logr"
+	"golang.org/x/net/context"
+)
+
+const (
+	codeCommitService      = "codecommit"
+	codeCommitTargetPrefix = "CodeCommit_20150413."
+	codeCommitContentType  = "application/x-amz-json-1.1"
+	codeCommitBranchPrefix = "refs/heads/"
+)
+
+// codeCommitReadOperations are the operations of the CodeCommit API which
+// change nothing, retried after a server error.
+var codeCommitReadOperations = map[string]bool{
+	"ListPullRequests": true,
+	"GetPullRequest":   true,
+	"GetBranch":        true,
+}
+
+var (
+	// codeCommitURLRe matches the HTTPS and SSH URLs of the CodeCommit
+	// repositories, such as
+	// https://git-codecommit.eu-west-1.amazonaws.com/v1/repos/helloworld.
+	codeCommitURLRe = regexp.MustCompile(`^(?:https|ssh)://(?:[^@/]+@)?git-codecommit(?:-fips)?\.([a-z0-9-]+)\.amazonaws\.com(?:\.cn)?(?::\d+)?/v1/repos/([^/]+?)/?$`)
+
+	// codeCommitGRCURLRe matches the URLs of git-remote-codecommit, such as
+	// codecommit::eu-west-1://helloworld.
+	codeCommitGRCURLRe = regexp.MustCompile(`^codecommit::([a-z0-9-]+)://(?:[^@/]+@)?([^/]+)$`)
+)
+
+// CodeCommitProvider is the provider of the AWS CodeCommit repositories. It
+// calls the CodeCommit API with the AWS credentials of the environment, such
+// as those of the IAM role of the service account (IRSA), rather than with a
+// token.
+type CodeCommitProvider struct {
+	log         logr.Logger
+	hostname    string
+	credentials aws.CredentialsProvider
+	httpClient  *http.Client
+	signer      *v4.Signer
+}
+
+// codeCommitRepository returns the repository of a CodeCommit URL. The org
+// of the repository is its region, as CodeCommit has no organizations.
+func codeCommitRepository(repoURL string) (Repository, bool) {
+	match := codeCommitURLRe.FindStringSubmatch(repoURL)
+	if match == nil {
+		match = codeCommitGRCURLRe.FindStringSubmatch(repoURL)
+	}
+	if match == nil {
+		return Repository{}, false
+	}
+
+	return Repository{
+		URL:  repoURL,
+		Org:  match[1],
+		Name: strings.TrimSuffix(match[2], ".git"),
+	}, true
+}
+
+type codeCommitTarget struct {
+	RepositoryName       string `json:"repositoryName"`
+	SourceReference      string `json:"sourceReference"`
+	DestinationReference string `json:"destinationReference,omitempty"`
+	SourceCommit         string `json:"sourceCommit,omitempty"`
+	DestinationCommit    string `json:"destinationCommit,omitempty"`
+}
+
+type codeCommitPullRequest struct {
+	PullRequestID      string             `json:"pullRequestId"`
+	PullRequestTargets []codeCommitTarget `json:"pullRequestTargets"`
+}
+
+func (p CodeCommitProvider) ListPullRequests(ctx context.Context, repo Repository) ([]PullRequest, error) {
+	var ids []string
+	nextToken := ""
+	for page := 0; ; page++ {
+		if page >= maxPages {
+			p.log.Info("stopped listing the pull requests after the maximum number of pages", "repository", repo.String(), "pages", maxPages)
+			break
+		}
+
+		var out struct {
+			PullRequestIDs []string `json:"pullRequestIds"`
+			NextToken      string   `json:"nextToken"`
+		}
+		in := map[string]string{
+			"repositoryName":    repo.Name,
+			"pullRequestStatus": "OPEN",
+		}
+		if nextToken != "" {
+			in["nextToken"] = nextToken
+		}
+		if err := p.call(ctx, repo, "ListPullRequests", in, &out); err != nil {
+			return nil, fmt.Errorf("failed to list pull requests: %w", err)
+		}
+
+		ids = append(ids, out.PullRequestIDs...)
+		if out.NextToken == "" {
+			break
+		}
+		nextToken = out.NextToken
+	}
+
+	prs := []PullRequest{}
+	for _, id := range ids {
+		var out struct {
+			PullRequest codeCommitPullRequest `json:"pullRequest"`
+		}
+		if err := p.call(ctx, repo, "GetPullRequest", map[string]string{"pullRequestId": id}, &out); err != nil {
+			return nil, fmt.Errorf("failed to get pull request %s: %w", id, err)
+		}
+
+		pr, ok := p.pullRequest(repo, out.PullRequest)
+		if !ok {
+			p.log.Info("skipping pull request of another repository or with an invalid ID", "pull-request", id)
+			continue
+		}
+		prs = append(prs, pr)
+	}
+
+	sort.Slice(prs, func(i, j int) bool {
+		return prs[i].Number < prs[j].Number
+	})
+
+	return prs, nil
+}
+
+// pullRequest returns the pull request of the target of a CodeCommit pull
+// request on the repository. A pull request has a target per repository.
+func (p CodeCommitProvider) pullRequest(repo Repository, pr codeCommitPullRequest) (PullRequest, bool) {
+	number, err := strconv.Atoi(pr.PullRequestID)
+	if err != nil {
+		return PullRequest{}, false
+	}
+
+	for _, target := range pr.PullRequestTargets {
+		if target.RepositoryName != repo.Name {
+			continue
+		}
+
+		return PullRequest{
+			Repository: repo,
+			Number:     number,
+			BaseBranch: strings.TrimPrefix(target.DestinationReference, codeCommitBranchPrefix),
+			HeadBranch: strings.TrimPrefix(target.SourceReference, codeCommitBranchPrefix),
+			BaseSha:    target.DestinationCommit,
+			HeadSha:    target.SourceCommit,
+			Link: fmt.Sprintf("https://%s.console.aws.amazon.com/codesuite/codecommit/repositories/%s/pull-requests/%d?region=%s",
+				repo.Org, repo.Name, number, repo.Org),
+		}, true
+	}
+
+	return PullRequest{}, false
+}
+
+func (p CodeCommitProvider) AddCommentToPullRequest(ctx context.Context, pr PullRequest, body []byte) (*Comment, error) {
+	var out struct {
+		Comment struct {
+			CommentID string `json:"commentId"`
+		} `json:"comment"`
+	}
+	in := map[string]string{
+		"pullRequestId":  strconv.Itoa(pr.Number),
+		"repositoryName": pr.Repository.Name,
+		"beforeCommitId": pr.BaseSha,
+		"afterCommitId":  pr.HeadSha,
+		"content":        string(body),
+	}
+	if err := p.call(ctx, pr.Repository, "PostCommentForPullRequest", in, &out); err != nil {
+		return nil, fmt.Errorf("failed to comment pull request %d: %w", pr.Number, err)
+	}
+
+	// the IDs of the CodeCommit comments are not numbers
+	return &Comment{
+		Link: pr.Link,
+	}, nil
+}
+
+func (p CodeCommitProvider) CreatePullRequest(ctx context.Context, repo Repository, newPR NewPullRequest) (*PullRequest, error) {
+	var branch struct {
+		Branch struct {
+			CommitID string `json:"commitId"`
+		} `json:"branch"`
+	}
+	if err := p.call(ctx, repo, "GetBranch", map[string]string{"repositoryName": repo.Name, "branchName": newPR.BaseBranch}, &branch); err != nil {
+		return nil, fmt.Errorf("failed to find branch %s: %w", newPR.BaseBranch, err)
+	}
+	baseSha := branch.Branch.CommitID
+
+	in := map[string]string{"repositoryName": repo.Name, "branchName": newPR.HeadBranch, "commitId": baseSha}
+	if err := p.call(ctx, repo, "CreateBranch", in, nil); err != nil {
+		return nil, fmt.Errorf("failed to create branch %s: %w", newPR.HeadBranch, err)
+	}
+
+	paths := make([]string, 0, len(newPR.Files))
+	for path := range newPR.Files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	// the files are committed at once
+	type putFile struct {
+		FilePath    string `json:"filePath"`
+		FileContent string `json:"fileContent"`
+	}
+	commit := struct {
+		RepositoryName string    `json:"repositoryName"`
+		BranchName     string    `json:"branchName"`
+		ParentCommitID string    `json:"parentCommitId"`
+		CommitMessage  string    `json:"commitMessage"`
+		PutFiles       []putFile `json:"putFiles"`
+	}{
+		RepositoryName: repo.Name,
+		BranchName:     newPR.HeadBranch,
+		ParentCommitID: baseSha,
+		CommitMessage:  newPR.CommitMessage,
+	}
+	for _, path := range paths {
+		commit.PutFiles = append(commit.PutFiles, putFile{
+			FilePath:    path,
+			FileContent: base64.StdEncoding.EncodeToString(newPR.Files[path]),
+		})
+	}
+	if len(commit.PutFiles) > 0 {
+		if err := p.call(ctx, repo, "CreateCommit", commit, nil); err != nil {
+			return nil, fmt.Errorf("failed to commit %s: %w", strings.Join(paths, ", "), err)
+		}
+	}
+
+	var out struct {
+		PullRequest codeCommitPullRequest `json:"pullRequest"`
+	}
+	create := struct {
+		Title       string             `json:"title"`
+		Description string             `json:"description,omitempty"`
+		Targets     []codeCommitTarget `json:"targets"`
+	}{
+		Title:       newPR.Title,
+		Description: newPR.Body,
+		Targets: []codeCommitTarget{{
+			RepositoryName:       repo.Name,
+			SourceReference:      newPR.HeadBranch,
+			DestinationReference: newPR.BaseBranch,
+		}},
+	}
+	if err := p.call(ctx, repo, "CreatePullRequest", create, &out); err != nil {
+		return nil, fmt.Errorf("failed to create pull request: %w", err)
+	}
+
+	pr, ok := p.pullRequest(repo, out.PullRequest)
+	if !ok {
+		return nil, fmt.Errorf("failed to create pull request: unexpected pull request %q", out.PullRequest.PullRequestID)
+	}
+
+	return &pr, nil
+}
+
+// call calls an operation of the CodeCommit API of the region of the
+// repository, signed with the AWS credentials of the provider.
+func (p CodeCommitProvider) call(ctx context.Context, repo Repository, operation string, in, out interface{}) error {
+	payload, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+
+	endpoint := p.hostname
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("codecommit.%s.amazonaws.com", repo.Org)
+	}
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+"/", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", codeCommitContentType)
+	req.Header.Set("X-Amz-Target", codeCommitTargetPrefix+operation)
+
+	credentials, err := p.credentials.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the AWS credentials: %w", err)
+	}
+	payloadHash := sha256.Sum256(payload)
+	if err := p.signer.SignHTTP(ctx, credentials, req, hex.EncodeToString(payloadHash[:]), codeCommitService, repo.Org, time.Now()); err != nil {
+		return fmt.Errorf("failed to sign the request: %w", err)
+	}
+	if codeCommitReadOperations[operation] {
+		// marks the request idempotent, without sending the header
+		req.Header["Idempotency-Key"] = nil
+	}
+
+	res, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Type != "" {
+			// the type may be prefixed with the namespace of the error
+			errType := apiErr.Type[strings.LastIndex(apiErr.Type, "#")+1:]
+			return fmt.Errorf("%s %s: %s", operation, errType, apiErr.Message)
+		}
+		return fmt.Errorf("%s: unexpected status %s", operation, res.Status)
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(body, out)
+}
+
+func (p *CodeCommitProvider) SetLogger(log logr.Logger) error {
+	p.log = log
+	if transport, ok := p.httpClient.Transport.(*retryTransport); ok {
+		transport.log = log
+	}
+
+	return nil
+}
+
+// SetToken accepts the API token of the planner, but does not use it: the
+// CodeCommit API is called with the AWS credentials of the environment.
+func (p *CodeCommitProvider) SetToken(tokenType, token string) error {
+	switch tokenType {
+	case APITokenType:
+	default:
+		return fmt.Errorf("unknown token type: %s", tokenType)
+	}
+
+	return nil
+}
+
+// SetHostname sets the endpoint of the CodeCommit API, which defaults to
+// the one of the region of each repository.
+func (p *CodeCommitProvider) SetHostname(hostname string) error {
+	p.hostname = hostname
+
+	return nil
+}
+
+func (p *CodeCommitProvider) Setup() error {
+	if p.credentials == nil {
+		cfg, err := config.LoadDefaultConfig(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to load the AWS configuration: %w", err)
+		}
+		p.credentials = cfg.Credentials
+	}
+	if p.credentials == nil {
+		return fmt.Errorf("missing the AWS credentials")
+	}
+
+	return nil
+}
+
+func newCodeCommitProvider() *CodeCommitProvider {
+	return &CodeCommitProvider{
+		log:        logr.Discard(),
+		httpClient: &http.Client{Timeout: 30 * time.Second, Transport: newRetryTransport(nil, logr.Discard())},
+		signer:     v4.NewSigner(),
+	}
+}
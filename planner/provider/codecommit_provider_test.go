@@ -0,0 +1,137 @@
+package provider_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/tf-controller/planner/provider"
+)
+
+type fakeCodeCommit struct {
+	calls         []string
+	authorization string
+	comment       map[string]string
+	// endless always returns a next token
+	endless bool
+}
+
+func (c *fakeCodeCommit) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	operation := strings.TrimPrefix(req.Header.Get("X-Amz-Target"), "CodeCommit_20150413.")
+	c.calls = append(c.calls, operation)
+	c.authorization = req.Header.Get("Authorization")
+
+	var in map[string]string
+	_ = json.NewDecoder(req.Body).Decode(&in)
+
+	var out string
+	switch operation {
+	case "ListPullRequests":
+		if c.endless {
+			out = `{"pullRequestIds": ["12"], "nextToken": "next"}`
+		} else if in["nextToken"] == "" {
+			out = `{"pullRequestIds": ["12"], "nextToken": "page-2"}`
+		} else {
+			out = `{"pullRequestIds": ["3"]}`
+		}
+	case "GetPullRequest":
+		out = `{"pullRequest": {"pullRequestId": "` + in["pullRequestId"] + `", "pullRequestTargets": [{
+			"repositoryName": "helloworld",
+			"sourceReference": "refs/heads/feature-` + in["pullRequestId"] + `",
+			"destinationReference": "refs/heads/main",
+			"sourceCommit": "abc",
+			"destinationCommit": "def"
+		}]}}`
+	case "PostCommentForPullRequest":
+		c.comment = in
+		out = `{"comment": {"commentId": "ff7a1c2e"}}`
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		out = `{"__type": "com.amazonaws.codecommit#InvalidActionException", "message": "unexpected action"}`
+	}
+	_, _ = w.Write([]byte(out))
+}
+
+func TestCodeCommitFromURL(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIAEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+
+	for _, url := range []string{
+		"https://git-codecommit.eu-west-1.amazonaws.com/v1/repos/helloworld",
+		"ssh://APKAEXAMPLE@git-codecommit.eu-west-1.amazonaws.com/v1/repos/helloworld",
+		"codecommit::eu-west-1://helloworld",
+	} {
+		p, repo, err := provider.FromURL(url)
+		assert.NoError(t, err)
+		assert.IsType(t, &provider.CodeCommitProvider{}, p)
+		assert.Equal(t, "eu-west-1", repo.Org)
+		assert.Equal(t, "helloworld", repo.Name)
+	}
+}
+
+func TestCodeCommitProvider(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIAEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+
+	codeCommit := &fakeCodeCommit{}
+	server := httptest.NewServer(codeCommit)
+	defer server.Close()
+
+	p, repo, err := provider.FromURL("https://git-codecommit.eu-west-1.amazonaws.com/v1/repos/helloworld", provider.WithDomain(server.URL))
+	assert.NoError(t, err)
+
+	prs, err := p.ListPullRequests(context.Background(), repo)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ListPullRequests", "ListPullRequests", "GetPullRequest", "GetPullRequest"}, codeCommit.calls)
+	assert.Contains(t, codeCommit.authorization, "Credential=AKIAEXAMPLE/")
+	assert.Contains(t, codeCommit.authorization, "/eu-west-1/codecommit/aws4_request")
+	if assert.Len(t, prs, 2) {
+		assert.Equal(t, 3, prs[0].Number)
+		assert.Equal(t, "feature-3", prs[0].HeadBranch)
+		assert.Equal(t, "main", prs[0].BaseBranch)
+		assert.Equal(t, "abc", prs[0].HeadSha)
+		assert.Equal(t, "https://eu-west-1.console.aws.amazon.com/codesuite/codecommit/repositories/helloworld/pull-requests/3?region=eu-west-1", prs[0].Link)
+		assert.Equal(t, 12, prs[1].Number)
+	}
+
+	comment, err := p.AddCommentToPullRequest(context.Background(), prs[0], []byte("plan"))
+	assert.NoError(t, err)
+	assert.Equal(t, prs[0].Link, comment.Link)
+	assert.Equal(t, map[string]string{
+		"pullRequestId":  "3",
+		"repositoryName": "helloworld",
+		"beforeCommitId": "def",
+		"afterCommitId":  "abc",
+		"content":        "plan",
+	}, codeCommit.comment)
+
+	_, err = p.CreatePullRequest(context.Background(), repo, provider.NewPullRequest{BaseBranch: "main", HeadBranch: "drift"})
+	assert.ErrorContains(t, err, "GetBranch InvalidActionException: unexpected action")
+}
+
+func TestCodeCommitProviderMaxPages(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIAEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+
+	codeCommit := &fakeCodeCommit{endless: true}
+	server := httptest.NewServer(codeCommit)
+	defer server.Close()
+
+	p, repo, err := provider.FromURL("https://git-codecommit.eu-west-1.amazonaws.com/v1/repos/helloworld", provider.WithDomain(server.URL))
+	assert.NoError(t, err)
+
+	_, err = p.ListPullRequests(context.Background(), repo)
+	assert.NoError(t, err)
+
+	listed := 0
+	for _, call := range codeCommit.calls {
+		if call == "ListPullRequests" {
+			listed++
+		}
+	}
+	assert.Equal(t, 100, listed)
+}
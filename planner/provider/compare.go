@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"golang.org/x/net/context"
+)
+
+// Commit is a commit of a repository.
+type Commit struct {
+	Sha string
+	// Message is the first line of the message of the commit.
+	Message string
+	Author  string
+}
+
+// Comparison is the difference between two commits of a repository.
+type Comparison struct {
+	// Commits are the commits of the head which the base does not include,
+	// oldest first.
+	Commits []Commit
+	// Files are the paths of the files the commits change, including the
+	// previous paths of the renamed files.
+	Files []string
+	// TotalCommits is the number of the commits of the head which the base
+	// does not include, more than the commits listed when the Git server
+	// truncates them.
+	TotalCommits int
+}
+
+// CompareProvider is implemented by the providers which compare two commits
+// of a repository, for the controller to record what a new revision of a
+// source changes since the last apply.
+type CompareProvider interface {
+	CompareCommits(ctx context.Context, repo Repository, base, head string) (*Comparison, error)
+}
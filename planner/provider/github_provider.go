@@ -0,0 +1,362 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/github"
+	"github.com/jenkins-x/go-scm/scm/factory"
+	"golang.org/x/net/context"
+)
+
+type GitHubProvider struct {
+	log      logr.Logger
+	apiToken string
+	hostname string
+	client   *scm.Client
+}
+
+func (p GitHubProvider) ListPullRequests(ctx context.Context, repo Repository) ([]PullRequest, error) {
+	prList, err := listPages(ctx, p.log, func(opts scm.ListOptions) ([]*scm.PullRequest, *scm.Response, error) {
+		return p.client.PullRequests.List(ctx, repo.String(), &scm.PullRequestListOptions{Page: opts.Page, Size: opts.Size})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull requests: %w", err)
+	}
+
+	prs := []PullRequest{}
+
+	for _, pr := range prList {
+		labels := []string{}
+		for _, label := range pr.Labels {
+			labels = append(labels, label.Name)
+		}
+
+		prs = append(prs, PullRequest{
+			Repository: repo,
+			Number:     pr.Number,
+			BaseBranch: pr.Base.Ref,
+			HeadBranch: pr.Head.Ref,
+			BaseSha:    pr.Base.Sha,
+			HeadSha:    pr.Head.Sha,
+			Fork:       pr.Fork != "" && pr.Fork != pr.Base.Repo.FullName,
+			Draft:      pr.Draft,
+			Labels:     labels,
+			Link:       pr.Link,
+		})
+	}
+
+	return prs, nil
+}
+
+func (p GitHubProvider) AddCommentToPullRequest(ctx context.Context, pr PullRequest, body []byte) (*Comment, error) {
+	comment, _, err := p.client.Issues.CreateComment(ctx, pr.Repository.String(), pr.Number, &scm.CommentInput{
+		Body: string(body),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull requests: %w", err)
+	}
+
+	return &Comment{
+		ID:   comment.ID,
+		Link: comment.Link,
+	}, nil
+}
+
+func (p GitHubProvider) CreatePullRequest(ctx context.Context, repo Repository, newPR NewPullRequest) (*PullRequest, error) {
+	base, _, err := p.client.Git.FindBranch(ctx, repo.String(), newPR.BaseBranch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find branch %s: %w", newPR.BaseBranch, err)
+	}
+
+	if _, _, err := p.client.Git.CreateRef(ctx, repo.String(), "refs/heads/"+newPR.HeadBranch, base.Sha); err != nil {
+		return nil, fmt.Errorf("failed to create branch %s: %w", newPR.HeadBranch, err)
+	}
+
+	paths := make([]string, 0, len(newPR.Files))
+	for path := range newPR.Files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		params := &scm.ContentParams{
+			Branch:  newPR.HeadBranch,
+			Message: newPR.CommitMessage,
+			Data:    newPR.Files[path],
+		}
+
+		// the file is updated when it exists on the base branch
+		current, res, err := p.client.Contents.Find(ctx, repo.String(), path, newPR.HeadBranch)
+		switch {
+		case err == nil:
+			params.Sha = current.Sha
+			_, err = p.client.Contents.Update(ctx, repo.String(), path, params)
+		case res != nil && res.Status == http.StatusNotFound:
+			_, err = p.client.Contents.Create(ctx, repo.String(), path, params)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to commit %s: %w", path, err)
+		}
+	}
+
+	pr, _, err := p.client.PullRequests.Create(ctx, repo.String(), &scm.PullRequestInput{
+		Title: newPR.Title,
+		Body:  newPR.Body,
+		Head:  newPR.HeadBranch,
+		Base:  newPR.BaseBranch,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pull request: %w", err)
+	}
+
+	return &PullRequest{
+		Repository: repo,
+		Number:     pr.Number,
+		BaseBranch: newPR.BaseBranch,
+		HeadBranch: newPR.HeadBranch,
+		BaseSha:    base.Sha,
+		HeadSha:    pr.Head.Sha,
+		Link:       pr.Link,
+	}, nil
+}
+
+// ListMergeQueueEntries returns the entries of the merge queues, from their
+// temporary branches.
+func (p GitHubProvider) ListMergeQueueEntries(ctx context.Context, repo Repository) ([]PullRequest, error) {
+	branches, err := listPages(ctx, p.log, func(opts scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
+		return p.client.Git.ListBranches(ctx, repo.String(), &opts)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	entries := []PullRequest{}
+
+	for _, branch := range branches {
+		base, number, ok := ParseMergeQueueBranch(branch.Name)
+		if !ok {
+			continue
+		}
+
+		entries = append(entries, PullRequest{
+			Repository: repo,
+			Number:     number,
+			BaseBranch: base,
+			HeadBranch: branch.Name,
+			HeadSha:    branch.Sha,
+			MergeQueue: true,
+			Link:       fmt.Sprintf("https://%s/%s/pull/%d", p.hostname, repo, number),
+		})
+	}
+
+	return entries, nil
+}
+
+func (p GitHubProvider) SetCommitStatus(ctx context.Context, repo Repository, sha string, status CommitStatus) error {
+	state := scm.StatePending
+	switch status.State {
+	case CommitStateSuccess:
+		state = scm.StateSuccess
+	case CommitStateFailure:
+		state = scm.StateFailure
+	}
+
+	if _, _, err := p.client.Repositories.CreateStatus(ctx, repo.String(), sha, &scm.StatusInput{
+		State:  state,
+		Label:  status.Context,
+		Desc:   status.Description,
+		Target: status.Link,
+	}); err != nil {
+		return fmt.Errorf("failed to set commit status: %w", err)
+	}
+
+	return nil
+}
+
+// Capabilities returns all the capabilities, GitHub and GitHub Enterprise
+// supporting them all.
+func (p GitHubProvider) Capabilities(ctx context.Context, repo Repository) (Capabilities, error) {
+	return Capabilities{
+		CapabilityCommitStatuses:    true,
+		CapabilityDraftPullRequests: true,
+		CapabilityReactions:         true,
+		CapabilityChangedFiles:      true,
+		CapabilityMergeQueues:       true,
+	}, nil
+}
+
+// AddReactionToPullRequest adds a reaction to a pull request, with the API
+// of the reactions of the issues, which go-scm does not cover.
+func (p GitHubProvider) AddReactionToPullRequest(ctx context.Context, pr PullRequest, reaction Reaction) error {
+	body, err := json.Marshal(map[string]string{"content": string(reaction)})
+	if err != nil {
+		return err
+	}
+
+	res, err := p.client.Do(ctx, &scm.Request{
+		Method: http.MethodPost,
+		Path:   fmt.Sprintf("repos/%s/issues/%d/reactions", pr.Repository, pr.Number),
+		Header: http.Header{
+			"Accept":       []string{"application/vnd.github+json"},
+			"Content-Type": []string{"application/json"},
+		},
+		Body: bytes.NewReader(body),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add reaction to pull request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.Status >= http.StatusMultipleChoices {
+		return fmt.Errorf("failed to add reaction to pull request: %s", http.StatusText(res.Status))
+	}
+
+	return nil
+}
+
+// CompareCommits compares two commits with the API of the comparisons,
+// which go-scm only covers for the files. GitHub lists up to 250 commits
+// and 300 files.
+func (p GitHubProvider) CompareCommits(ctx context.Context, repo Repository, base, head string) (*Comparison, error) {
+	res, err := p.client.Do(ctx, &scm.Request{
+		Method: http.MethodGet,
+		Path:   fmt.Sprintf("repos/%s/compare/%s...%s", repo, base, head),
+		Header: http.Header{"Accept": []string{"application/vnd.github+json"}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare commits: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.Status >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("failed to compare commits: %s", http.StatusText(res.Status))
+	}
+
+	var out struct {
+		TotalCommits int `json:"total_commits"`
+		Commits      []struct {
+			Sha    string `json:"sha"`
+			Commit struct {
+				Message string `json:"message"`
+				Author  struct {
+					Name string `json:"name"`
+				} `json:"author"`
+			} `json:"commit"`
+		} `json:"commits"`
+		Files []struct {
+			Filename         string `json:"filename"`
+			PreviousFilename string `json:"previous_filename"`
+		} `json:"files"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to decode the comparison: %w", err)
+	}
+
+	comparison := &Comparison{TotalCommits: out.TotalCommits}
+	for _, commit := range out.Commits {
+		message, _, _ := strings.Cut(commit.Commit.Message, "\n")
+		comparison.Commits = append(comparison.Commits, Commit{
+			Sha:     commit.Sha,
+			Message: message,
+			Author:  commit.Commit.Author.Name,
+		})
+	}
+	for _, file := range out.Files {
+		comparison.Files = append(comparison.Files, file.Filename)
+		if file.PreviousFilename != "" && file.PreviousFilename != file.Filename {
+			comparison.Files = append(comparison.Files, file.PreviousFilename)
+		}
+	}
+
+	return comparison, nil
+}
+
+func (p GitHubProvider) ListChangedFiles(ctx context.Context, pr PullRequest) ([]string, error) {
+	changes, err := listPages(ctx, p.log, func(opts scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
+		return p.client.PullRequests.ListChanges(ctx, pr.Repository.String(), pr.Number, &opts)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list changed files: %w", err)
+	}
+
+	paths := []string{}
+	for _, change := range changes {
+		paths = append(paths, change.Path)
+		// go-scm does not flag the files GitHub reports as renamed
+		if change.PreviousPath != "" && change.PreviousPath != change.Path {
+			paths = append(paths, change.PreviousPath)
+		}
+	}
+
+	return paths, nil
+}
+
+func (p *GitHubProvider) SetLogger(log logr.Logger) error {
+	p.log = log
+
+	return nil
+}
+
+func (p *GitHubProvider) SetToken(tokenType, token string) error {
+	switch tokenType {
+	case APITokenType:
+		p.apiToken = token
+	default:
+		return fmt.Errorf("unknown token type: %s", tokenType)
+	}
+
+	return nil
+}
+
+func (p *GitHubProvider) SetHostname(hostname string) error {
+	p.hostname = hostname
+
+	return nil
+}
+
+func (p *GitHubProvider) Setup() error {
+	var err error
+
+	if p.apiToken == "" {
+		return fmt.Errorf("missing required option: Token")
+	}
+
+	if p.hostname == "" {
+		p.hostname = "github.com"
+	}
+
+	if p.hostname != "" {
+		p.client, err = github.New(fmt.Sprintf("https://%s", p.hostname))
+		if err != nil {
+			return fmt.Errorf("failed to create new github client: %w", err)
+		}
+	} else {
+		p.client = github.NewDefault()
+	}
+
+	p.client, err = factory.NewClient(
+		"github",
+		fmt.Sprintf("https://%s", p.hostname),
+		p.apiToken,
+	)
+	if err != nil {
+		return err
+	}
+
+	p.client.Client.Transport = newRetryTransport(p.client.Client.Transport, p.log)
+
+	return nil
+}
+
+func newGitHubProvider() *GitHubProvider {
+	return &GitHubProvider{
+		log: logr.Discard(),
+	}
+}
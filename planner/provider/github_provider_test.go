@@ -0,0 +1,180 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/tf-controller/planner/provider"
+)
+
+type fakeGitHub struct {
+	url   string
+	calls []string
+	// failures are the statuses of the next responses of each path, before
+	// the successful one.
+	failures  map[string][]int
+	reactions []string
+}
+
+func (g *fakeGitHub) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	call := req.Method + " " + req.URL.Path
+	if page := req.URL.Query().Get("page"); page != "" {
+		call += "?page=" + page
+	}
+	g.calls = append(g.calls, call)
+
+	if failures := g.failures[call]; len(failures) > 0 {
+		g.failures[call] = failures[1:]
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(failures[0])
+		_, _ = w.Write([]byte(`{"message": "You have exceeded a secondary rate limit."}`))
+		return
+	}
+
+	pullRequest := func(number int) string {
+		return fmt.Sprintf(`{"number": %d, "head": {"ref": "feature-%d", "sha": "abc"}, "base": {"ref": "main", "sha": "def", "repo": {"full_name": "org/repo"}}}`, number, number)
+	}
+
+	switch {
+	case call == "GET /api/v3/repos/org/repo/pulls?page=1":
+		w.Header().Set("Link", fmt.Sprintf(`<%s/api/v3/repos/org/repo/pulls?page=2>; rel="next", <%s/api/v3/repos/org/repo/pulls?page=2>; rel="last"`, g.url, g.url))
+		_, _ = w.Write([]byte("[" + pullRequest(1) + "]"))
+	case call == "GET /api/v3/repos/org/repo/pulls?page=2":
+		_, _ = w.Write([]byte("[" + pullRequest(2) + "]"))
+	case strings.HasSuffix(call, "/comments"):
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": 1}`))
+	case strings.HasSuffix(call, "/reactions"):
+		body, _ := io.ReadAll(req.Body)
+		g.reactions = append(g.reactions, string(body))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": 1}`))
+	case call == "GET /api/v3/repos/org/repo/pulls/1/files?page=1":
+		_, _ = w.Write([]byte(`[{"filename": "terraform/main.tf", "status": "modified"}, {"filename": "modules/vpc/main.tf", "previous_filename": "vpc/main.tf", "status": "renamed"}]`))
+	case call == "GET /api/v3/repos/org/repo/compare/aaa...ccc":
+		_, _ = w.Write([]byte(`{"total_commits": 2, "commits": [{"sha": "bbb", "commit": {"message": "Add a subnet\n\nFor the database.", "author": {"name": "Jane"}}}, {"sha": "ccc", "commit": {"message": "Resize the VPC", "author": {"name": "John"}}}], "files": [{"filename": "terraform/main.tf"}, {"filename": "terraform/vpc.tf", "previous_filename": "vpc.tf"}]}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestGitHubProviderRetries(t *testing.T) {
+	gitHub := &fakeGitHub{failures: map[string][]int{
+		"GET /api/v3/repos/org/repo/pulls?page=2":       {http.StatusBadGateway, http.StatusForbidden},
+		"POST /api/v3/repos/org/repo/issues/1/comments": {http.StatusTooManyRequests},
+		"POST /api/v3/repos/org/repo/issues/2/comments": {http.StatusBadGateway},
+	}}
+	server := httptest.NewTLSServer(gitHub)
+	defer server.Close()
+	gitHub.url = server.URL
+
+	// the client of the provider trusts the certificate of the server
+	defaultTransport := http.DefaultTransport
+	http.DefaultTransport = server.Client().Transport
+	defer func() { http.DefaultTransport = defaultTransport }()
+
+	p, err := provider.New(provider.ProviderGitHub,
+		provider.WithToken(provider.APITokenType, "token"),
+		provider.WithDomain(strings.TrimPrefix(server.URL, "https://")))
+	assert.NoError(t, err)
+	repo := provider.Repository{Org: "org", Name: "repo"}
+
+	// all the pages are listed, the failed page retried
+	prs, err := p.ListPullRequests(context.Background(), repo)
+	assert.NoError(t, err)
+	assert.Len(t, prs, 2)
+	assert.Equal(t, 2, prs[1].Number)
+	assert.Equal(t, []string{
+		"GET /api/v3/repos/org/repo/pulls?page=1",
+		"GET /api/v3/repos/org/repo/pulls?page=2",
+		"GET /api/v3/repos/org/repo/pulls?page=2",
+		"GET /api/v3/repos/org/repo/pulls?page=2",
+	}, gitHub.calls)
+
+	// a comment is posted again after a rate limit, but not after a server
+	// error, as it may have been posted
+	_, err = p.AddCommentToPullRequest(context.Background(), prs[0], []byte("plan"))
+	assert.NoError(t, err)
+	_, err = p.AddCommentToPullRequest(context.Background(), prs[1], []byte("plan"))
+	assert.Error(t, err)
+	assert.Equal(t, []string{
+		"POST /api/v3/repos/org/repo/issues/1/comments",
+		"POST /api/v3/repos/org/repo/issues/1/comments",
+		"POST /api/v3/repos/org/repo/issues/2/comments",
+	}, gitHub.calls[4:])
+
+	// a canceled context is not retried
+	gitHub.failures["GET /api/v3/repos/org/repo/pulls?page=1"] = []int{http.StatusBadGateway}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = p.ListPullRequests(ctx, repo)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestGitHubProviderCapabilities(t *testing.T) {
+	gitHub := &fakeGitHub{}
+	server := httptest.NewTLSServer(gitHub)
+	defer server.Close()
+	gitHub.url = server.URL
+
+	defaultTransport := http.DefaultTransport
+	http.DefaultTransport = server.Client().Transport
+	defer func() { http.DefaultTransport = defaultTransport }()
+
+	p, err := provider.New(provider.ProviderGitHub,
+		provider.WithToken(provider.APITokenType, "token"),
+		provider.WithDomain(strings.TrimPrefix(server.URL, "https://")))
+	assert.NoError(t, err)
+	repo := provider.Repository{Org: "org", Name: "repo"}
+	pr := provider.PullRequest{Repository: repo, Number: 1}
+
+	capabilities, err := provider.CapabilitiesOf(context.Background(), p, repo)
+	assert.NoError(t, err)
+	assert.True(t, capabilities.Has(provider.CapabilityReactions))
+	assert.True(t, capabilities.Has(provider.CapabilityChangedFiles))
+
+	assert.NoError(t, p.(provider.ReactionProvider).AddReactionToPullRequest(context.Background(), pr, provider.ReactionEyes))
+	assert.Equal(t, []string{`{"content":"eyes"}`}, gitHub.reactions)
+
+	// the previous paths of the renamed files are changed too
+	paths, err := p.(provider.ChangedFilesProvider).ListChangedFiles(context.Background(), pr)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"terraform/main.tf", "modules/vpc/main.tf", "vpc/main.tf"}, paths)
+}
+
+func TestGitHubProviderCompareCommits(t *testing.T) {
+	gitHub := &fakeGitHub{}
+	server := httptest.NewTLSServer(gitHub)
+	defer server.Close()
+	gitHub.url = server.URL
+
+	defaultTransport := http.DefaultTransport
+	http.DefaultTransport = server.Client().Transport
+	defer func() { http.DefaultTransport = defaultTransport }()
+
+	p, err := provider.New(provider.ProviderGitHub,
+		provider.WithToken(provider.APITokenType, "token"),
+		provider.WithDomain(strings.TrimPrefix(server.URL, "https://")))
+	assert.NoError(t, err)
+	repo := provider.Repository{Org: "org", Name: "repo"}
+
+	comparison, err := p.(provider.CompareProvider).CompareCommits(context.Background(), repo, "aaa", "ccc")
+	assert.NoError(t, err)
+	assert.Equal(t, &provider.Comparison{
+		Commits: []provider.Commit{
+			{Sha: "bbb", Message: "Add a subnet", Author: "Jane"},
+			{Sha: "ccc", Message: "Resize the VPC", Author: "John"},
+		},
+		Files:        []string{"terraform/main.tf", "terraform/vpc.tf", "vpc.tf"},
+		TotalCommits: 2,
+	}, comparison)
+
+	_, err = p.(provider.CompareProvider).CompareCommits(context.Background(), repo, "aaa", "ddd")
+	assert.Error(t, err)
+}
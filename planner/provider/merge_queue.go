@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/context"
+)
+
+// MergeQueueBranchPrefix starts the names of the temporary branches GitHub
+// creates for the entries of a merge queue, like
+// gh-readonly-queue/main/pr-123-<sha of the base>.
+const MergeQueueBranchPrefix = "gh-readonly-queue/"
+
+// MergeQueueProvider is implemented by the providers of the Git servers
+// with merge queues. The entries of a queue are planned like pull requests,
+// at the speculative merge commit of their temporary branch, and the status
+// of their plans is reported on that commit for the queue to wait for it.
+type MergeQueueProvider interface {
+	// ListMergeQueueEntries returns the entries of the merge queues of the
+	// repository, as pull requests whose head is the temporary branch.
+	ListMergeQueueEntries(ctx context.Context, repo Repository) ([]PullRequest, error)
+
+	CommitStatusProvider
+}
+
+// CommitState is the state of a commit status.
+type CommitState string
+
+const (
+	CommitStatePending = CommitState("pending")
+	CommitStateSuccess = CommitState("success")
+	CommitStateFailure = CommitState("failure")
+)
+
+// CommitStatus is a status of a commit, such as a required status check of
+// a merge queue.
+type CommitStatus struct {
+	State CommitState
+	// Context identifies the status among the ones of the commit.
+	Context     string
+	Description string
+	// Link is the page of the details of the status.
+	Link string
+}
+
+// ParseMergeQueueBranch returns the base branch and the number of the pull
+// request of a merge queue temporary branch, and false for other branches.
+func ParseMergeQueueBranch(branch string) (string, int, bool) {
+	if !strings.HasPrefix(branch, MergeQueueBranchPrefix) {
+		return "", 0, false
+	}
+	rest := strings.TrimPrefix(branch, MergeQueueBranchPrefix)
+
+	i := strings.LastIndex(rest, "/pr-")
+	if i <= 0 {
+		return "", 0, false
+	}
+	base, entry := rest[:i], rest[i+len("/pr-"):]
+
+	number, _, ok := strings.Cut(entry, "-")
+	if !ok {
+		return "", 0, false
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil || n <= 0 {
+		return "", 0, false
+	}
+
+	return base, n, true
+}
@@ -1,6 +1,8 @@
 package provider
 
 import (
+	"fmt"
+
 	"github.com/go-logr/logr"
 )
 
@@ -25,3 +27,15 @@ func WithDomain(domain string) ProviderOption {
 		return p.SetHostname(domain)
 	}
 }
+
+// WithPluginTLS makes a plugin provider connect to its plugin over TLS.
+func WithPluginTLS(enabled bool) ProviderOption {
+	return func(p Provider) error {
+		plugin, ok := p.(*PluginProvider)
+		if !ok {
+			return fmt.Errorf("TLS is only an option of the plugin provider")
+		}
+
+		return plugin.SetTLS(enabled)
+	}
+}
@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"crypto/tls"
+	"fmt"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/weaveworks/tf-controller/gitprovider"
+	"github.com/weaveworks/tf-controller/internal/fips"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// PluginProvider is a provider served by a gRPC plugin implementing the
+// gitprovider.GitProvider service, for the Git servers without a built-in
+// provider. Its hostname is the address of the plugin.
+type PluginProvider struct {
+	log      logr.Logger
+	apiToken string
+	address  string
+	tls      bool
+	client   gitprovider.GitProviderClient
+}
+
+// pluginConns are the connections to the plugins, by address and transport,
+// shared by the providers as one is set up on each poll.
+var (
+	pluginConnsMu sync.Mutex
+	pluginConns   = map[string]*grpc.ClientConn{}
+)
+
+func (p PluginProvider) ListPullRequests(ctx context.Context, repo Repository) ([]PullRequest, error) {
+	reply, err := p.client.ListPullRequests(p.outgoingContext(ctx), &gitprovider.ListPullRequestsRequest{
+		Repository: repositoryToPlugin(repo),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull requests: %w", err)
+	}
+
+	prs := []PullRequest{}
+	for _, pr := range reply.PullRequests {
+		prs = append(prs, pullRequestFromPlugin(repo, pr))
+	}
+
+	return prs, nil
+}
+
+func (p PluginProvider) AddCommentToPullRequest(ctx context.Context, pr PullRequest, body []byte) (*Comment, error) {
+	reply, err := p.client.AddCommentToPullRequest(p.outgoingContext(ctx), &gitprovider.AddCommentToPullRequestRequest{
+		PullRequest: pullRequestToPlugin(pr),
+		Body:        string(body),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add comment to pull request: %w", err)
+	}
+
+	return &Comment{
+		ID:   int(reply.Id),
+		Link: reply.Link,
+	}, nil
+}
+
+func (p PluginProvider) CreatePullRequest(ctx context.Context, repo Repository, newPR NewPullRequest) (*PullRequest, error) {
+	reply, err := p.client.CreatePullRequest(p.outgoingContext(ctx), &gitprovider.CreatePullRequestRequest{
+		Repository:    repositoryToPlugin(repo),
+		Title:         newPR.Title,
+		Body:          newPR.Body,
+		BaseBranch:    newPR.BaseBranch,
+		HeadBranch:    newPR.HeadBranch,
+		CommitMessage: newPR.CommitMessage,
+		Files:         newPR.Files,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pull request: %w", err)
+	}
+	if reply.PullRequest == nil {
+		return nil, fmt.Errorf("failed to create pull request: the plugin returned no pull request")
+	}
+
+	pr := pullRequestFromPlugin(repo, reply.PullRequest)
+	return &pr, nil
+}
+
+// Capabilities returns the capabilities the plugin serves for the Git server
+// of a repository, none for the plugins without the GetCapabilities RPC.
+func (p PluginProvider) Capabilities(ctx context.Context, repo Repository) (Capabilities, error) {
+	reply, err := p.client.GetCapabilities(p.outgoingContext(ctx), &gitprovider.GetCapabilitiesRequest{
+		Repository: repositoryToPlugin(repo),
+	})
+	if status.Code(err) == codes.Unimplemented {
+		return Capabilities{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get capabilities: %w", err)
+	}
+
+	capabilities := Capabilities{}
+	for _, capability := range reply.Capabilities {
+		switch capability := Capability(capability); capability {
+		case CapabilityCommitStatuses, CapabilityDraftPullRequests, CapabilityReactions, CapabilityChangedFiles:
+			capabilities[capability] = true
+		default:
+			// the merge queues are not served by the plugins, and the
+			// capabilities of newer plugins are ignored
+			p.log.V(1).Info("ignoring unknown capability of the git provider plugin", "capability", string(capability))
+		}
+	}
+
+	return capabilities, nil
+}
+
+func (p PluginProvider) SetCommitStatus(ctx context.Context, repo Repository, sha string, commitStatus CommitStatus) error {
+	if _, err := p.client.SetCommitStatus(p.outgoingContext(ctx), &gitprovider.SetCommitStatusRequest{
+		Repository:  repositoryToPlugin(repo),
+		Sha:         sha,
+		State:       string(commitStatus.State),
+		Context:     commitStatus.Context,
+		Description: commitStatus.Description,
+		Link:        commitStatus.Link,
+	}); err != nil {
+		return fmt.Errorf("failed to set commit status: %w", err)
+	}
+
+	return nil
+}
+
+func (p PluginProvider) AddReactionToPullRequest(ctx context.Context, pr PullRequest, reaction Reaction) error {
+	if _, err := p.client.AddReactionToPullRequest(p.outgoingContext(ctx), &gitprovider.AddReactionToPullRequestRequest{
+		PullRequest: pullRequestToPlugin(pr),
+		Reaction:    string(reaction),
+	}); err != nil {
+		return fmt.Errorf("failed to add reaction to pull request: %w", err)
+	}
+
+	return nil
+}
+
+func (p PluginProvider) ListChangedFiles(ctx context.Context, pr PullRequest) ([]string, error) {
+	reply, err := p.client.ListChangedFiles(p.outgoingContext(ctx), &gitprovider.ListChangedFilesRequest{
+		PullRequest: pullRequestToPlugin(pr),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list changed files: %w", err)
+	}
+
+	return reply.Paths, nil
+}
+
+func (p *PluginProvider) SetLogger(log logr.Logger) error {
+	p.log = log
+
+	return nil
+}
+
+func (p *PluginProvider) SetToken(tokenType, token string) error {
+	switch tokenType {
+	case APITokenType:
+		p.apiToken = token
+	default:
+		return fmt.Errorf("unknown token type: %s", tokenType)
+	}
+
+	return nil
+}
+
+// SetHostname sets the address of the plugin, as host:port.
+func (p *PluginProvider) SetHostname(hostname string) error {
+	p.address = hostname
+
+	return nil
+}
+
+// SetTLS makes the provider connect to the plugin over TLS, with the CAs of
+// the system.
+func (p *PluginProvider) SetTLS(enabled bool) error {
+	p.tls = enabled
+
+	return nil
+}
+
+func (p *PluginProvider) Setup() error {
+	if p.address == "" {
+		return fmt.Errorf("missing required option: Domain")
+	}
+
+	key := "insecure://" + p.address
+	creds := insecure.NewCredentials()
+	if p.tls {
+		key = "tls://" + p.address
+		creds = credentials.NewTLS(fips.Restrict(&tls.Config{MinVersion: tls.VersionTLS12}))
+	}
+
+	pluginConnsMu.Lock()
+	defer pluginConnsMu.Unlock()
+
+	conn, ok := pluginConns[key]
+	if !ok {
+		var err error
+		// the connection is established lazily, on the first call
+		conn, err = grpc.Dial(p.address, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			return fmt.Errorf("failed to connect to the git provider plugin at %s: %w", p.address, err)
+		}
+		pluginConns[key] = conn
+	}
+	p.client = gitprovider.NewGitProviderClient(conn)
+
+	return nil
+}
+
+// outgoingContext passes the API token to the plugin as a bearer token.
+func (p PluginProvider) outgoingContext(ctx context.Context) context.Context {
+	if p.apiToken == "" {
+		return ctx
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+p.apiToken)
+}
+
+func newPluginProvider() *PluginProvider {
+	return &PluginProvider{
+		log: logr.Discard(),
+	}
+}
+
+func repositoryToPlugin(repo Repository) *gitprovider.Repository {
+	return &gitprovider.Repository{
+		Url:     repo.URL,
+		Project: repo.Project,
+		Org:     repo.Org,
+		Name:    repo.Name,
+	}
+}
+
+func pullRequestToPlugin(pr PullRequest) *gitprovider.PullRequest {
+	return &gitprovider.PullRequest{
+		Repository: repositoryToPlugin(pr.Repository),
+		Number:     int64(pr.Number),
+		BaseBranch: pr.BaseBranch,
+		HeadBranch: pr.HeadBranch,
+		BaseSha:    pr.BaseSha,
+		HeadSha:    pr.HeadSha,
+		Fork:       pr.Fork,
+		Labels:     pr.Labels,
+		Link:       pr.Link,
+		Draft:      pr.Draft,
+	}
+}
+
+// pullRequestFromPlugin converts a pull request of the plugin, in the
+// repository it was requested for.
+func pullRequestFromPlugin(repo Repository, pr *gitprovider.PullRequest) PullRequest {
+	labels := pr.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+
+	return PullRequest{
+		Repository: repo,
+		Number:     int(pr.Number),
+		BaseBranch: pr.BaseBranch,
+		HeadBranch: pr.HeadBranch,
+		BaseSha:    pr.BaseSha,
+		HeadSha:    pr.HeadSha,
+		Fork:       pr.Fork,
+		Draft:      pr.Draft,
+		Labels:     labels,
+		Link:       pr.Link,
+	}
+}
@@ -0,0 +1,217 @@
+package provider_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/tf-controller/gitprovider"
+	"github.com/weaveworks/tf-controller/planner/provider"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type fakePlugin struct {
+	gitprovider.UnimplementedGitProviderServer
+
+	authorization []string
+	repository    *gitprovider.Repository
+	comment       string
+	files         map[string][]byte
+}
+
+func (p *fakePlugin) ListPullRequests(ctx context.Context, req *gitprovider.ListPullRequestsRequest) (*gitprovider.ListPullRequestsReply, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	p.authorization = md.Get("authorization")
+	p.repository = req.Repository
+
+	return &gitprovider.ListPullRequestsReply{
+		PullRequests: []*gitprovider.PullRequest{
+			{Number: 7, BaseBranch: "main", HeadBranch: "feature", HeadSha: "abc", Labels: []string{"ok-to-plan"}},
+		},
+	}, nil
+}
+
+func (p *fakePlugin) AddCommentToPullRequest(ctx context.Context, req *gitprovider.AddCommentToPullRequestRequest) (*gitprovider.AddCommentToPullRequestReply, error) {
+	p.comment = req.Body
+
+	return &gitprovider.AddCommentToPullRequestReply{Id: 42, Link: "https://git.example.com/comments/42"}, nil
+}
+
+func (p *fakePlugin) CreatePullRequest(ctx context.Context, req *gitprovider.CreatePullRequestRequest) (*gitprovider.CreatePullRequestReply, error) {
+	p.files = req.Files
+
+	return &gitprovider.CreatePullRequestReply{
+		PullRequest: &gitprovider.PullRequest{Number: 8, BaseBranch: req.BaseBranch, HeadBranch: req.HeadBranch},
+	}, nil
+}
+
+func TestPluginProvider(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	plugin := &fakePlugin{}
+	server := grpc.NewServer()
+	gitprovider.RegisterGitProviderServer(server, plugin)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	p, repo, err := provider.FromPlugin(
+		listener.Addr().String(),
+		"git@git.example.com:infra/terraform.git",
+		provider.WithToken(provider.APITokenType, "token"),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "infra/terraform", repo.String())
+
+	prs, err := p.ListPullRequests(context.TODO(), repo)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Bearer token"}, plugin.authorization)
+	assert.Equal(t, "git@git.example.com:infra/terraform.git", plugin.repository.Url)
+	assert.Equal(t, []provider.PullRequest{{
+		Repository: repo,
+		Number:     7,
+		BaseBranch: "main",
+		HeadBranch: "feature",
+		HeadSha:    "abc",
+		Labels:     []string{"ok-to-plan"},
+	}}, prs)
+
+	comment, err := p.AddCommentToPullRequest(context.TODO(), prs[0], []byte("Plan: 1 to add"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Plan: 1 to add", plugin.comment)
+	assert.Equal(t, 42, comment.ID)
+
+	pr, err := p.CreatePullRequest(context.TODO(), repo, provider.NewPullRequest{
+		BaseBranch: "main",
+		HeadBranch: "drift",
+		Files:      map[string][]byte{"DRIFT.md": []byte("drift")},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 8, pr.Number)
+	assert.Equal(t, "drift", string(plugin.files["DRIFT.md"]))
+
+	// the plugin has no optional capabilities without GetCapabilities
+	capabilities, err := provider.CapabilitiesOf(context.TODO(), p, repo)
+	assert.NoError(t, err)
+	assert.Empty(t, capabilities)
+
+	// TLS is only an option of the plugin provider
+	_, err = provider.New(provider.ProviderGitHub, provider.WithPluginTLS(true))
+	assert.Error(t, err)
+}
+
+type fakeCapablePlugin struct {
+	fakePlugin
+
+	statuses  []*gitprovider.SetCommitStatusRequest
+	reactions []string
+}
+
+func (p *fakeCapablePlugin) GetCapabilities(ctx context.Context, req *gitprovider.GetCapabilitiesRequest) (*gitprovider.GetCapabilitiesReply, error) {
+	return &gitprovider.GetCapabilitiesReply{Capabilities: []string{"commitStatuses", "changedFiles", "mergeQueues", "unknown"}}, nil
+}
+
+func (p *fakeCapablePlugin) SetCommitStatus(ctx context.Context, req *gitprovider.SetCommitStatusRequest) (*gitprovider.SetCommitStatusReply, error) {
+	p.statuses = append(p.statuses, req)
+	return &gitprovider.SetCommitStatusReply{}, nil
+}
+
+func (p *fakeCapablePlugin) ListChangedFiles(ctx context.Context, req *gitprovider.ListChangedFilesRequest) (*gitprovider.ListChangedFilesReply, error) {
+	return &gitprovider.ListChangedFilesReply{Paths: []string{"terraform/main.tf"}}, nil
+}
+
+func TestPluginProviderCapabilities(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	plugin := &fakeCapablePlugin{}
+	server := grpc.NewServer()
+	gitprovider.RegisterGitProviderServer(server, plugin)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	p, repo, err := provider.FromPlugin(listener.Addr().String(), "https://gitea.example.com/infra/terraform.git")
+	assert.NoError(t, err)
+
+	// the merge queues are not served by the plugins
+	capabilities, err := provider.CapabilitiesOf(context.TODO(), p, repo)
+	assert.NoError(t, err)
+	assert.Equal(t, provider.Capabilities{
+		provider.CapabilityCommitStatuses: true,
+		provider.CapabilityChangedFiles:   true,
+	}, capabilities)
+
+	statuses, ok := p.(provider.CommitStatusProvider)
+	assert.True(t, ok)
+	assert.NoError(t, statuses.SetCommitStatus(context.TODO(), repo, "abc", provider.CommitStatus{
+		State:   provider.CommitStateSuccess,
+		Context: "tf-controller/plan/default/tf1",
+	}))
+	assert.Len(t, plugin.statuses, 1)
+	assert.Equal(t, "success", plugin.statuses[0].State)
+	assert.Equal(t, "abc", plugin.statuses[0].Sha)
+
+	files, ok := p.(provider.ChangedFilesProvider)
+	assert.True(t, ok)
+	paths, err := files.ListChangedFiles(context.TODO(), provider.PullRequest{Repository: repo, Number: 7})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"terraform/main.tf"}, paths)
+
+	// the RPCs the plugin does not serve fail
+	reactions, ok := p.(provider.ReactionProvider)
+	assert.True(t, ok)
+	assert.Error(t, reactions.AddReactionToPullRequest(context.TODO(), provider.PullRequest{Repository: repo, Number: 7}, provider.ReactionEyes))
+}
+
+func TestRepositoryFromURL(t *testing.T) {
+	testCases := []struct {
+		url         string
+		repoName    string
+		repoOrg     string
+		repoProject string
+		shouldError bool
+	}{
+		{
+			url:      "https://github.com/weaveworks/tf-controller",
+			repoOrg:  "weaveworks",
+			repoName: "tf-controller",
+		},
+		{
+			url:      "https://git.example.com/infra/terraform.git",
+			repoOrg:  "infra",
+			repoName: "terraform",
+		},
+		{
+			url:         "ssh://git@git.example.com:2222/platform/infra/terraform",
+			repoProject: "platform",
+			repoOrg:     "infra",
+			repoName:    "terraform",
+		},
+		{
+			url:      "git@git.example.com:infra/terraform.git",
+			repoOrg:  "infra",
+			repoName: "terraform",
+		},
+		{
+			url:         "https://git.example.com/terraform",
+			shouldError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		repo, err := provider.RepositoryFromURL(testCase.url)
+
+		if testCase.shouldError {
+			assert.Error(t, err)
+			continue
+		}
+
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.url, repo.URL)
+		assert.Equal(t, testCase.repoOrg, repo.Org)
+		assert.Equal(t, testCase.repoName, repo.Name)
+		assert.Equal(t, testCase.repoProject, repo.Project)
+	}
+}
@@ -0,0 +1,213 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	giturl "github.com/kubescape/go-git-url"
+	giturlapis "github.com/kubescape/go-git-url/apis"
+	azureparserv1 "github.com/kubescape/go-git-url/azureparser/v1"
+	"golang.org/x/net/context"
+)
+
+type ProviderType string
+
+const (
+	ProviderGitHub    = ProviderType(giturlapis.ProviderGitHub)
+	ProviderGitlab    = ProviderType(giturlapis.ProviderGitLab)
+	ProviderBitbucket = ProviderType(giturlapis.ProviderBitBucket)
+	ProviderAzure     = ProviderType(giturlapis.ProviderAzure)
+
+	// ProviderCodeCommit is the provider of the AWS CodeCommit repositories,
+	// whose URLs are not known to go-git-url.
+	ProviderCodeCommit = ProviderType("codecommit")
+
+	// ProviderBitbucketServer is the provider of the repositories of
+	// Bitbucket Server and Data Center, whose URLs are not known to
+	// go-git-url.
+	ProviderBitbucketServer = ProviderType("bitbucket-server")
+
+	// ProviderPlugin is a provider served out-of-tree by a gRPC plugin, for
+	// the Git servers without a built-in provider.
+	ProviderPlugin = ProviderType("plugin")
+)
+
+type Provider interface {
+	ListPullRequests(ctx context.Context, repo Repository) ([]PullRequest, error)
+	AddCommentToPullRequest(ctx context.Context, repo PullRequest, body []byte) (*Comment, error)
+	CreatePullRequest(ctx context.Context, repo Repository, pr NewPullRequest) (*PullRequest, error)
+
+	SetLogger(logr.Logger) error
+	SetToken(tokenType, token string) error
+	SetHostname(hostname string) error
+
+	Setup() error
+}
+
+func New(provider ProviderType, options ...ProviderOption) (Provider, error) {
+	var p Provider
+	switch provider {
+	case ProviderGitHub:
+		p = newGitHubProvider()
+	case ProviderCodeCommit:
+		p = newCodeCommitProvider()
+	case ProviderBitbucket:
+		p = newBitbucketProvider(false)
+	case ProviderBitbucketServer:
+		p = newBitbucketProvider(true)
+	case ProviderAzure:
+		p = newAzureDevOpsProvider()
+	case ProviderPlugin:
+		p = newPluginProvider()
+	default:
+		return nil, fmt.Errorf("unknown provider: %s", provider)
+	}
+
+	for _, opt := range options {
+		if err := opt(p); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := p.Setup(); err != nil {
+		return p, err
+	}
+
+	return p, nil
+}
+
+func FromURL(repoURL string, options ...ProviderOption) (Provider, Repository, error) {
+	if repo, ok := codeCommitRepository(repoURL); ok {
+		provider, err := New(ProviderCodeCommit, options...)
+		if err != nil {
+			return nil, repo, err
+		}
+
+		return provider, repo, nil
+	}
+
+	if repo, ok := azureDevOpsRepository(repoURL); ok {
+		provider, err := New(ProviderAzure, options...)
+		if err != nil {
+			return nil, repo, err
+		}
+
+		return provider, repo, nil
+	}
+
+	gitURL, err := giturl.NewGitURL(repoURL)
+	if err != nil {
+		if repo, serverURL, ok := bitbucketServerRepository(repoURL); ok {
+			// the server of the repository, unless another is given
+			provider, err := New(ProviderBitbucketServer, append([]ProviderOption{WithDomain(serverURL)}, options...)...)
+			if err != nil {
+				return nil, repo, err
+			}
+
+			return provider, repo, nil
+		}
+
+		return nil, Repository{}, fmt.Errorf("failed parsing repository url: %w", err)
+	}
+
+	targetProvider := ProviderType(gitURL.GetProvider())
+	repo := repositoryFromURL(repoURL, gitURL)
+
+	provider, err := New(targetProvider, options...)
+	if err != nil {
+		return nil, repo, err
+	}
+
+	return provider, repo, nil
+}
+
+// FromPlugin returns the provider served by the gRPC plugin at the address
+// for the repository, whatever its Git server.
+func FromPlugin(address, repoURL string, options ...ProviderOption) (Provider, Repository, error) {
+	repo, err := RepositoryFromURL(repoURL)
+	if err != nil {
+		return nil, Repository{}, err
+	}
+
+	provider, err := New(ProviderPlugin, append([]ProviderOption{WithDomain(address)}, options...)...)
+	if err != nil {
+		return nil, repo, err
+	}
+
+	return provider, repo, nil
+}
+
+// RepositoryFromURL returns the repository of a Git URL, without setting up
+// a provider. The URLs of the Git servers unknown to the built-in providers
+// are parsed as <host>/[<project>/]<org>/<name>.
+func RepositoryFromURL(repoURL string) (Repository, error) {
+	if repo, ok := codeCommitRepository(repoURL); ok {
+		return repo, nil
+	}
+	if repo, ok := azureDevOpsRepository(repoURL); ok {
+		return repo, nil
+	}
+
+	gitURL, err := giturl.NewGitURL(repoURL)
+	if err != nil {
+		if repo, _, ok := bitbucketServerRepository(repoURL); ok {
+			return repo, nil
+		}
+		if repo, ok := parseRepositoryURL(repoURL); ok {
+			return repo, nil
+		}
+
+		return Repository{}, fmt.Errorf("failed parsing repository url: %w", err)
+	}
+
+	return repositoryFromURL(repoURL, gitURL), nil
+}
+
+func repositoryFromURL(repoURL string, gitURL giturl.IGitURL) Repository {
+	repo := Repository{
+		URL:  repoURL,
+		Org:  gitURL.GetOwnerName(),
+		Name: gitURL.GetRepoName(),
+	}
+
+	// the repositories of Azure DevOps are in a project of the organization
+	if azureURL, ok := gitURL.(*azureparserv1.AzureURL); ok {
+		repo.Project = azureURL.GetProjectName()
+	}
+
+	return repo
+}
+
+// parseRepositoryURL parses the URL of a repository of any Git server, such
+// as https://git.example.com/org/name.git or git@git.example.com:org/name.
+func parseRepositoryURL(repoURL string) (Repository, bool) {
+	rest := repoURL
+	if i := strings.Index(rest, "://"); i >= 0 {
+		rest = rest[i+len("://"):]
+	} else if i := strings.Index(rest, ":"); i >= 0 {
+		// scp-like syntax, user@host:path
+		rest = rest[:i] + "/" + rest[i+1:]
+	}
+
+	parts := strings.Split(strings.Trim(rest, "/"), "/")
+	// the host, an optional project, the org and the name
+	if len(parts) < 3 || len(parts) > 4 {
+		return Repository{}, false
+	}
+	for _, part := range parts {
+		if part == "" {
+			return Repository{}, false
+		}
+	}
+
+	parts = parts[1:]
+	repo := Repository{URL: repoURL}
+	if len(parts) == 3 {
+		repo.Project, parts = parts[0], parts[1:]
+	}
+	repo.Org = parts[0]
+	repo.Name = strings.TrimSuffix(parts[1], ".git")
+
+	return repo, repo.Name != ""
+}
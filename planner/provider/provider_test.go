@@ -4,7 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
-	"github.com/weaveworks/tf-controller/internal/git/provider"
+	"github.com/weaveworks/tf-controller/planner/provider"
 )
 
 func TestFromURL(t *testing.T) {
@@ -57,3 +57,15 @@ func TestFromURL(t *testing.T) {
 	}
 
 }
+
+func TestParseMergeQueueBranch(t *testing.T) {
+	base, number, ok := provider.ParseMergeQueueBranch("gh-readonly-queue/release/v1/pr-123-0f1e2d3c")
+	assert.True(t, ok)
+	assert.Equal(t, "release/v1", base)
+	assert.Equal(t, 123, number)
+
+	for _, branch := range []string{"main", "gh-readonly-queue/main", "gh-readonly-queue/main/pr-abc-0f1e2d3c", "gh-readonly-queue/pr-1-0f1e2d3c"} {
+		_, _, ok := provider.ParseMergeQueueBranch(branch)
+		assert.False(t, ok, branch)
+	}
+}
@@ -0,0 +1,47 @@
+package provider
+
+type PullRequest struct {
+	Repository Repository
+	Number     int
+	BaseBranch string
+	HeadBranch string
+	BaseSha    string
+	HeadSha    string
+	// Fork is true when the head branch lives in a different repository
+	// than the base branch.
+	Fork bool
+	// MergeQueue is true for an entry of a merge queue, whose head is the
+	// temporary branch of the queue, at the speculative merge commit of
+	// the pull request with the entries ahead of it.
+	MergeQueue bool
+	// Draft is true for a draft pull request, with the providers with the
+	// CapabilityDraftPullRequests.
+	Draft  bool
+	Labels []string
+	// Link is the web page of the pull request.
+	Link string
+}
+
+// NewPullRequest is a pull request to open. Its head branch is created from
+// the base branch, with a commit of the files.
+type NewPullRequest struct {
+	Title      string
+	Body       string
+	BaseBranch string
+	HeadBranch string
+	// CommitMessage is the message of the commit of each file.
+	CommitMessage string
+	// Files are the contents of the files to commit by path.
+	Files map[string][]byte
+}
+
+// HasLabel reports whether the pull request carries the given label.
+func (pr PullRequest) HasLabel(label string) bool {
+	for _, l := range pr.Labels {
+		if l == label {
+			return true
+		}
+	}
+
+	return false
+}
@@ -3,6 +3,8 @@ package provider
 import "fmt"
 
 type Repository struct {
+	// URL is the URL the repository was parsed from.
+	URL     string
 	Project string
 	Org     string
 	Name    string
@@ -0,0 +1,207 @@
+package provider
+
+import (
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/jenkins-x/go-scm/scm"
+	"golang.org/x/net/context"
+)
+
+const (
+	// maxRetries is the number of times a request is retried after a
+	// server error or a rate limit.
+	maxRetries = 4
+	// minBackoff and maxBackoff bound the wait before a retry, doubled
+	// after each attempt, with a random jitter for the clients throttled
+	// together not to retry together.
+	minBackoff = 500 * time.Millisecond
+	maxBackoff = 30 * time.Second
+	// maxRetryAfter is the longest wait a rate limit is retried after. A
+	// longer one, like the reset of the primary rate limit of GitHub, fails
+	// the request, to be retried on the next poll.
+	maxRetryAfter = time.Minute
+
+	// pageSize is the size of the pages of the lists, the largest most Git
+	// servers accept, and maxPages the number of pages listed at most, not
+	// to list forever from a server always returning a next page.
+	pageSize = 100
+	maxPages = 100
+)
+
+// retryTransport retries the requests failing with a server error, or with
+// a rate limit like the secondary rate limits of GitHub, after the wait the
+// server asks for, or with an exponential backoff. It is the transport of the
+// HTTP clients of the built-in providers.
+//
+// The requests which cannot be replayed safely, the ones changing something
+// on the server, are only retried after a rate limit, as the server has not
+// handled them then. A POST request reading only is marked idempotent with a
+// nil Idempotency-Key header, like for the retries of net/http.
+type retryTransport struct {
+	base http.RoundTripper
+	log  logr.Logger
+}
+
+func newRetryTransport(base http.RoundTripper, log logr.Logger) *retryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &retryTransport{base: base, log: log}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		res, err := t.base.RoundTrip(req)
+		if attempt >= maxRetries || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+			return res, err
+		}
+		wait, retry := retryAfter(req, res, err, attempt)
+		if !retry {
+			return res, err
+		}
+
+		if res != nil {
+			_, _ = io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+		t.log.V(1).Info("retrying the request", "method", req.Method, "url", req.URL.Redacted(), "attempt", attempt+1, "wait", wait)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// retryAfter returns the wait before retrying a request, and false when it
+// is not to be retried.
+func retryAfter(req *http.Request, res *http.Response, err error, attempt int) (time.Duration, bool) {
+	if err != nil {
+		if req.Context().Err() != nil || !idempotent(req) {
+			return 0, false
+		}
+		return backoff(attempt), true
+	}
+
+	switch {
+	case rateLimited(res):
+		if wait, ok := serverWait(res); ok {
+			return wait, wait <= maxRetryAfter
+		}
+		return backoff(attempt), true
+	case res.StatusCode >= http.StatusInternalServerError && res.StatusCode != http.StatusNotImplemented:
+		if !idempotent(req) {
+			return 0, false
+		}
+		if wait, ok := serverWait(res); ok && wait <= maxRetryAfter {
+			return wait, true
+		}
+		return backoff(attempt), true
+	}
+	return 0, false
+}
+
+// rateLimited reports whether a response is a rate limit: a 429, or a 403
+// of GitHub with a Retry-After header for the secondary rate limits, or
+// without remaining requests for the primary one.
+func rateLimited(res *http.Response) bool {
+	switch res.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusForbidden:
+		return res.Header.Get("Retry-After") != "" || res.Header.Get("X-RateLimit-Remaining") == "0"
+	}
+	return false
+}
+
+// serverWait returns the wait the server asks for, from the Retry-After
+// header in seconds, or from the reset time of the GitHub rate limit.
+func serverWait(res *http.Response) (time.Duration, bool) {
+	if value := res.Header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(value); err == nil {
+			return nonNegative(time.Until(date)), true
+		}
+	}
+	if res.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(res.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			return nonNegative(time.Until(time.Unix(reset, 0))), true
+		}
+	}
+	return 0, false
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// backoff returns the wait before the retry of an attempt, with a full
+// jitter.
+func backoff(attempt int) time.Duration {
+	wait := minBackoff << attempt
+	if wait > maxBackoff || wait <= 0 {
+		wait = maxBackoff
+	}
+	return time.Duration(rand.Int63n(int64(wait)) + 1)
+}
+
+func idempotent(req *http.Request) bool {
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	if _, ok := req.Header["Idempotency-Key"]; ok {
+		return true
+	}
+	_, ok := req.Header["X-Idempotency-Key"]
+	return ok
+}
+
+// listPages returns the items of all the pages of a list of a go-scm client,
+// from the first page until the one without a next page.
+func listPages[T any](ctx context.Context, log logr.Logger, list func(opts scm.ListOptions) ([]T, *scm.Response, error)) ([]T, error) {
+	var items []T
+	opts := scm.ListOptions{Page: 1, Size: pageSize}
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		page, res, err := list(opts)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+
+		if res == nil || res.Page.Next <= opts.Page {
+			return items, nil
+		}
+		if opts.Page >= maxPages {
+			log.Info("listed the maximum number of pages, ignoring the next ones", "pages", maxPages, "items", len(items))
+			return items, nil
+		}
+		opts.Page = res.Page.Next
+	}
+}
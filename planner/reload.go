@@ -0,0 +1,280 @@
+package planner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// configWatchDelay is the delay before watching the ConfigMap and the Secret
+// of the config again, after a change or an error.
+const configWatchDelay = 5 * time.Second
+
+// ConfigStatus is the result of the validation of the config, returned by
+// the validation endpoint.
+type ConfigStatus struct {
+	Valid     bool     `json:"valid"`
+	ConfigMap string   `json:"configMap"`
+	Secret    string   `json:"secret,omitempty"`
+	Errors    []string `json:"errors,omitempty"`
+	// LoadedAt is the time the config in use was loaded, empty when no
+	// valid config was loaded yet.
+	LoadedAt *metav1.Time `json:"loadedAt,omitempty"`
+}
+
+// validateConfig reads the config and its Secret, and returns the errors
+// that prevent using them. The config and the Secret are nil when they
+// cannot be read.
+func (s *Server) validateConfig(ctx context.Context) (*Config, *corev1.Secret, []string) {
+	config, err := s.readConfig(ctx)
+	if err != nil {
+		return nil, nil, []string{err.Error()}
+	}
+	if s.staticConfig != nil && config.SecretName == "" {
+		// the Git provider factory of the embedding controller
+		// authenticates by itself
+		return config, &corev1.Secret{}, nil
+	}
+
+	secretRef := client.ObjectKey{Namespace: config.SecretNamespace, Name: config.SecretName}
+	secret, err := s.getSecret(ctx, secretRef)
+	if err != nil {
+		return config, nil, []string{fmt.Sprintf("Secret %s: %s", secretRef, err)}
+	}
+	if len(secret.Data["token"]) == 0 {
+		return config, secret, []string{fmt.Sprintf("Secret %s has no token key", secretRef)}
+	}
+
+	return config, secret, nil
+}
+
+// validateResources checks that the Terraform objects of the config exist,
+// that their branch planner annotations are valid, and that the Git provider
+// of their source can be set up with the token of the Secret, when it has
+// one.
+func (s *Server) validateResources(ctx context.Context, config *Config, secret *corev1.Secret) []string {
+	var errs []string
+	for _, resource := range config.Resources {
+		tf, err := s.getTerraform(ctx, resource)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("resource %s: %s", resource, err))
+			continue
+		}
+
+		if _, err := branchOptionsFor(config, tf); err != nil {
+			errs = append(errs, fmt.Sprintf("resource %s: %s", resource, err))
+		}
+		if _, err := pollIntervalOf(tf); err != nil {
+			errs = append(errs, fmt.Sprintf("resource %s: %s", resource, err))
+		}
+
+		source, err := s.getSource(ctx, tf)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("resource %s: %s", resource, err))
+			continue
+		}
+
+		repoURL, err := originalRepositoryURL(tf, source)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("resource %s: %s", resource, err))
+			continue
+		}
+
+		if secret == nil || len(secret.Data["token"]) == 0 {
+			continue
+		}
+		if _, _, err := s.gitProvider(config, repoURL, secret); err != nil {
+			errs = append(errs, fmt.Sprintf("resource %s: %s", resource, err))
+		}
+	}
+
+	return errs
+}
+
+// reload reads the config and its Secret, and uses them when they are valid.
+// An invalid config is logged and the previous one is kept, for a mistake in
+// the ConfigMap or the Secret not to stop the planning of pull requests.
+func (s *Server) reload(ctx context.Context) {
+	config, secret, errs := s.validateConfig(ctx)
+	if len(errs) > 0 {
+		s.log.Error(fmt.Errorf("%s", strings.Join(errs, "; ")), "invalid config, keeping the previous one", "configMap", s.configMapRef)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.config != nil && s.secret != nil &&
+		reflect.DeepEqual(config, s.config) && secret.ResourceVersion == s.secret.ResourceVersion {
+		return
+	}
+
+	s.config = config
+	s.secret = secret
+	s.loadedAt = metav1.NewTime(s.clock.Now())
+	s.log.Info("loaded the config", "configMap", s.configMapRef, "secret", client.ObjectKeyFromObject(secret), "resources", len(config.Resources))
+}
+
+// pollingIntervalFor returns the polling interval of the controller config,
+// when it is valid and sets one, or the interval of the flag. The config is
+// read at each polling interval, for its changes to apply without a restart.
+func (s *Server) pollingIntervalFor(ctx context.Context) time.Duration {
+	if s.controllerConfig == "" {
+		return s.pollingInterval
+	}
+
+	var config infrav1.TerraformControllerConfig
+	if err := s.clusterClient.Get(ctx, client.ObjectKey{Name: s.controllerConfig}, &config); err != nil {
+		if !apierrors.IsNotFound(err) {
+			s.log.Error(err, "failed to read the controller config", "name", s.controllerConfig)
+		}
+		return s.pollingInterval
+	}
+	if !config.IsValid() || config.Spec.Planner == nil || config.Spec.Planner.PollingInterval == nil ||
+		config.Spec.Planner.PollingInterval.Duration <= 0 {
+		return s.pollingInterval
+	}
+	return config.Spec.Planner.PollingInterval.Duration
+}
+
+// requestReload asks the polling loop to reload the config before its next
+// polling interval.
+func (s *Server) requestReload() {
+	select {
+	case s.reloads <- struct{}{}:
+	default:
+	}
+}
+
+// secretRef returns the Secret of the config in use.
+func (s *Server) secretRef() client.ObjectKey {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.config == nil {
+		return client.ObjectKey{}
+	}
+	return client.ObjectKey{Namespace: s.config.SecretNamespace, Name: s.config.SecretName}
+}
+
+// watchConfig reloads the config as soon as its ConfigMap or its Secret
+// changes, instead of at the next polling interval.
+func (s *Server) watchConfig(ctx context.Context, watcher client.WithWatch) {
+	for {
+		if err := s.watchConfigOnce(ctx, watcher); err != nil {
+			s.log.Error(err, "failed to watch the config")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.clock.After(configWatchDelay):
+		}
+	}
+}
+
+// watchConfigOnce waits for a change of the ConfigMap or of the Secret of the
+// config. The Secret is watched again after each change, as the ConfigMap may
+// reference another one.
+func (s *Server) watchConfigOnce(ctx context.Context, watcher client.WithWatch) error {
+	configMaps, err := watchObject(ctx, watcher, &corev1.ConfigMapList{}, s.configMapRef)
+	if err != nil {
+		return fmt.Errorf("unable to watch ConfigMap %s: %w", s.configMapRef, err)
+	}
+	defer configMaps.Stop()
+
+	// a nil channel blocks, until a valid config references a Secret
+	var secretEvents <-chan watch.Event
+	if secretRef := s.secretRef(); secretRef.Name != "" {
+		secrets, err := watchObject(ctx, watcher, &corev1.SecretList{}, secretRef)
+		if err != nil {
+			return fmt.Errorf("unable to watch Secret %s: %w", secretRef, err)
+		}
+		defer secrets.Stop()
+		secretEvents = secrets.ResultChan()
+	}
+
+	select {
+	case <-ctx.Done():
+	case _, ok := <-configMaps.ResultChan():
+		if ok {
+			s.requestReload()
+		}
+	case _, ok := <-secretEvents:
+		if ok {
+			s.requestReload()
+		}
+	}
+
+	return nil
+}
+
+// watchObject watches the changes of an object after its current version.
+func watchObject(ctx context.Context, watcher client.WithWatch, list client.ObjectList, ref client.ObjectKey) (watch.Interface, error) {
+	opts := []client.ListOption{
+		client.InNamespace(ref.Namespace),
+		client.MatchingFields{"metadata.name": ref.Name},
+	}
+	if err := watcher.List(ctx, list, opts...); err != nil {
+		return nil, err
+	}
+
+	opts = append(opts, &client.ListOptions{Raw: &metav1.ListOptions{ResourceVersion: list.GetResourceVersion()}})
+	return watcher.Watch(ctx, list, opts...)
+}
+
+// ValidateHandler serves the validation of the config:
+//
+//	GET /validate
+//
+// It reads the ConfigMap and the Secret of the config as they are now, checks
+// the Terraform objects and their sources, and answers 200 when the config is
+// valid, or 422 with the errors found. The values of the Secret are never
+// returned.
+func (s *Server) ValidateHandler() http.Handler {
+	return http.HandlerFunc(s.serveValidate)
+}
+
+func (s *Server) serveValidate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx := r.Context()
+	status := ConfigStatus{ConfigMap: s.configMapRef.String()}
+
+	config, secret, errs := s.validateConfig(ctx)
+	if config != nil {
+		status.Secret = client.ObjectKey{Namespace: config.SecretNamespace, Name: config.SecretName}.String()
+		errs = append(errs, s.validateResources(ctx, config, secret)...)
+	}
+	status.Errors = errs
+	status.Valid = len(errs) == 0
+
+	s.mu.Lock()
+	if !s.loadedAt.IsZero() {
+		loadedAt := s.loadedAt
+		status.LoadedAt = &loadedAt
+	}
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if !status.Valid {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		s.log.Error(err, "failed to write the config status")
+	}
+}
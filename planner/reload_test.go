@@ -0,0 +1,244 @@
+package planner
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1b2 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/go-logr/logr"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/planner/provider"
+)
+
+func Test_reload(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctx := context.Background()
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "branch-based-planner", Namespace: "default"},
+		Data:       map[string]string{"secretName": "bbp-token", "resources": "- namespace: default\n  name: tf1\n"},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bbp-token", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("github")},
+	}
+	server, clusterClient := newConfigTestServer(g, configMap, secret)
+
+	server.reload(ctx)
+	g.Expect(server.config).ToNot(gomega.BeNil())
+	expectToEqual(g, server.config.Resources, []client.ObjectKey{{Namespace: "default", Name: "tf1"}})
+	expectToEqual(g, string(server.secret.Data["token"]), "github")
+	g.Expect(server.loadedAt.IsZero()).To(gomega.BeFalse())
+
+	// a new list of resources and a new token are picked up
+	configMap.Data["resources"] = "- namespace: default\n  name: tf2\n"
+	g.Expect(clusterClient.Update(ctx, configMap)).To(gomega.Succeed())
+	secret.Data["token"] = []byte("rotated")
+	g.Expect(clusterClient.Update(ctx, secret)).To(gomega.Succeed())
+
+	server.reload(ctx)
+	expectToEqual(g, server.config.Resources, []client.ObjectKey{{Namespace: "default", Name: "tf2"}})
+	expectToEqual(g, string(server.secret.Data["token"]), "rotated")
+
+	// an invalid config keeps the previous one
+	configMap.Data["forkPolicy"] = "unknown"
+	configMap.Data["resources"] = "- namespace: default\n  name: tf3\n"
+	g.Expect(clusterClient.Update(ctx, configMap)).To(gomega.Succeed())
+
+	server.reload(ctx)
+	expectToEqual(g, server.config.Resources, []client.ObjectKey{{Namespace: "default", Name: "tf2"}})
+
+	// so does a Secret without a token
+	delete(configMap.Data, "forkPolicy")
+	g.Expect(clusterClient.Update(ctx, configMap)).To(gomega.Succeed())
+	secret.Data = map[string][]byte{}
+	g.Expect(clusterClient.Update(ctx, secret)).To(gomega.Succeed())
+
+	server.reload(ctx)
+	expectToEqual(g, server.config.Resources, []client.ObjectKey{{Namespace: "default", Name: "tf2"}})
+	expectToEqual(g, string(server.secret.Data["token"]), "rotated")
+}
+
+func Test_ValidateHandler(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctx := context.Background()
+
+	tf := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "tf1", Namespace: "default"},
+		Spec: infrav1.TerraformSpec{
+			SourceRef: infrav1.CrossNamespaceSourceReference{Kind: "GitRepository", Name: "source"},
+		},
+	}
+	source := &sourcev1b2.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "source", Namespace: "default"},
+		Spec:       sourcev1b2.GitRepositorySpec{URL: "https://github.com/org/repo"},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "branch-based-planner", Namespace: "default"},
+		Data:       map[string]string{"secretName": "bbp-token", "resources": "- namespace: default\n  name: tf1\n"},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bbp-token", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("github")},
+	}
+	server, clusterClient := newConfigTestServer(g, tf, source, configMap, secret)
+
+	validate := func() (int, ConfigStatus) {
+		rec := httptest.NewRecorder()
+		server.ValidateHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/validate", nil))
+
+		var status ConfigStatus
+		g.Expect(json.Unmarshal(rec.Body.Bytes(), &status)).To(gomega.Succeed())
+		return rec.Code, status
+	}
+
+	code, status := validate()
+	expectToEqual(g, code, http.StatusOK)
+	g.Expect(status.Valid).To(gomega.BeTrue())
+	expectToEqual(g, status.ConfigMap, "default/branch-based-planner")
+	expectToEqual(g, status.Secret, "default/bbp-token")
+	g.Expect(status.LoadedAt).To(gomega.BeNil())
+
+	server.reload(ctx)
+	_, status = validate()
+	g.Expect(status.LoadedAt).ToNot(gomega.BeNil())
+
+	configMap.Data["resources"] = "- namespace: default\n  name: tf1\n- namespace: default\n  name: missing\n"
+	g.Expect(clusterClient.Update(ctx, configMap)).To(gomega.Succeed())
+
+	code, status = validate()
+	expectToEqual(g, code, http.StatusUnprocessableEntity)
+	g.Expect(status.Valid).To(gomega.BeFalse())
+	g.Expect(status.Errors).To(gomega.HaveLen(1))
+	g.Expect(status.Errors[0]).To(gomega.ContainSubstring("resource default/missing"))
+
+	configMap.Data["maxConcurrentPlansPerNamespace"] = "-1"
+	g.Expect(clusterClient.Update(ctx, configMap)).To(gomega.Succeed())
+
+	code, status = validate()
+	expectToEqual(g, code, http.StatusUnprocessableEntity)
+	expectToEqual(g, status.Errors, []string{`maxConcurrentPlansPerNamespace must be a non-negative integer: "-1"`})
+
+	delete(configMap.Data, "maxConcurrentPlansPerNamespace")
+	configMap.Data["commentTemplate"] = "{{ .Plan "
+	g.Expect(clusterClient.Update(ctx, configMap)).To(gomega.Succeed())
+
+	code, status = validate()
+	expectToEqual(g, code, http.StatusUnprocessableEntity)
+	g.Expect(status.Errors).To(gomega.HaveLen(1))
+	g.Expect(status.Errors[0]).To(gomega.HavePrefix("invalid commentTemplate"))
+}
+
+func Test_pollingIntervalFor(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctx := context.Background()
+
+	controllerConfig := &infrav1.TerraformControllerConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "tf-controller", Generation: 1},
+		Spec: infrav1.TerraformControllerConfigSpec{
+			Planner: &infrav1.PlannerConfig{PollingInterval: &metav1.Duration{Duration: time.Minute}},
+		},
+	}
+	server, clusterClient := newConfigTestServer(g, controllerConfig)
+	server.pollingInterval = 30 * time.Second
+
+	// without a controller config, the flag applies
+	expectToEqual(g, server.pollingIntervalFor(ctx), 30*time.Second)
+
+	// the config is not used until the controller finds it valid
+	server.controllerConfig = "tf-controller"
+	expectToEqual(g, server.pollingIntervalFor(ctx), 30*time.Second)
+
+	controllerConfig.Status = infrav1.TerraformControllerConfigStatus{
+		ObservedGeneration: 1,
+		Conditions: []metav1.Condition{{
+			Type:   meta.ReadyCondition,
+			Status: metav1.ConditionTrue,
+			Reason: infrav1.ConfigLoadedReason,
+		}},
+	}
+	g.Expect(clusterClient.Update(ctx, controllerConfig)).To(gomega.Succeed())
+	expectToEqual(g, server.pollingIntervalFor(ctx), time.Minute)
+
+	// a deleted config falls back to the flag
+	g.Expect(clusterClient.Delete(ctx, controllerConfig)).To(gomega.Succeed())
+	expectToEqual(g, server.pollingIntervalFor(ctx), 30*time.Second)
+}
+
+func Test_gitProviderPlugin(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctx := context.Background()
+
+	tf := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "tf1", Namespace: "default"},
+		Spec: infrav1.TerraformSpec{
+			SourceRef: infrav1.CrossNamespaceSourceReference{Kind: "GitRepository", Name: "source"},
+		},
+	}
+	source := &sourcev1b2.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "source", Namespace: "default"},
+		Spec:       sourcev1b2.GitRepositorySpec{URL: "https://git.example.com/org/repo"},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "branch-based-planner", Namespace: "default"},
+		Data:       map[string]string{"secretName": "bbp-token", "resources": "- namespace: default\n  name: tf1\n"},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bbp-token", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("token")},
+	}
+	server, clusterClient := newConfigTestServer(g, tf, source, configMap, secret)
+
+	// no built-in provider serves the Git server of the source
+	server.reload(ctx)
+	g.Expect(server.validateResources(ctx, server.config, secret)).To(gomega.HaveLen(1))
+
+	configMap.Data["gitProviderPlugin"] = "git-provider-plugin.flux-system.svc:9090"
+	configMap.Data["gitProviderPluginTLS"] = "true"
+	g.Expect(clusterClient.Update(ctx, configMap)).To(gomega.Succeed())
+
+	server.reload(ctx)
+	g.Expect(server.validateResources(ctx, server.config, secret)).To(gomega.BeEmpty())
+	expectToEqual(g, server.config.GitProviderPlugin, "git-provider-plugin.flux-system.svc:9090")
+	expectToEqual(g, server.config.GitProviderPluginTLS, true)
+
+	gitProvider, repo, err := server.gitProvider(server.config, source.Spec.URL, secret)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(gitProvider).To(gomega.BeAssignableToTypeOf(&provider.PluginProvider{}))
+	expectToEqual(g, repo.String(), "org/repo")
+
+	configMap.Data["gitProviderPluginTLS"] = "maybe"
+	g.Expect(clusterClient.Update(ctx, configMap)).To(gomega.Succeed())
+	_, err = server.readConfig(ctx)
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func newConfigTestServer(g *gomega.WithT, objects ...client.Object) (*Server, client.Client) {
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(sourcev1b2.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(gomega.Succeed())
+
+	clusterClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	server, err := New(
+		WithLogger(logr.Discard()),
+		WithClusterClient(clusterClient),
+		WithConfigMap("default/branch-based-planner"),
+	)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	return server, clusterClient
+}
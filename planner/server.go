@@ -0,0 +1,352 @@
+package planner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/weaveworks/tf-controller/internal/correlation"
+	"github.com/weaveworks/tf-controller/planner/provider"
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+)
+
+const DefaultPollingInterval = time.Second * 30
+
+type Server struct {
+	log             logr.Logger
+	clusterClient   client.Client
+	configMapRef    client.ObjectKey
+	pollingInterval time.Duration
+	slots           *planSlots
+
+	// staticConfig is the config passed by a controller embedding the
+	// planner, used instead of the ConfigMap, gitProviders returns the Git
+	// providers of the repositories, and clock tells the time and ticks
+	// the polling interval.
+	staticConfig *Config
+	gitProviders GitProviderFactory
+	clock        clock.WithTicker
+
+	// writes limits the writes of the clusterClient, which throttles them,
+	// and watcher is the client before it is throttled, to watch the config.
+	writes  *rate.Limiter
+	watcher client.WithWatch
+
+	// controllerConfig is the name of the TerraformControllerConfig which
+	// may override the polling interval, or empty for the flag alone.
+	controllerConfig string
+
+	// requiredLabel is the label the pull requests must carry to be
+	// planned, unless their original Terraform object overrides it, or
+	// empty to plan all of them.
+	requiredLabel string
+
+	// mu guards the config in use, which the polling loop reloads, for the
+	// validation endpoint and the watch of the config to read it.
+	mu       sync.Mutex
+	config   *Config
+	secret   *corev1.Secret
+	loadedAt metav1.Time
+	reloads  chan struct{}
+
+	// pendingPolls are the Terraform objects whose pull requests the
+	// webhooks asked to poll before the next polling interval, guarded by
+	// mu, and polls wakes the polling loop up for them.
+	pendingPolls map[types.NamespacedName]bool
+	polls        chan struct{}
+
+	// deliveries are the IDs of the deliveries of the webhooks handled
+	// recently, to refuse the ones replayed.
+	deliveries *deliveryCache
+
+	// features are the optional features of the Git provider of the
+	// repository being polled, unsupported records the capabilities
+	// missing from the providers of the repositories, not to log them on
+	// each poll, and changedFiles caches the files the pull requests
+	// change, by repository and number, and schedule tracks when the
+	// Terraform objects are due to be polled. They are only used by the
+	// polling loop.
+	features     providerFeatures
+	unsupported  map[string]bool
+	changedFiles map[string]changedFiles
+	schedule     pollSchedule
+}
+
+// New returns a planner polling the pull requests of the Terraform objects
+// of its config. It is started with Start, e.g. as a Runnable of the manager
+// of a controller embedding it.
+func New(options ...Option) (*Server, error) {
+	server := &Server{
+		log:        logr.Discard(),
+		reloads:    make(chan struct{}, 1),
+		polls:      make(chan struct{}, 1),
+		clock:      clock.RealClock{},
+		deliveries: newDeliveryCache(),
+	}
+
+	for _, opt := range options {
+		if err := opt(server); err != nil {
+			return nil, err
+		}
+	}
+
+	if server.gitProviders == nil {
+		server.gitProviders = defaultGitProviders{log: server.log}
+	}
+	if server.pollingInterval <= 0 {
+		server.pollingInterval = DefaultPollingInterval
+	}
+
+	if server.clusterClient != nil {
+		server.watcher, _ = server.clusterClient.(client.WithWatch)
+		server.writes = newWriteLimiter(nil)
+		server.clusterClient = &throttledClient{Client: server.clusterClient, limiter: server.writes}
+	}
+
+	return server, nil
+}
+
+func (s *Server) Start(ctx context.Context) error {
+	if s.watcher != nil && s.staticConfig == nil {
+		go s.watchConfig(ctx, s.watcher)
+	}
+
+	interval := s.pollingIntervalFor(ctx)
+	tick := s.schedule.tick(interval)
+	ticker := s.clock.NewTicker(tick)
+	defer func() { ticker.Stop() }()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-s.reloads:
+			s.reload(ctx)
+
+		case <-ticker.C():
+			// Reload the config in each iteration too, for the changes to be
+			// picked up when the config cannot be watched. An invalid config
+			// keeps the previous one, without a restart of the pod.
+			s.reload(ctx)
+			if next := s.pollingIntervalFor(ctx); next != interval {
+				s.log.Info("changed the polling interval", "from", interval, "to", next)
+				interval = next
+			}
+
+			if s.config == nil {
+				s.log.Info("no valid config loaded, skipping polling", "configMap", s.configMapRef)
+			} else if resources := s.schedule.due(s.config.Resources, s.clock.Now(), interval, tick); len(resources) > 0 {
+				s.pollResources(ctx, resources)
+			}
+
+			// the ticks follow the shortest polling interval of the
+			// Terraform objects, as of their last poll
+			if next := s.schedule.tick(interval); next != tick {
+				tick = next
+				ticker.Stop()
+				ticker = s.clock.NewTicker(tick)
+			}
+
+		case <-s.polls:
+			if resources := s.takePollRequests(); len(resources) > 0 {
+				s.pollResources(ctx, resources)
+			}
+		}
+	}
+}
+
+// pollResources polls the pull requests of Terraform objects of the config
+// in use.
+func (s *Server) pollResources(ctx context.Context, resources []types.NamespacedName) {
+	config, secret := s.config, s.secret
+	if config == nil {
+		return
+	}
+
+	if s.writes != nil {
+		setWriteLimits(s.writes, config)
+	}
+
+	s.slots = nil
+	if config.HasPlanLimits() {
+		slots := newPlanSlots(config)
+		if err := s.countPlansInFlight(ctx, slots); err != nil {
+			s.log.Error(err, "failed to count plans in flight")
+			return
+		}
+		s.slots = slots
+	}
+
+	for _, resource := range resources {
+		s.schedule.polled(resource, s.clock.Now())
+		if err := s.poll(ctx, resource, secret); err != nil {
+			s.log.Error(err, "failed to check pull request")
+		}
+	}
+}
+
+func (s *Server) poll(ctx context.Context, resource types.NamespacedName, secret *corev1.Secret) error {
+	if secret == nil {
+		return fmt.Errorf("secret is not defined")
+	}
+
+	tf, err := s.getTerraform(ctx, resource)
+	if err != nil {
+		return fmt.Errorf("failed to get Terraform object: %w", err)
+	}
+
+	interval, err := pollIntervalOf(tf)
+	if err != nil {
+		s.log.Error(err, "polling at the interval of the planner", "terraform", resource)
+	}
+	s.schedule.setInterval(resource, interval)
+
+	source, err := s.getSource(ctx, tf)
+	if err != nil {
+		return fmt.Errorf("failed to get Source object: %w", err)
+	}
+
+	repoURL, err := originalRepositoryURL(tf, source)
+	if err != nil {
+		return err
+	}
+
+	gitProvider, repo, err := s.gitProvider(s.config, repoURL, secret)
+	if err != nil {
+		return fmt.Errorf("failed to get git provider: %w", err)
+	}
+
+	features := s.featuresOf(ctx, s.config, gitProvider, repo)
+
+	prs, err := gitProvider.ListPullRequests(ctx, repo)
+	if err != nil {
+		return fmt.Errorf("failed to list pull requests: %w", err)
+	}
+	if !features.drafts {
+		// the draft flag of the providers without drafts is not trusted
+		for i := range prs {
+			prs[i].Draft = false
+		}
+	}
+	s.forgetChangedFiles(repo, prs)
+
+	var entries []provider.PullRequest
+	if features.mergeQueue != nil {
+		if entries, err = features.mergeQueue.ListMergeQueueEntries(ctx, repo); err != nil {
+			return fmt.Errorf("failed to list merge queue entries: %w", err)
+		}
+	}
+
+	prs = append(prs, entries...)
+	s.features = features
+	defer func() { s.features = providerFeatures{} }()
+	if err := s.reconcile(ctx, tf, source, prs); err != nil {
+		return err
+	}
+	if features.statuses == nil {
+		return nil
+	}
+
+	return s.reportCommitStatuses(ctx, features.statuses, tf, prs)
+}
+
+func (s *Server) reconcile(ctx context.Context, original *infrav1.Terraform, source source, prs []provider.PullRequest) error {
+	config := s.config
+	if config == nil {
+		config = &Config{ForkPolicy: ForkPolicySkip, ForkApprovalLabel: DefaultForkApprovalLabel}
+	}
+
+	// Oldest pull requests first, for them to get the plan slots first.
+	prs = append([]provider.PullRequest(nil), prs...)
+	sort.SliceStable(prs, func(i, j int) bool { return prs[i].Number < prs[j].Number })
+
+	options, err := branchOptionsFor(config, original)
+	if err != nil {
+		return err
+	}
+
+	requiredLabel := s.requiredLabelFor(original)
+
+	active := map[client.ObjectKey]bool{}
+	for _, pr := range prs {
+		// log with the fields of the branch Terraform object, to trace its
+		// runs in the logs of the controller and of the runner
+		log := correlation.Fields{
+			Namespace:   options.namespace,
+			Name:        branchName(original, pr, options),
+			PullRequest: strconv.Itoa(pr.Number),
+		}.Logger(s.log).WithValues("terraform", client.ObjectKeyFromObject(original))
+
+		if git, ok := source.(*sourcev1.GitRepository); ok &&
+			git.Spec.Reference != nil && git.Spec.Reference.Branch != "" && pr.BaseBranch != git.Spec.Reference.Branch {
+			continue
+		}
+
+		// the entries of the merge queues are planned whatever their labels,
+		// for the queues not to wait for the statuses of their plans
+		if requiredLabel != "" && !pr.MergeQueue && !pr.HasLabel(requiredLabel) {
+			log.Info("not planning pull request", "reason", "missing the label "+requiredLabel)
+			continue
+		}
+
+		decision := decide(config, pr)
+		if !decision.plan {
+			log.Info("not planning pull request", "reason", decision.reason)
+			continue
+		}
+
+		if s.features.files != nil && !pr.MergeQueue {
+			changed, err := s.changesPath(ctx, s.features.files, original, pr)
+			if err != nil {
+				// planned anyway, rather than deleting its plan
+				log.Error(err, "failed to list the changed files, planning the pull request")
+			} else if !changed {
+				log.Info("not planning pull request", "reason", "no file changed under the path "+original.Spec.Path)
+				continue
+			}
+		}
+
+		sandboxServiceAccount := ""
+		if decision.restricted {
+			sandboxServiceAccount = config.ForkServiceAccountName
+		}
+
+		active[branchKey(original, pr, options)] = true
+
+		queued := false
+		if s.slots != nil {
+			admitted, err := s.admit(ctx, s.slots, original, source, pr, options)
+			if err != nil {
+				log.Error(err, "failed to admit the plan")
+				continue
+			}
+			if !admitted {
+				log.Info("queueing the plan of the pull request")
+				queued = true
+			}
+		}
+
+		workspace := ""
+		if config.WorkspacePerBranch {
+			workspace = branchWorkspace(config, pr)
+		}
+
+		if err := s.reconcileBranch(ctx, original, source, pr, options, sandboxServiceAccount, workspace, queued); err != nil {
+			log.Error(err, "failed to reconcile branch")
+		}
+	}
+
+	return s.deleteStaleBranches(ctx, original, active)
+}
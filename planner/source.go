@@ -0,0 +1,247 @@
+package planner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	apiv1 "github.com/fluxcd/source-controller/api/v1"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/planner/provider"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+//+kubebuilder:rbac:groups=source.toolkit.fluxcd.io,resources=gitrepositories;ocirepositories,verbs=create;update;patch;delete
+
+const (
+	// AnnotationRepository on an original Terraform object whose source is
+	// an OCIRepository is the URL of the Git repository of its pull
+	// requests, when the artifacts do not record it. The planner sets it
+	// on the OCIRepository objects of the branches too.
+	AnnotationRepository = "infra.weave.works/branch-planner-repository"
+	// AnnotationOCITag on an original Terraform object overrides the
+	// ociTagTemplate field of the planner ConfigMap for its branches.
+	AnnotationOCITag = "infra.weave.works/branch-planner-oci-tag"
+
+	// DefaultOCITagTemplate publishes the branches to tags named after
+	// them, e.g. feature-vpc for the branch feature/vpc.
+	DefaultOCITagTemplate = "{{ .HeadBranch }}"
+
+	// ociSourceMetadataKey is the annotation of the OCI artifacts pushed
+	// by flux push artifact with the URL of their Git repository.
+	ociSourceMetadataKey = "org.opencontainers.image.source"
+
+	maxOCITagLength = 128
+)
+
+var invalidOCITagChars = regexp.MustCompile(`[^a-zA-Z0-9_.-]`)
+
+// OCITagData are the fields of the template of the OCI tags the branches of
+// a pull request are published to.
+type OCITagData struct {
+	// HeadBranch is the head branch of the pull request, or the temporary
+	// branch of its entry in a merge queue.
+	HeadBranch string
+	// HeadSha is the head commit of the pull request.
+	HeadSha string
+	// Number is the number of the pull request.
+	Number int
+}
+
+// source is the source of an original Terraform object, and the copies of
+// it which the branches plan: a GitRepository following the head branches
+// of the pull requests, or an OCIRepository following the tags the head
+// branches are published to.
+type source interface {
+	client.Object
+	GetArtifact() *apiv1.Artifact
+	GetConditions() []metav1.Condition
+}
+
+// newSource returns an empty source of a kind, or nil if the kind is not
+// supported.
+func newSource(kind string) source {
+	switch kind {
+	case sourcev1.GitRepositoryKind:
+		return &sourcev1.GitRepository{}
+	case sourcev1.OCIRepositoryKind:
+		return &sourcev1.OCIRepository{}
+	}
+	return nil
+}
+
+// sourceKind returns the kind of a source, which the objects read with a
+// typed client do not carry.
+func sourceKind(src source) string {
+	if _, ok := src.(*sourcev1.OCIRepository); ok {
+		return sourcev1.OCIRepositoryKind
+	}
+	return sourcev1.GitRepositoryKind
+}
+
+// repositoryURL returns the URL of the Git repository of the pull requests
+// of a source: the URL of a GitRepository, or the one an OCIRepository is
+// annotated with, or else the one recorded by its artifact. It is empty if
+// it is unknown.
+func repositoryURL(src source) string {
+	switch src := src.(type) {
+	case *sourcev1.GitRepository:
+		return src.Spec.URL
+	case *sourcev1.OCIRepository:
+		if url := src.GetAnnotations()[AnnotationRepository]; url != "" {
+			return url
+		}
+		if artifact := src.GetArtifact(); artifact != nil {
+			return artifact.Metadata[ociSourceMetadataKey]
+		}
+	}
+	return ""
+}
+
+// originalRepositoryURL returns the URL of the Git repository of the pull
+// requests of an original Terraform object, its annotation taking
+// precedence over its source.
+func originalRepositoryURL(original *infrav1.Terraform, src source) (string, error) {
+	if url := original.GetAnnotations()[AnnotationRepository]; url != "" && sourceKind(src) == sourcev1.OCIRepositoryKind {
+		return url, nil
+	}
+	if url := repositoryURL(src); url != "" {
+		return url, nil
+	}
+	return "", fmt.Errorf("the Git repository of the OCIRepository %s is unknown, annotate the Terraform object with %s",
+		client.ObjectKeyFromObject(src), AnnotationRepository)
+}
+
+// ociTagTemplate returns the template of the OCI tags the branches of an
+// original Terraform object are published to, its annotation taking
+// precedence over the config.
+func ociTagTemplate(config *Config, original *infrav1.Terraform) (*template.Template, error) {
+	text := DefaultOCITagTemplate
+	if config != nil && config.OCITagTemplate != "" {
+		text = config.OCITagTemplate
+	}
+	if value, ok := original.GetAnnotations()[AnnotationOCITag]; ok {
+		text = value
+	}
+	return parseOCITagTemplate(text)
+}
+
+// parseOCITagTemplate parses the template of the OCI tags of the branches.
+func parseOCITagTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("ociTag").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OCI tag template: %w", err)
+	}
+	if _, err := ociTag(tmpl, provider.PullRequest{HeadBranch: "main", HeadSha: "0", Number: 1}); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// ociTag returns the OCI tag the head branch of a pull request is published
+// to, with the characters a tag cannot have replaced with dashes.
+func ociTag(tmpl *template.Template, pr provider.PullRequest) (string, error) {
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, OCITagData{HeadBranch: pr.HeadBranch, HeadSha: pr.HeadSha, Number: pr.Number}); err != nil {
+		return "", fmt.Errorf("invalid OCI tag template: %w", err)
+	}
+
+	tag := strings.TrimLeft(invalidOCITagChars.ReplaceAllString(b.String(), "-"), ".-")
+	if len(tag) > maxOCITagLength {
+		tag = tag[:maxOCITagLength]
+	}
+	if tag == "" {
+		return "", fmt.Errorf("the OCI tag of the pull request %d is empty", pr.Number)
+	}
+	return tag, nil
+}
+
+// reconcileBranchSource creates or updates the copy of the source of an
+// original Terraform object which the branch of a pull request plans.
+func (s *Server) reconcileBranchSource(ctx context.Context, original *infrav1.Terraform, src source, pr provider.PullRequest, options branchOptions) (source, error) {
+	namespace := src.GetNamespace()
+	if options.scratch(original) {
+		namespace = options.namespace
+	}
+	name := branchName(original, pr, options)
+
+	switch src := src.(type) {
+	case *sourcev1.GitRepository:
+		branchSource := &sourcev1.GitRepository{}
+		branchSource.SetNamespace(namespace)
+		branchSource.SetName(name)
+		if _, err := controllerutil.CreateOrUpdate(ctx, s.clusterClient, branchSource, func() error {
+			branchSource.SetLabels(branchLabels(original, pr))
+			branchSource.Spec = *src.Spec.DeepCopy()
+			branchSource.Spec.Reference = &sourcev1.GitRepositoryRef{Branch: pr.HeadBranch}
+			if pr.Fork {
+				// The head branch of a fork does not exist in the repository of
+				// the source, GitHub exposes it as a pull request ref instead.
+				branchSource.Spec.Reference = &sourcev1.GitRepositoryRef{Name: fmt.Sprintf("refs/pull/%d/head", pr.Number)}
+			}
+			if pr.MergeQueue {
+				// The temporary branch of a merge queue entry is pinned to the
+				// speculative merge commit which the status is reported on.
+				branchSource.Spec.Reference.Commit = pr.HeadSha
+			}
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("unable to create or update branch source: %w", err)
+		}
+		return branchSource, nil
+
+	case *sourcev1.OCIRepository:
+		tmpl, err := ociTagTemplate(s.config, original)
+		if err != nil {
+			return nil, err
+		}
+		tag, err := ociTag(tmpl, pr)
+		if err != nil {
+			return nil, err
+		}
+		url, err := originalRepositoryURL(original, src)
+		if err != nil {
+			return nil, err
+		}
+
+		branchSource := &sourcev1.OCIRepository{}
+		branchSource.SetNamespace(namespace)
+		branchSource.SetName(name)
+		if _, err := controllerutil.CreateOrUpdate(ctx, s.clusterClient, branchSource, func() error {
+			branchSource.SetLabels(branchLabels(original, pr))
+			// the artifacts of the branches may not record the repository
+			// of their pull requests, unlike the one of the original
+			branchSource.SetAnnotations(map[string]string{AnnotationRepository: url})
+			branchSource.Spec = *src.Spec.DeepCopy()
+			branchSource.Spec.Reference = &sourcev1.OCIRepositoryRef{Tag: tag}
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("unable to create or update branch source: %w", err)
+		}
+		return branchSource, nil
+	}
+
+	return nil, fmt.Errorf("branch based planner does not support source kind: %s", sourceKind(src))
+}
+
+// deleteBranchSource deletes the source of a branch Terraform object.
+func (s *Server) deleteBranchSource(ctx context.Context, branchTF *infrav1.Terraform) error {
+	branchSource := newSource(branchTF.Spec.SourceRef.Kind)
+	if branchSource == nil {
+		return nil
+	}
+	branchSource.SetNamespace(branchTF.Spec.SourceRef.Namespace)
+	branchSource.SetName(branchTF.Spec.SourceRef.Name)
+
+	if err := s.clusterClient.Delete(ctx, branchSource); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("unable to delete branch source: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package planner
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "github.com/fluxcd/source-controller/api/v1"
+	sourcev1b2 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/go-logr/logr"
+	"github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/planner/provider"
+)
+
+func Test_ociTag(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	pr := provider.PullRequest{Number: 42, HeadBranch: "feature/vpc", HeadSha: "5d3a9f1c"}
+
+	tmpl, err := parseOCITagTemplate(DefaultOCITagTemplate)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	tag, err := ociTag(tmpl, pr)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	expectToEqual(g, tag, "feature-vpc")
+
+	tmpl, err = parseOCITagTemplate("pr-{{ .Number }}-{{ .HeadSha }}")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	tag, err = ociTag(tmpl, pr)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	expectToEqual(g, tag, "pr-42-5d3a9f1c")
+
+	// a tag starts with a letter, a digit or an underscore
+	tmpl, _ = parseOCITagTemplate("{{ .HeadBranch }}")
+	tag, err = ociTag(tmpl, provider.PullRequest{Number: 1, HeadBranch: "-/.hidden"})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	expectToEqual(g, tag, "hidden")
+
+	_, err = ociTag(tmpl, provider.PullRequest{Number: 1, HeadBranch: "/"})
+	g.Expect(err).To(gomega.HaveOccurred())
+
+	_, err = parseOCITagTemplate("{{ .Branch }}")
+	g.Expect(err).To(gomega.HaveOccurred())
+	_, err = parseOCITagTemplate("{{ .HeadBranch ")
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func Test_originalRepositoryURL(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	original := &infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "tf1", Namespace: "default"}}
+	source := &sourcev1b2.OCIRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "source", Namespace: "default"},
+		Spec:       sourcev1b2.OCIRepositorySpec{URL: "oci://ghcr.io/org/infra"},
+	}
+
+	_, err := originalRepositoryURL(original, source)
+	g.Expect(err).To(gomega.HaveOccurred())
+
+	// the URL recorded by flux push artifact
+	source.Status.Artifact = &apiv1.Artifact{Metadata: map[string]string{ociSourceMetadataKey: "https://github.com/org/infra"}}
+	url, err := originalRepositoryURL(original, source)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	expectToEqual(g, url, "https://github.com/org/infra")
+
+	// the annotation takes precedence
+	original.SetAnnotations(map[string]string{AnnotationRepository: "https://github.com/org/other"})
+	url, err = originalRepositoryURL(original, source)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	expectToEqual(g, url, "https://github.com/org/other")
+
+	// but not over the URL of a GitRepository
+	url, err = originalRepositoryURL(original, &sourcev1b2.GitRepository{Spec: sourcev1b2.GitRepositorySpec{URL: "https://github.com/org/repo"}})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	expectToEqual(g, url, "https://github.com/org/repo")
+}
+
+func Test_reconcileOCIRepository(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(sourcev1b2.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(gomega.Succeed())
+
+	original := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "tf1",
+			Namespace:   "default",
+			UID:         "uid",
+			Annotations: map[string]string{AnnotationOCITag: "pr-{{ .Number }}"},
+		},
+		Spec: infrav1.TerraformSpec{
+			SourceRef: infrav1.CrossNamespaceSourceReference{Kind: "OCIRepository", Name: "source", Namespace: "default"},
+		},
+	}
+	source := &sourcev1b2.OCIRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "source", Namespace: "default"},
+		Spec: sourcev1b2.OCIRepositorySpec{
+			URL:       "oci://ghcr.io/org/infra",
+			Reference: &sourcev1b2.OCIRepositoryRef{Tag: "main"},
+			Provider:  "generic",
+		},
+		Status: sourcev1b2.OCIRepositoryStatus{
+			Artifact: &apiv1.Artifact{Metadata: map[string]string{ociSourceMetadataKey: "https://github.com/org/infra"}},
+		},
+	}
+
+	server, err := New(
+		WithLogger(logr.Discard()),
+		WithClusterClient(fake.NewClientBuilder().WithScheme(scheme).WithObjects(original, source).Build()),
+	)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	got, err := server.getSource(ctx, original)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	expectToEqual(g, sourceKind(got), "OCIRepository")
+
+	g.Expect(server.reconcile(ctx, original, source, []provider.PullRequest{{Number: 7, HeadBranch: "feature/vpc"}})).To(gomega.Succeed())
+
+	branchTF := &infrav1.Terraform{}
+	g.Expect(server.clusterClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "tf1-pr-7"}, branchTF)).To(gomega.Succeed())
+	expectToEqual(g, branchTF.Spec.SourceRef, infrav1.CrossNamespaceSourceReference{Kind: "OCIRepository", Name: "tf1-pr-7", Namespace: "default"})
+	g.Expect(branchTF.Spec.PlanOnly).To(gomega.BeTrue())
+
+	branchSource := &sourcev1b2.OCIRepository{}
+	g.Expect(server.clusterClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "tf1-pr-7"}, branchSource)).To(gomega.Succeed())
+	expectToEqual(g, branchSource.Spec.URL, "oci://ghcr.io/org/infra")
+	expectToEqual(g, branchSource.Spec.Reference, &sourcev1b2.OCIRepositoryRef{Tag: "pr-7"})
+	expectToEqual(g, branchSource.GetLabels()[LabelPRID], "7")
+	// the branch keeps the repository of its pull request, for the status
+	// API and the plan limits
+	expectToEqual(g, repositoryURL(branchSource), "https://github.com/org/infra")
+
+	// the branches of a closed pull request are deleted with their source
+	g.Expect(server.reconcile(ctx, original, source, nil)).To(gomega.Succeed())
+	err = server.clusterClient.Get(ctx, client.ObjectKeyFromObject(branchTF), branchTF)
+	g.Expect(apierrors.IsNotFound(err)).To(gomega.BeTrue())
+	err = server.clusterClient.Get(ctx, client.ObjectKeyFromObject(branchSource), branchSource)
+	g.Expect(apierrors.IsNotFound(err)).To(gomega.BeTrue())
+
+	// other kinds of sources are not supported
+	original.Spec.SourceRef.Kind = "Bucket"
+	_, err = server.getSource(ctx, original)
+	g.Expect(err).To(gomega.HaveOccurred())
+}
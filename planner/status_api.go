@@ -0,0 +1,257 @@
+package planner
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/internal/correlation"
+	"github.com/weaveworks/tf-controller/internal/informer/bbp"
+	"github.com/weaveworks/tf-controller/planner/provider"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// StatusAPITokenKey is the key of the secret of the config holding the
+	// bearer token of the plan status API.
+	StatusAPITokenKey = "statusAPIToken"
+
+	statusAPIPrefix = "/v1/pr/"
+	statusAPISuffix = "/plan-status"
+)
+
+// PlanState is the state of the plans of a pull request.
+type PlanState string
+
+const (
+	PlanStateSuccess PlanState = "success"
+	PlanStateFailure PlanState = "failure"
+	PlanStatePending PlanState = "pending"
+	PlanStateQueued  PlanState = "queued"
+)
+
+// PlanStatus is the aggregated status of the branch Terraform objects of a
+// pull request, returned by the plan status API.
+type PlanStatus struct {
+	Repository  string            `json:"repository"`
+	PullRequest int               `json:"pullRequest"`
+	URL         string            `json:"url,omitempty"`
+	State       PlanState         `json:"state"`
+	Terraforms  []TerraformStatus `json:"terraforms"`
+}
+
+// TerraformStatus is the status of the plan of a branch Terraform object.
+type TerraformStatus struct {
+	Namespace      string    `json:"namespace"`
+	Name           string    `json:"name"`
+	Primary        string    `json:"primary"`
+	State          PlanState `json:"state"`
+	Revision       string    `json:"revision,omitempty"`
+	Summary        string    `json:"summary,omitempty"`
+	Message        string    `json:"message,omitempty"`
+	MainDivergence string    `json:"mainDivergence,omitempty"`
+}
+
+// StatusHandler serves the plan status API, for CI systems to gate the
+// merge of pull requests on their plans:
+//
+//	GET /v1/pr/{repository}/{number}/plan-status
+//
+// The repository is the path of the repository, like org/name. Requests are
+// authenticated with the bearer token in the secret of the config.
+func (s *Server) StatusHandler() http.Handler {
+	return http.HandlerFunc(s.serveStatus)
+}
+
+func (s *Server) serveStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	repository, number, err := parseStatusPath(r.URL.Path)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	ctx := r.Context()
+	if status, err := s.authenticate(ctx, r); err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	planStatus, err := s.planStatus(ctx, repository, number)
+	if err != nil {
+		s.log.Error(err, "failed to get the plan status", "repository", repository, correlation.PullRequestKey, strconv.Itoa(number))
+		http.Error(w, "failed to get the plan status", http.StatusInternalServerError)
+		return
+	}
+	if len(planStatus.Terraforms) == 0 {
+		http.Error(w, "no plan found for the pull request", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(planStatus); err != nil {
+		s.log.Error(err, "failed to write the plan status")
+	}
+}
+
+// parseStatusPath returns the repository and the pull request number of a
+// plan status path. The repository may contain slashes.
+func parseStatusPath(path string) (string, int, error) {
+	if !strings.HasPrefix(path, statusAPIPrefix) || !strings.HasSuffix(path, statusAPISuffix) {
+		return "", 0, fmt.Errorf("not found")
+	}
+
+	path = strings.TrimSuffix(strings.TrimPrefix(path, statusAPIPrefix), statusAPISuffix)
+	i := strings.LastIndex(path, "/")
+	if i <= 0 {
+		return "", 0, fmt.Errorf("expected /v1/pr/{repository}/{number}/plan-status")
+	}
+
+	number, err := strconv.Atoi(path[i+1:])
+	if err != nil || number <= 0 {
+		return "", 0, fmt.Errorf("invalid pull request number: %q", path[i+1:])
+	}
+
+	return strings.Trim(path[:i], "/"), number, nil
+}
+
+// authenticate checks the bearer token of the request against the token in
+// the secret of the config, read on each request so that it can be rotated.
+func (s *Server) authenticate(ctx context.Context, r *http.Request) (int, error) {
+	config, err := s.readConfig(ctx)
+	if err != nil {
+		s.log.Error(err, "failed to read the config")
+		return http.StatusInternalServerError, fmt.Errorf("failed to read the config")
+	}
+
+	secret, err := s.getSecret(ctx, client.ObjectKey{Namespace: config.SecretNamespace, Name: config.SecretName})
+	if err != nil {
+		s.log.Error(err, "failed to get secret")
+		return http.StatusInternalServerError, fmt.Errorf("failed to read the token")
+	}
+
+	token := secret.Data[StatusAPITokenKey]
+	if len(token) == 0 {
+		return http.StatusServiceUnavailable, fmt.Errorf("the plan status API is disabled: the secret has no %s key", StatusAPITokenKey)
+	}
+
+	authorization := r.Header.Get("Authorization")
+	bearer := strings.TrimPrefix(authorization, "Bearer ")
+	if bearer == authorization || subtle.ConstantTimeCompare([]byte(bearer), token) != 1 {
+		return http.StatusUnauthorized, fmt.Errorf("unauthorized")
+	}
+
+	return http.StatusOK, nil
+}
+
+// planStatus aggregates the status of the branch Terraform objects of a
+// pull request, across all the namespaces.
+func (s *Server) planStatus(ctx context.Context, repository string, number int) (*PlanStatus, error) {
+	var list infrav1.TerraformList
+	if err := s.clusterClient.List(ctx, &list, client.MatchingLabels{
+		LabelBranchPlanner: "true",
+		LabelPRID:          strconv.Itoa(number),
+	}); err != nil {
+		return nil, fmt.Errorf("unable to list branch Terraform objects: %w", err)
+	}
+
+	planStatus := &PlanStatus{Repository: repository, PullRequest: number, Terraforms: []TerraformStatus{}}
+	for i := range list.Items {
+		branchTF := &list.Items[i]
+		if branchTF.GetLabels()[LabelMergeQueue] == "true" {
+			// the merge queue entries report their status on their commit
+			continue
+		}
+		branchSource, err := s.getBranchSource(ctx, branchTF)
+		if err != nil {
+			return nil, err
+		}
+		if branchSource == nil {
+			continue
+		}
+
+		repo, err := provider.RepositoryFromURL(repositoryURL(branchSource))
+		if err != nil || !strings.EqualFold(repo.String(), repository) {
+			continue
+		}
+
+		planStatus.Terraforms = append(planStatus.Terraforms, branchStatus(branchTF, branchSource))
+		if pr := branchTF.Status.PullRequest; pr != nil && pr.URL != "" {
+			planStatus.URL = pr.URL
+		}
+	}
+
+	sort.Slice(planStatus.Terraforms, func(i, j int) bool {
+		a, b := planStatus.Terraforms[i], planStatus.Terraforms[j]
+		return a.Namespace < b.Namespace || (a.Namespace == b.Namespace && a.Name < b.Name)
+	})
+	planStatus.State = aggregateState(planStatus.Terraforms)
+
+	return planStatus, nil
+}
+
+// branchStatus returns the status of the plan of the latest revision of a
+// branch Terraform object.
+func branchStatus(branchTF *infrav1.Terraform, branchSource source) TerraformStatus {
+	status := TerraformStatus{
+		Namespace: branchTF.GetNamespace(),
+		Name:      branchTF.GetName(),
+		Primary:   branchTF.GetLabels()[LabelPrimaryResource],
+		Revision:  branchTF.Status.LastAttemptedRevision,
+		Summary:   branchTF.Status.Summary,
+		State:     PlanStatePending,
+	}
+
+	ready := apimeta.FindStatusCondition(branchTF.Status.Conditions, meta.ReadyCondition)
+	if ready != nil {
+		status.Message = ready.Message
+	}
+	if diverged := apimeta.FindStatusCondition(branchTF.Status.Conditions, bbp.ConditionTypeMainDiverged); diverged != nil &&
+		diverged.Status == metav1.ConditionTrue {
+		status.MainDivergence = diverged.Message
+	}
+
+	switch {
+	case apimeta.IsStatusConditionTrue(branchTF.Status.Conditions, ConditionTypeQueued):
+		status.State = PlanStateQueued
+	case branchSource.GetArtifact() == nil && apimeta.IsStatusConditionFalse(branchSource.GetConditions(), meta.ReadyCondition):
+		// the branch cannot be fetched, so it never gets planned
+		status.State = PlanStateFailure
+		status.Message = apimeta.FindStatusCondition(branchSource.GetConditions(), meta.ReadyCondition).Message
+	case planInFlight(branchTF, branchSource) || ready == nil:
+	case ready.Status == metav1.ConditionFalse:
+		status.State = PlanStateFailure
+	case ready.Reason == infrav1.PlannedWithChangesReason || ready.Reason == infrav1.PlannedNoChangesReason:
+		status.State = PlanStateSuccess
+	}
+
+	return status
+}
+
+// aggregateState fails if any plan failed, and succeeds only when all the
+// plans succeeded.
+func aggregateState(statuses []TerraformStatus) PlanState {
+	state := PlanStateSuccess
+	for _, status := range statuses {
+		switch status.State {
+		case PlanStateFailure:
+			return PlanStateFailure
+		case PlanStatePending, PlanStateQueued:
+			state = PlanStatePending
+		}
+	}
+	return state
+}
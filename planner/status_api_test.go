@@ -0,0 +1,126 @@
+package planner
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	sourcev1b2 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/go-logr/logr"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+)
+
+func Test_parseStatusPath(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	repository, number, err := parseStatusPath("/v1/pr/org/group/repo/42/plan-status")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	expectToEqual(g, repository, "org/group/repo")
+	expectToEqual(g, number, 42)
+
+	_, _, err = parseStatusPath("/v1/pr/org/repo/abc/plan-status")
+	g.Expect(err).To(gomega.HaveOccurred())
+
+	_, _, err = parseStatusPath("/v1/pr/42/plan-status")
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func Test_StatusHandler(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(sourcev1b2.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(gomega.Succeed())
+
+	branchTF := func(name string, ready metav1.Condition) *infrav1.Terraform {
+		return &infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels: map[string]string{
+					LabelBranchPlanner:   "true",
+					LabelPRID:            "7",
+					LabelPrimaryResource: name[:len(name)-len("-pr-7")],
+				},
+			},
+			Spec: infrav1.TerraformSpec{
+				SourceRef: infrav1.CrossNamespaceSourceReference{Kind: "GitRepository", Name: "source-pr-7", Namespace: "default"},
+			},
+			Status: infrav1.TerraformStatus{
+				LastAttemptedRevision: "pr-7@sha1:aaaaaaaa",
+				Conditions:            []metav1.Condition{ready},
+			},
+		}
+	}
+
+	planned := branchTF("tf1-pr-7", metav1.Condition{Type: meta.ReadyCondition, Status: metav1.ConditionUnknown, Reason: infrav1.PlannedWithChangesReason})
+	failed := branchTF("tf2-pr-7", metav1.Condition{Type: meta.ReadyCondition, Status: metav1.ConditionFalse, Reason: infrav1.TFExecPlanFailedReason, Message: "plan failed"})
+	source := &sourcev1b2.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "source-pr-7", Namespace: "default"},
+		Spec:       sourcev1b2.GitRepositorySpec{URL: "https://github.com/org/repo"},
+		Status: sourcev1b2.GitRepositoryStatus{
+			Artifact: &sourcev1.Artifact{Revision: "pr-7@sha1:aaaaaaaa"},
+		},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "branch-based-planner", Namespace: "default"},
+		Data:       map[string]string{"secretName": "bbp-token"},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bbp-token", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("github"), StatusAPITokenKey: []byte("status")},
+	}
+
+	server, err := New(
+		WithLogger(logr.Discard()),
+		WithClusterClient(fake.NewClientBuilder().WithScheme(scheme).WithObjects(planned, failed, source, configMap, secret).Build()),
+		WithConfigMap("default/branch-based-planner"),
+	)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	get := func(path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		server.StatusHandler().ServeHTTP(rec, req)
+		return rec
+	}
+
+	expectToEqual(g, get("/v1/pr/org/repo/7/plan-status", "").Code, http.StatusUnauthorized)
+	expectToEqual(g, get("/v1/pr/org/repo/7/plan-status", "github").Code, http.StatusUnauthorized)
+	expectToEqual(g, get("/v1/pr/org/other/7/plan-status", "status").Code, http.StatusNotFound)
+	expectToEqual(g, get("/v1/pr/org/repo/8/plan-status", "status").Code, http.StatusNotFound)
+
+	rec := get("/v1/pr/Org/Repo/7/plan-status", "status")
+	expectToEqual(g, rec.Code, http.StatusOK)
+
+	var planStatus PlanStatus
+	g.Expect(json.Unmarshal(rec.Body.Bytes(), &planStatus)).To(gomega.Succeed())
+	expectToEqual(g, planStatus.State, PlanStateFailure)
+	expectToEqual(g, len(planStatus.Terraforms), 2)
+	expectToEqual(g, planStatus.Terraforms[0].Primary, "tf1")
+	expectToEqual(g, planStatus.Terraforms[0].State, PlanStateSuccess)
+	expectToEqual(g, planStatus.Terraforms[1].State, PlanStateFailure)
+	expectToEqual(g, planStatus.Terraforms[1].Message, "plan failed")
+}
+
+func Test_aggregateState(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	expectToEqual(g, aggregateState([]TerraformStatus{{State: PlanStateSuccess}, {State: PlanStateQueued}}), PlanStatePending)
+	expectToEqual(g, aggregateState([]TerraformStatus{{State: PlanStatePending}, {State: PlanStateFailure}}), PlanStateFailure)
+	expectToEqual(g, aggregateState([]TerraformStatus{{State: PlanStateSuccess}}), PlanStateSuccess)
+}
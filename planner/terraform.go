@@ -1,10 +1,9 @@
-package polling
+package planner
 
 import (
 	"context"
 	"fmt"
 
-	sourcev1b2 "github.com/fluxcd/source-controller/api/v1beta2"
 	"github.com/weaveworks/tf-controller/api/v1alpha2"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -20,8 +19,9 @@ func (s *Server) getTerraform(ctx context.Context, ref client.ObjectKey) (*v1alp
 	return obj, nil
 }
 
-func (s *Server) getSource(ctx context.Context, tf *v1alpha2.Terraform) (*sourcev1b2.GitRepository, error) {
-	if tf.Spec.SourceRef.Kind != sourcev1b2.GitRepositoryKind {
+func (s *Server) getSource(ctx context.Context, tf *v1alpha2.Terraform) (source, error) {
+	obj := newSource(tf.Spec.SourceRef.Kind)
+	if obj == nil {
 		return nil, fmt.Errorf("branch based planner does not support source kind: %s", tf.Spec.SourceRef.Kind)
 	}
 
@@ -29,7 +29,9 @@ func (s *Server) getSource(ctx context.Context, tf *v1alpha2.Terraform) (*source
 		Namespace: tf.Spec.SourceRef.Namespace,
 		Name:      tf.Spec.SourceRef.Name,
 	}
-	obj := &sourcev1b2.GitRepository{}
+	if ref.Namespace == "" {
+		ref.Namespace = tf.GetNamespace()
+	}
 	err := s.clusterClient.Get(ctx, ref, obj)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get Source: %w", err)
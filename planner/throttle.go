@@ -0,0 +1,151 @@
+package planner
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"golang.org/x/time/rate"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultBranchWritesPerSecond and DefaultBranchWriteBurst limit the
+	// writes of the planner to the API server, for a change of an original
+	// Terraform object with many open pull requests to update its branches
+	// in batches of the burst, rather than all at once.
+	DefaultBranchWritesPerSecond = 10
+	DefaultBranchWriteBurst      = 20
+)
+
+// newWriteLimiter returns the limiter of the writes of the planner, with the
+// limits of the config.
+func newWriteLimiter(config *Config) *rate.Limiter {
+	return rate.NewLimiter(writeLimits(config))
+}
+
+// setWriteLimits changes the limits of the writes to the ones of a reloaded
+// config.
+func setWriteLimits(limiter *rate.Limiter, config *Config) {
+	limit, burst := writeLimits(config)
+	if limiter.Limit() != limit {
+		limiter.SetLimit(limit)
+	}
+	if limiter.Burst() != burst {
+		limiter.SetBurst(burst)
+	}
+}
+
+// writeLimits returns the limits of the writes of a config, the defaults
+// when it has none. A rate of zero does not limit the writes.
+func writeLimits(config *Config) (rate.Limit, int) {
+	writesPerSecond, burst := float64(DefaultBranchWritesPerSecond), DefaultBranchWriteBurst
+	if config != nil && config.BranchWritesPerSecond != nil {
+		writesPerSecond = *config.BranchWritesPerSecond
+	}
+	if config != nil && config.BranchWriteBurst > 0 {
+		burst = config.BranchWriteBurst
+	}
+
+	if writesPerSecond == 0 {
+		return rate.Inf, burst
+	}
+	return rate.Limit(writesPerSecond), burst
+}
+
+func parseWriteLimits(config *Config, data map[string]string) error {
+	if value := data["branchWritesPerSecond"]; value != "" {
+		writesPerSecond, err := strconv.ParseFloat(value, 64)
+		if err != nil || writesPerSecond < 0 {
+			return fmt.Errorf("branchWritesPerSecond must be a non-negative number: %q", value)
+		}
+		config.BranchWritesPerSecond = &writesPerSecond
+	}
+
+	burst, err := parseLimit(data, "branchWriteBurst")
+	if err != nil {
+		return err
+	}
+	config.BranchWriteBurst = burst
+
+	return nil
+}
+
+// throttledClient waits for the limiter before each write, the reads being
+// served as they come.
+type throttledClient struct {
+	client.Client
+	limiter *rate.Limiter
+}
+
+func (c *throttledClient) wait(ctx context.Context) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return fmt.Errorf("throttled write: %w", err)
+	}
+	return nil
+}
+
+func (c *throttledClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := c.wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *throttledClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := c.wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *throttledClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if err := c.wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *throttledClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if err := c.wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c *throttledClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	if err := c.wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.DeleteAllOf(ctx, obj, opts...)
+}
+
+func (c *throttledClient) Status() client.SubResourceWriter {
+	return &throttledStatusWriter{SubResourceWriter: c.Client.Status(), client: c}
+}
+
+type throttledStatusWriter struct {
+	client.SubResourceWriter
+	client *throttledClient
+}
+
+func (w *throttledStatusWriter) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	if err := w.client.wait(ctx); err != nil {
+		return err
+	}
+	return w.SubResourceWriter.Create(ctx, obj, subResource, opts...)
+}
+
+func (w *throttledStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	if err := w.client.wait(ctx); err != nil {
+		return err
+	}
+	return w.SubResourceWriter.Update(ctx, obj, opts...)
+}
+
+func (w *throttledStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	if err := w.client.wait(ctx); err != nil {
+		return err
+	}
+	return w.SubResourceWriter.Patch(ctx, obj, patch, opts...)
+}
@@ -0,0 +1,63 @@
+package planner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Test_throttledClient(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctx := context.Background()
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "branch-based-planner", Namespace: "default"},
+		Data: map[string]string{
+			"secretName":            "bbp-token",
+			"branchWritesPerSecond": "0.5",
+			"branchWriteBurst":      "2",
+		},
+	}
+	server, _ := newConfigTestServer(g, configMap)
+	expectToEqual(g, server.writes.Limit(), rate.Limit(DefaultBranchWritesPerSecond))
+	expectToEqual(g, server.writes.Burst(), DefaultBranchWriteBurst)
+
+	config, err := server.readConfig(ctx)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	setWriteLimits(server.writes, config)
+	expectToEqual(g, server.writes.Limit(), rate.Limit(0.5))
+	expectToEqual(g, server.writes.Burst(), 2)
+
+	// the writes of the burst are made at once, the next one waits for the
+	// rate, longer than the deadline
+	for _, name := range []string{"a", "b"} {
+		obj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+		g.Expect(server.clusterClient.Create(ctx, obj)).To(gomega.Succeed())
+	}
+	deadline, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	obj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "c", Namespace: "default"}}
+	g.Expect(server.clusterClient.Create(deadline, obj)).To(gomega.MatchError(gomega.ContainSubstring("throttled write")))
+
+	// the reads are not throttled
+	g.Expect(server.clusterClient.Get(deadline, client.ObjectKeyFromObject(configMap), &corev1.ConfigMap{})).To(gomega.Succeed())
+
+	// a rate of zero does not limit the writes
+	configMap.Data["branchWritesPerSecond"] = "0"
+	g.Expect(server.watcher.Update(ctx, configMap)).To(gomega.Succeed())
+	config, err = server.readConfig(ctx)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	setWriteLimits(server.writes, config)
+	g.Expect(server.clusterClient.Create(deadline, obj)).To(gomega.Succeed())
+
+	configMap.Data["branchWritesPerSecond"] = "-1"
+	g.Expect(server.watcher.Update(ctx, configMap)).To(gomega.Succeed())
+	_, err = server.readConfig(ctx)
+	g.Expect(err).To(gomega.MatchError(`branchWritesPerSecond must be a non-negative number: "-1"`))
+}
@@ -0,0 +1,264 @@
+package planner
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/weaveworks/tf-controller/planner/provider"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// WebhookSecretKey is the key of the secret of the config holding the
+	// secret of the webhooks of the Git providers.
+	WebhookSecretKey = "webhookSecret"
+
+	// maxWebhookPayload is the largest payload of a webhook, the one of
+	// GitHub.
+	maxWebhookPayload = 25 << 20
+)
+
+// webhookEvents are the events of the Git providers which change the pull
+// requests or their branches, by the header naming them.
+var webhookEvents = map[string][]string{
+	"X-GitHub-Event": {"pull_request", "push", "merge_group"},
+	"X-Gitlab-Event": {"Merge Request Hook", "Push Hook"},
+}
+
+// webhookPayload holds the URLs of the repository of the events of GitHub
+// and GitLab.
+type webhookPayload struct {
+	Repository struct {
+		HTMLURL  string `json:"html_url"`
+		CloneURL string `json:"clone_url"`
+	} `json:"repository"`
+	Project struct {
+		WebURL     string `json:"web_url"`
+		GitHTTPURL string `json:"git_http_url"`
+	} `json:"project"`
+}
+
+// repository returns the repository of the event, or false if the payload
+// has none.
+func (p webhookPayload) repository() (provider.Repository, bool) {
+	for _, url := range []string{p.Repository.HTMLURL, p.Repository.CloneURL, p.Project.WebURL, p.Project.GitHTTPURL} {
+		if url == "" {
+			continue
+		}
+		if repo, err := provider.RepositoryFromURL(url); err == nil {
+			return repo, true
+		}
+	}
+	return provider.Repository{}, false
+}
+
+// WebhookHandler receives the webhooks of GitHub and GitLab, to poll the
+// pull requests of a repository as soon as they change rather than at the
+// next polling interval:
+//
+//	POST /v1/webhook
+//
+// The webhooks of GitHub are authenticated with their HMAC signature, and
+// the ones of GitLab with their token, both with the webhook secret in the
+// secret of the config. A delivery whose ID was handled within the delivery
+// window is refused with 409 Conflict, so that a replayed delivery does not
+// plan the pull requests again.
+func (s *Server) WebhookHandler() http.Handler {
+	return http.HandlerFunc(s.serveWebhook)
+}
+
+func (s *Server) serveWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookPayload))
+	if err != nil {
+		http.Error(w, "failed to read the payload", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	if status, err := s.verifyWebhook(ctx, r, body); err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	id := deliveryID(r)
+	if id == "" {
+		http.Error(w, "the webhook has no delivery ID", http.StatusBadRequest)
+		return
+	}
+	if !s.deliveries.add(id, s.clock.Now()) {
+		http.Error(w, "the delivery was already handled", http.StatusConflict)
+		return
+	}
+
+	if !isWebhookEvent(r) {
+		// e.g. the ping of a new webhook
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	var payload webhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+	repo, ok := payload.repository()
+	if !ok {
+		http.Error(w, "the payload has no repository", http.StatusBadRequest)
+		return
+	}
+
+	resources, err := s.resourcesOf(ctx, repo)
+	if err != nil {
+		s.log.Error(err, "failed to find the resources of the repository", "repository", repo.String())
+		s.deliveries.forget(id)
+		http.Error(w, "failed to find the resources of the repository", http.StatusInternalServerError)
+		return
+	}
+	if len(resources) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	s.log.Info("polling the pull requests on webhook", "repository", repo.String(), "resources", len(resources))
+	s.requestPoll(resources...)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// verifyWebhook checks the signature of a webhook of GitHub, or the token
+// of a webhook of GitLab, against the webhook secret of the config, read on
+// each request so that it can be rotated.
+func (s *Server) verifyWebhook(ctx context.Context, r *http.Request, body []byte) (int, error) {
+	config, err := s.readConfig(ctx)
+	if err != nil {
+		s.log.Error(err, "failed to read the config")
+		return http.StatusInternalServerError, fmt.Errorf("failed to read the config")
+	}
+
+	secret, err := s.getSecret(ctx, client.ObjectKey{Namespace: config.SecretNamespace, Name: config.SecretName})
+	if err != nil {
+		s.log.Error(err, "failed to get secret")
+		return http.StatusInternalServerError, fmt.Errorf("failed to read the webhook secret")
+	}
+
+	key := secret.Data[WebhookSecretKey]
+	if len(key) == 0 {
+		return http.StatusServiceUnavailable, fmt.Errorf("the webhooks are disabled: the secret has no %s key", WebhookSecretKey)
+	}
+
+	switch {
+	case r.Header.Get("X-GitHub-Event") != "":
+		signature := strings.TrimPrefix(r.Header.Get("X-Hub-Signature-256"), "sha256=")
+		got, err := hex.DecodeString(signature)
+		mac := hmac.New(sha256.New, key)
+		mac.Write(body)
+		if err != nil || !hmac.Equal(got, mac.Sum(nil)) {
+			return http.StatusUnauthorized, fmt.Errorf("invalid signature")
+		}
+	case r.Header.Get("X-Gitlab-Event") != "":
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("X-Gitlab-Token")), key) != 1 {
+			return http.StatusUnauthorized, fmt.Errorf("invalid token")
+		}
+	default:
+		return http.StatusBadRequest, fmt.Errorf("unknown webhook, expected a webhook of GitHub or GitLab")
+	}
+
+	return http.StatusOK, nil
+}
+
+// isWebhookEvent reports whether the event of a webhook may change the pull
+// requests.
+func isWebhookEvent(r *http.Request) bool {
+	for header, events := range webhookEvents {
+		event := r.Header.Get(header)
+		for _, e := range events {
+			if event == e {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// resourcesOf returns the Terraform objects of the config in use whose pull
+// requests are on a repository.
+func (s *Server) resourcesOf(ctx context.Context, repo provider.Repository) ([]types.NamespacedName, error) {
+	s.mu.Lock()
+	config := s.config
+	s.mu.Unlock()
+	if config == nil {
+		return nil, nil
+	}
+
+	var resources []types.NamespacedName
+	for _, resource := range config.Resources {
+		tf, err := s.getTerraform(ctx, resource)
+		if err != nil {
+			// a Terraform object missing from the cluster is skipped, as
+			// by the polling loop
+			continue
+		}
+		source, err := s.getSource(ctx, tf)
+		if err != nil {
+			continue
+		}
+		repoURL, err := originalRepositoryURL(tf, source)
+		if err != nil {
+			continue
+		}
+		if sourceRepo, err := provider.RepositoryFromURL(repoURL); err == nil && strings.EqualFold(sourceRepo.String(), repo.String()) {
+			resources = append(resources, resource)
+		}
+	}
+	return resources, nil
+}
+
+// requestPoll asks the polling loop to poll the pull requests of Terraform
+// objects before its next polling interval. The requests made while the
+// loop is busy are polled at once.
+func (s *Server) requestPoll(resources ...types.NamespacedName) {
+	s.mu.Lock()
+	if s.pendingPolls == nil {
+		s.pendingPolls = map[types.NamespacedName]bool{}
+	}
+	for _, resource := range resources {
+		s.pendingPolls[resource] = true
+	}
+	s.mu.Unlock()
+
+	select {
+	case s.polls <- struct{}{}:
+	default:
+	}
+}
+
+// takePollRequests returns the Terraform objects to poll before the next
+// polling interval, in the order of the config, and forgets them.
+func (s *Server) takePollRequests() []types.NamespacedName {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var resources []types.NamespacedName
+	if s.config != nil {
+		for _, resource := range s.config.Resources {
+			if s.pendingPolls[resource] {
+				resources = append(resources, resource)
+			}
+		}
+	}
+	s.pendingPolls = nil
+	return resources
+}
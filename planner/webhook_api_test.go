@@ -0,0 +1,158 @@
+package planner
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	sourcev1b2 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/go-logr/logr"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+)
+
+func Test_WebhookHandler(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(sourcev1b2.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(gomega.Succeed())
+
+	original := func(name, source string) *infrav1.Terraform {
+		return &infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: infrav1.TerraformSpec{
+				SourceRef: infrav1.CrossNamespaceSourceReference{Kind: "GitRepository", Name: source},
+			},
+		}
+	}
+	gitRepository := func(name, url string) *sourcev1b2.GitRepository {
+		return &sourcev1b2.GitRepository{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       sourcev1b2.GitRepositorySpec{URL: url},
+		}
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "branch-based-planner", Namespace: "default"},
+		Data: map[string]string{
+			"secretName": "bbp-token",
+			"resources":  "- namespace: default\n  name: tf1\n- namespace: default\n  name: tf2\n- namespace: default\n  name: tf3",
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bbp-token", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("github")},
+	}
+
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		original("tf1", "repo"), original("tf2", "other"), original("tf3", "repo"),
+		gitRepository("repo", "https://github.com/org/repo"), gitRepository("other", "https://github.com/org/other"),
+		configMap, secret,
+	).Build()
+	server, err := New(
+		WithLogger(logr.Discard()),
+		WithClusterClient(kubeClient),
+		WithConfigMap("default/branch-based-planner"),
+	)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	server.config, err = server.readConfig(ctx)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	payload := `{"action": "synchronize", "repository": {"html_url": "https://github.com/Org/Repo"}}`
+	sign := func(key, body string) string {
+		mac := hmac.New(sha256.New, []byte(key))
+		mac.Write([]byte(body))
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+	deliveries := 0
+	post := func(headers map[string]string, body string) int {
+		req := httptest.NewRequest(http.MethodPost, "/v1/webhook", strings.NewReader(body))
+		// each delivery has its own ID, unless the test sets one
+		deliveries++
+		req.Header.Set("X-GitHub-Delivery", fmt.Sprintf("delivery-%d", deliveries))
+		req.Header.Set("X-Gitlab-Event-UUID", fmt.Sprintf("delivery-%d", deliveries))
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		rec := httptest.NewRecorder()
+		server.WebhookHandler().ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// the webhooks are disabled without a secret
+	expectToEqual(g, post(map[string]string{"X-GitHub-Event": "pull_request", "X-Hub-Signature-256": sign("", payload)}, payload), http.StatusServiceUnavailable)
+
+	secret.Data[WebhookSecretKey] = []byte("s3cr3t")
+	g.Expect(kubeClient.Update(ctx, secret)).To(gomega.Succeed())
+
+	expectToEqual(g, post(map[string]string{"X-GitHub-Event": "pull_request"}, payload), http.StatusUnauthorized)
+	expectToEqual(g, post(map[string]string{"X-GitHub-Event": "pull_request", "X-Hub-Signature-256": sign("wrong", payload)}, payload), http.StatusUnauthorized)
+	expectToEqual(g, post(map[string]string{"X-Gitlab-Event": "Merge Request Hook", "X-Gitlab-Token": "wrong"}, payload), http.StatusUnauthorized)
+	expectToEqual(g, post(map[string]string{}, payload), http.StatusBadRequest)
+	g.Expect(server.takePollRequests()).To(gomega.BeEmpty())
+
+	// a ping is acknowledged without polling
+	expectToEqual(g, post(map[string]string{"X-GitHub-Event": "ping", "X-Hub-Signature-256": sign("s3cr3t", payload)}, payload), http.StatusNoContent)
+	g.Expect(server.takePollRequests()).To(gomega.BeEmpty())
+
+	expectToEqual(g, post(map[string]string{"X-GitHub-Event": "pull_request", "X-Hub-Signature-256": sign("s3cr3t", payload)}, payload), http.StatusAccepted)
+	expectToEqual(g, server.takePollRequests(), []types.NamespacedName{{Namespace: "default", Name: "tf1"}, {Namespace: "default", Name: "tf3"}})
+	g.Expect(server.takePollRequests()).To(gomega.BeEmpty())
+
+	gitlab := `{"object_kind": "merge_request", "project": {"web_url": "https://gitlab.com/org/other"}}`
+	expectToEqual(g, post(map[string]string{"X-Gitlab-Event": "Merge Request Hook", "X-Gitlab-Token": "s3cr3t"}, gitlab), http.StatusAccepted)
+	expectToEqual(g, server.takePollRequests(), []types.NamespacedName{{Namespace: "default", Name: "tf2"}})
+
+	// a replayed delivery is refused, and does not poll again
+	replayed := map[string]string{"X-GitHub-Event": "pull_request", "X-Hub-Signature-256": sign("s3cr3t", payload), "X-GitHub-Delivery": "72d3162e-cc78-11e3-81ab-4c9367dc0958"}
+	expectToEqual(g, post(replayed, payload), http.StatusAccepted)
+	expectToEqual(g, len(server.takePollRequests()), 2)
+	expectToEqual(g, post(replayed, payload), http.StatusConflict)
+	g.Expect(server.takePollRequests()).To(gomega.BeEmpty())
+
+	replayedGitLab := map[string]string{"X-Gitlab-Event": "Merge Request Hook", "X-Gitlab-Token": "s3cr3t", "X-Gitlab-Event-UUID": "13792a34-cac6-4fda-95a8-c58e00a3954e"}
+	expectToEqual(g, post(replayedGitLab, gitlab), http.StatusAccepted)
+	expectToEqual(g, post(replayedGitLab, gitlab), http.StatusConflict)
+	expectToEqual(g, server.takePollRequests(), []types.NamespacedName{{Namespace: "default", Name: "tf2"}})
+
+	// a delivery without an ID is refused
+	expectToEqual(g, post(map[string]string{"X-GitHub-Event": "pull_request", "X-Hub-Signature-256": sign("s3cr3t", payload), "X-GitHub-Delivery": ""}, payload), http.StatusBadRequest)
+	g.Expect(server.takePollRequests()).To(gomega.BeEmpty())
+
+	// a repository without Terraform objects is not polled
+	unknown := `{"repository": {"html_url": "https://github.com/org/unknown"}}`
+	expectToEqual(g, post(map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature-256": sign("s3cr3t", unknown)}, unknown), http.StatusNoContent)
+	g.Expect(server.takePollRequests()).To(gomega.BeEmpty())
+}
+
+func Test_deliveryCache(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	cache := newDeliveryCache()
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	g.Expect(cache.add("a", now)).To(gomega.BeTrue())
+	g.Expect(cache.add("a", now.Add(time.Hour))).To(gomega.BeFalse())
+
+	// a failed delivery may be redelivered
+	cache.forget("a")
+	g.Expect(cache.add("a", now.Add(time.Hour))).To(gomega.BeTrue())
+
+	// the IDs are forgotten after the delivery window
+	g.Expect(cache.add("a", now.Add(time.Hour+deliveryWindow+time.Second))).To(gomega.BeTrue())
+}
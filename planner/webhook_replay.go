@@ -0,0 +1,72 @@
+package planner
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// deliveryWindow is how long the IDs of the deliveries of the webhooks are
+// remembered. The signatures of GitHub and the tokens of GitLab do not
+// cover a timestamp, so a captured delivery can only be refused while its
+// ID is remembered.
+const deliveryWindow = 24 * time.Hour
+
+// deliveryHeaders are the headers of the unique IDs of the deliveries of
+// the webhooks, by the header naming their event. A delivery redelivered
+// by the Git provider keeps its ID.
+var deliveryHeaders = map[string]string{
+	"X-GitHub-Event": "X-GitHub-Delivery",
+	"X-Gitlab-Event": "X-Gitlab-Event-UUID",
+}
+
+// deliveryID returns the ID of the delivery of a webhook, or an empty
+// string if it has none.
+func deliveryID(r *http.Request) string {
+	for event, header := range deliveryHeaders {
+		if r.Header.Get(event) != "" {
+			return r.Header.Get(header)
+		}
+	}
+	return ""
+}
+
+// deliveryCache remembers the IDs of the deliveries of the webhooks handled
+// within the delivery window, so that a delivery replayed does not poll the
+// pull requests, and plan them, again.
+type deliveryCache struct {
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+func newDeliveryCache() *deliveryCache {
+	return &deliveryCache{seen: map[string]time.Time{}}
+}
+
+// add records the ID of a delivery, and tells whether it was not seen
+// within the delivery window. The IDs are forgotten once the window has
+// elapsed.
+func (c *deliveryCache) add(id string, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for seen, expiry := range c.seen {
+		if now.After(expiry) {
+			delete(c.seen, seen)
+		}
+	}
+
+	if _, ok := c.seen[id]; ok {
+		return false
+	}
+	c.seen[id] = now.Add(deliveryWindow)
+	return true
+}
+
+// forget forgets the ID of a delivery which failed, for the Git provider to
+// redeliver it.
+func (c *deliveryCache) forget(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.seen, id)
+}
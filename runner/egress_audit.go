@@ -0,0 +1,223 @@
+package runner
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"github.com/weaveworks/tf-controller/utils"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// egressAuditLabel marks the ConfigMaps of the egress audit.
+const egressAuditLabel = "infra.contrib.fluxcd.io/egress-audit"
+
+// egressRequestMessage is the message the HTTP transports of the providers,
+// from the plugin SDK and from the AWS SDK, log for each request.
+const egressRequestMessage = "HTTP Request Sent"
+
+// logFieldRegexp matches the key=value fields of a log line, where the value
+// is quoted when it has spaces.
+var logFieldRegexp = regexp.MustCompile(`([\w.@-]+)=("(?:[^"\\]|\\.)*"|\S+)`)
+
+// EgressCall is a cloud API call logged by a provider, with the number of
+// times it was made.
+type EgressCall struct {
+	Host      string `json:"host"`
+	Method    string `json:"method"`
+	Operation string `json:"operation,omitempty"`
+	Count     int    `json:"count"`
+}
+
+// startEgressAudit makes terraform log the requests of the providers to a
+// file, when spec.egressAudit is set. The returned function stops the audit
+// and writes the calls of the operation to the audit ConfigMap.
+func (r *TerraformRunnerServer) startEgressAudit(ctx context.Context, operation string) func() {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	if r.terraform == nil || r.terraform.GetEgressAuditConfigMapName() == "" {
+		return func() {}
+	}
+
+	// the log has the bodies of the requests, so it is deleted once parsed
+	logFile, err := os.CreateTemp("", "tf-egress-audit-*.log")
+	if err != nil {
+		log.Error(err, "unable to start the egress audit")
+		return func() {}
+	}
+	logFile.Close()
+	logPath := logFile.Name()
+
+	envs := r.env
+	if envs == nil {
+		envs = utils.EnvMap(os.Environ())
+	}
+	auditEnvs := map[string]string{}
+	for k, v := range envs {
+		auditEnvs[k] = v
+	}
+	auditEnvs["TF_LOG_CORE"] = "OFF"
+	auditEnvs["TF_LOG_PROVIDER"] = "DEBUG"
+
+	if err := r.tf.SetEnv(auditEnvs); err != nil {
+		log.Error(err, "unable to start the egress audit")
+		os.Remove(logPath)
+		return func() {}
+	}
+	if err := r.tf.SetLogPath(logPath); err != nil {
+		log.Error(err, "unable to start the egress audit")
+		r.tf.SetEnv(r.env)
+		os.Remove(logPath)
+		return func() {}
+	}
+
+	return func() {
+		r.tf.SetLogPath("")
+		r.tf.SetEnv(r.env)
+		defer os.Remove(logPath)
+
+		f, err := os.Open(logPath)
+		if err != nil {
+			log.Error(err, "unable to read the egress audit log")
+			return
+		}
+		defer f.Close()
+
+		calls, err := parseEgressCalls(f)
+		if err != nil {
+			log.Error(err, "unable to parse the egress audit log")
+			return
+		}
+		if err := r.writeEgressAudit(ctx, operation, calls); err != nil {
+			log.Error(err, "unable to write the egress audit")
+			return
+		}
+		log.Info(fmt.Sprintf("recorded %d cloud API calls of the %s", len(calls), operation))
+	}
+}
+
+// parseEgressCalls reads the requests the providers logged, and counts
+// them by host, method and operation.
+func parseEgressCalls(reader io.Reader) ([]EgressCall, error) {
+	counts := map[EgressCall]int{}
+
+	// the lines are read whole, as the logged bodies can be long
+	br := bufio.NewReader(reader)
+	for {
+		line, err := br.ReadString('\n')
+		if strings.Contains(line, egressRequestMessage) {
+			if call, ok := parseEgressCall(line); ok {
+				counts[call]++
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	calls := make([]EgressCall, 0, len(counts))
+	for call, count := range counts {
+		call.Count = count
+		calls = append(calls, call)
+	}
+	sort.Slice(calls, func(i, j int) bool {
+		if calls[i].Host != calls[j].Host {
+			return calls[i].Host < calls[j].Host
+		}
+		if calls[i].Operation != calls[j].Operation {
+			return calls[i].Operation < calls[j].Operation
+		}
+		return calls[i].Method < calls[j].Method
+	})
+	return calls, nil
+}
+
+// parseEgressCall parses the fields of a logged request. The AWS SDK also
+// logs the service and the operation, e.g. EC2:DescribeVpcs.
+func parseEgressCall(line string) (EgressCall, bool) {
+	fields := map[string]string{}
+	for _, match := range logFieldRegexp.FindAllStringSubmatch(line, -1) {
+		value := match[2]
+		if strings.HasPrefix(value, `"`) {
+			if unquoted, err := strconv.Unquote(value); err == nil {
+				value = unquoted
+			}
+		}
+		fields[match[1]] = value
+	}
+
+	u, err := url.Parse(fields["http.url"])
+	if err != nil || u.Host == "" {
+		return EgressCall{}, false
+	}
+
+	call := EgressCall{
+		Host:   u.Host,
+		Method: fields["http.method"],
+	}
+	if service, method := fields["rpc.service"], fields["rpc.method"]; service != "" && method != "" {
+		call.Operation = service + ":" + method
+	}
+	return call, true
+}
+
+// writeEgressAudit writes the calls of the operation to the audit ConfigMap,
+// under the <operation>.json key.
+func (r *TerraformRunnerServer) writeEgressAudit(ctx context.Context, operation string, calls []EgressCall) error {
+	if calls == nil {
+		calls = []EgressCall{}
+	}
+	data, err := json.MarshalIndent(calls, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	key := types.NamespacedName{Namespace: r.terraform.Namespace, Name: r.terraform.GetEgressAuditConfigMapName()}
+	var configMap corev1.ConfigMap
+	if err := r.Client.Get(ctx, key, &configMap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+
+		configMap = corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+				Labels: map[string]string{
+					egressAuditLabel: r.terraform.Name,
+				},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: infrav1.GroupVersion.Group + "/" + infrav1.GroupVersion.Version,
+						Kind:       infrav1.TerraformKind,
+						Name:       r.terraform.Name,
+						UID:        r.terraform.UID,
+					},
+				},
+			},
+			Data: map[string]string{operation + ".json": string(data)},
+		}
+		return r.Client.Create(ctx, &configMap)
+	}
+
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	configMap.Data[operation+".json"] = string(data)
+	return r.Client.Update(ctx, &configMap)
+}
@@ -0,0 +1,60 @@
+package runner
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+const egressAuditLog = `2023-06-01T10:00:00.000Z [DEBUG] provider.terraform-provider-aws_v5.1.0_x5: HTTP Request Sent: @caller=github.com/hashicorp/aws-sdk-go-base/v2/logging/tf_logger.go:45 @module=aws.http http.method=POST http.url=https://sts.us-east-1.amazonaws.com/ rpc.method=GetCallerIdentity rpc.service=STS http.request.body="Action=GetCallerIdentity&Version=2011-06-15" tf_provider_addr=registry.terraform.io/hashicorp/aws timestamp=2023-06-01T10:00:00.000Z
+2023-06-01T10:00:01.000Z [DEBUG] provider.terraform-provider-aws_v5.1.0_x5: HTTP Response Received: @module=aws.http http.status_code=200 rpc.method=GetCallerIdentity rpc.service=STS
+2023-06-01T10:00:02.000Z [DEBUG] provider.terraform-provider-aws_v5.1.0_x5: HTTP Request Sent: @module=aws.http http.method=POST http.url=https://ec2.us-east-1.amazonaws.com/ rpc.method=DescribeVpcs rpc.service=EC2 http.user_agent="APN/1.0 HashiCorp/1.0 Terraform/1.5.0"
+2023-06-01T10:00:03.000Z [DEBUG] provider.terraform-provider-aws_v5.1.0_x5: HTTP Request Sent: @module=aws.http http.method=POST http.url=https://ec2.us-east-1.amazonaws.com/ rpc.method=DescribeVpcs rpc.service=EC2
+2023-06-01T10:00:04.000Z [DEBUG] provider.terraform-provider-google_v4.70.0_x5: HTTP Request Sent: @module=google http.method=GET http.url="https://compute.googleapis.com/compute/v1/projects/demo/global/networks/vpc?alt=json"
+`
+
+func TestParseEgressCalls(t *testing.T) {
+	g := NewWithT(t)
+
+	calls, err := parseEgressCalls(strings.NewReader(egressAuditLog))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(calls).To(Equal([]EgressCall{
+		{Host: "compute.googleapis.com", Method: "GET", Count: 1},
+		{Host: "ec2.us-east-1.amazonaws.com", Method: "POST", Operation: "EC2:DescribeVpcs", Count: 2},
+		{Host: "sts.us-east-1.amazonaws.com", Method: "POST", Operation: "STS:GetCallerIdentity", Count: 1},
+	}))
+}
+
+func TestWriteEgressAudit(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	r := &TerraformRunnerServer{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		terraform: &infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system", UID: "uid"},
+			Spec:       infrav1.TerraformSpec{EgressAudit: &infrav1.EgressAudit{}},
+		},
+	}
+
+	calls := []EgressCall{{Host: "sts.us-east-1.amazonaws.com", Method: "POST", Operation: "STS:GetCallerIdentity", Count: 1}}
+	g.Expect(r.writeEgressAudit(context.TODO(), "plan", calls)).To(Succeed())
+	g.Expect(r.writeEgressAudit(context.TODO(), "apply", nil)).To(Succeed())
+
+	var configMap corev1.ConfigMap
+	g.Expect(r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "flux-system", Name: "helloworld-egress-audit"}, &configMap)).To(Succeed())
+	g.Expect(configMap.OwnerReferences[0].UID).To(Equal(types.UID("uid")))
+	g.Expect(configMap.Data["plan.json"]).To(MatchJSON(`[{"host": "sts.us-east-1.amazonaws.com", "method": "POST", "operation": "STS:GetCallerIdentity", "count": 1}]`))
+	g.Expect(configMap.Data["apply.json"]).To(MatchJSON(`[]`))
+}
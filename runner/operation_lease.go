@@ -0,0 +1,174 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	// OperationLeaseDuration is how long the operation lease stays valid
+	// without being renewed by the runner.
+	OperationLeaseDuration = 30 * time.Second
+	// OperationAnnotation records the operation the runner is running.
+	OperationAnnotation = "infra.contrib.fluxcd.io/operation"
+
+	operationLeaseRenewPeriod = 10 * time.Second
+)
+
+// OperationLeaseName returns the name of the Lease held by the runner of the
+// Terraform object of the given name while it runs an operation.
+func OperationLeaseName(name string) string {
+	return name + "-tf-runner-operation"
+}
+
+// OperationLeaseHeld reports whether the lease is held by a runner, and the
+// time left before it expires.
+func OperationLeaseHeld(lease *coordinationv1.Lease, now time.Time) (bool, time.Duration) {
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return false, 0
+	}
+	expiry := lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second)
+	if !expiry.After(now) {
+		return false, 0
+	}
+	return true, expiry.Sub(now)
+}
+
+// detachedContext keeps the values of its parent, but is never cancelled
+// along with it.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool)         { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}               { return nil }
+func (detachedContext) Err() error                          { return nil }
+func (c detachedContext) Value(key interface{}) interface{} { return c.parent.Value(key) }
+
+// startOperation detaches the operation from the RPC, so that it runs to
+// completion when the controller goes away, and holds the operation lease
+// for a new controller instance to wait for the operation instead of
+// replacing the runner pod. The returned function must be called once the
+// operation is done.
+func (r *TerraformRunnerServer) startOperation(ctx context.Context, operation string) (context.Context, func()) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+
+	opCtx, cancel := context.WithCancel(detachedContext{parent: ctx})
+	go func() {
+		select {
+		case <-r.Done:
+			cancel()
+		case <-opCtx.Done():
+		}
+	}()
+
+	if r.terraform == nil {
+		return opCtx, cancel
+	}
+
+	holder := os.Getenv("POD_NAME")
+	if holder == "" {
+		holder, _ = os.Hostname()
+	}
+
+	leaseKey := types.NamespacedName{Namespace: r.terraform.Namespace, Name: OperationLeaseName(r.terraform.Name)}
+	var mu sync.Mutex
+	released := false
+	renew := func() error {
+		mu.Lock()
+		defer mu.Unlock()
+		if released {
+			return nil
+		}
+
+		now := metav1.NewMicroTime(time.Now())
+		duration := int32(OperationLeaseDuration.Seconds())
+
+		var lease coordinationv1.Lease
+		err := r.Client.Get(opCtx, leaseKey, &lease)
+		if apierrors.IsNotFound(err) {
+			vTrue := true
+			lease = coordinationv1.Lease{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        leaseKey.Name,
+					Namespace:   leaseKey.Namespace,
+					Annotations: map[string]string{OperationAnnotation: operation},
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: infrav1.GroupVersion.Group + "/" + infrav1.GroupVersion.Version,
+							Kind:       infrav1.TerraformKind,
+							Name:       r.terraform.Name,
+							UID:        r.terraform.UID,
+							Controller: &vTrue,
+						},
+					},
+				},
+				Spec: coordinationv1.LeaseSpec{
+					HolderIdentity:       &holder,
+					LeaseDurationSeconds: &duration,
+					AcquireTime:          &now,
+					RenewTime:            &now,
+				},
+			}
+			return r.Client.Create(opCtx, &lease)
+		} else if err != nil {
+			return err
+		}
+
+		if lease.Annotations == nil {
+			lease.Annotations = map[string]string{}
+		}
+		if lease.Annotations[OperationAnnotation] != operation {
+			lease.Spec.AcquireTime = &now
+		}
+		lease.Annotations[OperationAnnotation] = operation
+		lease.Spec.HolderIdentity = &holder
+		lease.Spec.LeaseDurationSeconds = &duration
+		lease.Spec.RenewTime = &now
+		return r.Client.Update(opCtx, &lease)
+	}
+
+	// The operation does not depend on the lease, failing to hold it only
+	// means a new controller instance would not wait for the operation.
+	if err := renew(); err != nil {
+		log.Error(err, "unable to acquire the operation lease", "operation", operation)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(operationLeaseRenewPeriod)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := renew(); err != nil {
+					log.Error(err, "unable to renew the operation lease", "operation", operation)
+				}
+			}
+		}
+	}()
+
+	return opCtx, func() {
+		close(done)
+
+		mu.Lock()
+		defer mu.Unlock()
+		released = true
+		lease := coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{Name: leaseKey.Name, Namespace: leaseKey.Namespace}}
+		if err := r.Client.Delete(opCtx, &lease); err != nil && !apierrors.IsNotFound(err) {
+			log.Error(err, "unable to release the operation lease", "operation", operation)
+		}
+		cancel()
+	}
+}
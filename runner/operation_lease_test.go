@@ -0,0 +1,79 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestStartOperation(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv("POD_NAME", "helloworld-tf-runner")
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	r := &TerraformRunnerServer{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Done:   make(chan os.Signal),
+		terraform: &infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system", UID: "uid"},
+		},
+	}
+
+	rpcCtx, rpcCancel := context.WithCancel(context.Background())
+	ctx, done := r.startOperation(rpcCtx, "apply")
+
+	var lease coordinationv1.Lease
+	leaseKey := types.NamespacedName{Namespace: "flux-system", Name: "helloworld-tf-runner-operation"}
+	g.Expect(r.Client.Get(context.Background(), leaseKey, &lease)).To(Succeed())
+	g.Expect(lease.Annotations[OperationAnnotation]).To(Equal("apply"))
+	g.Expect(*lease.Spec.HolderIdentity).To(Equal("helloworld-tf-runner"))
+	g.Expect(lease.OwnerReferences[0].UID).To(Equal(types.UID("uid")))
+
+	held, left := OperationLeaseHeld(&lease, time.Now())
+	g.Expect(held).To(BeTrue())
+	g.Expect(left).To(BeNumerically("<=", OperationLeaseDuration))
+
+	// the controller going away does not cancel the operation
+	rpcCancel()
+	g.Expect(ctx.Err()).ToNot(HaveOccurred())
+
+	done()
+	g.Expect(ctx.Err()).To(HaveOccurred())
+	err := r.Client.Get(context.Background(), leaseKey, &lease)
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestOperationLeaseHeld(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Now()
+	duration := int32(30)
+	renewTime := metav1.NewMicroTime(now.Add(-40 * time.Second))
+	lease := &coordinationv1.Lease{
+		Spec: coordinationv1.LeaseSpec{LeaseDurationSeconds: &duration, RenewTime: &renewTime},
+	}
+
+	held, _ := OperationLeaseHeld(lease, now)
+	g.Expect(held).To(BeFalse())
+
+	renewTime = metav1.NewMicroTime(now.Add(-10 * time.Second))
+	held, left := OperationLeaseHeld(lease, now)
+	g.Expect(held).To(BeTrue())
+	g.Expect(left).To(Equal(20 * time.Second))
+
+	held, _ = OperationLeaseHeld(&coordinationv1.Lease{}, now)
+	g.Expect(held).To(BeFalse())
+}
@@ -0,0 +1,290 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// progressPollInterval is how often WatchProgress looks for new progress.
+const progressPollInterval = time.Second
+
+var (
+	// progressStartRegexp matches the lines terraform prints when it starts
+	// to work on a resource, for example "aws_instance.web: Creating...".
+	progressStartRegexp = regexp.MustCompile(`^(\S+): (Creating|Modifying|Destroying|Reading)\.\.\.`)
+	// progressCompleteRegexp matches the lines terraform prints when it is
+	// done with a resource, for example "aws_instance.web: Creation complete
+	// after 2s [id=i-123]".
+	progressCompleteRegexp = regexp.MustCompile(`^(\S+): (Creation|Modifications|Destruction|Read) complete(?: after (\S+))?`)
+	// progressRefreshRegexp matches the lines terraform prints when it
+	// refreshes a resource, for example "aws_instance.web: Refreshing
+	// state... [id=i-123]".
+	progressRefreshRegexp = regexp.MustCompile(`^(\S+): Refreshing state\.\.\.`)
+)
+
+// progressActions are the actions of the resources, by the verb terraform
+// prints when it starts and when it completes them.
+var progressActions = map[string]string{
+	"Creating":      "create",
+	"Creation":      "create",
+	"Modifying":     "update",
+	"Modifications": "update",
+	"Destroying":    "delete",
+	"Destruction":   "delete",
+	"Reading":       "read",
+	"Read":          "read",
+}
+
+// progressTracker counts the resources terraform reports in its output while
+// it plans or applies, and times them. It is written as the stdout of
+// terraform, and never keeps the output itself, which may contain sensitive
+// values.
+type progressTracker struct {
+	mu        sync.Mutex
+	operation string
+	total     int32
+	completed int32
+	current   []string
+	done      bool
+	version   int
+	line      []byte
+
+	// started is when terraform started each resource in progress, by
+	// action and address, and timings are the times of the completed ones.
+	now     func() time.Time
+	started map[string]time.Time
+	timings []*ResourceTiming
+}
+
+func newProgressTracker(operation string, total int32) *progressTracker {
+	return &progressTracker{
+		operation: operation,
+		total:     total,
+		now:       time.Now,
+		started:   map[string]time.Time{},
+	}
+}
+
+func (p *progressTracker) Write(data []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.line = append(p.line, data...)
+	for {
+		i := bytes.IndexByte(p.line, '\n')
+		if i < 0 {
+			break
+		}
+		p.parseLine(strings.TrimSpace(string(p.line[:i])))
+		p.line = p.line[i+1:]
+	}
+	return len(data), nil
+}
+
+func (p *progressTracker) parseLine(line string) {
+	if m := progressRefreshRegexp.FindStringSubmatch(line); m != nil {
+		p.completed++
+		p.version++
+		return
+	}
+
+	if m := progressStartRegexp.FindStringSubmatch(line); m != nil {
+		p.current = append(p.current, m[1])
+		p.started[progressActions[m[2]]+" "+m[1]] = p.now()
+		p.version++
+		return
+	}
+
+	if m := progressCompleteRegexp.FindStringSubmatch(line); m != nil {
+		for i, address := range p.current {
+			if address == m[1] {
+				p.current = append(p.current[:i], p.current[i+1:]...)
+				break
+			}
+		}
+		p.recordTiming(m[1], progressActions[m[2]], m[3])
+		// data sources are read, but not counted as changes
+		if m[2] != "Read" {
+			p.completed++
+		}
+		p.version++
+	}
+}
+
+// recordTiming records the time terraform took to complete an action on a
+// resource, measured from the line it started it, or the time terraform
+// printed, to the second, when the start was not seen.
+func (p *progressTracker) recordTiming(address, action, printed string) {
+	key := action + " " + address
+	var elapsed time.Duration
+	if start, ok := p.started[key]; ok {
+		elapsed = p.now().Sub(start)
+		delete(p.started, key)
+	} else if d, err := time.ParseDuration(printed); err == nil {
+		elapsed = d
+	} else {
+		return
+	}
+
+	p.timings = append(p.timings, &ResourceTiming{
+		Address: address,
+		Action:  action,
+		Seconds: elapsed.Seconds(),
+	})
+}
+
+// resourceTimings returns the times of the resources completed so far.
+func (p *progressTracker) resourceTimings() []*ResourceTiming {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]*ResourceTiming(nil), p.timings...)
+}
+
+// setTotal sets the number of resources the operation is expected to go
+// through, when it is only known after the operation started.
+func (p *progressTracker) setTotal(total int32) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.total = total
+	p.version++
+}
+
+func (p *progressTracker) finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done = true
+	p.current = nil
+	p.version++
+}
+
+// snapshot returns the current progress, and its version to tell whether it
+// changed since the last snapshot.
+func (p *progressTracker) snapshot() (*ProgressReply, int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	total := p.total
+	// the output is the source of truth when the expected total is wrong
+	if total > 0 && p.completed > total {
+		total = p.completed
+	}
+	return &ProgressReply{
+		Operation: p.operation,
+		Total:     total,
+		Completed: p.completed,
+		Current:   append([]string(nil), p.current...),
+		Done:      p.done,
+	}, p.version
+}
+
+// trackProgress starts tracking the progress of an operation. The returned
+// tracker must be finished once the operation is over.
+func (r *TerraformRunnerServer) trackProgress(operation string, total int32) *progressTracker {
+	tracker := newProgressTracker(operation, total)
+	r.progressMu.Lock()
+	r.progress = tracker
+	r.progressMu.Unlock()
+	return tracker
+}
+
+func (r *TerraformRunnerServer) currentProgress() *progressTracker {
+	r.progressMu.Lock()
+	defer r.progressMu.Unlock()
+	return r.progress
+}
+
+// WatchProgress streams the progress of the running plan or apply, or of the
+// next one when none is running, until it is done.
+func (r *TerraformRunnerServer) WatchProgress(req *WatchProgressRequest, stream Runner_WatchProgressServer) error {
+	ctx := stream.Context()
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	if req.TfInstance != r.InstanceID {
+		err := fmt.Errorf("no TF instance found")
+		log.Error(err, "no terraform")
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	ticker := time.NewTicker(progressPollInterval)
+	defer ticker.Stop()
+
+	// a finished operation is the previous one, wait for the next one
+	var previous *progressTracker
+	tracker := r.currentProgress()
+	if tracker != nil {
+		if reply, _ := tracker.snapshot(); reply.Done {
+			previous, tracker = tracker, nil
+		}
+	}
+
+	last := -1
+	for {
+		if tracker == nil {
+			if current := r.currentProgress(); current != nil && current != previous {
+				tracker = current
+			}
+		}
+
+		if tracker != nil {
+			reply, version := tracker.snapshot()
+			if version != last {
+				if err := stream.Send(reply); err != nil {
+					return err
+				}
+				last = version
+			}
+			if reply.Done {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// countStateResources counts the instances of the managed resources in the
+// current state, which are the resources a plan refreshes.
+func (r *TerraformRunnerServer) countStateResources(ctx context.Context) (int32, error) {
+	data, err := r.pullState(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return parseStateResourceCount(data)
+}
+
+func parseStateResourceCount(data []byte) (int32, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return 0, nil
+	}
+
+	var state struct {
+		Resources []struct {
+			Mode      string            `json:"mode"`
+			Instances []json.RawMessage `json:"instances"`
+		} `json:"resources"`
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return 0, fmt.Errorf("unable to parse the state: %w", err)
+	}
+
+	var count int32
+	for _, resource := range state.Resources {
+		if resource.Mode == "managed" {
+			count += int32(len(resource.Instances))
+		}
+	}
+	return count, nil
+}
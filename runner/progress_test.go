@@ -0,0 +1,114 @@
+package runner
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestProgressTrackerApply(t *testing.T) {
+	g := NewWithT(t)
+
+	tracker := newProgressTracker("apply", 3)
+	_, err := tracker.Write([]byte("data.aws_ami.ubuntu: Reading...\n" +
+		"data.aws_ami.ubuntu: Read complete after 1s [id=ami-123]\n" +
+		"aws_instance.web: Creating...\n" +
+		"aws_s3_bucket.logs: Modifying... [id=logs]\n" +
+		"aws_instance.web: Still creating... [10s elapsed]\n" +
+		"aws_s3_bucket.logs: Modifications complete after 2s [id=logs]\n" +
+		"aws_security_group.old: Destroy"))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	reply, version := tracker.snapshot()
+	g.Expect(reply.Operation).To(Equal("apply"))
+	g.Expect(reply.Total).To(Equal(int32(3)))
+	g.Expect(reply.Completed).To(Equal(int32(1)))
+	g.Expect(reply.Current).To(Equal([]string{"aws_instance.web"}))
+	g.Expect(reply.Done).To(BeFalse())
+
+	// the rest of a line is parsed once it is written
+	_, err = tracker.Write([]byte("ing... [id=sg-123]\n" +
+		"aws_instance.web: Creation complete after 12s [id=i-123]\n"))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	reply, next := tracker.snapshot()
+	g.Expect(next).ToNot(Equal(version))
+	g.Expect(reply.Completed).To(Equal(int32(2)))
+	g.Expect(reply.Current).To(Equal([]string{"aws_security_group.old"}))
+
+	_, err = tracker.Write([]byte("aws_security_group.old: Destruction complete after 1s\n" +
+		"aws_iam_role.extra: Creation complete after 1s\n"))
+	g.Expect(err).ToNot(HaveOccurred())
+	tracker.finish()
+
+	reply, _ = tracker.snapshot()
+	g.Expect(reply.Completed).To(Equal(int32(4)))
+	g.Expect(reply.Total).To(Equal(int32(4)))
+	g.Expect(reply.Current).To(BeEmpty())
+	g.Expect(reply.Done).To(BeTrue())
+}
+
+func TestProgressTrackerPlan(t *testing.T) {
+	g := NewWithT(t)
+
+	tracker := newProgressTracker("plan", 0)
+	_, err := tracker.Write([]byte("aws_instance.web: Refreshing state... [id=i-123]\n" +
+		"module.vpc.aws_vpc.this[0]: Refreshing state... [id=vpc-123]\n"))
+	g.Expect(err).ToNot(HaveOccurred())
+	tracker.setTotal(3)
+
+	reply, _ := tracker.snapshot()
+	g.Expect(reply.Completed).To(Equal(int32(2)))
+	g.Expect(reply.Total).To(Equal(int32(3)))
+}
+
+func TestParseStateResourceCount(t *testing.T) {
+	g := NewWithT(t)
+
+	count, err := parseStateResourceCount([]byte(`{
+  "version": 4,
+  "resources": [
+    {"mode": "managed", "type": "aws_instance", "name": "web", "instances": [{}, {}]},
+    {"mode": "data", "type": "aws_ami", "name": "ubuntu", "instances": [{}]},
+    {"mode": "managed", "type": "aws_vpc", "name": "this", "instances": [{}]}
+  ]
+}`))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(count).To(Equal(int32(3)))
+
+	count, err = parseStateResourceCount(nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(count).To(Equal(int32(0)))
+
+	_, err = parseStateResourceCount([]byte("not json"))
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestProgressTrackerTimings(t *testing.T) {
+	g := NewWithT(t)
+
+	tracker := newProgressTracker("apply", 2)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	tracker.now = func() time.Time { return now }
+
+	_, err := tracker.Write([]byte("aws_instance.web: Creating...\n" +
+		"aws_instance.old: Destroying... [id=i-old]\n"))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	now = now.Add(1500 * time.Millisecond)
+	_, err = tracker.Write([]byte("aws_instance.old: Destruction complete after 1s\n"))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	now = now.Add(3 * time.Minute)
+	// the start of the bucket is not seen, the time printed is used
+	_, err = tracker.Write([]byte("aws_instance.web: Creation complete after 3m1s [id=i-123]\n" +
+		"aws_s3_bucket.logs: Modifications complete after 2s [id=logs]\n"))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(tracker.resourceTimings()).To(Equal([]*ResourceTiming{
+		{Address: "aws_instance.old", Action: "delete", Seconds: 1.5},
+		{Address: "aws_instance.web", Action: "create", Seconds: 181.5},
+		{Address: "aws_s3_bucket.logs", Action: "update", Seconds: 2},
+	}))
+}
@@ -20,6 +20,108 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type GetVersionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProtocolVersion int32 `protobuf:"varint,1,opt,name=protocolVersion,proto3" json:"protocolVersion,omitempty"`
+}
+
+func (x *GetVersionRequest) Reset() {
+	*x = GetVersionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVersionRequest) ProtoMessage() {}
+
+func (x *GetVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVersionRequest.ProtoReflect.Descriptor instead.
+func (*GetVersionRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetVersionRequest) GetProtocolVersion() int32 {
+	if x != nil {
+		return x.ProtocolVersion
+	}
+	return 0
+}
+
+type GetVersionReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProtocolVersion    int32 `protobuf:"varint,1,opt,name=protocolVersion,proto3" json:"protocolVersion,omitempty"`
+	MinProtocolVersion int32 `protobuf:"varint,2,opt,name=minProtocolVersion,proto3" json:"minProtocolVersion,omitempty"`
+}
+
+func (x *GetVersionReply) Reset() {
+	*x = GetVersionReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetVersionReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVersionReply) ProtoMessage() {}
+
+func (x *GetVersionReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVersionReply.ProtoReflect.Descriptor instead.
+func (*GetVersionReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetVersionReply) GetProtocolVersion() int32 {
+	if x != nil {
+		return x.ProtocolVersion
+	}
+	return 0
+}
+
+func (x *GetVersionReply) GetMinProtocolVersion() int32 {
+	if x != nil {
+		return x.MinProtocolVersion
+	}
+	return 0
+}
+
 type LookPathRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -31,7 +133,7 @@ type LookPathRequest struct {
 func (x *LookPathRequest) Reset() {
 	*x = LookPathRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[0]
+		mi := &file_runner_runner_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -44,7 +146,7 @@ func (x *LookPathRequest) String() string {
 func (*LookPathRequest) ProtoMessage() {}
 
 func (x *LookPathRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[0]
+	mi := &file_runner_runner_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -57,7 +159,7 @@ func (x *LookPathRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LookPathRequest.ProtoReflect.Descriptor instead.
 func (*LookPathRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{0}
+	return file_runner_runner_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *LookPathRequest) GetFile() string {
@@ -78,7 +180,7 @@ type LookPathReply struct {
 func (x *LookPathReply) Reset() {
 	*x = LookPathReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[1]
+		mi := &file_runner_runner_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -91,7 +193,7 @@ func (x *LookPathReply) String() string {
 func (*LookPathReply) ProtoMessage() {}
 
 func (x *LookPathReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[1]
+	mi := &file_runner_runner_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -104,7 +206,7 @@ func (x *LookPathReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LookPathReply.ProtoReflect.Descriptor instead.
 func (*LookPathReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{1}
+	return file_runner_runner_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *LookPathReply) GetExecPath() string {
@@ -128,7 +230,7 @@ type NewTerraformRequest struct {
 func (x *NewTerraformRequest) Reset() {
 	*x = NewTerraformRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[2]
+		mi := &file_runner_runner_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -141,7 +243,7 @@ func (x *NewTerraformRequest) String() string {
 func (*NewTerraformRequest) ProtoMessage() {}
 
 func (x *NewTerraformRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[2]
+	mi := &file_runner_runner_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -154,7 +256,7 @@ func (x *NewTerraformRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NewTerraformRequest.ProtoReflect.Descriptor instead.
 func (*NewTerraformRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{2}
+	return file_runner_runner_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *NewTerraformRequest) GetWorkingDir() string {
@@ -196,7 +298,7 @@ type NewTerraformReply struct {
 func (x *NewTerraformReply) Reset() {
 	*x = NewTerraformReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[3]
+		mi := &file_runner_runner_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -209,7 +311,7 @@ func (x *NewTerraformReply) String() string {
 func (*NewTerraformReply) ProtoMessage() {}
 
 func (x *NewTerraformReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[3]
+	mi := &file_runner_runner_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -222,7 +324,7 @@ func (x *NewTerraformReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NewTerraformReply.ProtoReflect.Descriptor instead.
 func (*NewTerraformReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{3}
+	return file_runner_runner_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *NewTerraformReply) GetId() string {
@@ -244,7 +346,7 @@ type SetEnvRequest struct {
 func (x *SetEnvRequest) Reset() {
 	*x = SetEnvRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[4]
+		mi := &file_runner_runner_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -257,7 +359,7 @@ func (x *SetEnvRequest) String() string {
 func (*SetEnvRequest) ProtoMessage() {}
 
 func (x *SetEnvRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[4]
+	mi := &file_runner_runner_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -270,7 +372,7 @@ func (x *SetEnvRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetEnvRequest.ProtoReflect.Descriptor instead.
 func (*SetEnvRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{4}
+	return file_runner_runner_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *SetEnvRequest) GetTfInstance() string {
@@ -298,7 +400,7 @@ type SetEnvReply struct {
 func (x *SetEnvReply) Reset() {
 	*x = SetEnvReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[5]
+		mi := &file_runner_runner_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -311,7 +413,7 @@ func (x *SetEnvReply) String() string {
 func (*SetEnvReply) ProtoMessage() {}
 
 func (x *SetEnvReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[5]
+	mi := &file_runner_runner_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -324,7 +426,7 @@ func (x *SetEnvReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetEnvReply.ProtoReflect.Descriptor instead.
 func (*SetEnvReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{5}
+	return file_runner_runner_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *SetEnvReply) GetMessage() string {
@@ -347,7 +449,7 @@ type FileMapping struct {
 func (x *FileMapping) Reset() {
 	*x = FileMapping{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[6]
+		mi := &file_runner_runner_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -360,7 +462,7 @@ func (x *FileMapping) String() string {
 func (*FileMapping) ProtoMessage() {}
 
 func (x *FileMapping) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[6]
+	mi := &file_runner_runner_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -373,7 +475,7 @@ func (x *FileMapping) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FileMapping.ProtoReflect.Descriptor instead.
 func (*FileMapping) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{6}
+	return file_runner_runner_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *FileMapping) GetContent() []byte {
@@ -409,7 +511,7 @@ type CreateFileMappingsRequest struct {
 func (x *CreateFileMappingsRequest) Reset() {
 	*x = CreateFileMappingsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[7]
+		mi := &file_runner_runner_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -422,7 +524,7 @@ func (x *CreateFileMappingsRequest) String() string {
 func (*CreateFileMappingsRequest) ProtoMessage() {}
 
 func (x *CreateFileMappingsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[7]
+	mi := &file_runner_runner_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -435,7 +537,7 @@ func (x *CreateFileMappingsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateFileMappingsRequest.ProtoReflect.Descriptor instead.
 func (*CreateFileMappingsRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{7}
+	return file_runner_runner_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *CreateFileMappingsRequest) GetWorkingDir() string {
@@ -463,7 +565,7 @@ type CreateFileMappingsReply struct {
 func (x *CreateFileMappingsReply) Reset() {
 	*x = CreateFileMappingsReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[8]
+		mi := &file_runner_runner_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -476,7 +578,7 @@ func (x *CreateFileMappingsReply) String() string {
 func (*CreateFileMappingsReply) ProtoMessage() {}
 
 func (x *CreateFileMappingsReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[8]
+	mi := &file_runner_runner_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -489,7 +591,7 @@ func (x *CreateFileMappingsReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateFileMappingsReply.ProtoReflect.Descriptor instead.
 func (*CreateFileMappingsReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{8}
+	return file_runner_runner_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *CreateFileMappingsReply) GetMessage() string {
@@ -508,12 +610,16 @@ type UploadAndExtractRequest struct {
 	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	TarGz     []byte `protobuf:"bytes,3,opt,name=tarGz,proto3" json:"tarGz,omitempty"`
 	Path      string `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	// digest is the sha256:<hex> digest of the artifact, to store it in the
+	// source cache of the node, or, without the tarGz, to extract it from the
+	// cache.
+	Digest string `protobuf:"bytes,5,opt,name=digest,proto3" json:"digest,omitempty"`
 }
 
 func (x *UploadAndExtractRequest) Reset() {
 	*x = UploadAndExtractRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[9]
+		mi := &file_runner_runner_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -526,7 +632,7 @@ func (x *UploadAndExtractRequest) String() string {
 func (*UploadAndExtractRequest) ProtoMessage() {}
 
 func (x *UploadAndExtractRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[9]
+	mi := &file_runner_runner_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -539,7 +645,7 @@ func (x *UploadAndExtractRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadAndExtractRequest.ProtoReflect.Descriptor instead.
 func (*UploadAndExtractRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{9}
+	return file_runner_runner_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *UploadAndExtractRequest) GetNamespace() string {
@@ -570,6 +676,13 @@ func (x *UploadAndExtractRequest) GetPath() string {
 	return ""
 }
 
+func (x *UploadAndExtractRequest) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
 type UploadAndExtractReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -577,12 +690,14 @@ type UploadAndExtractReply struct {
 
 	WorkingDir string `protobuf:"bytes,1,opt,name=workingDir,proto3" json:"workingDir,omitempty"`
 	TmpDir     string `protobuf:"bytes,2,opt,name=tmpDir,proto3" json:"tmpDir,omitempty"`
+	// cached is true when the artifact was extracted from the source cache.
+	Cached bool `protobuf:"varint,3,opt,name=cached,proto3" json:"cached,omitempty"`
 }
 
 func (x *UploadAndExtractReply) Reset() {
 	*x = UploadAndExtractReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[10]
+		mi := &file_runner_runner_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -595,7 +710,7 @@ func (x *UploadAndExtractReply) String() string {
 func (*UploadAndExtractReply) ProtoMessage() {}
 
 func (x *UploadAndExtractReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[10]
+	mi := &file_runner_runner_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -608,7 +723,7 @@ func (x *UploadAndExtractReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadAndExtractReply.ProtoReflect.Descriptor instead.
 func (*UploadAndExtractReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{10}
+	return file_runner_runner_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *UploadAndExtractReply) GetWorkingDir() string {
@@ -625,6 +740,13 @@ func (x *UploadAndExtractReply) GetTmpDir() string {
 	return ""
 }
 
+func (x *UploadAndExtractReply) GetCached() bool {
+	if x != nil {
+		return x.Cached
+	}
+	return false
+}
+
 type CleanupDirRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -636,7 +758,7 @@ type CleanupDirRequest struct {
 func (x *CleanupDirRequest) Reset() {
 	*x = CleanupDirRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[11]
+		mi := &file_runner_runner_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -649,7 +771,7 @@ func (x *CleanupDirRequest) String() string {
 func (*CleanupDirRequest) ProtoMessage() {}
 
 func (x *CleanupDirRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[11]
+	mi := &file_runner_runner_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -662,7 +784,7 @@ func (x *CleanupDirRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CleanupDirRequest.ProtoReflect.Descriptor instead.
 func (*CleanupDirRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{11}
+	return file_runner_runner_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *CleanupDirRequest) GetTmpDir() string {
@@ -683,7 +805,7 @@ type CleanupDirReply struct {
 func (x *CleanupDirReply) Reset() {
 	*x = CleanupDirReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[12]
+		mi := &file_runner_runner_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -696,7 +818,7 @@ func (x *CleanupDirReply) String() string {
 func (*CleanupDirReply) ProtoMessage() {}
 
 func (x *CleanupDirReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[12]
+	mi := &file_runner_runner_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -709,7 +831,7 @@ func (x *CleanupDirReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CleanupDirReply.ProtoReflect.Descriptor instead.
 func (*CleanupDirReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{12}
+	return file_runner_runner_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *CleanupDirReply) GetMessage() string {
@@ -731,7 +853,7 @@ type WriteBackendConfigRequest struct {
 func (x *WriteBackendConfigRequest) Reset() {
 	*x = WriteBackendConfigRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[13]
+		mi := &file_runner_runner_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -744,7 +866,7 @@ func (x *WriteBackendConfigRequest) String() string {
 func (*WriteBackendConfigRequest) ProtoMessage() {}
 
 func (x *WriteBackendConfigRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[13]
+	mi := &file_runner_runner_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -757,7 +879,7 @@ func (x *WriteBackendConfigRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WriteBackendConfigRequest.ProtoReflect.Descriptor instead.
 func (*WriteBackendConfigRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{13}
+	return file_runner_runner_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *WriteBackendConfigRequest) GetDirPath() string {
@@ -785,7 +907,7 @@ type WriteBackendConfigReply struct {
 func (x *WriteBackendConfigReply) Reset() {
 	*x = WriteBackendConfigReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[14]
+		mi := &file_runner_runner_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -798,7 +920,7 @@ func (x *WriteBackendConfigReply) String() string {
 func (*WriteBackendConfigReply) ProtoMessage() {}
 
 func (x *WriteBackendConfigReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[14]
+	mi := &file_runner_runner_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -811,7 +933,7 @@ func (x *WriteBackendConfigReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WriteBackendConfigReply.ProtoReflect.Descriptor instead.
 func (*WriteBackendConfigReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{14}
+	return file_runner_runner_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *WriteBackendConfigReply) GetMessage() string {
@@ -821,33 +943,32 @@ func (x *WriteBackendConfigReply) GetMessage() string {
 	return ""
 }
 
-type ProcessCliConfigRequest struct {
+type WriteSimulationConfigRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	DirPath   string `protobuf:"bytes,1,opt,name=dirPath,proto3" json:"dirPath,omitempty"`
-	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	Name      string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	DirPath          string `protobuf:"bytes,1,opt,name=dirPath,proto3" json:"dirPath,omitempty"`
+	SimulationConfig []byte `protobuf:"bytes,2,opt,name=simulationConfig,proto3" json:"simulationConfig,omitempty"`
 }
 
-func (x *ProcessCliConfigRequest) Reset() {
-	*x = ProcessCliConfigRequest{}
+func (x *WriteSimulationConfigRequest) Reset() {
+	*x = WriteSimulationConfigRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[15]
+		mi := &file_runner_runner_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ProcessCliConfigRequest) String() string {
+func (x *WriteSimulationConfigRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProcessCliConfigRequest) ProtoMessage() {}
+func (*WriteSimulationConfigRequest) ProtoMessage() {}
 
-func (x *ProcessCliConfigRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[15]
+func (x *WriteSimulationConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -858,57 +979,50 @@ func (x *ProcessCliConfigRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ProcessCliConfigRequest.ProtoReflect.Descriptor instead.
-func (*ProcessCliConfigRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use WriteSimulationConfigRequest.ProtoReflect.Descriptor instead.
+func (*WriteSimulationConfigRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *ProcessCliConfigRequest) GetDirPath() string {
+func (x *WriteSimulationConfigRequest) GetDirPath() string {
 	if x != nil {
 		return x.DirPath
 	}
 	return ""
 }
 
-func (x *ProcessCliConfigRequest) GetNamespace() string {
-	if x != nil {
-		return x.Namespace
-	}
-	return ""
-}
-
-func (x *ProcessCliConfigRequest) GetName() string {
+func (x *WriteSimulationConfigRequest) GetSimulationConfig() []byte {
 	if x != nil {
-		return x.Name
+		return x.SimulationConfig
 	}
-	return ""
+	return nil
 }
 
-type ProcessCliConfigReply struct {
+type WriteSimulationConfigReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	FilePath string `protobuf:"bytes,1,opt,name=filePath,proto3" json:"filePath,omitempty"`
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 }
 
-func (x *ProcessCliConfigReply) Reset() {
-	*x = ProcessCliConfigReply{}
+func (x *WriteSimulationConfigReply) Reset() {
+	*x = WriteSimulationConfigReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[16]
+		mi := &file_runner_runner_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ProcessCliConfigReply) String() string {
+func (x *WriteSimulationConfigReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProcessCliConfigReply) ProtoMessage() {}
+func (*WriteSimulationConfigReply) ProtoMessage() {}
 
-func (x *ProcessCliConfigReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[16]
+func (x *WriteSimulationConfigReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -919,43 +1033,45 @@ func (x *ProcessCliConfigReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ProcessCliConfigReply.ProtoReflect.Descriptor instead.
-func (*ProcessCliConfigReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use WriteSimulationConfigReply.ProtoReflect.Descriptor instead.
+func (*WriteSimulationConfigReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *ProcessCliConfigReply) GetFilePath() string {
+func (x *WriteSimulationConfigReply) GetMessage() string {
 	if x != nil {
-		return x.FilePath
+		return x.Message
 	}
 	return ""
 }
 
-type GenerateVarsForTFRequest struct {
+type ProcessCliConfigRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	WorkingDir string `protobuf:"bytes,1,opt,name=workingDir,proto3" json:"workingDir,omitempty"`
+	DirPath   string `protobuf:"bytes,1,opt,name=dirPath,proto3" json:"dirPath,omitempty"`
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name      string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
 }
 
-func (x *GenerateVarsForTFRequest) Reset() {
-	*x = GenerateVarsForTFRequest{}
+func (x *ProcessCliConfigRequest) Reset() {
+	*x = ProcessCliConfigRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[17]
+		mi := &file_runner_runner_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GenerateVarsForTFRequest) String() string {
+func (x *ProcessCliConfigRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenerateVarsForTFRequest) ProtoMessage() {}
+func (*ProcessCliConfigRequest) ProtoMessage() {}
 
-func (x *GenerateVarsForTFRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[17]
+func (x *ProcessCliConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -966,43 +1082,57 @@ func (x *GenerateVarsForTFRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenerateVarsForTFRequest.ProtoReflect.Descriptor instead.
-func (*GenerateVarsForTFRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use ProcessCliConfigRequest.ProtoReflect.Descriptor instead.
+func (*ProcessCliConfigRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *GenerateVarsForTFRequest) GetWorkingDir() string {
+func (x *ProcessCliConfigRequest) GetDirPath() string {
 	if x != nil {
-		return x.WorkingDir
+		return x.DirPath
 	}
 	return ""
 }
 
-type GenerateVarsForTFReply struct {
+func (x *ProcessCliConfigRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ProcessCliConfigRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ProcessCliConfigReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	FilePath string `protobuf:"bytes,1,opt,name=filePath,proto3" json:"filePath,omitempty"`
 }
 
-func (x *GenerateVarsForTFReply) Reset() {
-	*x = GenerateVarsForTFReply{}
+func (x *ProcessCliConfigReply) Reset() {
+	*x = ProcessCliConfigReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[18]
+		mi := &file_runner_runner_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GenerateVarsForTFReply) String() string {
+func (x *ProcessCliConfigReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenerateVarsForTFReply) ProtoMessage() {}
+func (*ProcessCliConfigReply) ProtoMessage() {}
 
-func (x *GenerateVarsForTFReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[18]
+func (x *ProcessCliConfigReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1013,43 +1143,46 @@ func (x *GenerateVarsForTFReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenerateVarsForTFReply.ProtoReflect.Descriptor instead.
-func (*GenerateVarsForTFReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use ProcessCliConfigReply.ProtoReflect.Descriptor instead.
+func (*ProcessCliConfigReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *GenerateVarsForTFReply) GetMessage() string {
+func (x *ProcessCliConfigReply) GetFilePath() string {
 	if x != nil {
-		return x.Message
+		return x.FilePath
 	}
 	return ""
 }
 
-type GenerateTemplateRequest struct {
+type ProcessGitCredentialsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	WorkingDir string `protobuf:"bytes,1,opt,name=workingDir,proto3" json:"workingDir,omitempty"`
+	DirPath   string   `protobuf:"bytes,1,opt,name=dirPath,proto3" json:"dirPath,omitempty"`
+	Namespace string   `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name      string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Hosts     []string `protobuf:"bytes,4,rep,name=hosts,proto3" json:"hosts,omitempty"`
 }
 
-func (x *GenerateTemplateRequest) Reset() {
-	*x = GenerateTemplateRequest{}
+func (x *ProcessGitCredentialsRequest) Reset() {
+	*x = ProcessGitCredentialsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[19]
+		mi := &file_runner_runner_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GenerateTemplateRequest) String() string {
+func (x *ProcessGitCredentialsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenerateTemplateRequest) ProtoMessage() {}
+func (*ProcessGitCredentialsRequest) ProtoMessage() {}
 
-func (x *GenerateTemplateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[19]
+func (x *ProcessGitCredentialsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1060,43 +1193,64 @@ func (x *GenerateTemplateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenerateTemplateRequest.ProtoReflect.Descriptor instead.
-func (*GenerateTemplateRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use ProcessGitCredentialsRequest.ProtoReflect.Descriptor instead.
+func (*ProcessGitCredentialsRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *GenerateTemplateRequest) GetWorkingDir() string {
+func (x *ProcessGitCredentialsRequest) GetDirPath() string {
 	if x != nil {
-		return x.WorkingDir
+		return x.DirPath
 	}
 	return ""
 }
 
-type GenerateTemplateReply struct {
+func (x *ProcessGitCredentialsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ProcessGitCredentialsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProcessGitCredentialsRequest) GetHosts() []string {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+type ProcessGitCredentialsReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Envs map[string]string `protobuf:"bytes,1,rep,name=envs,proto3" json:"envs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *GenerateTemplateReply) Reset() {
-	*x = GenerateTemplateReply{}
+func (x *ProcessGitCredentialsReply) Reset() {
+	*x = ProcessGitCredentialsReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[20]
+		mi := &file_runner_runner_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GenerateTemplateReply) String() string {
+func (x *ProcessGitCredentialsReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenerateTemplateReply) ProtoMessage() {}
+func (*ProcessGitCredentialsReply) ProtoMessage() {}
 
-func (x *GenerateTemplateReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[20]
+func (x *ProcessGitCredentialsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1107,47 +1261,43 @@ func (x *GenerateTemplateReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenerateTemplateReply.ProtoReflect.Descriptor instead.
-func (*GenerateTemplateReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use ProcessGitCredentialsReply.ProtoReflect.Descriptor instead.
+func (*ProcessGitCredentialsReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *GenerateTemplateReply) GetMessage() string {
+func (x *ProcessGitCredentialsReply) GetEnvs() map[string]string {
 	if x != nil {
-		return x.Message
+		return x.Envs
 	}
-	return ""
+	return nil
 }
 
-type PlanRequest struct {
+type CheckPlanFreshnessRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TfInstance string   `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
-	Out        string   `protobuf:"bytes,2,opt,name=out,proto3" json:"out,omitempty"`
-	Refresh    bool     `protobuf:"varint,3,opt,name=refresh,proto3" json:"refresh,omitempty"`
-	Destroy    bool     `protobuf:"varint,4,opt,name=destroy,proto3" json:"destroy,omitempty"`
-	Targets    []string `protobuf:"bytes,5,rep,name=targets,proto3" json:"targets,omitempty"`
+	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
 }
 
-func (x *PlanRequest) Reset() {
-	*x = PlanRequest{}
+func (x *CheckPlanFreshnessRequest) Reset() {
+	*x = CheckPlanFreshnessRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[21]
+		mi := &file_runner_runner_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PlanRequest) String() string {
+func (x *CheckPlanFreshnessRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlanRequest) ProtoMessage() {}
+func (*CheckPlanFreshnessRequest) ProtoMessage() {}
 
-func (x *PlanRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[21]
+func (x *CheckPlanFreshnessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1158,74 +1308,46 @@ func (x *PlanRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlanRequest.ProtoReflect.Descriptor instead.
-func (*PlanRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use CheckPlanFreshnessRequest.ProtoReflect.Descriptor instead.
+func (*CheckPlanFreshnessRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *PlanRequest) GetTfInstance() string {
+func (x *CheckPlanFreshnessRequest) GetTfInstance() string {
 	if x != nil {
 		return x.TfInstance
 	}
 	return ""
 }
 
-func (x *PlanRequest) GetOut() string {
-	if x != nil {
-		return x.Out
-	}
-	return ""
-}
-
-func (x *PlanRequest) GetRefresh() bool {
-	if x != nil {
-		return x.Refresh
-	}
-	return false
-}
-
-func (x *PlanRequest) GetDestroy() bool {
-	if x != nil {
-		return x.Destroy
-	}
-	return false
-}
-
-func (x *PlanRequest) GetTargets() []string {
-	if x != nil {
-		return x.Targets
-	}
-	return nil
-}
-
-type PlanReply struct {
+type CheckPlanFreshnessReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Drifted             bool   `protobuf:"varint,1,opt,name=drifted,proto3" json:"drifted,omitempty"`
-	Message             string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	StateLockIdentifier string `protobuf:"bytes,3,opt,name=stateLockIdentifier,proto3" json:"stateLockIdentifier,omitempty"`
-	PlanCreated         bool   `protobuf:"varint,4,opt,name=planCreated,proto3" json:"planCreated,omitempty"`
+	Stale          bool  `protobuf:"varint,1,opt,name=stale,proto3" json:"stale,omitempty"`
+	PlanSerial     int64 `protobuf:"varint,2,opt,name=planSerial,proto3" json:"planSerial,omitempty"`
+	StateSerial    int64 `protobuf:"varint,3,opt,name=stateSerial,proto3" json:"stateSerial,omitempty"`
+	LineageChanged bool  `protobuf:"varint,4,opt,name=lineageChanged,proto3" json:"lineageChanged,omitempty"`
 }
 
-func (x *PlanReply) Reset() {
-	*x = PlanReply{}
+func (x *CheckPlanFreshnessReply) Reset() {
+	*x = CheckPlanFreshnessReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[22]
+		mi := &file_runner_runner_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PlanReply) String() string {
+func (x *CheckPlanFreshnessReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlanReply) ProtoMessage() {}
+func (*CheckPlanFreshnessReply) ProtoMessage() {}
 
-func (x *PlanReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[22]
+func (x *CheckPlanFreshnessReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1236,65 +1358,66 @@ func (x *PlanReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlanReply.ProtoReflect.Descriptor instead.
-func (*PlanReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use CheckPlanFreshnessReply.ProtoReflect.Descriptor instead.
+func (*CheckPlanFreshnessReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *PlanReply) GetDrifted() bool {
+func (x *CheckPlanFreshnessReply) GetStale() bool {
 	if x != nil {
-		return x.Drifted
+		return x.Stale
 	}
 	return false
 }
 
-func (x *PlanReply) GetMessage() string {
+func (x *CheckPlanFreshnessReply) GetPlanSerial() int64 {
 	if x != nil {
-		return x.Message
+		return x.PlanSerial
 	}
-	return ""
+	return 0
 }
 
-func (x *PlanReply) GetStateLockIdentifier() string {
+func (x *CheckPlanFreshnessReply) GetStateSerial() int64 {
 	if x != nil {
-		return x.StateLockIdentifier
+		return x.StateSerial
 	}
-	return ""
+	return 0
 }
 
-func (x *PlanReply) GetPlanCreated() bool {
+func (x *CheckPlanFreshnessReply) GetLineageChanged() bool {
 	if x != nil {
-		return x.PlanCreated
+		return x.LineageChanged
 	}
 	return false
 }
 
-type ShowPlanFileRequest struct {
+type FetchRegistryModuleRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
-	Filename   string `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	Source     string `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Version    string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
 }
 
-func (x *ShowPlanFileRequest) Reset() {
-	*x = ShowPlanFileRequest{}
+func (x *FetchRegistryModuleRequest) Reset() {
+	*x = FetchRegistryModuleRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[23]
+		mi := &file_runner_runner_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ShowPlanFileRequest) String() string {
+func (x *FetchRegistryModuleRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShowPlanFileRequest) ProtoMessage() {}
+func (*FetchRegistryModuleRequest) ProtoMessage() {}
 
-func (x *ShowPlanFileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[23]
+func (x *FetchRegistryModuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1305,50 +1428,57 @@ func (x *ShowPlanFileRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShowPlanFileRequest.ProtoReflect.Descriptor instead.
-func (*ShowPlanFileRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use FetchRegistryModuleRequest.ProtoReflect.Descriptor instead.
+func (*FetchRegistryModuleRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *ShowPlanFileRequest) GetTfInstance() string {
+func (x *FetchRegistryModuleRequest) GetTfInstance() string {
 	if x != nil {
 		return x.TfInstance
 	}
 	return ""
 }
 
-func (x *ShowPlanFileRequest) GetFilename() string {
+func (x *FetchRegistryModuleRequest) GetSource() string {
 	if x != nil {
-		return x.Filename
+		return x.Source
 	}
 	return ""
 }
 
-type ShowPlanFileReply struct {
+func (x *FetchRegistryModuleRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type FetchRegistryModuleReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	JsonOutput []byte `protobuf:"bytes,1,opt,name=jsonOutput,proto3" json:"jsonOutput,omitempty"`
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
 }
 
-func (x *ShowPlanFileReply) Reset() {
-	*x = ShowPlanFileReply{}
+func (x *FetchRegistryModuleReply) Reset() {
+	*x = FetchRegistryModuleReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[24]
+		mi := &file_runner_runner_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ShowPlanFileReply) String() string {
+func (x *FetchRegistryModuleReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShowPlanFileReply) ProtoMessage() {}
+func (*FetchRegistryModuleReply) ProtoMessage() {}
 
-func (x *ShowPlanFileReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[24]
+func (x *FetchRegistryModuleReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1359,44 +1489,41 @@ func (x *ShowPlanFileReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShowPlanFileReply.ProtoReflect.Descriptor instead.
-func (*ShowPlanFileReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use FetchRegistryModuleReply.ProtoReflect.Descriptor instead.
+func (*FetchRegistryModuleReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *ShowPlanFileReply) GetJsonOutput() []byte {
+func (x *FetchRegistryModuleReply) GetVersion() string {
 	if x != nil {
-		return x.JsonOutput
+		return x.Version
 	}
-	return nil
+	return ""
 }
 
-type ShowPlanFileRawRequest struct {
+type GetResourceUsageRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
-	Filename   string `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
 }
 
-func (x *ShowPlanFileRawRequest) Reset() {
-	*x = ShowPlanFileRawRequest{}
+func (x *GetResourceUsageRequest) Reset() {
+	*x = GetResourceUsageRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[25]
+		mi := &file_runner_runner_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ShowPlanFileRawRequest) String() string {
+func (x *GetResourceUsageRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShowPlanFileRawRequest) ProtoMessage() {}
+func (*GetResourceUsageRequest) ProtoMessage() {}
 
-func (x *ShowPlanFileRawRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[25]
+func (x *GetResourceUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1407,50 +1534,37 @@ func (x *ShowPlanFileRawRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShowPlanFileRawRequest.ProtoReflect.Descriptor instead.
-func (*ShowPlanFileRawRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{25}
-}
-
-func (x *ShowPlanFileRawRequest) GetTfInstance() string {
-	if x != nil {
-		return x.TfInstance
-	}
-	return ""
-}
-
-func (x *ShowPlanFileRawRequest) GetFilename() string {
-	if x != nil {
-		return x.Filename
-	}
-	return ""
+// Deprecated: Use GetResourceUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetResourceUsageRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{27}
 }
 
-type ShowPlanFileRawReply struct {
+type GetResourceUsageReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	RawOutput string `protobuf:"bytes,1,opt,name=rawOutput,proto3" json:"rawOutput,omitempty"`
+	CpuSeconds      float64 `protobuf:"fixed64,1,opt,name=cpuSeconds,proto3" json:"cpuSeconds,omitempty"`
+	PeakMemoryBytes int64   `protobuf:"varint,2,opt,name=peakMemoryBytes,proto3" json:"peakMemoryBytes,omitempty"`
 }
 
-func (x *ShowPlanFileRawReply) Reset() {
-	*x = ShowPlanFileRawReply{}
+func (x *GetResourceUsageReply) Reset() {
+	*x = GetResourceUsageReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[26]
+		mi := &file_runner_runner_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ShowPlanFileRawReply) String() string {
+func (x *GetResourceUsageReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShowPlanFileRawReply) ProtoMessage() {}
+func (*GetResourceUsageReply) ProtoMessage() {}
 
-func (x *ShowPlanFileRawReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[26]
+func (x *GetResourceUsageReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1461,48 +1575,50 @@ func (x *ShowPlanFileRawReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShowPlanFileRawReply.ProtoReflect.Descriptor instead.
-func (*ShowPlanFileRawReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use GetResourceUsageReply.ProtoReflect.Descriptor instead.
+func (*GetResourceUsageReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *ShowPlanFileRawReply) GetRawOutput() string {
+func (x *GetResourceUsageReply) GetCpuSeconds() float64 {
 	if x != nil {
-		return x.RawOutput
+		return x.CpuSeconds
 	}
-	return ""
+	return 0
 }
 
-type SaveTFPlanRequest struct {
+func (x *GetResourceUsageReply) GetPeakMemoryBytes() int64 {
+	if x != nil {
+		return x.PeakMemoryBytes
+	}
+	return 0
+}
+
+type WatchProgressRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TfInstance               string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
-	BackendCompletelyDisable bool   `protobuf:"varint,2,opt,name=backendCompletelyDisable,proto3" json:"backendCompletelyDisable,omitempty"`
-	Name                     string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Namespace                string `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	Uuid                     string `protobuf:"bytes,5,opt,name=uuid,proto3" json:"uuid,omitempty"`
-	Revision                 string `protobuf:"bytes,6,opt,name=revision,proto3" json:"revision,omitempty"`
+	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
 }
 
-func (x *SaveTFPlanRequest) Reset() {
-	*x = SaveTFPlanRequest{}
+func (x *WatchProgressRequest) Reset() {
+	*x = WatchProgressRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[27]
+		mi := &file_runner_runner_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SaveTFPlanRequest) String() string {
+func (x *WatchProgressRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SaveTFPlanRequest) ProtoMessage() {}
+func (*WatchProgressRequest) ProtoMessage() {}
 
-func (x *SaveTFPlanRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[27]
+func (x *WatchProgressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1513,78 +1629,125 @@ func (x *SaveTFPlanRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SaveTFPlanRequest.ProtoReflect.Descriptor instead.
-func (*SaveTFPlanRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use WatchProgressRequest.ProtoReflect.Descriptor instead.
+func (*WatchProgressRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *SaveTFPlanRequest) GetTfInstance() string {
+func (x *WatchProgressRequest) GetTfInstance() string {
 	if x != nil {
 		return x.TfInstance
 	}
 	return ""
 }
 
-func (x *SaveTFPlanRequest) GetBackendCompletelyDisable() bool {
-	if x != nil {
-		return x.BackendCompletelyDisable
+type ProgressReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Operation string   `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	Total     int32    `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Completed int32    `protobuf:"varint,3,opt,name=completed,proto3" json:"completed,omitempty"`
+	Current   []string `protobuf:"bytes,4,rep,name=current,proto3" json:"current,omitempty"`
+	Done      bool     `protobuf:"varint,5,opt,name=done,proto3" json:"done,omitempty"`
+}
+
+func (x *ProgressReply) Reset() {
+	*x = ProgressReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return false
 }
 
-func (x *SaveTFPlanRequest) GetName() string {
+func (x *ProgressReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProgressReply) ProtoMessage() {}
+
+func (x *ProgressReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProgressReply.ProtoReflect.Descriptor instead.
+func (*ProgressReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ProgressReply) GetOperation() string {
 	if x != nil {
-		return x.Name
+		return x.Operation
 	}
 	return ""
 }
 
-func (x *SaveTFPlanRequest) GetNamespace() string {
+func (x *ProgressReply) GetTotal() int32 {
 	if x != nil {
-		return x.Namespace
+		return x.Total
 	}
-	return ""
+	return 0
 }
 
-func (x *SaveTFPlanRequest) GetUuid() string {
+func (x *ProgressReply) GetCompleted() int32 {
 	if x != nil {
-		return x.Uuid
+		return x.Completed
 	}
-	return ""
+	return 0
 }
 
-func (x *SaveTFPlanRequest) GetRevision() string {
+func (x *ProgressReply) GetCurrent() []string {
 	if x != nil {
-		return x.Revision
+		return x.Current
 	}
-	return ""
+	return nil
 }
 
-type SaveTFPlanReply struct {
+func (x *ProgressReply) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+type GenerateVarsForTFRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	WorkingDir string `protobuf:"bytes,1,opt,name=workingDir,proto3" json:"workingDir,omitempty"`
+	// execVars tells that the varsFrom of the Terraform object include the
+	// Exec kind, which the older runners drop.
+	ExecVars bool `protobuf:"varint,2,opt,name=execVars,proto3" json:"execVars,omitempty"`
 }
 
-func (x *SaveTFPlanReply) Reset() {
-	*x = SaveTFPlanReply{}
+func (x *GenerateVarsForTFRequest) Reset() {
+	*x = GenerateVarsForTFRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[28]
+		mi := &file_runner_runner_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SaveTFPlanReply) String() string {
+func (x *GenerateVarsForTFRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SaveTFPlanReply) ProtoMessage() {}
+func (*GenerateVarsForTFRequest) ProtoMessage() {}
 
-func (x *SaveTFPlanReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[28]
+func (x *GenerateVarsForTFRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1595,47 +1758,50 @@ func (x *SaveTFPlanReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SaveTFPlanReply.ProtoReflect.Descriptor instead.
-func (*SaveTFPlanReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use GenerateVarsForTFRequest.ProtoReflect.Descriptor instead.
+func (*GenerateVarsForTFRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *SaveTFPlanReply) GetMessage() string {
+func (x *GenerateVarsForTFRequest) GetWorkingDir() string {
 	if x != nil {
-		return x.Message
+		return x.WorkingDir
 	}
 	return ""
 }
 
-type LoadTFPlanRequest struct {
+func (x *GenerateVarsForTFRequest) GetExecVars() bool {
+	if x != nil {
+		return x.ExecVars
+	}
+	return false
+}
+
+type GenerateVarsForTFReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TfInstance               string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
-	BackendCompletelyDisable bool   `protobuf:"varint,2,opt,name=backendCompletelyDisable,proto3" json:"backendCompletelyDisable,omitempty"`
-	Name                     string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Namespace                string `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	PendingPlan              string `protobuf:"bytes,5,opt,name=pendingPlan,proto3" json:"pendingPlan,omitempty"`
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 }
 
-func (x *LoadTFPlanRequest) Reset() {
-	*x = LoadTFPlanRequest{}
+func (x *GenerateVarsForTFReply) Reset() {
+	*x = GenerateVarsForTFReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[29]
+		mi := &file_runner_runner_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *LoadTFPlanRequest) String() string {
+func (x *GenerateVarsForTFReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoadTFPlanRequest) ProtoMessage() {}
+func (*GenerateVarsForTFReply) ProtoMessage() {}
 
-func (x *LoadTFPlanRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[29]
+func (x *GenerateVarsForTFReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1646,47 +1812,66 @@ func (x *LoadTFPlanRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoadTFPlanRequest.ProtoReflect.Descriptor instead.
-func (*LoadTFPlanRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use GenerateVarsForTFReply.ProtoReflect.Descriptor instead.
+func (*GenerateVarsForTFReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *LoadTFPlanRequest) GetTfInstance() string {
+func (x *GenerateVarsForTFReply) GetMessage() string {
 	if x != nil {
-		return x.TfInstance
+		return x.Message
 	}
 	return ""
 }
 
-func (x *LoadTFPlanRequest) GetBackendCompletelyDisable() bool {
-	if x != nil {
-		return x.BackendCompletelyDisable
-	}
-	return false
+type GenerateTemplateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	WorkingDir string `protobuf:"bytes,1,opt,name=workingDir,proto3" json:"workingDir,omitempty"`
 }
 
-func (x *LoadTFPlanRequest) GetName() string {
-	if x != nil {
-		return x.Name
+func (x *GenerateTemplateRequest) Reset() {
+	*x = GenerateTemplateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (x *LoadTFPlanRequest) GetNamespace() string {
-	if x != nil {
-		return x.Namespace
+func (x *GenerateTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateTemplateRequest) ProtoMessage() {}
+
+func (x *GenerateTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *LoadTFPlanRequest) GetPendingPlan() string {
+// Deprecated: Use GenerateTemplateRequest.ProtoReflect.Descriptor instead.
+func (*GenerateTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GenerateTemplateRequest) GetWorkingDir() string {
 	if x != nil {
-		return x.PendingPlan
+		return x.WorkingDir
 	}
 	return ""
 }
 
-type LoadTFPlanReply struct {
+type GenerateTemplateReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -1694,23 +1879,23 @@ type LoadTFPlanReply struct {
 	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 }
 
-func (x *LoadTFPlanReply) Reset() {
-	*x = LoadTFPlanReply{}
+func (x *GenerateTemplateReply) Reset() {
+	*x = GenerateTemplateReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[30]
+		mi := &file_runner_runner_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *LoadTFPlanReply) String() string {
+func (x *GenerateTemplateReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoadTFPlanReply) ProtoMessage() {}
+func (*GenerateTemplateReply) ProtoMessage() {}
 
-func (x *LoadTFPlanReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[30]
+func (x *GenerateTemplateReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1721,47 +1906,48 @@ func (x *LoadTFPlanReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoadTFPlanReply.ProtoReflect.Descriptor instead.
-func (*LoadTFPlanReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use GenerateTemplateReply.ProtoReflect.Descriptor instead.
+func (*GenerateTemplateReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *LoadTFPlanReply) GetMessage() string {
+func (x *GenerateTemplateReply) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-type ApplyRequest struct {
+type PlanRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TfInstance         string   `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
-	DirOrPlan          string   `protobuf:"bytes,2,opt,name=dirOrPlan,proto3" json:"dirOrPlan,omitempty"`
-	RefreshBeforeApply bool     `protobuf:"varint,3,opt,name=refreshBeforeApply,proto3" json:"refreshBeforeApply,omitempty"`
-	Targets            []string `protobuf:"bytes,4,rep,name=targets,proto3" json:"targets,omitempty"`
-	Parallelism        int32    `protobuf:"varint,5,opt,name=parallelism,proto3" json:"parallelism,omitempty"`
+	TfInstance  string   `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	Out         string   `protobuf:"bytes,2,opt,name=out,proto3" json:"out,omitempty"`
+	Refresh     bool     `protobuf:"varint,3,opt,name=refresh,proto3" json:"refresh,omitempty"`
+	Destroy     bool     `protobuf:"varint,4,opt,name=destroy,proto3" json:"destroy,omitempty"`
+	Targets     []string `protobuf:"bytes,5,rep,name=targets,proto3" json:"targets,omitempty"`
+	DisableLock bool     `protobuf:"varint,6,opt,name=disableLock,proto3" json:"disableLock,omitempty"`
 }
 
-func (x *ApplyRequest) Reset() {
-	*x = ApplyRequest{}
+func (x *PlanRequest) Reset() {
+	*x = PlanRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[31]
+		mi := &file_runner_runner_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ApplyRequest) String() string {
+func (x *PlanRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ApplyRequest) ProtoMessage() {}
+func (*PlanRequest) ProtoMessage() {}
 
-func (x *ApplyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[31]
+func (x *PlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1772,72 +1958,90 @@ func (x *ApplyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ApplyRequest.ProtoReflect.Descriptor instead.
-func (*ApplyRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use PlanRequest.ProtoReflect.Descriptor instead.
+func (*PlanRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *ApplyRequest) GetTfInstance() string {
+func (x *PlanRequest) GetTfInstance() string {
 	if x != nil {
 		return x.TfInstance
 	}
 	return ""
 }
 
-func (x *ApplyRequest) GetDirOrPlan() string {
+func (x *PlanRequest) GetOut() string {
 	if x != nil {
-		return x.DirOrPlan
+		return x.Out
 	}
 	return ""
 }
 
-func (x *ApplyRequest) GetRefreshBeforeApply() bool {
+func (x *PlanRequest) GetRefresh() bool {
 	if x != nil {
-		return x.RefreshBeforeApply
+		return x.Refresh
 	}
 	return false
 }
 
-func (x *ApplyRequest) GetTargets() []string {
+func (x *PlanRequest) GetDestroy() bool {
+	if x != nil {
+		return x.Destroy
+	}
+	return false
+}
+
+func (x *PlanRequest) GetTargets() []string {
 	if x != nil {
 		return x.Targets
 	}
 	return nil
 }
 
-func (x *ApplyRequest) GetParallelism() int32 {
+func (x *PlanRequest) GetDisableLock() bool {
 	if x != nil {
-		return x.Parallelism
+		return x.DisableLock
 	}
-	return 0
+	return false
 }
 
-type ApplyReply struct {
+type PlanReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message             string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	StateLockIdentifier string `protobuf:"bytes,2,opt,name=stateLockIdentifier,proto3" json:"stateLockIdentifier,omitempty"`
+	Drifted                  bool                       `protobuf:"varint,1,opt,name=drifted,proto3" json:"drifted,omitempty"`
+	Message                  string                     `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	StateLockIdentifier      string                     `protobuf:"bytes,3,opt,name=stateLockIdentifier,proto3" json:"stateLockIdentifier,omitempty"`
+	PlanCreated              bool                       `protobuf:"varint,4,opt,name=planCreated,proto3" json:"planCreated,omitempty"`
+	ToAdd                    int32                      `protobuf:"varint,5,opt,name=toAdd,proto3" json:"toAdd,omitempty"`
+	ToChange                 int32                      `protobuf:"varint,6,opt,name=toChange,proto3" json:"toChange,omitempty"`
+	ToDestroy                int32                      `protobuf:"varint,7,opt,name=toDestroy,proto3" json:"toDestroy,omitempty"`
+	VariableValidationErrors []*VariableValidationError `protobuf:"bytes,8,rep,name=variableValidationErrors,proto3" json:"variableValidationErrors,omitempty"`
+	Diagnostics              []*Diagnostic              `protobuf:"bytes,9,rep,name=diagnostics,proto3" json:"diagnostics,omitempty"`
+	// suspectedMoves are the resources the plan destroys and creates again,
+	// with the same attributes, at another address, which a moved block
+	// would rather move.
+	SuspectedMoves []*ResourceMove `protobuf:"bytes,10,rep,name=suspectedMoves,proto3" json:"suspectedMoves,omitempty"`
 }
 
-func (x *ApplyReply) Reset() {
-	*x = ApplyReply{}
+func (x *PlanReply) Reset() {
+	*x = PlanReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[32]
+		mi := &file_runner_runner_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ApplyReply) String() string {
+func (x *PlanReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ApplyReply) ProtoMessage() {}
+func (*PlanReply) ProtoMessage() {}
 
-func (x *ApplyReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[32]
+func (x *PlanReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1848,50 +2052,107 @@ func (x *ApplyReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ApplyReply.ProtoReflect.Descriptor instead.
-func (*ApplyReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{32}
+// Deprecated: Use PlanReply.ProtoReflect.Descriptor instead.
+func (*PlanReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *ApplyReply) GetMessage() string {
+func (x *PlanReply) GetDrifted() bool {
+	if x != nil {
+		return x.Drifted
+	}
+	return false
+}
+
+func (x *PlanReply) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *ApplyReply) GetStateLockIdentifier() string {
+func (x *PlanReply) GetStateLockIdentifier() string {
 	if x != nil {
 		return x.StateLockIdentifier
 	}
 	return ""
 }
 
-type GetInventoryRequest struct {
+func (x *PlanReply) GetPlanCreated() bool {
+	if x != nil {
+		return x.PlanCreated
+	}
+	return false
+}
+
+func (x *PlanReply) GetToAdd() int32 {
+	if x != nil {
+		return x.ToAdd
+	}
+	return 0
+}
+
+func (x *PlanReply) GetToChange() int32 {
+	if x != nil {
+		return x.ToChange
+	}
+	return 0
+}
+
+func (x *PlanReply) GetToDestroy() int32 {
+	if x != nil {
+		return x.ToDestroy
+	}
+	return 0
+}
+
+func (x *PlanReply) GetVariableValidationErrors() []*VariableValidationError {
+	if x != nil {
+		return x.VariableValidationErrors
+	}
+	return nil
+}
+
+func (x *PlanReply) GetDiagnostics() []*Diagnostic {
+	if x != nil {
+		return x.Diagnostics
+	}
+	return nil
+}
+
+func (x *PlanReply) GetSuspectedMoves() []*ResourceMove {
+	if x != nil {
+		return x.SuspectedMoves
+	}
+	return nil
+}
+
+type ResourceMove struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
 }
 
-func (x *GetInventoryRequest) Reset() {
-	*x = GetInventoryRequest{}
+func (x *ResourceMove) Reset() {
+	*x = ResourceMove{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[33]
+		mi := &file_runner_runner_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetInventoryRequest) String() string {
+func (x *ResourceMove) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetInventoryRequest) ProtoMessage() {}
+func (*ResourceMove) ProtoMessage() {}
 
-func (x *GetInventoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[33]
+func (x *ResourceMove) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1902,43 +2163,51 @@ func (x *GetInventoryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetInventoryRequest.ProtoReflect.Descriptor instead.
-func (*GetInventoryRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use ResourceMove.ProtoReflect.Descriptor instead.
+func (*ResourceMove) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *GetInventoryRequest) GetTfInstance() string {
+func (x *ResourceMove) GetFrom() string {
 	if x != nil {
-		return x.TfInstance
+		return x.From
 	}
 	return ""
 }
 
-type GetInventoryReply struct {
+func (x *ResourceMove) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type VariableValidationError struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Inventories []*Inventory `protobuf:"bytes,1,rep,name=inventories,proto3" json:"inventories,omitempty"`
+	Variable string `protobuf:"bytes,1,opt,name=variable,proto3" json:"variable,omitempty"`
+	Message  string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 }
 
-func (x *GetInventoryReply) Reset() {
-	*x = GetInventoryReply{}
+func (x *VariableValidationError) Reset() {
+	*x = VariableValidationError{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[34]
+		mi := &file_runner_runner_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetInventoryReply) String() string {
+func (x *VariableValidationError) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetInventoryReply) ProtoMessage() {}
+func (*VariableValidationError) ProtoMessage() {}
 
-func (x *GetInventoryReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[34]
+func (x *VariableValidationError) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1949,45 +2218,58 @@ func (x *GetInventoryReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetInventoryReply.ProtoReflect.Descriptor instead.
-func (*GetInventoryReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{34}
+// Deprecated: Use VariableValidationError.ProtoReflect.Descriptor instead.
+func (*VariableValidationError) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *GetInventoryReply) GetInventories() []*Inventory {
+func (x *VariableValidationError) GetVariable() string {
 	if x != nil {
-		return x.Inventories
+		return x.Variable
 	}
-	return nil
+	return ""
 }
 
-type Inventory struct {
+func (x *VariableValidationError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Diagnostic is an error or a warning of terraform, with its location in
+// the configuration when it has one.
+type Diagnostic struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name       string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Type       string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
-	Identifier string `protobuf:"bytes,3,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	Severity string `protobuf:"bytes,1,opt,name=severity,proto3" json:"severity,omitempty"`
+	Summary  string `protobuf:"bytes,2,opt,name=summary,proto3" json:"summary,omitempty"`
+	Detail   string `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"`
+	// filename is relative to the working directory of terraform.
+	Filename string `protobuf:"bytes,4,opt,name=filename,proto3" json:"filename,omitempty"`
+	Line     int32  `protobuf:"varint,5,opt,name=line,proto3" json:"line,omitempty"`
+	Column   int32  `protobuf:"varint,6,opt,name=column,proto3" json:"column,omitempty"`
 }
 
-func (x *Inventory) Reset() {
-	*x = Inventory{}
+func (x *Diagnostic) Reset() {
+	*x = Diagnostic{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[35]
+		mi := &file_runner_runner_proto_msgTypes[39]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Inventory) String() string {
+func (x *Diagnostic) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Inventory) ProtoMessage() {}
+func (*Diagnostic) ProtoMessage() {}
 
-func (x *Inventory) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[35]
+func (x *Diagnostic) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[39]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1998,58 +2280,79 @@ func (x *Inventory) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Inventory.ProtoReflect.Descriptor instead.
-func (*Inventory) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{35}
+// Deprecated: Use Diagnostic.ProtoReflect.Descriptor instead.
+func (*Diagnostic) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *Inventory) GetName() string {
+func (x *Diagnostic) GetSeverity() string {
 	if x != nil {
-		return x.Name
+		return x.Severity
 	}
 	return ""
 }
 
-func (x *Inventory) GetType() string {
+func (x *Diagnostic) GetSummary() string {
 	if x != nil {
-		return x.Type
+		return x.Summary
 	}
 	return ""
 }
 
-func (x *Inventory) GetIdentifier() string {
+func (x *Diagnostic) GetDetail() string {
 	if x != nil {
-		return x.Identifier
+		return x.Detail
 	}
 	return ""
 }
 
-type DestroyRequest struct {
+func (x *Diagnostic) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *Diagnostic) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *Diagnostic) GetColumn() int32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+type ShowPlanFileRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TfInstance string   `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
-	Targets    []string `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets,omitempty"`
+	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	Filename   string `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
 }
 
-func (x *DestroyRequest) Reset() {
-	*x = DestroyRequest{}
+func (x *ShowPlanFileRequest) Reset() {
+	*x = ShowPlanFileRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[36]
+		mi := &file_runner_runner_proto_msgTypes[40]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DestroyRequest) String() string {
+func (x *ShowPlanFileRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DestroyRequest) ProtoMessage() {}
+func (*ShowPlanFileRequest) ProtoMessage() {}
 
-func (x *DestroyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[36]
+func (x *ShowPlanFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[40]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2060,51 +2363,50 @@ func (x *DestroyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DestroyRequest.ProtoReflect.Descriptor instead.
-func (*DestroyRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{36}
+// Deprecated: Use ShowPlanFileRequest.ProtoReflect.Descriptor instead.
+func (*ShowPlanFileRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *DestroyRequest) GetTfInstance() string {
+func (x *ShowPlanFileRequest) GetTfInstance() string {
 	if x != nil {
 		return x.TfInstance
 	}
 	return ""
 }
 
-func (x *DestroyRequest) GetTargets() []string {
+func (x *ShowPlanFileRequest) GetFilename() string {
 	if x != nil {
-		return x.Targets
+		return x.Filename
 	}
-	return nil
+	return ""
 }
 
-type DestroyReply struct {
+type ShowPlanFileReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message             string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	StateLockIdentifier string `protobuf:"bytes,2,opt,name=stateLockIdentifier,proto3" json:"stateLockIdentifier,omitempty"`
+	JsonOutput []byte `protobuf:"bytes,1,opt,name=jsonOutput,proto3" json:"jsonOutput,omitempty"`
 }
 
-func (x *DestroyReply) Reset() {
-	*x = DestroyReply{}
+func (x *ShowPlanFileReply) Reset() {
+	*x = ShowPlanFileReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[37]
+		mi := &file_runner_runner_proto_msgTypes[41]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DestroyReply) String() string {
+func (x *ShowPlanFileReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DestroyReply) ProtoMessage() {}
+func (*ShowPlanFileReply) ProtoMessage() {}
 
-func (x *DestroyReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[37]
+func (x *ShowPlanFileReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[41]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2115,50 +2417,44 @@ func (x *DestroyReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DestroyReply.ProtoReflect.Descriptor instead.
-func (*DestroyReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{37}
-}
-
-func (x *DestroyReply) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
+// Deprecated: Use ShowPlanFileReply.ProtoReflect.Descriptor instead.
+func (*ShowPlanFileReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *DestroyReply) GetStateLockIdentifier() string {
+func (x *ShowPlanFileReply) GetJsonOutput() []byte {
 	if x != nil {
-		return x.StateLockIdentifier
+		return x.JsonOutput
 	}
-	return ""
+	return nil
 }
 
-type OutputRequest struct {
+type ShowPlanFileRawRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	Filename   string `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
 }
 
-func (x *OutputRequest) Reset() {
-	*x = OutputRequest{}
+func (x *ShowPlanFileRawRequest) Reset() {
+	*x = ShowPlanFileRawRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[38]
+		mi := &file_runner_runner_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *OutputRequest) String() string {
+func (x *ShowPlanFileRawRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OutputRequest) ProtoMessage() {}
+func (*ShowPlanFileRawRequest) ProtoMessage() {}
 
-func (x *OutputRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[38]
+func (x *ShowPlanFileRawRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2169,92 +2465,50 @@ func (x *OutputRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OutputRequest.ProtoReflect.Descriptor instead.
-func (*OutputRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{38}
+// Deprecated: Use ShowPlanFileRawRequest.ProtoReflect.Descriptor instead.
+func (*ShowPlanFileRawRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *OutputRequest) GetTfInstance() string {
+func (x *ShowPlanFileRawRequest) GetTfInstance() string {
 	if x != nil {
 		return x.TfInstance
 	}
 	return ""
 }
 
-type OutputReply struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Outputs map[string]*OutputMeta `protobuf:"bytes,1,rep,name=outputs,proto3" json:"outputs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-}
-
-func (x *OutputReply) Reset() {
-	*x = OutputReply{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[39]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *OutputReply) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*OutputReply) ProtoMessage() {}
-
-func (x *OutputReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[39]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use OutputReply.ProtoReflect.Descriptor instead.
-func (*OutputReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{39}
-}
-
-func (x *OutputReply) GetOutputs() map[string]*OutputMeta {
+func (x *ShowPlanFileRawRequest) GetFilename() string {
 	if x != nil {
-		return x.Outputs
+		return x.Filename
 	}
-	return nil
+	return ""
 }
 
-type OutputMeta struct {
+type ShowPlanFileRawReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Sensitive bool   `protobuf:"varint,1,opt,name=sensitive,proto3" json:"sensitive,omitempty"`
-	Type      []byte `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
-	Value     []byte `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	RawOutput string `protobuf:"bytes,1,opt,name=rawOutput,proto3" json:"rawOutput,omitempty"`
 }
 
-func (x *OutputMeta) Reset() {
-	*x = OutputMeta{}
+func (x *ShowPlanFileRawReply) Reset() {
+	*x = ShowPlanFileRawReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[40]
+		mi := &file_runner_runner_proto_msgTypes[43]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *OutputMeta) String() string {
+func (x *ShowPlanFileRawReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OutputMeta) ProtoMessage() {}
+func (*ShowPlanFileRawReply) ProtoMessage() {}
 
-func (x *OutputMeta) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[40]
+func (x *ShowPlanFileRawReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[43]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2265,63 +2519,48 @@ func (x *OutputMeta) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OutputMeta.ProtoReflect.Descriptor instead.
-func (*OutputMeta) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{40}
-}
-
-func (x *OutputMeta) GetSensitive() bool {
-	if x != nil {
-		return x.Sensitive
-	}
-	return false
-}
-
-func (x *OutputMeta) GetType() []byte {
-	if x != nil {
-		return x.Type
-	}
-	return nil
+// Deprecated: Use ShowPlanFileRawReply.ProtoReflect.Descriptor instead.
+func (*ShowPlanFileRawReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *OutputMeta) GetValue() []byte {
+func (x *ShowPlanFileRawReply) GetRawOutput() string {
 	if x != nil {
-		return x.Value
+		return x.RawOutput
 	}
-	return nil
+	return ""
 }
 
-type WriteOutputsRequest struct {
+type SaveTFPlanRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Namespace   string            `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	Name        string            `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	SecretName  string            `protobuf:"bytes,3,opt,name=secretName,proto3" json:"secretName,omitempty"`
-	Uuid        string            `protobuf:"bytes,4,opt,name=uuid,proto3" json:"uuid,omitempty"`
-	Data        map[string][]byte `protobuf:"bytes,5,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Labels      map[string]string `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Annotations map[string]string `protobuf:"bytes,7,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	TfInstance               string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	BackendCompletelyDisable bool   `protobuf:"varint,2,opt,name=backendCompletelyDisable,proto3" json:"backendCompletelyDisable,omitempty"`
+	Name                     string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace                string `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Uuid                     string `protobuf:"bytes,5,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Revision                 string `protobuf:"bytes,6,opt,name=revision,proto3" json:"revision,omitempty"`
 }
 
-func (x *WriteOutputsRequest) Reset() {
-	*x = WriteOutputsRequest{}
+func (x *SaveTFPlanRequest) Reset() {
+	*x = SaveTFPlanRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[41]
+		mi := &file_runner_runner_proto_msgTypes[44]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WriteOutputsRequest) String() string {
+func (x *SaveTFPlanRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WriteOutputsRequest) ProtoMessage() {}
+func (*SaveTFPlanRequest) ProtoMessage() {}
 
-func (x *WriteOutputsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[41]
+func (x *SaveTFPlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[44]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2332,86 +2571,81 @@ func (x *WriteOutputsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WriteOutputsRequest.ProtoReflect.Descriptor instead.
-func (*WriteOutputsRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{41}
+// Deprecated: Use SaveTFPlanRequest.ProtoReflect.Descriptor instead.
+func (*SaveTFPlanRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *WriteOutputsRequest) GetNamespace() string {
+func (x *SaveTFPlanRequest) GetTfInstance() string {
 	if x != nil {
-		return x.Namespace
+		return x.TfInstance
 	}
 	return ""
 }
 
-func (x *WriteOutputsRequest) GetName() string {
+func (x *SaveTFPlanRequest) GetBackendCompletelyDisable() bool {
 	if x != nil {
-		return x.Name
+		return x.BackendCompletelyDisable
 	}
-	return ""
+	return false
 }
 
-func (x *WriteOutputsRequest) GetSecretName() string {
+func (x *SaveTFPlanRequest) GetName() string {
 	if x != nil {
-		return x.SecretName
+		return x.Name
 	}
 	return ""
 }
 
-func (x *WriteOutputsRequest) GetUuid() string {
+func (x *SaveTFPlanRequest) GetNamespace() string {
 	if x != nil {
-		return x.Uuid
+		return x.Namespace
 	}
 	return ""
 }
 
-func (x *WriteOutputsRequest) GetData() map[string][]byte {
-	if x != nil {
-		return x.Data
-	}
-	return nil
-}
-
-func (x *WriteOutputsRequest) GetLabels() map[string]string {
+func (x *SaveTFPlanRequest) GetUuid() string {
 	if x != nil {
-		return x.Labels
+		return x.Uuid
 	}
-	return nil
+	return ""
 }
 
-func (x *WriteOutputsRequest) GetAnnotations() map[string]string {
+func (x *SaveTFPlanRequest) GetRevision() string {
 	if x != nil {
-		return x.Annotations
+		return x.Revision
 	}
-	return nil
+	return ""
 }
 
-type WriteOutputsReply struct {
+type SaveTFPlanReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	Changed bool   `protobuf:"varint,2,opt,name=changed,proto3" json:"changed,omitempty"`
+	// planChangesError is the reason the changes of the plan could not be
+	// published, which does not fail the plan.
+	PlanChangesError string `protobuf:"bytes,2,opt,name=planChangesError,proto3" json:"planChangesError,omitempty"`
 }
 
-func (x *WriteOutputsReply) Reset() {
-	*x = WriteOutputsReply{}
+func (x *SaveTFPlanReply) Reset() {
+	*x = SaveTFPlanReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[42]
+		mi := &file_runner_runner_proto_msgTypes[45]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WriteOutputsReply) String() string {
+func (x *SaveTFPlanReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WriteOutputsReply) ProtoMessage() {}
+func (*SaveTFPlanReply) ProtoMessage() {}
 
-func (x *WriteOutputsReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[42]
+func (x *SaveTFPlanReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[45]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2422,51 +2656,54 @@ func (x *WriteOutputsReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WriteOutputsReply.ProtoReflect.Descriptor instead.
-func (*WriteOutputsReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{42}
+// Deprecated: Use SaveTFPlanReply.ProtoReflect.Descriptor instead.
+func (*SaveTFPlanReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *WriteOutputsReply) GetMessage() string {
+func (x *SaveTFPlanReply) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *WriteOutputsReply) GetChanged() bool {
+func (x *SaveTFPlanReply) GetPlanChangesError() string {
 	if x != nil {
-		return x.Changed
+		return x.PlanChangesError
 	}
-	return false
+	return ""
 }
 
-type GetOutputsRequest struct {
+type LoadTFPlanRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Namespace  string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	SecretName string `protobuf:"bytes,2,opt,name=secretName,proto3" json:"secretName,omitempty"`
+	TfInstance               string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	BackendCompletelyDisable bool   `protobuf:"varint,2,opt,name=backendCompletelyDisable,proto3" json:"backendCompletelyDisable,omitempty"`
+	Name                     string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace                string `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	PendingPlan              string `protobuf:"bytes,5,opt,name=pendingPlan,proto3" json:"pendingPlan,omitempty"`
 }
 
-func (x *GetOutputsRequest) Reset() {
-	*x = GetOutputsRequest{}
+func (x *LoadTFPlanRequest) Reset() {
+	*x = LoadTFPlanRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[43]
+		mi := &file_runner_runner_proto_msgTypes[46]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetOutputsRequest) String() string {
+func (x *LoadTFPlanRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOutputsRequest) ProtoMessage() {}
+func (*LoadTFPlanRequest) ProtoMessage() {}
 
-func (x *GetOutputsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[43]
+func (x *LoadTFPlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[46]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2477,50 +2714,71 @@ func (x *GetOutputsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOutputsRequest.ProtoReflect.Descriptor instead.
-func (*GetOutputsRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{43}
+// Deprecated: Use LoadTFPlanRequest.ProtoReflect.Descriptor instead.
+func (*LoadTFPlanRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *GetOutputsRequest) GetNamespace() string {
+func (x *LoadTFPlanRequest) GetTfInstance() string {
 	if x != nil {
-		return x.Namespace
+		return x.TfInstance
 	}
 	return ""
 }
 
-func (x *GetOutputsRequest) GetSecretName() string {
+func (x *LoadTFPlanRequest) GetBackendCompletelyDisable() bool {
 	if x != nil {
-		return x.SecretName
+		return x.BackendCompletelyDisable
 	}
-	return ""
+	return false
 }
 
-type GetOutputsReply struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Outputs map[string]string `protobuf:"bytes,1,rep,name=outputs,proto3" json:"outputs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+func (x *LoadTFPlanRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
-func (x *GetOutputsReply) Reset() {
-	*x = GetOutputsReply{}
+func (x *LoadTFPlanRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *LoadTFPlanRequest) GetPendingPlan() string {
+	if x != nil {
+		return x.PendingPlan
+	}
+	return ""
+}
+
+type LoadTFPlanReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *LoadTFPlanReply) Reset() {
+	*x = LoadTFPlanReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[44]
+		mi := &file_runner_runner_proto_msgTypes[47]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetOutputsReply) String() string {
+func (x *LoadTFPlanReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOutputsReply) ProtoMessage() {}
+func (*LoadTFPlanReply) ProtoMessage() {}
 
-func (x *GetOutputsReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[44]
+func (x *LoadTFPlanReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[47]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2531,45 +2789,48 @@ func (x *GetOutputsReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOutputsReply.ProtoReflect.Descriptor instead.
-func (*GetOutputsReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{44}
+// Deprecated: Use LoadTFPlanReply.ProtoReflect.Descriptor instead.
+func (*LoadTFPlanReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *GetOutputsReply) GetOutputs() map[string]string {
+func (x *LoadTFPlanReply) GetMessage() string {
 	if x != nil {
-		return x.Outputs
+		return x.Message
 	}
-	return nil
+	return ""
 }
 
-type InitRequest struct {
+type ApplyRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
-	Upgrade    bool   `protobuf:"varint,2,opt,name=upgrade,proto3" json:"upgrade,omitempty"`
-	ForceCopy  bool   `protobuf:"varint,3,opt,name=forceCopy,proto3" json:"forceCopy,omitempty"`
+	TfInstance         string   `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	DirOrPlan          string   `protobuf:"bytes,2,opt,name=dirOrPlan,proto3" json:"dirOrPlan,omitempty"`
+	RefreshBeforeApply bool     `protobuf:"varint,3,opt,name=refreshBeforeApply,proto3" json:"refreshBeforeApply,omitempty"`
+	Targets            []string `protobuf:"bytes,4,rep,name=targets,proto3" json:"targets,omitempty"`
+	Parallelism        int32    `protobuf:"varint,5,opt,name=parallelism,proto3" json:"parallelism,omitempty"`
+	ExpectedChanges    int32    `protobuf:"varint,6,opt,name=expectedChanges,proto3" json:"expectedChanges,omitempty"`
 }
 
-func (x *InitRequest) Reset() {
-	*x = InitRequest{}
+func (x *ApplyRequest) Reset() {
+	*x = ApplyRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[45]
+		mi := &file_runner_runner_proto_msgTypes[48]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *InitRequest) String() string {
+func (x *ApplyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InitRequest) ProtoMessage() {}
+func (*ApplyRequest) ProtoMessage() {}
 
-func (x *InitRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[45]
+func (x *ApplyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[48]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2580,58 +2841,82 @@ func (x *InitRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InitRequest.ProtoReflect.Descriptor instead.
-func (*InitRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{45}
+// Deprecated: Use ApplyRequest.ProtoReflect.Descriptor instead.
+func (*ApplyRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *InitRequest) GetTfInstance() string {
+func (x *ApplyRequest) GetTfInstance() string {
 	if x != nil {
 		return x.TfInstance
 	}
 	return ""
 }
 
-func (x *InitRequest) GetUpgrade() bool {
+func (x *ApplyRequest) GetDirOrPlan() string {
 	if x != nil {
-		return x.Upgrade
+		return x.DirOrPlan
 	}
-	return false
+	return ""
 }
 
-func (x *InitRequest) GetForceCopy() bool {
+func (x *ApplyRequest) GetRefreshBeforeApply() bool {
 	if x != nil {
-		return x.ForceCopy
+		return x.RefreshBeforeApply
 	}
 	return false
 }
 
-type InitReply struct {
+func (x *ApplyRequest) GetTargets() []string {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *ApplyRequest) GetParallelism() int32 {
+	if x != nil {
+		return x.Parallelism
+	}
+	return 0
+}
+
+func (x *ApplyRequest) GetExpectedChanges() int32 {
+	if x != nil {
+		return x.ExpectedChanges
+	}
+	return 0
+}
+
+type ApplyReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message             string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	StateLockIdentifier string `protobuf:"bytes,2,opt,name=stateLockIdentifier,proto3" json:"stateLockIdentifier,omitempty"`
+	Message             string        `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	StateLockIdentifier string        `protobuf:"bytes,2,opt,name=stateLockIdentifier,proto3" json:"stateLockIdentifier,omitempty"`
+	Diagnostics         []*Diagnostic `protobuf:"bytes,3,rep,name=diagnostics,proto3" json:"diagnostics,omitempty"`
+	// resourceTimings are the times terraform took for each resource.
+	ResourceTimings []*ResourceTiming `protobuf:"bytes,4,rep,name=resourceTimings,proto3" json:"resourceTimings,omitempty"`
 }
 
-func (x *InitReply) Reset() {
-	*x = InitReply{}
+func (x *ApplyReply) Reset() {
+	*x = ApplyReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[46]
+		mi := &file_runner_runner_proto_msgTypes[49]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *InitReply) String() string {
+func (x *ApplyReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InitReply) ProtoMessage() {}
+func (*ApplyReply) ProtoMessage() {}
 
-func (x *InitReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[46]
+func (x *ApplyReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[49]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2642,50 +2927,68 @@ func (x *InitReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InitReply.ProtoReflect.Descriptor instead.
-func (*InitReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{46}
+// Deprecated: Use ApplyReply.ProtoReflect.Descriptor instead.
+func (*ApplyReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *InitReply) GetMessage() string {
+func (x *ApplyReply) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *InitReply) GetStateLockIdentifier() string {
+func (x *ApplyReply) GetStateLockIdentifier() string {
 	if x != nil {
 		return x.StateLockIdentifier
 	}
 	return ""
 }
 
-type WorkspaceRequest struct {
+func (x *ApplyReply) GetDiagnostics() []*Diagnostic {
+	if x != nil {
+		return x.Diagnostics
+	}
+	return nil
+}
+
+func (x *ApplyReply) GetResourceTimings() []*ResourceTiming {
+	if x != nil {
+		return x.ResourceTimings
+	}
+	return nil
+}
+
+// ResourceTiming is the time terraform took for an action on a resource.
+type ResourceTiming struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// action is create, update, delete or read.
+	Action  string  `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	Seconds float64 `protobuf:"fixed64,3,opt,name=seconds,proto3" json:"seconds,omitempty"`
 }
 
-func (x *WorkspaceRequest) Reset() {
-	*x = WorkspaceRequest{}
+func (x *ResourceTiming) Reset() {
+	*x = ResourceTiming{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[47]
+		mi := &file_runner_runner_proto_msgTypes[50]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WorkspaceRequest) String() string {
+func (x *ResourceTiming) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WorkspaceRequest) ProtoMessage() {}
+func (*ResourceTiming) ProtoMessage() {}
 
-func (x *WorkspaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[47]
+func (x *ResourceTiming) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[50]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2696,43 +2999,58 @@ func (x *WorkspaceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WorkspaceRequest.ProtoReflect.Descriptor instead.
-func (*WorkspaceRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{47}
+// Deprecated: Use ResourceTiming.ProtoReflect.Descriptor instead.
+func (*ResourceTiming) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *WorkspaceRequest) GetTfInstance() string {
+func (x *ResourceTiming) GetAddress() string {
 	if x != nil {
-		return x.TfInstance
+		return x.Address
 	}
 	return ""
 }
 
-type WorkspaceReply struct {
+func (x *ResourceTiming) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ResourceTiming) GetSeconds() float64 {
+	if x != nil {
+		return x.Seconds
+	}
+	return 0
+}
+
+type RefreshRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	TfInstance string   `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	Targets    []string `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets,omitempty"`
 }
 
-func (x *WorkspaceReply) Reset() {
-	*x = WorkspaceReply{}
+func (x *RefreshRequest) Reset() {
+	*x = RefreshRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[48]
+		mi := &file_runner_runner_proto_msgTypes[51]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WorkspaceReply) String() string {
+func (x *RefreshRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WorkspaceReply) ProtoMessage() {}
+func (*RefreshRequest) ProtoMessage() {}
 
-func (x *WorkspaceReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[48]
+func (x *RefreshRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[51]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2743,43 +3061,52 @@ func (x *WorkspaceReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WorkspaceReply.ProtoReflect.Descriptor instead.
-func (*WorkspaceReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{48}
+// Deprecated: Use RefreshRequest.ProtoReflect.Descriptor instead.
+func (*RefreshRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *WorkspaceReply) GetMessage() string {
+func (x *RefreshRequest) GetTfInstance() string {
 	if x != nil {
-		return x.Message
+		return x.TfInstance
 	}
 	return ""
 }
 
-type UploadRequest struct {
+func (x *RefreshRequest) GetTargets() []string {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+type RefreshReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Blob []byte `protobuf:"bytes,1,opt,name=blob,proto3" json:"blob,omitempty"`
+	Message             string        `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	StateLockIdentifier string        `protobuf:"bytes,2,opt,name=stateLockIdentifier,proto3" json:"stateLockIdentifier,omitempty"`
+	Diagnostics         []*Diagnostic `protobuf:"bytes,3,rep,name=diagnostics,proto3" json:"diagnostics,omitempty"`
 }
 
-func (x *UploadRequest) Reset() {
-	*x = UploadRequest{}
+func (x *RefreshReply) Reset() {
+	*x = RefreshReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[49]
+		mi := &file_runner_runner_proto_msgTypes[52]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UploadRequest) String() string {
+func (x *RefreshReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadRequest) ProtoMessage() {}
+func (*RefreshReply) ProtoMessage() {}
 
-func (x *UploadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[49]
+func (x *RefreshReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[52]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2790,43 +3117,57 @@ func (x *UploadRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadRequest.ProtoReflect.Descriptor instead.
-func (*UploadRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{49}
+// Deprecated: Use RefreshReply.ProtoReflect.Descriptor instead.
+func (*RefreshReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *UploadRequest) GetBlob() []byte {
+func (x *RefreshReply) GetMessage() string {
 	if x != nil {
-		return x.Blob
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RefreshReply) GetStateLockIdentifier() string {
+	if x != nil {
+		return x.StateLockIdentifier
+	}
+	return ""
+}
+
+func (x *RefreshReply) GetDiagnostics() []*Diagnostic {
+	if x != nil {
+		return x.Diagnostics
 	}
 	return nil
 }
 
-type UploadReply struct {
+type GetInventoryRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
 }
 
-func (x *UploadReply) Reset() {
-	*x = UploadReply{}
+func (x *GetInventoryRequest) Reset() {
+	*x = GetInventoryRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[50]
+		mi := &file_runner_runner_proto_msgTypes[53]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UploadReply) String() string {
+func (x *GetInventoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadReply) ProtoMessage() {}
+func (*GetInventoryRequest) ProtoMessage() {}
 
-func (x *UploadReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[50]
+func (x *GetInventoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[53]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2837,47 +3178,43 @@ func (x *UploadReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadReply.ProtoReflect.Descriptor instead.
-func (*UploadReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{50}
+// Deprecated: Use GetInventoryRequest.ProtoReflect.Descriptor instead.
+func (*GetInventoryRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *UploadReply) GetMessage() string {
+func (x *GetInventoryRequest) GetTfInstance() string {
 	if x != nil {
-		return x.Message
+		return x.TfInstance
 	}
 	return ""
 }
 
-type FinalizeSecretsRequest struct {
+type GetInventoryReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Namespace                string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	Name                     string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Workspace                string `protobuf:"bytes,3,opt,name=workspace,proto3" json:"workspace,omitempty"`
-	HasSpecifiedOutputSecret bool   `protobuf:"varint,4,opt,name=hasSpecifiedOutputSecret,proto3" json:"hasSpecifiedOutputSecret,omitempty"`
-	OutputSecretName         string `protobuf:"bytes,5,opt,name=outputSecretName,proto3" json:"outputSecretName,omitempty"`
+	Inventories []*Inventory `protobuf:"bytes,1,rep,name=inventories,proto3" json:"inventories,omitempty"`
 }
 
-func (x *FinalizeSecretsRequest) Reset() {
-	*x = FinalizeSecretsRequest{}
+func (x *GetInventoryReply) Reset() {
+	*x = GetInventoryReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[51]
+		mi := &file_runner_runner_proto_msgTypes[54]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *FinalizeSecretsRequest) String() string {
+func (x *GetInventoryReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FinalizeSecretsRequest) ProtoMessage() {}
+func (*GetInventoryReply) ProtoMessage() {}
 
-func (x *FinalizeSecretsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[51]
+func (x *GetInventoryReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[54]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2888,72 +3225,107 @@ func (x *FinalizeSecretsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FinalizeSecretsRequest.ProtoReflect.Descriptor instead.
-func (*FinalizeSecretsRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{51}
+// Deprecated: Use GetInventoryReply.ProtoReflect.Descriptor instead.
+func (*GetInventoryReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *FinalizeSecretsRequest) GetNamespace() string {
+func (x *GetInventoryReply) GetInventories() []*Inventory {
 	if x != nil {
-		return x.Namespace
+		return x.Inventories
 	}
-	return ""
+	return nil
 }
 
-func (x *FinalizeSecretsRequest) GetName() string {
-	if x != nil {
-		return x.Name
+type Inventory struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name       string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type       string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Identifier string `protobuf:"bytes,3,opt,name=identifier,proto3" json:"identifier,omitempty"`
+}
+
+func (x *Inventory) Reset() {
+	*x = Inventory{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (x *FinalizeSecretsRequest) GetWorkspace() string {
+func (x *Inventory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Inventory) ProtoMessage() {}
+
+func (x *Inventory) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Inventory.ProtoReflect.Descriptor instead.
+func (*Inventory) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *Inventory) GetName() string {
 	if x != nil {
-		return x.Workspace
+		return x.Name
 	}
 	return ""
 }
 
-func (x *FinalizeSecretsRequest) GetHasSpecifiedOutputSecret() bool {
+func (x *Inventory) GetType() string {
 	if x != nil {
-		return x.HasSpecifiedOutputSecret
+		return x.Type
 	}
-	return false
+	return ""
 }
 
-func (x *FinalizeSecretsRequest) GetOutputSecretName() string {
+func (x *Inventory) GetIdentifier() string {
 	if x != nil {
-		return x.OutputSecretName
+		return x.Identifier
 	}
 	return ""
 }
 
-type FinalizeSecretsReply struct {
+type DestroyRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message  string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	NotFound bool   `protobuf:"varint,2,opt,name=notFound,proto3" json:"notFound,omitempty"`
+	TfInstance string   `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	Targets    []string `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets,omitempty"`
 }
 
-func (x *FinalizeSecretsReply) Reset() {
-	*x = FinalizeSecretsReply{}
+func (x *DestroyRequest) Reset() {
+	*x = DestroyRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[52]
+		mi := &file_runner_runner_proto_msgTypes[56]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *FinalizeSecretsReply) String() string {
+func (x *DestroyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FinalizeSecretsReply) ProtoMessage() {}
+func (*DestroyRequest) ProtoMessage() {}
 
-func (x *FinalizeSecretsReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[52]
+func (x *DestroyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[56]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2964,50 +3336,51 @@ func (x *FinalizeSecretsReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FinalizeSecretsReply.ProtoReflect.Descriptor instead.
-func (*FinalizeSecretsReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{52}
+// Deprecated: Use DestroyRequest.ProtoReflect.Descriptor instead.
+func (*DestroyRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *FinalizeSecretsReply) GetMessage() string {
+func (x *DestroyRequest) GetTfInstance() string {
 	if x != nil {
-		return x.Message
+		return x.TfInstance
 	}
 	return ""
 }
 
-func (x *FinalizeSecretsReply) GetNotFound() bool {
+func (x *DestroyRequest) GetTargets() []string {
 	if x != nil {
-		return x.NotFound
+		return x.Targets
 	}
-	return false
+	return nil
 }
 
-type ForceUnlockRequest struct {
+type DestroyReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	LockIdentifier string `protobuf:"bytes,1,opt,name=lockIdentifier,proto3" json:"lockIdentifier,omitempty"`
+	Message             string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	StateLockIdentifier string `protobuf:"bytes,2,opt,name=stateLockIdentifier,proto3" json:"stateLockIdentifier,omitempty"`
 }
 
-func (x *ForceUnlockRequest) Reset() {
-	*x = ForceUnlockRequest{}
+func (x *DestroyReply) Reset() {
+	*x = DestroyReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[53]
+		mi := &file_runner_runner_proto_msgTypes[57]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ForceUnlockRequest) String() string {
+func (x *DestroyReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ForceUnlockRequest) ProtoMessage() {}
+func (*DestroyReply) ProtoMessage() {}
 
-func (x *ForceUnlockRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[53]
+func (x *DestroyReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[57]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3018,44 +3391,50 @@ func (x *ForceUnlockRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ForceUnlockRequest.ProtoReflect.Descriptor instead.
-func (*ForceUnlockRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{53}
+// Deprecated: Use DestroyReply.ProtoReflect.Descriptor instead.
+func (*DestroyReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *ForceUnlockRequest) GetLockIdentifier() string {
+func (x *DestroyReply) GetMessage() string {
 	if x != nil {
-		return x.LockIdentifier
+		return x.Message
 	}
 	return ""
 }
 
-type ForceUnlockReply struct {
+func (x *DestroyReply) GetStateLockIdentifier() string {
+	if x != nil {
+		return x.StateLockIdentifier
+	}
+	return ""
+}
+
+type OutputRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	Success bool   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
 }
 
-func (x *ForceUnlockReply) Reset() {
-	*x = ForceUnlockReply{}
+func (x *OutputRequest) Reset() {
+	*x = OutputRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[54]
+		mi := &file_runner_runner_proto_msgTypes[58]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ForceUnlockReply) String() string {
+func (x *OutputRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ForceUnlockReply) ProtoMessage() {}
+func (*OutputRequest) ProtoMessage() {}
 
-func (x *ForceUnlockReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[54]
+func (x *OutputRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[58]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3066,48 +3445,43 @@ func (x *ForceUnlockReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ForceUnlockReply.ProtoReflect.Descriptor instead.
-func (*ForceUnlockReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{54}
+// Deprecated: Use OutputRequest.ProtoReflect.Descriptor instead.
+func (*OutputRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *ForceUnlockReply) GetMessage() string {
+func (x *OutputRequest) GetTfInstance() string {
 	if x != nil {
-		return x.Message
+		return x.TfInstance
 	}
 	return ""
 }
 
-func (x *ForceUnlockReply) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
-}
-
-type BreakTheGlassRequest struct {
+type OutputReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Outputs map[string]*OutputMeta `protobuf:"bytes,1,rep,name=outputs,proto3" json:"outputs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *BreakTheGlassRequest) Reset() {
-	*x = BreakTheGlassRequest{}
+func (x *OutputReply) Reset() {
+	*x = OutputReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[55]
+		mi := &file_runner_runner_proto_msgTypes[59]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *BreakTheGlassRequest) String() string {
+func (x *OutputReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BreakTheGlassRequest) ProtoMessage() {}
+func (*OutputReply) ProtoMessage() {}
 
-func (x *BreakTheGlassRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[55]
+func (x *OutputReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[59]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3118,37 +3492,45 @@ func (x *BreakTheGlassRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BreakTheGlassRequest.ProtoReflect.Descriptor instead.
-func (*BreakTheGlassRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{55}
+// Deprecated: Use OutputReply.ProtoReflect.Descriptor instead.
+func (*OutputReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{59}
 }
 
-type BreakTheGlassReply struct {
+func (x *OutputReply) GetOutputs() map[string]*OutputMeta {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+type OutputMeta struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	Success bool   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Sensitive bool   `protobuf:"varint,1,opt,name=sensitive,proto3" json:"sensitive,omitempty"`
+	Type      []byte `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Value     []byte `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
 }
 
-func (x *BreakTheGlassReply) Reset() {
-	*x = BreakTheGlassReply{}
+func (x *OutputMeta) Reset() {
+	*x = OutputMeta{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[56]
+		mi := &file_runner_runner_proto_msgTypes[60]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *BreakTheGlassReply) String() string {
+func (x *OutputMeta) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BreakTheGlassReply) ProtoMessage() {}
+func (*OutputMeta) ProtoMessage() {}
 
-func (x *BreakTheGlassReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[56]
+func (x *OutputMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[60]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3159,340 +3541,1778 @@ func (x *BreakTheGlassReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BreakTheGlassReply.ProtoReflect.Descriptor instead.
-func (*BreakTheGlassReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{56}
+// Deprecated: Use OutputMeta.ProtoReflect.Descriptor instead.
+func (*OutputMeta) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *BreakTheGlassReply) GetMessage() string {
+func (x *OutputMeta) GetSensitive() bool {
 	if x != nil {
-		return x.Message
+		return x.Sensitive
 	}
-	return ""
+	return false
 }
 
-func (x *BreakTheGlassReply) GetSuccess() bool {
+func (x *OutputMeta) GetType() []byte {
 	if x != nil {
-		return x.Success
+		return x.Type
 	}
-	return false
+	return nil
+}
+
+func (x *OutputMeta) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type WriteOutputsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Namespace   string            `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name        string            `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	SecretName  string            `protobuf:"bytes,3,opt,name=secretName,proto3" json:"secretName,omitempty"`
+	Uuid        string            `protobuf:"bytes,4,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Data        map[string][]byte `protobuf:"bytes,5,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Labels      map[string]string `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Annotations map[string]string `protobuf:"bytes,7,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Checksums   bool              `protobuf:"varint,8,opt,name=checksums,proto3" json:"checksums,omitempty"`
+}
+
+func (x *WriteOutputsRequest) Reset() {
+	*x = WriteOutputsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[61]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WriteOutputsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteOutputsRequest) ProtoMessage() {}
+
+func (x *WriteOutputsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[61]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteOutputsRequest.ProtoReflect.Descriptor instead.
+func (*WriteOutputsRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *WriteOutputsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *WriteOutputsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WriteOutputsRequest) GetSecretName() string {
+	if x != nil {
+		return x.SecretName
+	}
+	return ""
+}
+
+func (x *WriteOutputsRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *WriteOutputsRequest) GetData() map[string][]byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *WriteOutputsRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *WriteOutputsRequest) GetAnnotations() map[string]string {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+func (x *WriteOutputsRequest) GetChecksums() bool {
+	if x != nil {
+		return x.Checksums
+	}
+	return false
+}
+
+type WriteOutputsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message     string   `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Changed     bool     `protobuf:"varint,2,opt,name=changed,proto3" json:"changed,omitempty"`
+	ChangedKeys []string `protobuf:"bytes,3,rep,name=changedKeys,proto3" json:"changedKeys,omitempty"`
+}
+
+func (x *WriteOutputsReply) Reset() {
+	*x = WriteOutputsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[62]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WriteOutputsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteOutputsReply) ProtoMessage() {}
+
+func (x *WriteOutputsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[62]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteOutputsReply.ProtoReflect.Descriptor instead.
+func (*WriteOutputsReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *WriteOutputsReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *WriteOutputsReply) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+func (x *WriteOutputsReply) GetChangedKeys() []string {
+	if x != nil {
+		return x.ChangedKeys
+	}
+	return nil
+}
+
+type GetOutputsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Namespace  string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	SecretName string `protobuf:"bytes,2,opt,name=secretName,proto3" json:"secretName,omitempty"`
+}
+
+func (x *GetOutputsRequest) Reset() {
+	*x = GetOutputsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[63]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetOutputsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOutputsRequest) ProtoMessage() {}
+
+func (x *GetOutputsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[63]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOutputsRequest.ProtoReflect.Descriptor instead.
+func (*GetOutputsRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *GetOutputsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *GetOutputsRequest) GetSecretName() string {
+	if x != nil {
+		return x.SecretName
+	}
+	return ""
+}
+
+type GetOutputsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Outputs map[string]string `protobuf:"bytes,1,rep,name=outputs,proto3" json:"outputs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *GetOutputsReply) Reset() {
+	*x = GetOutputsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[64]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetOutputsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOutputsReply) ProtoMessage() {}
+
+func (x *GetOutputsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[64]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOutputsReply.ProtoReflect.Descriptor instead.
+func (*GetOutputsReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *GetOutputsReply) GetOutputs() map[string]string {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+type InitRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	Upgrade    bool   `protobuf:"varint,2,opt,name=upgrade,proto3" json:"upgrade,omitempty"`
+	ForceCopy  bool   `protobuf:"varint,3,opt,name=forceCopy,proto3" json:"forceCopy,omitempty"`
+	// dependencyLock is the dependency lock file to init with, in place of
+	// the one of the source, when not upgrading.
+	DependencyLock string `protobuf:"bytes,4,opt,name=dependencyLock,proto3" json:"dependencyLock,omitempty"`
+}
+
+func (x *InitRequest) Reset() {
+	*x = InitRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[65]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InitRequest) ProtoMessage() {}
+
+func (x *InitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[65]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InitRequest.ProtoReflect.Descriptor instead.
+func (*InitRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *InitRequest) GetTfInstance() string {
+	if x != nil {
+		return x.TfInstance
+	}
+	return ""
+}
+
+func (x *InitRequest) GetUpgrade() bool {
+	if x != nil {
+		return x.Upgrade
+	}
+	return false
+}
+
+func (x *InitRequest) GetForceCopy() bool {
+	if x != nil {
+		return x.ForceCopy
+	}
+	return false
+}
+
+func (x *InitRequest) GetDependencyLock() string {
+	if x != nil {
+		return x.DependencyLock
+	}
+	return ""
+}
+
+type InitReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message             string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	StateLockIdentifier string `protobuf:"bytes,2,opt,name=stateLockIdentifier,proto3" json:"stateLockIdentifier,omitempty"`
+	// dependencyLock is the dependency lock file after init.
+	DependencyLock string `protobuf:"bytes,3,opt,name=dependencyLock,proto3" json:"dependencyLock,omitempty"`
+	// upgraded is true when the providers were upgraded, because it was
+	// requested or because the dependency lock did not match the constraints.
+	Upgraded bool `protobuf:"varint,4,opt,name=upgraded,proto3" json:"upgraded,omitempty"`
+}
+
+func (x *InitReply) Reset() {
+	*x = InitReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[66]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InitReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InitReply) ProtoMessage() {}
+
+func (x *InitReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[66]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InitReply.ProtoReflect.Descriptor instead.
+func (*InitReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *InitReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *InitReply) GetStateLockIdentifier() string {
+	if x != nil {
+		return x.StateLockIdentifier
+	}
+	return ""
+}
+
+func (x *InitReply) GetDependencyLock() string {
+	if x != nil {
+		return x.DependencyLock
+	}
+	return ""
+}
+
+func (x *InitReply) GetUpgraded() bool {
+	if x != nil {
+		return x.Upgraded
+	}
+	return false
+}
+
+type WorkspaceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+}
+
+func (x *WorkspaceRequest) Reset() {
+	*x = WorkspaceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[67]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceRequest) ProtoMessage() {}
+
+func (x *WorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[67]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*WorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *WorkspaceRequest) GetTfInstance() string {
+	if x != nil {
+		return x.TfInstance
+	}
+	return ""
+}
+
+type WorkspaceReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *WorkspaceReply) Reset() {
+	*x = WorkspaceReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[68]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkspaceReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceReply) ProtoMessage() {}
+
+func (x *WorkspaceReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[68]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceReply.ProtoReflect.Descriptor instead.
+func (*WorkspaceReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *WorkspaceReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type UploadRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Blob []byte `protobuf:"bytes,1,opt,name=blob,proto3" json:"blob,omitempty"`
+}
+
+func (x *UploadRequest) Reset() {
+	*x = UploadRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[69]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadRequest) ProtoMessage() {}
+
+func (x *UploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[69]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadRequest.ProtoReflect.Descriptor instead.
+func (*UploadRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *UploadRequest) GetBlob() []byte {
+	if x != nil {
+		return x.Blob
+	}
+	return nil
+}
+
+type UploadReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *UploadReply) Reset() {
+	*x = UploadReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[70]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadReply) ProtoMessage() {}
+
+func (x *UploadReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[70]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadReply.ProtoReflect.Descriptor instead.
+func (*UploadReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *UploadReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type FinalizeSecretsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Namespace                string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name                     string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Workspace                string `protobuf:"bytes,3,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	HasSpecifiedOutputSecret bool   `protobuf:"varint,4,opt,name=hasSpecifiedOutputSecret,proto3" json:"hasSpecifiedOutputSecret,omitempty"`
+	OutputSecretName         string `protobuf:"bytes,5,opt,name=outputSecretName,proto3" json:"outputSecretName,omitempty"`
+	StateSecretName          string `protobuf:"bytes,6,opt,name=stateSecretName,proto3" json:"stateSecretName,omitempty"`
+	StateSecretNamespace     string `protobuf:"bytes,7,opt,name=stateSecretNamespace,proto3" json:"stateSecretNamespace,omitempty"`
+}
+
+func (x *FinalizeSecretsRequest) Reset() {
+	*x = FinalizeSecretsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[71]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FinalizeSecretsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinalizeSecretsRequest) ProtoMessage() {}
+
+func (x *FinalizeSecretsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[71]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinalizeSecretsRequest.ProtoReflect.Descriptor instead.
+func (*FinalizeSecretsRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *FinalizeSecretsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *FinalizeSecretsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FinalizeSecretsRequest) GetWorkspace() string {
+	if x != nil {
+		return x.Workspace
+	}
+	return ""
+}
+
+func (x *FinalizeSecretsRequest) GetHasSpecifiedOutputSecret() bool {
+	if x != nil {
+		return x.HasSpecifiedOutputSecret
+	}
+	return false
+}
+
+func (x *FinalizeSecretsRequest) GetOutputSecretName() string {
+	if x != nil {
+		return x.OutputSecretName
+	}
+	return ""
+}
+
+func (x *FinalizeSecretsRequest) GetStateSecretName() string {
+	if x != nil {
+		return x.StateSecretName
+	}
+	return ""
+}
+
+func (x *FinalizeSecretsRequest) GetStateSecretNamespace() string {
+	if x != nil {
+		return x.StateSecretNamespace
+	}
+	return ""
+}
+
+type FinalizeSecretsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message  string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	NotFound bool   `protobuf:"varint,2,opt,name=notFound,proto3" json:"notFound,omitempty"`
+}
+
+func (x *FinalizeSecretsReply) Reset() {
+	*x = FinalizeSecretsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[72]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FinalizeSecretsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinalizeSecretsReply) ProtoMessage() {}
+
+func (x *FinalizeSecretsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[72]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinalizeSecretsReply.ProtoReflect.Descriptor instead.
+func (*FinalizeSecretsReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *FinalizeSecretsReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *FinalizeSecretsReply) GetNotFound() bool {
+	if x != nil {
+		return x.NotFound
+	}
+	return false
+}
+
+type ForceUnlockRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LockIdentifier string `protobuf:"bytes,1,opt,name=lockIdentifier,proto3" json:"lockIdentifier,omitempty"`
+}
+
+func (x *ForceUnlockRequest) Reset() {
+	*x = ForceUnlockRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[73]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ForceUnlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForceUnlockRequest) ProtoMessage() {}
+
+func (x *ForceUnlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[73]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForceUnlockRequest.ProtoReflect.Descriptor instead.
+func (*ForceUnlockRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ForceUnlockRequest) GetLockIdentifier() string {
+	if x != nil {
+		return x.LockIdentifier
+	}
+	return ""
+}
+
+type ForceUnlockReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Success bool   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+}
+
+func (x *ForceUnlockReply) Reset() {
+	*x = ForceUnlockReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[74]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ForceUnlockReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForceUnlockReply) ProtoMessage() {}
+
+func (x *ForceUnlockReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[74]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForceUnlockReply.ProtoReflect.Descriptor instead.
+func (*ForceUnlockReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ForceUnlockReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ForceUnlockReply) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type BreakTheGlassRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *BreakTheGlassRequest) Reset() {
+	*x = BreakTheGlassRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[75]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BreakTheGlassRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BreakTheGlassRequest) ProtoMessage() {}
+
+func (x *BreakTheGlassRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[75]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BreakTheGlassRequest.ProtoReflect.Descriptor instead.
+func (*BreakTheGlassRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{75}
+}
+
+type BreakTheGlassReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Success bool   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+}
+
+func (x *BreakTheGlassReply) Reset() {
+	*x = BreakTheGlassReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[76]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BreakTheGlassReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BreakTheGlassReply) ProtoMessage() {}
+
+func (x *BreakTheGlassReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[76]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BreakTheGlassReply.ProtoReflect.Descriptor instead.
+func (*BreakTheGlassReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *BreakTheGlassReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *BreakTheGlassReply) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type CheckCredentialsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Providers []string `protobuf:"bytes,1,rep,name=providers,proto3" json:"providers,omitempty"`
+}
+
+func (x *CheckCredentialsRequest) Reset() {
+	*x = CheckCredentialsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[77]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckCredentialsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckCredentialsRequest) ProtoMessage() {}
+
+func (x *CheckCredentialsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[77]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckCredentialsRequest.ProtoReflect.Descriptor instead.
+func (*CheckCredentialsRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *CheckCredentialsRequest) GetProviders() []string {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+type ProviderCredentials struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Provider  string `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	Valid     bool   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	Identity  string `protobuf:"bytes,3,opt,name=identity,proto3" json:"identity,omitempty"`
+	ExpiresAt string `protobuf:"bytes,4,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	Message   string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *ProviderCredentials) Reset() {
+	*x = ProviderCredentials{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[78]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProviderCredentials) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderCredentials) ProtoMessage() {}
+
+func (x *ProviderCredentials) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[78]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderCredentials.ProtoReflect.Descriptor instead.
+func (*ProviderCredentials) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *ProviderCredentials) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *ProviderCredentials) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ProviderCredentials) GetIdentity() string {
+	if x != nil {
+		return x.Identity
+	}
+	return ""
+}
+
+func (x *ProviderCredentials) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *ProviderCredentials) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type CheckCredentialsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Credentials []*ProviderCredentials `protobuf:"bytes,1,rep,name=credentials,proto3" json:"credentials,omitempty"`
+}
+
+func (x *CheckCredentialsReply) Reset() {
+	*x = CheckCredentialsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[79]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckCredentialsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckCredentialsReply) ProtoMessage() {}
+
+func (x *CheckCredentialsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[79]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckCredentialsReply.ProtoReflect.Descriptor instead.
+func (*CheckCredentialsReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *CheckCredentialsReply) GetCredentials() []*ProviderCredentials {
+	if x != nil {
+		return x.Credentials
+	}
+	return nil
+}
+
+type ExportResultsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Namespace     string            `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name          string            `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ConfigMapName string            `protobuf:"bytes,3,opt,name=configMapName,proto3" json:"configMapName,omitempty"`
+	Uuid          string            `protobuf:"bytes,4,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Data          map[string]string `protobuf:"bytes,5,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Annotations   map[string]string `protobuf:"bytes,6,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ExportResultsRequest) Reset() {
+	*x = ExportResultsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[80]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportResultsRequest) ProtoMessage() {}
+
+func (x *ExportResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[80]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportResultsRequest.ProtoReflect.Descriptor instead.
+func (*ExportResultsRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *ExportResultsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ExportResultsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExportResultsRequest) GetConfigMapName() string {
+	if x != nil {
+		return x.ConfigMapName
+	}
+	return ""
+}
+
+func (x *ExportResultsRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *ExportResultsRequest) GetData() map[string]string {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ExportResultsRequest) GetAnnotations() map[string]string {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+type ExportResultsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *ExportResultsReply) Reset() {
+	*x = ExportResultsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[81]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportResultsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportResultsReply) ProtoMessage() {}
+
+func (x *ExportResultsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[81]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportResultsReply.ProtoReflect.Descriptor instead.
+func (*ExportResultsReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *ExportResultsReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
 }
 
 var File_runner_runner_proto protoreflect.FileDescriptor
 
 var file_runner_runner_proto_rawDesc = []byte{
 	0x0a, 0x13, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2f, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x22, 0x25, 0x0a,
-	0x0f, 0x4c, 0x6f, 0x6f, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x12, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x66, 0x69, 0x6c, 0x65, 0x22, 0x2b, 0x0a, 0x0d, 0x4c, 0x6f, 0x6f, 0x6b, 0x50, 0x61, 0x74, 0x68,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x65, 0x63, 0x50, 0x61, 0x74,
-	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x65, 0x63, 0x50, 0x61, 0x74,
-	0x68, 0x22, 0x8f, 0x01, 0x0a, 0x13, 0x4e, 0x65, 0x77, 0x54, 0x65, 0x72, 0x72, 0x61, 0x66, 0x6f,
-	0x72, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x77, 0x6f, 0x72,
-	0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x77,
-	0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x65,
-	0x63, 0x50, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x65,
-	0x63, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x65, 0x72, 0x72, 0x61, 0x66, 0x6f,
-	0x72, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x74, 0x65, 0x72, 0x72, 0x61, 0x66,
-	0x6f, 0x72, 0x6d, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49,
-	0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
-	0x65, 0x49, 0x44, 0x22, 0x23, 0x0a, 0x11, 0x4e, 0x65, 0x77, 0x54, 0x65, 0x72, 0x72, 0x61, 0x66,
-	0x6f, 0x72, 0x6d, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x9d, 0x01, 0x0a, 0x0d, 0x53, 0x65, 0x74,
-	0x45, 0x6e, 0x76, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66,
-	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
-	0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x33, 0x0a, 0x04, 0x65, 0x6e,
-	0x76, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65,
-	0x72, 0x2e, 0x53, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
-	0x45, 0x6e, 0x76, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x65, 0x6e, 0x76, 0x73, 0x1a,
-	0x37, 0x0a, 0x09, 0x45, 0x6e, 0x76, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
-	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x27, 0x0a, 0x0b, 0x53, 0x65, 0x74, 0x45,
-	0x6e, 0x76, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x22, 0x57, 0x0a, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67,
-	0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f,
-	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f,
-	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x74, 0x0a, 0x19, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69,
-	0x6e, 0x67, 0x44, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x77, 0x6f, 0x72,
-	0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x12, 0x37, 0x0a, 0x0c, 0x66, 0x69, 0x6c, 0x65, 0x4d,
-	0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e,
-	0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x66, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x70, 0x69,
-	0x6e, 0x67, 0x52, 0x0c, 0x66, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x73,
-	0x22, 0x33, 0x0a, 0x17, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x61,
-	0x70, 0x70, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x75, 0x0a, 0x17, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41,
-	0x6e, 0x64, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12,
-	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x72, 0x47, 0x7a, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x05, 0x74, 0x61, 0x72, 0x47, 0x7a, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x4f, 0x0a, 0x15,
-	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x6e, 0x64, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x22, 0x3d, 0x0a,
+	0x11, 0x47, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x6b, 0x0a, 0x0f,
+	0x47, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x28, 0x0a, 0x0f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63,
+	0x6f, 0x6c, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x12, 0x6d, 0x69, 0x6e,
+	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12, 0x6d, 0x69, 0x6e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63,
+	0x6f, 0x6c, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x25, 0x0a, 0x0f, 0x4c, 0x6f, 0x6f,
+	0x6b, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65,
+	0x22, 0x2b, 0x0a, 0x0d, 0x4c, 0x6f, 0x6f, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x65, 0x63, 0x50, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x65, 0x63, 0x50, 0x61, 0x74, 0x68, 0x22, 0x8f, 0x01,
+	0x0a, 0x13, 0x4e, 0x65, 0x77, 0x54, 0x65, 0x72, 0x72, 0x61, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67,
 	0x44, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69,
-	0x6e, 0x67, 0x44, 0x69, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6d, 0x70, 0x44, 0x69, 0x72, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x6d, 0x70, 0x44, 0x69, 0x72, 0x22, 0x2b, 0x0a,
-	0x11, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x44, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6d, 0x70, 0x44, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x74, 0x6d, 0x70, 0x44, 0x69, 0x72, 0x22, 0x2b, 0x0a, 0x0f, 0x43, 0x6c,
-	0x65, 0x61, 0x6e, 0x75, 0x70, 0x44, 0x69, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a,
-	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x5b, 0x0a, 0x19, 0x57, 0x72, 0x69, 0x74, 0x65,
-	0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x69, 0x72, 0x50, 0x61, 0x74, 0x68, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x69, 0x72, 0x50, 0x61, 0x74, 0x68, 0x12, 0x24,
-	0x0a, 0x0d, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x22, 0x33, 0x0a, 0x17, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61, 0x63,
-	0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
-	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x65, 0x0a, 0x17, 0x50, 0x72, 0x6f,
-	0x63, 0x65, 0x73, 0x73, 0x43, 0x6c, 0x69, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x69, 0x72, 0x50, 0x61, 0x74, 0x68, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x69, 0x72, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1c,
-	0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x22, 0x33, 0x0a, 0x15, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x43, 0x6c, 0x69, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c,
-	0x65, 0x50, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c,
-	0x65, 0x50, 0x61, 0x74, 0x68, 0x22, 0x3a, 0x0a, 0x18, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
-	0x65, 0x56, 0x61, 0x72, 0x73, 0x46, 0x6f, 0x72, 0x54, 0x46, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69,
-	0x72, 0x22, 0x32, 0x0a, 0x16, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x56, 0x61, 0x72,
-	0x73, 0x46, 0x6f, 0x72, 0x54, 0x46, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x39, 0x0a, 0x17, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
-	0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x6e, 0x67, 0x44, 0x69, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x65, 0x63, 0x50, 0x61, 0x74,
+	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x65, 0x63, 0x50, 0x61, 0x74,
+	0x68, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x65, 0x72, 0x72, 0x61, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x74, 0x65, 0x72, 0x72, 0x61, 0x66, 0x6f, 0x72, 0x6d, 0x12,
+	0x1e, 0x0a, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x44, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x44, 0x22,
+	0x23, 0x0a, 0x11, 0x4e, 0x65, 0x77, 0x54, 0x65, 0x72, 0x72, 0x61, 0x66, 0x6f, 0x72, 0x6d, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x22, 0x9d, 0x01, 0x0a, 0x0d, 0x53, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x33, 0x0a, 0x04, 0x65, 0x6e, 0x76, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x65,
+	0x74, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x45, 0x6e, 0x76, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x65, 0x6e, 0x76, 0x73, 0x1a, 0x37, 0x0a, 0x09, 0x45,
+	0x6e, 0x76, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x27, 0x0a, 0x0b, 0x53, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x57, 0x0a,
+	0x0b, 0x66, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x12, 0x18, 0x0a, 0x07,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x74, 0x0a, 0x19, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x46, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67,
+	0x44, 0x69, 0x72, 0x12, 0x37, 0x0a, 0x0c, 0x66, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x70, 0x69,
+	0x6e, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x72, 0x75, 0x6e, 0x6e,
+	0x65, 0x72, 0x2e, 0x66, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x52, 0x0c,
+	0x66, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x33, 0x0a, 0x17,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e,
+	0x67, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0x8d, 0x01, 0x0a, 0x17, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x6e, 0x64, 0x45,
+	0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a,
+	0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x74, 0x61, 0x72, 0x47, 0x7a, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
+	0x74, 0x61, 0x72, 0x47, 0x7a, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67,
+	0x65, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73,
+	0x74, 0x22, 0x67, 0x0a, 0x15, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x6e, 0x64, 0x45, 0x78,
+	0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x77, 0x6f,
+	0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6d,
+	0x70, 0x44, 0x69, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x6d, 0x70, 0x44,
+	0x69, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x22, 0x2b, 0x0a, 0x11, 0x43, 0x6c,
+	0x65, 0x61, 0x6e, 0x75, 0x70, 0x44, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x74, 0x6d, 0x70, 0x44, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x74, 0x6d, 0x70, 0x44, 0x69, 0x72, 0x22, 0x2b, 0x0a, 0x0f, 0x43, 0x6c, 0x65, 0x61, 0x6e,
+	0x75, 0x70, 0x44, 0x69, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x22, 0x5b, 0x0a, 0x19, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61, 0x63,
+	0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x69, 0x72, 0x50, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x64, 0x69, 0x72, 0x50, 0x61, 0x74, 0x68, 0x12, 0x24, 0x0a, 0x0d, 0x62,
+	0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0d, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x22, 0x33, 0x0a, 0x17, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e,
+	0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x64, 0x0a, 0x1c, 0x57, 0x72, 0x69, 0x74, 0x65, 0x53,
+	0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x69, 0x72, 0x50, 0x61, 0x74,
+	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x69, 0x72, 0x50, 0x61, 0x74, 0x68,
+	0x12, 0x2a, 0x0a, 0x10, 0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x73, 0x69, 0x6d, 0x75,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x36, 0x0a, 0x1a,
+	0x57, 0x72, 0x69, 0x74, 0x65, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x22, 0x65, 0x0a, 0x17, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x43,
+	0x6c, 0x69, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x64, 0x69, 0x72, 0x50, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x64, 0x69, 0x72, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x33, 0x0a, 0x15, 0x50,
+	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x43, 0x6c, 0x69, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68,
+	0x22, 0x80, 0x01, 0x0a, 0x1c, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x47, 0x69, 0x74, 0x43,
+	0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x69, 0x72, 0x50, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x64, 0x69, 0x72, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x6e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x68, 0x6f,
+	0x73, 0x74, 0x73, 0x22, 0x97, 0x01, 0x0a, 0x1a, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x47,
+	0x69, 0x74, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x40, 0x0a, 0x04, 0x65, 0x6e, 0x76, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x2c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73,
+	0x73, 0x47, 0x69, 0x74, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x2e, 0x45, 0x6e, 0x76, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04,
+	0x65, 0x6e, 0x76, 0x73, 0x1a, 0x37, 0x0a, 0x09, 0x45, 0x6e, 0x76, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x3b, 0x0a,
+	0x19, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x6c, 0x61, 0x6e, 0x46, 0x72, 0x65, 0x73, 0x68, 0x6e,
+	0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x99, 0x01, 0x0a, 0x17, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x50, 0x6c, 0x61, 0x6e, 0x46, 0x72, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x73,
+	0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
+	0x70, 0x6c, 0x61, 0x6e, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0a, 0x70, 0x6c, 0x61, 0x6e, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x12, 0x20, 0x0a, 0x0b,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x12, 0x26,
+	0x0a, 0x0e, 0x6c, 0x69, 0x6e, 0x65, 0x61, 0x67, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x6c, 0x69, 0x6e, 0x65, 0x61, 0x67, 0x65, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x22, 0x6e, 0x0a, 0x1a, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x34, 0x0a, 0x18, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x19, 0x0a, 0x17,
+	0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x55, 0x73, 0x61, 0x67, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x61, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x70, 0x75, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x63, 0x70, 0x75, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73,
+	0x12, 0x28, 0x0a, 0x0f, 0x70, 0x65, 0x61, 0x6b, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x42, 0x79,
+	0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x70, 0x65, 0x61, 0x6b, 0x4d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0x36, 0x0a, 0x14, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x22, 0x8f, 0x01, 0x0a, 0x0d, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x70,
+	0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x63, 0x6f, 0x6d,
+	0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x74, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x64, 0x6f, 0x6e, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04,
+	0x64, 0x6f, 0x6e, 0x65, 0x22, 0x56, 0x0a, 0x18, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x56, 0x61, 0x72, 0x73, 0x46, 0x6f, 0x72, 0x54, 0x46, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
 	0x12, 0x1e, 0x0a, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72,
-	0x22, 0x31, 0x0a, 0x15, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70,
-	0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x22, 0x8d, 0x01, 0x0a, 0x0b, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x65, 0x63, 0x56, 0x61, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x65, 0x78, 0x65, 0x63, 0x56, 0x61, 0x72, 0x73, 0x22, 0x32, 0x0a, 0x16,
+	0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x56, 0x61, 0x72, 0x73, 0x46, 0x6f, 0x72, 0x54,
+	0x46, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x22, 0x39, 0x0a, 0x17, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70,
+	0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x77,
+	0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x22, 0x31, 0x0a, 0x15, 0x47,
+	0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xaf,
+	0x01, 0x0a, 0x0b, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e,
+	0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6f, 0x75, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65,
+	0x73, 0x74, 0x72, 0x6f, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x64, 0x65, 0x73,
+	0x74, 0x72, 0x6f, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x12, 0x20,
+	0x0a, 0x0b, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x4c, 0x6f, 0x63, 0x6b,
+	0x22, 0xb4, 0x03, 0x0a, 0x09, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18,
+	0x0a, 0x07, 0x64, 0x72, 0x69, 0x66, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x07, 0x64, 0x72, 0x69, 0x66, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x30, 0x0a, 0x13, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x49,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x13, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x66, 0x69, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x6c, 0x61, 0x6e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x70, 0x6c, 0x61, 0x6e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x41, 0x64, 0x64, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x41, 0x64, 0x64, 0x12, 0x1a, 0x0a, 0x08,
+	0x74, 0x6f, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x74, 0x6f, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x6f, 0x44, 0x65,
+	0x73, 0x74, 0x72, 0x6f, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x74, 0x6f, 0x44,
+	0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x12, 0x5b, 0x0a, 0x18, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62,
+	0x6c, 0x65, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65,
+	0x72, 0x2e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x18, 0x76, 0x61, 0x72, 0x69, 0x61,
+	0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x73, 0x12, 0x34, 0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69,
+	0x63, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65,
+	0x72, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x0b, 0x64, 0x69,
+	0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x3c, 0x0a, 0x0e, 0x73, 0x75, 0x73,
+	0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x4d, 0x6f, 0x76, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x0e, 0x73, 0x75, 0x73, 0x70, 0x65, 0x63, 0x74,
+	0x65, 0x64, 0x4d, 0x6f, 0x76, 0x65, 0x73, 0x22, 0x32, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74,
+	0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x22, 0x4f, 0x0a, 0x17, 0x56,
+	0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62,
+	0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62,
+	0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xa2, 0x01, 0x0a,
+	0x0a, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x12, 0x1a, 0x0a, 0x08, 0x73,
+	0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73,
+	0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61,
+	0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72,
+	0x79, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c,
+	0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c,
+	0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6c,
+	0x75, 0x6d, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d,
+	0x6e, 0x22, 0x51, 0x0a, 0x13, 0x53, 0x68, 0x6f, 0x77, 0x50, 0x6c, 0x61, 0x6e, 0x46, 0x69, 0x6c,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65,
+	0x6e, 0x61, 0x6d, 0x65, 0x22, 0x33, 0x0a, 0x11, 0x53, 0x68, 0x6f, 0x77, 0x50, 0x6c, 0x61, 0x6e,
+	0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x6a, 0x73, 0x6f,
+	0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x6a,
+	0x73, 0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0x54, 0x0a, 0x16, 0x53, 0x68, 0x6f,
+	0x77, 0x50, 0x6c, 0x61, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x61, 0x77, 0x52, 0x65, 0x71, 0x75,
 	0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
 	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61,
-	0x6e, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x6f, 0x75, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x12,
-	0x18, 0x0a, 0x07, 0x64, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x07, 0x64, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x61, 0x72,
-	0x67, 0x65, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x74, 0x61, 0x72, 0x67,
-	0x65, 0x74, 0x73, 0x22, 0x93, 0x01, 0x0a, 0x09, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x72, 0x69, 0x66, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x07, 0x64, 0x72, 0x69, 0x66, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x30, 0x0a, 0x13, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4c, 0x6f,
-	0x63, 0x6b, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x13, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x65,
-	0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x6c, 0x61, 0x6e, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x70, 0x6c,
-	0x61, 0x6e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x22, 0x51, 0x0a, 0x13, 0x53, 0x68, 0x6f,
-	0x77, 0x50, 0x6c, 0x61, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
-	0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x33, 0x0a, 0x11,
-	0x53, 0x68, 0x6f, 0x77, 0x50, 0x6c, 0x61, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x6a, 0x73, 0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x6a, 0x73, 0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75,
-	0x74, 0x22, 0x54, 0x0a, 0x16, 0x53, 0x68, 0x6f, 0x77, 0x50, 0x6c, 0x61, 0x6e, 0x46, 0x69, 0x6c,
-	0x65, 0x52, 0x61, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74,
+	0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x22,
+	0x34, 0x0a, 0x14, 0x53, 0x68, 0x6f, 0x77, 0x50, 0x6c, 0x61, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x52,
+	0x61, 0x77, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x61, 0x77, 0x4f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x61, 0x77, 0x4f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0xd1, 0x01, 0x0a, 0x11, 0x53, 0x61, 0x76, 0x65, 0x54, 0x46,
+	0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74,
 	0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66,
-	0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66,
-	0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x34, 0x0a, 0x14, 0x53, 0x68, 0x6f, 0x77, 0x50,
-	0x6c, 0x61, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x61, 0x77, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
-	0x1c, 0x0a, 0x09, 0x72, 0x61, 0x77, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x72, 0x61, 0x77, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0xd1, 0x01,
-	0x0a, 0x11, 0x53, 0x61, 0x76, 0x65, 0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61,
-	0x6e, 0x63, 0x65, 0x12, 0x3a, 0x0a, 0x18, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f,
-	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x6c, 0x79, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x18, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f,
-	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x6c, 0x79, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x12,
-	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
-	0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
-	0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
-	0x6e, 0x22, 0x2b, 0x0a, 0x0f, 0x53, 0x61, 0x76, 0x65, 0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xc3,
-	0x01, 0x0a, 0x11, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
-	0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74,
-	0x61, 0x6e, 0x63, 0x65, 0x12, 0x3a, 0x0a, 0x18, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43,
-	0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x6c, 0x79, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x18, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43,
-	0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x6c, 0x79, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65,
-	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
-	0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x6c, 0x61,
-	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
-	0x50, 0x6c, 0x61, 0x6e, 0x22, 0x2b, 0x0a, 0x0f, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x46, 0x50, 0x6c,
-	0x61, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x22, 0xb8, 0x01, 0x0a, 0x0c, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
-	0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x4f, 0x72, 0x50, 0x6c, 0x61, 0x6e, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x72, 0x4f, 0x72, 0x50, 0x6c, 0x61, 0x6e,
-	0x12, 0x2e, 0x0a, 0x12, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x42, 0x65, 0x66, 0x6f, 0x72,
-	0x65, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x72, 0x65,
-	0x66, 0x72, 0x65, 0x73, 0x68, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x41, 0x70, 0x70, 0x6c, 0x79,
-	0x12, 0x18, 0x0a, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
-	0x09, 0x52, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x61,
-	0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x69, 0x73, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x0b, 0x70, 0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x69, 0x73, 0x6d, 0x22, 0x58, 0x0a, 0x0a,
-	0x41, 0x70, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x12, 0x30, 0x0a, 0x13, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x63,
-	0x6b, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x13, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x65, 0x6e,
-	0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x22, 0x35, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76,
-	0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a,
+	0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x3a, 0x0a, 0x18, 0x62,
+	0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x6c, 0x79,
+	0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x18, 0x62,
+	0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x6c, 0x79,
+	0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x1a, 0x0a,
+	0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x57, 0x0a, 0x0f, 0x53, 0x61, 0x76,
+	0x65, 0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x70, 0x6c, 0x61, 0x6e, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x73, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x10, 0x70, 0x6c, 0x61, 0x6e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x22, 0xc3, 0x01, 0x0a, 0x11, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x46, 0x50, 0x6c, 0x61,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x3a, 0x0a, 0x18, 0x62, 0x61, 0x63, 0x6b,
+	0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x6c, 0x79, 0x44, 0x69, 0x73,
+	0x61, 0x62, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x18, 0x62, 0x61, 0x63, 0x6b,
+	0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x6c, 0x79, 0x44, 0x69, 0x73,
+	0x61, 0x62, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x50, 0x6c, 0x61, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x65, 0x6e,
+	0x64, 0x69, 0x6e, 0x67, 0x50, 0x6c, 0x61, 0x6e, 0x22, 0x2b, 0x0a, 0x0f, 0x4c, 0x6f, 0x61, 0x64,
+	0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xe2, 0x01, 0x0a, 0x0c, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x4f, 0x72, 0x50,
+	0x6c, 0x61, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x72, 0x4f, 0x72,
+	0x50, 0x6c, 0x61, 0x6e, 0x12, 0x2e, 0x0a, 0x12, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x42,
+	0x65, 0x66, 0x6f, 0x72, 0x65, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x12, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x41,
+	0x70, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x12, 0x20,
+	0x0a, 0x0b, 0x70, 0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x69, 0x73, 0x6d, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0b, 0x70, 0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x69, 0x73, 0x6d,
+	0x12, 0x28, 0x0a, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63,
+	0x74, 0x65, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0xd0, 0x01, 0x0a, 0x0a, 0x41,
+	0x70, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x30, 0x0a, 0x13, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x63, 0x6b,
+	0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x13, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x34, 0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73,
+	0x74, 0x69, 0x63, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x72, 0x75, 0x6e,
+	0x6e, 0x65, 0x72, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x0b,
+	0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x40, 0x0a, 0x0f, 0x72,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x52, 0x0f, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x5c, 0x0a,
+	0x0e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x12,
+	0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x07, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x4a, 0x0a, 0x0e, 0x52,
+	0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a,
 	0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x48, 0x0a,
-	0x11, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x12, 0x33, 0x0a, 0x0b, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x69, 0x65,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72,
-	0x2e, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x0b, 0x69, 0x6e, 0x76, 0x65,
-	0x6e, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x22, 0x53, 0x0a, 0x09, 0x49, 0x6e, 0x76, 0x65, 0x6e,
-	0x74, 0x6f, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
-	0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x22, 0x4a, 0x0a, 0x0e,
-	0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e,
-	0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x18,
-	0x0a, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x22, 0x5a, 0x0a, 0x0c, 0x44, 0x65, 0x73, 0x74,
-	0x72, 0x6f, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x22, 0x90, 0x01, 0x0a, 0x0c, 0x52, 0x65, 0x66, 0x72,
+	0x65, 0x73, 0x68, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
 	0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
 	0x67, 0x65, 0x12, 0x30, 0x0a, 0x13, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x49,
 	0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
 	0x13, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69,
-	0x66, 0x69, 0x65, 0x72, 0x22, 0x2f, 0x0a, 0x0d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61,
-	0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73,
-	0x74, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x99, 0x01, 0x0a, 0x0b, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3a, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e,
-	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x4f, 0x75, 0x74, 0x70,
-	0x75, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74,
-	0x73, 0x1a, 0x4e, 0x0a, 0x0c, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x6b, 0x65, 0x79, 0x12, 0x28, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x4f, 0x75, 0x74, 0x70,
-	0x75, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
-	0x01, 0x22, 0x54, 0x0a, 0x0a, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x12,
-	0x1c, 0x0a, 0x09, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x09, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x12, 0x12, 0x0a,
-	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x74, 0x79, 0x70,
-	0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xfb, 0x03, 0x0a, 0x13, 0x57, 0x72, 0x69, 0x74,
-	0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d,
-	0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x39, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x57, 0x72, 0x69,
-	0x74, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x2e, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61,
-	0x12, 0x3f, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x27, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4f,
-	0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x61,
-	0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c,
-	0x73, 0x12, 0x4e, 0x0a, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e,
+	0x66, 0x69, 0x65, 0x72, 0x12, 0x34, 0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74,
+	0x69, 0x63, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x72, 0x75, 0x6e, 0x6e,
+	0x65, 0x72, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x0b, 0x64,
+	0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x22, 0x35, 0x0a, 0x13, 0x47, 0x65,
+	0x74, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x22, 0x48, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72,
+	0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x33, 0x0a, 0x0b, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74,
+	0x6f, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x75,
+	0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x0b,
+	0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x22, 0x53, 0x0a, 0x09, 0x49,
+	0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72,
+	0x22, 0x4a, 0x0a, 0x0e, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x22, 0x5a, 0x0a, 0x0c,
+	0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x30, 0x0a, 0x13, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4c,
+	0x6f, 0x63, 0x6b, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x13, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x49, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x22, 0x2f, 0x0a, 0x0d, 0x4f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74,
+	0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x99, 0x01, 0x0a, 0x0b, 0x4f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3a, 0x0a, 0x07, 0x6f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x72, 0x75, 0x6e,
+	0x6e, 0x65, 0x72, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e,
+	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x6f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x73, 0x1a, 0x4e, 0x0a, 0x0c, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x28, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e,
+	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x54, 0x0a, 0x0a, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4d,
+	0x65, 0x74, 0x61, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x99, 0x04, 0x0a, 0x13,
 	0x57, 0x72, 0x69, 0x74, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x1a, 0x37, 0x0a, 0x09, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
-	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
-	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61,
-	0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3e, 0x0a, 0x10, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x47, 0x0a, 0x11, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4f, 0x75,
-	0x74, 0x70, 0x75, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x22, 0x51,
-	0x0a, 0x11, 0x47, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
 	0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
-	0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d,
-	0x65, 0x22, 0x8d, 0x01, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3e, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e,
-	0x47, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e,
-	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x6f, 0x75,
-	0x74, 0x70, 0x75, 0x74, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
-	0x01, 0x22, 0x65, 0x0a, 0x0b, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
-	0x12, 0x18, 0x0a, 0x07, 0x75, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x07, 0x75, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x6f,
-	0x72, 0x63, 0x65, 0x43, 0x6f, 0x70, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x66,
-	0x6f, 0x72, 0x63, 0x65, 0x43, 0x6f, 0x70, 0x79, 0x22, 0x57, 0x0a, 0x09, 0x49, 0x6e, 0x69, 0x74,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
-	0x30, 0x0a, 0x13, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x65, 0x6e,
-	0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x73, 0x74,
-	0x61, 0x74, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65,
-	0x72, 0x22, 0x32, 0x0a, 0x10, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x52, 0x65,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e,
+	0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x39, 0x0a, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72,
+	0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x3f, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x06,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x57, 0x72,
+	0x69, 0x74, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x4e, 0x0a, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x72, 0x75, 0x6e,
+	0x6e, 0x65, 0x72, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75,
+	0x6d, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73,
+	0x75, 0x6d, 0x73, 0x1a, 0x37, 0x0a, 0x09, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x39, 0x0a, 0x0b,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3e, 0x0a, 0x10, 0x41, 0x6e, 0x6e, 0x6f, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x69, 0x0a, 0x11, 0x57, 0x72, 0x69, 0x74, 0x65,
+	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64,
+	0x12, 0x20, 0x0a, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x4b, 0x65,
+	0x79, 0x73, 0x22, 0x51, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x8d, 0x01, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3e, 0x0a, 0x07, 0x6f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x72, 0x75, 0x6e,
+	0x6e, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x4f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x8d, 0x01, 0x0a, 0x0b, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65,
 	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61,
 	0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73,
-	0x74, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x2a, 0x0a, 0x0e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61,
-	0x63, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x75, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x43, 0x6f, 0x70, 0x79, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x09, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x43, 0x6f, 0x70, 0x79, 0x12, 0x26, 0x0a,
+	0x0e, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x4c, 0x6f, 0x63, 0x6b, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63,
+	0x79, 0x4c, 0x6f, 0x63, 0x6b, 0x22, 0x9b, 0x01, 0x0a, 0x09, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x30, 0x0a,
+	0x13, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x66, 0x69, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12,
+	0x26, 0x0a, 0x0e, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x4c, 0x6f, 0x63,
+	0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65,
+	0x6e, 0x63, 0x79, 0x4c, 0x6f, 0x63, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x70, 0x67, 0x72, 0x61,
+	0x64, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x75, 0x70, 0x67, 0x72, 0x61,
+	0x64, 0x65, 0x64, 0x22, 0x32, 0x0a, 0x10, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x2a, 0x0a, 0x0e, 0x57, 0x6f, 0x72, 0x6b, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x22, 0x23, 0x0a, 0x0d, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6c, 0x6f, 0x62, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x04, 0x62, 0x6c, 0x6f, 0x62, 0x22, 0x27, 0x0a, 0x0b, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
 	0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x22, 0x23, 0x0a, 0x0d, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6c, 0x6f, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x04, 0x62, 0x6c, 0x6f, 0x62, 0x22, 0x27, 0x0a, 0x0b, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
-	0xd0, 0x01, 0x0a, 0x16, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x53, 0x65, 0x63, 0x72,
-	0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61,
-	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e,
-	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09,
-	0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x3a, 0x0a, 0x18, 0x68, 0x61,
-	0x73, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74,
-	0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x18, 0x68, 0x61,
-	0x73, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74,
-	0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x2a, 0x0a, 0x10, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74,
-	0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x10, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61,
-	0x6d, 0x65, 0x22, 0x4c, 0x0a, 0x14, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x53, 0x65,
+	0x65, 0x22, 0xae, 0x02, 0x0a, 0x16, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c,
+	0x0a, 0x09, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x3a, 0x0a, 0x18,
+	0x68, 0x61, 0x73, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x18,
+	0x68, 0x61, 0x73, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x2a, 0x0a, 0x10, 0x6f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x10, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63,
+	0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x32,
+	0x0a, 0x14, 0x73, 0x74, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x14, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x22, 0x4c, 0x0a, 0x14, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x53, 0x65,
 	0x63, 0x72, 0x65, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
 	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
 	0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x6f, 0x74, 0x46, 0x6f, 0x75, 0x6e, 0x64,
@@ -3511,136 +5331,235 @@ var file_runner_runner_proto_rawDesc = []byte{
 	0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18,
 	0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x32, 0xf5, 0x0f, 0x0a, 0x06, 0x52, 0x75, 0x6e,
-	0x6e, 0x65, 0x72, 0x12, 0x3c, 0x0a, 0x08, 0x4c, 0x6f, 0x6f, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x12,
-	0x17, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x4c, 0x6f, 0x6f, 0x6b, 0x50, 0x61, 0x74,
-	0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65,
-	0x72, 0x2e, 0x4c, 0x6f, 0x6f, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
-	0x00, 0x12, 0x48, 0x0a, 0x0c, 0x4e, 0x65, 0x77, 0x54, 0x65, 0x72, 0x72, 0x61, 0x66, 0x6f, 0x72,
-	0x6d, 0x12, 0x1b, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x4e, 0x65, 0x77, 0x54, 0x65,
-	0x72, 0x72, 0x61, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
-	0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x4e, 0x65, 0x77, 0x54, 0x65, 0x72, 0x72, 0x61,
-	0x66, 0x6f, 0x72, 0x6d, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x06, 0x53,
-	0x65, 0x74, 0x45, 0x6e, 0x76, 0x12, 0x15, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53,
-	0x65, 0x74, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x72,
-	0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x22, 0x00, 0x12, 0x5a, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c,
-	0x65, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x21, 0x2e, 0x72, 0x75, 0x6e, 0x6e,
-	0x65, 0x72, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x70,
-	0x70, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x72,
-	0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65,
-	0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
-	0x54, 0x0a, 0x10, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x6e, 0x64, 0x45, 0x78, 0x74, 0x72,
-	0x61, 0x63, 0x74, 0x12, 0x1f, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x55, 0x70, 0x6c,
-	0x6f, 0x61, 0x64, 0x41, 0x6e, 0x64, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x55, 0x70,
-	0x6c, 0x6f, 0x61, 0x64, 0x41, 0x6e, 0x64, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x65,
-	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0a, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70,
-	0x44, 0x69, 0x72, 0x12, 0x19, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x43, 0x6c, 0x65,
-	0x61, 0x6e, 0x75, 0x70, 0x44, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17,
-	0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x44,
-	0x69, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5a, 0x0a, 0x12, 0x57, 0x72, 0x69,
-	0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12,
-	0x21, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61,
-	0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x57, 0x72, 0x69, 0x74,
-	0x65, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65,
-	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x10, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
-	0x43, 0x6c, 0x69, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1f, 0x2e, 0x72, 0x75, 0x6e, 0x6e,
-	0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x43, 0x6c, 0x69, 0x43, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x72, 0x75, 0x6e,
-	0x6e, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x43, 0x6c, 0x69, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x11, 0x47,
-	0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x56, 0x61, 0x72, 0x73, 0x46, 0x6f, 0x72, 0x54, 0x46,
-	0x12, 0x20, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61,
-	0x74, 0x65, 0x56, 0x61, 0x72, 0x73, 0x46, 0x6f, 0x72, 0x54, 0x46, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x6e, 0x65,
-	0x72, 0x61, 0x74, 0x65, 0x56, 0x61, 0x72, 0x73, 0x46, 0x6f, 0x72, 0x54, 0x46, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x10, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
-	0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x1f, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65,
-	0x72, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61,
-	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x72, 0x75, 0x6e, 0x6e,
-	0x65, 0x72, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c,
-	0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x30, 0x0a, 0x04, 0x50, 0x6c,
-	0x61, 0x6e, 0x12, 0x13, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x50, 0x6c, 0x61, 0x6e,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72,
-	0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x0f,
-	0x53, 0x68, 0x6f, 0x77, 0x50, 0x6c, 0x61, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x61, 0x77, 0x12,
-	0x1e, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x68, 0x6f, 0x77, 0x50, 0x6c, 0x61,
-	0x6e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x61, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x1c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x68, 0x6f, 0x77, 0x50, 0x6c, 0x61,
-	0x6e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x61, 0x77, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
-	0x48, 0x0a, 0x0c, 0x53, 0x68, 0x6f, 0x77, 0x50, 0x6c, 0x61, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x12,
-	0x1b, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x68, 0x6f, 0x77, 0x50, 0x6c, 0x61,
-	0x6e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x72,
-	0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x68, 0x6f, 0x77, 0x50, 0x6c, 0x61, 0x6e, 0x46, 0x69,
-	0x6c, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0a, 0x53, 0x61, 0x76,
-	0x65, 0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x12, 0x19, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72,
-	0x2e, 0x53, 0x61, 0x76, 0x65, 0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x17, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x61, 0x76, 0x65,
-	0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x42, 0x0a,
-	0x0a, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x12, 0x19, 0x2e, 0x72, 0x75,
-	0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e,
-	0x4c, 0x6f, 0x61, 0x64, 0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
-	0x00, 0x12, 0x33, 0x0a, 0x05, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x12, 0x14, 0x2e, 0x72, 0x75, 0x6e,
-	0x6e, 0x65, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x12, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76,
-	0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x1b, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e,
-	0x47, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74,
-	0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
-	0x12, 0x39, 0x0a, 0x07, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x12, 0x16, 0x2e, 0x72, 0x75,
-	0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x73,
-	0x74, 0x72, 0x6f, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x06, 0x4f,
-	0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x15, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x4f,
-	0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x72,
-	0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0c, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4f, 0x75, 0x74, 0x70,
-	0x75, 0x74, 0x73, 0x12, 0x1b, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x57, 0x72, 0x69,
-	0x74, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x19, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4f,
-	0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x42, 0x0a,
-	0x0a, 0x47, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x12, 0x19, 0x2e, 0x72, 0x75,
-	0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x52,
+	0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x37, 0x0a, 0x17, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
+	0x73, 0x22, 0x9b, 0x01, 0x0a, 0x13, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x72,
+	0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x69,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x65, 0x73, 0x41, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
+	0x56, 0x0a, 0x15, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x61, 0x6c, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3d, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43,
+	0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x52, 0x0b, 0x63, 0x72, 0x65, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x22, 0x88, 0x03, 0x0a, 0x14, 0x45, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4d, 0x61, 0x70, 0x4e,
+	0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x4d, 0x61, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x3a, 0x0a, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x72, 0x75, 0x6e,
+	0x6e, 0x65, 0x72, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x4f, 0x0a, 0x0b, 0x61, 0x6e, 0x6e, 0x6f,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e,
+	0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x41, 0x6e, 0x6e, 0x6f,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x61, 0x6e,
+	0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x37, 0x0a, 0x09, 0x44, 0x61, 0x74,
+	0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x1a, 0x3e, 0x0a, 0x10, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x2e, 0x0a, 0x12, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x32, 0xbc, 0x16, 0x0a, 0x06, 0x52, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x12, 0x42, 0x0a,
+	0x0a, 0x47, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x72, 0x75,
+	0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e,
-	0x47, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
-	0x00, 0x12, 0x30, 0x0a, 0x04, 0x49, 0x6e, 0x69, 0x74, 0x12, 0x13, 0x2e, 0x72, 0x75, 0x6e, 0x6e,
-	0x65, 0x72, 0x2e, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11,
-	0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0f, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x57, 0x6f, 0x72,
-	0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x18, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e,
-	0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x16, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70,
-	0x61, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x06, 0x55, 0x70,
-	0x6c, 0x6f, 0x61, 0x64, 0x12, 0x15, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x55, 0x70,
-	0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x72, 0x75,
-	0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x22, 0x00, 0x12, 0x51, 0x0a, 0x0f, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x53, 0x65,
-	0x63, 0x72, 0x65, 0x74, 0x73, 0x12, 0x1e, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x46,
-	0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x46,
-	0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x52, 0x65,
-	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0b, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x55, 0x6e,
-	0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1a, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x46, 0x6f,
-	0x72, 0x63, 0x65, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x18, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x55,
-	0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x19,
-	0x53, 0x74, 0x61, 0x72, 0x74, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x54, 0x68, 0x65, 0x47, 0x6c, 0x61,
-	0x73, 0x73, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x2e, 0x72, 0x75, 0x6e, 0x6e,
-	0x65, 0x72, 0x2e, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x54, 0x68, 0x65, 0x47, 0x6c, 0x61, 0x73, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72,
+	0x47, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x3c, 0x0a, 0x08, 0x4c, 0x6f, 0x6f, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x12, 0x17, 0x2e,
+	0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x4c, 0x6f, 0x6f, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e,
+	0x4c, 0x6f, 0x6f, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
+	0x48, 0x0a, 0x0c, 0x4e, 0x65, 0x77, 0x54, 0x65, 0x72, 0x72, 0x61, 0x66, 0x6f, 0x72, 0x6d, 0x12,
+	0x1b, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x4e, 0x65, 0x77, 0x54, 0x65, 0x72, 0x72,
+	0x61, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x72,
+	0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x4e, 0x65, 0x77, 0x54, 0x65, 0x72, 0x72, 0x61, 0x66, 0x6f,
+	0x72, 0x6d, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x06, 0x53, 0x65, 0x74,
+	0x45, 0x6e, 0x76, 0x12, 0x15, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x74,
+	0x45, 0x6e, 0x76, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x72, 0x75, 0x6e,
+	0x6e, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x5a, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x4d,
+	0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x21, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x70, 0x69,
+	0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x72, 0x75, 0x6e,
+	0x6e, 0x65, 0x72, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x61,
+	0x70, 0x70, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x54, 0x0a,
+	0x10, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x6e, 0x64, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63,
+	0x74, 0x12, 0x1f, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x41, 0x6e, 0x64, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x41, 0x6e, 0x64, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0a, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x44, 0x69,
+	0x72, 0x12, 0x19, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x43, 0x6c, 0x65, 0x61, 0x6e,
+	0x75, 0x70, 0x44, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x72,
+	0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x44, 0x69, 0x72,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5a, 0x0a, 0x12, 0x57, 0x72, 0x69, 0x74, 0x65,
+	0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x21, 0x2e,
+	0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61, 0x63, 0x6b,
+	0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1f, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42,
+	0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x12, 0x63, 0x0a, 0x15, 0x57, 0x72, 0x69, 0x74, 0x65, 0x53, 0x69, 0x6d, 0x75,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x24, 0x2e, 0x72,
+	0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x53, 0x69, 0x6d, 0x75, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x22, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x57, 0x72, 0x69, 0x74,
+	0x65, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x10, 0x50, 0x72, 0x6f, 0x63,
+	0x65, 0x73, 0x73, 0x43, 0x6c, 0x69, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1f, 0x2e, 0x72,
+	0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x43, 0x6c, 0x69,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e,
+	0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x43, 0x6c,
+	0x69, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x63,
+	0x0a, 0x15, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x47, 0x69, 0x74, 0x43, 0x72, 0x65, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x12, 0x24, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72,
+	0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x47, 0x69, 0x74, 0x43, 0x72, 0x65, 0x64, 0x65,
+	0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e,
+	0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x47, 0x69,
+	0x74, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x12, 0x5a, 0x0a, 0x12, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x6c, 0x61, 0x6e,
+	0x46, 0x72, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x21, 0x2e, 0x72, 0x75, 0x6e, 0x6e,
+	0x65, 0x72, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x6c, 0x61, 0x6e, 0x46, 0x72, 0x65, 0x73,
+	0x68, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x72,
+	0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x6c, 0x61, 0x6e, 0x46,
+	0x72, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
+	0x5d, 0x0a, 0x13, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79,
+	0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x22, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e,
+	0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x4d, 0x6f, 0x64,
+	0x75, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x72, 0x75, 0x6e,
+	0x6e, 0x65, 0x72, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72,
+	0x79, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x57,
+	0x0a, 0x11, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x56, 0x61, 0x72, 0x73, 0x46, 0x6f,
+	0x72, 0x54, 0x46, 0x12, 0x20, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x6e,
+	0x65, 0x72, 0x61, 0x74, 0x65, 0x56, 0x61, 0x72, 0x73, 0x46, 0x6f, 0x72, 0x54, 0x46, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47,
+	0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x56, 0x61, 0x72, 0x73, 0x46, 0x6f, 0x72, 0x54, 0x46,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x10, 0x47, 0x65, 0x6e, 0x65, 0x72,
+	0x61, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x1f, 0x2e, 0x72, 0x75,
+	0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6d,
+	0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x72,
+	0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x54, 0x65,
+	0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x30, 0x0a,
+	0x04, 0x50, 0x6c, 0x61, 0x6e, 0x12, 0x13, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x50,
+	0x6c, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x72, 0x75, 0x6e,
+	0x6e, 0x65, 0x72, 0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
+	0x51, 0x0a, 0x0f, 0x53, 0x68, 0x6f, 0x77, 0x50, 0x6c, 0x61, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x52,
+	0x61, 0x77, 0x12, 0x1e, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x68, 0x6f, 0x77,
+	0x50, 0x6c, 0x61, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x61, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x68, 0x6f, 0x77,
+	0x50, 0x6c, 0x61, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x61, 0x77, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x48, 0x0a, 0x0c, 0x53, 0x68, 0x6f, 0x77, 0x50, 0x6c, 0x61, 0x6e, 0x46, 0x69,
+	0x6c, 0x65, 0x12, 0x1b, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x68, 0x6f, 0x77,
+	0x50, 0x6c, 0x61, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x19, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x68, 0x6f, 0x77, 0x50, 0x6c, 0x61,
+	0x6e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0a,
+	0x53, 0x61, 0x76, 0x65, 0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x12, 0x19, 0x2e, 0x72, 0x75, 0x6e,
+	0x6e, 0x65, 0x72, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53,
+	0x61, 0x76, 0x65, 0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x12, 0x42, 0x0a, 0x0a, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x12, 0x19,
+	0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x46, 0x50, 0x6c,
+	0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x72, 0x75, 0x6e, 0x6e,
+	0x65, 0x72, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x22, 0x00, 0x12, 0x33, 0x0a, 0x05, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x12, 0x14, 0x2e,
+	0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x41, 0x70, 0x70,
+	0x6c, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x07, 0x52, 0x65, 0x66,
+	0x72, 0x65, 0x73, 0x68, 0x12, 0x16, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x52, 0x65,
+	0x66, 0x72, 0x65, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x72,
+	0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x65, 0x6e,
+	0x74, 0x6f, 0x72, 0x79, 0x12, 0x1b, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47, 0x65,
+	0x74, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x19, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e,
+	0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x39,
+	0x0a, 0x07, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x12, 0x16, 0x2e, 0x72, 0x75, 0x6e, 0x6e,
+	0x65, 0x72, 0x2e, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x14, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x73, 0x74, 0x72,
+	0x6f, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x06, 0x4f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x12, 0x15, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x4f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x72, 0x75, 0x6e,
+	0x6e, 0x65, 0x72, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x48, 0x0a, 0x0c, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x73, 0x12, 0x1b, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65,
+	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
+	0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0a, 0x47,
+	0x65, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x12, 0x19, 0x2e, 0x72, 0x75, 0x6e, 0x6e,
+	0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47, 0x65,
+	0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
+	0x30, 0x0a, 0x04, 0x49, 0x6e, 0x69, 0x74, 0x12, 0x13, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72,
+	0x2e, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x72,
+	0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x45, 0x0a, 0x0f, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x12, 0x18, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x57, 0x6f,
+	0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
+	0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x06, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x12, 0x15, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x72, 0x75, 0x6e, 0x6e,
+	0x65, 0x72, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x12, 0x51, 0x0a, 0x0f, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x53, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x73, 0x12, 0x1e, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x46, 0x69, 0x6e,
+	0x61, 0x6c, 0x69, 0x7a, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x46, 0x69, 0x6e,
+	0x61, 0x6c, 0x69, 0x7a, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0b, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x55, 0x6e, 0x6c, 0x6f,
+	0x63, 0x6b, 0x12, 0x1a, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x46, 0x6f, 0x72, 0x63,
+	0x65, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18,
+	0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x55, 0x6e, 0x6c,
+	0x6f, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x19, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x54, 0x68, 0x65, 0x47, 0x6c, 0x61, 0x73, 0x73,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72,
 	0x2e, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x54, 0x68, 0x65, 0x47, 0x6c, 0x61, 0x73, 0x73, 0x52, 0x65,
-	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x59, 0x0a, 0x1b, 0x48, 0x61, 0x73, 0x42, 0x72, 0x65, 0x61,
-	0x6b, 0x54, 0x68, 0x65, 0x47, 0x6c, 0x61, 0x73, 0x73, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
-	0x44, 0x6f, 0x6e, 0x65, 0x12, 0x1c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x42, 0x72,
-	0x65, 0x61, 0x6b, 0x54, 0x68, 0x65, 0x47, 0x6c, 0x61, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x42, 0x72, 0x65, 0x61,
-	0x6b, 0x54, 0x68, 0x65, 0x47, 0x6c, 0x61, 0x73, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
-	0x42, 0x08, 0x5a, 0x06, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x42,
+	0x72, 0x65, 0x61, 0x6b, 0x54, 0x68, 0x65, 0x47, 0x6c, 0x61, 0x73, 0x73, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x12, 0x59, 0x0a, 0x1b, 0x48, 0x61, 0x73, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x54,
+	0x68, 0x65, 0x47, 0x6c, 0x61, 0x73, 0x73, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x6f,
+	0x6e, 0x65, 0x12, 0x1c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x42, 0x72, 0x65, 0x61,
+	0x6b, 0x54, 0x68, 0x65, 0x47, 0x6c, 0x61, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1a, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x54,
+	0x68, 0x65, 0x47, 0x6c, 0x61, 0x73, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x54,
+	0x0a, 0x10, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61,
+	0x6c, 0x73, 0x12, 0x1f, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0d, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x1c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x45,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x45, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x48, 0x0a, 0x0d, 0x57, 0x61, 0x74, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x12, 0x1c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x15, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x30, 0x01, 0x12, 0x54, 0x0a, 0x10, 0x47,
+	0x65, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x1f, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1d, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x42, 0x08, 0x5a, 0x06, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -3655,143 +5574,201 @@ func file_runner_runner_proto_rawDescGZIP() []byte {
 	return file_runner_runner_proto_rawDescData
 }
 
-var file_runner_runner_proto_msgTypes = make([]protoimpl.MessageInfo, 63)
+var file_runner_runner_proto_msgTypes = make([]protoimpl.MessageInfo, 91)
 var file_runner_runner_proto_goTypes = []interface{}{
-	(*LookPathRequest)(nil),           // 0: runner.LookPathRequest
-	(*LookPathReply)(nil),             // 1: runner.LookPathReply
-	(*NewTerraformRequest)(nil),       // 2: runner.NewTerraformRequest
-	(*NewTerraformReply)(nil),         // 3: runner.NewTerraformReply
-	(*SetEnvRequest)(nil),             // 4: runner.SetEnvRequest
-	(*SetEnvReply)(nil),               // 5: runner.SetEnvReply
-	(*FileMapping)(nil),               // 6: runner.fileMapping
-	(*CreateFileMappingsRequest)(nil), // 7: runner.CreateFileMappingsRequest
-	(*CreateFileMappingsReply)(nil),   // 8: runner.CreateFileMappingsReply
-	(*UploadAndExtractRequest)(nil),   // 9: runner.UploadAndExtractRequest
-	(*UploadAndExtractReply)(nil),     // 10: runner.UploadAndExtractReply
-	(*CleanupDirRequest)(nil),         // 11: runner.CleanupDirRequest
-	(*CleanupDirReply)(nil),           // 12: runner.CleanupDirReply
-	(*WriteBackendConfigRequest)(nil), // 13: runner.WriteBackendConfigRequest
-	(*WriteBackendConfigReply)(nil),   // 14: runner.WriteBackendConfigReply
-	(*ProcessCliConfigRequest)(nil),   // 15: runner.ProcessCliConfigRequest
-	(*ProcessCliConfigReply)(nil),     // 16: runner.ProcessCliConfigReply
-	(*GenerateVarsForTFRequest)(nil),  // 17: runner.GenerateVarsForTFRequest
-	(*GenerateVarsForTFReply)(nil),    // 18: runner.GenerateVarsForTFReply
-	(*GenerateTemplateRequest)(nil),   // 19: runner.GenerateTemplateRequest
-	(*GenerateTemplateReply)(nil),     // 20: runner.GenerateTemplateReply
-	(*PlanRequest)(nil),               // 21: runner.PlanRequest
-	(*PlanReply)(nil),                 // 22: runner.PlanReply
-	(*ShowPlanFileRequest)(nil),       // 23: runner.ShowPlanFileRequest
-	(*ShowPlanFileReply)(nil),         // 24: runner.ShowPlanFileReply
-	(*ShowPlanFileRawRequest)(nil),    // 25: runner.ShowPlanFileRawRequest
-	(*ShowPlanFileRawReply)(nil),      // 26: runner.ShowPlanFileRawReply
-	(*SaveTFPlanRequest)(nil),         // 27: runner.SaveTFPlanRequest
-	(*SaveTFPlanReply)(nil),           // 28: runner.SaveTFPlanReply
-	(*LoadTFPlanRequest)(nil),         // 29: runner.LoadTFPlanRequest
-	(*LoadTFPlanReply)(nil),           // 30: runner.LoadTFPlanReply
-	(*ApplyRequest)(nil),              // 31: runner.ApplyRequest
-	(*ApplyReply)(nil),                // 32: runner.ApplyReply
-	(*GetInventoryRequest)(nil),       // 33: runner.GetInventoryRequest
-	(*GetInventoryReply)(nil),         // 34: runner.GetInventoryReply
-	(*Inventory)(nil),                 // 35: runner.Inventory
-	(*DestroyRequest)(nil),            // 36: runner.DestroyRequest
-	(*DestroyReply)(nil),              // 37: runner.DestroyReply
-	(*OutputRequest)(nil),             // 38: runner.OutputRequest
-	(*OutputReply)(nil),               // 39: runner.OutputReply
-	(*OutputMeta)(nil),                // 40: runner.OutputMeta
-	(*WriteOutputsRequest)(nil),       // 41: runner.WriteOutputsRequest
-	(*WriteOutputsReply)(nil),         // 42: runner.WriteOutputsReply
-	(*GetOutputsRequest)(nil),         // 43: runner.GetOutputsRequest
-	(*GetOutputsReply)(nil),           // 44: runner.GetOutputsReply
-	(*InitRequest)(nil),               // 45: runner.InitRequest
-	(*InitReply)(nil),                 // 46: runner.InitReply
-	(*WorkspaceRequest)(nil),          // 47: runner.WorkspaceRequest
-	(*WorkspaceReply)(nil),            // 48: runner.WorkspaceReply
-	(*UploadRequest)(nil),             // 49: runner.UploadRequest
-	(*UploadReply)(nil),               // 50: runner.UploadReply
-	(*FinalizeSecretsRequest)(nil),    // 51: runner.FinalizeSecretsRequest
-	(*FinalizeSecretsReply)(nil),      // 52: runner.FinalizeSecretsReply
-	(*ForceUnlockRequest)(nil),        // 53: runner.ForceUnlockRequest
-	(*ForceUnlockReply)(nil),          // 54: runner.ForceUnlockReply
-	(*BreakTheGlassRequest)(nil),      // 55: runner.BreakTheGlassRequest
-	(*BreakTheGlassReply)(nil),        // 56: runner.BreakTheGlassReply
-	nil,                               // 57: runner.SetEnvRequest.EnvsEntry
-	nil,                               // 58: runner.OutputReply.OutputsEntry
-	nil,                               // 59: runner.WriteOutputsRequest.DataEntry
-	nil,                               // 60: runner.WriteOutputsRequest.LabelsEntry
-	nil,                               // 61: runner.WriteOutputsRequest.AnnotationsEntry
-	nil,                               // 62: runner.GetOutputsReply.OutputsEntry
+	(*GetVersionRequest)(nil),            // 0: runner.GetVersionRequest
+	(*GetVersionReply)(nil),              // 1: runner.GetVersionReply
+	(*LookPathRequest)(nil),              // 2: runner.LookPathRequest
+	(*LookPathReply)(nil),                // 3: runner.LookPathReply
+	(*NewTerraformRequest)(nil),          // 4: runner.NewTerraformRequest
+	(*NewTerraformReply)(nil),            // 5: runner.NewTerraformReply
+	(*SetEnvRequest)(nil),                // 6: runner.SetEnvRequest
+	(*SetEnvReply)(nil),                  // 7: runner.SetEnvReply
+	(*FileMapping)(nil),                  // 8: runner.fileMapping
+	(*CreateFileMappingsRequest)(nil),    // 9: runner.CreateFileMappingsRequest
+	(*CreateFileMappingsReply)(nil),      // 10: runner.CreateFileMappingsReply
+	(*UploadAndExtractRequest)(nil),      // 11: runner.UploadAndExtractRequest
+	(*UploadAndExtractReply)(nil),        // 12: runner.UploadAndExtractReply
+	(*CleanupDirRequest)(nil),            // 13: runner.CleanupDirRequest
+	(*CleanupDirReply)(nil),              // 14: runner.CleanupDirReply
+	(*WriteBackendConfigRequest)(nil),    // 15: runner.WriteBackendConfigRequest
+	(*WriteBackendConfigReply)(nil),      // 16: runner.WriteBackendConfigReply
+	(*WriteSimulationConfigRequest)(nil), // 17: runner.WriteSimulationConfigRequest
+	(*WriteSimulationConfigReply)(nil),   // 18: runner.WriteSimulationConfigReply
+	(*ProcessCliConfigRequest)(nil),      // 19: runner.ProcessCliConfigRequest
+	(*ProcessCliConfigReply)(nil),        // 20: runner.ProcessCliConfigReply
+	(*ProcessGitCredentialsRequest)(nil), // 21: runner.ProcessGitCredentialsRequest
+	(*ProcessGitCredentialsReply)(nil),   // 22: runner.ProcessGitCredentialsReply
+	(*CheckPlanFreshnessRequest)(nil),    // 23: runner.CheckPlanFreshnessRequest
+	(*CheckPlanFreshnessReply)(nil),      // 24: runner.CheckPlanFreshnessReply
+	(*FetchRegistryModuleRequest)(nil),   // 25: runner.FetchRegistryModuleRequest
+	(*FetchRegistryModuleReply)(nil),     // 26: runner.FetchRegistryModuleReply
+	(*GetResourceUsageRequest)(nil),      // 27: runner.GetResourceUsageRequest
+	(*GetResourceUsageReply)(nil),        // 28: runner.GetResourceUsageReply
+	(*WatchProgressRequest)(nil),         // 29: runner.WatchProgressRequest
+	(*ProgressReply)(nil),                // 30: runner.ProgressReply
+	(*GenerateVarsForTFRequest)(nil),     // 31: runner.GenerateVarsForTFRequest
+	(*GenerateVarsForTFReply)(nil),       // 32: runner.GenerateVarsForTFReply
+	(*GenerateTemplateRequest)(nil),      // 33: runner.GenerateTemplateRequest
+	(*GenerateTemplateReply)(nil),        // 34: runner.GenerateTemplateReply
+	(*PlanRequest)(nil),                  // 35: runner.PlanRequest
+	(*PlanReply)(nil),                    // 36: runner.PlanReply
+	(*ResourceMove)(nil),                 // 37: runner.ResourceMove
+	(*VariableValidationError)(nil),      // 38: runner.VariableValidationError
+	(*Diagnostic)(nil),                   // 39: runner.Diagnostic
+	(*ShowPlanFileRequest)(nil),          // 40: runner.ShowPlanFileRequest
+	(*ShowPlanFileReply)(nil),            // 41: runner.ShowPlanFileReply
+	(*ShowPlanFileRawRequest)(nil),       // 42: runner.ShowPlanFileRawRequest
+	(*ShowPlanFileRawReply)(nil),         // 43: runner.ShowPlanFileRawReply
+	(*SaveTFPlanRequest)(nil),            // 44: runner.SaveTFPlanRequest
+	(*SaveTFPlanReply)(nil),              // 45: runner.SaveTFPlanReply
+	(*LoadTFPlanRequest)(nil),            // 46: runner.LoadTFPlanRequest
+	(*LoadTFPlanReply)(nil),              // 47: runner.LoadTFPlanReply
+	(*ApplyRequest)(nil),                 // 48: runner.ApplyRequest
+	(*ApplyReply)(nil),                   // 49: runner.ApplyReply
+	(*ResourceTiming)(nil),               // 50: runner.ResourceTiming
+	(*RefreshRequest)(nil),               // 51: runner.RefreshRequest
+	(*RefreshReply)(nil),                 // 52: runner.RefreshReply
+	(*GetInventoryRequest)(nil),          // 53: runner.GetInventoryRequest
+	(*GetInventoryReply)(nil),            // 54: runner.GetInventoryReply
+	(*Inventory)(nil),                    // 55: runner.Inventory
+	(*DestroyRequest)(nil),               // 56: runner.DestroyRequest
+	(*DestroyReply)(nil),                 // 57: runner.DestroyReply
+	(*OutputRequest)(nil),                // 58: runner.OutputRequest
+	(*OutputReply)(nil),                  // 59: runner.OutputReply
+	(*OutputMeta)(nil),                   // 60: runner.OutputMeta
+	(*WriteOutputsRequest)(nil),          // 61: runner.WriteOutputsRequest
+	(*WriteOutputsReply)(nil),            // 62: runner.WriteOutputsReply
+	(*GetOutputsRequest)(nil),            // 63: runner.GetOutputsRequest
+	(*GetOutputsReply)(nil),              // 64: runner.GetOutputsReply
+	(*InitRequest)(nil),                  // 65: runner.InitRequest
+	(*InitReply)(nil),                    // 66: runner.InitReply
+	(*WorkspaceRequest)(nil),             // 67: runner.WorkspaceRequest
+	(*WorkspaceReply)(nil),               // 68: runner.WorkspaceReply
+	(*UploadRequest)(nil),                // 69: runner.UploadRequest
+	(*UploadReply)(nil),                  // 70: runner.UploadReply
+	(*FinalizeSecretsRequest)(nil),       // 71: runner.FinalizeSecretsRequest
+	(*FinalizeSecretsReply)(nil),         // 72: runner.FinalizeSecretsReply
+	(*ForceUnlockRequest)(nil),           // 73: runner.ForceUnlockRequest
+	(*ForceUnlockReply)(nil),             // 74: runner.ForceUnlockReply
+	(*BreakTheGlassRequest)(nil),         // 75: runner.BreakTheGlassRequest
+	(*BreakTheGlassReply)(nil),           // 76: runner.BreakTheGlassReply
+	(*CheckCredentialsRequest)(nil),      // 77: runner.CheckCredentialsRequest
+	(*ProviderCredentials)(nil),          // 78: runner.ProviderCredentials
+	(*CheckCredentialsReply)(nil),        // 79: runner.CheckCredentialsReply
+	(*ExportResultsRequest)(nil),         // 80: runner.ExportResultsRequest
+	(*ExportResultsReply)(nil),           // 81: runner.ExportResultsReply
+	nil,                                  // 82: runner.SetEnvRequest.EnvsEntry
+	nil,                                  // 83: runner.ProcessGitCredentialsReply.EnvsEntry
+	nil,                                  // 84: runner.OutputReply.OutputsEntry
+	nil,                                  // 85: runner.WriteOutputsRequest.DataEntry
+	nil,                                  // 86: runner.WriteOutputsRequest.LabelsEntry
+	nil,                                  // 87: runner.WriteOutputsRequest.AnnotationsEntry
+	nil,                                  // 88: runner.GetOutputsReply.OutputsEntry
+	nil,                                  // 89: runner.ExportResultsRequest.DataEntry
+	nil,                                  // 90: runner.ExportResultsRequest.AnnotationsEntry
 }
 var file_runner_runner_proto_depIdxs = []int32{
-	57, // 0: runner.SetEnvRequest.envs:type_name -> runner.SetEnvRequest.EnvsEntry
-	6,  // 1: runner.CreateFileMappingsRequest.fileMappings:type_name -> runner.fileMapping
-	35, // 2: runner.GetInventoryReply.inventories:type_name -> runner.Inventory
-	58, // 3: runner.OutputReply.outputs:type_name -> runner.OutputReply.OutputsEntry
-	59, // 4: runner.WriteOutputsRequest.data:type_name -> runner.WriteOutputsRequest.DataEntry
-	60, // 5: runner.WriteOutputsRequest.labels:type_name -> runner.WriteOutputsRequest.LabelsEntry
-	61, // 6: runner.WriteOutputsRequest.annotations:type_name -> runner.WriteOutputsRequest.AnnotationsEntry
-	62, // 7: runner.GetOutputsReply.outputs:type_name -> runner.GetOutputsReply.OutputsEntry
-	40, // 8: runner.OutputReply.OutputsEntry.value:type_name -> runner.OutputMeta
-	0,  // 9: runner.Runner.LookPath:input_type -> runner.LookPathRequest
-	2,  // 10: runner.Runner.NewTerraform:input_type -> runner.NewTerraformRequest
-	4,  // 11: runner.Runner.SetEnv:input_type -> runner.SetEnvRequest
-	7,  // 12: runner.Runner.CreateFileMappings:input_type -> runner.CreateFileMappingsRequest
-	9,  // 13: runner.Runner.UploadAndExtract:input_type -> runner.UploadAndExtractRequest
-	11, // 14: runner.Runner.CleanupDir:input_type -> runner.CleanupDirRequest
-	13, // 15: runner.Runner.WriteBackendConfig:input_type -> runner.WriteBackendConfigRequest
-	15, // 16: runner.Runner.ProcessCliConfig:input_type -> runner.ProcessCliConfigRequest
-	17, // 17: runner.Runner.GenerateVarsForTF:input_type -> runner.GenerateVarsForTFRequest
-	19, // 18: runner.Runner.GenerateTemplate:input_type -> runner.GenerateTemplateRequest
-	21, // 19: runner.Runner.Plan:input_type -> runner.PlanRequest
-	25, // 20: runner.Runner.ShowPlanFileRaw:input_type -> runner.ShowPlanFileRawRequest
-	23, // 21: runner.Runner.ShowPlanFile:input_type -> runner.ShowPlanFileRequest
-	27, // 22: runner.Runner.SaveTFPlan:input_type -> runner.SaveTFPlanRequest
-	29, // 23: runner.Runner.LoadTFPlan:input_type -> runner.LoadTFPlanRequest
-	31, // 24: runner.Runner.Apply:input_type -> runner.ApplyRequest
-	33, // 25: runner.Runner.GetInventory:input_type -> runner.GetInventoryRequest
-	36, // 26: runner.Runner.Destroy:input_type -> runner.DestroyRequest
-	38, // 27: runner.Runner.Output:input_type -> runner.OutputRequest
-	41, // 28: runner.Runner.WriteOutputs:input_type -> runner.WriteOutputsRequest
-	43, // 29: runner.Runner.GetOutputs:input_type -> runner.GetOutputsRequest
-	45, // 30: runner.Runner.Init:input_type -> runner.InitRequest
-	47, // 31: runner.Runner.SelectWorkspace:input_type -> runner.WorkspaceRequest
-	49, // 32: runner.Runner.Upload:input_type -> runner.UploadRequest
-	51, // 33: runner.Runner.FinalizeSecrets:input_type -> runner.FinalizeSecretsRequest
-	53, // 34: runner.Runner.ForceUnlock:input_type -> runner.ForceUnlockRequest
-	55, // 35: runner.Runner.StartBreakTheGlassSession:input_type -> runner.BreakTheGlassRequest
-	55, // 36: runner.Runner.HasBreakTheGlassSessionDone:input_type -> runner.BreakTheGlassRequest
-	1,  // 37: runner.Runner.LookPath:output_type -> runner.LookPathReply
-	3,  // 38: runner.Runner.NewTerraform:output_type -> runner.NewTerraformReply
-	5,  // 39: runner.Runner.SetEnv:output_type -> runner.SetEnvReply
-	8,  // 40: runner.Runner.CreateFileMappings:output_type -> runner.CreateFileMappingsReply
-	10, // 41: runner.Runner.UploadAndExtract:output_type -> runner.UploadAndExtractReply
-	12, // 42: runner.Runner.CleanupDir:output_type -> runner.CleanupDirReply
-	14, // 43: runner.Runner.WriteBackendConfig:output_type -> runner.WriteBackendConfigReply
-	16, // 44: runner.Runner.ProcessCliConfig:output_type -> runner.ProcessCliConfigReply
-	18, // 45: runner.Runner.GenerateVarsForTF:output_type -> runner.GenerateVarsForTFReply
-	20, // 46: runner.Runner.GenerateTemplate:output_type -> runner.GenerateTemplateReply
-	22, // 47: runner.Runner.Plan:output_type -> runner.PlanReply
-	26, // 48: runner.Runner.ShowPlanFileRaw:output_type -> runner.ShowPlanFileRawReply
-	24, // 49: runner.Runner.ShowPlanFile:output_type -> runner.ShowPlanFileReply
-	28, // 50: runner.Runner.SaveTFPlan:output_type -> runner.SaveTFPlanReply
-	30, // 51: runner.Runner.LoadTFPlan:output_type -> runner.LoadTFPlanReply
-	32, // 52: runner.Runner.Apply:output_type -> runner.ApplyReply
-	34, // 53: runner.Runner.GetInventory:output_type -> runner.GetInventoryReply
-	37, // 54: runner.Runner.Destroy:output_type -> runner.DestroyReply
-	39, // 55: runner.Runner.Output:output_type -> runner.OutputReply
-	42, // 56: runner.Runner.WriteOutputs:output_type -> runner.WriteOutputsReply
-	44, // 57: runner.Runner.GetOutputs:output_type -> runner.GetOutputsReply
-	46, // 58: runner.Runner.Init:output_type -> runner.InitReply
-	48, // 59: runner.Runner.SelectWorkspace:output_type -> runner.WorkspaceReply
-	50, // 60: runner.Runner.Upload:output_type -> runner.UploadReply
-	52, // 61: runner.Runner.FinalizeSecrets:output_type -> runner.FinalizeSecretsReply
-	54, // 62: runner.Runner.ForceUnlock:output_type -> runner.ForceUnlockReply
-	56, // 63: runner.Runner.StartBreakTheGlassSession:output_type -> runner.BreakTheGlassReply
-	56, // 64: runner.Runner.HasBreakTheGlassSessionDone:output_type -> runner.BreakTheGlassReply
-	37, // [37:65] is the sub-list for method output_type
-	9,  // [9:37] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	82, // 0: runner.SetEnvRequest.envs:type_name -> runner.SetEnvRequest.EnvsEntry
+	8,  // 1: runner.CreateFileMappingsRequest.fileMappings:type_name -> runner.fileMapping
+	83, // 2: runner.ProcessGitCredentialsReply.envs:type_name -> runner.ProcessGitCredentialsReply.EnvsEntry
+	38, // 3: runner.PlanReply.variableValidationErrors:type_name -> runner.VariableValidationError
+	39, // 4: runner.PlanReply.diagnostics:type_name -> runner.Diagnostic
+	37, // 5: runner.PlanReply.suspectedMoves:type_name -> runner.ResourceMove
+	39, // 6: runner.ApplyReply.diagnostics:type_name -> runner.Diagnostic
+	50, // 7: runner.ApplyReply.resourceTimings:type_name -> runner.ResourceTiming
+	39, // 8: runner.RefreshReply.diagnostics:type_name -> runner.Diagnostic
+	55, // 9: runner.GetInventoryReply.inventories:type_name -> runner.Inventory
+	84, // 10: runner.OutputReply.outputs:type_name -> runner.OutputReply.OutputsEntry
+	85, // 11: runner.WriteOutputsRequest.data:type_name -> runner.WriteOutputsRequest.DataEntry
+	86, // 12: runner.WriteOutputsRequest.labels:type_name -> runner.WriteOutputsRequest.LabelsEntry
+	87, // 13: runner.WriteOutputsRequest.annotations:type_name -> runner.WriteOutputsRequest.AnnotationsEntry
+	88, // 14: runner.GetOutputsReply.outputs:type_name -> runner.GetOutputsReply.OutputsEntry
+	78, // 15: runner.CheckCredentialsReply.credentials:type_name -> runner.ProviderCredentials
+	89, // 16: runner.ExportResultsRequest.data:type_name -> runner.ExportResultsRequest.DataEntry
+	90, // 17: runner.ExportResultsRequest.annotations:type_name -> runner.ExportResultsRequest.AnnotationsEntry
+	60, // 18: runner.OutputReply.OutputsEntry.value:type_name -> runner.OutputMeta
+	0,  // 19: runner.Runner.GetVersion:input_type -> runner.GetVersionRequest
+	2,  // 20: runner.Runner.LookPath:input_type -> runner.LookPathRequest
+	4,  // 21: runner.Runner.NewTerraform:input_type -> runner.NewTerraformRequest
+	6,  // 22: runner.Runner.SetEnv:input_type -> runner.SetEnvRequest
+	9,  // 23: runner.Runner.CreateFileMappings:input_type -> runner.CreateFileMappingsRequest
+	11, // 24: runner.Runner.UploadAndExtract:input_type -> runner.UploadAndExtractRequest
+	13, // 25: runner.Runner.CleanupDir:input_type -> runner.CleanupDirRequest
+	15, // 26: runner.Runner.WriteBackendConfig:input_type -> runner.WriteBackendConfigRequest
+	17, // 27: runner.Runner.WriteSimulationConfig:input_type -> runner.WriteSimulationConfigRequest
+	19, // 28: runner.Runner.ProcessCliConfig:input_type -> runner.ProcessCliConfigRequest
+	21, // 29: runner.Runner.ProcessGitCredentials:input_type -> runner.ProcessGitCredentialsRequest
+	23, // 30: runner.Runner.CheckPlanFreshness:input_type -> runner.CheckPlanFreshnessRequest
+	25, // 31: runner.Runner.FetchRegistryModule:input_type -> runner.FetchRegistryModuleRequest
+	31, // 32: runner.Runner.GenerateVarsForTF:input_type -> runner.GenerateVarsForTFRequest
+	33, // 33: runner.Runner.GenerateTemplate:input_type -> runner.GenerateTemplateRequest
+	35, // 34: runner.Runner.Plan:input_type -> runner.PlanRequest
+	42, // 35: runner.Runner.ShowPlanFileRaw:input_type -> runner.ShowPlanFileRawRequest
+	40, // 36: runner.Runner.ShowPlanFile:input_type -> runner.ShowPlanFileRequest
+	44, // 37: runner.Runner.SaveTFPlan:input_type -> runner.SaveTFPlanRequest
+	46, // 38: runner.Runner.LoadTFPlan:input_type -> runner.LoadTFPlanRequest
+	48, // 39: runner.Runner.Apply:input_type -> runner.ApplyRequest
+	51, // 40: runner.Runner.Refresh:input_type -> runner.RefreshRequest
+	53, // 41: runner.Runner.GetInventory:input_type -> runner.GetInventoryRequest
+	56, // 42: runner.Runner.Destroy:input_type -> runner.DestroyRequest
+	58, // 43: runner.Runner.Output:input_type -> runner.OutputRequest
+	61, // 44: runner.Runner.WriteOutputs:input_type -> runner.WriteOutputsRequest
+	63, // 45: runner.Runner.GetOutputs:input_type -> runner.GetOutputsRequest
+	65, // 46: runner.Runner.Init:input_type -> runner.InitRequest
+	67, // 47: runner.Runner.SelectWorkspace:input_type -> runner.WorkspaceRequest
+	69, // 48: runner.Runner.Upload:input_type -> runner.UploadRequest
+	71, // 49: runner.Runner.FinalizeSecrets:input_type -> runner.FinalizeSecretsRequest
+	73, // 50: runner.Runner.ForceUnlock:input_type -> runner.ForceUnlockRequest
+	75, // 51: runner.Runner.StartBreakTheGlassSession:input_type -> runner.BreakTheGlassRequest
+	75, // 52: runner.Runner.HasBreakTheGlassSessionDone:input_type -> runner.BreakTheGlassRequest
+	77, // 53: runner.Runner.CheckCredentials:input_type -> runner.CheckCredentialsRequest
+	80, // 54: runner.Runner.ExportResults:input_type -> runner.ExportResultsRequest
+	29, // 55: runner.Runner.WatchProgress:input_type -> runner.WatchProgressRequest
+	27, // 56: runner.Runner.GetResourceUsage:input_type -> runner.GetResourceUsageRequest
+	1,  // 57: runner.Runner.GetVersion:output_type -> runner.GetVersionReply
+	3,  // 58: runner.Runner.LookPath:output_type -> runner.LookPathReply
+	5,  // 59: runner.Runner.NewTerraform:output_type -> runner.NewTerraformReply
+	7,  // 60: runner.Runner.SetEnv:output_type -> runner.SetEnvReply
+	10, // 61: runner.Runner.CreateFileMappings:output_type -> runner.CreateFileMappingsReply
+	12, // 62: runner.Runner.UploadAndExtract:output_type -> runner.UploadAndExtractReply
+	14, // 63: runner.Runner.CleanupDir:output_type -> runner.CleanupDirReply
+	16, // 64: runner.Runner.WriteBackendConfig:output_type -> runner.WriteBackendConfigReply
+	18, // 65: runner.Runner.WriteSimulationConfig:output_type -> runner.WriteSimulationConfigReply
+	20, // 66: runner.Runner.ProcessCliConfig:output_type -> runner.ProcessCliConfigReply
+	22, // 67: runner.Runner.ProcessGitCredentials:output_type -> runner.ProcessGitCredentialsReply
+	24, // 68: runner.Runner.CheckPlanFreshness:output_type -> runner.CheckPlanFreshnessReply
+	26, // 69: runner.Runner.FetchRegistryModule:output_type -> runner.FetchRegistryModuleReply
+	32, // 70: runner.Runner.GenerateVarsForTF:output_type -> runner.GenerateVarsForTFReply
+	34, // 71: runner.Runner.GenerateTemplate:output_type -> runner.GenerateTemplateReply
+	36, // 72: runner.Runner.Plan:output_type -> runner.PlanReply
+	43, // 73: runner.Runner.ShowPlanFileRaw:output_type -> runner.ShowPlanFileRawReply
+	41, // 74: runner.Runner.ShowPlanFile:output_type -> runner.ShowPlanFileReply
+	45, // 75: runner.Runner.SaveTFPlan:output_type -> runner.SaveTFPlanReply
+	47, // 76: runner.Runner.LoadTFPlan:output_type -> runner.LoadTFPlanReply
+	49, // 77: runner.Runner.Apply:output_type -> runner.ApplyReply
+	52, // 78: runner.Runner.Refresh:output_type -> runner.RefreshReply
+	54, // 79: runner.Runner.GetInventory:output_type -> runner.GetInventoryReply
+	57, // 80: runner.Runner.Destroy:output_type -> runner.DestroyReply
+	59, // 81: runner.Runner.Output:output_type -> runner.OutputReply
+	62, // 82: runner.Runner.WriteOutputs:output_type -> runner.WriteOutputsReply
+	64, // 83: runner.Runner.GetOutputs:output_type -> runner.GetOutputsReply
+	66, // 84: runner.Runner.Init:output_type -> runner.InitReply
+	68, // 85: runner.Runner.SelectWorkspace:output_type -> runner.WorkspaceReply
+	70, // 86: runner.Runner.Upload:output_type -> runner.UploadReply
+	72, // 87: runner.Runner.FinalizeSecrets:output_type -> runner.FinalizeSecretsReply
+	74, // 88: runner.Runner.ForceUnlock:output_type -> runner.ForceUnlockReply
+	76, // 89: runner.Runner.StartBreakTheGlassSession:output_type -> runner.BreakTheGlassReply
+	76, // 90: runner.Runner.HasBreakTheGlassSessionDone:output_type -> runner.BreakTheGlassReply
+	79, // 91: runner.Runner.CheckCredentials:output_type -> runner.CheckCredentialsReply
+	81, // 92: runner.Runner.ExportResults:output_type -> runner.ExportResultsReply
+	30, // 93: runner.Runner.WatchProgress:output_type -> runner.ProgressReply
+	28, // 94: runner.Runner.GetResourceUsage:output_type -> runner.GetResourceUsageReply
+	57, // [57:95] is the sub-list for method output_type
+	19, // [19:57] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_runner_runner_proto_init() }
@@ -3801,6 +5778,30 @@ func file_runner_runner_proto_init() {
 	}
 	if !protoimpl.UnsafeEnabled {
 		file_runner_runner_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetVersionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetVersionReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*LookPathRequest); i {
 			case 0:
 				return &v.state
@@ -3812,8 +5813,128 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LookPathReply); i {
+		file_runner_runner_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LookPathReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewTerraformRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewTerraformReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetEnvRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetEnvReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileMapping); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateFileMappingsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateFileMappingsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadAndExtractRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadAndExtractReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CleanupDirRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3824,8 +5945,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NewTerraformRequest); i {
+		file_runner_runner_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CleanupDirReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3836,8 +5957,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NewTerraformReply); i {
+		file_runner_runner_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WriteBackendConfigRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3848,8 +5969,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetEnvRequest); i {
+		file_runner_runner_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WriteBackendConfigReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3860,8 +5981,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetEnvReply); i {
+		file_runner_runner_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WriteSimulationConfigRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3872,8 +5993,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FileMapping); i {
+		file_runner_runner_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WriteSimulationConfigReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3884,8 +6005,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateFileMappingsRequest); i {
+		file_runner_runner_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProcessCliConfigRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3896,8 +6017,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateFileMappingsReply); i {
+		file_runner_runner_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProcessCliConfigReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3908,8 +6029,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UploadAndExtractRequest); i {
+		file_runner_runner_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProcessGitCredentialsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3920,8 +6041,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UploadAndExtractReply); i {
+		file_runner_runner_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProcessGitCredentialsReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3932,8 +6053,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CleanupDirRequest); i {
+		file_runner_runner_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckPlanFreshnessRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3944,8 +6065,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CleanupDirReply); i {
+		file_runner_runner_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckPlanFreshnessReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3956,8 +6077,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WriteBackendConfigRequest); i {
+		file_runner_runner_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FetchRegistryModuleRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3968,8 +6089,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WriteBackendConfigReply); i {
+		file_runner_runner_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FetchRegistryModuleReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3980,8 +6101,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProcessCliConfigRequest); i {
+		file_runner_runner_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetResourceUsageRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3992,8 +6113,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProcessCliConfigReply); i {
+		file_runner_runner_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetResourceUsageReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4004,7 +6125,31 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchProgressRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProgressReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GenerateVarsForTFRequest); i {
 			case 0:
 				return &v.state
@@ -4016,7 +6161,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GenerateVarsForTFReply); i {
 			case 0:
 				return &v.state
@@ -4028,7 +6173,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GenerateTemplateRequest); i {
 			case 0:
 				return &v.state
@@ -4040,7 +6185,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GenerateTemplateReply); i {
 			case 0:
 				return &v.state
@@ -4052,7 +6197,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PlanRequest); i {
 			case 0:
 				return &v.state
@@ -4064,7 +6209,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PlanReply); i {
 			case 0:
 				return &v.state
@@ -4076,7 +6221,43 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResourceMove); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VariableValidationError); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Diagnostic); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ShowPlanFileRequest); i {
 			case 0:
 				return &v.state
@@ -4088,7 +6269,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ShowPlanFileReply); i {
 			case 0:
 				return &v.state
@@ -4100,7 +6281,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ShowPlanFileRawRequest); i {
 			case 0:
 				return &v.state
@@ -4112,7 +6293,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ShowPlanFileRawReply); i {
 			case 0:
 				return &v.state
@@ -4124,7 +6305,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SaveTFPlanRequest); i {
 			case 0:
 				return &v.state
@@ -4136,7 +6317,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SaveTFPlanReply); i {
 			case 0:
 				return &v.state
@@ -4148,7 +6329,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*LoadTFPlanRequest); i {
 			case 0:
 				return &v.state
@@ -4160,7 +6341,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*LoadTFPlanReply); i {
 			case 0:
 				return &v.state
@@ -4172,7 +6353,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ApplyRequest); i {
 			case 0:
 				return &v.state
@@ -4184,7 +6365,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ApplyReply); i {
 			case 0:
 				return &v.state
@@ -4196,7 +6377,43 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResourceTiming); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RefreshRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RefreshReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetInventoryRequest); i {
 			case 0:
 				return &v.state
@@ -4208,7 +6425,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetInventoryReply); i {
 			case 0:
 				return &v.state
@@ -4220,7 +6437,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Inventory); i {
 			case 0:
 				return &v.state
@@ -4232,7 +6449,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DestroyRequest); i {
 			case 0:
 				return &v.state
@@ -4244,7 +6461,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DestroyReply); i {
 			case 0:
 				return &v.state
@@ -4256,7 +6473,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*OutputRequest); i {
 			case 0:
 				return &v.state
@@ -4268,7 +6485,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*OutputReply); i {
 			case 0:
 				return &v.state
@@ -4280,7 +6497,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*OutputMeta); i {
 			case 0:
 				return &v.state
@@ -4292,7 +6509,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*WriteOutputsRequest); i {
 			case 0:
 				return &v.state
@@ -4304,7 +6521,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*WriteOutputsReply); i {
 			case 0:
 				return &v.state
@@ -4316,7 +6533,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetOutputsRequest); i {
 			case 0:
 				return &v.state
@@ -4328,7 +6545,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetOutputsReply); i {
 			case 0:
 				return &v.state
@@ -4340,7 +6557,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[65].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*InitRequest); i {
 			case 0:
 				return &v.state
@@ -4352,7 +6569,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[66].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*InitReply); i {
 			case 0:
 				return &v.state
@@ -4364,7 +6581,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[67].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*WorkspaceRequest); i {
 			case 0:
 				return &v.state
@@ -4376,7 +6593,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[68].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*WorkspaceReply); i {
 			case 0:
 				return &v.state
@@ -4388,7 +6605,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[69].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UploadRequest); i {
 			case 0:
 				return &v.state
@@ -4400,7 +6617,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[70].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UploadReply); i {
 			case 0:
 				return &v.state
@@ -4412,7 +6629,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[71].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*FinalizeSecretsRequest); i {
 			case 0:
 				return &v.state
@@ -4424,7 +6641,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[72].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*FinalizeSecretsReply); i {
 			case 0:
 				return &v.state
@@ -4436,7 +6653,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[73].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ForceUnlockRequest); i {
 			case 0:
 				return &v.state
@@ -4448,7 +6665,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[74].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ForceUnlockReply); i {
 			case 0:
 				return &v.state
@@ -4460,7 +6677,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[75].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*BreakTheGlassRequest); i {
 			case 0:
 				return &v.state
@@ -4472,7 +6689,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[76].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*BreakTheGlassReply); i {
 			case 0:
 				return &v.state
@@ -4484,6 +6701,66 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
+		file_runner_runner_proto_msgTypes[77].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckCredentialsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[78].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProviderCredentials); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[79].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckCredentialsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[80].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportResultsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[81].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportResultsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -4491,7 +6768,7 @@ func file_runner_runner_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_runner_runner_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   63,
+			NumMessages:   91,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
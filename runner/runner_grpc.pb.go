@@ -22,6 +22,7 @@ const _ = grpc.SupportPackageIsVersion7
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type RunnerClient interface {
+	GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionReply, error)
 	LookPath(ctx context.Context, in *LookPathRequest, opts ...grpc.CallOption) (*LookPathReply, error)
 	NewTerraform(ctx context.Context, in *NewTerraformRequest, opts ...grpc.CallOption) (*NewTerraformReply, error)
 	SetEnv(ctx context.Context, in *SetEnvRequest, opts ...grpc.CallOption) (*SetEnvReply, error)
@@ -29,7 +30,11 @@ type RunnerClient interface {
 	UploadAndExtract(ctx context.Context, in *UploadAndExtractRequest, opts ...grpc.CallOption) (*UploadAndExtractReply, error)
 	CleanupDir(ctx context.Context, in *CleanupDirRequest, opts ...grpc.CallOption) (*CleanupDirReply, error)
 	WriteBackendConfig(ctx context.Context, in *WriteBackendConfigRequest, opts ...grpc.CallOption) (*WriteBackendConfigReply, error)
+	WriteSimulationConfig(ctx context.Context, in *WriteSimulationConfigRequest, opts ...grpc.CallOption) (*WriteSimulationConfigReply, error)
 	ProcessCliConfig(ctx context.Context, in *ProcessCliConfigRequest, opts ...grpc.CallOption) (*ProcessCliConfigReply, error)
+	ProcessGitCredentials(ctx context.Context, in *ProcessGitCredentialsRequest, opts ...grpc.CallOption) (*ProcessGitCredentialsReply, error)
+	CheckPlanFreshness(ctx context.Context, in *CheckPlanFreshnessRequest, opts ...grpc.CallOption) (*CheckPlanFreshnessReply, error)
+	FetchRegistryModule(ctx context.Context, in *FetchRegistryModuleRequest, opts ...grpc.CallOption) (*FetchRegistryModuleReply, error)
 	GenerateVarsForTF(ctx context.Context, in *GenerateVarsForTFRequest, opts ...grpc.CallOption) (*GenerateVarsForTFReply, error)
 	GenerateTemplate(ctx context.Context, in *GenerateTemplateRequest, opts ...grpc.CallOption) (*GenerateTemplateReply, error)
 	Plan(ctx context.Context, in *PlanRequest, opts ...grpc.CallOption) (*PlanReply, error)
@@ -38,6 +43,7 @@ type RunnerClient interface {
 	SaveTFPlan(ctx context.Context, in *SaveTFPlanRequest, opts ...grpc.CallOption) (*SaveTFPlanReply, error)
 	LoadTFPlan(ctx context.Context, in *LoadTFPlanRequest, opts ...grpc.CallOption) (*LoadTFPlanReply, error)
 	Apply(ctx context.Context, in *ApplyRequest, opts ...grpc.CallOption) (*ApplyReply, error)
+	Refresh(ctx context.Context, in *RefreshRequest, opts ...grpc.CallOption) (*RefreshReply, error)
 	GetInventory(ctx context.Context, in *GetInventoryRequest, opts ...grpc.CallOption) (*GetInventoryReply, error)
 	Destroy(ctx context.Context, in *DestroyRequest, opts ...grpc.CallOption) (*DestroyReply, error)
 	Output(ctx context.Context, in *OutputRequest, opts ...grpc.CallOption) (*OutputReply, error)
@@ -50,6 +56,10 @@ type RunnerClient interface {
 	ForceUnlock(ctx context.Context, in *ForceUnlockRequest, opts ...grpc.CallOption) (*ForceUnlockReply, error)
 	StartBreakTheGlassSession(ctx context.Context, in *BreakTheGlassRequest, opts ...grpc.CallOption) (*BreakTheGlassReply, error)
 	HasBreakTheGlassSessionDone(ctx context.Context, in *BreakTheGlassRequest, opts ...grpc.CallOption) (*BreakTheGlassReply, error)
+	CheckCredentials(ctx context.Context, in *CheckCredentialsRequest, opts ...grpc.CallOption) (*CheckCredentialsReply, error)
+	ExportResults(ctx context.Context, in *ExportResultsRequest, opts ...grpc.CallOption) (*ExportResultsReply, error)
+	WatchProgress(ctx context.Context, in *WatchProgressRequest, opts ...grpc.CallOption) (Runner_WatchProgressClient, error)
+	GetResourceUsage(ctx context.Context, in *GetResourceUsageRequest, opts ...grpc.CallOption) (*GetResourceUsageReply, error)
 }
 
 type runnerClient struct {
@@ -60,6 +70,15 @@ func NewRunnerClient(cc grpc.ClientConnInterface) RunnerClient {
 	return &runnerClient{cc}
 }
 
+func (c *runnerClient) GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionReply, error) {
+	out := new(GetVersionReply)
+	err := c.cc.Invoke(ctx, "/runner.Runner/GetVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *runnerClient) LookPath(ctx context.Context, in *LookPathRequest, opts ...grpc.CallOption) (*LookPathReply, error) {
 	out := new(LookPathReply)
 	err := c.cc.Invoke(ctx, "/runner.Runner/LookPath", in, out, opts...)
@@ -123,6 +142,15 @@ func (c *runnerClient) WriteBackendConfig(ctx context.Context, in *WriteBackendC
 	return out, nil
 }
 
+func (c *runnerClient) WriteSimulationConfig(ctx context.Context, in *WriteSimulationConfigRequest, opts ...grpc.CallOption) (*WriteSimulationConfigReply, error) {
+	out := new(WriteSimulationConfigReply)
+	err := c.cc.Invoke(ctx, "/runner.Runner/WriteSimulationConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *runnerClient) ProcessCliConfig(ctx context.Context, in *ProcessCliConfigRequest, opts ...grpc.CallOption) (*ProcessCliConfigReply, error) {
 	out := new(ProcessCliConfigReply)
 	err := c.cc.Invoke(ctx, "/runner.Runner/ProcessCliConfig", in, out, opts...)
@@ -132,6 +160,33 @@ func (c *runnerClient) ProcessCliConfig(ctx context.Context, in *ProcessCliConfi
 	return out, nil
 }
 
+func (c *runnerClient) ProcessGitCredentials(ctx context.Context, in *ProcessGitCredentialsRequest, opts ...grpc.CallOption) (*ProcessGitCredentialsReply, error) {
+	out := new(ProcessGitCredentialsReply)
+	err := c.cc.Invoke(ctx, "/runner.Runner/ProcessGitCredentials", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runnerClient) CheckPlanFreshness(ctx context.Context, in *CheckPlanFreshnessRequest, opts ...grpc.CallOption) (*CheckPlanFreshnessReply, error) {
+	out := new(CheckPlanFreshnessReply)
+	err := c.cc.Invoke(ctx, "/runner.Runner/CheckPlanFreshness", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runnerClient) FetchRegistryModule(ctx context.Context, in *FetchRegistryModuleRequest, opts ...grpc.CallOption) (*FetchRegistryModuleReply, error) {
+	out := new(FetchRegistryModuleReply)
+	err := c.cc.Invoke(ctx, "/runner.Runner/FetchRegistryModule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *runnerClient) GenerateVarsForTF(ctx context.Context, in *GenerateVarsForTFRequest, opts ...grpc.CallOption) (*GenerateVarsForTFReply, error) {
 	out := new(GenerateVarsForTFReply)
 	err := c.cc.Invoke(ctx, "/runner.Runner/GenerateVarsForTF", in, out, opts...)
@@ -204,6 +259,15 @@ func (c *runnerClient) Apply(ctx context.Context, in *ApplyRequest, opts ...grpc
 	return out, nil
 }
 
+func (c *runnerClient) Refresh(ctx context.Context, in *RefreshRequest, opts ...grpc.CallOption) (*RefreshReply, error) {
+	out := new(RefreshReply)
+	err := c.cc.Invoke(ctx, "/runner.Runner/Refresh", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *runnerClient) GetInventory(ctx context.Context, in *GetInventoryRequest, opts ...grpc.CallOption) (*GetInventoryReply, error) {
 	out := new(GetInventoryReply)
 	err := c.cc.Invoke(ctx, "/runner.Runner/GetInventory", in, out, opts...)
@@ -312,10 +376,70 @@ func (c *runnerClient) HasBreakTheGlassSessionDone(ctx context.Context, in *Brea
 	return out, nil
 }
 
+func (c *runnerClient) CheckCredentials(ctx context.Context, in *CheckCredentialsRequest, opts ...grpc.CallOption) (*CheckCredentialsReply, error) {
+	out := new(CheckCredentialsReply)
+	err := c.cc.Invoke(ctx, "/runner.Runner/CheckCredentials", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runnerClient) ExportResults(ctx context.Context, in *ExportResultsRequest, opts ...grpc.CallOption) (*ExportResultsReply, error) {
+	out := new(ExportResultsReply)
+	err := c.cc.Invoke(ctx, "/runner.Runner/ExportResults", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runnerClient) WatchProgress(ctx context.Context, in *WatchProgressRequest, opts ...grpc.CallOption) (Runner_WatchProgressClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Runner_ServiceDesc.Streams[0], "/runner.Runner/WatchProgress", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &runnerWatchProgressClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Runner_WatchProgressClient interface {
+	Recv() (*ProgressReply, error)
+	grpc.ClientStream
+}
+
+type runnerWatchProgressClient struct {
+	grpc.ClientStream
+}
+
+func (x *runnerWatchProgressClient) Recv() (*ProgressReply, error) {
+	m := new(ProgressReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *runnerClient) GetResourceUsage(ctx context.Context, in *GetResourceUsageRequest, opts ...grpc.CallOption) (*GetResourceUsageReply, error) {
+	out := new(GetResourceUsageReply)
+	err := c.cc.Invoke(ctx, "/runner.Runner/GetResourceUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RunnerServer is the server API for Runner service.
 // All implementations must embed UnimplementedRunnerServer
 // for forward compatibility
 type RunnerServer interface {
+	GetVersion(context.Context, *GetVersionRequest) (*GetVersionReply, error)
 	LookPath(context.Context, *LookPathRequest) (*LookPathReply, error)
 	NewTerraform(context.Context, *NewTerraformRequest) (*NewTerraformReply, error)
 	SetEnv(context.Context, *SetEnvRequest) (*SetEnvReply, error)
@@ -323,7 +447,11 @@ type RunnerServer interface {
 	UploadAndExtract(context.Context, *UploadAndExtractRequest) (*UploadAndExtractReply, error)
 	CleanupDir(context.Context, *CleanupDirRequest) (*CleanupDirReply, error)
 	WriteBackendConfig(context.Context, *WriteBackendConfigRequest) (*WriteBackendConfigReply, error)
+	WriteSimulationConfig(context.Context, *WriteSimulationConfigRequest) (*WriteSimulationConfigReply, error)
 	ProcessCliConfig(context.Context, *ProcessCliConfigRequest) (*ProcessCliConfigReply, error)
+	ProcessGitCredentials(context.Context, *ProcessGitCredentialsRequest) (*ProcessGitCredentialsReply, error)
+	CheckPlanFreshness(context.Context, *CheckPlanFreshnessRequest) (*CheckPlanFreshnessReply, error)
+	FetchRegistryModule(context.Context, *FetchRegistryModuleRequest) (*FetchRegistryModuleReply, error)
 	GenerateVarsForTF(context.Context, *GenerateVarsForTFRequest) (*GenerateVarsForTFReply, error)
 	GenerateTemplate(context.Context, *GenerateTemplateRequest) (*GenerateTemplateReply, error)
 	Plan(context.Context, *PlanRequest) (*PlanReply, error)
@@ -332,6 +460,7 @@ type RunnerServer interface {
 	SaveTFPlan(context.Context, *SaveTFPlanRequest) (*SaveTFPlanReply, error)
 	LoadTFPlan(context.Context, *LoadTFPlanRequest) (*LoadTFPlanReply, error)
 	Apply(context.Context, *ApplyRequest) (*ApplyReply, error)
+	Refresh(context.Context, *RefreshRequest) (*RefreshReply, error)
 	GetInventory(context.Context, *GetInventoryRequest) (*GetInventoryReply, error)
 	Destroy(context.Context, *DestroyRequest) (*DestroyReply, error)
 	Output(context.Context, *OutputRequest) (*OutputReply, error)
@@ -344,6 +473,10 @@ type RunnerServer interface {
 	ForceUnlock(context.Context, *ForceUnlockRequest) (*ForceUnlockReply, error)
 	StartBreakTheGlassSession(context.Context, *BreakTheGlassRequest) (*BreakTheGlassReply, error)
 	HasBreakTheGlassSessionDone(context.Context, *BreakTheGlassRequest) (*BreakTheGlassReply, error)
+	CheckCredentials(context.Context, *CheckCredentialsRequest) (*CheckCredentialsReply, error)
+	ExportResults(context.Context, *ExportResultsRequest) (*ExportResultsReply, error)
+	WatchProgress(*WatchProgressRequest, Runner_WatchProgressServer) error
+	GetResourceUsage(context.Context, *GetResourceUsageRequest) (*GetResourceUsageReply, error)
 	mustEmbedUnimplementedRunnerServer()
 }
 
@@ -351,6 +484,9 @@ type RunnerServer interface {
 type UnimplementedRunnerServer struct {
 }
 
+func (UnimplementedRunnerServer) GetVersion(context.Context, *GetVersionRequest) (*GetVersionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVersion not implemented")
+}
 func (UnimplementedRunnerServer) LookPath(context.Context, *LookPathRequest) (*LookPathReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LookPath not implemented")
 }
@@ -372,9 +508,21 @@ func (UnimplementedRunnerServer) CleanupDir(context.Context, *CleanupDirRequest)
 func (UnimplementedRunnerServer) WriteBackendConfig(context.Context, *WriteBackendConfigRequest) (*WriteBackendConfigReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method WriteBackendConfig not implemented")
 }
+func (UnimplementedRunnerServer) WriteSimulationConfig(context.Context, *WriteSimulationConfigRequest) (*WriteSimulationConfigReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WriteSimulationConfig not implemented")
+}
 func (UnimplementedRunnerServer) ProcessCliConfig(context.Context, *ProcessCliConfigRequest) (*ProcessCliConfigReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ProcessCliConfig not implemented")
 }
+func (UnimplementedRunnerServer) ProcessGitCredentials(context.Context, *ProcessGitCredentialsRequest) (*ProcessGitCredentialsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProcessGitCredentials not implemented")
+}
+func (UnimplementedRunnerServer) CheckPlanFreshness(context.Context, *CheckPlanFreshnessRequest) (*CheckPlanFreshnessReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckPlanFreshness not implemented")
+}
+func (UnimplementedRunnerServer) FetchRegistryModule(context.Context, *FetchRegistryModuleRequest) (*FetchRegistryModuleReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchRegistryModule not implemented")
+}
 func (UnimplementedRunnerServer) GenerateVarsForTF(context.Context, *GenerateVarsForTFRequest) (*GenerateVarsForTFReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GenerateVarsForTF not implemented")
 }
@@ -399,6 +547,9 @@ func (UnimplementedRunnerServer) LoadTFPlan(context.Context, *LoadTFPlanRequest)
 func (UnimplementedRunnerServer) Apply(context.Context, *ApplyRequest) (*ApplyReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Apply not implemented")
 }
+func (UnimplementedRunnerServer) Refresh(context.Context, *RefreshRequest) (*RefreshReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Refresh not implemented")
+}
 func (UnimplementedRunnerServer) GetInventory(context.Context, *GetInventoryRequest) (*GetInventoryReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInventory not implemented")
 }
@@ -435,6 +586,18 @@ func (UnimplementedRunnerServer) StartBreakTheGlassSession(context.Context, *Bre
 func (UnimplementedRunnerServer) HasBreakTheGlassSessionDone(context.Context, *BreakTheGlassRequest) (*BreakTheGlassReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HasBreakTheGlassSessionDone not implemented")
 }
+func (UnimplementedRunnerServer) CheckCredentials(context.Context, *CheckCredentialsRequest) (*CheckCredentialsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckCredentials not implemented")
+}
+func (UnimplementedRunnerServer) ExportResults(context.Context, *ExportResultsRequest) (*ExportResultsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportResults not implemented")
+}
+func (UnimplementedRunnerServer) WatchProgress(*WatchProgressRequest, Runner_WatchProgressServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchProgress not implemented")
+}
+func (UnimplementedRunnerServer) GetResourceUsage(context.Context, *GetResourceUsageRequest) (*GetResourceUsageReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResourceUsage not implemented")
+}
 func (UnimplementedRunnerServer) mustEmbedUnimplementedRunnerServer() {}
 
 // UnsafeRunnerServer may be embedded to opt out of forward compatibility for this service.
@@ -448,6 +611,24 @@ func RegisterRunnerServer(s grpc.ServiceRegistrar, srv RunnerServer) {
 	s.RegisterService(&Runner_ServiceDesc, srv)
 }
 
+func _Runner_GetVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).GetVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runner.Runner/GetVersion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).GetVersion(ctx, req.(*GetVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Runner_LookPath_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(LookPathRequest)
 	if err := dec(in); err != nil {
@@ -574,6 +755,24 @@ func _Runner_WriteBackendConfig_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Runner_WriteSimulationConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteSimulationConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).WriteSimulationConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runner.Runner/WriteSimulationConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).WriteSimulationConfig(ctx, req.(*WriteSimulationConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Runner_ProcessCliConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ProcessCliConfigRequest)
 	if err := dec(in); err != nil {
@@ -592,6 +791,60 @@ func _Runner_ProcessCliConfig_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Runner_ProcessGitCredentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProcessGitCredentialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).ProcessGitCredentials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runner.Runner/ProcessGitCredentials",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).ProcessGitCredentials(ctx, req.(*ProcessGitCredentialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Runner_CheckPlanFreshness_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckPlanFreshnessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).CheckPlanFreshness(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runner.Runner/CheckPlanFreshness",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).CheckPlanFreshness(ctx, req.(*CheckPlanFreshnessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Runner_FetchRegistryModule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchRegistryModuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).FetchRegistryModule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runner.Runner/FetchRegistryModule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).FetchRegistryModule(ctx, req.(*FetchRegistryModuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Runner_GenerateVarsForTF_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GenerateVarsForTFRequest)
 	if err := dec(in); err != nil {
@@ -736,6 +989,24 @@ func _Runner_Apply_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Runner_Refresh_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).Refresh(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runner.Runner/Refresh",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).Refresh(ctx, req.(*RefreshRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Runner_GetInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetInventoryRequest)
 	if err := dec(in); err != nil {
@@ -952,6 +1223,81 @@ func _Runner_HasBreakTheGlassSessionDone_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Runner_CheckCredentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckCredentialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).CheckCredentials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runner.Runner/CheckCredentials",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).CheckCredentials(ctx, req.(*CheckCredentialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Runner_ExportResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).ExportResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runner.Runner/ExportResults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).ExportResults(ctx, req.(*ExportResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Runner_WatchProgress_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchProgressRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RunnerServer).WatchProgress(m, &runnerWatchProgressServer{stream})
+}
+
+type Runner_WatchProgressServer interface {
+	Send(*ProgressReply) error
+	grpc.ServerStream
+}
+
+type runnerWatchProgressServer struct {
+	grpc.ServerStream
+}
+
+func (x *runnerWatchProgressServer) Send(m *ProgressReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Runner_GetResourceUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetResourceUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).GetResourceUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runner.Runner/GetResourceUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).GetResourceUsage(ctx, req.(*GetResourceUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Runner_ServiceDesc is the grpc.ServiceDesc for Runner service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -959,6 +1305,10 @@ var Runner_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "runner.Runner",
 	HandlerType: (*RunnerServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetVersion",
+			Handler:    _Runner_GetVersion_Handler,
+		},
 		{
 			MethodName: "LookPath",
 			Handler:    _Runner_LookPath_Handler,
@@ -987,10 +1337,26 @@ var Runner_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "WriteBackendConfig",
 			Handler:    _Runner_WriteBackendConfig_Handler,
 		},
+		{
+			MethodName: "WriteSimulationConfig",
+			Handler:    _Runner_WriteSimulationConfig_Handler,
+		},
 		{
 			MethodName: "ProcessCliConfig",
 			Handler:    _Runner_ProcessCliConfig_Handler,
 		},
+		{
+			MethodName: "ProcessGitCredentials",
+			Handler:    _Runner_ProcessGitCredentials_Handler,
+		},
+		{
+			MethodName: "CheckPlanFreshness",
+			Handler:    _Runner_CheckPlanFreshness_Handler,
+		},
+		{
+			MethodName: "FetchRegistryModule",
+			Handler:    _Runner_FetchRegistryModule_Handler,
+		},
 		{
 			MethodName: "GenerateVarsForTF",
 			Handler:    _Runner_GenerateVarsForTF_Handler,
@@ -1023,6 +1389,10 @@ var Runner_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Apply",
 			Handler:    _Runner_Apply_Handler,
 		},
+		{
+			MethodName: "Refresh",
+			Handler:    _Runner_Refresh_Handler,
+		},
 		{
 			MethodName: "GetInventory",
 			Handler:    _Runner_GetInventory_Handler,
@@ -1071,7 +1441,25 @@ var Runner_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "HasBreakTheGlassSessionDone",
 			Handler:    _Runner_HasBreakTheGlassSessionDone_Handler,
 		},
+		{
+			MethodName: "CheckCredentials",
+			Handler:    _Runner_CheckCredentials_Handler,
+		},
+		{
+			MethodName: "ExportResults",
+			Handler:    _Runner_ExportResults_Handler,
+		},
+		{
+			MethodName: "GetResourceUsage",
+			Handler:    _Runner_GetResourceUsage_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchProgress",
+			Handler:       _Runner_WatchProgress_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "runner/runner.proto",
 }
@@ -0,0 +1,25 @@
+package runner
+
+import (
+	"path"
+	"strings"
+)
+
+// windowsScriptCommand is the interpreter of the PowerShell scripts on
+// Windows, where a script cannot be run by itself.
+var windowsScriptCommand = []string{"powershell.exe", "-NoLogo", "-NoProfile", "-NonInteractive", "-ExecutionPolicy", "Bypass", "-File"}
+
+// scriptCommand returns the command running a binary of the runner pod on
+// an operating system: a PowerShell script is run with its interpreter on
+// Windows, the other commands as they are.
+func scriptCommand(goos string, command []string) []string {
+	if goos != "windows" || len(command) == 0 {
+		return command
+	}
+
+	// the path of the script may have either separator on Windows
+	if strings.EqualFold(path.Ext(strings.ReplaceAll(command[0], `\`, "/")), ".ps1") {
+		return append(append([]string(nil), windowsScriptCommand...), command...)
+	}
+	return command
+}
@@ -5,11 +5,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	securejoin "github.com/cyphar/filepath-securejoin"
 	"github.com/fluxcd/pkg/untar"
@@ -36,6 +38,7 @@ const (
 	runnerFileMappingDirectoryPermissions = 0700
 	runnerFileMappingFilePermissions      = 0600
 	HomePath                              = "/home/runner"
+	dependencyLockFilename                = ".terraform.lock.hcl"
 )
 
 type LocalPrintfer struct {
@@ -54,6 +57,14 @@ type TerraformRunnerServer struct {
 	Done       chan os.Signal
 	terraform  *infrav1.Terraform
 	InstanceID string
+	// env is the environment of the terraform commands run outside of tfexec
+	env map[string]string
+	// progress tracks the running, or the last, plan or apply
+	progressMu sync.Mutex
+	progress   *progressTracker
+	// SourceCacheDir is the directory of the source cache of the node,
+	// shared by its runners. The artifacts are not cached when empty.
+	SourceCacheDir string
 }
 
 const loggerName = "runner.terraform"
@@ -78,7 +89,26 @@ func (r *TerraformRunnerServer) UploadAndExtract(ctx context.Context, req *Uploa
 		return nil, err
 	}
 
-	buf := bytes.NewBuffer(req.TarGz)
+	cache := sourceCache{dir: r.SourceCacheDir}
+	tarGz := req.TarGz
+	cached := false
+	if len(tarGz) == 0 && req.Digest != "" {
+		tarGz, cached, err = cache.get(req.Digest)
+		if err != nil {
+			log.Error(err, "unable to read the artifact from the source cache", "digest", req.Digest)
+		}
+		if !cached {
+			return nil, status.Errorf(codes.NotFound, "artifact %s not found in the source cache", req.Digest)
+		}
+		log.Info("extracting the artifact from the source cache", "digest", req.Digest)
+	} else if req.Digest != "" {
+		// the artifact is extracted even when it cannot be cached
+		if err := cache.put(req.Digest, tarGz); err != nil {
+			log.Error(err, "unable to store the artifact in the source cache", "digest", req.Digest)
+		}
+	}
+
+	buf := bytes.NewBuffer(tarGz)
 	if _, err = untar.Untar(buf, tmpDir); err != nil {
 		log.Error(err, "unable to extract tar file", "namespace", req.Namespace, "name", req.Name)
 		return nil, fmt.Errorf("failed to untar artifact, error: %w", err)
@@ -96,7 +126,7 @@ func (r *TerraformRunnerServer) UploadAndExtract(ctx context.Context, req *Uploa
 		return nil, err
 	}
 
-	return &UploadAndExtractReply{WorkingDir: dirPath, TmpDir: tmpDir}, nil
+	return &UploadAndExtractReply{WorkingDir: dirPath, TmpDir: tmpDir, Cached: cached}, nil
 }
 
 func (r *TerraformRunnerServer) CleanupDir(ctx context.Context, req *CleanupDirRequest) (*CleanupDirReply, error) {
@@ -131,6 +161,27 @@ func (r *TerraformRunnerServer) WriteBackendConfig(ctx context.Context, req *Wri
 	return &WriteBackendConfigReply{Message: "ok"}, nil
 }
 
+// WriteSimulationConfig writes the provider configurations of the simulation
+// to an override file, which Terraform merges into the provider blocks of the
+// module.
+func (r *TerraformRunnerServer) WriteSimulationConfig(ctx context.Context, req *WriteSimulationConfigRequest) (*WriteSimulationConfigReply, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	const simulationConfigPath = "simulation_override.tf"
+	log.Info("write simulation config", "path", req.DirPath, "config", simulationConfigPath)
+	filePath, err := securejoin.SecureJoin(req.DirPath, simulationConfigPath)
+	if err != nil {
+		log.Error(err, "unable to join securely", "dirPath", req.DirPath, "config", simulationConfigPath)
+		return nil, err
+	}
+
+	if err := os.WriteFile(filePath, req.SimulationConfig, 0644); err != nil {
+		log.Error(err, "unable to write file", "filePath", filePath)
+		return nil, err
+	}
+
+	return &WriteSimulationConfigReply{Message: "ok"}, nil
+}
+
 func (r *TerraformRunnerServer) ProcessCliConfig(ctx context.Context, req *ProcessCliConfigRequest) (*ProcessCliConfigReply, error) {
 	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
 	log.Info("processing configuration", "namespace", req.Namespace, "name", req.Name)
@@ -229,6 +280,7 @@ func (r *TerraformRunnerServer) SetEnv(ctx context.Context, req *SetEnvRequest)
 		log.Error(err, "unable to set envvars", "envvars", envs)
 		return nil, err
 	}
+	r.env = envs
 
 	return &SetEnvReply{Message: "ok"}, nil
 }
@@ -343,9 +395,26 @@ func (r *TerraformRunnerServer) Init(ctx context.Context, req *InitRequest) (*In
 		}
 	}
 
-	initOpts := []tfexec.InitOption{tfexec.Upgrade(req.Upgrade), tfexec.ForceCopy(req.ForceCopy)}
+	lockFile := filepath.Join(r.tf.WorkingDir(), dependencyLockFilename)
+	upgrade := req.Upgrade
+	if !upgrade && req.DependencyLock != "" {
+		if err := os.WriteFile(lockFile, []byte(req.DependencyLock), 0644); err != nil {
+			log.Error(err, "unable to write the dependency lock file")
+			return nil, err
+		}
+	}
+
+	initOpts := []tfexec.InitOption{tfexec.Upgrade(upgrade), tfexec.ForceCopy(req.ForceCopy)}
 	initOpts = append(initOpts, backendConfigsOpts...)
-	if err := r.tf.Init(ctx, initOpts...); err != nil {
+	err := r.tf.Init(ctx, initOpts...)
+	if err != nil && !upgrade && dependencyLockMismatch(err) {
+		// the version constraints changed since the dependency lock
+		log.Info("the dependency lock does not match the version constraints, upgrading the providers")
+		upgrade = true
+		initOpts[0] = tfexec.Upgrade(upgrade)
+		err = r.tf.Init(ctx, initOpts...)
+	}
+	if err != nil {
 		st := status.New(codes.Internal, err.Error())
 		var stateErr *tfexec.ErrStateLocked
 
@@ -361,7 +430,19 @@ func (r *TerraformRunnerServer) Init(ctx context.Context, req *InitRequest) (*In
 		return nil, st.Err()
 	}
 
-	return &InitReply{Message: "ok"}, nil
+	dependencyLock, err := os.ReadFile(lockFile)
+	if err != nil && !os.IsNotExist(err) {
+		log.Error(err, "unable to read the dependency lock file")
+		return nil, err
+	}
+
+	return &InitReply{Message: "ok", DependencyLock: string(dependencyLock), Upgraded: upgrade}, nil
+}
+
+// dependencyLockMismatch reports whether terraform init failed because the
+// providers of the dependency lock do not match the version constraints.
+func dependencyLockMismatch(err error) bool {
+	return strings.Contains(err.Error(), "terraform init -upgrade")
 }
 
 func (r *TerraformRunnerServer) SelectWorkspace(ctx context.Context, req *WorkspaceRequest) (*WorkspaceReply, error) {
@@ -442,21 +523,15 @@ func (r *TerraformRunnerServer) LoadTFPlan(ctx context.Context, req *LoadTFPlanR
 func (r *TerraformRunnerServer) Destroy(ctx context.Context, req *DestroyRequest) (*DestroyReply, error) {
 	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
 	log.Info("running destroy")
-	ctx, cancel := context.WithCancel(ctx)
-	go func() {
-		select {
-		case <-r.Done:
-			cancel()
-		case <-ctx.Done():
-		}
-	}()
-
 	if req.TfInstance != r.InstanceID {
 		err := fmt.Errorf("no TF instance found")
 		log.Error(err, "no terraform")
 		return nil, err
 	}
 
+	ctx, done := r.startOperation(ctx, "destroy")
+	defer done()
+
 	var destroyOpt []tfexec.DestroyOption
 	for _, target := range req.Targets {
 		destroyOpt = append(destroyOpt, tfexec.Target(target))
@@ -484,21 +559,18 @@ func (r *TerraformRunnerServer) Destroy(ctx context.Context, req *DestroyRequest
 func (r *TerraformRunnerServer) Apply(ctx context.Context, req *ApplyRequest) (*ApplyReply, error) {
 	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
 	log.Info("running apply")
-	ctx, cancel := context.WithCancel(ctx)
-	go func() {
-		select {
-		case <-r.Done:
-			cancel()
-		case <-ctx.Done():
-		}
-	}()
-
 	if req.TfInstance != r.InstanceID {
 		err := fmt.Errorf("no TF instance found")
 		log.Error(err, "no terraform")
 		return nil, err
 	}
 
+	ctx, done := r.startOperation(ctx, "apply")
+	defer done()
+
+	stopEgressAudit := r.startEgressAudit(ctx, "apply")
+	defer stopEgressAudit()
+
 	var applyOpt []tfexec.ApplyOption
 	if req.DirOrPlan != "" {
 		applyOpt = []tfexec.ApplyOption{tfexec.DirOrPlan(req.DirOrPlan)}
@@ -516,6 +588,15 @@ func (r *TerraformRunnerServer) Apply(ctx context.Context, req *ApplyRequest) (*
 		applyOpt = append(applyOpt, tfexec.Parallelism(int(req.Parallelism)))
 	}
 
+	tracker := r.trackProgress("apply", req.ExpectedChanges)
+	defer tracker.finish()
+	if os.Getenv("DISABLE_TF_LOGS") == "1" {
+		r.tf.SetStdout(tracker)
+	} else {
+		r.tf.SetStdout(io.MultiWriter(os.Stdout, tracker))
+	}
+	defer r.initLogger(log)
+
 	if err := r.tf.Apply(ctx, applyOpt...); err != nil {
 		st := status.New(codes.Internal, err.Error())
 		var stateErr *tfexec.ErrStateLocked
@@ -526,13 +607,85 @@ func (r *TerraformRunnerServer) Apply(ctx context.Context, req *ApplyRequest) (*
 			if err != nil {
 				return nil, err
 			}
+		} else if detailed, detailsErr := st.WithDetails(&ApplyReply{
+			Message:     "not ok",
+			Diagnostics: r.errorDiagnostics(ctx, err.Error()),
+		}); detailsErr == nil {
+			st = detailed
 		}
 
 		log.Error(err, "unable to apply plan")
 		return nil, st.Err()
 	}
 
-	return &ApplyReply{Message: "ok"}, nil
+	return &ApplyReply{Message: "ok", ResourceTimings: tracker.resourceTimings()}, nil
+}
+
+// Refresh updates the state from the providers, without changing any
+// resource, like terraform apply -refresh-only.
+func (r *TerraformRunnerServer) Refresh(ctx context.Context, req *RefreshRequest) (*RefreshReply, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	log.Info("refreshing the state")
+	if req.TfInstance != r.InstanceID {
+		err := fmt.Errorf("no TF instance found")
+		log.Error(err, "no terraform")
+		return nil, err
+	}
+
+	ctx, done := r.startOperation(ctx, "refresh")
+	defer done()
+
+	stopEgressAudit := r.startEgressAudit(ctx, "refresh")
+	defer stopEgressAudit()
+
+	var refreshOpt []tfexec.RefreshCmdOption
+	for _, target := range req.Targets {
+		refreshOpt = append(refreshOpt, tfexec.Target(target))
+	}
+
+	tracker := r.trackProgress("refresh", 0)
+	defer tracker.finish()
+	if os.Getenv("DISABLE_TF_LOGS") == "1" {
+		r.tf.SetStdout(tracker)
+	} else {
+		r.tf.SetStdout(io.MultiWriter(os.Stdout, tracker))
+	}
+	defer r.initLogger(log)
+
+	// the refresh goes through the resources in the state
+	countCtx, cancelCount := context.WithCancel(ctx)
+	defer cancelCount()
+	go func() {
+		total, err := r.countStateResources(countCtx)
+		if err != nil {
+			log.Info(fmt.Sprintf("unable to count the resources of the state: %s", err.Error()))
+			return
+		}
+		tracker.setTotal(total)
+	}()
+
+	if err := r.tf.Refresh(ctx, refreshOpt...); err != nil {
+		st := status.New(codes.Internal, err.Error())
+		var stateErr *tfexec.ErrStateLocked
+
+		if errors.As(err, &stateErr) {
+			st, err = st.WithDetails(&RefreshReply{Message: "not ok", StateLockIdentifier: stateErr.ID})
+
+			if err != nil {
+				return nil, err
+			}
+		} else if detailed, detailsErr := st.WithDetails(&RefreshReply{
+			Message:     "not ok",
+			Diagnostics: r.errorDiagnostics(ctx, err.Error()),
+		}); detailsErr == nil {
+			st = detailed
+		}
+
+		log.Error(err, "unable to refresh the state")
+		return nil, st.Err()
+	}
+
+	return &RefreshReply{Message: "ok"}, nil
 }
 
 func getInventoryFromTerraformModule(m *tfjson.StateModule) []*Inventory {
@@ -592,6 +745,28 @@ func (r *TerraformRunnerServer) GetInventory(ctx context.Context, req *GetInvent
 func (r *TerraformRunnerServer) FinalizeSecrets(ctx context.Context, req *FinalizeSecretsRequest) (*FinalizeSecretsReply, error) {
 	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
 	log.Info("finalize the output secrets")
+
+	if req.StateSecretName != "" {
+		stateNamespace := req.Namespace
+		if req.StateSecretNamespace != "" {
+			stateNamespace = req.StateSecretNamespace
+		}
+		stateObjectKey := types.NamespacedName{Namespace: stateNamespace, Name: req.StateSecretName}
+		var stateSecret corev1.Secret
+		if err := r.Client.Get(ctx, stateObjectKey, &stateSecret); err == nil {
+			if err := r.Client.Delete(ctx, &stateSecret); err != nil && !apierrors.IsNotFound(err) {
+				// transient failure
+				log.Error(err, "unable to delete the state secret")
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			log.Info("deleted the state secret", "secret", stateObjectKey)
+		} else if !apierrors.IsNotFound(err) {
+			// transient failure
+			log.Error(err, "unable to get the state secret")
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
 	// nil dereference bug here
 	planObjectKey := types.NamespacedName{Namespace: req.Namespace, Name: "tfplan-" + req.Workspace + "-" + req.Name}
 	var planSecret corev1.Secret
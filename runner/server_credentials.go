@@ -0,0 +1,179 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	// awsDefaultRegion is used to reach the global STS endpoint when no region is configured.
+	awsDefaultRegion = "us-east-1"
+
+	// awsCredentialExpirationEnv declares the expiry of static AWS keys,
+	// which is otherwise unknown to the runner.
+	awsCredentialExpirationEnv = "AWS_CREDENTIAL_EXPIRATION"
+
+	// gcpAccessTokenEnv is the environment variable the Google provider reads an access token from.
+	gcpAccessTokenEnv = "GOOGLE_OAUTH_ACCESS_TOKEN"
+)
+
+var (
+	gcpTokenInfoURL      = "https://oauth2.googleapis.com/tokeninfo"
+	gcpMetadataTokenURL  = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+	credentialsCheckHTTP = &http.Client{Timeout: 10 * time.Second}
+)
+
+// CheckCredentials verifies the cloud credentials available to the runner
+// for each of the requested providers. An invalid credential is not an error,
+// it is reported in the reply instead.
+func (r *TerraformRunnerServer) CheckCredentials(ctx context.Context, req *CheckCredentialsRequest) (*CheckCredentialsReply, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	log.Info("checking credentials", "providers", req.Providers)
+
+	reply := &CheckCredentialsReply{}
+	for _, provider := range req.Providers {
+		var credentials *ProviderCredentials
+		switch infrav1.CredentialsProvider(provider) {
+		case infrav1.CredentialsProviderAWS:
+			credentials = checkAWSCredentials(ctx)
+		case infrav1.CredentialsProviderGCP:
+			credentials = checkGCPCredentials(ctx)
+		default:
+			err := fmt.Errorf("unsupported credentials provider: %s", provider)
+			log.Error(err, "unable to check credentials")
+			return nil, err
+		}
+
+		if !credentials.Valid {
+			log.Info("invalid credentials", "provider", provider, "message", credentials.Message)
+		}
+		reply.Credentials = append(reply.Credentials, credentials)
+	}
+
+	return reply, nil
+}
+
+func checkAWSCredentials(ctx context.Context) *ProviderCredentials {
+	result := &ProviderCredentials{Provider: string(infrav1.CredentialsProviderAWS)}
+
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		result.Message = fmt.Sprintf("unable to load AWS configuration: %s", err)
+		return result
+	}
+	if cfg.Region == "" {
+		cfg.Region = awsDefaultRegion
+	}
+
+	creds, err := cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		result.Message = fmt.Sprintf("unable to retrieve AWS credentials: %s", err)
+		return result
+	}
+
+	identity, err := sts.NewFromConfig(cfg).GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		result.Message = fmt.Sprintf("STS GetCallerIdentity failed: %s", err)
+		return result
+	}
+
+	result.Valid = true
+	result.Identity = aws.ToString(identity.Arn)
+	if creds.CanExpire {
+		result.ExpiresAt = creds.Expires.UTC().Format(time.RFC3339)
+	} else if expiresAt, err := time.Parse(time.RFC3339, os.Getenv(awsCredentialExpirationEnv)); err == nil {
+		result.ExpiresAt = expiresAt.UTC().Format(time.RFC3339)
+	}
+	return result
+}
+
+// gcpTokenInfo is the response of the Google OAuth2 token info endpoint.
+type gcpTokenInfo struct {
+	Email            string `json:"email"`
+	ExpiresIn        string `json:"expires_in"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func checkGCPCredentials(ctx context.Context) *ProviderCredentials {
+	result := &ProviderCredentials{Provider: string(infrav1.CredentialsProviderGCP)}
+
+	token := os.Getenv(gcpAccessTokenEnv)
+	if token == "" {
+		var err error
+		if token, err = gcpMetadataToken(ctx); err != nil {
+			result.Message = fmt.Sprintf("unable to obtain a GCP access token: %s", err)
+			return result
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, gcpTokenInfoURL+"?access_token="+url.QueryEscape(token), nil)
+	if err != nil {
+		result.Message = err.Error()
+		return result
+	}
+	resp, err := credentialsCheckHTTP.Do(req)
+	if err != nil {
+		result.Message = fmt.Sprintf("token introspection failed: %s", err)
+		return result
+	}
+	defer resp.Body.Close()
+
+	var info gcpTokenInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		result.Message = fmt.Sprintf("unable to decode the token info: %s", err)
+		return result
+	}
+	if resp.StatusCode != http.StatusOK {
+		result.Message = fmt.Sprintf("token introspection failed with status %d: %s", resp.StatusCode, info.ErrorDescription)
+		return result
+	}
+
+	result.Valid = true
+	result.Identity = info.Email
+	if expiresIn, err := strconv.Atoi(info.ExpiresIn); err == nil {
+		result.ExpiresAt = time.Now().Add(time.Duration(expiresIn) * time.Second).UTC().Format(time.RFC3339)
+	}
+	return result
+}
+
+// gcpMetadataToken obtains an access token of the default service account
+// from the metadata server, which is the case for the GKE Workload Identity.
+func gcpMetadataToken(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, gcpMetadataTokenURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	resp, err := credentialsCheckHTTP.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("metadata server returned status %d: %s", resp.StatusCode, body)
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", err
+	}
+	return token.AccessToken, nil
+}
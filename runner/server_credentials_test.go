@@ -0,0 +1,40 @@
+package runner
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestCheckGCPCredentials(t *testing.T) {
+	g := NewWithT(t)
+
+	tokenInfo := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("access_token") != "valid-token" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error_description": "Invalid Value"}`))
+			return
+		}
+		w.Write([]byte(`{"email": "runner@project.iam.gserviceaccount.com", "expires_in": "3599"}`))
+	}))
+	defer tokenInfo.Close()
+
+	oldURL := gcpTokenInfoURL
+	gcpTokenInfoURL = tokenInfo.URL
+	defer func() { gcpTokenInfoURL = oldURL }()
+
+	t.Setenv(gcpAccessTokenEnv, "valid-token")
+	result := checkGCPCredentials(context.Background())
+	g.Expect(result.Valid).To(BeTrue())
+	g.Expect(result.Provider).To(Equal("gcp"))
+	g.Expect(result.Identity).To(Equal("runner@project.iam.gserviceaccount.com"))
+	g.Expect(result.ExpiresAt).ToNot(BeEmpty())
+
+	t.Setenv(gcpAccessTokenEnv, "expired-token")
+	result = checkGCPCredentials(context.Background())
+	g.Expect(result.Valid).To(BeFalse())
+	g.Expect(result.Message).To(ContainSubstring("Invalid Value"))
+}
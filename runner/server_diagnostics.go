@@ -0,0 +1,127 @@
+package runner
+
+import (
+	"context"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// maxDiagnostics is the number of diagnostics returned for a failed command,
+// for the status of the object to stay small.
+const maxDiagnostics = 10
+
+var diagnosticLocationRe = regexp.MustCompile(`^on (.+) line (\d+)(?:, in .*)?:$`)
+
+// errorDiagnostics returns the diagnostics of a failed terraform command. The
+// diagnostics of the configuration are read from the machine-readable output
+// of terraform validate; the others, like the errors of the providers, are
+// parsed from the output of the failed command.
+func (r *TerraformRunnerServer) errorDiagnostics(ctx context.Context, output string) []*Diagnostic {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+
+	// the JSON output is not logged
+	r.tf.SetStdout(io.Discard)
+	r.tf.SetStderr(io.Discard)
+	defer r.initLogger(log)
+
+	validateOutput, err := r.tf.Validate(ctx)
+	if err != nil {
+		log.Info("unable to validate the configuration for its diagnostics", "error", err.Error())
+	} else if diagnostics := diagnosticsFromJSON(validateOutput.Diagnostics); !validateOutput.Valid && len(diagnostics) > 0 {
+		return diagnostics
+	}
+
+	return parseErrorDiagnostics(output)
+}
+
+// diagnosticsFromJSON converts the diagnostics of the JSON output of
+// terraform, without the warnings when there are errors.
+func diagnosticsFromJSON(jsonDiagnostics []tfjson.Diagnostic) []*Diagnostic {
+	var errors, warnings []*Diagnostic
+	for _, jsonDiagnostic := range jsonDiagnostics {
+		diagnostic := &Diagnostic{
+			Severity: string(jsonDiagnostic.Severity),
+			Summary:  jsonDiagnostic.Summary,
+			Detail:   jsonDiagnostic.Detail,
+		}
+		if jsonDiagnostic.Range != nil {
+			diagnostic.Filename = jsonDiagnostic.Range.Filename
+			diagnostic.Line = int32(jsonDiagnostic.Range.Start.Line)
+			diagnostic.Column = int32(jsonDiagnostic.Range.Start.Column)
+		}
+
+		if jsonDiagnostic.Severity == tfjson.DiagnosticSeverityError {
+			errors = append(errors, diagnostic)
+		} else {
+			warnings = append(warnings, diagnostic)
+		}
+	}
+
+	if len(errors) == 0 {
+		return limitDiagnostics(warnings)
+	}
+	return limitDiagnostics(errors)
+}
+
+// parseErrorDiagnostics returns the error diagnostics printed by terraform,
+// with the file and the line of their source snippet.
+func parseErrorDiagnostics(output string) []*Diagnostic {
+	var result []*Diagnostic
+	for _, lines := range splitDiagnostics(output) {
+		if len(lines) == 0 {
+			continue
+		}
+
+		diagnostic := &Diagnostic{
+			Severity: string(tfjson.DiagnosticSeverityError),
+			Summary:  strings.TrimPrefix(lines[0], "Error: "),
+		}
+
+		var paragraphs, paragraph []string
+		inSource := false
+		endParagraph := func() {
+			if len(paragraph) > 0 {
+				paragraphs = append(paragraphs, strings.Join(paragraph, " "))
+				paragraph = nil
+			}
+		}
+		for _, line := range lines[1:] {
+			trimmed := strings.TrimSpace(line)
+			switch {
+			case diagnosticLocationRe.MatchString(trimmed):
+				// the source snippet, down to the next blank line
+				inSource = true
+				m := diagnosticLocationRe.FindStringSubmatch(trimmed)
+				if diagnostic.Filename == "" {
+					line, _ := strconv.Atoi(m[2])
+					diagnostic.Filename, diagnostic.Line = m[1], int32(line)
+				}
+			case strings.HasPrefix(trimmed, "with ") && strings.HasSuffix(trimmed, ","):
+				// the address of the resource, before its source snippet
+			case trimmed == "":
+				inSource = false
+				endParagraph()
+			case !inSource:
+				paragraph = append(paragraph, trimmed)
+			}
+		}
+		endParagraph()
+
+		diagnostic.Detail = strings.Join(paragraphs, "\n")
+		result = append(result, diagnostic)
+	}
+
+	return limitDiagnostics(result)
+}
+
+func limitDiagnostics(diagnostics []*Diagnostic) []*Diagnostic {
+	if len(diagnostics) > maxDiagnostics {
+		return diagnostics[:maxDiagnostics]
+	}
+	return diagnostics
+}
@@ -0,0 +1,77 @@
+package runner
+
+import (
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	. "github.com/onsi/gomega"
+)
+
+func TestParseErrorDiagnostics(t *testing.T) {
+	g := NewWithT(t)
+
+	output := `exit status 1
+
+Warning: Argument is deprecated
+
+  on main.tf line 3, in resource "aws_s3_bucket" "logs":
+   3:   acl = "private"
+
+Error: creating S3 Bucket (logs): BucketAlreadyExists
+
+  with aws_s3_bucket.logs,
+  on main.tf line 1, in resource "aws_s3_bucket" "logs":
+   1: resource "aws_s3_bucket" "logs" {
+
+The requested bucket name is not available.
+Select a different name and try again.
+
+Error: Unsupported argument
+
+  on modules/vpc/main.tf line 12:
+  12:   cidr = "10.0.0.0/16"
+
+An argument named "cidr" is not expected here.
+`
+	diagnostics := parseErrorDiagnostics(output)
+	g.Expect(diagnostics).To(HaveLen(2))
+
+	g.Expect(diagnostics[0].Severity).To(Equal("error"))
+	g.Expect(diagnostics[0].Summary).To(Equal("creating S3 Bucket (logs): BucketAlreadyExists"))
+	g.Expect(diagnostics[0].Detail).To(Equal("The requested bucket name is not available. Select a different name and try again."))
+	g.Expect(diagnostics[0].Filename).To(Equal("main.tf"))
+	g.Expect(diagnostics[0].Line).To(Equal(int32(1)))
+
+	g.Expect(diagnostics[1].Summary).To(Equal("Unsupported argument"))
+	g.Expect(diagnostics[1].Detail).To(Equal(`An argument named "cidr" is not expected here.`))
+	g.Expect(diagnostics[1].Filename).To(Equal("modules/vpc/main.tf"))
+	g.Expect(diagnostics[1].Line).To(Equal(int32(12)))
+
+	g.Expect(parseErrorDiagnostics("exit status 1")).To(BeEmpty())
+}
+
+func TestDiagnosticsFromJSON(t *testing.T) {
+	g := NewWithT(t)
+
+	diagnostics := diagnosticsFromJSON([]tfjson.Diagnostic{
+		{
+			Severity: tfjson.DiagnosticSeverityWarning,
+			Summary:  "Argument is deprecated",
+		},
+		{
+			Severity: tfjson.DiagnosticSeverityError,
+			Summary:  "Reference to undeclared input variable",
+			Detail:   `An input variable with the name "region" has not been declared.`,
+			Range: &tfjson.Range{
+				Filename: "main.tf",
+				Start:    tfjson.Pos{Line: 2, Column: 12},
+				End:      tfjson.Pos{Line: 2, Column: 22},
+			},
+		},
+	})
+	g.Expect(diagnostics).To(HaveLen(1))
+	g.Expect(diagnostics[0].Severity).To(Equal("error"))
+	g.Expect(diagnostics[0].Filename).To(Equal("main.tf"))
+	g.Expect(diagnostics[0].Line).To(Equal(int32(2)))
+	g.Expect(diagnostics[0].Column).To(Equal(int32(12)))
+}
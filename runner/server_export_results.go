@@ -0,0 +1,68 @@
+package runner
+
+import (
+	"context"
+	"reflect"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// ExportResults writes the reports of a run to a ConfigMap, replacing the
+// reports of the previous run.
+func (r *TerraformRunnerServer) ExportResults(ctx context.Context, req *ExportResultsRequest) (*ExportResultsReply, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	log.Info("export results to configmap", "configmap", req.ConfigMapName)
+
+	objectKey := types.NamespacedName{Namespace: req.Namespace, Name: req.ConfigMapName}
+	var resultsConfigMap corev1.ConfigMap
+
+	if err := r.Client.Get(ctx, objectKey, &resultsConfigMap); err == nil {
+		if reflect.DeepEqual(resultsConfigMap.Data, req.Data) &&
+			reflect.DeepEqual(resultsConfigMap.Annotations, req.Annotations) {
+			return &ExportResultsReply{Message: "ok"}, nil
+		}
+
+		resultsConfigMap.Data = req.Data
+		resultsConfigMap.Annotations = req.Annotations
+		if err := r.Client.Update(ctx, &resultsConfigMap); err != nil {
+			log.Error(err, "unable to update results configmap")
+			return nil, err
+		}
+
+		return &ExportResultsReply{Message: "ok"}, nil
+	} else if !apierrors.IsNotFound(err) {
+		log.Error(err, "unable to get results configmap")
+		return nil, err
+	}
+
+	vTrue := true
+	resultsConfigMap = corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        req.ConfigMapName,
+			Namespace:   req.Namespace,
+			Annotations: req.Annotations,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: infrav1.GroupVersion.Group + "/" + infrav1.GroupVersion.Version,
+					Kind:       infrav1.TerraformKind,
+					Name:       req.Name,
+					UID:        types.UID(req.Uuid),
+					Controller: &vTrue,
+				},
+			},
+		},
+		Data: req.Data,
+	}
+
+	if err := r.Client.Create(ctx, &resultsConfigMap); err != nil {
+		log.Error(err, "unable to create results configmap")
+		return nil, err
+	}
+
+	return &ExportResultsReply{Message: "ok"}, nil
+}
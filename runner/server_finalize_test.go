@@ -0,0 +1,69 @@
+package runner
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestFinalizeSecretsDeletesState(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	secret := func(name string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "flux-system"}}
+	}
+	r := &TerraformRunnerServer{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(secret("tfplan-default-helloworld"), secret("tfstate-default-helloworld")).Build(),
+	}
+
+	_, err := r.FinalizeSecrets(ctx, &FinalizeSecretsRequest{
+		Namespace:       "flux-system",
+		Name:            "helloworld",
+		Workspace:       "default",
+		StateSecretName: "tfstate-default-helloworld",
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	for _, name := range []string{"tfplan-default-helloworld", "tfstate-default-helloworld"} {
+		err := r.Get(ctx, types.NamespacedName{Namespace: "flux-system", Name: name}, &corev1.Secret{})
+		g.Expect(apierrors.IsNotFound(err)).To(BeTrue(), name)
+	}
+}
+
+func TestFinalizeSecretsDeletesStateInAnotherNamespace(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	plan := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "tfplan-default-helloworld", Namespace: "team-a"}}
+	state := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "tfstate-default-helloworld", Namespace: "tf-state"}}
+	r := &TerraformRunnerServer{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(plan, state).Build(),
+	}
+
+	_, err := r.FinalizeSecrets(ctx, &FinalizeSecretsRequest{
+		Namespace:            "team-a",
+		Name:                 "helloworld",
+		Workspace:            "default",
+		StateSecretName:      "tfstate-default-helloworld",
+		StateSecretNamespace: "tf-state",
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	err = r.Get(ctx, types.NamespacedName{Namespace: "tf-state", Name: "tfstate-default-helloworld"}, &corev1.Secret{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
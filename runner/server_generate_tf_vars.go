@@ -340,6 +340,15 @@ func (r *TerraformRunnerServer) GenerateVarsForTF(ctx context.Context, req *Gene
 					}
 				}
 			}
+		} else if vf.Kind == "Exec" {
+			execVars, err := execVars(ctx, req.WorkingDir, vf)
+			if err != nil {
+				log.Error(err, "unable to run the command of varsFrom", "name", vf.Name)
+				return nil, err
+			}
+			for key, val := range execVars {
+				vars[key] = val
+			}
 		}
 	}
 
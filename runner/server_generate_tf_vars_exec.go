@@ -0,0 +1,185 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+const (
+	defaultExecVarsTimeout       = 30 * time.Second
+	defaultExecVarsMaxOutputSize = 1 << 20
+	// maxExecVarsStderrSize caps the standard error of the command kept for
+	// the error message.
+	maxExecVarsStderrSize = 4 << 10
+)
+
+// execVars runs the command of an Exec varsFrom in the working directory,
+// and returns the variables of the JSON object it writes to its standard
+// output, selected and renamed by the varsKeys. A missing binary is ignored
+// when the varsFrom is optional.
+func execVars(ctx context.Context, workingDir string, vf infrav1.VarsReference) (map[string]*apiextensionsv1.JSON, error) {
+	if vf.Exec == nil || len(vf.Exec.Command) == 0 {
+		return nil, fmt.Errorf("varsFrom %s: exec.command is required with the Exec kind", vf.Name)
+	}
+
+	timeout := defaultExecVarsTimeout
+	if vf.Exec.Timeout != nil {
+		timeout = vf.Exec.Timeout.Duration
+	}
+	maxOutputSize := int64(defaultExecVarsMaxOutputSize)
+	if vf.Exec.MaxOutputSize != nil {
+		maxOutputSize = vf.Exec.MaxOutputSize.Value()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	stdout := &limitedBuffer{max: maxOutputSize}
+	stderr := &limitedBuffer{max: maxExecVarsStderrSize, truncate: true}
+	command := scriptCommand(runtime.GOOS, vf.Exec.Command)
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Dir = workingDir
+
+	err := runWithOutput(ctx, cmd, stdout, stderr)
+	switch {
+	case err != nil && vf.Optional && (errors.Is(err, exec.ErrNotFound) || errors.Is(err, fs.ErrNotExist)):
+		return nil, nil
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return nil, fmt.Errorf("varsFrom %s: the command did not finish within %s", vf.Name, timeout)
+	case stdout.exceeded:
+		return nil, fmt.Errorf("varsFrom %s: the output of the command exceeds %d bytes", vf.Name, maxOutputSize)
+	case err != nil:
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("varsFrom %s: %w: %s", vf.Name, err, message)
+		}
+		return nil, fmt.Errorf("varsFrom %s: %w", vf.Name, err)
+	}
+
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(stdout.Bytes(), &values); err != nil || values == nil {
+		return nil, fmt.Errorf("varsFrom %s: the output of the command must be a JSON object", vf.Name)
+	}
+
+	vars := map[string]*apiextensionsv1.JSON{}
+	// if VarsKeys is null, use all
+	if vf.VarsKeys == nil {
+		for key, value := range values {
+			vars[key] = &apiextensionsv1.JSON{Raw: value}
+		}
+		return vars, nil
+	}
+
+	for _, pattern := range vf.VarsKeys {
+		oldKey, newKey, err := parseRenamePattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		if value, ok := values[oldKey]; ok {
+			vars[newKey] = &apiextensionsv1.JSON{Raw: value}
+		}
+	}
+	return vars, nil
+}
+
+// runWithOutput runs the command with its standard output and error copied
+// to the writers. Unlike with cmd.Run, it does not wait for the processes
+// the command started, which may keep its output open, once the context is
+// done.
+func runWithOutput(ctx context.Context, cmd *exec.Cmd, stdout, stderr io.Writer) error {
+	var (
+		readers []*os.File
+		copies  []chan error
+	)
+	for _, output := range []struct {
+		set func(*os.File)
+		w   io.Writer
+	}{
+		{func(f *os.File) { cmd.Stdout = f }, stdout},
+		{func(f *os.File) { cmd.Stderr = f }, stderr},
+	} {
+		r, w, err := os.Pipe()
+		if err != nil {
+			return err
+		}
+		defer w.Close()
+		output.set(w)
+		readers = append(readers, r)
+
+		copied := make(chan error, 1)
+		go func(dst io.Writer) {
+			// closing the pipe when the output is too large fails the
+			// writes of the command
+			defer r.Close()
+			_, err := io.Copy(dst, r)
+			copied <- err
+		}(output.w)
+		copies = append(copies, copied)
+	}
+
+	if err := cmd.Start(); err != nil {
+		for _, r := range readers {
+			r.Close()
+		}
+		return err
+	}
+	cmd.Stdout.(*os.File).Close()
+	cmd.Stderr.(*os.File).Close()
+
+	err := cmd.Wait()
+	for _, copied := range copies {
+		select {
+		case <-copied:
+		case <-ctx.Done():
+			for _, r := range readers {
+				r.Close()
+			}
+			<-copied
+		}
+	}
+	return err
+}
+
+// limitedBuffer is a buffer which fails the writes beyond its maximum size,
+// or drops them when it truncates. It does not embed bytes.Buffer, for
+// io.Copy not to bypass the limit with its ReadFrom.
+type limitedBuffer struct {
+	buf      bytes.Buffer
+	max      int64
+	truncate bool
+	exceeded bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if int64(b.buf.Len()+len(p)) > b.max {
+		b.exceeded = true
+		if !b.truncate {
+			return 0, fmt.Errorf("output exceeds %d bytes", b.max)
+		}
+		if remaining := b.max - int64(b.buf.Len()); remaining > 0 {
+			b.buf.Write(p[:remaining])
+		}
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+func (b *limitedBuffer) Bytes() []byte {
+	return b.buf.Bytes()
+}
+
+func (b *limitedBuffer) String() string {
+	return b.buf.String()
+}
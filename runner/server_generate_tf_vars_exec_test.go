@@ -0,0 +1,92 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestExecVars(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	plugin := func(name, script string) string {
+		path := filepath.Join(dir, name)
+		g.Expect(os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0o700)).To(Succeed())
+		return path
+	}
+	exec := func(command ...string) *infrav1.ExecVarsSource {
+		return &infrav1.ExecVarsSource{Command: command}
+	}
+
+	ipam := plugin("ipam", `echo '{"cidr": "10.0.'"$1"'.0/24", "vlan": 42, "tags": {"team": "a"}}'`)
+	vars, err := execVars(ctx, dir, infrav1.VarsReference{Kind: "Exec", Name: "ipam", Exec: exec(ipam, "3")})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(vars).To(HaveLen(3))
+	g.Expect(string(vars["cidr"].Raw)).To(Equal(`"10.0.3.0/24"`))
+	g.Expect(string(vars["vlan"].Raw)).To(Equal(`42`))
+
+	// varsKeys select and rename the values
+	vars, err = execVars(ctx, dir, infrav1.VarsReference{Kind: "Exec", Name: "ipam", Exec: exec(ipam, "3"), VarsKeys: []string{"cidr:subnet", "missing"}})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(vars).To(HaveLen(1))
+	g.Expect(string(vars["subnet"].Raw)).To(Equal(`"10.0.3.0/24"`))
+
+	// the command runs in the working directory
+	pwd := plugin("pwd", `printf '{"dir": "%s"}' "$(pwd)"`)
+	vars, err = execVars(ctx, dir, infrav1.VarsReference{Kind: "Exec", Name: "pwd", Exec: exec(pwd)})
+	g.Expect(err).ToNot(HaveOccurred())
+	realDir, _ := filepath.EvalSymlinks(dir)
+	g.Expect(string(vars["dir"].Raw)).To(Or(Equal(`"`+dir+`"`), Equal(`"`+realDir+`"`)))
+
+	failing := plugin("failing", `echo "cmdb is unreachable" >&2; exit 3`)
+	_, err = execVars(ctx, dir, infrav1.VarsReference{Kind: "Exec", Name: "cmdb", Exec: exec(failing)})
+	g.Expect(err).To(MatchError(ContainSubstring("varsFrom cmdb: exit status 3: cmdb is unreachable")))
+
+	notAnObject := plugin("array", `echo '["a", "b"]'`)
+	_, err = execVars(ctx, dir, infrav1.VarsReference{Kind: "Exec", Name: "array", Exec: exec(notAnObject)})
+	g.Expect(err).To(MatchError("varsFrom array: the output of the command must be a JSON object"))
+
+	slow := plugin("slow", `sleep 5; echo '{}'`)
+	slowExec := exec(slow)
+	slowExec.Timeout = &metav1.Duration{Duration: 100 * time.Millisecond}
+	_, err = execVars(ctx, dir, infrav1.VarsReference{Kind: "Exec", Name: "slow", Exec: slowExec})
+	g.Expect(err).To(MatchError("varsFrom slow: the command did not finish within 100ms"))
+
+	large := plugin("large", `head -c 4096 /dev/zero`)
+	largeExec := exec(large)
+	maxOutputSize := resource.MustParse("1Ki")
+	largeExec.MaxOutputSize = &maxOutputSize
+	_, err = execVars(ctx, dir, infrav1.VarsReference{Kind: "Exec", Name: "large", Exec: largeExec})
+	g.Expect(err).To(MatchError("varsFrom large: the output of the command exceeds 1024 bytes"))
+
+	// a missing binary fails, unless the varsFrom is optional
+	missing := filepath.Join(dir, "missing")
+	_, err = execVars(ctx, dir, infrav1.VarsReference{Kind: "Exec", Name: "missing", Exec: exec(missing)})
+	g.Expect(err).To(HaveOccurred())
+	vars, err = execVars(ctx, dir, infrav1.VarsReference{Kind: "Exec", Name: "missing", Exec: exec(missing), Optional: true})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(vars).To(BeEmpty())
+
+	_, err = execVars(ctx, dir, infrav1.VarsReference{Kind: "Exec", Name: "empty"})
+	g.Expect(err).To(MatchError("varsFrom empty: exec.command is required with the Exec kind"))
+}
+
+func TestScriptCommand(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(scriptCommand("windows", []string{`.\scripts\ipam.ps1`, "3"})).To(Equal([]string{
+		"powershell.exe", "-NoLogo", "-NoProfile", "-NonInteractive", "-ExecutionPolicy", "Bypass", "-File", `.\scripts\ipam.ps1`, "3",
+	}))
+	g.Expect(scriptCommand("windows", []string{"C:/tools/IPAM.PS1"})).To(HaveLen(8))
+	g.Expect(scriptCommand("windows", []string{`C:\tools\ipam.exe`, "3"})).To(Equal([]string{`C:\tools\ipam.exe`, "3"}))
+	g.Expect(scriptCommand("linux", []string{"./ipam.ps1"})).To(Equal([]string{"./ipam.ps1"}))
+}
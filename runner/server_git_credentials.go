@@ -0,0 +1,138 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	securejoin "github.com/cyphar/filepath-securejoin"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const gitCredentialsDir = ".git-credentials"
+
+// ProcessGitCredentials writes the Git credentials of a Secret to the
+// directory of the request, and returns the environment variables which make
+// the git commands run by terraform init use them.
+func (r *TerraformRunnerServer) ProcessGitCredentials(ctx context.Context, req *ProcessGitCredentialsRequest) (*ProcessGitCredentialsReply, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	log.Info("processing git credentials", "namespace", req.Namespace, "name", req.Name)
+
+	secretKey := types.NamespacedName{Namespace: req.Namespace, Name: req.Name}
+	var secret corev1.Secret
+	if err := r.Get(ctx, secretKey, &secret); err != nil {
+		log.Error(err, "unable to get secret", "namespace", req.Namespace, "name", req.Name)
+		return nil, err
+	}
+
+	dir, err := securejoin.SecureJoin(req.DirPath, gitCredentialsDir)
+	if err != nil {
+		log.Error(err, "secure join error", "dirPath", req.DirPath)
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		log.Error(err, "unable to create git credentials directory", "dir", dir)
+		return nil, err
+	}
+
+	envs, err := writeGitCredentials(dir, secret.Data, req.Hosts)
+	if err != nil {
+		log.Error(err, "unable to write git credentials", "namespace", req.Namespace, "name", req.Name)
+		return nil, err
+	}
+
+	return &ProcessGitCredentialsReply{Envs: envs}, nil
+}
+
+// writeGitCredentials writes the SSH key and known hosts, and a Git
+// configuration for the HTTPS credentials, to dir.
+func writeGitCredentials(dir string, data map[string][]byte, hosts []string) (map[string]string, error) {
+	writeFile := func(name string, content []byte) (string, error) {
+		path := filepath.Join(dir, name)
+		return path, os.WriteFile(path, content, 0600)
+	}
+
+	envs := map[string]string{
+		// fail rather than wait for a password nobody will type
+		"GIT_TERMINAL_PROMPT": "0",
+	}
+
+	if identity, ok := data["identity"]; ok {
+		knownHosts, ok := data["known_hosts"]
+		if !ok {
+			return nil, fmt.Errorf("the secret must contain known_hosts along with identity")
+		}
+
+		identityPath, err := writeFile("identity", identity)
+		if err != nil {
+			return nil, err
+		}
+		knownHostsPath, err := writeFile("known_hosts", knownHosts)
+		if err != nil {
+			return nil, err
+		}
+
+		envs["GIT_SSH_COMMAND"] = fmt.Sprintf("ssh -i '%s' -o IdentitiesOnly=yes -o UserKnownHostsFile='%s' -o StrictHostKeyChecking=yes",
+			identityPath, knownHostsPath)
+	}
+
+	_, hasUsername := data["username"]
+	_, hasPassword := data["password"]
+	bearerToken, hasBearerToken := data["bearerToken"]
+	if hasUsername != hasPassword {
+		return nil, fmt.Errorf("the secret must contain both username and password, or neither")
+	}
+
+	var gitConfig strings.Builder
+	if hasUsername {
+		usernamePath, err := writeFile("username", data["username"])
+		if err != nil {
+			return nil, err
+		}
+		passwordPath, err := writeFile("password", data["password"])
+		if err != nil {
+			return nil, err
+		}
+
+		// the helper reads the files on each request, so that the
+		// credentials never appear in the configuration
+		helper := fmt.Sprintf(`!f() { test \"$1\" = get && echo username=$(cat '%s') && echo password=$(cat '%s'); }; f`,
+			usernamePath, passwordPath)
+		for _, section := range gitConfigSections("credential", hosts, "") {
+			fmt.Fprintf(&gitConfig, "[%s]\n\thelper = \"%s\"\n", section, helper)
+		}
+	}
+	if hasBearerToken {
+		for _, section := range gitConfigSections("http", hosts, "/") {
+			fmt.Fprintf(&gitConfig, "[%s]\n\textraHeader = \"Authorization: Bearer %s\"\n", section, strings.TrimSpace(string(bearerToken)))
+		}
+	}
+
+	if gitConfig.Len() > 0 {
+		gitConfigPath, err := writeFile("gitconfig", []byte(gitConfig.String()))
+		if err != nil {
+			return nil, err
+		}
+		envs["GIT_CONFIG_GLOBAL"] = gitConfigPath
+	}
+
+	return envs, nil
+}
+
+// gitConfigSections returns the name of the section of the Git configuration
+// for each host, or the section for all hosts when there are none.
+func gitConfigSections(name string, hosts []string, suffix string) []string {
+	if len(hosts) == 0 {
+		return []string{name}
+	}
+
+	sections := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		sections = append(sections, fmt.Sprintf(`%s "https://%s%s"`, name, host, suffix))
+	}
+	return sections
+}
@@ -0,0 +1,77 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestProcessGitCredentials(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "git-credentials", Namespace: "flux-system"},
+		Data: map[string][]byte{
+			"identity":    []byte("private key"),
+			"known_hosts": []byte("github.com ssh-ed25519 AAAA"),
+			"username":    []byte("git"),
+			"password":    []byte("token"),
+		},
+	}
+	r := &TerraformRunnerServer{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build(),
+	}
+
+	dir := t.TempDir()
+	reply, err := r.ProcessGitCredentials(context.Background(), &ProcessGitCredentialsRequest{
+		DirPath:   dir,
+		Namespace: "flux-system",
+		Name:      "git-credentials",
+		Hosts:     []string{"github.com"},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	credentialsDir := filepath.Join(dir, gitCredentialsDir)
+	identityPath := filepath.Join(credentialsDir, "identity")
+	g.Expect(reply.Envs["GIT_SSH_COMMAND"]).To(ContainSubstring("-i '" + identityPath + "'"))
+	g.Expect(reply.Envs["GIT_SSH_COMMAND"]).To(ContainSubstring("StrictHostKeyChecking=yes"))
+	g.Expect(reply.Envs["GIT_CONFIG_GLOBAL"]).To(Equal(filepath.Join(credentialsDir, "gitconfig")))
+
+	info, err := os.Stat(identityPath)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+
+	gitConfig, err := os.ReadFile(reply.Envs["GIT_CONFIG_GLOBAL"])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(gitConfig)).To(HavePrefix(`[credential "https://github.com"]`))
+	g.Expect(string(gitConfig)).ToNot(ContainSubstring("token"))
+}
+
+func TestWriteGitCredentials(t *testing.T) {
+	g := NewWithT(t)
+
+	envs, err := writeGitCredentials(t.TempDir(), map[string][]byte{"bearerToken": []byte("token\n")}, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(envs).ToNot(HaveKey("GIT_SSH_COMMAND"))
+
+	gitConfig, err := os.ReadFile(envs["GIT_CONFIG_GLOBAL"])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(gitConfig)).To(Equal("[http]\n\textraHeader = \"Authorization: Bearer token\"\n"))
+
+	_, err = writeGitCredentials(t.TempDir(), map[string][]byte{"identity": []byte("private key")}, nil)
+	g.Expect(err).To(MatchError(ContainSubstring("known_hosts")))
+
+	_, err = writeGitCredentials(t.TempDir(), map[string][]byte{"username": []byte("git")}, nil)
+	g.Expect(err).To(MatchError(ContainSubstring("password")))
+}
@@ -1,10 +1,14 @@
 package runner
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
-	"reflect"
+	"sort"
 
 	"github.com/hashicorp/terraform-exec/tfexec"
 	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
@@ -13,6 +17,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func (r *TerraformRunnerServer) tfOutput(ctx context.Context, opts ...tfexec.OutputOption) (map[string]tfexec.OutputMeta, error) {
@@ -53,6 +58,11 @@ func (r *TerraformRunnerServer) Output(ctx context.Context, req *OutputRequest)
 	return outputReply, nil
 }
 
+// WriteOutputs writes the outputs to the secret of the request. The data of
+// an existing secret is only rewritten when the hash of the outputs changed,
+// so that the workloads reloaded on secret changes are not restarted by
+// every apply. With the checksums of the request, the secret is stamped with
+// the checksum of each key, and the reply lists the keys which changed.
 func (r *TerraformRunnerServer) WriteOutputs(ctx context.Context, req *WriteOutputsRequest) (*WriteOutputsReply, error) {
 	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
 	log.Info("write outputs to secret")
@@ -60,62 +70,150 @@ func (r *TerraformRunnerServer) WriteOutputs(ctx context.Context, req *WriteOutp
 	objectKey := types.NamespacedName{Namespace: req.Namespace, Name: req.SecretName}
 	var outputSecret corev1.Secret
 
-	drift := true
-	create := true
-	if err := r.Client.Get(ctx, objectKey, &outputSecret); err == nil {
-		// if everything is there, we don't write anything
-		if reflect.DeepEqual(outputSecret.Data, req.Data) {
-			drift = false
-		} else {
-			// found, but need update
-			create = false
+	hash := outputsHash(req.Data)
+	annotations, err := outputsAnnotations(req, hash)
+	if err != nil {
+		log.Error(err, "unable to compute the checksums of the outputs")
+		return nil, err
+	}
+	if err := r.Client.Get(ctx, objectKey, &outputSecret); apierrors.IsNotFound(err) {
+		vTrue := true
+		outputSecret = corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        req.SecretName,
+				Namespace:   req.Namespace,
+				Labels:      req.Labels,
+				Annotations: annotations,
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: infrav1.GroupVersion.Group + "/" + infrav1.GroupVersion.Version,
+						Kind:       infrav1.TerraformKind,
+						Name:       req.Name,
+						UID:        types.UID(req.Uuid),
+						Controller: &vTrue,
+					},
+				},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: req.Data,
 		}
-	} else if apierrors.IsNotFound(err) == false {
+
+		if err := r.Client.Create(ctx, &outputSecret); err != nil {
+			log.Error(err, "unable to create secret")
+			return nil, err
+		}
+
+		reply := &WriteOutputsReply{Message: "ok", Changed: true}
+		if req.Checksums {
+			reply.ChangedKeys = changedOutputKeys(nil, req.Data)
+		}
+		return reply, nil
+	} else if err != nil {
 		log.Error(err, "unable to get output secret")
 		return nil, err
 	}
 
-	if drift {
-		if create {
-			vTrue := true
-			outputSecret = corev1.Secret{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:        req.SecretName,
-					Namespace:   req.Namespace,
-					Labels:      req.Labels,
-					Annotations: req.Annotations,
-					OwnerReferences: []metav1.OwnerReference{
-						{
-							APIVersion: infrav1.GroupVersion.Group + "/" + infrav1.GroupVersion.Version,
-							Kind:       infrav1.TerraformKind,
-							Name:       req.Name,
-							UID:        types.UID(req.Uuid),
-							Controller: &vTrue,
-						},
-					},
-				},
-				Type: corev1.SecretTypeOpaque,
-				Data: req.Data,
-			}
-
-			err := r.Client.Create(ctx, &outputSecret)
-			if err != nil {
-				log.Error(err, "unable to create secret")
-				return nil, err
-			}
-		} else {
-			outputSecret.Data = req.Data
-			err := r.Client.Update(ctx, &outputSecret)
-			if err != nil {
-				log.Error(err, "unable to update secret")
-				return nil, err
-			}
+	// the data is hashed rather than the annotation trusted, so that the
+	// changes made to the secret by hand are reverted
+	changed := outputsHash(outputSecret.Data) != hash
+
+	previous := outputSecret.Data
+	patch := client.MergeFrom(outputSecret.DeepCopy())
+	if changed {
+		outputSecret.Data = req.Data
+	}
+	for k, v := range req.Labels {
+		metav1.SetMetaDataLabel(&outputSecret.ObjectMeta, k, v)
+	}
+	for k, v := range annotations {
+		metav1.SetMetaDataAnnotation(&outputSecret.ObjectMeta, k, v)
+	}
+
+	if !changed && !metadataChanged(patch, &outputSecret) {
+		return &WriteOutputsReply{Message: "ok", Changed: false}, nil
+	}
+
+	if err := r.Client.Patch(ctx, &outputSecret, patch); err != nil {
+		log.Error(err, "unable to update secret")
+		return nil, err
+	}
+
+	reply := &WriteOutputsReply{Message: "ok", Changed: changed}
+	if changed && req.Checksums {
+		reply.ChangedKeys = changedOutputKeys(previous, req.Data)
+	}
+	return reply, nil
+}
+
+// outputsHash returns the hash of the data of the outputs secret, which does
+// not depend on the order of the keys.
+func outputsHash(data map[string][]byte) string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		fmt.Fprintf(h, "%d:%s%d:", len(k), k, len(data[k]))
+		h.Write(data[k])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// outputsAnnotations returns the annotations of the outputs secret: those of
+// the request, the hash of the data and, with the checksums of the request,
+// the checksum of each key.
+func outputsAnnotations(req *WriteOutputsRequest, hash string) (map[string]string, error) {
+	result := make(map[string]string, len(req.Annotations)+2)
+	for k, v := range req.Annotations {
+		result[k] = v
+	}
+	result[infrav1.OutputsHashAnnotation] = hash
+
+	if req.Checksums {
+		checksums := make(map[string]string, len(req.Data))
+		for k, v := range req.Data {
+			checksums[k] = outputChecksum(v)
+		}
+		// the keys of a map are marshalled in order
+		out, err := json.Marshal(checksums)
+		if err != nil {
+			return nil, err
 		}
+		result[infrav1.OutputsChecksumsAnnotation] = string(out)
+	}
+	return result, nil
+}
+
+func outputChecksum(value []byte) string {
+	sum := sha256.Sum256(value)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
 
-		return &WriteOutputsReply{Message: "ok", Changed: true}, nil
+// changedOutputKeys returns the keys added, changed or removed between the
+// previous and the current data of the outputs secret, in order.
+func changedOutputKeys(previous, current map[string][]byte) []string {
+	var keys []string
+	for k, v := range current {
+		if old, ok := previous[k]; !ok || !bytes.Equal(old, v) {
+			keys = append(keys, k)
+		}
 	}
+	for k := range previous {
+		if _, ok := current[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
 
-	return &WriteOutputsReply{Message: "ok", Changed: false}, nil
+// metadataChanged tells whether the patch of the secret is not empty.
+func metadataChanged(patch client.Patch, outputSecret *corev1.Secret) bool {
+	data, err := patch.Data(outputSecret)
+	return err != nil || string(data) != "{}"
 }
 
 func (r *TerraformRunnerServer) GetOutputs(ctx context.Context, req *GetOutputsRequest) (*GetOutputsReply, error) {
@@ -0,0 +1,121 @@
+package runner
+
+import (
+	"context"
+	"testing"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestWriteOutputsOnlyWhenChanged(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	r := &TerraformRunnerServer{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+	}
+
+	req := &WriteOutputsRequest{
+		Namespace:  "flux-system",
+		Name:       "helloworld",
+		SecretName: "helloworld-outputs",
+		Uuid:       "uid",
+		Data:       map[string][]byte{"hello": []byte("world"), "empty": {}},
+	}
+	reply, err := r.WriteOutputs(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(reply.Changed).To(BeTrue())
+
+	key := types.NamespacedName{Namespace: "flux-system", Name: "helloworld-outputs"}
+	var secret corev1.Secret
+	g.Expect(r.Get(ctx, key, &secret)).To(Succeed())
+	g.Expect(secret.Annotations).To(HaveKeyWithValue(infrav1.OutputsHashAnnotation, outputsHash(req.Data)))
+	resourceVersion := secret.ResourceVersion
+
+	// the same outputs do not touch the secret
+	reply, err = r.WriteOutputs(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(reply.Changed).To(BeFalse())
+	g.Expect(r.Get(ctx, key, &secret)).To(Succeed())
+	g.Expect(secret.ResourceVersion).To(Equal(resourceVersion))
+
+	// a new label is added without rewriting the outputs
+	req.Labels = map[string]string{"app": "helloworld"}
+	reply, err = r.WriteOutputs(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(reply.Changed).To(BeFalse())
+	g.Expect(r.Get(ctx, key, &secret)).To(Succeed())
+	g.Expect(secret.Labels).To(HaveKeyWithValue("app", "helloworld"))
+
+	req.Data = map[string][]byte{"hello": []byte("tf-controller")}
+	reply, err = r.WriteOutputs(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(reply.Changed).To(BeTrue())
+	g.Expect(r.Get(ctx, key, &secret)).To(Succeed())
+	g.Expect(secret.Data).To(Equal(req.Data))
+	g.Expect(secret.Annotations).To(HaveKeyWithValue(infrav1.OutputsHashAnnotation, outputsHash(req.Data)))
+}
+
+func TestOutputsHash(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(outputsHash(map[string][]byte{"a": nil})).To(Equal(outputsHash(map[string][]byte{"a": {}})))
+	g.Expect(outputsHash(map[string][]byte{"ab": []byte("c")})).ToNot(Equal(outputsHash(map[string][]byte{"a": []byte("bc")})))
+}
+
+func TestWriteOutputsChecksums(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	r := &TerraformRunnerServer{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+	}
+
+	req := &WriteOutputsRequest{
+		Namespace:  "flux-system",
+		Name:       "helloworld",
+		SecretName: "helloworld-outputs",
+		Uuid:       "uid",
+		Data:       map[string][]byte{"endpoint": []byte("db.example.com"), "password": []byte("hunter2")},
+		Checksums:  true,
+	}
+	reply, err := r.WriteOutputs(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(reply.ChangedKeys).To(Equal([]string{"endpoint", "password"}))
+
+	key := types.NamespacedName{Namespace: "flux-system", Name: "helloworld-outputs"}
+	var secret corev1.Secret
+	g.Expect(r.Get(ctx, key, &secret)).To(Succeed())
+	g.Expect(secret.Annotations).To(HaveKeyWithValue(infrav1.OutputsChecksumsAnnotation,
+		`{"endpoint":"`+outputChecksum([]byte("db.example.com"))+`","password":"`+outputChecksum([]byte("hunter2"))+`"}`))
+
+	// the same outputs change no key
+	reply, err = r.WriteOutputs(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(reply.Changed).To(BeFalse())
+	g.Expect(reply.ChangedKeys).To(BeEmpty())
+
+	req.Data = map[string][]byte{"endpoint": []byte("db.example.com"), "password": []byte("hunter3"), "port": []byte("5432")}
+	reply, err = r.WriteOutputs(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(reply.ChangedKeys).To(Equal([]string{"password", "port"}))
+
+	delete(req.Data, "endpoint")
+	reply, err = r.WriteOutputs(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(reply.ChangedKeys).To(Equal([]string{"endpoint"}))
+	g.Expect(r.Get(ctx, key, &secret)).To(Succeed())
+	g.Expect(secret.Data).To(Equal(req.Data))
+	g.Expect(secret.Annotations[infrav1.OutputsChecksumsAnnotation]).ToNot(ContainSubstring("endpoint"))
+}
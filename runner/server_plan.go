@@ -74,13 +74,28 @@ func sanitizeLog(log string) string {
 func (r *TerraformRunnerServer) tfPlan(ctx context.Context, opts ...tfexec.PlanOption) (bool, error) {
 	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
 
-	// This is the only place where we disable the logger
-	r.tf.SetStdout(io.Discard)
+	// This is the only place where we disable the logger, the output only
+	// feeds the progress
+	tracker := r.trackProgress("plan", 0)
+	defer tracker.finish()
+	r.tf.SetStdout(tracker)
 	errBuf := &bytes.Buffer{}
 	r.tf.SetStderr(errBuf)
 
 	defer r.initLogger(log)
 
+	// the plan refreshes the resources in the state
+	countCtx, cancelCount := context.WithCancel(ctx)
+	defer cancelCount()
+	go func() {
+		total, err := r.countStateResources(countCtx)
+		if err != nil {
+			log.Info(fmt.Sprintf("unable to count the resources of the state: %s", err.Error()))
+			return
+		}
+		tracker.setTotal(total)
+	}()
+
 	diff, err := r.tf.Plan(ctx, opts...)
 	// sanitize the error message only if it's not a state lock error
 	var sl *tfexec.ErrStateLocked
@@ -95,21 +110,18 @@ func (r *TerraformRunnerServer) tfPlan(ctx context.Context, opts ...tfexec.PlanO
 func (r *TerraformRunnerServer) Plan(ctx context.Context, req *PlanRequest) (*PlanReply, error) {
 	log := controllerruntime.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
 	log.Info("creating a plan")
-	ctx, cancel := context.WithCancel(ctx)
-	go func() {
-		select {
-		case <-r.Done:
-			cancel()
-		case <-ctx.Done():
-		}
-	}()
-
 	if req.TfInstance != r.InstanceID {
 		err := fmt.Errorf("no TF instance found")
 		log.Error(err, "no terraform")
 		return nil, err
 	}
 
+	ctx, done := r.startOperation(ctx, "plan")
+	defer done()
+
+	stopEgressAudit := r.startEgressAudit(ctx, "plan")
+	defer stopEgressAudit()
+
 	var planOpt []tfexec.PlanOption
 	if req.Out != "" {
 		planOpt = append(planOpt, tfexec.Out(req.Out))
@@ -130,6 +142,10 @@ func (r *TerraformRunnerServer) Plan(ctx context.Context, req *PlanRequest) (*Pl
 		planOpt = append(planOpt, tfexec.Target(target))
 	}
 
+	if req.DisableLock {
+		planOpt = append(planOpt, tfexec.Lock(false))
+	}
+
 	drifted, err := r.tfPlan(ctx, planOpt...)
 	if err != nil {
 		st := status.New(codes.Internal, err.Error())
@@ -141,6 +157,12 @@ func (r *TerraformRunnerServer) Plan(ctx context.Context, req *PlanRequest) (*Pl
 			if err != nil {
 				return nil, err
 			}
+		} else if detailed, detailsErr := st.WithDetails(&PlanReply{
+			Message:                  "not ok",
+			VariableValidationErrors: parseVariableValidationErrors(err.Error()),
+			Diagnostics:              r.errorDiagnostics(ctx, err.Error()),
+		}); detailsErr == nil {
+			st = detailed
 		}
 
 		log.Error(err, "error creating the plan")
@@ -148,6 +170,8 @@ func (r *TerraformRunnerServer) Plan(ctx context.Context, req *PlanRequest) (*Pl
 	}
 
 	planCreated := false
+	var toAdd, toChange, toDestroy int32
+	var moves []*ResourceMove
 	if req.Out != "" {
 		planCreated = true
 
@@ -165,7 +189,43 @@ func (r *TerraformRunnerServer) Plan(ctx context.Context, req *PlanRequest) (*Pl
 			planCreated = false
 		}
 
+		toAdd, toChange, toDestroy = countResourceChanges(plan)
+		moves = suspectedMoves(plan)
+	}
+
+	return &PlanReply{
+		Message:        "ok",
+		Drifted:        drifted,
+		PlanCreated:    planCreated,
+		ToAdd:          toAdd,
+		ToChange:       toChange,
+		ToDestroy:      toDestroy,
+		SuspectedMoves: moves,
+	}, nil
+}
+
+// countResourceChanges counts the resources to add, change and destroy
+// the same way Terraform does in the plan summary, where a replaced
+// resource is both added and destroyed.
+func countResourceChanges(plan *tfjson.Plan) (toAdd, toChange, toDestroy int32) {
+	for _, rc := range plan.ResourceChanges {
+		if rc.Change == nil {
+			continue
+		}
+
+		actions := rc.Change.Actions
+		switch {
+		case actions.Replace():
+			toAdd++
+			toDestroy++
+		case actions.Create():
+			toAdd++
+		case actions.Update():
+			toChange++
+		case actions.Delete():
+			toDestroy++
+		}
 	}
 
-	return &PlanReply{Message: "ok", Drifted: drifted, PlanCreated: planCreated}, nil
+	return toAdd, toChange, toDestroy
 }
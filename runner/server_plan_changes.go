@@ -0,0 +1,161 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/weaveworks/tf-controller/api/planchanges"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// maxPlanChangesSize is the size of the encoded document of the changes of a
+// plan above which the values of the resources are left out, to stay below
+// the 1MiB limit of a ConfigMap.
+const maxPlanChangesSize = 900 * 1024
+
+// publishPlanChanges writes the resource changes of the plan to the ConfigMap
+// of the object, in the schema of the planchanges package.
+func (r *TerraformRunnerServer) publishPlanChanges(ctx context.Context, name, namespace, uuid, planId, revision string, plan *tfjson.Plan) error {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+
+	document := planChangesDocument(plan, planId, revision, true)
+	data, err := planchanges.Encode(document)
+	if err == nil && len(data) > maxPlanChangesSize {
+		log.Info("the changes of the plan are too large, publishing them without their values", "size", len(data))
+		document = planChangesDocument(plan, planId, revision, false)
+		data, err = planchanges.Encode(document)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to encode the changes of the plan: %w", err)
+	}
+	if len(data) > maxPlanChangesSize {
+		return fmt.Errorf("the changes of the plan are too large to be published, even without their values: %d bytes", len(data))
+	}
+
+	configMapName := planchanges.ConfigMapName(r.terraform.WorkspaceName(), name)
+	var configMap v1.ConfigMap
+	exists := true
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: configMapName, Namespace: namespace}, &configMap); err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("unable to get the plan changes configmap: %w", err)
+		}
+		exists = false
+	}
+
+	configMap.ObjectMeta = metav1.ObjectMeta{
+		Name:            configMapName,
+		Namespace:       namespace,
+		ResourceVersion: configMap.ResourceVersion,
+		Annotations: map[string]string{
+			SavedPlanSecretAnnotation:           planId,
+			planchanges.SchemaVersionAnnotation: planchanges.SchemaVersion,
+		},
+		OwnerReferences: []metav1.OwnerReference{
+			{
+				APIVersion: infrav1.GroupVersion.Group + "/" + infrav1.GroupVersion.Version,
+				Kind:       infrav1.TerraformKind,
+				Name:       name,
+				UID:        types.UID(uuid),
+			},
+		},
+	}
+	configMap.Data = nil
+	configMap.BinaryData = map[string][]byte{planchanges.DataKey: data}
+
+	if exists {
+		err = r.Client.Update(ctx, &configMap)
+	} else {
+		err = r.Client.Create(ctx, &configMap)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to write the plan changes configmap: %w", err)
+	}
+	return nil
+}
+
+// planChangesDocument normalizes the resource changes of a plan: the
+// resources without changes are left out, the others are sorted by address,
+// and their sensitive values are masked.
+func planChangesDocument(plan *tfjson.Plan, planId, revision string, withValues bool) *planchanges.Document {
+	document := &planchanges.Document{
+		SchemaVersion:    planchanges.SchemaVersion,
+		PlanID:           planId,
+		Revision:         revision,
+		TerraformVersion: plan.TerraformVersion,
+		ValuesOmitted:    !withValues,
+		ResourceChanges:  []planchanges.ResourceChange{},
+	}
+
+	for _, rc := range plan.ResourceChanges {
+		if rc.Change == nil || rc.Change.Actions.NoOp() {
+			continue
+		}
+
+		actions := make([]string, 0, len(rc.Change.Actions))
+		for _, action := range rc.Change.Actions {
+			actions = append(actions, string(action))
+		}
+
+		change := planchanges.ResourceChange{
+			Address:       rc.Address,
+			ModuleAddress: rc.ModuleAddress,
+			Mode:          string(rc.Mode),
+			Type:          rc.Type,
+			Name:          rc.Name,
+			Index:         rc.Index,
+			ProviderName:  rc.ProviderName,
+			Deposed:       rc.DeposedKey,
+			Actions:       actions,
+		}
+		if withValues {
+			change.Before = maskSensitiveValues(rc.Change.Before, rc.Change.BeforeSensitive)
+			change.After = maskSensitiveValues(rc.Change.After, rc.Change.AfterSensitive)
+			change.AfterUnknown = rc.Change.AfterUnknown
+		}
+		document.ResourceChanges = append(document.ResourceChanges, change)
+	}
+
+	sort.SliceStable(document.ResourceChanges, func(i, j int) bool {
+		return document.ResourceChanges[i].Address < document.ResourceChanges[j].Address
+	})
+	return document
+}
+
+// maskSensitiveValues replaces the values marked true in the sensitivity of
+// a value, which has the same structure as the value.
+func maskSensitiveValues(value interface{}, sensitive interface{}) interface{} {
+	switch s := sensitive.(type) {
+	case bool:
+		if s && value != nil {
+			return planchanges.SensitiveValue
+		}
+	case map[string]interface{}:
+		if object, ok := value.(map[string]interface{}); ok {
+			masked := make(map[string]interface{}, len(object))
+			for key, v := range object {
+				masked[key] = maskSensitiveValues(v, s[key])
+			}
+			return masked
+		}
+	case []interface{}:
+		if list, ok := value.([]interface{}); ok {
+			masked := make([]interface{}, len(list))
+			for i, v := range list {
+				if i < len(s) {
+					masked[i] = maskSensitiveValues(v, s[i])
+				} else {
+					masked[i] = v
+				}
+			}
+			return masked
+		}
+	}
+	return value
+}
@@ -0,0 +1,162 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/weaveworks/tf-controller/api/planchanges"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+const planWithChanges = `{
+  "format_version": "1.1",
+  "terraform_version": "1.5.0",
+  "resource_changes": [
+    {
+      "address": "module.db.aws_db_instance.main",
+      "module_address": "module.db",
+      "mode": "managed",
+      "type": "aws_db_instance",
+      "name": "main",
+      "provider_name": "registry.terraform.io/hashicorp/aws",
+      "change": {
+        "actions": ["update"],
+        "before": {"instance_class": "db.t3.small", "password": "hunter2", "tags": ["a", "b"]},
+        "after": {"instance_class": "db.t3.large", "password": "hunter3", "tags": ["a", "c"]},
+        "after_unknown": {},
+        "before_sensitive": {"password": true, "tags": [false, true]},
+        "after_sensitive": {"password": true, "tags": [false, true]}
+      }
+    },
+    {
+      "address": "aws_s3_bucket.logs",
+      "mode": "managed",
+      "type": "aws_s3_bucket",
+      "name": "logs",
+      "provider_name": "registry.terraform.io/hashicorp/aws",
+      "change": {"actions": ["no-op"], "before": {}, "after": {}}
+    },
+    {
+      "address": "aws_instance.web[0]",
+      "mode": "managed",
+      "type": "aws_instance",
+      "name": "web",
+      "index": 0,
+      "provider_name": "registry.terraform.io/hashicorp/aws",
+      "change": {
+        "actions": ["create"],
+        "before": null,
+        "after": {"ami": "ami-123"},
+        "after_unknown": {"id": true}
+      }
+    }
+  ]
+}`
+
+func TestPlanChangesDocument(t *testing.T) {
+	g := NewWithT(t)
+
+	var plan tfjson.Plan
+	g.Expect(json.Unmarshal([]byte(planWithChanges), &plan)).To(Succeed())
+
+	document := planChangesDocument(&plan, "plan-main-abc", "main@sha1:abc", true)
+	g.Expect(document.SchemaVersion).To(Equal(planchanges.SchemaVersion))
+	g.Expect(document.TerraformVersion).To(Equal("1.5.0"))
+	g.Expect(document.ResourceChanges).To(HaveLen(2))
+
+	web := document.ResourceChanges[0]
+	g.Expect(web.Address).To(Equal("aws_instance.web[0]"))
+	g.Expect(web.Actions).To(Equal([]string{"create"}))
+	g.Expect(web.Before).To(BeNil())
+	g.Expect(web.AfterUnknown).To(Equal(map[string]interface{}{"id": true}))
+
+	db := document.ResourceChanges[1]
+	g.Expect(db.ModuleAddress).To(Equal("module.db"))
+	g.Expect(db.Before).To(Equal(map[string]interface{}{
+		"instance_class": "db.t3.small",
+		"password":       planchanges.SensitiveValue,
+		"tags":           []interface{}{"a", planchanges.SensitiveValue},
+	}))
+	g.Expect(db.After.(map[string]interface{})["password"]).To(Equal(planchanges.SensitiveValue))
+
+	document = planChangesDocument(&plan, "plan-main-abc", "main@sha1:abc", false)
+	g.Expect(document.ValuesOmitted).To(BeTrue())
+	g.Expect(document.ResourceChanges[1].Before).To(BeNil())
+	g.Expect(document.ResourceChanges[1].Actions).To(Equal([]string{"update"}))
+}
+
+func TestPublishPlanChanges(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(v1.AddToScheme(scheme)).To(Succeed())
+
+	var plan tfjson.Plan
+	g.Expect(json.Unmarshal([]byte(planWithChanges), &plan)).To(Succeed())
+
+	r := &TerraformRunnerServer{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		terraform: &infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+		},
+	}
+
+	key := types.NamespacedName{Name: "tfplan-default-helloworld.changes", Namespace: "flux-system"}
+	for _, planId := range []string{"plan-main-abc", "plan-main-def"} {
+		g.Expect(r.publishPlanChanges(context.TODO(), "helloworld", "flux-system", "uid", planId, "main@sha1:abc", &plan)).To(Succeed())
+
+		var configMap v1.ConfigMap
+		g.Expect(r.Client.Get(context.TODO(), key, &configMap)).To(Succeed())
+		g.Expect(configMap.Annotations).To(HaveKeyWithValue(SavedPlanSecretAnnotation, planId))
+		g.Expect(configMap.Annotations).To(HaveKeyWithValue(planchanges.SchemaVersionAnnotation, planchanges.SchemaVersion))
+		g.Expect(configMap.OwnerReferences).To(HaveLen(1))
+
+		document, err := planchanges.Decode(configMap.BinaryData[planchanges.DataKey])
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(document.PlanID).To(Equal(planId))
+		g.Expect(document.ResourceChanges).To(HaveLen(2))
+	}
+}
+
+func TestPublishPlanChangesTooLarge(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(v1.AddToScheme(scheme)).To(Succeed())
+
+	// the addresses alone do not fit in a ConfigMap once compressed
+	random := rand.New(rand.NewSource(1))
+	plan := tfjson.Plan{TerraformVersion: "1.5.0"}
+	for i := 0; i < 40000; i++ {
+		name := fmt.Sprintf("%016x%016x%016x%016x", random.Uint64(), random.Uint64(), random.Uint64(), random.Uint64())
+		plan.ResourceChanges = append(plan.ResourceChanges, &tfjson.ResourceChange{
+			Address: "null_resource." + name,
+			Mode:    tfjson.ManagedResourceMode,
+			Type:    "null_resource",
+			Name:    name,
+			Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionCreate}},
+		})
+	}
+
+	r := &TerraformRunnerServer{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		terraform: &infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+		},
+	}
+
+	err := r.publishPlanChanges(context.TODO(), "helloworld", "flux-system", "uid", "plan-main-abc", "main@sha1:abc", &plan)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("too large"))
+}
@@ -0,0 +1,123 @@
+package runner
+
+import (
+	"regexp"
+	"strings"
+)
+
+const validationRulePrefix = "This was checked by the validation rule at"
+
+var (
+	variableTraversalRe   = regexp.MustCompile(`var\.([A-Za-z_][A-Za-z0-9_-]*)`)
+	variableDeclarationRe = regexp.MustCompile(`variable "([^"]+)"`)
+)
+
+// parseVariableValidationErrors returns the variables whose values failed
+// their validation rules, or could not be converted to their type, from the
+// diagnostics printed by terraform plan.
+func parseVariableValidationErrors(output string) []*VariableValidationError {
+	var result []*VariableValidationError
+	for _, diagnostic := range splitDiagnostics(output) {
+		if len(diagnostic) == 0 {
+			continue
+		}
+		summary := strings.TrimPrefix(diagnostic[0], "Error: ")
+		if summary != "Invalid value for variable" && summary != "Invalid value for input variable" {
+			continue
+		}
+
+		variable, message := parseVariableDiagnostic(diagnostic[1:])
+		if variable == "" || message == "" {
+			continue
+		}
+		result = append(result, &VariableValidationError{Variable: variable, Message: message})
+	}
+	return result
+}
+
+// splitDiagnostics splits the output into the lines of each error
+// diagnostic, without the box drawn around them when the output has colors.
+func splitDiagnostics(output string) [][]string {
+	var diagnostics [][]string
+	var current []string
+	flush := func() {
+		if current != nil {
+			diagnostics = append(diagnostics, current)
+			current = nil
+		}
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimRight(line, " \r")
+		switch {
+		case strings.HasPrefix(line, "╷"), strings.HasPrefix(line, "╵"):
+			flush()
+			continue
+		case strings.HasPrefix(line, "│"):
+			line = strings.TrimPrefix(strings.TrimPrefix(line, "│"), " ")
+		}
+
+		if strings.HasPrefix(line, "Error: ") || strings.HasPrefix(line, "Warning: ") {
+			flush()
+			if strings.HasPrefix(line, "Error: ") {
+				current = []string{line}
+			}
+			continue
+		}
+		if current != nil {
+			current = append(current, line)
+		}
+	}
+	flush()
+
+	return diagnostics
+}
+
+// parseVariableDiagnostic returns the name of the variable of a diagnostic,
+// and its detail without the location of the validation rule.
+func parseVariableDiagnostic(lines []string) (string, string) {
+	var variable string
+	var paragraphs []string
+	var paragraph []string
+	inSource := false
+
+	endParagraph := func() {
+		if len(paragraph) > 0 {
+			text := strings.Join(paragraph, " ")
+			if !strings.HasPrefix(text, validationRulePrefix) {
+				paragraphs = append(paragraphs, text)
+			}
+			paragraph = nil
+		}
+	}
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "on ") && strings.HasSuffix(trimmed, ":"):
+			// the source snippet, down to the next blank line
+			inSource = true
+		case trimmed == "":
+			inSource = false
+			endParagraph()
+		case inSource:
+			if m := variableTraversalRe.FindStringSubmatch(trimmed); m != nil && variable == "" {
+				variable = m[1]
+			} else if m := variableDeclarationRe.FindStringSubmatch(trimmed); m != nil && variable == "" {
+				variable = m[1]
+			}
+		default:
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+	endParagraph()
+
+	message := strings.Join(paragraphs, "\n")
+	if variable == "" {
+		// the value is not in a source file, like with -var
+		if m := variableTraversalRe.FindStringSubmatch(message); m != nil {
+			variable = m[1]
+		}
+	}
+	return variable, message
+}
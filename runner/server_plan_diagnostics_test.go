@@ -0,0 +1,62 @@
+package runner
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseVariableValidationErrors(t *testing.T) {
+	g := NewWithT(t)
+
+	plain := `exit status 1
+
+Error: Invalid value for variable
+
+  on main.tf line 1:
+   1: variable "image_id" {
+    ├────────────────
+    │ var.image_id is "abc"
+
+The image_id value must be a valid AMI id, starting with
+"ami-".
+
+This was checked by the validation rule at main.tf:5,3-13.
+
+Warning: Deprecated attribute
+
+  on main.tf line 12:
+  12:   name = var.name
+
+Error: Invalid value for input variable
+
+The given value is not suitable for var.replicas declared at main.tf:9,1-20:
+a number is required.
+`
+	errs := parseVariableValidationErrors(plain)
+	g.Expect(errs).To(HaveLen(2))
+	g.Expect(errs[0].Variable).To(Equal("image_id"))
+	g.Expect(errs[0].Message).To(Equal(`The image_id value must be a valid AMI id, starting with "ami-".`))
+	g.Expect(errs[1].Variable).To(Equal("replicas"))
+	g.Expect(errs[1].Message).To(HavePrefix("The given value is not suitable for var.replicas"))
+
+	boxed := `╷
+│ Error: Invalid value for variable
+│ 
+│   on variables.tf line 3:
+│    3: variable "environment" {
+│     ├────────────────
+│     │ var.environment is "qa"
+│ 
+│ The environment must be one of dev, staging or prod.
+│ 
+│ This was checked by the validation rule at variables.tf:6,3-13.
+╵
+`
+	errs = parseVariableValidationErrors(boxed)
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Variable).To(Equal("environment"))
+	g.Expect(errs[0].Message).To(Equal("The environment must be one of dev, staging or prod."))
+
+	g.Expect(parseVariableValidationErrors("Error: Error acquiring the state lock\n\nLock Info:\n  ID: 1234")).To(BeEmpty())
+}
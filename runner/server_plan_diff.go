@@ -0,0 +1,292 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	kubernetesManifestType = "kubernetes_manifest"
+	sensitiveDiffValue     = "(sensitive value)"
+	planDiffFieldOwner     = "tf-controller-plan-diff"
+)
+
+// planDiffRenderer renders the field-level diff of a resource change, or
+// returns an empty string when it does not handle the change.
+type planDiffRenderer func(ctx context.Context, r *TerraformRunnerServer, rc *tfjson.ResourceChange) (string, error)
+
+var planDiffRenderers = map[infrav1.ReadablePlanDiff]planDiffRenderer{
+	infrav1.ReadablePlanDiffKubernetesManifest:       renderKubernetesManifestDiff,
+	infrav1.ReadablePlanDiffKubernetesManifestDryRun: renderKubernetesManifestDryRunDiff,
+}
+
+var identifierRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// renderPlanDiffs renders the diffs of the resource changes of the plan with
+// the renderers, to be appended to the human readable plan.
+func (r *TerraformRunnerServer) renderPlanDiffs(ctx context.Context, plan *tfjson.Plan, names []infrav1.ReadablePlanDiff) string {
+	var sections []string
+	for _, name := range names {
+		render, ok := planDiffRenderers[name]
+		if !ok {
+			continue
+		}
+
+		for _, rc := range plan.ResourceChanges {
+			if rc.Change == nil || rc.Change.Actions.NoOp() || rc.Change.Actions.Read() {
+				continue
+			}
+
+			diff, err := render(ctx, r, rc)
+			if err != nil {
+				diff = fmt.Sprintf("# %s: unable to render the diff: %s\n", rc.Address, err)
+			}
+			if diff != "" {
+				sections = append(sections, diff)
+			}
+		}
+	}
+
+	if len(sections) == 0 {
+		return ""
+	}
+	return "\nField-level diffs:\n\n" + strings.Join(sections, "\n")
+}
+
+// renderKubernetesManifestDiff renders the changes between the manifests of
+// a kubernetes_manifest resource before and after the plan.
+func renderKubernetesManifestDiff(_ context.Context, _ *TerraformRunnerServer, rc *tfjson.ResourceChange) (string, error) {
+	if rc.Type != kubernetesManifestType {
+		return "", nil
+	}
+
+	before := manifestOf(rc.Change.Before)
+	after := manifestOf(rc.Change.After)
+	sensitive := append(sensitivePaths(rc.Change.BeforeSensitive), sensitivePaths(rc.Change.AfterSensitive)...)
+
+	object := after
+	if object == nil {
+		object = before
+	}
+	return renderFieldDiff(rc.Address, object, "", before, after, sensitive), nil
+}
+
+// renderKubernetesManifestDryRunDiff renders the changes between the live
+// object of a kubernetes_manifest resource and the object returned by a
+// server-side dry-run of its planned manifest. It falls back to the planned
+// manifests when the dry-run fails, for example when the resource belongs to
+// another cluster.
+func renderKubernetesManifestDryRunDiff(ctx context.Context, r *TerraformRunnerServer, rc *tfjson.ResourceChange) (string, error) {
+	if rc.Type != kubernetesManifestType {
+		return "", nil
+	}
+
+	after := manifestOf(rc.Change.After)
+	if after == nil || rc.Change.Actions.Delete() {
+		return renderKubernetesManifestDiff(ctx, r, rc)
+	}
+
+	live, dryRun, err := r.dryRunManifest(ctx, after)
+	if err != nil {
+		diff, _ := renderKubernetesManifestDiff(ctx, r, rc)
+		return strings.Replace(diff, "\n", fmt.Sprintf(" (server-side dry-run failed: %s)\n", err), 1), nil
+	}
+
+	sensitive := append(sensitivePaths(rc.Change.BeforeSensitive), sensitivePaths(rc.Change.AfterSensitive)...)
+	return renderFieldDiff(rc.Address, after, "server-side dry-run", live, dryRun, sensitive), nil
+}
+
+// dryRunManifest returns the live object of the manifest, if any, and the
+// object as the API server would store it once the manifest is applied.
+func (r *TerraformRunnerServer) dryRunManifest(ctx context.Context, manifest map[string]interface{}) (map[string]interface{}, map[string]interface{}, error) {
+	desired := &unstructured.Unstructured{Object: manifest}
+
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(desired.GroupVersionKind())
+	var live map[string]interface{}
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(desired), current); err == nil {
+		live = pruneServerFields(current.Object)
+	} else if !apierrors.IsNotFound(err) {
+		return nil, nil, err
+	}
+
+	applied := desired.DeepCopy()
+	if err := r.Client.Patch(ctx, applied, client.Apply, client.DryRunAll, client.ForceOwnership, client.FieldOwner(planDiffFieldOwner)); err != nil {
+		return nil, nil, err
+	}
+
+	return live, pruneServerFields(applied.Object), nil
+}
+
+// pruneServerFields removes the fields which change on every write, and the
+// status, which the plan does not change.
+func pruneServerFields(object map[string]interface{}) map[string]interface{} {
+	object = (&unstructured.Unstructured{Object: object}).DeepCopy().Object
+	delete(object, "status")
+	if metadata, ok := object["metadata"].(map[string]interface{}); ok {
+		for _, field := range []string{"managedFields", "resourceVersion", "generation", "uid", "creationTimestamp"} {
+			delete(metadata, field)
+		}
+	}
+	return object
+}
+
+func manifestOf(value interface{}) map[string]interface{} {
+	attributes, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	manifest, _ := attributes["manifest"].(map[string]interface{})
+	return manifest
+}
+
+// sensitivePaths returns the paths of the sensitive values of the manifest,
+// from the sensitivity of the attributes of a change.
+func sensitivePaths(value interface{}) []string {
+	var paths []string
+	attributes, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	if sensitive, ok := attributes["manifest"].(bool); ok && sensitive {
+		return []string{""}
+	}
+
+	flat := map[string]interface{}{}
+	flattenFields("", attributes["manifest"], flat)
+	for path, sensitive := range flat {
+		if sensitive == true {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// renderFieldDiff renders the fields added, changed and removed between two
+// objects, one per line.
+func renderFieldDiff(address string, object map[string]interface{}, source string, before, after map[string]interface{}, sensitive []string) string {
+	obj := &unstructured.Unstructured{Object: object}
+	if obj.GetKind() == "Secret" {
+		sensitive = append(sensitive, "data", "stringData")
+	}
+
+	name := obj.GetName()
+	if obj.GetNamespace() != "" {
+		name = obj.GetNamespace() + "/" + name
+	}
+	header := fmt.Sprintf("# %s (%s %s %s)", address, obj.GetAPIVersion(), obj.GetKind(), name)
+	if source != "" {
+		header += ", " + source
+	}
+
+	beforeFields := map[string]interface{}{}
+	afterFields := map[string]interface{}{}
+	flattenFields("", before, beforeFields)
+	flattenFields("", after, afterFields)
+
+	paths := make([]string, 0, len(beforeFields)+len(afterFields))
+	for path := range beforeFields {
+		paths = append(paths, path)
+	}
+	for path := range afterFields {
+		if _, ok := beforeFields[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	var lines []string
+	for _, path := range paths {
+		oldValue, hadOld := beforeFields[path]
+		newValue, hasNew := afterFields[path]
+		switch {
+		case hadOld && hasNew:
+			oldJSON, newJSON := renderValue(oldValue), renderValue(newValue)
+			if oldJSON == newJSON {
+				continue
+			}
+			if isSensitive(path, sensitive) {
+				lines = append(lines, fmt.Sprintf("  ~ %s: %s", path, sensitiveDiffValue))
+			} else {
+				lines = append(lines, fmt.Sprintf("  ~ %s: %s => %s", path, oldJSON, newJSON))
+			}
+		case hasNew:
+			lines = append(lines, fmt.Sprintf("  + %s: %s", path, maskValue(path, newValue, sensitive)))
+		default:
+			lines = append(lines, fmt.Sprintf("  - %s: %s", path, maskValue(path, oldValue, sensitive)))
+		}
+	}
+
+	if len(lines) == 0 {
+		lines = append(lines, "  (no field changes)")
+	}
+	return header + "\n" + strings.Join(lines, "\n") + "\n"
+}
+
+// flattenFields flattens an object into its leaf values, keyed by path.
+func flattenFields(prefix string, value interface{}, out map[string]interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 && prefix != "" {
+			out[prefix] = v
+		}
+		for key, child := range v {
+			flattenFields(joinPath(prefix, key), child, out)
+		}
+	case []interface{}:
+		if len(v) == 0 && prefix != "" {
+			out[prefix] = v
+		}
+		for i, child := range v {
+			flattenFields(fmt.Sprintf("%s[%d]", prefix, i), child, out)
+		}
+	case nil:
+		// the provider sets the absent optional fields to null
+	default:
+		out[prefix] = v
+	}
+}
+
+func joinPath(prefix, key string) string {
+	if !identifierRe.MatchString(key) {
+		return fmt.Sprintf("%s[%q]", prefix, key)
+	}
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+func isSensitive(path string, sensitive []string) bool {
+	for _, prefix := range sensitive {
+		if prefix == "" || path == prefix || strings.HasPrefix(path, prefix+".") || strings.HasPrefix(path, prefix+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+func maskValue(path string, value interface{}, sensitive []string) string {
+	if isSensitive(path, sensitive) {
+		return sensitiveDiffValue
+	}
+	return renderValue(value)
+}
+
+func renderValue(value interface{}) string {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(b)
+}
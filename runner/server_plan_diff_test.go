@@ -0,0 +1,116 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega"
+)
+
+const kubernetesManifestPlan = `{
+  "format_version": "1.1",
+  "resource_changes": [
+    {
+      "address": "kubernetes_manifest.app",
+      "type": "kubernetes_manifest",
+      "change": {
+        "actions": ["update"],
+        "before": {"manifest": {
+          "apiVersion": "v1", "kind": "ConfigMap",
+          "metadata": {"name": "app", "namespace": "default", "labels": {"app.kubernetes.io/name": "app"}},
+          "data": {"replicas": "2", "removed": "yes"}
+        }},
+        "after": {"manifest": {
+          "apiVersion": "v1", "kind": "ConfigMap",
+          "metadata": {"name": "app", "namespace": "default", "labels": {"app.kubernetes.io/name": "app"}},
+          "data": {"replicas": "3", "added": "yes"}
+        }}
+      }
+    },
+    {
+      "address": "kubernetes_manifest.credentials",
+      "type": "kubernetes_manifest",
+      "change": {
+        "actions": ["create"],
+        "before": null,
+        "after": {"manifest": {
+          "apiVersion": "v1", "kind": "Secret",
+          "metadata": {"name": "credentials", "namespace": "default"},
+          "stringData": {"password": "hunter2"}
+        }}
+      }
+    },
+    {
+      "address": "null_resource.other",
+      "type": "null_resource",
+      "change": {"actions": ["create"], "before": null, "after": {}}
+    }
+  ]
+}`
+
+func TestRenderPlanDiffs(t *testing.T) {
+	g := NewWithT(t)
+
+	var plan tfjson.Plan
+	g.Expect(json.Unmarshal([]byte(kubernetesManifestPlan), &plan)).To(Succeed())
+
+	r := &TerraformRunnerServer{}
+	diff := r.renderPlanDiffs(context.Background(), &plan, []infrav1.ReadablePlanDiff{infrav1.ReadablePlanDiffKubernetesManifest})
+	g.Expect(diff).To(Equal(`
+Field-level diffs:
+
+# kubernetes_manifest.app (v1 ConfigMap default/app)
+  + data.added: "yes"
+  - data.removed: "yes"
+  ~ data.replicas: "2" => "3"
+
+# kubernetes_manifest.credentials (v1 Secret default/credentials)
+  + apiVersion: "v1"
+  + kind: "Secret"
+  + metadata.name: "credentials"
+  + metadata.namespace: "default"
+  + stringData.password: (sensitive value)
+`))
+}
+
+func TestRenderKubernetesManifestDryRunDiffFallback(t *testing.T) {
+	g := NewWithT(t)
+
+	var plan tfjson.Plan
+	g.Expect(json.Unmarshal([]byte(kubernetesManifestPlan), &plan)).To(Succeed())
+
+	// the runner may not patch the objects of its cluster
+	r := &TerraformRunnerServer{Client: forbiddenPatchClient{fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()}}
+	diff, err := renderKubernetesManifestDryRunDiff(context.Background(), r, plan.ResourceChanges[0])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(diff).To(HavePrefix("# kubernetes_manifest.app (v1 ConfigMap default/app) (server-side dry-run failed: forbidden)\n"))
+	g.Expect(diff).To(ContainSubstring(`~ data.replicas: "2" => "3"`))
+}
+
+type forbiddenPatchClient struct {
+	client.Client
+}
+
+func (c forbiddenPatchClient) Patch(context.Context, client.Object, client.Patch, ...client.PatchOption) error {
+	return fmt.Errorf("forbidden")
+}
+
+func TestJoinPath(t *testing.T) {
+	g := NewWithT(t)
+
+	fields := map[string]interface{}{}
+	flattenFields("", map[string]interface{}{
+		"metadata": map[string]interface{}{"annotations": map[string]interface{}{"app.kubernetes.io/name": "app"}},
+		"spec":     map[string]interface{}{"ports": []interface{}{map[string]interface{}{"port": float64(80)}}},
+	}, fields)
+	g.Expect(fields).To(HaveKey(`metadata.annotations["app.kubernetes.io/name"]`))
+	g.Expect(fields).To(HaveKeyWithValue("spec.ports[0].port", float64(80)))
+}
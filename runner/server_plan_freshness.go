@@ -0,0 +1,144 @@
+package runner
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+
+	"github.com/weaveworks/tf-controller/utils"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// planStateFileName is the name of the snapshot of the state, as it was when
+// the plan was created, in the plan file.
+const planStateFileName = "tfstate"
+
+// stateMeta is the part of a state file which identifies its snapshot.
+type stateMeta struct {
+	Serial  int64  `json:"serial"`
+	Lineage string `json:"lineage"`
+}
+
+// CheckPlanFreshness compares the state recorded in the loaded plan with the
+// current state of the backend. The plan is stale when the state changed
+// since the plan was created, for example after terraform was run by hand
+// against the same backend, and applying it would fail or undo the changes.
+func (r *TerraformRunnerServer) CheckPlanFreshness(ctx context.Context, req *CheckPlanFreshnessRequest) (*CheckPlanFreshnessReply, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	log.Info("checking plan freshness")
+	if req.TfInstance != r.InstanceID {
+		err := fmt.Errorf("no TF instance found")
+		log.Error(err, "no terraform")
+		return nil, err
+	}
+
+	planned, err := readPlanStateMeta(filepath.Join(r.tf.WorkingDir(), TFPlanName))
+	if err != nil {
+		log.Error(err, "unable to read the state of the plan")
+		return nil, err
+	}
+
+	current, err := r.pullStateMeta(ctx)
+	if err != nil {
+		log.Error(err, "unable to pull the state")
+		return nil, err
+	}
+
+	reply := &CheckPlanFreshnessReply{
+		PlanSerial:     planned.Serial,
+		StateSerial:    current.Serial,
+		LineageChanged: planned.Lineage != current.Lineage,
+	}
+	reply.Stale = reply.LineageChanged || planned.Serial != current.Serial
+	return reply, nil
+}
+
+// readPlanStateMeta returns the serial and lineage of the state snapshot
+// stored in the plan file. A plan created without a state has none.
+func readPlanStateMeta(planFile string) (stateMeta, error) {
+	zr, err := zip.OpenReader(planFile)
+	if err != nil {
+		return stateMeta{}, fmt.Errorf("unable to open the plan file: %w", err)
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		if f.Name != planStateFileName {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return stateMeta{}, fmt.Errorf("unable to open the state of the plan: %w", err)
+		}
+		defer rc.Close()
+
+		data, err := io.ReadAll(rc)
+		if err != nil {
+			return stateMeta{}, fmt.Errorf("unable to read the state of the plan: %w", err)
+		}
+		return parseStateMeta(data)
+	}
+
+	return stateMeta{}, nil
+}
+
+// pullStateMeta returns the serial and lineage of the current state in the
+// backend. The tfexec version in use has no state pull, so the command is run
+// with the environment given to SetEnv.
+func (r *TerraformRunnerServer) pullStateMeta(ctx context.Context) (stateMeta, error) {
+	data, err := r.pullState(ctx)
+	if err != nil {
+		return stateMeta{}, err
+	}
+
+	return parseStateMeta(data)
+}
+
+// pullState returns the current state in the backend.
+func (r *TerraformRunnerServer) pullState(ctx context.Context) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, r.tf.ExecPath(), "state", "pull")
+	cmd.Dir = r.tf.WorkingDir()
+	cmd.Env = r.commandEnv()
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("terraform state pull: %w: %s", err, stderr.String())
+	}
+
+	return stdout.Bytes(), nil
+}
+
+func (r *TerraformRunnerServer) commandEnv() []string {
+	envs := r.env
+	if envs == nil {
+		envs = utils.EnvMap(os.Environ())
+	}
+
+	result := make([]string, 0, len(envs)+1)
+	for k, v := range envs {
+		result = append(result, k+"="+v)
+	}
+	sort.Strings(result)
+	return append(result, "TF_IN_AUTOMATION=1")
+}
+
+// parseStateMeta parses the serial and lineage of a state file. An empty
+// state file is a backend without state yet.
+func parseStateMeta(data []byte) (stateMeta, error) {
+	var meta stateMeta
+	if len(bytes.TrimSpace(data)) == 0 {
+		return meta, nil
+	}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return meta, fmt.Errorf("unable to parse the state: %w", err)
+	}
+	return meta, nil
+}
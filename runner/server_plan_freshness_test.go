@@ -0,0 +1,70 @@
+package runner
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func writePlanFile(t *testing.T, files map[string]string) string {
+	planFile := filepath.Join(t.TempDir(), TFPlanName)
+	f, err := os.Create(planFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return planFile
+}
+
+func TestReadPlanStateMeta(t *testing.T) {
+	g := NewWithT(t)
+
+	planFile := writePlanFile(t, map[string]string{
+		"tfplan":  "plan",
+		"tfstate": `{"version": 4, "serial": 7, "lineage": "2e3b1a4c", "resources": []}`,
+	})
+	meta, err := readPlanStateMeta(planFile)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(meta).To(Equal(stateMeta{Serial: 7, Lineage: "2e3b1a4c"}))
+
+	// a plan created without state
+	planFile = writePlanFile(t, map[string]string{"tfplan": "plan"})
+	meta, err = readPlanStateMeta(planFile)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(meta).To(Equal(stateMeta{}))
+
+	_, err = readPlanStateMeta(filepath.Join(t.TempDir(), "missing"))
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestParseStateMeta(t *testing.T) {
+	g := NewWithT(t)
+
+	meta, err := parseStateMeta([]byte("\n"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(meta).To(Equal(stateMeta{}))
+
+	meta, err = parseStateMeta([]byte(`{"serial": 3, "lineage": "abc"}`))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(meta).To(Equal(stateMeta{Serial: 3, Lineage: "abc"}))
+
+	_, err = parseStateMeta([]byte("Error: backend not initialized"))
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,75 @@
+package runner
+
+import (
+	"reflect"
+	"sort"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// suspectedMoves pairs the resources a plan destroys with the resources of
+// the same type it creates, at another address, with the same attributes.
+// Such a pair is most likely a resource renamed, or moved into a module,
+// without a moved block, so that applying the plan replaces it.
+func suspectedMoves(plan *tfjson.Plan) []*ResourceMove {
+	var deleted, created []*tfjson.ResourceChange
+	for _, rc := range plan.ResourceChanges {
+		if rc.Change == nil || rc.Mode != tfjson.ManagedResourceMode || rc.DeposedKey != "" {
+			continue
+		}
+		switch {
+		case rc.Change.Actions.Delete():
+			deleted = append(deleted, rc)
+		case rc.Change.Actions.Create():
+			created = append(created, rc)
+		}
+	}
+
+	sort.SliceStable(deleted, func(i, j int) bool { return deleted[i].Address < deleted[j].Address })
+	sort.SliceStable(created, func(i, j int) bool { return created[i].Address < created[j].Address })
+
+	var moves []*ResourceMove
+	matched := make([]bool, len(created))
+	for _, from := range deleted {
+		for i, to := range created {
+			if matched[i] || from.Type != to.Type || from.ProviderName != to.ProviderName {
+				continue
+			}
+			if identicalResource(from.Change.Before, to.Change.After, to.Change.AfterUnknown) {
+				matched[i] = true
+				moves = append(moves, &ResourceMove{From: from.Address, To: to.Address})
+				break
+			}
+		}
+	}
+	return moves
+}
+
+// identicalResource tells whether the attributes of a resource to create,
+// apart from those only known once created, such as its ID, are those of a
+// resource to destroy.
+func identicalResource(before, after, afterUnknown interface{}) bool {
+	beforeValues, ok := before.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	afterValues, ok := after.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	unknown, _ := afterUnknown.(map[string]interface{})
+
+	compared := 0
+	for key, value := range afterValues {
+		if isUnknown, _ := unknown[key].(bool); isUnknown {
+			continue
+		}
+		if !reflect.DeepEqual(beforeValues[key], value) {
+			return false
+		}
+		if value != nil {
+			compared++
+		}
+	}
+	return compared > 0
+}
@@ -0,0 +1,121 @@
+package runner
+
+import (
+	"encoding/json"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	. "github.com/onsi/gomega"
+)
+
+const planWithRenames = `{
+  "format_version": "1.1",
+  "terraform_version": "1.5.0",
+  "resource_changes": [
+    {
+      "address": "aws_s3_bucket.logs",
+      "mode": "managed",
+      "type": "aws_s3_bucket",
+      "name": "logs",
+      "provider_name": "registry.terraform.io/hashicorp/aws",
+      "change": {
+        "actions": ["delete"],
+        "before": {"id": "logs", "bucket": "logs", "force_destroy": false},
+        "after": null,
+        "after_unknown": {}
+      }
+    },
+    {
+      "address": "module.storage.aws_s3_bucket.logs",
+      "module_address": "module.storage",
+      "mode": "managed",
+      "type": "aws_s3_bucket",
+      "name": "logs",
+      "provider_name": "registry.terraform.io/hashicorp/aws",
+      "change": {
+        "actions": ["create"],
+        "before": null,
+        "after": {"bucket": "logs", "force_destroy": false},
+        "after_unknown": {"id": true}
+      }
+    },
+    {
+      "address": "aws_s3_bucket.assets",
+      "mode": "managed",
+      "type": "aws_s3_bucket",
+      "name": "assets",
+      "provider_name": "registry.terraform.io/hashicorp/aws",
+      "change": {
+        "actions": ["delete"],
+        "before": {"id": "assets", "bucket": "assets", "force_destroy": false},
+        "after": null,
+        "after_unknown": {}
+      }
+    },
+    {
+      "address": "aws_s3_bucket.media",
+      "mode": "managed",
+      "type": "aws_s3_bucket",
+      "name": "media",
+      "provider_name": "registry.terraform.io/hashicorp/aws",
+      "change": {
+        "actions": ["create"],
+        "before": null,
+        "after": {"bucket": "media", "force_destroy": false},
+        "after_unknown": {"id": true}
+      }
+    },
+    {
+      "address": "aws_instance.web",
+      "mode": "managed",
+      "type": "aws_instance",
+      "name": "web",
+      "provider_name": "registry.terraform.io/hashicorp/aws",
+      "change": {
+        "actions": ["delete", "create"],
+        "before": {"id": "i-123", "ami": "ami-1"},
+        "after": {"ami": "ami-1"},
+        "after_unknown": {"id": true}
+      }
+    }
+  ]
+}`
+
+func TestSuspectedMoves(t *testing.T) {
+	g := NewWithT(t)
+
+	var plan tfjson.Plan
+	g.Expect(json.Unmarshal([]byte(planWithRenames), &plan)).To(Succeed())
+
+	// the bucket moved into a module is suspected, not the bucket replaced
+	// by another one, nor the instance replaced in place
+	moves := suspectedMoves(&plan)
+	g.Expect(moves).To(HaveLen(1))
+	g.Expect(moves[0].From).To(Equal("aws_s3_bucket.logs"))
+	g.Expect(moves[0].To).To(Equal("module.storage.aws_s3_bucket.logs"))
+
+	g.Expect(suspectedMoves(&tfjson.Plan{})).To(BeEmpty())
+}
+
+func TestIdenticalResource(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(identicalResource(
+		map[string]interface{}{"id": "a", "name": "a"},
+		map[string]interface{}{"name": "a"},
+		map[string]interface{}{"id": true},
+	)).To(BeTrue())
+	g.Expect(identicalResource(
+		map[string]interface{}{"id": "a", "name": "a"},
+		map[string]interface{}{"name": "b"},
+		nil,
+	)).To(BeFalse())
+
+	// a resource without any known attribute cannot be told apart
+	g.Expect(identicalResource(
+		map[string]interface{}{"id": "a"},
+		map[string]interface{}{"id": nil},
+		map[string]interface{}{"id": true},
+	)).To(BeFalse())
+}
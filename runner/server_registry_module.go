@@ -0,0 +1,447 @@
+package runner
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	securejoin "github.com/cyphar/filepath-securejoin"
+	"github.com/fluxcd/pkg/untar"
+	goversion "github.com/hashicorp/go-version"
+	"github.com/weaveworks/tf-controller/utils"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	defaultRegistryHostname = "registry.terraform.io"
+	registryRequestTimeout  = 2 * time.Minute
+	// maxRegistryModuleSize caps the size of the archive of a module.
+	maxRegistryModuleSize = 512 << 20
+)
+
+var (
+	registryNameRegexp     = regexp.MustCompile(`^[0-9A-Za-z](?:[0-9A-Za-z-_]{0,62}[0-9A-Za-z])?$`)
+	registryProviderRegexp = regexp.MustCompile(`^[0-9a-z]{1,64}$`)
+)
+
+// RegistryTokenEnv returns the name of the environment variable terraform
+// reads the API token of the registry of the given hostname from, e.g.
+// TF_TOKEN_app_terraform_io.
+func RegistryTokenEnv(hostname string) string {
+	name := strings.ReplaceAll(hostname, "-", "__")
+	name = strings.ReplaceAll(name, ".", "_")
+	return "TF_TOKEN_" + name
+}
+
+// registryModuleSource is a parsed registry module address.
+type registryModuleSource struct {
+	Hostname  string
+	Namespace string
+	Name      string
+	Provider  string
+	// Subdir is the directory of the module in its package.
+	Subdir string
+}
+
+func parseRegistryModuleSource(source string) (registryModuleSource, error) {
+	var result registryModuleSource
+
+	address := source
+	if i := strings.Index(address, "//"); i >= 0 {
+		address, result.Subdir = address[:i], strings.Trim(address[i+2:], "/")
+	}
+
+	parts := strings.Split(address, "/")
+	switch len(parts) {
+	case 3:
+		result.Hostname = defaultRegistryHostname
+	case 4:
+		result.Hostname, parts = strings.ToLower(parts[0]), parts[1:]
+	default:
+		return result, fmt.Errorf("invalid registry module source %q: expected [<hostname>/]<namespace>/<name>/<provider>", source)
+	}
+	if !registryNameRegexp.MatchString(parts[0]) || !registryNameRegexp.MatchString(parts[1]) || !registryProviderRegexp.MatchString(parts[2]) {
+		return result, fmt.Errorf("invalid registry module source %q: expected [<hostname>/]<namespace>/<name>/<provider>", source)
+	}
+	result.Namespace, result.Name, result.Provider = parts[0], parts[1], parts[2]
+
+	return result, nil
+}
+
+// registryClient fetches modules with the module registry protocol.
+type registryClient struct {
+	httpClient *http.Client
+	// token returns the API token of the registry of the given hostname.
+	token func(hostname string) string
+}
+
+// FetchRegistryModule downloads a module of a module registry, such as the
+// HCP Terraform private registry, into the working directory, to be the
+// root module. The API tokens of the registries are read from the
+// environment variables terraform reads them from.
+func (r *TerraformRunnerServer) FetchRegistryModule(ctx context.Context, req *FetchRegistryModuleRequest) (*FetchRegistryModuleReply, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	log.Info("fetching registry module", "source", req.Source, "version", req.Version)
+	if req.TfInstance != r.InstanceID {
+		err := fmt.Errorf("no TF instance found")
+		log.Error(err, "no terraform")
+		return nil, err
+	}
+
+	envs := r.env
+	if envs == nil {
+		envs = utils.EnvMap(os.Environ())
+	}
+	client := &registryClient{
+		httpClient: &http.Client{Timeout: registryRequestTimeout},
+		token: func(hostname string) string {
+			return envs[RegistryTokenEnv(hostname)]
+		},
+	}
+
+	version, err := client.fetch(ctx, r.tf.WorkingDir(), req.Source, req.Version)
+	if err != nil {
+		log.Error(err, "unable to fetch registry module", "source", req.Source)
+		return nil, err
+	}
+
+	log.Info("fetched registry module", "source", req.Source, "version", version)
+	return &FetchRegistryModuleReply{Version: version}, nil
+}
+
+// fetch resolves the version constraint, and extracts the module into dir.
+// It returns the resolved version.
+func (c *registryClient) fetch(ctx context.Context, dir, source, constraint string) (string, error) {
+	module, err := parseRegistryModuleSource(source)
+	if err != nil {
+		return "", err
+	}
+
+	baseURL, err := c.discoverModules(ctx, module.Hostname)
+	if err != nil {
+		return "", err
+	}
+	moduleURL, err := baseURL.Parse(path.Join(module.Namespace, module.Name, module.Provider) + "/")
+	if err != nil {
+		return "", err
+	}
+
+	version, err := c.resolveVersion(ctx, module.Hostname, moduleURL, constraint)
+	if err != nil {
+		return "", err
+	}
+
+	location, err := c.downloadLocation(ctx, module.Hostname, moduleURL, version)
+	if err != nil {
+		return "", err
+	}
+
+	if err := c.download(ctx, module.Hostname, location, module.Subdir, dir); err != nil {
+		return "", fmt.Errorf("unable to download module %s %s: %w", source, version, err)
+	}
+	return version, nil
+}
+
+// discoverModules returns the base URL of the modules API of the registry.
+func (c *registryClient) discoverModules(ctx context.Context, hostname string) (*url.URL, error) {
+	discoveryURL := &url.URL{Scheme: "https", Host: hostname, Path: "/.well-known/terraform.json"}
+
+	var services map[string]interface{}
+	if err := c.getJSON(ctx, hostname, discoveryURL.String(), &services); err != nil {
+		return nil, fmt.Errorf("unable to discover the services of %s: %w", hostname, err)
+	}
+
+	modules, ok := services["modules.v1"].(string)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a module registry", hostname)
+	}
+	if !strings.HasSuffix(modules, "/") {
+		modules += "/"
+	}
+	return discoveryURL.Parse(modules)
+}
+
+// resolveVersion returns the latest version of the module which meets the
+// constraint. Pre-releases are only used when the constraint is exactly one.
+func (c *registryClient) resolveVersion(ctx context.Context, hostname string, moduleURL *url.URL, constraint string) (string, error) {
+	var constraints goversion.Constraints
+	if constraint != "" {
+		var err error
+		if constraints, err = goversion.NewConstraint(constraint); err != nil {
+			return "", fmt.Errorf("invalid module version %q: %w", constraint, err)
+		}
+	}
+
+	versionsURL, err := moduleURL.Parse("versions")
+	if err != nil {
+		return "", err
+	}
+
+	var reply struct {
+		Modules []struct {
+			Versions []struct {
+				Version string `json:"version"`
+			} `json:"versions"`
+		} `json:"modules"`
+	}
+	if err := c.getJSON(ctx, hostname, versionsURL.String(), &reply); err != nil {
+		return "", fmt.Errorf("unable to list the versions of the module: %w", err)
+	}
+
+	var versions goversion.Collection
+	for _, module := range reply.Modules {
+		for _, v := range module.Versions {
+			version, err := goversion.NewVersion(v.Version)
+			if err != nil {
+				continue
+			}
+			if version.Prerelease() != "" && version.Original() != constraint {
+				continue
+			}
+			if constraints == nil || constraints.Check(version) {
+				versions = append(versions, version)
+			}
+		}
+	}
+	if len(versions) == 0 {
+		return "", fmt.Errorf("no version of the module matches %q", constraint)
+	}
+
+	sort.Sort(versions)
+	return versions[len(versions)-1].Original(), nil
+}
+
+// downloadLocation returns the location of the package of the module
+// version, from the X-Terraform-Get header or from the JSON body.
+func (c *registryClient) downloadLocation(ctx context.Context, hostname string, moduleURL *url.URL, version string) (*url.URL, error) {
+	downloadURL, err := moduleURL.Parse(url.PathEscape(version) + "/download")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.get(ctx, hostname, downloadURL.String())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	location := resp.Header.Get("X-Terraform-Get")
+	if location == "" {
+		var body struct {
+			Location string `json:"location"`
+		}
+		if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body); err == nil {
+			location = body.Location
+		}
+	}
+	if location == "" {
+		return nil, fmt.Errorf("the registry returned no download location for version %s", version)
+	}
+
+	// go-getter forced getters, e.g. git::, need terraform to fetch them
+	if i := strings.Index(location, "::"); i >= 0 && !strings.Contains(location[:i], "/") {
+		return nil, fmt.Errorf("unsupported module package %q: only HTTP archives can be fetched, source the module from a root module instead", location)
+	}
+
+	return downloadURL.Parse(location)
+}
+
+// download extracts the archive at location into dir. The directory of the
+// module in the archive is either given with the source, or with the
+// location, after a double slash. A "*" directory is the only directory of
+// the archive, as in the archives of GitHub.
+func (c *registryClient) download(ctx context.Context, hostname string, location *url.URL, subdir, dir string) error {
+	copied := *location
+	location = &copied
+	if i := strings.Index(location.Path, "//"); i >= 0 {
+		locationSubdir := strings.Trim(location.Path[i+2:], "/")
+		location.Path = location.Path[:i]
+		location.RawPath = ""
+		subdir = path.Join(locationSubdir, subdir)
+	}
+
+	query := location.Query()
+	archive := query.Get("archive")
+	query.Del("archive")
+	location.RawQuery = query.Encode()
+
+	// the token is only sent to the registry, not to the storage of the packages
+	tokenHostname := ""
+	if location.Host == hostname {
+		tokenHostname = hostname
+	}
+	resp, err := c.get(ctx, tokenHostname, location.String())
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxRegistryModuleSize+1))
+	if err != nil {
+		return err
+	}
+	if len(data) > maxRegistryModuleSize {
+		return fmt.Errorf("the module package is larger than %d bytes", maxRegistryModuleSize)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "registry-module-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	switch {
+	case archive == "zip" || (archive == "" && (strings.HasSuffix(location.Path, ".zip") || bytes.HasPrefix(data, []byte("PK\x03\x04")))):
+		err = extractZip(data, tmpDir)
+	case archive == "" || archive == "tar.gz" || archive == "tgz":
+		_, err = untar.Untar(bytes.NewReader(data), tmpDir)
+	default:
+		err = fmt.Errorf("unsupported archive format %q", archive)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to extract the module package: %w", err)
+	}
+
+	moduleDir := tmpDir
+	for _, part := range strings.Split(subdir, "/") {
+		switch part {
+		case "":
+			continue
+		case "*":
+			entries, err := os.ReadDir(moduleDir)
+			if err != nil {
+				return err
+			}
+			if len(entries) != 1 || !entries[0].IsDir() {
+				return fmt.Errorf("the module package must have a single directory")
+			}
+			part = entries[0].Name()
+		}
+		if moduleDir, err = securejoin.SecureJoin(moduleDir, part); err != nil {
+			return err
+		}
+	}
+	if info, err := os.Stat(moduleDir); err != nil || !info.IsDir() {
+		return fmt.Errorf("the module package has no directory %q", subdir)
+	}
+
+	return copyDir(moduleDir, dir)
+}
+
+func (c *registryClient) get(ctx context.Context, hostname, rawURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if hostname != "" {
+		if token := c.token(hostname); token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("GET %s: %s, check the API token of %s", req.URL.Redacted(), resp.Status, hostname)
+		}
+		return nil, fmt.Errorf("GET %s: %s", req.URL.Redacted(), resp.Status)
+	}
+	return resp, nil
+}
+
+func (c *registryClient) getJSON(ctx context.Context, hostname, rawURL string, v interface{}) error {
+	resp, err := c.get(ctx, hostname, rawURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return json.NewDecoder(io.LimitReader(resp.Body, 10<<20)).Decode(v)
+}
+
+func extractZip(data []byte, dir string) error {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return err
+	}
+
+	for _, f := range zr.File {
+		target, err := securejoin.SecureJoin(dir, f.Name)
+		if err != nil {
+			return err
+		}
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, f.Mode().Perm()|0o600)
+		if err != nil {
+			rc.Close()
+			return err
+		}
+		_, err = io.Copy(out, rc)
+		rc.Close()
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyDir copies the files of src into dst. The files of dst are kept,
+// unless the module has a file of the same name.
+func copyDir(src, dst string) error {
+	return filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		switch {
+		case info.IsDir():
+			return os.MkdirAll(target, 0o755)
+		case !info.Mode().IsRegular():
+			// symlinks are not followed out of the module
+			return nil
+		}
+
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, info.Mode().Perm()|0o600)
+	})
+}
@@ -0,0 +1,130 @@
+package runner
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRegistryTokenEnv(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(RegistryTokenEnv("app.terraform.io")).To(Equal("TF_TOKEN_app_terraform_io"))
+	g.Expect(RegistryTokenEnv("tfe.my-corp.com")).To(Equal("TF_TOKEN_tfe_my__corp_com"))
+}
+
+func TestParseRegistryModuleSource(t *testing.T) {
+	g := NewWithT(t)
+
+	module, err := parseRegistryModuleSource("app.terraform.io/example-corp/vpc/aws//modules/subnets")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(module).To(Equal(registryModuleSource{
+		Hostname:  "app.terraform.io",
+		Namespace: "example-corp",
+		Name:      "vpc",
+		Provider:  "aws",
+		Subdir:    "modules/subnets",
+	}))
+
+	module, err = parseRegistryModuleSource("terraform-aws-modules/vpc/aws")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(module.Hostname).To(Equal("registry.terraform.io"))
+
+	_, err = parseRegistryModuleSource("github.com/org/repo")
+	g.Expect(err).To(HaveOccurred())
+	_, err = parseRegistryModuleSource("app.terraform.io//vpc/aws")
+	g.Expect(err).To(HaveOccurred())
+}
+
+func tarGz(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestRegistryClientFetch(t *testing.T) {
+	g := NewWithT(t)
+
+	archive := tarGz(t, map[string]string{
+		"vpc-1.2.0/main.tf":              `resource "null_resource" "vpc" {}`,
+		"vpc-1.2.0/modules/subnets/a.tf": `variable "cidr" {}`,
+	})
+
+	var authorizations []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/terraform.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"modules.v1": "/api/registry/v1/modules/"}`)
+	})
+	mux.HandleFunc("/api/registry/v1/modules/example-corp/vpc/aws/versions", func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"modules": [{"versions": [{"version": "1.1.0"}, {"version": "1.2.0"}, {"version": "1.3.0-beta"}, {"version": "2.0.0"}]}]}`)
+	})
+	mux.HandleFunc("/api/registry/v1/modules/example-corp/vpc/aws/1.2.0/download", func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		w.Header().Set("X-Terraform-Get", "/archives/vpc.tar.gz//*?archive=tar.gz")
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/archives/vpc.tar.gz", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(archive)
+	})
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+	hostname := strings.TrimPrefix(server.URL, "https://")
+
+	client := &registryClient{
+		httpClient: server.Client(),
+		token: func(h string) string {
+			if h == hostname {
+				return "s3cr3t"
+			}
+			return ""
+		},
+	}
+
+	dir := t.TempDir()
+	g.Expect(os.WriteFile(filepath.Join(dir, "terraform.tfvars"), []byte(`cidr = "10.0.0.0/16"`), 0644)).To(Succeed())
+
+	version, err := client.fetch(context.TODO(), dir, hostname+"/example-corp/vpc/aws", "~> 1.1")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(version).To(Equal("1.2.0"))
+	g.Expect(authorizations).To(Equal([]string{"Bearer s3cr3t", "Bearer s3cr3t"}))
+
+	g.Expect(filepath.Join(dir, "main.tf")).To(BeARegularFile())
+	g.Expect(filepath.Join(dir, "modules", "subnets", "a.tf")).To(BeARegularFile())
+	g.Expect(filepath.Join(dir, "terraform.tfvars")).To(BeARegularFile())
+
+	// the source can select a module of the package
+	dir = t.TempDir()
+	_, err = client.fetch(context.TODO(), dir, hostname+"/example-corp/vpc/aws//modules/subnets", "1.2.0")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(filepath.Join(dir, "a.tf")).To(BeARegularFile())
+	g.Expect(filepath.Join(dir, "main.tf")).ToNot(BeAnExistingFile())
+
+	_, err = client.fetch(context.TODO(), t.TempDir(), hostname+"/example-corp/vpc/aws", "~> 3.0")
+	g.Expect(err).To(MatchError(ContainSubstring("no version of the module matches")))
+}
@@ -0,0 +1,80 @@
+package runner
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// cgroupRoot is where the cgroup of the runner pod is mounted.
+var cgroupRoot = "/sys/fs/cgroup"
+
+// GetResourceUsage returns the CPU time and the peak memory the runner pod
+// used since it started, as accounted by its cgroup, so that terraform and
+// its providers are counted too. The values are zero when the cgroup cannot
+// be read.
+func (r *TerraformRunnerServer) GetResourceUsage(ctx context.Context, req *GetResourceUsageRequest) (*GetResourceUsageReply, error) {
+	reply := &GetResourceUsageReply{}
+	if cpuSeconds, ok := cgroupCPUSeconds(cgroupRoot); ok {
+		reply.CpuSeconds = cpuSeconds
+	}
+	if peakMemory, ok := cgroupPeakMemory(cgroupRoot); ok {
+		reply.PeakMemoryBytes = peakMemory
+	}
+	return reply, nil
+}
+
+// cgroupCPUSeconds reads the CPU time of the cgroup, from cpu.stat with
+// cgroup v2 or from cpuacct.usage with cgroup v1.
+func cgroupCPUSeconds(root string) (float64, bool) {
+	if data, err := os.ReadFile(filepath.Join(root, "cpu.stat")); err == nil {
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			key, value, _ := strings.Cut(scanner.Text(), " ")
+			if key != "usage_usec" {
+				continue
+			}
+			usec, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return 0, false
+			}
+			return float64(usec) / 1e6, true
+		}
+		return 0, false
+	}
+
+	if nsec, ok := readCgroupUint(filepath.Join(root, "cpuacct", "cpuacct.usage")); ok {
+		return float64(nsec) / 1e9, true
+	}
+	return 0, false
+}
+
+// cgroupPeakMemory reads the peak memory of the cgroup, from memory.peak
+// with cgroup v2 or from memory.max_usage_in_bytes with cgroup v1.
+func cgroupPeakMemory(root string) (int64, bool) {
+	for _, name := range []string{
+		"memory.peak",
+		filepath.Join("memory", "memory.max_usage_in_bytes"),
+	} {
+		if bytes, ok := readCgroupUint(filepath.Join(root, name)); ok {
+			return int64(bytes), true
+		}
+	}
+	return 0, false
+}
+
+func readCgroupUint(name string) (uint64, bool) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return 0, false
+	}
+	value, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
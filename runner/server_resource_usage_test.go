@@ -0,0 +1,47 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestGetResourceUsage(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	write := func(name, content string) {
+		g.Expect(os.MkdirAll(filepath.Dir(name), 0o700)).To(Succeed())
+		g.Expect(os.WriteFile(name, []byte(content), 0o600)).To(Succeed())
+	}
+	defer func(root string) { cgroupRoot = root }(cgroupRoot)
+	r := &TerraformRunnerServer{}
+
+	// cgroup v2
+	cgroupRoot = t.TempDir()
+	write(filepath.Join(cgroupRoot, "cpu.stat"), "usage_usec 2500000\nuser_usec 2000000\nsystem_usec 500000\n")
+	write(filepath.Join(cgroupRoot, "memory.peak"), "268435456\n")
+	reply, err := r.GetResourceUsage(ctx, &GetResourceUsageRequest{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(reply.CpuSeconds).To(Equal(2.5))
+	g.Expect(reply.PeakMemoryBytes).To(Equal(int64(268435456)))
+
+	// cgroup v1
+	cgroupRoot = t.TempDir()
+	write(filepath.Join(cgroupRoot, "cpuacct", "cpuacct.usage"), "1500000000\n")
+	write(filepath.Join(cgroupRoot, "memory", "memory.max_usage_in_bytes"), "1048576\n")
+	reply, err = r.GetResourceUsage(ctx, &GetResourceUsageRequest{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(reply.CpuSeconds).To(Equal(1.5))
+	g.Expect(reply.PeakMemoryBytes).To(Equal(int64(1048576)))
+
+	// without a cgroup
+	cgroupRoot = t.TempDir()
+	reply, err = r.GetResourceUsage(ctx, &GetResourceUsageRequest{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(reply.CpuSeconds).To(BeZero())
+	g.Expect(reply.PeakMemoryBytes).To(BeZero())
+}
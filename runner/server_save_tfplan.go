@@ -69,12 +69,40 @@ func (r *TerraformRunnerServer) SaveTFPlan(ctx context.Context, req *SaveTFPlanR
 			return nil, err
 		}
 
+		if len(r.terraform.Spec.ReadablePlanDiffs) > 0 {
+			planObj, err := r.tfShowPlanFile(ctx, TFPlanName)
+			if err != nil {
+				log.Error(err, "unable to get the plan output for the diffs")
+				return nil, err
+			}
+			rawOutput += r.renderPlanDiffs(ctx, planObj, r.terraform.Spec.ReadablePlanDiffs)
+		}
+
 		if err := r.writePlanAsConfigMap(ctx, req.Name, req.Namespace, log, planId, rawOutput, "", req.Uuid); err != nil {
 			return nil, err
 		}
 	}
 
-	return &SaveTFPlanReply{Message: "ok"}, nil
+	// the changes of the plan are only a report, failing to publish them
+	// does not fail the plan
+	reply := &SaveTFPlanReply{Message: "ok"}
+	if r.terraform.Spec.PublishPlanChanges && !req.BackendCompletelyDisable {
+		if err := r.savePlanChanges(ctx, req, planId); err != nil {
+			log.Error(err, "unable to publish the changes of the plan")
+			reply.PlanChangesError = err.Error()
+		}
+	}
+
+	return reply, nil
+}
+
+func (r *TerraformRunnerServer) savePlanChanges(ctx context.Context, req *SaveTFPlanRequest, planId string) error {
+	planObj, err := r.tfShowPlanFile(ctx, TFPlanName)
+	if err != nil {
+		return fmt.Errorf("unable to get the plan output for the changes: %w", err)
+	}
+
+	return r.publishPlanChanges(ctx, req.Name, req.Namespace, req.Uuid, planId, req.Revision, planObj)
 }
 
 func (r *TerraformRunnerServer) writePlanAsSecret(ctx context.Context, name string, namespace string, log logr.Logger, planId string, tfplan []byte, suffix string, uuid string) error {
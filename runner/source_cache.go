@@ -0,0 +1,137 @@
+package runner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// sourceCacheTTL is the time after which an artifact not used by any runner
+// of the node is removed from the source cache.
+const sourceCacheTTL = 7 * 24 * time.Hour
+
+var sha256DigestRe = regexp.MustCompile(`^sha256:([a-f0-9]{64})$`)
+
+// sourceCache caches the artifacts of the sources on the node of the runner,
+// by digest, for the runners of the next reconciliations of the same
+// revision to skip their download. The cache is shared with the runners of
+// the other namespaces of the node, so an artifact is verified against its
+// digest every time it is read, and a tampered one is never extracted.
+type sourceCache struct {
+	dir string
+}
+
+// path returns the path of the artifact of a sha256 digest in the cache.
+func (c sourceCache) path(digest string) (string, bool) {
+	if c.dir == "" {
+		return "", false
+	}
+	match := sha256DigestRe.FindStringSubmatch(digest)
+	if match == nil {
+		return "", false
+	}
+	return filepath.Join(c.dir, "sha256", match[1]+".tar.gz"), true
+}
+
+// get returns the artifact of the digest, if the cache has it. An artifact
+// which does not match its digest is removed.
+func (c sourceCache) get(digest string) ([]byte, bool, error) {
+	path, ok := c.path(digest)
+	if !ok {
+		return nil, false, nil
+	}
+
+	tarGz, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !matchesDigest(tarGz, digest) {
+		err := fmt.Errorf("cached artifact %s does not match its digest", digest)
+		if rmErr := os.Remove(path); rmErr != nil {
+			err = fmt.Errorf("%w, and cannot be removed: %s", err, rmErr)
+		}
+		return nil, false, err
+	}
+
+	// keep the artifacts in use from expiring
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+	return tarGz, true, nil
+}
+
+// put stores the artifact of the digest, unless it does not match it, and
+// removes the expired artifacts. The artifact is written to a temporary
+// file renamed into place, for the other runners of the node to never read
+// it partially written.
+func (c sourceCache) put(digest string, tarGz []byte) error {
+	path, ok := c.path(digest)
+	if !ok {
+		return nil
+	}
+	if !matchesDigest(tarGz, digest) {
+		return fmt.Errorf("artifact does not match its digest %s", digest)
+	}
+
+	// the cache is disabled when the node has no cache directory, like in
+	// the runner pods without its hostPath volume
+	if _, err := os.Stat(c.dir); err != nil {
+		return nil
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	c.expire(dir)
+
+	tmp, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(tarGz); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// expire removes the artifacts not used for the sourceCacheTTL, and the
+// temporary files left by the runners terminated while writing them.
+func (c sourceCache) expire(dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || entry.IsDir() {
+			continue
+		}
+		if time.Since(info.ModTime()) > sourceCacheTTL {
+			_ = os.Remove(filepath.Join(dir, entry.Name()))
+		}
+	}
+}
+
+// matchesDigest tells whether the artifact matches its sha256 digest.
+func matchesDigest(tarGz []byte, digest string) bool {
+	sum := sha256.Sum256(tarGz)
+	return digest == "sha256:"+hex.EncodeToString(sum[:])
+}
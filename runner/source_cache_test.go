@@ -0,0 +1,112 @@
+package runner
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	. "github.com/onsi/gomega"
+)
+
+func testArtifact(t *testing.T, files map[string]string) ([]byte, string) {
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, content := range files {
+		if err := tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tarWriter.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes(), fmt.Sprintf("sha256:%x", sha256.Sum256(buf.Bytes()))
+}
+
+func TestUploadAndExtractSourceCache(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	t.Setenv("TMPDIR", t.TempDir())
+
+	cacheDir := t.TempDir()
+	r := &TerraformRunnerServer{SourceCacheDir: cacheDir}
+	tarGz, digest := testArtifact(t, map[string]string{"main.tf": `resource "null_resource" "a" {}`})
+	cachedPath := filepath.Join(cacheDir, "sha256", digest[len("sha256:"):]+".tar.gz")
+
+	// not cached yet
+	_, err := r.UploadAndExtract(ctx, &UploadAndExtractRequest{Namespace: "flux-system", Name: "helloworld", Path: ".", Digest: digest})
+	g.Expect(status.Code(err)).To(Equal(codes.NotFound))
+
+	// an uploaded artifact is cached
+	reply, err := r.UploadAndExtract(ctx, &UploadAndExtractRequest{Namespace: "flux-system", Name: "helloworld", Path: ".", TarGz: tarGz, Digest: digest})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(reply.Cached).To(BeFalse())
+	g.Expect(cachedPath).To(BeARegularFile())
+
+	// the runner of another reconciliation extracts it from the cache
+	r = &TerraformRunnerServer{SourceCacheDir: cacheDir}
+	reply, err = r.UploadAndExtract(ctx, &UploadAndExtractRequest{Namespace: "team-a", Name: "helloworld", Path: ".", Digest: digest})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(reply.Cached).To(BeTrue())
+	g.Expect(filepath.Join(reply.WorkingDir, "main.tf")).To(BeARegularFile())
+
+	// a tampered artifact is removed rather than extracted
+	tampered, _ := testArtifact(t, map[string]string{"main.tf": `resource "null_resource" "b" {}`})
+	g.Expect(os.WriteFile(cachedPath, tampered, 0o644)).To(Succeed())
+	_, err = r.UploadAndExtract(ctx, &UploadAndExtractRequest{Namespace: "team-a", Name: "helloworld", Path: ".", Digest: digest})
+	g.Expect(status.Code(err)).To(Equal(codes.NotFound))
+	g.Expect(cachedPath).ToNot(BeAnExistingFile())
+
+	// an artifact which does not match its digest is extracted, not cached
+	reply, err = r.UploadAndExtract(ctx, &UploadAndExtractRequest{Namespace: "team-a", Name: "helloworld", Path: ".", TarGz: tampered, Digest: digest})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(filepath.Join(reply.WorkingDir, "main.tf")).To(BeARegularFile())
+	g.Expect(cachedPath).ToNot(BeAnExistingFile())
+
+	// without a cache directory, nothing is cached
+	r = &TerraformRunnerServer{}
+	_, err = r.UploadAndExtract(ctx, &UploadAndExtractRequest{Namespace: "team-a", Name: "helloworld", Path: ".", TarGz: tarGz, Digest: digest})
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = r.UploadAndExtract(ctx, &UploadAndExtractRequest{Namespace: "team-a", Name: "helloworld", Path: ".", Digest: digest})
+	g.Expect(status.Code(err)).To(Equal(codes.NotFound))
+}
+
+func TestSourceCacheExpire(t *testing.T) {
+	g := NewWithT(t)
+
+	cache := sourceCache{dir: t.TempDir()}
+	oldTarGz, oldDigest := testArtifact(t, map[string]string{"main.tf": "old"})
+	g.Expect(cache.put(oldDigest, oldTarGz)).To(Succeed())
+	oldPath, _ := cache.path(oldDigest)
+	expired := time.Now().Add(-sourceCacheTTL - time.Hour)
+	g.Expect(os.Chtimes(oldPath, expired, expired)).To(Succeed())
+
+	tarGz, digest := testArtifact(t, map[string]string{"main.tf": "new"})
+	g.Expect(cache.put(digest, tarGz)).To(Succeed())
+	g.Expect(oldPath).ToNot(BeAnExistingFile())
+
+	cached, ok, err := cache.get(digest)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+	g.Expect(cached).To(Equal(tarGz))
+
+	// only the sha256 digests are cached
+	_, ok = cache.path("sha512:abc")
+	g.Expect(ok).To(BeFalse())
+}
@@ -0,0 +1,231 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	// ProtocolVersion is the version of the controller-runner protocol
+	// implemented by this package. It must be increased whenever an RPC, or
+	// a field the other side relies upon, is added.
+	//
+	// Version 1 is the protocol of the runners without the GetVersion RPC.
+	// Version 2 adds the CheckCredentials and ExportResults RPCs.
+	// Version 3 adds the ProcessGitCredentials RPC.
+	// Version 4 adds the CheckPlanFreshness RPC.
+	// Version 5 adds the stateSecretName field of FinalizeSecrets.
+	// Version 6 adds the WatchProgress RPC.
+	// Version 7 adds the FetchRegistryModule RPC.
+	// Version 8 adds the stateSecretNamespace field of FinalizeSecrets.
+	// Version 9 adds the GetResourceUsage RPC.
+	// Version 10 adds the disableLock field of Plan.
+	// Version 11 adds the dependencyLock fields of Init.
+	// Version 12 adds the Refresh RPC.
+	// Version 13 adds the planChangesError field of SaveTFPlan.
+	// Version 14 adds the checksums fields of WriteOutputs.
+	// Version 15 adds the suspectedMoves field of Plan.
+	// Version 16 adds the WriteSimulationConfig RPC.
+	// Version 17 adds the resourceTimings field of Apply.
+	// Version 18 adds the digest field of UploadAndExtract.
+	// Version 19 adds the Exec kind of varsFrom, and the execVars field of
+	// GenerateVarsForTF.
+	ProtocolVersion int32 = 19
+
+	// MinProtocolVersion is the oldest protocol version of the other side
+	// this package still works with. It must allow the runner images of, at
+	// least, the previous minor release, so that upgrading the controller
+	// does not break the runners in flight.
+	MinProtocolVersion int32 = 1
+)
+
+// GetVersion returns the protocol versions implemented by the runner.
+func (r *TerraformRunnerServer) GetVersion(ctx context.Context, req *GetVersionRequest) (*GetVersionReply, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	log.Info("negotiating the protocol version", "controller-protocol-version", req.ProtocolVersion)
+
+	if req.ProtocolVersion < MinProtocolVersion {
+		err := fmt.Errorf("controller protocol version %d is not supported, the minimum version is %d", req.ProtocolVersion, MinProtocolVersion)
+		log.Error(err, "unsupported controller")
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	return &GetVersionReply{
+		ProtocolVersion:    ProtocolVersion,
+		MinProtocolVersion: MinProtocolVersion,
+	}, nil
+}
+
+// NegotiateVersion asks the runner for its protocol version, and returns a
+// client that fails the RPCs the runner does not implement yet with a
+// descriptive error. It returns an error if the runner and this package are
+// not compatible.
+func NegotiateVersion(ctx context.Context, client RunnerClient) (RunnerClient, int32, error) {
+	reply, err := client.GetVersion(ctx, &GetVersionRequest{ProtocolVersion: ProtocolVersion})
+	if status.Code(err) == codes.Unimplemented {
+		reply, err = &GetVersionReply{ProtocolVersion: 1, MinProtocolVersion: 1}, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("unable to get the runner protocol version: %w", err)
+	}
+
+	if reply.ProtocolVersion < MinProtocolVersion {
+		return nil, reply.ProtocolVersion, fmt.Errorf("runner protocol version %d is not supported, the minimum version is %d: upgrade the runner image",
+			reply.ProtocolVersion, MinProtocolVersion)
+	}
+	if reply.MinProtocolVersion > ProtocolVersion {
+		return nil, reply.ProtocolVersion, fmt.Errorf("the runner requires protocol version %d or later, the controller implements version %d: upgrade the controller",
+			reply.MinProtocolVersion, ProtocolVersion)
+	}
+
+	return &versionedClient{RunnerClient: client, version: reply.ProtocolVersion}, reply.ProtocolVersion, nil
+}
+
+// versionedClient guards the RPCs introduced after the protocol version
+// of an older runner.
+type versionedClient struct {
+	RunnerClient
+	version int32
+}
+
+func (c *versionedClient) require(version int32, rpc string) error {
+	if c.version >= version {
+		return nil
+	}
+	return status.Errorf(codes.Unimplemented, "%s requires runner protocol version %d, the runner implements version %d: upgrade the runner image",
+		rpc, version, c.version)
+}
+
+func (c *versionedClient) CheckCredentials(ctx context.Context, in *CheckCredentialsRequest, opts ...grpc.CallOption) (*CheckCredentialsReply, error) {
+	if err := c.require(2, "CheckCredentials"); err != nil {
+		return nil, err
+	}
+	return c.RunnerClient.CheckCredentials(ctx, in, opts...)
+}
+
+func (c *versionedClient) ExportResults(ctx context.Context, in *ExportResultsRequest, opts ...grpc.CallOption) (*ExportResultsReply, error) {
+	if err := c.require(2, "ExportResults"); err != nil {
+		return nil, err
+	}
+	return c.RunnerClient.ExportResults(ctx, in, opts...)
+}
+
+func (c *versionedClient) ProcessGitCredentials(ctx context.Context, in *ProcessGitCredentialsRequest, opts ...grpc.CallOption) (*ProcessGitCredentialsReply, error) {
+	if err := c.require(3, "ProcessGitCredentials"); err != nil {
+		return nil, err
+	}
+	return c.RunnerClient.ProcessGitCredentials(ctx, in, opts...)
+}
+
+func (c *versionedClient) CheckPlanFreshness(ctx context.Context, in *CheckPlanFreshnessRequest, opts ...grpc.CallOption) (*CheckPlanFreshnessReply, error) {
+	if err := c.require(4, "CheckPlanFreshness"); err != nil {
+		return nil, err
+	}
+	return c.RunnerClient.CheckPlanFreshness(ctx, in, opts...)
+}
+
+func (c *versionedClient) FinalizeSecrets(ctx context.Context, in *FinalizeSecretsRequest, opts ...grpc.CallOption) (*FinalizeSecretsReply, error) {
+	if in.StateSecretName != "" {
+		// an older runner would keep the state without telling
+		if err := c.require(5, "FinalizeSecrets with the state"); err != nil {
+			return nil, err
+		}
+	}
+	if in.StateSecretName != "" && in.StateSecretNamespace != "" && in.StateSecretNamespace != in.Namespace {
+		// an older runner would look for the state in the wrong namespace
+		if err := c.require(8, "FinalizeSecrets with the state in another namespace"); err != nil {
+			return nil, err
+		}
+	}
+	return c.RunnerClient.FinalizeSecrets(ctx, in, opts...)
+}
+
+func (c *versionedClient) WatchProgress(ctx context.Context, in *WatchProgressRequest, opts ...grpc.CallOption) (Runner_WatchProgressClient, error) {
+	if err := c.require(6, "WatchProgress"); err != nil {
+		return nil, err
+	}
+	return c.RunnerClient.WatchProgress(ctx, in, opts...)
+}
+
+func (c *versionedClient) FetchRegistryModule(ctx context.Context, in *FetchRegistryModuleRequest, opts ...grpc.CallOption) (*FetchRegistryModuleReply, error) {
+	if err := c.require(7, "FetchRegistryModule"); err != nil {
+		return nil, err
+	}
+	return c.RunnerClient.FetchRegistryModule(ctx, in, opts...)
+}
+
+func (c *versionedClient) GetResourceUsage(ctx context.Context, in *GetResourceUsageRequest, opts ...grpc.CallOption) (*GetResourceUsageReply, error) {
+	if err := c.require(9, "GetResourceUsage"); err != nil {
+		return nil, err
+	}
+	return c.RunnerClient.GetResourceUsage(ctx, in, opts...)
+}
+
+func (c *versionedClient) Refresh(ctx context.Context, in *RefreshRequest, opts ...grpc.CallOption) (*RefreshReply, error) {
+	if err := c.require(12, "Refresh"); err != nil {
+		return nil, err
+	}
+	return c.RunnerClient.Refresh(ctx, in, opts...)
+}
+
+func (c *versionedClient) WriteSimulationConfig(ctx context.Context, in *WriteSimulationConfigRequest, opts ...grpc.CallOption) (*WriteSimulationConfigReply, error) {
+	if err := c.require(16, "WriteSimulationConfig"); err != nil {
+		return nil, err
+	}
+	return c.RunnerClient.WriteSimulationConfig(ctx, in, opts...)
+}
+
+func (c *versionedClient) Plan(ctx context.Context, in *PlanRequest, opts ...grpc.CallOption) (*PlanReply, error) {
+	if in.DisableLock {
+		// an older runner would hold the state lock of the main branch
+		if err := c.require(10, "Plan without the state lock"); err != nil {
+			return nil, err
+		}
+	}
+	return c.RunnerClient.Plan(ctx, in, opts...)
+}
+
+func (c *versionedClient) Init(ctx context.Context, in *InitRequest, opts ...grpc.CallOption) (*InitReply, error) {
+	if in.DependencyLock != "" {
+		// an older runner would init with the providers unpinned
+		if err := c.require(11, "Init with the dependency lock"); err != nil {
+			return nil, err
+		}
+	}
+	return c.RunnerClient.Init(ctx, in, opts...)
+}
+
+func (c *versionedClient) UploadAndExtract(ctx context.Context, in *UploadAndExtractRequest, opts ...grpc.CallOption) (*UploadAndExtractReply, error) {
+	if in.Digest != "" && len(in.TarGz) == 0 {
+		// an older runner would extract an empty artifact
+		if err := c.require(18, "UploadAndExtract from the source cache"); err != nil {
+			return nil, err
+		}
+	}
+	return c.RunnerClient.UploadAndExtract(ctx, in, opts...)
+}
+
+func (c *versionedClient) GenerateVarsForTF(ctx context.Context, in *GenerateVarsForTFRequest, opts ...grpc.CallOption) (*GenerateVarsForTFReply, error) {
+	if in.ExecVars {
+		// an older runner would drop the variables of the Exec kind
+		if err := c.require(19, "GenerateVarsForTF with the Exec kind of varsFrom"); err != nil {
+			return nil, err
+		}
+	}
+	return c.RunnerClient.GenerateVarsForTF(ctx, in, opts...)
+}
+
+func (c *versionedClient) WriteOutputs(ctx context.Context, in *WriteOutputsRequest, opts ...grpc.CallOption) (*WriteOutputsReply, error) {
+	if in.Checksums {
+		// an older runner would write the secret without the checksums
+		if err := c.require(14, "WriteOutputs with the checksums"); err != nil {
+			return nil, err
+		}
+	}
+	return c.RunnerClient.WriteOutputs(ctx, in, opts...)
+}
@@ -0,0 +1,228 @@
+package runner
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeVersionClient is a runner client that only implements GetVersion,
+// CheckCredentials, Init, Plan, UploadAndExtract, GenerateVarsForTF,
+// WriteOutputs and FinalizeSecrets.
+type fakeVersionClient struct {
+	RunnerClient
+	reply *GetVersionReply
+}
+
+func (c *fakeVersionClient) GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionReply, error) {
+	if c.reply == nil {
+		return nil, status.Error(codes.Unimplemented, "unknown method GetVersion for service runner.Runner")
+	}
+	return c.reply, nil
+}
+
+func (c *fakeVersionClient) CheckCredentials(ctx context.Context, in *CheckCredentialsRequest, opts ...grpc.CallOption) (*CheckCredentialsReply, error) {
+	return &CheckCredentialsReply{}, nil
+}
+
+func (c *fakeVersionClient) Init(ctx context.Context, in *InitRequest, opts ...grpc.CallOption) (*InitReply, error) {
+	return &InitReply{}, nil
+}
+
+func (c *fakeVersionClient) Plan(ctx context.Context, in *PlanRequest, opts ...grpc.CallOption) (*PlanReply, error) {
+	return &PlanReply{}, nil
+}
+
+func (c *fakeVersionClient) UploadAndExtract(ctx context.Context, in *UploadAndExtractRequest, opts ...grpc.CallOption) (*UploadAndExtractReply, error) {
+	return &UploadAndExtractReply{}, nil
+}
+
+func (c *fakeVersionClient) GenerateVarsForTF(ctx context.Context, in *GenerateVarsForTFRequest, opts ...grpc.CallOption) (*GenerateVarsForTFReply, error) {
+	return &GenerateVarsForTFReply{}, nil
+}
+
+func (c *fakeVersionClient) WriteOutputs(ctx context.Context, in *WriteOutputsRequest, opts ...grpc.CallOption) (*WriteOutputsReply, error) {
+	return &WriteOutputsReply{}, nil
+}
+
+func (c *fakeVersionClient) FinalizeSecrets(ctx context.Context, in *FinalizeSecretsRequest, opts ...grpc.CallOption) (*FinalizeSecretsReply, error) {
+	return &FinalizeSecretsReply{}, nil
+}
+
+func TestNegotiateVersion(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	// a runner without GetVersion implements the first protocol version
+	client, version, err := NegotiateVersion(ctx, &fakeVersionClient{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(version).To(Equal(int32(1)))
+	_, err = client.CheckCredentials(ctx, &CheckCredentialsRequest{})
+	g.Expect(status.Code(err)).To(Equal(codes.Unimplemented))
+	g.Expect(err.Error()).To(ContainSubstring("upgrade the runner image"))
+
+	client, version, err = NegotiateVersion(ctx, &fakeVersionClient{reply: &GetVersionReply{ProtocolVersion: ProtocolVersion, MinProtocolVersion: MinProtocolVersion}})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(version).To(Equal(ProtocolVersion))
+	_, err = client.CheckCredentials(ctx, &CheckCredentialsRequest{})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, _, err = NegotiateVersion(ctx, &fakeVersionClient{reply: &GetVersionReply{ProtocolVersion: ProtocolVersion + 1, MinProtocolVersion: ProtocolVersion + 1}})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("upgrade the controller"))
+}
+
+func TestGetVersion(t *testing.T) {
+	g := NewWithT(t)
+	server := &TerraformRunnerServer{}
+
+	reply, err := server.GetVersion(context.Background(), &GetVersionRequest{ProtocolVersion: ProtocolVersion})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(reply.ProtocolVersion).To(Equal(ProtocolVersion))
+
+	_, err = server.GetVersion(context.Background(), &GetVersionRequest{ProtocolVersion: 0})
+	g.Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+}
+
+func TestVersionedFinalizeSecrets(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	client, _, err := NegotiateVersion(ctx, &fakeVersionClient{reply: &GetVersionReply{ProtocolVersion: 4, MinProtocolVersion: MinProtocolVersion}})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = client.FinalizeSecrets(ctx, &FinalizeSecretsRequest{})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// the state is not deleted silently by an older runner
+	_, err = client.FinalizeSecrets(ctx, &FinalizeSecretsRequest{StateSecretName: "tfstate-default-helloworld"})
+	g.Expect(status.Code(err)).To(Equal(codes.Unimplemented))
+}
+
+func TestVersionedFinalizeSecretsStateNamespace(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	client, _, err := NegotiateVersion(ctx, &fakeVersionClient{reply: &GetVersionReply{ProtocolVersion: 7, MinProtocolVersion: MinProtocolVersion}})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = client.FinalizeSecrets(ctx, &FinalizeSecretsRequest{
+		Namespace:            "team-a",
+		StateSecretName:      "tfstate-default-helloworld",
+		StateSecretNamespace: "team-a",
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// an older runner would delete the state in the wrong namespace
+	_, err = client.FinalizeSecrets(ctx, &FinalizeSecretsRequest{
+		Namespace:            "team-a",
+		StateSecretName:      "tfstate-default-helloworld",
+		StateSecretNamespace: "tf-state",
+	})
+	g.Expect(status.Code(err)).To(Equal(codes.Unimplemented))
+}
+
+func TestVersionedPlanDisableLock(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	client, _, err := NegotiateVersion(ctx, &fakeVersionClient{reply: &GetVersionReply{ProtocolVersion: 9, MinProtocolVersion: MinProtocolVersion}})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = client.Plan(ctx, &PlanRequest{})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// an older runner would plan with the state lock
+	_, err = client.Plan(ctx, &PlanRequest{DisableLock: true})
+	g.Expect(status.Code(err)).To(Equal(codes.Unimplemented))
+}
+
+func TestVersionedInitDependencyLock(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	client, _, err := NegotiateVersion(ctx, &fakeVersionClient{reply: &GetVersionReply{ProtocolVersion: 10, MinProtocolVersion: MinProtocolVersion}})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = client.Init(ctx, &InitRequest{Upgrade: true})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// an older runner would init with the providers unpinned
+	_, err = client.Init(ctx, &InitRequest{DependencyLock: "# This file is maintained automatically by \"terraform init\".\n"})
+	g.Expect(status.Code(err)).To(Equal(codes.Unimplemented))
+}
+
+func TestVersionedRefresh(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	client, _, err := NegotiateVersion(ctx, &fakeVersionClient{reply: &GetVersionReply{ProtocolVersion: 11, MinProtocolVersion: MinProtocolVersion}})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = client.Refresh(ctx, &RefreshRequest{})
+	g.Expect(status.Code(err)).To(Equal(codes.Unimplemented))
+	g.Expect(err.Error()).To(ContainSubstring("upgrade the runner image"))
+}
+
+func TestVersionedWriteOutputsChecksums(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	client, _, err := NegotiateVersion(ctx, &fakeVersionClient{reply: &GetVersionReply{ProtocolVersion: 13, MinProtocolVersion: MinProtocolVersion}})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = client.WriteOutputs(ctx, &WriteOutputsRequest{SecretName: "helloworld-outputs"})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// an older runner would write the secret without the checksums
+	_, err = client.WriteOutputs(ctx, &WriteOutputsRequest{SecretName: "helloworld-outputs", Checksums: true})
+	g.Expect(status.Code(err)).To(Equal(codes.Unimplemented))
+}
+
+func TestVersionedWriteSimulationConfig(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	client, _, err := NegotiateVersion(ctx, &fakeVersionClient{reply: &GetVersionReply{ProtocolVersion: 15, MinProtocolVersion: MinProtocolVersion}})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = client.WriteSimulationConfig(ctx, &WriteSimulationConfigRequest{})
+	g.Expect(status.Code(err)).To(Equal(codes.Unimplemented))
+	g.Expect(err.Error()).To(ContainSubstring("upgrade the runner image"))
+}
+
+func TestVersionedUploadAndExtractFromCache(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	client, _, err := NegotiateVersion(ctx, &fakeVersionClient{reply: &GetVersionReply{ProtocolVersion: 17, MinProtocolVersion: MinProtocolVersion}})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// an older runner ignores the digest of an uploaded artifact
+	_, err = client.UploadAndExtract(ctx, &UploadAndExtractRequest{TarGz: []byte("artifact"), Digest: "sha256:abc"})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// an older runner would extract an empty artifact
+	_, err = client.UploadAndExtract(ctx, &UploadAndExtractRequest{Digest: "sha256:abc"})
+	g.Expect(status.Code(err)).To(Equal(codes.Unimplemented))
+}
+
+func TestVersionedGenerateVarsForTFExec(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	client, _, err := NegotiateVersion(ctx, &fakeVersionClient{reply: &GetVersionReply{ProtocolVersion: 18, MinProtocolVersion: MinProtocolVersion}})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = client.GenerateVarsForTF(ctx, &GenerateVarsForTFRequest{WorkingDir: "/tmp"})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// an older runner would drop the variables of the Exec kind
+	_, err = client.GenerateVarsForTF(ctx, &GenerateVarsForTFRequest{WorkingDir: "/tmp", ExecVars: true})
+	g.Expect(status.Code(err)).To(Equal(codes.Unimplemented))
+	g.Expect(err.Error()).To(ContainSubstring("upgrade the runner image"))
+}
@@ -0,0 +1,299 @@
+package tfctl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// auditReportKey is the key of the report in the ConfigMap of the audit.
+const auditReportKey = "report.json"
+
+// auditPollInterval is the interval at which the audit checks whether the
+// plans it requested are over.
+var auditPollInterval = 2 * time.Second
+
+// AuditPlanOptions selects the Terraform objects to audit.
+type AuditPlanOptions struct {
+	Names         []string
+	All           bool
+	AllNamespaces bool
+	Selector      string
+	// Refresh requests a plan of the objects, rather than reporting their
+	// last plan.
+	Refresh bool
+	Timeout time.Duration
+	// ReportConfigMap is the name of the ConfigMap the report is written to,
+	// in the namespace of the CLI.
+	ReportConfigMap string
+}
+
+// AuditReport is the drift and the pending changes of a fleet of Terraform
+// objects.
+type AuditReport struct {
+	GeneratedAt metav1.Time   `json:"generatedAt"`
+	Selector    string        `json:"selector,omitempty"`
+	Drifted     int           `json:"drifted"`
+	Pending     int           `json:"pending"`
+	Failed      int           `json:"failed"`
+	Terraforms  []AuditResult `json:"terraforms"`
+}
+
+// AuditResult is the drift and the pending changes of a Terraform object.
+type AuditResult struct {
+	Namespace   string               `json:"namespace"`
+	Name        string               `json:"name"`
+	Ready       string               `json:"ready"`
+	Drift       bool                 `json:"drift"`
+	PendingPlan string               `json:"pendingPlan,omitempty"`
+	Changes     *infrav1.PlanChanges `json:"changes,omitempty"`
+	Revision    string               `json:"revision,omitempty"`
+	Refreshed   bool                 `json:"refreshed"`
+	Message     string               `json:"message,omitempty"`
+}
+
+// AuditPlan plans the selected Terraform objects off-schedule, without
+// applying them, and reports their drift and their pending changes. The
+// objects the controller would apply on a reconciliation are reported from
+// their last plan instead.
+func (c *CLI) AuditPlan(out io.Writer, opts AuditPlanOptions) error {
+	ctx := context.TODO()
+
+	terraforms, err := c.auditedTerraforms(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if len(terraforms) == 0 {
+		return fmt.Errorf("no Terraform object to audit")
+	}
+
+	results := make([]AuditResult, len(terraforms))
+	requested := map[int]string{}
+	for i := range terraforms {
+		terraform := &terraforms[i]
+		results[i] = AuditResult{Namespace: terraform.Namespace, Name: terraform.Name}
+		if !opts.Refresh {
+			continue
+		}
+		if reason := auditSkipReason(terraform); reason != "" {
+			results[i].Message = "not refreshed: " + reason
+			continue
+		}
+
+		requestedAt := time.Now().Format(time.RFC3339Nano)
+		if err := requestReconciliationAt(ctx, c.client, client.ObjectKeyFromObject(terraform), requestedAt); err != nil {
+			results[i].Message = fmt.Sprintf("not refreshed: %s", err)
+			continue
+		}
+		requested[i] = requestedAt
+	}
+
+	if len(requested) > 0 {
+		fmt.Fprintf(out, " Plans requested for %d Terraform objects, waiting for them to be over\n", len(requested))
+	}
+	deadline := time.Now().Add(opts.Timeout)
+	for len(requested) > 0 {
+		for i, requestedAt := range requested {
+			if err := c.client.Get(ctx, client.ObjectKeyFromObject(&terraforms[i]), &terraforms[i]); err != nil {
+				return err
+			}
+			if auditPlanOver(&terraforms[i], requestedAt) {
+				results[i].Refreshed = true
+				delete(requested, i)
+			}
+		}
+		if len(requested) == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			for i := range requested {
+				results[i].Message = fmt.Sprintf("not refreshed: the plan is not over after %s", opts.Timeout)
+			}
+			break
+		}
+		time.Sleep(auditPollInterval)
+	}
+
+	report := &AuditReport{
+		GeneratedAt: metav1.Now(),
+		Selector:    opts.Selector,
+		Terraforms:  results,
+	}
+	for i := range terraforms {
+		report.add(&terraforms[i], &report.Terraforms[i])
+	}
+
+	printAuditReport(out, report)
+
+	if opts.ReportConfigMap != "" {
+		if err := c.writeAuditReport(ctx, opts.ReportConfigMap, report); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, " Report written to ConfigMap %s/%s\n", c.namespace, opts.ReportConfigMap)
+	}
+
+	return nil
+}
+
+// auditedTerraforms returns the Terraform objects to audit, by namespace and
+// name.
+func (c *CLI) auditedTerraforms(ctx context.Context, opts AuditPlanOptions) ([]infrav1.Terraform, error) {
+	var terraforms []infrav1.Terraform
+	if !opts.All {
+		if len(opts.Names) == 0 {
+			return nil, fmt.Errorf("either Terraform object names or --all must be given")
+		}
+		for _, name := range opts.Names {
+			terraform := infrav1.Terraform{}
+			if err := c.client.Get(ctx, types.NamespacedName{Namespace: c.namespace, Name: name}, &terraform); err != nil {
+				return nil, err
+			}
+			terraforms = append(terraforms, terraform)
+		}
+		return terraforms, nil
+	}
+
+	var listOptions []client.ListOption
+	if !opts.AllNamespaces {
+		listOptions = append(listOptions, client.InNamespace(c.namespace))
+	}
+	if opts.Selector != "" {
+		selector, err := labels.Parse(opts.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector %q: %w", opts.Selector, err)
+		}
+		listOptions = append(listOptions, client.MatchingLabelsSelector{Selector: selector})
+	}
+
+	list := &infrav1.TerraformList{}
+	if err := c.client.List(ctx, list, listOptions...); err != nil {
+		return nil, err
+	}
+	terraforms = list.Items
+	sort.Slice(terraforms, func(i, j int) bool {
+		a, b := &terraforms[i], &terraforms[j]
+		return a.Namespace < b.Namespace || (a.Namespace == b.Namespace && a.Name < b.Name)
+	})
+	return terraforms, nil
+}
+
+// auditSkipReason tells why a Terraform object is not planned by the audit,
+// or returns an empty string. A reconciliation applies the changes of the
+// objects which are forced or auto-approved, or whose pending plan is
+// approved, so they are not reconciled off-schedule.
+func auditSkipReason(terraform *infrav1.Terraform) string {
+	switch {
+	case terraform.Spec.Suspend:
+		return "the object is suspended"
+	case terraform.Spec.Force:
+		return "the controller would apply the changes of the object"
+	case terraform.Spec.PlanOnly:
+		return ""
+	case terraform.Spec.ApprovePlan == infrav1.ApprovePlanAutoValue:
+		return "the controller would apply the changes of the object"
+	case terraform.Spec.ApprovePlan != "" && terraform.Status.Plan.Pending != "" &&
+		strings.HasPrefix(terraform.Status.Plan.Pending, terraform.Spec.ApprovePlan):
+		return "the pending plan is approved"
+	}
+	return ""
+}
+
+// auditPlanOver tells whether the controller is done with the reconciliation
+// requested at the given time.
+func auditPlanOver(terraform *infrav1.Terraform, requestedAt string) bool {
+	if terraform.Status.GetLastHandledReconcileRequest() != requestedAt {
+		return false
+	}
+	ready := apimeta.FindStatusCondition(terraform.Status.Conditions, meta.ReadyCondition)
+	return ready != nil && ready.Reason != meta.ProgressingReason
+}
+
+// add sets the result of the audit of a Terraform object from its status.
+func (r *AuditReport) add(terraform *infrav1.Terraform, result *AuditResult) {
+	result.Ready = string(metav1.ConditionUnknown)
+	if ready := apimeta.FindStatusCondition(terraform.Status.Conditions, meta.ReadyCondition); ready != nil {
+		result.Ready = string(ready.Status)
+		if ready.Status == metav1.ConditionFalse {
+			r.Failed++
+			if result.Message == "" {
+				result.Message = ready.Message
+			}
+		}
+	}
+	result.Drift = terraform.HasDrift()
+	result.PendingPlan = terraform.Status.Plan.Pending
+	result.Changes = terraform.Status.Plan.Changes
+	result.Revision = terraform.Status.LastAppliedRevision
+
+	if result.Drift {
+		r.Drifted++
+	}
+	if result.PendingPlan != "" {
+		r.Pending++
+	}
+}
+
+func printAuditReport(out io.Writer, report *AuditReport) {
+	table := newTablePrinter(out, []string{"Namespace", "Name", "Ready", "Drift", "Pending Changes", "Message"})
+	for _, result := range report.Terraforms {
+		changes := "-"
+		if result.PendingPlan != "" {
+			changes = result.PendingPlan
+			if result.Changes != nil {
+				changes = fmt.Sprintf("+%d ~%d -%d", result.Changes.Add, result.Changes.Change, result.Changes.Destroy)
+			}
+		}
+		table.Append([]string{
+			result.Namespace,
+			result.Name,
+			result.Ready,
+			fmt.Sprintf("%t", result.Drift),
+			changes,
+			result.Message,
+		})
+	}
+	table.Render()
+
+	fmt.Fprintf(out, "\n %d Terraform objects audited: %d drifted, %d with changes pending, %d failed\n",
+		len(report.Terraforms), report.Drifted, report.Pending, report.Failed)
+}
+
+// writeAuditReport creates or updates the ConfigMap of the report.
+func (c *CLI) writeAuditReport(ctx context.Context, name string, report *AuditReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err = c.client.Get(ctx, types.NamespacedName{Namespace: c.namespace, Name: name}, configMap)
+	if apierrors.IsNotFound(err) {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: c.namespace, Name: name},
+			Data:       map[string]string{auditReportKey: string(data)},
+		}
+		return c.client.Create(ctx, configMap)
+	} else if err != nil {
+		return err
+	}
+
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	configMap.Data[auditReportKey] = string(data)
+	return c.client.Update(ctx, configMap)
+}
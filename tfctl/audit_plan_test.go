@@ -0,0 +1,110 @@
+package tfctl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestAuditPlan(t *testing.T) {
+	g := NewWithT(t)
+	auditPollInterval = 10 * time.Millisecond
+
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	terraform := func(name string, env string, spec infrav1.TerraformSpec) *infrav1.Terraform {
+		return &infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "flux-system", Labels: map[string]string{"env": env}},
+			Spec:       spec,
+			Status: infrav1.TerraformStatus{
+				Conditions: []metav1.Condition{{Type: meta.ReadyCondition, Status: metav1.ConditionTrue, Reason: infrav1.NoDriftReason}},
+			},
+		}
+	}
+
+	// the controller plans the changes of the manually approved objects
+	interceptors := interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			if err := c.Patch(ctx, obj, patch, opts...); err != nil {
+				return err
+			}
+			tf := obj.(*infrav1.Terraform)
+			tf.Status.SetLastHandledReconcileRequest(tf.GetAnnotations()[meta.ReconcileRequestAnnotation])
+			tf.Status.Plan = infrav1.PlanStatus{Pending: "plan-main-abc", Changes: &infrav1.PlanChanges{Add: 1}}
+			tf.Status.Conditions = []metav1.Condition{{Type: meta.ReadyCondition, Status: metav1.ConditionUnknown, Reason: infrav1.PlannedWithChangesReason}}
+			return c.Status().Update(ctx, tf)
+		},
+	}
+
+	c := &CLI{
+		client: fake.NewClientBuilder().WithScheme(scheme).
+			WithStatusSubresource(&infrav1.Terraform{}).
+			WithObjects(
+				terraform("network", "prod", infrav1.TerraformSpec{}),
+				terraform("database", "prod", infrav1.TerraformSpec{ApprovePlan: infrav1.ApprovePlanAutoValue}),
+				terraform("sandbox", "dev", infrav1.TerraformSpec{}),
+			).
+			WithInterceptorFuncs(interceptors).Build(),
+		namespace: "flux-system",
+	}
+
+	var out bytes.Buffer
+	g.Expect(c.AuditPlan(&out, AuditPlanOptions{
+		All:             true,
+		Selector:        "env=prod",
+		Refresh:         true,
+		Timeout:         time.Second,
+		ReportConfigMap: "audit",
+	})).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring("2 Terraform objects audited: 0 drifted, 1 with changes pending, 0 failed"))
+
+	configMap := &corev1.ConfigMap{}
+	g.Expect(c.client.Get(context.TODO(), types.NamespacedName{Namespace: "flux-system", Name: "audit"}, configMap)).To(Succeed())
+	var report AuditReport
+	g.Expect(json.Unmarshal([]byte(configMap.Data[auditReportKey]), &report)).To(Succeed())
+	g.Expect(report.Terraforms).To(HaveLen(2))
+
+	database, network := report.Terraforms[0], report.Terraforms[1]
+	g.Expect(database.Name).To(Equal("database"))
+	g.Expect(database.Refreshed).To(BeFalse())
+	g.Expect(database.Message).To(Equal("not refreshed: the controller would apply the changes of the object"))
+	g.Expect(network.Name).To(Equal("network"))
+	g.Expect(network.Refreshed).To(BeTrue())
+	g.Expect(network.PendingPlan).To(Equal("plan-main-abc"))
+	g.Expect(network.Changes).To(Equal(&infrav1.PlanChanges{Add: 1}))
+}
+
+func TestAuditSkipReason(t *testing.T) {
+	g := NewWithT(t)
+
+	terraform := &infrav1.Terraform{}
+	g.Expect(auditSkipReason(terraform)).To(BeEmpty())
+
+	terraform.Spec.ApprovePlan = "plan-main-abc"
+	g.Expect(auditSkipReason(terraform)).To(BeEmpty())
+	terraform.Status.Plan.Pending = "plan-main-abc1234"
+	g.Expect(auditSkipReason(terraform)).To(Equal("the pending plan is approved"))
+
+	terraform.Spec.ApprovePlan = infrav1.ApprovePlanAutoValue
+	terraform.Spec.PlanOnly = true
+	g.Expect(auditSkipReason(terraform)).To(BeEmpty())
+	terraform.Spec.Force = true
+	g.Expect(auditSkipReason(terraform)).To(Equal("the controller would apply the changes of the object"))
+	terraform.Spec.Suspend = true
+	g.Expect(auditSkipReason(terraform)).To(Equal("the object is suspended"))
+}
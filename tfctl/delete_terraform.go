@@ -4,13 +4,30 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 
 	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
-// DeleteTerraform deletes the terraform resource from the cluster
-func (c *CLI) DeleteTerraform(out io.Writer, resource string) error {
+// DeleteTerraform deletes the terraform resource from the cluster. With
+// force, the finalizer of the controller is removed, so that an object the
+// controller cannot finalize, for example because its backend is
+// unreachable, does not block the deletion of its namespace. The deletion
+// policy is then skipped, so keepState must acknowledge that the resources
+// and the state are left as they are.
+func (c *CLI) DeleteTerraform(out io.Writer, resource string, force, keepState bool) error {
+	if force && !keepState {
+		return fmt.Errorf("--force requires --keep-state: the deletion policy is skipped, and the Terraform resources and state are kept")
+	}
+	if keepState && !force {
+		return fmt.Errorf("--keep-state can only be used with --force")
+	}
+
 	key := types.NamespacedName{
 		Name:      resource,
 		Namespace: c.namespace,
@@ -21,11 +38,62 @@ func (c *CLI) DeleteTerraform(out io.Writer, resource string) error {
 		return err
 	}
 
-	if err := c.client.Delete(context.TODO(), terraform); err != nil {
+	if terraform.DeletionTimestamp.IsZero() {
+		if err := c.client.Delete(context.TODO(), terraform); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(out, " deleted Terraform resource %s/%s\n", c.namespace, resource)
+
+	if !force {
+		return nil
+	}
+
+	if err := removeFinalizer(context.TODO(), c.client, key, infrav1.TerraformFinalizer); err != nil {
 		return err
 	}
+	fmt.Fprintf(out, " removed the finalizer of Terraform resource %s/%s, its resources and state are kept\n", c.namespace, resource)
 
-	fmt.Fprintf(out, " deleted Terraform resource %s/%s\n", c.namespace, resource)
+	// release the dependencies, as the controller does once finalized
+	dependantFinalizer := infrav1.TFDependencyOfPrefix + resource
+	for _, d := range terraform.Spec.DependsOn {
+		if d.Namespace == "" {
+			d.Namespace = c.namespace
+		}
+		if err := removeFinalizer(context.TODO(), c.client, types.NamespacedName{Namespace: d.Namespace, Name: d.Name}, dependantFinalizer); err != nil {
+			return err
+		}
+	}
+
+	var dependants []string
+	for _, finalizer := range terraform.GetFinalizers() {
+		if strings.HasPrefix(finalizer, infrav1.TFDependencyOfPrefix) {
+			dependants = append(dependants, strings.TrimPrefix(finalizer, infrav1.TFDependencyOfPrefix))
+		}
+	}
+	if len(dependants) > 0 {
+		fmt.Fprintf(out, " Terraform resource %s/%s waits for its dependants to be deleted: %s\n", c.namespace, resource, strings.Join(dependants, ", "))
+	}
 
 	return nil
 }
+
+func removeFinalizer(ctx context.Context, kubeClient client.Client, namespacedName types.NamespacedName, finalizer string) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		terraform := &infrav1.Terraform{}
+		if err := kubeClient.Get(ctx, namespacedName, terraform); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		if !controllerutil.ContainsFinalizer(terraform, finalizer) {
+			return nil
+		}
+
+		patch := client.MergeFrom(terraform.DeepCopy())
+		controllerutil.RemoveFinalizer(terraform, finalizer)
+		return kubeClient.Patch(ctx, terraform, patch)
+	})
+}
@@ -0,0 +1,57 @@
+package tfctl
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDeleteTerraformForce(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	dependency := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "network",
+			Namespace:  "default",
+			Finalizers: []string{infrav1.TerraformFinalizer, infrav1.TFDependencyOfPrefix + "hello-world"},
+		},
+	}
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "hello-world",
+			Namespace:  "default",
+			Finalizers: []string{infrav1.TerraformFinalizer},
+		},
+		Spec: infrav1.TerraformSpec{
+			DependsOn: []meta.NamespacedObjectReference{{Name: "network"}},
+		},
+	}
+	c := &CLI{
+		client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(dependency, terraform).Build(),
+		namespace: "default",
+	}
+
+	var out bytes.Buffer
+	g.Expect(c.DeleteTerraform(&out, "hello-world", true, false)).To(MatchError(ContainSubstring("--keep-state")))
+	g.Expect(c.DeleteTerraform(&out, "hello-world", false, true)).ToNot(Succeed())
+
+	g.Expect(c.DeleteTerraform(&out, "hello-world", true, true)).To(Succeed())
+	err := c.client.Get(ctx, types.NamespacedName{Namespace: "default", Name: "hello-world"}, &infrav1.Terraform{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+	g.Expect(c.client.Get(ctx, types.NamespacedName{Namespace: "default", Name: "network"}, dependency)).To(Succeed())
+	g.Expect(dependency.Finalizers).To(Equal([]string{infrav1.TerraformFinalizer}))
+}
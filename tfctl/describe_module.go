@@ -0,0 +1,67 @@
+package tfctl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DescribeModule prints the description, the inputs and the outputs of the
+// root module of the terraform resource, as documented in its status.
+func (c *CLI) DescribeModule(out io.Writer, resource string) error {
+	key := types.NamespacedName{
+		Name:      resource,
+		Namespace: c.namespace,
+	}
+
+	terraform := &infrav1.Terraform{}
+	if err := c.client.Get(context.TODO(), key, terraform); err != nil {
+		return err
+	}
+
+	module := terraform.Status.Module
+	if module == nil {
+		fmt.Fprintf(out, " Terraform resource %s/%s has no module documentation yet\n", c.namespace, resource)
+		return nil
+	}
+
+	fmt.Fprintf(out, "Module of %s/%s at revision %s\n", c.namespace, resource, module.Revision)
+	if module.Description != "" {
+		fmt.Fprintf(out, "\n%s\n", module.Description)
+	}
+
+	fmt.Fprintln(out, "\nInputs:")
+	inputs := newTablePrinter(out, []string{"Name", "Type", "Default", "Required", "Sensitive", "Description"})
+	for _, input := range module.Inputs {
+		defaultValue := ""
+		if input.Default != nil {
+			defaultValue = *input.Default
+		}
+		inputs.Append([]string{
+			input.Name,
+			input.Type,
+			defaultValue,
+			strconv.FormatBool(input.Required),
+			strconv.FormatBool(input.Sensitive),
+			input.Description,
+		})
+	}
+	inputs.Render()
+
+	fmt.Fprintln(out, "\nOutputs:")
+	outputs := newTablePrinter(out, []string{"Name", "Sensitive", "Description"})
+	for _, output := range module.Outputs {
+		outputs.Append([]string{
+			output.Name,
+			strconv.FormatBool(output.Sensitive),
+			output.Description,
+		})
+	}
+	outputs.Render()
+
+	return nil
+}
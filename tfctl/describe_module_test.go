@@ -0,0 +1,54 @@
+package tfctl
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDescribeModule(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	azsDefault := `["eu-west-1a"]`
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "network", Namespace: "default"},
+		Status: infrav1.TerraformStatus{
+			Module: &infrav1.ModuleStatus{
+				Revision:    "main@sha1:abc",
+				Description: "Creates the VPC.",
+				Inputs: []infrav1.ModuleInput{
+					{Name: "azs", Type: "list(string)", Default: &azsDefault},
+					{Name: "cidr", Type: "string", Required: true, Description: "CIDR block of the VPC"},
+				},
+				Outputs: []infrav1.ModuleOutput{{Name: "vpc_id", Description: "ID of the VPC"}},
+			},
+		},
+	}
+	undocumented := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "default"},
+	}
+
+	c := &CLI{
+		client:    fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(terraform, undocumented).Build(),
+		namespace: "default",
+	}
+
+	var out bytes.Buffer
+	g.Expect(c.DescribeModule(&out, "network")).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring("Module of default/network at revision main@sha1:abc\n\nCreates the VPC.\n"))
+	g.Expect(out.String()).To(MatchRegexp(`cidr\s+string\s+true\s+false\s+CIDR block of the VPC`))
+	g.Expect(out.String()).To(MatchRegexp(`azs\s+list\(string\)\s+\["eu-west-1a"\]\s+false`))
+	g.Expect(out.String()).To(MatchRegexp(`vpc_id\s+false\s+ID of the VPC`))
+
+	out.Reset()
+	g.Expect(c.DescribeModule(&out, "new")).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring("has no module documentation yet"))
+}
@@ -0,0 +1,538 @@
+package tfctl
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	controllerDeploymentName   = "tf-controller"
+	plannerDeploymentName      = "tf-controller-planner"
+	defaultPlannerConfigMap    = "default/branch-based-planner"
+	namespacePolicyWebhookName = "mterraform.infra.contrib.fluxcd.io"
+	defaultRunnerSAName        = "tf-runner"
+	// certExpiryWarning is how long before its expiry a runner CA is
+	// reported. The controller rotates it 2 hours ahead by default.
+	certExpiryWarning = time.Hour
+)
+
+// githubAPIURL is the API the branch planner uses.
+var githubAPIURL = "https://api.github.com"
+
+// doctorCRDs are the CRDs of tf-controller, with the version tfctl uses.
+var doctorCRDs = []string{
+	"terraforms.infra.contrib.fluxcd.io",
+	"terraformtemplates.infra.contrib.fluxcd.io",
+	"terraformnamespacepolicies.infra.contrib.fluxcd.io",
+	"terraformapprovalqueues.infra.contrib.fluxcd.io",
+	"terraformcontrollerconfigs.infra.contrib.fluxcd.io",
+}
+
+// doctorPermission is a permission the service account of the controller
+// must have.
+type doctorPermission struct {
+	group, resource, subresource, verb string
+}
+
+var controllerPermissions = []doctorPermission{
+	{infrav1.GroupVersion.Group, "terraforms", "", "list"},
+	{infrav1.GroupVersion.Group, "terraforms", "", "watch"},
+	{infrav1.GroupVersion.Group, "terraforms", "", "patch"},
+	{infrav1.GroupVersion.Group, "terraforms", "status", "patch"},
+	{"source.toolkit.fluxcd.io", "gitrepositories", "", "list"},
+	{"source.toolkit.fluxcd.io", "ocirepositories", "", "list"},
+	{"source.toolkit.fluxcd.io", "buckets", "", "list"},
+	{"", "pods", "", "create"},
+	{"", "pods", "", "delete"},
+	{"", "secrets", "", "create"},
+	{"", "events", "", "create"},
+	{"coordination.k8s.io", "leases", "", "get"},
+}
+
+var runnerPermissions = []doctorPermission{
+	{"", "secrets", "", "create"},
+	{"", "secrets", "", "get"},
+	{"coordination.k8s.io", "leases", "", "create"},
+}
+
+// doctor records the results of the checks.
+type doctor struct {
+	out      io.Writer
+	failures int
+	warnings int
+}
+
+func (d *doctor) pass(format string, args ...interface{}) {
+	fmt.Fprintf(d.out, " ✔ %s\n", fmt.Sprintf(format, args...))
+}
+
+func (d *doctor) warn(hint, format string, args ...interface{}) {
+	d.warnings++
+	fmt.Fprintf(d.out, " ! %s\n", fmt.Sprintf(format, args...))
+	if hint != "" {
+		fmt.Fprintf(d.out, "   hint: %s\n", hint)
+	}
+}
+
+func (d *doctor) fail(hint, format string, args ...interface{}) {
+	d.failures++
+	fmt.Fprintf(d.out, " ✗ %s\n", fmt.Sprintf(format, args...))
+	if hint != "" {
+		fmt.Fprintf(d.out, "   hint: %s\n", hint)
+	}
+}
+
+// Doctor inspects the installation of tf-controller in the namespace, and
+// reports the misconfigurations it finds with hints to fix them.
+func (c *CLI) Doctor(out io.Writer) error {
+	ctx := context.TODO()
+	d := &doctor{out: out}
+
+	fmt.Fprintln(out, "Controller")
+	deployment := c.checkControllerDeployment(ctx, d)
+	c.checkCRDs(ctx, d)
+
+	terraforms := &infrav1.TerraformList{}
+	if err := c.client.List(ctx, terraforms); err != nil {
+		d.warn("", "unable to list the Terraform objects: %s", err)
+	}
+
+	fmt.Fprintln(out, "Certificates")
+	c.checkRunnerCerts(ctx, d, terraforms.Items)
+	c.checkNamespacePolicyWebhook(ctx, d)
+
+	fmt.Fprintln(out, "RBAC")
+	if deployment != nil {
+		serviceAccount := deployment.Spec.Template.Spec.ServiceAccountName
+		if serviceAccount == "" {
+			serviceAccount = "default"
+		}
+		c.checkPermissions(ctx, d, "controller", types.NamespacedName{Namespace: c.namespace, Name: serviceAccount}, "", controllerPermissions)
+	}
+	c.checkRunnerServiceAccounts(ctx, d, terraforms.Items)
+
+	fmt.Fprintln(out, "Branch planner")
+	c.checkPlanner(ctx, d)
+
+	fmt.Fprintf(out, "\n%d problem(s), %d warning(s)\n", d.failures, d.warnings)
+	if d.failures > 0 {
+		return fmt.Errorf("found %d problem(s) in the installation", d.failures)
+	}
+	return nil
+}
+
+func (c *CLI) checkControllerDeployment(ctx context.Context, d *doctor) *appsv1.Deployment {
+	deployment := &appsv1.Deployment{}
+	key := types.NamespacedName{Namespace: c.namespace, Name: controllerDeploymentName}
+	if err := c.client.Get(ctx, key, deployment); err != nil {
+		if apierrors.IsNotFound(err) {
+			d.fail("install it with tfctl install, or set --namespace to its namespace",
+				"deployment %s not found", key)
+		} else {
+			d.fail("", "unable to get deployment %s: %s", key, err)
+		}
+		return nil
+	}
+
+	if deployment.Status.AvailableReplicas == 0 {
+		d.fail(fmt.Sprintf("check the pods and the logs of the controller: kubectl -n %s logs deploy/%s", c.namespace, controllerDeploymentName),
+			"deployment %s has no available replica", key)
+	} else {
+		d.pass("deployment %s is available", key)
+	}
+	return deployment
+}
+
+func (c *CLI) checkCRDs(ctx context.Context, d *doctor) {
+	version := infrav1.GroupVersion.Version
+	for _, name := range doctorCRDs {
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		if err := c.client.Get(ctx, types.NamespacedName{Name: name}, crd); err != nil {
+			if apierrors.IsNotFound(err) {
+				d.fail("install the CRDs of the release of the controller", "CRD %s not found", name)
+			} else {
+				d.fail("", "unable to get CRD %s: %s", name, err)
+			}
+			continue
+		}
+
+		served, storage := false, false
+		for _, v := range crd.Spec.Versions {
+			if v.Name == version {
+				served, storage = v.Served, v.Storage
+			}
+		}
+		switch {
+		case !served:
+			d.fail("upgrade the CRDs to the release of the controller", "CRD %s does not serve %s", name, version)
+		case !storage:
+			d.warn("upgrade the CRDs to the release of the controller", "CRD %s does not store %s", name, version)
+		default:
+			d.pass("CRD %s serves and stores %s", name, version)
+		}
+	}
+}
+
+// checkRunnerCerts checks the CA the controller generates for the mTLS
+// connections to the runners, in the namespaces of the Terraform objects.
+func (c *CLI) checkRunnerCerts(ctx context.Context, d *doctor, terraforms []infrav1.Terraform) {
+	hint := fmt.Sprintf("the controller rotates the runner CA itself, check its logs: kubectl -n %s logs deploy/%s", c.namespace, controllerDeploymentName)
+	for _, namespace := range terraformNamespaces(terraforms) {
+		secrets := &corev1.SecretList{}
+		if err := c.client.List(ctx, secrets, client.InNamespace(namespace), client.MatchingLabels{infrav1.RunnerLabel: "true"}); err != nil {
+			d.fail("", "unable to list the secrets of %s: %s", namespace, err)
+			continue
+		}
+
+		var latest time.Time
+		for _, secret := range secrets.Items {
+			if !strings.HasPrefix(secret.Name, infrav1.RunnerTLSSecretName+"-") {
+				continue
+			}
+			notAfter, err := certNotAfter(secret.Data["ca.crt"])
+			if err != nil {
+				d.warn("delete the secret for the controller to generate it again", "secret %s/%s: %s", namespace, secret.Name, err)
+				continue
+			}
+			if notAfter.After(latest) {
+				latest = notAfter
+			}
+		}
+
+		switch {
+		case latest.IsZero():
+			// the certificates are generated when a runner starts
+			d.warn(hint, "no runner certificate found in %s", namespace)
+		case time.Now().After(latest):
+			d.fail(hint, "the runner CA of %s expired at %s", namespace, latest.Format(time.RFC3339))
+		case time.Until(latest) < certExpiryWarning:
+			d.warn(hint, "the runner CA of %s expires at %s", namespace, latest.Format(time.RFC3339))
+		default:
+			d.pass("the runner CA of %s is valid until %s", namespace, latest.Format(time.RFC3339))
+		}
+	}
+}
+
+// terraformNamespaces returns the sorted namespaces of the Terraform objects.
+func terraformNamespaces(terraforms []infrav1.Terraform) []string {
+	seen := map[string]bool{}
+	var namespaces []string
+	for _, terraform := range terraforms {
+		if !seen[terraform.Namespace] {
+			seen[terraform.Namespace] = true
+			namespaces = append(namespaces, terraform.Namespace)
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// checkNamespacePolicyWebhook checks the webhook of the namespace policies,
+// when they are enabled.
+func (c *CLI) checkNamespacePolicyWebhook(ctx context.Context, d *doctor) {
+	webhooks := &admissionregistrationv1.MutatingWebhookConfigurationList{}
+	if err := c.client.List(ctx, webhooks); err != nil {
+		d.warn("", "unable to list the mutating webhooks: %s", err)
+		return
+	}
+
+	found := false
+	for _, configuration := range webhooks.Items {
+		for _, webhook := range configuration.Webhooks {
+			if webhook.Name != namespacePolicyWebhookName {
+				continue
+			}
+			found = true
+
+			if len(webhook.ClientConfig.CABundle) == 0 {
+				d.fail("check that cert-manager is installed, and injects the CA of the webhook certificate",
+					"webhook %s of %s has no CA bundle", webhook.Name, configuration.Name)
+				continue
+			}
+
+			service := webhook.ClientConfig.Service
+			if service == nil {
+				d.pass("webhook %s of %s has a CA bundle", webhook.Name, configuration.Name)
+				continue
+			}
+			svc := &corev1.Service{}
+			if err := c.client.Get(ctx, types.NamespacedName{Namespace: service.Namespace, Name: service.Name}, svc); err != nil {
+				d.fail("enable namespacePolicies in the values of the chart again to restore it",
+					"service %s/%s of webhook %s: %s", service.Namespace, service.Name, webhook.Name, err)
+				continue
+			}
+
+			secretKey := types.NamespacedName{Namespace: service.Namespace, Name: service.Name + "-tls"}
+			secret := &corev1.Secret{}
+			if err := c.client.Get(ctx, secretKey, secret); err != nil {
+				d.fail("check the Certificate of the webhook: kubectl -n "+service.Namespace+" describe certificate "+service.Name,
+					"certificate secret %s of webhook %s: %s", secretKey, webhook.Name, err)
+				continue
+			}
+			notAfter, err := certNotAfter(secret.Data["tls.crt"])
+			switch {
+			case err != nil:
+				d.fail("check the Certificate of the webhook", "certificate secret %s: %s", secretKey, err)
+			case time.Now().After(notAfter):
+				d.fail("check that cert-manager renews the Certificate of the webhook", "the webhook certificate %s expired at %s", secretKey, notAfter.Format(time.RFC3339))
+			default:
+				d.pass("webhook %s has a certificate valid until %s", webhook.Name, notAfter.Format(time.RFC3339))
+			}
+		}
+	}
+
+	if !found {
+		d.pass("namespace policies webhook not installed")
+	}
+}
+
+// checkRunnerServiceAccounts checks the service accounts of the runners of
+// the Terraform objects.
+func (c *CLI) checkRunnerServiceAccounts(ctx context.Context, d *doctor, terraforms []infrav1.Terraform) {
+	serviceAccounts := map[types.NamespacedName]bool{}
+	for _, terraform := range terraforms {
+		name := terraform.Spec.ServiceAccountName
+		if name == "" {
+			name = defaultRunnerSAName
+		}
+		serviceAccounts[types.NamespacedName{Namespace: terraform.Namespace, Name: name}] = true
+	}
+
+	keys := make([]types.NamespacedName, 0, len(serviceAccounts))
+	for key := range serviceAccounts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+
+	for _, key := range keys {
+		if err := c.client.Get(ctx, key, &corev1.ServiceAccount{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				d.fail("add the namespace to runner.allowedNamespaces in the values of the chart",
+					"runner service account %s not found", key)
+			} else {
+				d.fail("", "unable to get runner service account %s: %s", key, err)
+			}
+			continue
+		}
+		c.checkPermissions(ctx, d, "runner", key, key.Namespace, runnerPermissions)
+	}
+}
+
+// checkPermissions checks the permissions of the service account in the
+// namespace, or in the cluster when the namespace is empty.
+func (c *CLI) checkPermissions(ctx context.Context, d *doctor, component string, serviceAccount types.NamespacedName, namespace string, permissions []doctorPermission) {
+	subject := fmt.Sprintf("%s service account %s", component, serviceAccount)
+	var denied []string
+	for _, permission := range permissions {
+		review := &authorizationv1.SubjectAccessReview{
+			Spec: authorizationv1.SubjectAccessReviewSpec{
+				User:   fmt.Sprintf("system:serviceaccount:%s:%s", serviceAccount.Namespace, serviceAccount.Name),
+				Groups: []string{"system:serviceaccounts", "system:serviceaccounts:" + serviceAccount.Namespace},
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace:   namespace,
+					Group:       permission.group,
+					Resource:    permission.resource,
+					Subresource: permission.subresource,
+					Verb:        permission.verb,
+				},
+			},
+		}
+		if err := c.client.Create(ctx, review); err != nil {
+			d.warn("run tfctl doctor as a user allowed to create SubjectAccessReviews",
+				"unable to check the permissions of the %s: %s", subject, err)
+			return
+		}
+		if !review.Status.Allowed {
+			resource := permission.resource
+			if permission.subresource != "" {
+				resource += "/" + permission.subresource
+			}
+			if permission.group != "" {
+				resource += "." + permission.group
+			}
+			denied = append(denied, permission.verb+" "+resource)
+		}
+	}
+
+	if len(denied) > 0 {
+		d.fail("check the ClusterRoleBindings of the service account, the chart creates them with rbac.create",
+			"the %s cannot %s", subject, strings.Join(denied, ", "))
+		return
+	}
+	d.pass("the %s has the permissions it needs", subject)
+}
+
+// checkPlanner checks the ConfigMap of the branch planner, its token, and
+// the connectivity to the API of the Git provider.
+func (c *CLI) checkPlanner(ctx context.Context, d *doctor) {
+	deployment := &appsv1.Deployment{}
+	if err := c.client.Get(ctx, types.NamespacedName{Namespace: c.namespace, Name: plannerDeploymentName}, deployment); err != nil {
+		if apierrors.IsNotFound(err) {
+			d.pass("branch planner not installed")
+		} else {
+			d.warn("", "unable to get the branch planner deployment: %s", err)
+		}
+		return
+	}
+
+	configMapKey := plannerConfigMapKey(deployment, c.namespace)
+	configMap := &corev1.ConfigMap{}
+	if err := c.client.Get(ctx, configMapKey, configMap); err != nil {
+		d.fail("create the ConfigMap of the branch planner, see docs/branch_based_planner",
+			"branch planner ConfigMap %s: %s", configMapKey, err)
+		return
+	}
+
+	problems, secretKey, resources := validatePlannerConfig(configMap.Data)
+	for _, problem := range problems {
+		d.fail("fix the ConfigMap of the branch planner, see docs/branch_based_planner", "branch planner ConfigMap %s: %s", configMapKey, problem)
+	}
+	if len(problems) == 0 {
+		d.pass("branch planner ConfigMap %s is valid", configMapKey)
+	}
+	if len(resources) == 0 {
+		d.warn("list the Terraform objects to plan the pull requests of in resources", "branch planner ConfigMap %s lists no Terraform object", configMapKey)
+	}
+
+	for _, resource := range resources {
+		if err := c.client.Get(ctx, resource, &infrav1.Terraform{}); err != nil {
+			d.warn("remove it from the resources of the branch planner ConfigMap", "branch planner resource %s: %s", resource, err)
+		}
+	}
+
+	if secretKey.Name == "" {
+		return
+	}
+	secret := &corev1.Secret{}
+	if err := c.client.Get(ctx, secretKey, secret); err != nil {
+		d.fail("create the secret with the API token of the Git provider in its token key", "branch planner secret %s: %s", secretKey, err)
+		return
+	}
+	token := string(secret.Data["token"])
+	if token == "" {
+		d.fail("set the API token of the Git provider in the token key of the secret", "branch planner secret %s has no token", secretKey)
+		return
+	}
+
+	if err := checkGitHubToken(ctx, token); err != nil {
+		d.fail("check the token of the branch planner secret, and that the GitHub API is reachable", "GitHub API: %s", err)
+		return
+	}
+	d.pass("the token of the branch planner is accepted by the GitHub API")
+}
+
+// plannerConfigMapKey returns the ConfigMap of the --polling-configmap flag
+// of the planner, or its default.
+func plannerConfigMapKey(deployment *appsv1.Deployment, namespace string) types.NamespacedName {
+	ref := defaultPlannerConfigMap
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		for i, arg := range container.Args {
+			switch {
+			case strings.HasPrefix(arg, "--polling-configmap="):
+				ref = strings.TrimPrefix(arg, "--polling-configmap=")
+			case arg == "--polling-configmap" && i+1 < len(container.Args):
+				ref = container.Args[i+1]
+			}
+		}
+	}
+
+	if parts := strings.SplitN(ref, "/", 2); len(parts) == 2 {
+		return types.NamespacedName{Namespace: parts[0], Name: parts[1]}
+	}
+	return types.NamespacedName{Namespace: namespace, Name: ref}
+}
+
+// validatePlannerConfig validates the data of the ConfigMap of the branch
+// planner as the planner reads it, and returns its secret and resources.
+func validatePlannerConfig(data map[string]string) ([]string, types.NamespacedName, []types.NamespacedName) {
+	var problems []string
+
+	secretKey := types.NamespacedName{Namespace: data["secretNamespace"], Name: data["secretName"]}
+	if secretKey.Namespace == "" {
+		secretKey.Namespace = "default"
+	}
+	if secretKey.Name == "" {
+		problems = append(problems, "secretName is required")
+	}
+
+	switch policy := data["forkPolicy"]; policy {
+	case "", "skip", "label":
+	case "restricted":
+		if data["forkServiceAccountName"] == "" {
+			problems = append(problems, `forkServiceAccountName is required with the "restricted" fork policy`)
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("unknown fork policy: %q", policy))
+	}
+
+	for _, key := range []string{"maxConcurrentPlansPerRepository", "maxConcurrentPlansPerNamespace"} {
+		if value := data[key]; value != "" {
+			if limit, err := strconv.Atoi(value); err != nil || limit < 0 {
+				problems = append(problems, fmt.Sprintf("%s must be a non-negative integer: %q", key, value))
+			}
+		}
+	}
+	if value := data["workspacePerBranch"]; value != "" {
+		if _, err := strconv.ParseBool(value); err != nil {
+			problems = append(problems, fmt.Sprintf("workspacePerBranch must be a boolean: %q", value))
+		}
+	}
+
+	var resources []types.NamespacedName
+	if err := yaml.Unmarshal([]byte(data["resources"]), &resources); err != nil {
+		problems = append(problems, fmt.Sprintf("unable to parse resources: %s", err))
+	}
+
+	return problems, secretKey, resources
+}
+
+func checkGitHubToken(ctx context.Context, token string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, githubAPIURL+"/rate_limit", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+func certNotAfter(data []byte) (time.Time, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return time.Time{}, fmt.Errorf("no PEM certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return cert.NotAfter, nil
+}
@@ -0,0 +1,192 @@
+package tfctl
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	appsv1 "k8s.io/api/apps/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func certPEM(t *testing.T, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    notAfter.Add(-24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestValidatePlannerConfig(t *testing.T) {
+	g := NewWithT(t)
+
+	problems, secretKey, resources := validatePlannerConfig(map[string]string{
+		"secretName": "github-token",
+		"resources":  "- namespace: default\n  name: helloworld\n",
+	})
+	g.Expect(problems).To(BeEmpty())
+	g.Expect(secretKey).To(Equal(types.NamespacedName{Namespace: "default", Name: "github-token"}))
+	g.Expect(resources).To(Equal([]types.NamespacedName{{Namespace: "default", Name: "helloworld"}}))
+
+	problems, _, _ = validatePlannerConfig(map[string]string{
+		"forkPolicy":                      "restricted",
+		"maxConcurrentPlansPerRepository": "-1",
+		"workspacePerBranch":              "yes",
+	})
+	g.Expect(problems).To(ConsistOf(
+		"secretName is required",
+		`forkServiceAccountName is required with the "restricted" fork policy`,
+		`maxConcurrentPlansPerRepository must be a non-negative integer: "-1"`,
+		`workspacePerBranch must be a boolean: "yes"`,
+	))
+}
+
+func TestPlannerConfigMapKey(t *testing.T) {
+	g := NewWithT(t)
+
+	deployment := &appsv1.Deployment{}
+	g.Expect(plannerConfigMapKey(deployment, "flux-system")).To(Equal(types.NamespacedName{Namespace: "default", Name: "branch-based-planner"}))
+
+	deployment.Spec.Template.Spec.Containers = []corev1.Container{
+		{Args: []string{"--polling-interval=30s", "--polling-configmap", "flux-system/planner"}},
+	}
+	g.Expect(plannerConfigMapKey(deployment, "flux-system")).To(Equal(types.NamespacedName{Namespace: "flux-system", Name: "planner"}))
+
+	deployment.Spec.Template.Spec.Containers[0].Args = []string{"--polling-configmap=planner"}
+	g.Expect(plannerConfigMapKey(deployment, "flux-system")).To(Equal(types.NamespacedName{Namespace: "flux-system", Name: "planner"}))
+}
+
+func TestDoctor(t *testing.T) {
+	g := NewWithT(t)
+
+	github := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer s3cr3t" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer github.Close()
+	defer func(url string) { githubAPIURL = url }(githubAPIURL)
+	githubAPIURL = github.URL
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	g.Expect(apiextensionsv1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	objects := []client.Object{
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "tf-controller", Namespace: "flux-system"},
+			Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				ServiceAccountName: "tf-controller",
+			}}},
+			Status: appsv1.DeploymentStatus{AvailableReplicas: 1},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "tf-controller-planner", Namespace: "flux-system"},
+			Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Args: []string{"--polling-configmap=flux-system/planner"}}},
+			}}},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "planner", Namespace: "flux-system"},
+			Data: map[string]string{
+				"secretNamespace": "flux-system",
+				"secretName":      "github-token",
+				"resources":       "- namespace: dev\n  name: helloworld\n",
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "github-token", Namespace: "flux-system"},
+			Data:       map[string][]byte{"token": []byte("s3cr3t")},
+		},
+		&infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "dev"}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "tf-runner", Namespace: "dev"}},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-%d", infrav1.RunnerTLSSecretName, time.Now().Add(-time.Hour).Unix()),
+				Namespace: "dev",
+				Labels:    map[string]string{infrav1.RunnerLabel: "true"},
+			},
+			Data: map[string][]byte{"ca.crt": certPEM(t, time.Now().Add(-time.Hour))},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-%d", infrav1.RunnerTLSSecretName, time.Now().Add(96*time.Hour).Unix()),
+				Namespace: "dev",
+				Labels:    map[string]string{infrav1.RunnerLabel: "true"},
+			},
+			Data: map[string][]byte{"ca.crt": certPEM(t, time.Now().Add(96*time.Hour))},
+		},
+	}
+	for _, name := range doctorCRDs {
+		objects = append(objects, &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+					{Name: "v1alpha1", Served: true},
+					{Name: "v1alpha2", Served: true, Storage: true},
+				},
+			},
+		})
+	}
+
+	// the runner service account of dev cannot create leases
+	interceptors := interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if review, ok := obj.(*authorizationv1.SubjectAccessReview); ok {
+				attributes := review.Spec.ResourceAttributes
+				review.Status.Allowed = !(review.Spec.User == "system:serviceaccount:dev:tf-runner" && attributes.Resource == "leases")
+				return nil
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}
+
+	c := &CLI{
+		client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).WithInterceptorFuncs(interceptors).Build(),
+		namespace: "flux-system",
+	}
+
+	var out bytes.Buffer
+	err := c.Doctor(&out)
+	g.Expect(err).To(MatchError("found 1 problem(s) in the installation"))
+	g.Expect(out.String()).To(ContainSubstring("✔ deployment flux-system/tf-controller is available"))
+	g.Expect(out.String()).To(ContainSubstring("✔ CRD terraforms.infra.contrib.fluxcd.io serves and stores v1alpha2"))
+	g.Expect(out.String()).To(ContainSubstring("✔ the runner CA of dev is valid until"))
+	g.Expect(out.String()).To(ContainSubstring("✔ the controller service account flux-system/tf-controller has the permissions it needs"))
+	g.Expect(out.String()).To(ContainSubstring("✗ the runner service account dev/tf-runner cannot create leases.coordination.k8s.io"))
+	g.Expect(out.String()).To(ContainSubstring("✔ branch planner ConfigMap flux-system/planner is valid"))
+	g.Expect(out.String()).To(ContainSubstring("✔ the token of the branch planner is accepted by the GitHub API"))
+}
@@ -0,0 +1,397 @@
+package tfctl
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	redactedValue = "**REDACTED**"
+
+	// minSecretValueLength is the length under which the values of the
+	// Secrets are not scrubbed, as they would match too much of the bundle.
+	minSecretValueLength = 4
+
+	maxBundleEvents = 100
+)
+
+// Export prints the manifest of the given Terraform resource, without its
+// status. With bundle, it writes a support bundle to the output file instead,
+// "-" being the standard output.
+func (c *CLI) Export(out io.Writer, resource string, bundle bool, output string) error {
+	key := types.NamespacedName{
+		Name:      resource,
+		Namespace: c.namespace,
+	}
+	terraform := &infrav1.Terraform{}
+	if err := c.client.Get(context.TODO(), key, terraform); err != nil {
+		return fmt.Errorf("resource %s not found", resource)
+	}
+
+	if !bundle {
+		terraform.Status = infrav1.TerraformStatus{}
+		data, err := terraformYAML(terraform)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		return err
+	}
+
+	if output == "" {
+		output = fmt.Sprintf("%s-%s-bundle.tar.gz", terraform.Namespace, terraform.Name)
+	}
+	w := out
+	if output != "-" {
+		f, err := os.Create(output)
+		if err != nil {
+			return fmt.Errorf("failed to create bundle: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := c.writeBundle(context.TODO(), w, terraform); err != nil {
+		return err
+	}
+	if output != "-" {
+		fmt.Fprintf(out, " Support bundle of %s/%s written to %s\n", terraform.Namespace, terraform.Name, output)
+	}
+	return nil
+}
+
+// writeBundle writes a gzipped tarball with the Terraform object, its
+// source, conditions, recent events, plan and runner pod, for support
+// tickets. The data of the Secrets are never added, and their values are
+// scrubbed from every file of the bundle.
+func (c *CLI) writeBundle(ctx context.Context, w io.Writer, terraform *infrav1.Terraform) error {
+	scrub := newScrubber(c.secretValues(ctx, terraform))
+	files := c.bundleFiles(ctx, terraform)
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	dir := terraform.Namespace + "-" + terraform.Name
+	now := time.Now()
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		data := []byte(scrub.Replace(files[name]))
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    dir + "/" + name,
+			Mode:    0o644,
+			Size:    int64(len(data)),
+			ModTime: now,
+		}); err != nil {
+			return fmt.Errorf("failed to write bundle: %w", err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return fmt.Errorf("failed to write bundle: %w", err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+	return gw.Close()
+}
+
+// bundleFiles collects the content of the bundle. The objects which cannot
+// be read are reported in their file, so that a partial bundle is still
+// written.
+func (c *CLI) bundleFiles(ctx context.Context, terraform *infrav1.Terraform) map[string]string {
+	files := map[string]string{}
+
+	if data, err := terraformYAML(redactVars(terraform)); err != nil {
+		files["terraform.yaml"] = err.Error()
+	} else {
+		files["terraform.yaml"] = string(data)
+	}
+	files["conditions.txt"] = conditionsTable(terraform)
+	files["source.yaml"] = c.sourceYAML(ctx, terraform)
+	files["plan.txt"] = c.planSummary(ctx, terraform)
+
+	podName := terraform.Name + "-tf-runner"
+	files["runner-pod.yaml"] = c.runnerPodYAML(ctx, terraform.Namespace, podName)
+	files["events.txt"] = c.eventsTable(ctx, terraform.Namespace, terraform.Name, podName)
+
+	return files
+}
+
+func terraformYAML(terraform *infrav1.Terraform) ([]byte, error) {
+	terraform = terraform.DeepCopy()
+	terraform.SetManagedFields(nil)
+	terraform.SetGroupVersionKind(infrav1.GroupVersion.WithKind(infrav1.TerraformKind))
+	return yaml.Marshal(terraform)
+}
+
+// redactVars removes the inline values of the variables, which may be
+// sensitive without coming from a Secret.
+func redactVars(terraform *infrav1.Terraform) *infrav1.Terraform {
+	terraform = terraform.DeepCopy()
+	for i := range terraform.Spec.Vars {
+		if terraform.Spec.Vars[i].Value != nil {
+			terraform.Spec.Vars[i].Value.Raw = []byte(fmt.Sprintf("%q", redactedValue))
+		}
+	}
+	if terraform.Spec.Values != nil {
+		terraform.Spec.Values.Raw = []byte(fmt.Sprintf("%q", redactedValue))
+	}
+	return terraform
+}
+
+func conditionsTable(terraform *infrav1.Terraform) string {
+	var b strings.Builder
+	tw := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "TYPE\tSTATUS\tREASON\tLAST TRANSITION\tMESSAGE")
+	for _, condition := range terraform.Status.Conditions {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", condition.Type, condition.Status, condition.Reason,
+			condition.LastTransitionTime.Format(time.RFC3339), condition.Message)
+	}
+	tw.Flush()
+	return b.String()
+}
+
+func (c *CLI) sourceYAML(ctx context.Context, terraform *infrav1.Terraform) string {
+	source, err := sourceObject(terraform)
+	if err != nil {
+		return fmt.Sprintf("# %s\n", err)
+	}
+	if err := c.client.Get(ctx, client.ObjectKeyFromObject(source), source); err != nil {
+		return fmt.Sprintf("# failed to get %s %s/%s: %s\n", source.GetKind(), source.GetNamespace(), source.GetName(), err)
+	}
+	source.SetManagedFields(nil)
+
+	data, err := yaml.Marshal(source.Object)
+	if err != nil {
+		return err.Error()
+	}
+	return string(data)
+}
+
+// planSummary describes the pending plan. The human readable plan masks the
+// sensitive values, while only the actions of the resource changes are kept
+// from the JSON plan.
+func (c *CLI) planSummary(ctx context.Context, terraform *infrav1.Terraform) string {
+	var b strings.Builder
+	plan := terraform.Status.Plan
+	fmt.Fprintf(&b, "Pending: %s\n", plan.Pending)
+	fmt.Fprintf(&b, "Last applied: %s\n", plan.LastApplied)
+	fmt.Fprintf(&b, "Destroy plan: %t\n", plan.IsDestroyPlan)
+	fmt.Fprintf(&b, "Drift detection plan: %t\n", plan.IsDriftDetectionPlan)
+	if plan.Changes != nil {
+		fmt.Fprintf(&b, "Changes: %d to add, %d to change, %d to destroy\n", plan.Changes.Add, plan.Changes.Change, plan.Changes.Destroy)
+	}
+
+	prefix := fmt.Sprintf("tfplan-%s-%s", terraform.WorkspaceName(), terraform.Name)
+	switch terraform.Spec.StoreReadablePlan {
+	case "human":
+		var tfplanCM corev1.ConfigMap
+		if err := c.client.Get(ctx, types.NamespacedName{Namespace: terraform.Namespace, Name: prefix}, &tfplanCM); err != nil {
+			fmt.Fprintf(&b, "\n# failed to get the readable plan: %s\n", err)
+		} else {
+			fmt.Fprintf(&b, "\n%s\n", tfplanCM.Data["tfplan"])
+		}
+	case "json":
+		var planSecret corev1.Secret
+		if err := c.client.Get(ctx, types.NamespacedName{Namespace: terraform.Namespace, Name: prefix + ".json"}, &planSecret); err != nil {
+			fmt.Fprintf(&b, "\n# failed to get the readable plan: %s\n", err)
+		} else if changes, err := resourceChanges(planSecret.Data["tfplan"]); err != nil {
+			fmt.Fprintf(&b, "\n# failed to decode the readable plan: %s\n", err)
+		} else {
+			fmt.Fprintf(&b, "\nResource changes:\n%s", changes)
+		}
+	}
+
+	return b.String()
+}
+
+func resourceChanges(encodedPlan []byte) (string, error) {
+	data, err := gzipDecode(encodedPlan)
+	if err != nil {
+		return "", err
+	}
+
+	var plan struct {
+		ResourceChanges []struct {
+			Address string `json:"address"`
+			Change  struct {
+				Actions []string `json:"actions"`
+			} `json:"change"`
+		} `json:"resource_changes"`
+	}
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for _, rc := range plan.ResourceChanges {
+		fmt.Fprintf(&b, "  %s: %s\n", rc.Address, strings.Join(rc.Change.Actions, ", "))
+	}
+	return b.String(), nil
+}
+
+// runnerPodYAML describes the runner pod, without the values of its
+// environment variables.
+func (c *CLI) runnerPodYAML(ctx context.Context, namespace, name string) string {
+	var pod corev1.Pod
+	if err := c.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &pod); apierrors.IsNotFound(err) {
+		return "# the runner pod is not running\n"
+	} else if err != nil {
+		return fmt.Sprintf("# failed to get the runner pod: %s\n", err)
+	}
+
+	pod.SetManagedFields(nil)
+	pod.APIVersion, pod.Kind = "v1", "Pod"
+	for _, containers := range [][]corev1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for i := range containers {
+			for j := range containers[i].Env {
+				if containers[i].Env[j].Value != "" {
+					containers[i].Env[j].Value = redactedValue
+				}
+			}
+		}
+	}
+
+	data, err := yaml.Marshal(&pod)
+	if err != nil {
+		return err.Error()
+	}
+	return string(data)
+}
+
+// eventsTable lists the most recent events of the given objects in the
+// namespace, oldest first.
+func (c *CLI) eventsTable(ctx context.Context, namespace string, names ...string) string {
+	var events corev1.EventList
+	if err := c.client.List(ctx, &events, client.InNamespace(namespace)); err != nil {
+		return fmt.Sprintf("# failed to list events: %s\n", err)
+	}
+
+	wanted := map[string]bool{}
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	var items []corev1.Event
+	for _, event := range events.Items {
+		if wanted[event.InvolvedObject.Name] {
+			items = append(items, event)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return eventTime(items[i]).Before(eventTime(items[j]))
+	})
+	if len(items) > maxBundleEvents {
+		items = items[len(items)-maxBundleEvents:]
+	}
+
+	var b strings.Builder
+	tw := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "LAST SEEN\tTYPE\tREASON\tOBJECT\tCOUNT\tMESSAGE")
+	for _, event := range items {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s/%s\t%d\t%s\n", eventTime(event).Format(time.RFC3339), event.Type, event.Reason,
+			event.InvolvedObject.Kind, event.InvolvedObject.Name, event.Count, strings.ReplaceAll(event.Message, "\n", " "))
+	}
+	tw.Flush()
+	return b.String()
+}
+
+func eventTime(event corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+// secretValues returns the values of the Secrets the Terraform object
+// references, and of its outputs Secret, to scrub them from the bundle.
+func (c *CLI) secretValues(ctx context.Context, terraform *infrav1.Terraform) []string {
+	names := map[string]bool{}
+	for _, ref := range terraform.Spec.VarsFrom {
+		if ref.Kind == "Secret" {
+			names[ref.Name] = true
+		}
+	}
+	for _, v := range terraform.Spec.Vars {
+		if v.ValueFrom != nil && v.ValueFrom.SecretKeyRef != nil {
+			names[v.ValueFrom.SecretKeyRef.Name] = true
+		}
+	}
+	for _, ref := range terraform.Spec.BackendConfigsFrom {
+		if ref.Kind == "Secret" {
+			names[ref.Name] = true
+		}
+	}
+	if terraform.Spec.CliConfigSecretRef != nil && terraform.Spec.CliConfigSecretRef.Namespace == "" {
+		names[terraform.Spec.CliConfigSecretRef.Name] = true
+	}
+	if terraform.Spec.GitCredentials != nil {
+		names[terraform.Spec.GitCredentials.SecretRef.Name] = true
+	}
+	if terraform.Spec.WriteOutputsToSecret != nil {
+		names[terraform.Spec.WriteOutputsToSecret.Name] = true
+	}
+
+	var values []string
+	for name := range names {
+		var secret corev1.Secret
+		if err := c.client.Get(ctx, types.NamespacedName{Namespace: terraform.Namespace, Name: name}, &secret); err != nil {
+			continue
+		}
+		for _, value := range secret.Data {
+			values = append(values, string(value))
+		}
+		for _, value := range secret.StringData {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// newScrubber returns a replacer of the secret values, the longest first so
+// that a value containing another one is fully scrubbed.
+func newScrubber(values []string) *strings.Replacer {
+	seen := map[string]bool{}
+	var unique []string
+	for _, value := range values {
+		for _, line := range strings.Split(value, "\n") {
+			line = strings.TrimSpace(line)
+			if len(line) < minSecretValueLength || seen[line] {
+				continue
+			}
+			seen[line] = true
+			unique = append(unique, line)
+		}
+	}
+	sort.Slice(unique, func(i, j int) bool { return len(unique[i]) > len(unique[j]) })
+
+	oldnew := make([]string, 0, 2*len(unique))
+	for _, value := range unique {
+		oldnew = append(oldnew, value, redactedValue)
+	}
+	return strings.NewReplacer(oldnew...)
+}
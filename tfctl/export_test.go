@@ -0,0 +1,123 @@
+package tfctl
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestExportBundle(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello-world", Namespace: "default"},
+		Spec: infrav1.TerraformSpec{
+			SourceRef:         infrav1.CrossNamespaceSourceReference{Kind: "GitRepository", Name: "hello-world"},
+			StoreReadablePlan: "human",
+			VarsFrom:          []infrav1.VarsReference{{Kind: "Secret", Name: "vars"}},
+			Vars:              []infrav1.Variable{{Name: "region", Value: &apiextensionsv1.JSON{Raw: []byte(`"eu-west-1"`)}}},
+		},
+		Status: infrav1.TerraformStatus{
+			Plan: infrav1.PlanStatus{Pending: "plan-main-abcdef"},
+			Conditions: []metav1.Condition{
+				{Type: "Ready", Status: metav1.ConditionUnknown, Reason: "TerraformPlannedWithChanges", Message: "Plan generated"},
+			},
+		},
+	}
+	source := &unstructured.Unstructured{}
+	source.SetAPIVersion("source.toolkit.fluxcd.io/v1")
+	source.SetKind("GitRepository")
+	source.SetNamespace("default")
+	source.SetName("hello-world")
+	g.Expect(unstructured.SetNestedField(source.Object, "https://github.com/org/repo", "spec", "url")).To(Succeed())
+
+	objects := []runtime.Object{
+		terraform,
+		source,
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "vars", Namespace: "default"},
+			Data:       map[string][]byte{"token": []byte("s3cr3t-token")},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "tfplan-default-hello-world", Namespace: "default"},
+			Data:       map[string]string{"tfplan": `+ token = "s3cr3t-token"`},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "hello-world-tf-runner", Namespace: "default"},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "tf-runner", Env: []corev1.EnvVar{{Name: "AWS_SECRET_ACCESS_KEY", Value: "abcd1234"}}}},
+			},
+		},
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "hello-world.1", Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Terraform", Name: "hello-world"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "error",
+			Message:        "apply failed with token s3cr3t-token",
+		},
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "other.1", Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Terraform", Name: "other"},
+			Message:        "not related",
+		},
+	}
+
+	c := &CLI{
+		client:    fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objects...).Build(),
+		namespace: "default",
+	}
+
+	var out bytes.Buffer
+	g.Expect(c.Export(&out, "hello-world", true, "-")).To(Succeed())
+
+	gr, err := gzip.NewReader(&out)
+	g.Expect(err).ToNot(HaveOccurred())
+	tr := tar.NewReader(gr)
+	files := map[string]string{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		g.Expect(err).ToNot(HaveOccurred())
+		data, err := io.ReadAll(tr)
+		g.Expect(err).ToNot(HaveOccurred())
+		files[header.Name] = string(data)
+	}
+
+	g.Expect(files).To(HaveLen(6))
+	for name, content := range files {
+		g.Expect(content).ToNot(ContainSubstring("s3cr3t-token"), name)
+		g.Expect(content).ToNot(ContainSubstring("abcd1234"), name)
+	}
+
+	g.Expect(files["default-hello-world/terraform.yaml"]).To(ContainSubstring("plan-main-abcdef"))
+	g.Expect(files["default-hello-world/terraform.yaml"]).ToNot(ContainSubstring("eu-west-1"))
+	g.Expect(files["default-hello-world/conditions.txt"]).To(ContainSubstring("TerraformPlannedWithChanges"))
+	g.Expect(files["default-hello-world/source.yaml"]).To(ContainSubstring("https://github.com/org/repo"))
+	g.Expect(files["default-hello-world/plan.txt"]).To(ContainSubstring("+ token = \"" + redactedValue + "\""))
+	g.Expect(files["default-hello-world/runner-pod.yaml"]).To(ContainSubstring("AWS_SECRET_ACCESS_KEY"))
+	g.Expect(files["default-hello-world/events.txt"]).To(ContainSubstring("apply failed with token " + redactedValue))
+	g.Expect(files["default-hello-world/events.txt"]).ToNot(ContainSubstring("not related"))
+
+	// without bundle, the manifest is exported
+	out.Reset()
+	g.Expect(c.Export(&out, "hello-world", false, "")).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring("kind: Terraform"))
+	g.Expect(out.String()).ToNot(ContainSubstring("status:\n  plan"))
+}
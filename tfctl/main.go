@@ -8,8 +8,11 @@ import (
 	"github.com/fluxcd/pkg/ssa"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 
@@ -43,6 +46,9 @@ func (c *CLI) Init(k8sConfig *rest.Config, config *viper.Viper) error {
 	cobra.CheckErr(corev1.AddToScheme(scheme))
 	cobra.CheckErr(appsv1.AddToScheme(scheme))
 	cobra.CheckErr(infrav1.AddToScheme(scheme))
+	cobra.CheckErr(apiextensionsv1.AddToScheme(scheme))
+	cobra.CheckErr(admissionregistrationv1.AddToScheme(scheme))
+	cobra.CheckErr(authorizationv1.AddToScheme(scheme))
 
 	client, err := client.NewWithWatch(k8sConfig, client.Options{
 		Scheme: scheme,
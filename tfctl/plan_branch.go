@@ -0,0 +1,142 @@
+package tfctl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// PlanBranch plans a branch of the GitRepository of the given Terraform
+// resource, in copies of the resource and of its source which the
+// controller deletes once expired, and shows the plan unless wait is zero.
+func (c *CLI) PlanBranch(out io.Writer, resource, branch string, expiry, waitFor time.Duration) error {
+	key := types.NamespacedName{
+		Name:      resource,
+		Namespace: c.namespace,
+	}
+
+	if branch == "" {
+		return fmt.Errorf("a branch to plan is required")
+	}
+	if expiry <= 0 {
+		return fmt.Errorf("the expiry must be positive, not %s", expiry)
+	}
+
+	expiresAt := time.Now().Add(expiry)
+	plan, err := planBranch(context.TODO(), c.client, key, branch, expiresAt)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, " Planning branch %s of %s/%s in %s/%s, deleted at %s\n",
+		branch, c.namespace, resource, plan.GetNamespace(), plan.GetName(), expiresAt.Format(time.RFC3339))
+
+	if waitFor == 0 {
+		return nil
+	}
+
+	var ready *metav1.Condition
+	if err := wait.PollImmediate(2*time.Second, waitFor, func() (bool, error) {
+		terraform := &infrav1.Terraform{}
+		if err := c.client.Get(context.TODO(), client.ObjectKeyFromObject(plan), terraform); err != nil {
+			return false, err
+		}
+		ready = apimeta.FindStatusCondition(terraform.Status.Conditions, meta.ReadyCondition)
+		return branchPlanOver(ready), nil
+	}); err != nil {
+		return fmt.Errorf("waiting for the plan of the branch: %w", err)
+	}
+	if ready.Status == metav1.ConditionFalse {
+		return fmt.Errorf("the plan of the branch failed: %s: %s", ready.Reason, ready.Message)
+	}
+
+	return c.ShowPlan(out, plan.GetName())
+}
+
+// branchPlanOver returns true once the plan of a branch is over, with or
+// without changes, or failed.
+func branchPlanOver(ready *metav1.Condition) bool {
+	if ready == nil {
+		return false
+	}
+	return ready.Status == metav1.ConditionFalse ||
+		ready.Reason == infrav1.PlannedWithChangesReason || ready.Reason == infrav1.PlannedNoChangesReason
+}
+
+// planBranch creates the copies of a Terraform object and of its
+// GitRepository planning a branch until it expires. The copy of the
+// Terraform object is owned by the original, and the copy of the source by
+// the copy of the Terraform object, for them to be garbage collected.
+func planBranch(ctx context.Context, kubeClient client.Client, key types.NamespacedName, branch string, expiresAt time.Time) (*infrav1.Terraform, error) {
+	original := &infrav1.Terraform{}
+	if err := kubeClient.Get(ctx, key, original); err != nil {
+		return nil, err
+	}
+	if of := original.GetLabels()[infrav1.BranchPlanOfLabel]; of != "" {
+		return nil, fmt.Errorf("%s is the plan of a branch of %s, plan the branch of %s instead", key, of, of)
+	}
+	if original.Spec.SourceRef.Kind != "GitRepository" {
+		return nil, fmt.Errorf("planning a branch is only supported with a GitRepository source, not %s", original.Spec.SourceRef.Kind)
+	}
+
+	source, err := sourceObject(original)
+	if err != nil {
+		return nil, err
+	}
+	if source.GetNamespace() != original.GetNamespace() {
+		return nil, fmt.Errorf("planning a branch is only supported with a GitRepository in the namespace of the Terraform resource, not %s", source.GetNamespace())
+	}
+	if err := kubeClient.Get(ctx, client.ObjectKeyFromObject(source), source); err != nil {
+		return nil, fmt.Errorf("unable to get the source: %w", err)
+	}
+	spec, _, err := unstructured.NestedMap(source.Object, "spec")
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the spec of the source: %w", err)
+	}
+
+	plan := original.NewBranchPlan(branch, expiresAt)
+	if err := controllerutil.SetOwnerReference(original, plan, kubeClient.Scheme()); err != nil {
+		return nil, err
+	}
+	if err := kubeClient.Create(ctx, plan); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("the branch %s of %s is planned already in %s, delete it to plan the branch again", branch, key, plan.GetName())
+		}
+		return nil, fmt.Errorf("unable to create the plan of the branch: %w", err)
+	}
+
+	branchSource := &unstructured.Unstructured{}
+	branchSource.SetGroupVersionKind(source.GroupVersionKind())
+	branchSource.SetNamespace(plan.GetNamespace())
+	branchSource.SetName(plan.GetName())
+	branchSource.SetLabels(plan.GetLabels())
+	branchSource.SetAnnotations(plan.GetAnnotations())
+	spec["ref"] = map[string]interface{}{"branch": branch}
+	delete(spec, "suspend")
+	if err := unstructured.SetNestedMap(branchSource.Object, spec, "spec"); err != nil {
+		return nil, err
+	}
+	if err := controllerutil.SetOwnerReference(plan, branchSource, kubeClient.Scheme()); err != nil {
+		return nil, err
+	}
+	if err := kubeClient.Create(ctx, branchSource); err != nil {
+		// the plan of the branch is useless without its source
+		if err := kubeClient.Delete(ctx, plan); client.IgnoreNotFound(err) != nil {
+			return nil, fmt.Errorf("unable to clean up the plan of the branch %s: %w", plan.GetName(), err)
+		}
+		return nil, fmt.Errorf("unable to create the source of the branch: %w", err)
+	}
+
+	return plan, nil
+}
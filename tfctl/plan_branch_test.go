@@ -0,0 +1,91 @@
+package tfctl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPlanBranch(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	source := &unstructured.Unstructured{}
+	source.SetAPIVersion("source.toolkit.fluxcd.io/v1")
+	source.SetKind("GitRepository")
+	source.SetNamespace("default")
+	source.SetName("helloworld")
+	g.Expect(unstructured.SetNestedMap(source.Object, map[string]interface{}{
+		"url":       "https://github.com/org/helloworld",
+		"interval":  "1m",
+		"ref":       map[string]interface{}{"tag": "v1.0.0"},
+		"secretRef": map[string]interface{}{"name": "helloworld-auth"},
+	}, "spec")).To(Succeed())
+
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "default", UID: "uid"},
+			Spec: infrav1.TerraformSpec{
+				ApprovePlan: infrav1.ApprovePlanAutoValue,
+				SourceRef:   infrav1.CrossNamespaceSourceReference{Kind: "GitRepository", Name: "helloworld"},
+			},
+		},
+		&infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "modules", Namespace: "default"},
+			Spec: infrav1.TerraformSpec{
+				SourceRef: infrav1.CrossNamespaceSourceReference{Kind: "OCIRepository", Name: "modules"},
+			},
+		},
+		source,
+	).Build()
+
+	key := client.ObjectKey{Namespace: "default", Name: "helloworld"}
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	plan, err := planBranch(ctx, kubeClient, key, "feature/x", expiresAt)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// the copy of the Terraform object only plans, and is owned by the original
+	terraform := &infrav1.Terraform{}
+	g.Expect(kubeClient.Get(ctx, client.ObjectKeyFromObject(plan), terraform)).To(Succeed())
+	g.Expect(terraform.Spec.PlanOnly).To(BeTrue())
+	g.Expect(terraform.Spec.ApprovePlan).To(BeEmpty())
+	g.Expect(terraform.Spec.SourceRef.Name).To(Equal(plan.GetName()))
+	g.Expect(terraform.OwnerReferences).To(HaveLen(1))
+	g.Expect(terraform.OwnerReferences[0].Name).To(Equal("helloworld"))
+	g.Expect(terraform.GetBranchPlanExpiry().Equal(expiresAt)).To(BeTrue())
+
+	// the copy of the source follows the branch, and is owned by the copy
+	branchSource := &unstructured.Unstructured{}
+	branchSource.SetGroupVersionKind(source.GroupVersionKind())
+	g.Expect(kubeClient.Get(ctx, client.ObjectKeyFromObject(plan), branchSource)).To(Succeed())
+	ref, _, _ := unstructured.NestedStringMap(branchSource.Object, "spec", "ref")
+	g.Expect(ref).To(Equal(map[string]string{"branch": "feature/x"}))
+	secretRef, _, _ := unstructured.NestedString(branchSource.Object, "spec", "secretRef", "name")
+	g.Expect(secretRef).To(Equal("helloworld-auth"))
+	g.Expect(branchSource.GetLabels()).To(HaveKeyWithValue(infrav1.BranchPlanOfLabel, "helloworld"))
+	g.Expect(branchSource.GetOwnerReferences()).To(HaveLen(1))
+	g.Expect(branchSource.GetOwnerReferences()[0].Name).To(Equal(plan.GetName()))
+
+	// a branch is planned once at a time
+	_, err = planBranch(ctx, kubeClient, key, "feature/x", expiresAt)
+	g.Expect(err).To(MatchError(ContainSubstring("planned already")))
+
+	// the plan of a branch cannot be planned again
+	_, err = planBranch(ctx, kubeClient, client.ObjectKeyFromObject(plan), "feature/y", expiresAt)
+	g.Expect(err).To(MatchError(ContainSubstring("is the plan of a branch")))
+
+	_, err = planBranch(ctx, kubeClient, client.ObjectKey{Namespace: "default", Name: "modules"}, "feature/x", expiresAt)
+	g.Expect(err).To(MatchError(ContainSubstring("only supported with a GitRepository source")))
+}
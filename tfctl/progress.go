@@ -0,0 +1,82 @@
+package tfctl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	progressBarWidth = 30
+	// progressPollInterval is how often the progress is read when watched.
+	progressPollInterval = 2 * time.Second
+)
+
+// Progress prints the progress of the plan or apply the terraform resource
+// is running. With watch, the progress is printed until the operation is over.
+func (c *CLI) Progress(out io.Writer, resource string, watch bool) error {
+	key := types.NamespacedName{
+		Name:      resource,
+		Namespace: c.namespace,
+	}
+
+	var seen bool
+	for {
+		terraform := &infrav1.Terraform{}
+		if err := c.client.Get(context.TODO(), key, terraform); err != nil {
+			return err
+		}
+
+		progress := terraform.Status.Progress
+		switch {
+		case progress != nil:
+			if watch {
+				// rewrite the line of the previous progress
+				fmt.Fprintf(out, "\r\033[K%s", renderProgress(progress))
+			} else {
+				fmt.Fprintln(out, renderProgress(progress))
+			}
+			seen = true
+		case seen:
+			fmt.Fprintln(out)
+			if ready := apimeta.FindStatusCondition(terraform.Status.Conditions, meta.ReadyCondition); ready != nil {
+				fmt.Fprintf(out, " %s/%s: %s\n", c.namespace, resource, ready.Message)
+			}
+			return nil
+		case !watch:
+			fmt.Fprintf(out, " Terraform resource %s/%s is not planning or applying\n", c.namespace, resource)
+		}
+
+		if !watch {
+			return nil
+		}
+		time.Sleep(progressPollInterval)
+	}
+}
+
+// renderProgress renders the progress as a bar, e.g.
+// "apply [#######-------] 12/25 48% aws_instance.web", or as a counter when
+// the total is unknown.
+func renderProgress(progress *infrav1.TerraformProgress) string {
+	var line string
+	if progress.Total > 0 {
+		filled := int(progress.Percentage) * progressBarWidth / 100
+		line = fmt.Sprintf("%s [%s%s] %d/%d %d%%", progress.Operation,
+			strings.Repeat("#", filled), strings.Repeat("-", progressBarWidth-filled),
+			progress.Completed, progress.Total, progress.Percentage)
+	} else {
+		line = fmt.Sprintf("%s %d resources", progress.Operation, progress.Completed)
+	}
+
+	if len(progress.CurrentResources) > 0 {
+		line += " " + strings.Join(progress.CurrentResources, ", ")
+	}
+	return line
+}
@@ -0,0 +1,59 @@
+package tfctl
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRenderProgress(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(renderProgress(&infrav1.TerraformProgress{
+		Operation:        "apply",
+		Total:            4,
+		Completed:        1,
+		Percentage:       25,
+		CurrentResources: []string{"aws_instance.web", "aws_s3_bucket.logs"},
+	})).To(Equal("apply [#######-----------------------] 1/4 25% aws_instance.web, aws_s3_bucket.logs"))
+
+	g.Expect(renderProgress(&infrav1.TerraformProgress{
+		Operation: "plan",
+		Completed: 12,
+	})).To(Equal("plan 12 resources"))
+}
+
+func TestProgress(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello-world", Namespace: "default"},
+		Status: infrav1.TerraformStatus{
+			Progress: &infrav1.TerraformProgress{Operation: "apply", Total: 2, Completed: 2, Percentage: 100},
+		},
+	}
+	idle := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "idle", Namespace: "default"},
+	}
+
+	c := &CLI{
+		client:    fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(terraform, idle).Build(),
+		namespace: "default",
+	}
+
+	var out bytes.Buffer
+	g.Expect(c.Progress(&out, "hello-world", false)).To(Succeed())
+	g.Expect(out.String()).To(Equal("apply [##############################] 2/2 100%\n"))
+
+	out.Reset()
+	g.Expect(c.Progress(&out, "idle", false)).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring("is not planning or applying"))
+}
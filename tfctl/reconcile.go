@@ -8,18 +8,53 @@ import (
 
 	"github.com/fluxcd/pkg/apis/meta"
 	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// Reconcile annotates the given object
-func (c *CLI) Reconcile(out io.Writer, resource string) error {
+const (
+	sourceGroup            = "source.toolkit.fluxcd.io"
+	sourceReconcileTimeout = 2 * time.Minute
+)
+
+// sourceVersions maps the supported source kinds to the API version
+// used when the source reference does not specify one.
+var sourceVersions = map[string]string{
+	"GitRepository": "v1",
+	"Bucket":        "v1beta2",
+	"OCIRepository": "v1beta2",
+}
+
+// Reconcile annotates the given object. If withSource is true, the source of
+// the object is reconciled first and the command waits for it to be handled.
+func (c *CLI) Reconcile(out io.Writer, resource string, withSource bool) error {
 	key := types.NamespacedName{
 		Name:      resource,
 		Namespace: c.namespace,
 	}
 
+	if withSource {
+		terraform := &infrav1.Terraform{}
+		if err := c.client.Get(context.TODO(), key, terraform); err != nil {
+			return err
+		}
+
+		source, err := sourceObject(terraform)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(out, " Reconcile requested for %s %s/%s\n", source.GetKind(), source.GetNamespace(), source.GetName())
+		if err := requestSourceReconciliation(context.TODO(), c.client, source); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, " %s %s/%s reconciled\n", source.GetKind(), source.GetNamespace(), source.GetName())
+	}
+
 	err := requestReconciliation(context.TODO(), c.client, key)
 	if err != nil {
 		return err
@@ -31,6 +66,12 @@ func (c *CLI) Reconcile(out io.Writer, resource string) error {
 }
 
 func requestReconciliation(ctx context.Context, kubeClient client.Client, namespacedName types.NamespacedName) error {
+	return requestReconciliationAt(ctx, kubeClient, namespacedName, time.Now().Format(time.RFC3339Nano))
+}
+
+// requestReconciliationAt annotates the given object with the time of the
+// request, which the controller reports as handled in the status.
+func requestReconciliationAt(ctx context.Context, kubeClient client.Client, namespacedName types.NamespacedName, requestedAt string) error {
 	return retry.RetryOnConflict(retry.DefaultBackoff, func() (err error) {
 		terraform := &infrav1.Terraform{}
 		if err := kubeClient.Get(ctx, namespacedName, terraform); err != nil {
@@ -39,12 +80,78 @@ func requestReconciliation(ctx context.Context, kubeClient client.Client, namesp
 		patch := client.MergeFrom(terraform.DeepCopy())
 		if ann := terraform.GetAnnotations(); ann == nil {
 			terraform.SetAnnotations(map[string]string{
-				meta.ReconcileRequestAnnotation: time.Now().Format(time.RFC3339Nano),
+				meta.ReconcileRequestAnnotation: requestedAt,
 			})
 		} else {
-			ann[meta.ReconcileRequestAnnotation] = time.Now().Format(time.RFC3339Nano)
+			ann[meta.ReconcileRequestAnnotation] = requestedAt
 			terraform.SetAnnotations(ann)
 		}
 		return kubeClient.Patch(ctx, terraform, patch)
 	})
 }
+
+// sourceObject returns an empty unstructured object pointing at the source of the given Terraform object.
+func sourceObject(terraform *infrav1.Terraform) (*unstructured.Unstructured, error) {
+	sourceRef := terraform.Spec.SourceRef
+
+	gv := schema.GroupVersion{Group: sourceGroup, Version: sourceVersions[sourceRef.Kind]}
+	if sourceRef.APIVersion != "" {
+		parsed, err := schema.ParseGroupVersion(sourceRef.APIVersion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid source API version %q: %w", sourceRef.APIVersion, err)
+		}
+		gv = parsed
+	}
+	if gv.Version == "" {
+		return nil, fmt.Errorf("source kind %q is not supported", sourceRef.Kind)
+	}
+
+	namespace := sourceRef.Namespace
+	if namespace == "" {
+		namespace = terraform.GetNamespace()
+	}
+
+	source := &unstructured.Unstructured{}
+	source.SetGroupVersionKind(gv.WithKind(sourceRef.Kind))
+	source.SetNamespace(namespace)
+	source.SetName(sourceRef.Name)
+	return source, nil
+}
+
+// requestSourceReconciliation annotates the given source object and waits
+// until the source controller reports the request as handled.
+func requestSourceReconciliation(ctx context.Context, kubeClient client.Client, source *unstructured.Unstructured) error {
+	key := client.ObjectKeyFromObject(source)
+	requestedAt := time.Now().Format(time.RFC3339Nano)
+
+	if err := retry.RetryOnConflict(retry.DefaultBackoff, func() (err error) {
+		obj := source.DeepCopy()
+		if err := kubeClient.Get(ctx, key, obj); err != nil {
+			return err
+		}
+		patch := client.MergeFrom(obj.DeepCopy())
+		ann := obj.GetAnnotations()
+		if ann == nil {
+			ann = map[string]string{}
+		}
+		ann[meta.ReconcileRequestAnnotation] = requestedAt
+		obj.SetAnnotations(ann)
+		return kubeClient.Patch(ctx, obj, patch)
+	}); err != nil {
+		return err
+	}
+
+	return wait.PollImmediate(1*time.Second, sourceReconcileTimeout, func() (bool, error) {
+		obj := source.DeepCopy()
+		if err := kubeClient.Get(ctx, key, obj); err != nil {
+			return false, err
+		}
+
+		handledAt, _, err := unstructured.NestedString(obj.Object, "status", "lastHandledReconcileAt")
+		if err != nil {
+			return false, err
+		}
+
+		return handledAt == requestedAt, nil
+	})
+}
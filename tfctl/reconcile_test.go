@@ -0,0 +1,56 @@
+package tfctl
+
+import (
+	"testing"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestSourceObject(t *testing.T) {
+	g := NewWithT(t)
+
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "helloworld",
+			Namespace: "default",
+		},
+		Spec: infrav1.TerraformSpec{
+			SourceRef: infrav1.CrossNamespaceSourceReference{
+				Kind: "GitRepository",
+				Name: "helloworld",
+			},
+		},
+	}
+
+	source, err := sourceObject(terraform)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(source.GroupVersionKind()).To(Equal(schema.GroupVersionKind{
+		Group:   "source.toolkit.fluxcd.io",
+		Version: "v1",
+		Kind:    "GitRepository",
+	}))
+	g.Expect(source.GetNamespace()).To(Equal("default"))
+	g.Expect(source.GetName()).To(Equal("helloworld"))
+
+	terraform.Spec.SourceRef = infrav1.CrossNamespaceSourceReference{
+		APIVersion: "source.toolkit.fluxcd.io/v1beta2",
+		Kind:       "OCIRepository",
+		Name:       "modules",
+		Namespace:  "flux-system",
+	}
+	source, err = sourceObject(terraform)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(source.GroupVersionKind().Version).To(Equal("v1beta2"))
+	g.Expect(source.GetNamespace()).To(Equal("flux-system"))
+
+	terraform.Spec.SourceRef = infrav1.CrossNamespaceSourceReference{
+		Kind: "HelmChart",
+		Name: "chart",
+	}
+	_, err = sourceObject(terraform)
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,106 @@
+package tfctl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// rollbackPlanTimeout is how long to wait for the rollback plan, which
+// includes the source-controller fetching the revision.
+const rollbackPlanTimeout = 5 * time.Minute
+
+// Rollback pins the given Terraform resource to a previous revision of its
+// GitRepository, and shows the plan to roll back to it once pending
+// approval.
+func (c *CLI) Rollback(out io.Writer, resource string, revision string) error {
+	key := types.NamespacedName{
+		Name:      resource,
+		Namespace: c.namespace,
+	}
+
+	revision = rollbackRevision(revision)
+	if revision == "" {
+		return fmt.Errorf("a revision to roll back to is required")
+	}
+
+	lastApplied, err := rollback(context.TODO(), c.client, key, revision)
+	if err != nil {
+		return err
+	}
+	if lastApplied != "" {
+		fmt.Fprintf(out, " Rolling back %s/%s from %s to %s\n", c.namespace, resource, lastApplied, revision)
+	} else {
+		fmt.Fprintf(out, " Rolling back %s/%s to %s\n", c.namespace, resource, revision)
+	}
+
+	if err := replan(context.TODO(), c.client, key); err != nil {
+		return err
+	}
+	if err := requestReconciliation(context.TODO(), c.client, key); err != nil {
+		return err
+	}
+
+	var pending string
+	if err := wait.PollImmediate(2*time.Second, rollbackPlanTimeout, func() (bool, error) {
+		terraform := &infrav1.Terraform{}
+		if err := c.client.Get(context.TODO(), key, terraform); err != nil {
+			return false, err
+		}
+		pending = terraform.Status.Plan.Pending
+		return pending != "", nil
+	}); err != nil {
+		return fmt.Errorf("waiting for the rollback plan: %w", err)
+	}
+
+	if err := c.ShowPlan(out, resource); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\n Set .spec.approvePlan to %s to apply the rollback.\n", pending)
+	fmt.Fprintln(out, " Remove .spec.sourceRevisionOverride to follow the source again.")
+
+	return nil
+}
+
+// rollbackRevision accepts a revision as reported in the status, e.g.
+// main@sha1:<sha>, and returns the commit SHA of it.
+func rollbackRevision(revision string) string {
+	if _, sha, ok := strings.Cut(revision, "@sha1:"); ok {
+		return sha
+	}
+	return strings.TrimPrefix(revision, "sha1:")
+}
+
+// rollback sets the source revision override of the Terraform object, and
+// turns off auto approve for the rollback plan to be reviewed. It returns
+// the last applied revision.
+func rollback(ctx context.Context, kubeClient client.Client, namespacedName types.NamespacedName, revision string) (string, error) {
+	var lastApplied string
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() (err error) {
+		terraform := &infrav1.Terraform{}
+		if err := kubeClient.Get(ctx, namespacedName, terraform); err != nil {
+			return err
+		}
+		if terraform.Spec.SourceRef.Kind != "GitRepository" {
+			return fmt.Errorf("rollback is only supported with a GitRepository source, not %s", terraform.Spec.SourceRef.Kind)
+		}
+		lastApplied = terraform.Status.LastAppliedRevision
+
+		patch := client.MergeFrom(terraform.DeepCopy())
+		terraform.Spec.SourceRevisionOverride = revision
+		if terraform.Spec.ApprovePlan == infrav1.ApprovePlanAutoValue {
+			terraform.Spec.ApprovePlan = ""
+		}
+		return kubeClient.Patch(ctx, terraform, patch)
+	})
+	return lastApplied, err
+}
@@ -0,0 +1,59 @@
+package tfctl
+
+import (
+	"context"
+	"testing"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRollbackRevision(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(rollbackRevision("main@sha1:b8e362c206e3d0cbb7ed22ced771a0056455a2fb")).To(Equal("b8e362c206e3d0cbb7ed22ced771a0056455a2fb"))
+	g.Expect(rollbackRevision("sha1:b8e362c206e3d0cbb7ed22ced771a0056455a2fb")).To(Equal("b8e362c206e3d0cbb7ed22ced771a0056455a2fb"))
+	g.Expect(rollbackRevision("v1.2.0")).To(Equal("v1.2.0"))
+}
+
+func TestRollback(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "default"},
+			Spec: infrav1.TerraformSpec{
+				ApprovePlan: infrav1.ApprovePlanAutoValue,
+				SourceRef:   infrav1.CrossNamespaceSourceReference{Kind: "GitRepository", Name: "helloworld"},
+			},
+			Status: infrav1.TerraformStatus{LastAppliedRevision: "main@sha1:0000000000000000000000000000000000000000"},
+		},
+		&infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "modules", Namespace: "default"},
+			Spec: infrav1.TerraformSpec{
+				SourceRef: infrav1.CrossNamespaceSourceReference{Kind: "OCIRepository", Name: "modules"},
+			},
+		},
+	).Build()
+
+	key := client.ObjectKey{Namespace: "default", Name: "helloworld"}
+	lastApplied, err := rollback(context.TODO(), kubeClient, key, "v1.2.0")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(lastApplied).To(Equal("main@sha1:0000000000000000000000000000000000000000"))
+
+	terraform := &infrav1.Terraform{}
+	g.Expect(kubeClient.Get(context.TODO(), key, terraform)).To(Succeed())
+	g.Expect(terraform.Spec.SourceRevisionOverride).To(Equal("v1.2.0"))
+	g.Expect(terraform.Spec.ApprovePlan).To(BeEmpty())
+
+	_, err = rollback(context.TODO(), kubeClient, client.ObjectKey{Namespace: "default", Name: "modules"}, "v1.2.0")
+	g.Expect(err).To(MatchError(ContainSubstring("only supported with a GitRepository source")))
+}
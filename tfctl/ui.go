@@ -0,0 +1,543 @@
+package tfctl
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	"github.com/hako/durafmt"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+	"golang.org/x/term"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// uiRefreshInterval is how often the dashboard lists the Terraform resources
+// again.
+const uiRefreshInterval = 2 * time.Second
+
+type uiView int
+
+const (
+	uiListView uiView = iota
+	uiPlanView
+	uiConfirmView
+)
+
+// uiAction is an action on a Terraform resource which waits for the
+// confirmation of the operator.
+type uiAction struct {
+	prompt string
+	run    func(ctx context.Context) (string, error)
+}
+
+// dashboard is the state of tfctl ui. It is kept apart from the terminal,
+// which only feeds it keys and prints what it renders.
+type dashboard struct {
+	cli           *CLI
+	allNamespaces bool
+	now           func() time.Time
+
+	terraforms []infrav1.Terraform
+	selected   int
+	offset     int
+	refreshed  time.Time
+	message    string
+
+	view       uiView
+	action     *uiAction
+	plan       []string
+	planTitle  string
+	planOffset int
+}
+
+// UI runs an interactive dashboard of the Terraform resources in the
+// terminal, from which their plans can be viewed, approved or replanned, and
+// their reconciliation suspended or resumed.
+func (c *CLI) UI(in *os.File, out io.Writer, allNamespaces bool) error {
+	fd := int(in.Fd())
+	if !term.IsTerminal(fd) {
+		return fmt.Errorf("tfctl ui needs a terminal")
+	}
+
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer term.Restore(fd, state)
+
+	// switch to the alternate screen and hide the cursor
+	fmt.Fprint(out, "\x1b[?1049h\x1b[?25l")
+	defer fmt.Fprint(out, "\x1b[?25h\x1b[?1049l")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	keys := make(chan string)
+	go readKeys(in, keys)
+
+	ticker := time.NewTicker(uiRefreshInterval)
+	defer ticker.Stop()
+
+	d := newDashboard(c, allNamespaces)
+	d.refresh(ctx)
+	for {
+		width, height, err := term.GetSize(fd)
+		if err != nil {
+			width, height = 120, 40
+		}
+		fmt.Fprint(out, "\x1b[H\x1b[2J"+d.render(width, height))
+
+		select {
+		case key, ok := <-keys:
+			if !ok || d.handleKey(ctx, key) {
+				return nil
+			}
+		case <-ticker.C:
+			d.refresh(ctx)
+		}
+	}
+}
+
+// readKeys reads the keys pressed in the terminal, naming the special ones,
+// until the input is closed.
+func readKeys(in io.Reader, keys chan<- string) {
+	defer close(keys)
+	buf := make([]byte, 16)
+	for {
+		n, err := in.Read(buf)
+		if err != nil {
+			return
+		}
+		keys <- keyName(buf[:n])
+	}
+}
+
+func keyName(input []byte) string {
+	switch string(input) {
+	case "\x1b[A", "\x1bOA":
+		return "up"
+	case "\x1b[B", "\x1bOB":
+		return "down"
+	case "\x1b[5~":
+		return "pgup"
+	case "\x1b[6~":
+		return "pgdown"
+	case "\x1b":
+		return "esc"
+	case "\r", "\n":
+		return "enter"
+	case "\x03":
+		return "ctrl+c"
+	}
+	return string(input)
+}
+
+func newDashboard(c *CLI, allNamespaces bool) *dashboard {
+	return &dashboard{
+		cli:           c,
+		allNamespaces: allNamespaces,
+		now:           time.Now,
+	}
+}
+
+// refresh lists the Terraform resources again, keeping the selection on the
+// same resource.
+func (d *dashboard) refresh(ctx context.Context) {
+	var listOptions []client.ListOption
+	if !d.allNamespaces {
+		listOptions = append(listOptions, client.InNamespace(d.cli.namespace))
+	}
+
+	list := &infrav1.TerraformList{}
+	if err := d.cli.client.List(ctx, list, listOptions...); err != nil {
+		d.message = fmt.Sprintf("unable to list the Terraform resources: %s", err)
+		return
+	}
+
+	var selected types.NamespacedName
+	if terraform := d.current(); terraform != nil {
+		selected = client.ObjectKeyFromObject(terraform)
+	}
+
+	d.terraforms = list.Items
+	sort.Slice(d.terraforms, func(i, j int) bool {
+		a, b := &d.terraforms[i], &d.terraforms[j]
+		return a.Namespace < b.Namespace || (a.Namespace == b.Namespace && a.Name < b.Name)
+	})
+	d.refreshed = d.now()
+
+	for i := range d.terraforms {
+		if client.ObjectKeyFromObject(&d.terraforms[i]) == selected {
+			d.selected = i
+			return
+		}
+	}
+	if d.selected >= len(d.terraforms) {
+		d.selected = len(d.terraforms) - 1
+	}
+	if d.selected < 0 {
+		d.selected = 0
+	}
+}
+
+func (d *dashboard) current() *infrav1.Terraform {
+	if d.selected < 0 || d.selected >= len(d.terraforms) {
+		return nil
+	}
+	return &d.terraforms[d.selected]
+}
+
+// handleKey updates the dashboard for a key, and returns true to quit.
+func (d *dashboard) handleKey(ctx context.Context, key string) bool {
+	if key == "ctrl+c" {
+		return true
+	}
+
+	switch d.view {
+	case uiConfirmView:
+		if key == "y" || key == "Y" {
+			message, err := d.action.run(ctx)
+			if err != nil {
+				message = err.Error()
+			}
+			d.message = message
+			d.refresh(ctx)
+		} else {
+			d.message = "Cancelled"
+		}
+		d.action = nil
+		d.view = uiListView
+
+	case uiPlanView:
+		switch key {
+		case "up", "k":
+			d.planOffset--
+		case "down", "j":
+			d.planOffset++
+		case "pgup":
+			d.planOffset -= 20
+		case "pgdown", " ":
+			d.planOffset += 20
+		case "q", "esc":
+			d.view = uiListView
+		}
+		if d.planOffset > len(d.plan)-1 {
+			d.planOffset = len(d.plan) - 1
+		}
+		if d.planOffset < 0 {
+			d.planOffset = 0
+		}
+
+	default:
+		switch key {
+		case "q", "esc":
+			return true
+		case "up", "k":
+			if d.selected > 0 {
+				d.selected--
+			}
+		case "down", "j":
+			if d.selected < len(d.terraforms)-1 {
+				d.selected++
+			}
+		case "enter", "p":
+			d.showPlan()
+		case "a":
+			d.confirmApprove()
+		case "r":
+			d.confirmReplan()
+		case "s":
+			d.confirmSuspend()
+		case "c":
+			if terraform := d.current(); terraform != nil {
+				key := client.ObjectKeyFromObject(terraform)
+				if err := requestReconciliation(ctx, d.cli.client, key); err != nil {
+					d.message = err.Error()
+				} else {
+					d.message = fmt.Sprintf("Reconciliation requested for %s", key)
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (d *dashboard) showPlan() {
+	terraform := d.current()
+	if terraform == nil {
+		return
+	}
+
+	cli := *d.cli
+	cli.namespace = terraform.Namespace
+	var out bytes.Buffer
+	if err := cli.ShowPlan(&out, terraform.Name); err != nil {
+		d.message = err.Error()
+		return
+	}
+
+	d.plan = strings.Split(strings.TrimRight(strings.ReplaceAll(out.String(), "\r\n", "\n"), "\n"), "\n")
+	d.planTitle = fmt.Sprintf("Plan %s of %s/%s", terraform.Status.Plan.Pending, terraform.Namespace, terraform.Name)
+	d.planOffset = 0
+	d.view = uiPlanView
+}
+
+func (d *dashboard) confirmApprove() {
+	terraform := d.current()
+	if terraform == nil {
+		return
+	}
+	if terraform.Status.Plan.Pending == "" {
+		d.message = fmt.Sprintf("There is no plan pending for %s/%s", terraform.Namespace, terraform.Name)
+		return
+	}
+
+	key := client.ObjectKeyFromObject(terraform)
+	planID := terraform.Status.Plan.Pending
+	d.confirm(fmt.Sprintf("Approve plan %s of %s?", planID, key), func(ctx context.Context) (string, error) {
+		if err := approvePlanInCluster(ctx, d.cli.client, key, planID); err != nil {
+			return "", err
+		}
+		if err := requestReconciliation(ctx, d.cli.client, key); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Plan %s of %s approved", planID, key), nil
+	})
+}
+
+func (d *dashboard) confirmReplan() {
+	terraform := d.current()
+	if terraform == nil {
+		return
+	}
+
+	key := client.ObjectKeyFromObject(terraform)
+	d.confirm(fmt.Sprintf("Replan %s?", key), func(ctx context.Context) (string, error) {
+		if err := replan(ctx, d.cli.client, key); err != nil {
+			return "", err
+		}
+		if err := requestReconciliation(ctx, d.cli.client, key); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Replan requested for %s", key), nil
+	})
+}
+
+func (d *dashboard) confirmSuspend() {
+	terraform := d.current()
+	if terraform == nil {
+		return
+	}
+
+	key := client.ObjectKeyFromObject(terraform)
+	if terraform.Spec.Suspend {
+		d.confirm(fmt.Sprintf("Resume the reconciliation of %s?", key), func(ctx context.Context) (string, error) {
+			if err := resumeReconciliation(ctx, d.cli.client, key); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Reconciliation resumed for %s", key), nil
+		})
+		return
+	}
+	d.confirm(fmt.Sprintf("Suspend the reconciliation of %s?", key), func(ctx context.Context) (string, error) {
+		if err := suspendReconciliation(ctx, d.cli.client, key); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Reconciliation suspended for %s", key), nil
+	})
+}
+
+func (d *dashboard) confirm(prompt string, run func(ctx context.Context) (string, error)) {
+	d.action = &uiAction{prompt: prompt, run: run}
+	d.view = uiConfirmView
+}
+
+// approvePlanInCluster sets spec.approvePlan to the plan, unless another plan
+// is pending by now. Unlike tfctl approve, it patches the object in the
+// cluster rather than its manifest.
+func approvePlanInCluster(ctx context.Context, kubeClient client.Client, namespacedName types.NamespacedName, planID string) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() (err error) {
+		terraform := &infrav1.Terraform{}
+		if err := kubeClient.Get(ctx, namespacedName, terraform); err != nil {
+			return err
+		}
+		if terraform.Status.Plan.Pending != planID {
+			return fmt.Errorf("plan %s of %s is no longer pending", planID, namespacedName)
+		}
+		patch := client.MergeFrom(terraform.DeepCopy())
+		terraform.Spec.ApprovePlan = planID
+		return kubeClient.Patch(ctx, terraform, patch)
+	})
+}
+
+// render returns the screen of the dashboard for a terminal of the given
+// size. The lines end with \r\n, the terminal being in raw mode.
+func (d *dashboard) render(width, height int) string {
+	var lines []string
+	switch d.view {
+	case uiPlanView:
+		lines = d.renderPlan(height)
+	default:
+		lines = d.renderList(height)
+	}
+
+	for i, line := range lines {
+		lines[i] = truncate(line, width)
+	}
+	return strings.Join(lines, "\r\n")
+}
+
+func (d *dashboard) renderList(height int) []string {
+	namespace := d.cli.namespace
+	if d.allNamespaces {
+		namespace = "all"
+	}
+	var pending, drifted int
+	for i := range d.terraforms {
+		if d.terraforms[i].Status.Plan.Pending != "" {
+			pending++
+		}
+		if hasDrift(&d.terraforms[i]) {
+			drifted++
+		}
+	}
+
+	lines := []string{
+		fmt.Sprintf("tfctl ui - namespace: %s - %d Terraform resources, %d pending approval, %d drifted - refreshed %s",
+			namespace, len(d.terraforms), pending, drifted, d.refreshed.Format("15:04:05")),
+		"",
+		fmt.Sprintf("  %-20s %-30s %-7s %-24s %-6s %-10s %-6s %s",
+			"NAMESPACE", "NAME", "READY", "PENDING PLAN", "DRIFT", "SUSPENDED", "AGE", "STATUS"),
+	}
+
+	// keep the selection in the rows between the header and the footer
+	rows := height - len(lines) - 3
+	if rows < 1 {
+		rows = 1
+	}
+	if d.selected < d.offset {
+		d.offset = d.selected
+	}
+	if d.selected >= d.offset+rows {
+		d.offset = d.selected - rows + 1
+	}
+
+	for i := d.offset; i < len(d.terraforms) && i < d.offset+rows; i++ {
+		line := "  " + d.renderRow(&d.terraforms[i])
+		if i == d.selected {
+			line = "\x1b[7m>" + line[1:] + "\x1b[0m"
+		}
+		lines = append(lines, line)
+	}
+	if len(d.terraforms) == 0 {
+		lines = append(lines, "  No Terraform resources found")
+	}
+
+	for len(lines) < height-2 {
+		lines = append(lines, "")
+	}
+	if d.view == uiConfirmView {
+		lines = append(lines, d.action.prompt+" (y/n)")
+	} else {
+		lines = append(lines, d.message)
+	}
+	lines = append(lines, "up/down move  enter/p plan  a approve  r replan  s suspend/resume  c reconcile  q quit")
+	return lines
+}
+
+func (d *dashboard) renderRow(terraform *infrav1.Terraform) string {
+	ready := "Unknown"
+	status := ""
+	if condition := apimeta.FindStatusCondition(terraform.Status.Conditions, meta.ReadyCondition); condition != nil {
+		ready = string(condition.Status)
+		status = shorten(condition.Message)
+	}
+	if terraform.Status.Progress != nil {
+		status = renderProgress(terraform.Status.Progress)
+	}
+
+	pending := "-"
+	if terraform.Status.Plan.Pending != "" {
+		pending = terraform.Status.Plan.Pending
+	}
+	drift := "-"
+	if hasDrift(terraform) {
+		drift = "yes"
+	}
+	suspended := "-"
+	if terraform.Spec.Suspend {
+		suspended = "yes"
+	}
+	age := "-"
+	if !terraform.CreationTimestamp.IsZero() {
+		age = durafmt.Parse(d.now().Sub(terraform.CreationTimestamp.Time)).LimitFirstN(1).String()
+	}
+
+	return fmt.Sprintf("%-20s %-30s %-7s %-24s %-6s %-10s %-6s %s",
+		truncate(terraform.Namespace, 20), truncate(terraform.Name, 30), ready,
+		truncate(pending, 24), drift, suspended, age, status)
+}
+
+// hasDrift is true when a drift was detected after the last apply of the
+// resource, or when the resource is not ready because of a drift.
+func hasDrift(terraform *infrav1.Terraform) bool {
+	if terraform.HasDrift() {
+		return true
+	}
+	condition := apimeta.FindStatusCondition(terraform.Status.Conditions, meta.ReadyCondition)
+	return condition != nil && condition.Reason == infrav1.DriftDetectedReason
+}
+
+func (d *dashboard) renderPlan(height int) []string {
+	lines := []string{d.planTitle, ""}
+
+	rows := height - len(lines) - 1
+	if rows < 1 {
+		rows = 1
+	}
+	end := d.planOffset + rows
+	if end > len(d.plan) {
+		end = len(d.plan)
+	}
+	lines = append(lines, d.plan[d.planOffset:end]...)
+
+	for len(lines) < height-1 {
+		lines = append(lines, "")
+	}
+	lines = append(lines, fmt.Sprintf("up/down scroll  pgup/pgdown page  q/esc back - lines %d-%d of %d",
+		d.planOffset+1, end, len(d.plan)))
+	return lines
+}
+
+// truncate shortens a line to a width, skipping the escape sequences of the
+// selection.
+func truncate(line string, width int) string {
+	visible := 0
+	escape := false
+	for i, r := range line {
+		switch {
+		case escape:
+			escape = r == '[' || r < '@' || r > '~'
+		case r == '\x1b':
+			escape = true
+		default:
+			if visible == width {
+				if strings.HasSuffix(line, "\x1b[0m") {
+					return line[:i] + "\x1b[0m"
+				}
+				return line[:i]
+			}
+			visible++
+		}
+	}
+	return line
+}
@@ -0,0 +1,121 @@
+package tfctl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha2"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDashboard(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+	pending := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "approve-me", Namespace: "default"},
+		Spec:       infrav1.TerraformSpec{StoreReadablePlan: "human"},
+		Status: infrav1.TerraformStatus{
+			Plan: infrav1.PlanStatus{Pending: "plan-main-abc"},
+			Conditions: []metav1.Condition{{
+				Type:    meta.ReadyCondition,
+				Status:  metav1.ConditionUnknown,
+				Reason:  infrav1.PlannedWithChangesReason,
+				Message: "Plan generated",
+			}},
+		},
+	}
+	drifted := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "drifted", Namespace: "default"},
+		Status: infrav1.TerraformStatus{
+			Conditions: []metav1.Condition{{
+				Type:    meta.ReadyCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  infrav1.DriftDetectedReason,
+				Message: "Drift detected",
+			}},
+		},
+	}
+	other := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "elsewhere", Namespace: "other"},
+	}
+	plan := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "tfplan-default-approve-me", Namespace: "default"},
+		Data:       map[string]string{"tfplan": "Plan: 1 to add, 0 to change, 0 to destroy."},
+	}
+
+	c := &CLI{
+		client:    fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(pending, drifted, other, plan).Build(),
+		namespace: "default",
+	}
+	d := newDashboard(c, false)
+	d.now = func() time.Time { return time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC) }
+
+	d.refresh(ctx)
+	g.Expect(d.terraforms).To(HaveLen(2))
+	screen := d.render(200, 20)
+	g.Expect(screen).To(ContainSubstring("2 Terraform resources, 1 pending approval, 1 drifted"))
+	g.Expect(screen).To(ContainSubstring("plan-main-abc"))
+	g.Expect(screen).ToNot(ContainSubstring("elsewhere"))
+
+	// the plan of the selected resource
+	g.Expect(d.handleKey(ctx, "enter")).To(BeFalse())
+	g.Expect(d.view).To(Equal(uiPlanView))
+	g.Expect(d.render(200, 20)).To(ContainSubstring("Plan: 1 to add"))
+	g.Expect(d.handleKey(ctx, "esc")).To(BeFalse())
+	g.Expect(d.view).To(Equal(uiListView))
+
+	// approving needs a confirmation
+	g.Expect(d.handleKey(ctx, "a")).To(BeFalse())
+	g.Expect(d.render(200, 20)).To(ContainSubstring("Approve plan plan-main-abc of default/approve-me? (y/n)"))
+	d.handleKey(ctx, "n")
+	g.Expect(d.message).To(Equal("Cancelled"))
+
+	d.handleKey(ctx, "a")
+	d.handleKey(ctx, "y")
+	approved := &infrav1.Terraform{}
+	g.Expect(c.client.Get(ctx, types.NamespacedName{Name: "approve-me", Namespace: "default"}, approved)).To(Succeed())
+	g.Expect(approved.Spec.ApprovePlan).To(Equal("plan-main-abc"))
+	g.Expect(approved.Annotations).To(HaveKey(meta.ReconcileRequestAnnotation))
+
+	// suspending and resuming the second resource
+	d.handleKey(ctx, "down")
+	g.Expect(d.current().Name).To(Equal("drifted"))
+	d.handleKey(ctx, "a")
+	g.Expect(d.message).To(ContainSubstring("There is no plan pending"))
+
+	d.handleKey(ctx, "s")
+	d.handleKey(ctx, "y")
+	g.Expect(d.current().Name).To(Equal("drifted"))
+	g.Expect(d.current().Spec.Suspend).To(BeTrue())
+	d.handleKey(ctx, "s")
+	g.Expect(d.action.prompt).To(HavePrefix("Resume"))
+	d.handleKey(ctx, "y")
+	g.Expect(d.current().Spec.Suspend).To(BeFalse())
+
+	g.Expect(d.handleKey(ctx, "q")).To(BeTrue())
+
+	all := newDashboard(c, true)
+	all.refresh(ctx)
+	g.Expect(all.terraforms).To(HaveLen(3))
+}
+
+func TestTruncate(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(truncate("hello world", 5)).To(Equal("hello"))
+	g.Expect(truncate("hello", 10)).To(Equal("hello"))
+	g.Expect(truncate("\x1b[7mhello world\x1b[0m", 5)).To(Equal("\x1b[7mhello\x1b[0m"))
+}